@@ -0,0 +1,308 @@
+// Package cdc implements change data capture: it tails the transactions
+// committed on a store, and its raw writes, and streams them to the
+// subscribers of the key ranges they touch.
+package cdc
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap/errors"
+)
+
+// ErrSlowSubscriber closes a subscription whose events were not consumed in
+// time, so a slow subscriber never blocks the writes.
+var ErrSlowSubscriber = errors.New("cdc: subscriber is too slow")
+
+// Hub dispatches the changes written to the storage to the subscriptions.
+type Hub struct {
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+	// maxTs is the largest commit ts observed, in any range, or timestamp
+	// passed to Advance.
+	maxTs uint64
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscription receives the changes of the key range [startKey, endKey), an
+// empty endKey means the range is unbounded.
+type Subscription struct {
+	startKey, endKey []byte
+	events           chan *kvrpcpb.ChangeDataEvent
+	// locks holds the start ts of the locks in the range, the resolved ts
+	// can't pass any of them.
+	locks    map[string]uint64
+	resolved uint64
+	err      error
+}
+
+// Events returns the channel of the events, closed when the subscription is.
+func (s *Subscription) Events() <-chan *kvrpcpb.ChangeDataEvent {
+	return s.events
+}
+
+// Err returns why the subscription was closed by the hub, if it was.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+func (s *Subscription) contains(key []byte) bool {
+	return bytes.Compare(key, s.startKey) >= 0 && (len(s.endKey) == 0 || bytes.Compare(key, s.endKey) < 0)
+}
+
+// Subscribe starts capturing the changes of [startKey, endKey). The locks
+// already in the range, which hold the resolved ts back until they are
+// resolved, are read from st with ctx. bufSize is the number of events
+// buffered before the subscription is considered too slow.
+func (h *Hub) Subscribe(ctx *kvrpcpb.Context, st storage.Storage, startKey, endKey []byte, bufSize int) (*Subscription, error) {
+	sub := &Subscription{
+		startKey: startKey,
+		endKey:   endKey,
+		events:   make(chan *kvrpcpb.ChangeDataEvent, bufSize),
+		locks:    make(map[string]uint64),
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// Read under the lock so no change is missed between the read and the
+	// registration: a write the reader doesn't see is only dispatched
+	// afterwards.
+	reader, err := st.Reader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	it := reader.IterCF(engine_util.CfLock)
+	defer it.Close()
+	for it.Seek(startKey); it.Valid(); it.Next() {
+		item := it.Item()
+		if !sub.contains(item.Key()) {
+			break
+		}
+		val, err := item.Value()
+		if err != nil {
+			return nil, err
+		}
+		lock, err := mvcc.ParseLock(val)
+		if err != nil {
+			return nil, err
+		}
+		sub.locks[string(item.KeyCopy(nil))] = lock.Ts
+	}
+	h.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Unsubscribe stops the subscription and closes its events channel.
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closeLocked(sub, nil)
+}
+
+func (h *Hub) closeLocked(sub *Subscription, err error) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	sub.err = err
+	close(sub.events)
+}
+
+// Observe dispatches a batch written to st with ctx. The values of the
+// committed puts, written by the prewrite, are read back from st. The caller
+// orders the writes of a key with their observation, e.g. with the latches of
+// the transactional commands, so the subscribers see them in the order of the
+// storage.
+func (h *Hub) Observe(ctx *kvrpcpb.Context, batch []storage.Modify, st storage.Storage) error {
+	h.mu.Lock()
+	idle := len(h.subs) == 0
+	h.mu.Unlock()
+	if idle {
+		return nil
+	}
+
+	// Reading the values may take a raft round trip, it is done before taking
+	// the lock so it doesn't hold back the writes of the other keys.
+	commits, err := commitRows(ctx, batch, st)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	rows := make(map[*Subscription][]*kvrpcpb.ChangeDataRow)
+	for i, m := range batch {
+		switch data := m.Data.(type) {
+		case storage.Put:
+			switch data.Cf {
+			case engine_util.CfLock:
+				lock, err := mvcc.ParseLock(data.Value)
+				if err != nil {
+					return err
+				}
+				h.forEachSub(data.Key, func(sub *Subscription) { sub.locks[string(data.Key)] = lock.Ts })
+			case engine_util.CfWrite:
+				row := commits[i]
+				if row == nil {
+					continue
+				}
+				if row.CommitTs > h.maxTs {
+					h.maxTs = row.CommitTs
+				}
+				h.forEachSub(row.Key, func(sub *Subscription) { rows[sub] = append(rows[sub], row) })
+			}
+		case storage.Delete:
+			if data.Cf == engine_util.CfLock {
+				h.forEachSub(data.Key, func(sub *Subscription) { delete(sub.locks, string(data.Key)) })
+			}
+		}
+	}
+	h.dispatchLocked(rows)
+	return nil
+}
+
+// ObserveRaw dispatches a batch written by the raw API. Raw writes have no
+// timestamps, their rows have a zero start and commit ts and leave the
+// resolved ts alone. A row has no column family, only the writes of the
+// default one are dispatched. As for Observe, the caller orders the writes of
+// a key with their observation.
+func (h *Hub) ObserveRaw(batch []storage.Modify) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.subs) == 0 {
+		return
+	}
+
+	rows := make(map[*Subscription][]*kvrpcpb.ChangeDataRow)
+	for _, m := range batch {
+		if m.Cf() != engine_util.CfDefault {
+			continue
+		}
+		row := &kvrpcpb.ChangeDataRow{Key: m.Key(), Value: m.Value()}
+		if _, ok := m.Data.(storage.Delete); ok {
+			row.Op = kvrpcpb.Op_Del
+		}
+		h.forEachSub(row.Key, func(sub *Subscription) { rows[sub] = append(rows[sub], row) })
+	}
+	h.dispatchLocked(rows)
+}
+
+// Advance lets the resolved ts of the subscriptions move up to ts, a
+// timestamp just taken from the timestamp oracle, even if nothing commits.
+func (h *Hub) Advance(ts uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if ts > h.maxTs {
+		h.maxTs = ts
+	}
+	h.dispatchLocked(nil)
+}
+
+// dispatchLocked sends each subscription its rows, with its resolved ts if
+// it advanced.
+func (h *Hub) dispatchLocked(rows map[*Subscription][]*kvrpcpb.ChangeDataRow) {
+	for sub := range h.subs {
+		event := &kvrpcpb.ChangeDataEvent{Rows: rows[sub]}
+		if resolved := h.resolvedTs(sub); resolved > sub.resolved {
+			sub.resolved = resolved
+			event.ResolvedTs = resolved
+		}
+		if len(event.Rows) == 0 && event.ResolvedTs == 0 {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			h.closeLocked(sub, ErrSlowSubscriber)
+		}
+	}
+}
+
+func (h *Hub) forEachSub(key []byte, f func(sub *Subscription)) {
+	for sub := range h.subs {
+		if sub.contains(key) {
+			f(sub)
+		}
+	}
+}
+
+// resolvedTs returns the largest ts below which every commit in the range of
+// sub has been observed. A transaction gets its commit ts once all its keys
+// are locked, so a commit not observed yet either has a lock in the range or
+// a commit ts above maxTs.
+func (h *Hub) resolvedTs(sub *Subscription) uint64 {
+	resolved := h.maxTs
+	for _, ts := range sub.locks {
+		if ts <= resolved {
+			resolved = ts - 1
+		}
+	}
+	return resolved
+}
+
+// commitRows returns the rows of the commit records put in the write CF by
+// batch, indexed by their position in batch.
+func commitRows(ctx *kvrpcpb.Context, batch []storage.Modify, st storage.Storage) (map[int]*kvrpcpb.ChangeDataRow, error) {
+	var reader storage.StorageReader
+	defer func() {
+		if reader != nil {
+			reader.Close()
+		}
+	}()
+	commits := make(map[int]*kvrpcpb.ChangeDataRow)
+	for i, m := range batch {
+		put, ok := m.Data.(storage.Put)
+		if !ok || put.Cf != engine_util.CfWrite {
+			continue
+		}
+		if reader == nil {
+			var err error
+			if reader, err = st.Reader(ctx); err != nil {
+				return nil, err
+			}
+		}
+		row, err := commitRow(put, reader)
+		if err != nil {
+			return nil, err
+		}
+		if row != nil {
+			commits[i] = row
+		}
+	}
+	return commits, nil
+}
+
+// commitRow returns the row of a commit record put in the write CF, or nil
+// for a rollback.
+func commitRow(put storage.Put, reader storage.StorageReader) (*kvrpcpb.ChangeDataRow, error) {
+	key, commitTs, err := codec.DecodeMvccKey(put.Key)
+	if err != nil {
+		return nil, err
+	}
+	write, err := mvcc.ParseWrite(put.Value)
+	if err != nil {
+		return nil, err
+	}
+	row := &kvrpcpb.ChangeDataRow{Key: key, StartTs: write.StartTS, CommitTs: commitTs}
+	switch write.Kind {
+	case mvcc.WriteKindPut:
+		row.Op = kvrpcpb.Op_Put
+		row.Value, err = reader.GetCF(engine_util.CfDefault, mvcc.EncodeKey(key, write.StartTS))
+		if err != nil {
+			return nil, err
+		}
+	case mvcc.WriteKindDelete:
+		row.Op = kvrpcpb.Op_Del
+	default:
+		return nil, nil
+	}
+	return row, nil
+}
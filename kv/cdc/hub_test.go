@@ -0,0 +1,127 @@
+package cdc
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func write(t *testing.T, hub *Hub, st storage.Storage, batch []storage.Modify) {
+	ctx := new(kvrpcpb.Context)
+	require.Nil(t, st.Write(ctx, batch))
+	require.Nil(t, hub.Observe(ctx, batch, st))
+}
+
+func prewrite(key, value []byte, startTs uint64) []storage.Modify {
+	lock := &mvcc.Lock{Primary: key, Ts: startTs, Ttl: 100, Kind: mvcc.WriteKindPut}
+	return []storage.Modify{
+		{Data: storage.Put{Cf: engine_util.CfDefault, Key: mvcc.EncodeKey(key, startTs), Value: value}},
+		{Data: storage.Put{Cf: engine_util.CfLock, Key: key, Value: lock.ToBytes()}},
+	}
+}
+
+func commit(key []byte, startTs, commitTs uint64) []storage.Modify {
+	w := &mvcc.Write{StartTS: startTs, Kind: mvcc.WriteKindPut}
+	return []storage.Modify{
+		{Data: storage.Put{Cf: engine_util.CfWrite, Key: mvcc.EncodeKey(key, commitTs), Value: w.ToBytes()}},
+		{Data: storage.Delete{Cf: engine_util.CfLock, Key: key}},
+	}
+}
+
+func TestHubStreamsCommits(t *testing.T) {
+	st := storage.NewMemStorage()
+	hub := NewHub()
+	// A lock taken before the subscription holds the resolved ts back.
+	write(t, hub, st, prewrite([]byte("b"), []byte("v0"), 5))
+	sub, err := hub.Subscribe(nil, st, []byte("a"), []byte("c"), 8)
+	require.Nil(t, err)
+
+	write(t, hub, st, prewrite([]byte("a"), []byte("v1"), 10))
+	write(t, hub, st, prewrite([]byte("x"), []byte("v2"), 11))
+	assert.Len(t, sub.Events(), 0)
+
+	// A commit outside of the range only moves the resolved ts, up to the
+	// lock of "b".
+	write(t, hub, st, commit([]byte("x"), 11, 12))
+	event := <-sub.Events()
+	assert.Len(t, event.Rows, 0)
+	assert.Equal(t, uint64(4), event.ResolvedTs)
+
+	write(t, hub, st, commit([]byte("a"), 10, 13))
+	event = <-sub.Events()
+	require.Len(t, event.Rows, 1)
+	row := event.Rows[0]
+	assert.Equal(t, kvrpcpb.Op_Put, row.Op)
+	assert.Equal(t, []byte("a"), row.Key)
+	assert.Equal(t, []byte("v1"), row.Value)
+	assert.Equal(t, uint64(10), row.StartTs)
+	assert.Equal(t, uint64(13), row.CommitTs)
+	assert.Equal(t, uint64(0), event.ResolvedTs)
+
+	write(t, hub, st, commit([]byte("b"), 5, 14))
+	event = <-sub.Events()
+	require.Len(t, event.Rows, 1)
+	assert.Equal(t, uint64(14), event.ResolvedTs)
+
+	hub.Unsubscribe(sub)
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.Nil(t, sub.Err())
+}
+
+func TestHubClosesSlowSubscription(t *testing.T) {
+	st := storage.NewMemStorage()
+	hub := NewHub()
+	sub, err := hub.Subscribe(nil, st, nil, nil, 1)
+	require.Nil(t, err)
+
+	for ts := uint64(1); ts <= 2; ts++ {
+		key := []byte{byte(ts)}
+		write(t, hub, st, prewrite(key, key, ts*10))
+		write(t, hub, st, commit(key, ts*10, ts*10+1))
+	}
+	<-sub.Events()
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.Equal(t, ErrSlowSubscriber, sub.Err())
+}
+
+func TestHubAdvance(t *testing.T) {
+	st := storage.NewMemStorage()
+	hub := NewHub()
+	sub, err := hub.Subscribe(nil, st, nil, nil, 8)
+	require.Nil(t, err)
+
+	hub.Advance(100)
+	event := <-sub.Events()
+	assert.Len(t, event.Rows, 0)
+	assert.Equal(t, uint64(100), event.ResolvedTs)
+	// Nothing is sent if the resolved ts didn't move.
+	hub.Advance(100)
+	assert.Len(t, sub.Events(), 0)
+}
+
+func TestHubStreamsRawWrites(t *testing.T) {
+	st := storage.NewMemStorage()
+	hub := NewHub()
+	sub, err := hub.Subscribe(nil, st, []byte("a"), []byte("c"), 8)
+	require.Nil(t, err)
+
+	hub.ObserveRaw([]storage.Modify{
+		{Data: storage.Put{Cf: engine_util.CfDefault, Key: []byte("a"), Value: []byte("v1")}},
+		{Data: storage.Put{Cf: engine_util.CfDefault, Key: []byte("x"), Value: []byte("v2")}},
+		// A row has no CF, the other CFs are not streamed.
+		{Data: storage.Put{Cf: engine_util.CfLock, Key: []byte("b"), Value: []byte("v3")}},
+		{Data: storage.Delete{Cf: engine_util.CfDefault, Key: []byte("b")}},
+	})
+	event := <-sub.Events()
+	require.Len(t, event.Rows, 2)
+	assert.Equal(t, &kvrpcpb.ChangeDataRow{Op: kvrpcpb.Op_Put, Key: []byte("a"), Value: []byte("v1")}, event.Rows[0])
+	assert.Equal(t, &kvrpcpb.ChangeDataRow{Op: kvrpcpb.Op_Del, Key: []byte("b")}, event.Rows[1])
+	assert.Equal(t, uint64(0), event.ResolvedTs)
+}
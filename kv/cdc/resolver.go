@@ -0,0 +1,79 @@
+package cdc
+
+import (
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+)
+
+// Resolver tracks the resolved ts of the regions: every transaction committed
+// in a region with a commit ts not above its resolved ts is visible, so reads
+// at such a ts are complete without checking locks with the leader.
+type Resolver struct {
+	mu sync.Mutex
+	// ts is the largest timestamp passed to Advance.
+	ts uint64
+	// resolved holds the resolved ts returned for each region, it never goes
+	// back.
+	resolved map[uint64]uint64
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{resolved: make(map[uint64]uint64)}
+}
+
+// Advance lets the resolved ts of the regions move up to ts, a timestamp just
+// taken from the timestamp oracle. Any transaction not locked yet will commit
+// with a larger ts.
+func (r *Resolver) Advance(ts uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ts > r.ts {
+		r.ts = ts
+	}
+}
+
+// ResolvedTs returns the resolved ts of the region read by reader: the last
+// timestamp passed to Advance, or less if a lock of the region is older. The
+// locks are read from reader, taken after that timestamp, so they include
+// every transaction which may commit with a ts not above it, whatever wrote
+// them.
+func (r *Resolver) ResolvedTs(regionID uint64, reader storage.StorageReader) (uint64, error) {
+	r.mu.Lock()
+	resolved := r.ts
+	r.mu.Unlock()
+
+	it := reader.IterCF(engine_util.CfLock)
+	defer it.Close()
+	for it.Seek(nil); it.Valid(); it.Next() {
+		val, err := it.Item().Value()
+		if err != nil {
+			return 0, err
+		}
+		lock, err := mvcc.ParseLock(val)
+		if err != nil {
+			return 0, err
+		}
+		if lock.Ts <= resolved {
+			resolved = lock.Ts - 1
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if prev := r.resolved[regionID]; prev > resolved {
+		return prev, nil
+	}
+	r.resolved[regionID] = resolved
+	return resolved, nil
+}
+
+// Deregister forgets the region, e.g. once this store is not its leader
+// anymore.
+func (r *Resolver) Deregister(regionID uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.resolved, regionID)
+}
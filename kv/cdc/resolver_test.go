@@ -0,0 +1,37 @@
+package cdc
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resolvedTs(t *testing.T, r *Resolver, st storage.Storage) uint64 {
+	reader, err := st.Reader(nil)
+	require.Nil(t, err)
+	defer reader.Close()
+	ts, err := r.ResolvedTs(1, reader)
+	require.Nil(t, err)
+	return ts
+}
+
+func TestResolverAdvance(t *testing.T) {
+	st := storage.NewMemStorage()
+	r := NewResolver()
+	assert.Equal(t, uint64(0), resolvedTs(t, r, st))
+
+	// The locks are read from the storage, however they were written.
+	require.Nil(t, st.Write(nil, prewrite([]byte("a"), []byte("v"), 10)))
+	r.Advance(20)
+	assert.Equal(t, uint64(9), resolvedTs(t, r, st))
+
+	require.Nil(t, st.Write(nil, commit([]byte("a"), 10, 21)))
+	r.Advance(30)
+	assert.Equal(t, uint64(30), resolvedTs(t, r, st))
+	// The resolved ts never goes back, a lock older than it belongs to a
+	// transaction which commits above it.
+	require.Nil(t, st.Write(nil, prewrite([]byte("b"), []byte("v"), 25)))
+	assert.Equal(t, uint64(30), resolvedTs(t, r, st))
+}
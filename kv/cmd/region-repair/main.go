@@ -0,0 +1,112 @@
+// region-repair checks the region metadata of a stopped store for overlaps,
+// data outside every region and other inconsistencies, and fixes them after
+// asking for confirmation.
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/repair"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+)
+
+var (
+	dbPath    = flag.String("path", "", "directory path of the store db")
+	checkOnly = flag.Bool("check", false, "only report the issues, do not fix anything")
+	tombstone = flag.Uint64("tombstone", 0, "mark the region with this id as removed")
+	setRange  = flag.Uint64("set-range", 0, "rewrite the key range of the region with this id to [-start, -end)")
+	startKey  = flag.String("start", "", "hex encoded start key for -set-range")
+	endKey    = flag.String("end", "", "hex encoded end key for -set-range, empty means unbounded")
+)
+
+func main() {
+	flag.Parse()
+	if *dbPath == "" {
+		log.Fatal("-path is required")
+	}
+	kvPath := filepath.Join(*dbPath, "kv")
+	raftPath := filepath.Join(*dbPath, "raft")
+	engines := engine_util.NewEngines(engine_util.CreateDB(kvPath, false), engine_util.CreateDB(raftPath, true), kvPath, raftPath)
+	defer engines.Close()
+
+	switch {
+	case *tombstone != 0:
+		if err := repair.Tombstone(engines.Kv, *tombstone); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("region %d is marked as tombstone\n", *tombstone)
+	case *setRange != 0:
+		start, err := hex.DecodeString(*startKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		end, err := hex.DecodeString(*endKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := repair.SetRange(engines.Kv, *setRange, start, end); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("region %d now covers [%x, %x)\n", *setRange, start, end)
+	default:
+		check(engines)
+	}
+}
+
+func check(engines *engine_util.Engines) {
+	issues, err := repair.Check(engines)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(issues) == 0 {
+		fmt.Println("no issue found")
+		return
+	}
+	in := bufio.NewReader(os.Stdin)
+	for _, issue := range issues {
+		fmt.Println(issue)
+		if issue.Commit != 0 {
+			fixCommit(in, engines, issue)
+			continue
+		}
+		if issue.Tombstone == 0 {
+			fmt.Println("  needs a manual fix, see -tombstone and -set-range")
+			continue
+		}
+		if *checkOnly {
+			fmt.Printf("  fix: tombstone region %d\n", issue.Tombstone)
+			continue
+		}
+		fmt.Printf("  tombstone region %d? [y/N] ", issue.Tombstone)
+		answer, _ := in.ReadString('\n')
+		if strings.TrimSpace(strings.ToLower(answer)) != "y" {
+			continue
+		}
+		if err := repair.Tombstone(engines.Kv, issue.Tombstone); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func fixCommit(in *bufio.Reader, engines *engine_util.Engines, issue *repair.Issue) {
+	regionID := issue.Regions[0]
+	if *checkOnly {
+		fmt.Printf("  fix: set commit index of region %d to %d\n", regionID, issue.Commit)
+		return
+	}
+	fmt.Printf("  set commit index of region %d to %d? [y/N] ", regionID, issue.Commit)
+	answer, _ := in.ReadString('\n')
+	if strings.TrimSpace(strings.ToLower(answer)) != "y" {
+		return
+	}
+	if err := repair.SetCommit(engines.Raft, regionID, issue.Commit); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,134 @@
+// replicate replicates the transactions committed in a key range of a source
+// cluster to a target cluster, asynchronously, until it is interrupted. It
+// saves a checkpoint so a restarted replicate resumes where it stopped.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
+	"github.com/pingcap-incubator/tinykv/kv/replication"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+	"google.golang.org/grpc"
+)
+
+var (
+	sourceAddr     = flag.String("source", "", "comma separated scheduler addresses of the source cluster")
+	targetAddr     = flag.String("target", "", "comma separated scheduler addresses of the target cluster")
+	startKey       = flag.String("start", "", "hex encoded start key of the replicated range")
+	endKey         = flag.String("end", "", "hex encoded end key of the replicated range, empty means unbounded")
+	checkpointPath = flag.String("checkpoint", "replicate.checkpoint", "file path of the checkpoint")
+	policy         = flag.String("policy", "overwrite", "what to do on conflicts with the target cluster, overwrite or skip")
+)
+
+func main() {
+	flag.Parse()
+	if *sourceAddr == "" || *targetAddr == "" {
+		log.Fatal("-source and -target are required")
+	}
+	start, err := hex.DecodeString(*startKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	end, err := hex.DecodeString(*endKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var conflictPolicy replication.ConflictPolicy
+	switch *policy {
+	case "overwrite":
+		conflictPolicy = replication.ConflictOverwrite
+	case "skip":
+		conflictPolicy = replication.ConflictSkip
+	default:
+		log.Fatalf("unknown policy %s", *policy)
+	}
+
+	source, err := scheduler_client.NewClient(strings.Split(*sourceAddr, ","), "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer source.Close()
+	target, err := scheduler_client.NewClient(strings.Split(*targetAddr, ","), "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer target.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sc := make(chan os.Signal, 1)
+	signal.Notify(sc, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	go func() {
+		<-sc
+		cancel()
+	}()
+
+	streams, err := openStreams(ctx, source, start, end)
+	if err != nil {
+		log.Fatal(err)
+	}
+	checkpoint := replication.NewGCCheckpoint(replication.NewFileCheckpoint(*checkpointPath), source, "replicate")
+	agent := replication.NewAgent(streams, replication.NewClusterSink(target, conflictPolicy), checkpoint)
+	if err := agent.Run(ctx); err != nil && err != context.Canceled {
+		log.Fatal(err)
+	}
+}
+
+// openStreams opens an EventFeed stream on the leader of every region of the
+// source cluster overlapping [start, end).
+func openStreams(ctx context.Context, source scheduler_client.Client, start, end []byte) ([]replication.EventStream, error) {
+	clients := make(map[uint64]tinykvpb.TinyKvClient)
+	var streams []replication.EventStream
+	key := start
+	for {
+		region, leader, err := source.GetRegion(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if leader == nil {
+			return nil, errors.Errorf("region %d has no leader", region.GetId())
+		}
+		client, ok := clients[leader.StoreId]
+		if !ok {
+			store, err := source.GetStore(ctx, leader.StoreId)
+			if err != nil {
+				return nil, err
+			}
+			cc, err := grpc.Dial(store.Address, grpc.WithInsecure())
+			if err != nil {
+				return nil, err
+			}
+			client = tinykvpb.NewTinyKvClient(cc)
+			clients[leader.StoreId] = client
+		}
+
+		regionEnd := region.EndKey
+		if len(end) > 0 && (len(regionEnd) == 0 || bytes.Compare(end, regionEnd) < 0) {
+			regionEnd = end
+		}
+		stream, err := client.EventFeed(ctx, &kvrpcpb.ChangeDataRequest{
+			Context:  &kvrpcpb.Context{RegionId: region.Id, RegionEpoch: region.RegionEpoch, Peer: leader},
+			StartKey: key,
+			EndKey:   regionEnd,
+		})
+		if err != nil {
+			return nil, err
+		}
+		streams = append(streams, stream)
+
+		if len(region.EndKey) == 0 || (len(end) > 0 && bytes.Compare(region.EndKey, end) >= 0) {
+			return streams, nil
+		}
+		key = region.EndKey
+	}
+}
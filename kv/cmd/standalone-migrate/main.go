@@ -0,0 +1,29 @@
+// standalone-migrate converts the db of a stopped standalone instance into the
+// db of a raft store, in place. Starting tinykv-server on the new db with a
+// new scheduler bootstraps a single store cluster serving the same data.
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage/raft_storage"
+	"github.com/pingcap-incubator/tinykv/log"
+)
+
+var (
+	standalonePath = flag.String("from", "", "directory path of the standalone db")
+	dbPath         = flag.String("path", "", "directory path of the store db to create")
+)
+
+func main() {
+	flag.Parse()
+	if *standalonePath == "" || *dbPath == "" {
+		log.Fatal("-from and -path are required")
+	}
+	if err := raft_storage.MigrateStandalone(*standalonePath, *dbPath); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s is moved to %s, start tinykv-server with -path %s and a new scheduler to bootstrap the cluster\n",
+		*standalonePath, *dbPath, *dbPath)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/pingcap-incubator/tinykv/log"
@@ -20,12 +21,71 @@ type Config struct {
 	RaftBaseTickInterval     time.Duration
 	RaftHeartbeatTicks       int
 	RaftElectionTimeoutTicks int
+	// The maximum drift assumed between the clocks of two stores over an
+	// election timeout, leader leases are shortened by it.
+	MaxClockDrift time.Duration
+	// Run a pre-vote round before elections, so a store rejoining after a
+	// partition does not disrupt the regions with an inflated term.
+	RaftPreVote bool
+	// The largest size of the entries of one raft append message, a follower
+	// far behind is caught up with several messages.
+	RaftMaxSizePerMsg uint64
+	// The most raft append messages sent to a follower without an
+	// acknowledgement.
+	RaftMaxInflightMsgs int
+	// The most raft heartbeats a leader waits before resending an unanswered
+	// probe to a follower, so a follower losing appends isn't flooded.
+	RaftMaxProbeBackoff int
+	// The largest size of the committed raft entries applied in one batch,
+	// so a large backlog does not stall the raft worker.
+	RaftMaxCommittedSizePerReady uint64
+	// The largest size of a proposed raft entry, a larger command is rejected
+	// instead of stalling the replication of the region.
+	RaftMaxEntrySize uint64
+	// The largest size of the raft entries a leader proposed and didn't
+	// commit yet, further proposals are rejected so a leader which lost its
+	// quorum doesn't pile them up in memory.
+	RaftMaxUncommittedSize uint64
+	// Checksum the raft entries when they are proposed, and verify them when
+	// they are read back or received, so a corrupted entry is never applied.
+	RaftEntryChecksums bool
+	// Send the proposals a region received in one round of the raft worker
+	// with a single raft append message per follower.
+	RaftBatchProposals bool
+	// The largest size of the writes applied to the kv engine in one write
+	// batch, a larger apply is written in several batches so it can't exceed
+	// the transaction limits of the engine.
+	ApplyMaxWriteBatchSize uint64
+
+	// The number of raft workers, each one handling the messages of a share
+	// of the regions, and of the goroutines they share to persist and apply
+	// the raft readies of the regions in parallel. Raise them on a machine
+	// with many cores once the raftstore utilization reported in the log
+	// gets close to 1. With a single apply worker the raft workers persist
+	// and apply the readies themselves.
+	RaftPollWorkers int
+	ApplyWorkers    int
+
+	// The longest a request waits for the peer to catch up with the applied
+	// index it asks for, it is rejected with ServerIsBusy afterwards.
+	MaxApplyWait time.Duration
+
+	// Interval to advance the resolved ts of the regions with a timestamp
+	// from the scheduler.
+	ResolvedTsInterval time.Duration
 
 	// Interval to gc unnecessary raft log (ms).
 	RaftLogGCTickInterval time.Duration
 	// When entry count exceed this value, gc will be forced trigger.
 	RaftLogGcCountLimit uint64
 
+	// Interval to compact the ranges of the kv engine where at least
+	// CompactTombstoneThreshold keys were deleted at once, by destroying a
+	// region or a range of data. Reads over such a range stay slow until
+	// the tombstones are compacted. A threshold of 0 disables it.
+	CompactCheckTickInterval  time.Duration
+	CompactTombstoneThreshold int
+
 	// Interval (ms) to check region whether need to be split or not.
 	SplitRegionCheckTickInterval time.Duration
 	// delay time before deleting a stale peer
@@ -37,8 +97,101 @@ type Config struct {
 	// [b,c), [c,d) will be regionSplitSize (maybe a little larger).
 	RegionMaxSize   uint64
 	RegionSplitSize uint64
+
+	// The most raft messages queued for a peer, so a flooded region can't
+	// fill the memory of the store. Heartbeats are dropped once half of it is
+	// queued, other messages but votes once all of it is. 0 means no limit.
+	PeerMailboxCapacity int
+
+	// Max number of snapshots sent and received at the same time, further
+	// snapshots wait in a queue.
+	SnapMaxConcurrentSend int
+	SnapMaxConcurrentRecv int
+
+	// The store reports itself busy to the scheduler once the kv engine has
+	// this many level-0 tables waiting for compaction, so no new peers or
+	// leaders are moved to it. It is reported healthy again once the backlog
+	// drops below half of it. 0 disables the check.
+	WriteStallL0Tables int
+
+	// LogOnly makes the store a warm standby: its peers persist the raft log
+	// but apply nothing and serve no requests until the store is promoted.
+	// As nothing is applied, the raft logs are not compacted meanwhile.
+	LogOnly bool
+
+	// Transport the raft messages are sent with, TransportGRPC or
+	// TransportLoopback.
+	Transport string
+
+	// The number of connections the raft messages to another store are
+	// spread over, each one with its own sending goroutine. The messages of a
+	// region always go over the same one, so they stay in order.
+	TransportSenders int
+
+	// Column families available to the raw API on top of default, write and
+	// lock, e.g. to keep index data or metadata apart. They share the badger
+	// DB and its tuning with the built-in ones. Every store of a cluster must
+	// know the same CFs, as they are replicated and sent in snapshots.
+	ExtraCFs []string
+
+	// Proxy the raw requests for regions this store doesn't serve to the
+	// store of their leader, through at most this many stores, so clients
+	// without a region cache can send requests to any store. 0 disables it.
+	ProxyMaxHops uint32
+
+	// The most bytes of keys and values a scan returns in one response,
+	// whatever the request asks, so a scan over large values can't exhaust
+	// the memory of the server. 0 means no limit.
+	ScanMaxBytes uint64
+
+	// Mirror ShadowSampleRate, between 0 and 1, of the reads to the store at
+	// ShadowAddr and count the responses which differ, to validate a cluster
+	// running another version. The raw writes are mirrored too, to
+	// ShadowScratchCF of the shadow cluster, if it is set. An empty
+	// ShadowAddr disables it.
+	ShadowAddr       string
+	ShadowSampleRate float64
+	ShadowScratchCF  string
+
+	// Keyspaces share the cluster between tenants, giving each a prefix its
+	// keys are stored under, and optionally quotas and a TTL. Once set, every
+	// raw request must name one of them in its context. Empty disables them.
+	Keyspaces []KeyspaceConfig
+	// Interval of the keyspace GC, which enforces the GC retention and the
+	// TTL of the keyspaces.
+	KeyspaceGCInterval time.Duration
+}
+
+// KeyspaceConfig is the namespace of the keys of a tenant. The keys of its
+// requests are relative to Prefix, the server adds and strips it.
+type KeyspaceConfig struct {
+	Name   string
+	Prefix string
+	// The most bytes of keys and values the tenant reads and writes per
+	// second. Requests beyond them are rejected until the next second. 0
+	// means no limit.
+	MaxReadBytesPerSec  uint64
+	MaxWriteBytesPerSec uint64
+	// DefaultTTL is how long the raw values written in the keyspace live,
+	// reads don't see them once it has passed, and the keyspace GC deletes
+	// those of the ExtraCFs. 0 means they never expire. Each value records whether it has a
+	// deadline, so it can be changed while the keyspace stores values, and
+	// only applies to the values written after.
+	DefaultTTL time.Duration
+	// GCRetention is how long the MVCC history of the keys under Prefix is
+	// kept: the keyspace GC deletes the versions overwritten or deleted
+	// before it. 0 keeps all of it.
+	GCRetention time.Duration
 }
 
+const (
+	// TransportGRPC sends raft messages to other TinyKV processes over gRPC.
+	TransportGRPC = "grpc"
+	// TransportLoopback sends raft messages to stores running in the same
+	// process, so a whole cluster can run in a single test binary.
+	TransportLoopback = "loopback"
+)
+
 func (c *Config) Validate() error {
 	if c.RaftHeartbeatTicks == 0 {
 		return fmt.Errorf("heartbeat tick must greater than 0")
@@ -53,6 +206,67 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("election tick must be greater than heartbeat tick.")
 	}
 
+	if c.MaxClockDrift >= c.RaftBaseTickInterval*time.Duration(c.RaftElectionTimeoutTicks) {
+		return fmt.Errorf("max clock drift must be smaller than the election timeout")
+	}
+
+	if c.SnapMaxConcurrentSend <= 0 || c.SnapMaxConcurrentRecv <= 0 {
+		return fmt.Errorf("snapshot concurrency must be greater than 0")
+	}
+
+	if c.RaftPollWorkers <= 0 || c.ApplyWorkers <= 0 || c.TransportSenders <= 0 {
+		return fmt.Errorf("raft poll workers, apply workers and transport senders must be greater than 0")
+	}
+
+	if c.PeerMailboxCapacity < 0 {
+		return fmt.Errorf("peer mailbox capacity must not be negative")
+	}
+
+	if c.WriteStallL0Tables < 0 {
+		return fmt.Errorf("write stall level-0 tables must not be negative")
+	}
+
+	if c.ResolvedTsInterval <= 0 {
+		return fmt.Errorf("resolved ts interval must be greater than 0")
+	}
+
+	for _, cf := range c.ExtraCFs {
+		if cf == "" || strings.Contains(cf, "_") {
+			return fmt.Errorf("invalid cf name %q", cf)
+		}
+	}
+
+	if c.ShadowSampleRate < 0 || c.ShadowSampleRate > 1 {
+		return fmt.Errorf("shadow sample rate must be between 0 and 1")
+	}
+
+	if c.Transport != TransportGRPC && c.Transport != TransportLoopback {
+		return fmt.Errorf("unknown transport %q", c.Transport)
+	}
+
+	if len(c.Keyspaces) > 0 && c.KeyspaceGCInterval <= 0 {
+		return fmt.Errorf("keyspace GC interval must be greater than 0")
+	}
+	for i, ks := range c.Keyspaces {
+		if ks.Name == "" || ks.Prefix == "" {
+			return fmt.Errorf("keyspace %q must have a name and a prefix", ks.Name)
+		}
+		if ks.DefaultTTL < 0 {
+			return fmt.Errorf("keyspace %q must have a non-negative TTL", ks.Name)
+		}
+		if ks.GCRetention < 0 {
+			return fmt.Errorf("keyspace %q must have a non-negative GC retention", ks.Name)
+		}
+		for _, other := range c.Keyspaces[:i] {
+			if ks.Name == other.Name {
+				return fmt.Errorf("duplicate keyspace %q", ks.Name)
+			}
+			if strings.HasPrefix(ks.Prefix, other.Prefix) || strings.HasPrefix(other.Prefix, ks.Prefix) {
+				return fmt.Errorf("prefixes of keyspaces %q and %q overlap", other.Name, ks.Name)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -71,40 +285,90 @@ func getLogLevel() (logLevel string) {
 
 func NewDefaultConfig() *Config {
 	return &Config{
-		SchedulerAddr:            "127.0.0.1:2379",
-		StoreAddr:                "127.0.0.1:20160",
-		LogLevel:                 getLogLevel(),
-		Raft:                     true,
-		RaftBaseTickInterval:     1 * time.Second,
-		RaftHeartbeatTicks:       2,
-		RaftElectionTimeoutTicks: 10,
-		RaftLogGCTickInterval:    10 * time.Second,
+		SchedulerAddr:                "127.0.0.1:2379",
+		StoreAddr:                    "127.0.0.1:20160",
+		LogLevel:                     getLogLevel(),
+		Raft:                         true,
+		RaftBaseTickInterval:         1 * time.Second,
+		RaftHeartbeatTicks:           2,
+		RaftElectionTimeoutTicks:     10,
+		RaftPreVote:                  true,
+		RaftMaxSizePerMsg:            1 * MB,
+		RaftMaxInflightMsgs:          256,
+		RaftMaxProbeBackoff:          8,
+		RaftMaxCommittedSizePerReady: 16 * MB,
+		RaftMaxEntrySize:             8 * MB,
+		RaftMaxUncommittedSize:       128 * MB,
+		RaftEntryChecksums:           true,
+		RaftBatchProposals:           true,
+		ApplyMaxWriteBatchSize:       4 * MB,
+		RaftPollWorkers:              1,
+		ApplyWorkers:                 1,
+		MaxClockDrift:                500 * time.Millisecond,
+		MaxApplyWait:                 2 * time.Second,
+		ResolvedTsInterval:           1 * time.Second,
+		KeyspaceGCInterval:           10 * time.Minute,
+		RaftLogGCTickInterval:        10 * time.Second,
 		// Assume the average size of entries is 1k.
 		RaftLogGcCountLimit:                 128000,
+		CompactCheckTickInterval:            5 * time.Minute,
+		CompactTombstoneThreshold:           10000,
 		SplitRegionCheckTickInterval:        10 * time.Second,
 		SchedulerHeartbeatTickInterval:      100 * time.Millisecond,
 		SchedulerStoreHeartbeatTickInterval: 10 * time.Second,
 		RegionMaxSize:                       144 * MB,
 		RegionSplitSize:                     96 * MB,
+		PeerMailboxCapacity:                 4096,
+		SnapMaxConcurrentSend:               2,
+		SnapMaxConcurrentRecv:               2,
+		WriteStallL0Tables:                  8,
+		ScanMaxBytes:                        32 * MB,
+		TransportSenders:                    1,
+		Transport:                           TransportGRPC,
 		DBPath:                              "/tmp/badger",
 	}
 }
 
 func NewTestConfig() *Config {
 	return &Config{
-		LogLevel:                 getLogLevel(),
-		Raft:                     true,
-		RaftBaseTickInterval:     50 * time.Millisecond,
-		RaftHeartbeatTicks:       2,
-		RaftElectionTimeoutTicks: 10,
-		RaftLogGCTickInterval:    50 * time.Millisecond,
+		LogLevel:                     getLogLevel(),
+		Raft:                         true,
+		RaftBaseTickInterval:         50 * time.Millisecond,
+		RaftHeartbeatTicks:           2,
+		RaftElectionTimeoutTicks:     10,
+		RaftPreVote:                  true,
+		RaftMaxSizePerMsg:            1 * MB,
+		RaftMaxInflightMsgs:          256,
+		RaftMaxProbeBackoff:          8,
+		RaftMaxCommittedSizePerReady: 16 * MB,
+		RaftMaxEntrySize:             8 * MB,
+		RaftMaxUncommittedSize:       128 * MB,
+		RaftEntryChecksums:           true,
+		RaftBatchProposals:           true,
+		ApplyMaxWriteBatchSize:       4 * MB,
+		RaftPollWorkers:              1,
+		ApplyWorkers:                 1,
+		MaxClockDrift:                50 * time.Millisecond,
+		MaxApplyWait:                 500 * time.Millisecond,
+		ResolvedTsInterval:           100 * time.Millisecond,
+		KeyspaceGCInterval:           time.Second,
+		RaftLogGCTickInterval:        50 * time.Millisecond,
 		// Assume the average size of entries is 1k.
 		RaftLogGcCountLimit:                 128000,
+		CompactCheckTickInterval:            time.Second,
+		CompactTombstoneThreshold:           10000,
 		SplitRegionCheckTickInterval:        100 * time.Millisecond,
 		SchedulerHeartbeatTickInterval:      100 * time.Millisecond,
 		SchedulerStoreHeartbeatTickInterval: 500 * time.Millisecond,
 		RegionMaxSize:                       144 * MB,
 		RegionSplitSize:                     96 * MB,
+		PeerMailboxCapacity:                 4096,
+		SnapMaxConcurrentSend:               2,
+		SnapMaxConcurrentRecv:               2,
+		WriteStallL0Tables:                  8,
+		ScanMaxBytes:                        32 * MB,
+		TransportSenders:                    1,
+		Transport:                           TransportGRPC,
 		DBPath:                              "/tmp/badger",
 	}
 }
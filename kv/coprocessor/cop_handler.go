@@ -21,7 +21,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/pingcap-incubator/tinykv/kv/coprocessor/rowcodec"
 	"github.com/pingcap-incubator/tinykv/kv/storage"
-	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/coprocessor"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
@@ -236,9 +236,9 @@ func buildResp(chunks []tipb.Chunk, counts []int64, err error, warnings []stmtct
 		}
 	}
 	if err != nil {
-		if ke, ok := err.(*mvcc.KeyError); ok {
+		if ke, ok := kverrors.AsKeyError(err); ok {
 			if ke.Locked == nil {
-				resp.OtherError = ke.Error()
+				resp.OtherError = ke.String()
 			} else {
 				resp.Locked = ke.Locked
 			}
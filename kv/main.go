@@ -2,10 +2,12 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"net"
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -15,7 +17,10 @@ import (
 	"github.com/pingcap-incubator/tinykv/kv/storage"
 	"github.com/pingcap-incubator/tinykv/kv/storage/raft_storage"
 	"github.com/pingcap-incubator/tinykv/kv/storage/standalone_storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
@@ -26,6 +31,17 @@ var (
 	storeAddr     = flag.String("addr", "", "store address")
 	dbPath        = flag.String("path", "", "directory path of db")
 	logLevel      = flag.String("loglevel", "", "the level of log")
+	readOnly      = flag.Bool("readonly", false, "start the store in read-only mode, SIGUSR1 toggles it")
+	logOnly       = flag.Bool("logonly", false, "start the store as a warm standby which keeps raft logs without applying them")
+	proxyMaxHops  = flag.Uint("proxy-hops", 0, "proxy requests for regions the store doesn't serve through at most this many stores, 0 disables it")
+	extraCFs      = flag.String("cfs", "", "comma separated column families to add to default, write and lock")
+	shadowAddr    = flag.String("shadow", "", "address of a store to mirror a sample of the requests to")
+	shadowRate    = flag.Float64("shadow-rate", 0.01, "share of the reads mirrored to the shadow store")
+	shadowCF      = flag.String("shadow-cf", "", "column family of the shadow store to mirror raw writes to, empty to mirror reads only")
+	pollWorkers   = flag.Int("raft-poll-workers", 1, "number of raft workers")
+	applyWorkers  = flag.Int("apply-workers", 1, "number of goroutines persisting and applying the raft readies")
+	senders       = flag.Int("transport-senders", 1, "number of connections the raft messages to each store are spread over")
+	keyspaces     = flag.String("keyspaces", "", "comma separated keyspaces of the tenants, as name=prefix[:max read bytes per second:max write bytes per second[:default ttl[:gc retention]]]")
 )
 
 func main() {
@@ -43,11 +59,36 @@ func main() {
 	if *logLevel != "" {
 		conf.LogLevel = *logLevel
 	}
+	conf.LogOnly = *logOnly
+	conf.ProxyMaxHops = uint32(*proxyMaxHops)
+	conf.RaftPollWorkers = *pollWorkers
+	conf.ApplyWorkers = *applyWorkers
+	conf.TransportSenders = *senders
+	if *extraCFs != "" {
+		conf.ExtraCFs = strings.Split(*extraCFs, ",")
+	}
+	conf.ShadowAddr = *shadowAddr
+	conf.ShadowSampleRate = *shadowRate
+	conf.ShadowScratchCF = *shadowCF
+	if *keyspaces != "" {
+		ks, err := parseKeyspaces(*keyspaces)
+		if err != nil {
+			log.Fatal(err)
+		}
+		conf.Keyspaces = ks
+	}
+	if err := conf.Validate(); err != nil {
+		log.Fatal(err)
+	}
 
 	log.SetLevelByString(conf.LogLevel)
 	log.SetFlags(log.Ldate | log.Ltime | log.Lmicroseconds | log.Lshortfile)
 	log.Infof("Server started with conf %+v", conf)
 
+	if err := engine_util.RegisterCFs(conf.ExtraCFs...); err != nil {
+		log.Fatal(err)
+	}
+
 	var storage storage.Storage
 	if conf.Raft {
 		storage = raft_storage.NewRaftStorage(conf)
@@ -57,19 +98,58 @@ func main() {
 	if err := storage.Start(); err != nil {
 		log.Fatal(err)
 	}
+	if rs, ok := storage.(*raft_storage.RaftStorage); ok {
+		rs.SetReadOnly(*readOnly)
+		handleReadOnlySignal(rs)
+		reportSnapApplyProgress(rs)
+		reportWorkerStats(rs)
+	}
+	var shadow *server.Shadow
+	if conf.ShadowAddr != "" {
+		cc, err := grpc.Dial(conf.ShadowAddr, grpc.WithInsecure())
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer cc.Close()
+		shadow = server.NewShadow(tinykvpb.NewTinyKvClient(cc), conf.ShadowSampleRate, conf.ShadowScratchCF)
+		reportShadowStats(shadow)
+	}
 	server := server.NewServer(storage)
+	server.LimitScanBytes(conf.ScanMaxBytes)
+	if len(conf.Keyspaces) > 0 {
+		server.UseKeyspaces(conf.Keyspaces)
+		reportKeyspaceStats(server)
+	}
+	if shadow != nil {
+		server.UseUnaryInterceptor(shadow.UnaryInterceptor())
+	}
+	if len(conf.Keyspaces) > 0 {
+		regions := func() []*kvrpcpb.Context { return []*kvrpcpb.Context{nil} }
+		if rs, ok := storage.(*raft_storage.RaftStorage); ok {
+			regions = rs.RegionContexts
+		}
+		server.StartKeyspaceGC(regions, conf.ExtraCFs, conf.KeyspaceGCInterval)
+	}
+	if rs, ok := storage.(*raft_storage.RaftStorage); ok {
+		server.StartResolvedTs(rs.SchedulerClient(), conf.ResolvedTsInterval)
+		if conf.ProxyMaxHops > 0 {
+			server.EnableProxy(rs.SchedulerClient(), conf.ProxyMaxHops)
+		}
+	}
+	defer server.Stop()
 
 	var alivePolicy = keepalive.EnforcementPolicy{
 		MinTime:             2 * time.Second, // If a client pings more than once every 2 seconds, terminate the connection
 		PermitWithoutStream: true,            // Allow pings even when there are no active streams
 	}
 
-	grpcServer := grpc.NewServer(
+	opts := []grpc.ServerOption{
 		grpc.KeepaliveEnforcementPolicy(alivePolicy),
-		grpc.InitialWindowSize(1<<30),
-		grpc.InitialConnWindowSize(1<<30),
-		grpc.MaxRecvMsgSize(10*1024*1024),
-	)
+		grpc.InitialWindowSize(1 << 30),
+		grpc.InitialConnWindowSize(1 << 30),
+		grpc.MaxRecvMsgSize(10 * 1024 * 1024),
+	}
+	grpcServer := grpc.NewServer(append(opts, server.GRPCServerOptions()...)...)
 	tinykvpb.RegisterTinyKvServer(grpcServer, server)
 	listenAddr := conf.StoreAddr[strings.IndexByte(conf.StoreAddr, ':'):]
 	l, err := net.Listen("tcp", listenAddr)
@@ -98,3 +178,110 @@ func handleSignal(grpcServer *grpc.Server) {
 		grpcServer.Stop()
 	}()
 }
+
+// handleReadOnlySignal toggles the read-only mode of the store on SIGUSR1, so
+// writes can be stopped during incident response without a restart.
+func handleReadOnlySignal(rs *raft_storage.RaftStorage) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+	go func() {
+		for range sigCh {
+			rs.SetReadOnly(!rs.IsReadOnly())
+		}
+	}()
+}
+
+// reportSnapApplyProgress logs the progress of the snapshots being applied
+// every 10 seconds, so a stuck apply shows up in the log.
+func reportSnapApplyProgress(rs *raft_storage.RaftStorage) {
+	go func() {
+		for range time.Tick(10 * time.Second) {
+			for _, p := range rs.SnapApplyProgress() {
+				log.Infof("applying snapshot %s: %s %d/%d bytes for %v",
+					p.Key, p.Phase, p.AppliedBytes, p.TotalBytes, time.Since(p.Start))
+			}
+		}
+	}()
+}
+
+// reportWorkerStats logs the utilization of the raft workers, of the apply
+// pool and of the transport every minute, to tell whether they need more
+// goroutines.
+func reportWorkerStats(rs *raft_storage.RaftStorage) {
+	go func() {
+		prev, last := rs.WorkerStats(), time.Now()
+		for now := range time.Tick(time.Minute) {
+			stats, elapsed := rs.WorkerStats(), now.Sub(last)
+			log.Infof("raftstore utilization: raft poll %s, apply %s, transport %s",
+				formatPoolStats(stats.RaftPoll, prev.RaftPoll, elapsed),
+				formatPoolStats(stats.Apply, prev.Apply, elapsed),
+				formatPoolStats(stats.Transport, prev.Transport, elapsed))
+			prev, last = stats, now
+		}
+	}()
+}
+
+func formatPoolStats(stats, prev worker.PoolStats, elapsed time.Duration) string {
+	return fmt.Sprintf("%.0f%% of %d workers with %d pending",
+		100*stats.Utilization(prev, elapsed), stats.Workers, stats.Pending)
+}
+
+// reportShadowStats logs the counters of the requests mirrored to the shadow
+// cluster every minute.
+func reportShadowStats(shadow *server.Shadow) {
+	go func() {
+		for range time.Tick(time.Minute) {
+			log.Infof("shadow requests: %+v", shadow.Stats())
+		}
+	}()
+}
+
+// reportKeyspaceStats logs the counters of the keyspaces every minute.
+func reportKeyspaceStats(server *server.Server) {
+	go func() {
+		for range time.Tick(time.Minute) {
+			for _, stats := range server.KeyspaceStats() {
+				log.Infof("keyspace requests: %+v", stats)
+			}
+		}
+	}()
+}
+
+// parseKeyspaces parses the keyspaces flag, see config.KeyspaceConfig.
+func parseKeyspaces(flag string) ([]config.KeyspaceConfig, error) {
+	var keyspaces []config.KeyspaceConfig
+	for _, spec := range strings.Split(flag, ",") {
+		eq := strings.IndexByte(spec, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("invalid keyspace %q", spec)
+		}
+		ks := config.KeyspaceConfig{Name: spec[:eq]}
+		fields := strings.Split(spec[eq+1:], ":")
+		ks.Prefix = fields[0]
+		switch len(fields) {
+		case 1:
+		case 3, 4, 5:
+			var err error
+			if ks.MaxReadBytesPerSec, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid read quota of keyspace %q: %v", ks.Name, err)
+			}
+			if ks.MaxWriteBytesPerSec, err = strconv.ParseUint(fields[2], 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid write quota of keyspace %q: %v", ks.Name, err)
+			}
+			if len(fields) >= 4 {
+				if ks.DefaultTTL, err = time.ParseDuration(fields[3]); err != nil {
+					return nil, fmt.Errorf("invalid TTL of keyspace %q: %v", ks.Name, err)
+				}
+			}
+			if len(fields) == 5 {
+				if ks.GCRetention, err = time.ParseDuration(fields[4]); err != nil {
+					return nil, fmt.Errorf("invalid GC retention of keyspace %q: %v", ks.Name, err)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid keyspace %q", spec)
+		}
+		keyspaces = append(keyspaces, ks)
+	}
+	return keyspaces, nil
+}
@@ -0,0 +1,416 @@
+package raftstore
+
+import (
+	"fmt"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap/errors"
+)
+
+// applyBatch collects the data writes of the committed entries applied in one
+// ready together with the resulting apply state, and persists both in a single
+// write batch. As the applied index can never be behind the data on disk,
+// entries replayed after a crash are recognized by alreadyApplied and skipped,
+// which makes re-applying them idempotent.
+//
+// The writes of a large apply are split in several write batches of at most
+// maxSize bytes by maybeCommit, each one persisted with the apply state of the
+// entries fully applied before it.
+type applyBatch struct {
+	ps      *PeerStorage
+	kvWB    engine_util.WriteBatch
+	state   rspb.RaftApplyState
+	maxSize uint64
+}
+
+// newApplyBatch creates an applyBatch for the peer storage, maxSize is the
+// size of the writes above which maybeCommit writes them, 0 means no limit.
+func newApplyBatch(ps *PeerStorage, maxSize uint64) *applyBatch {
+	ab := &applyBatch{ps: ps, maxSize: maxSize}
+	ab.state.AppliedIndex = ps.applyState.AppliedIndex
+	ab.state.TruncatedState = &rspb.RaftTruncatedState{
+		Index: ps.applyState.TruncatedState.Index,
+		Term:  ps.applyState.TruncatedState.Term,
+	}
+	return ab
+}
+
+// writeBatch returns the batch the data writes of the entries are added to.
+func (ab *applyBatch) writeBatch() *engine_util.WriteBatch {
+	return &ab.kvWB
+}
+
+// alreadyApplied returns true if the entry was applied before, in this batch
+// or before a restart, and must not be applied again.
+func (ab *applyBatch) alreadyApplied(entry *eraftpb.Entry) bool {
+	return entry.Index <= ab.state.AppliedIndex
+}
+
+// advance marks the entry as applied, its writes must already be in the batch.
+func (ab *applyBatch) advance(entry *eraftpb.Entry) {
+	if entry.Index > ab.state.AppliedIndex {
+		ab.state.AppliedIndex = entry.Index
+	}
+}
+
+// truncate records the raft log truncation applied by a CompactLog command.
+func (ab *applyBatch) truncate(index, term uint64) {
+	if index > ab.state.TruncatedState.Index {
+		ab.state.TruncatedState.Index = index
+		ab.state.TruncatedState.Term = term
+	}
+}
+
+// maybeCommit commits the batch if its writes exceed maxSize. It may be called
+// between two entries, or between two writes of an entry putting or deleting
+// keys: the applied index then stays before the entry, which is applied again
+// after a crash, and writing the same keys again is harmless. It must not be
+// called in the middle of an admin command.
+func (ab *applyBatch) maybeCommit() error {
+	if ab.maxSize == 0 || uint64(ab.kvWB.Size()) < ab.maxSize {
+		return nil
+	}
+	return ab.commit()
+}
+
+// commit writes the data and the apply state to the kv engine atomically and
+// only then exposes the new apply state through the PeerStorage.
+func (ab *applyBatch) commit() error {
+	if ab.state.AppliedIndex == ab.ps.applyState.AppliedIndex &&
+		ab.state.TruncatedState.Index == ab.ps.applyState.TruncatedState.Index &&
+		ab.kvWB.Len() == 0 {
+		return nil
+	}
+	state := ab.state
+	state.TruncatedState = &rspb.RaftTruncatedState{
+		Index: ab.state.TruncatedState.Index,
+		Term:  ab.state.TruncatedState.Term,
+	}
+	if err := ab.kvWB.SetMeta(meta.ApplyStateKey(ab.ps.region.GetId()), &state); err != nil {
+		return err
+	}
+	if err := ab.ps.Engines.WriteKV(&ab.kvWB); err != nil {
+		return err
+	}
+	ab.ps.applyState = &state
+	ab.kvWB.Reset()
+	return nil
+}
+
+// applyEntries applies the committed entries of a ready and persists the
+// resulting apply state with their writes.
+func (d *peerMsgHandler) applyEntries(entries []eraftpb.Entry) {
+	ab := newApplyBatch(d.peerStorage, d.ctx.cfg.ApplyMaxWriteBatchSize)
+	for i := range entries {
+		entry := &entries[i]
+		if ab.alreadyApplied(entry) {
+			continue
+		}
+		switch entry.EntryType {
+		case eraftpb.EntryType_EntryConfChange, eraftpb.EntryType_EntryConfChangeV2:
+			d.applyConfChange(ab, entry)
+		default:
+			d.applyNormal(ab, entry)
+		}
+		ab.advance(entry)
+		d.maybeCommit(ab)
+	}
+	d.mustCommit(ab)
+}
+
+// takeProposal returns the callback of the proposal of the entry marked as
+// committed, or nil if the entry was not proposed by this peer. The proposals
+// before the entry were overwritten by another leader, they are notified as
+// stale.
+func (d *peerMsgHandler) takeProposal(entry *eraftpb.Entry) *message.Callback {
+	for len(d.proposals) > 0 {
+		p := d.proposals[0]
+		if p.index > entry.Index {
+			return nil
+		}
+		d.proposals = d.proposals[1:]
+		if p.index == entry.Index && p.term == entry.Term {
+			p.cb.MarkCommitted()
+			return p.cb
+		}
+		d.callbacks.Done(p.cb, ErrRespStaleCommand(entry.Term))
+	}
+	return nil
+}
+
+func (d *peerMsgHandler) applyNormal(ab *applyBatch, entry *eraftpb.Entry) {
+	// The no-op entry of a new leader.
+	if len(entry.Data) == 0 {
+		return
+	}
+	msg := new(raft_cmdpb.RaftCmdRequest)
+	if err := msg.Unmarshal(entry.Data); err != nil {
+		panic(fmt.Sprintf("%s failed to decode entry %d: %v", d.Tag, entry.Index, err))
+	}
+	cb := d.takeProposal(entry)
+	var resp *raft_cmdpb.RaftCmdResponse
+	// The region may have changed since the command was proposed, e.g. split
+	// away the keys of the command.
+	if err := util.CheckRegionEpoch(msg, d.Region(), true); err != nil {
+		resp = ErrResp(err)
+	} else if err := util.CheckRequestKeysInRegion(msg, d.Region()); err != nil {
+		resp = ErrResp(err)
+	} else if msg.AdminRequest != nil {
+		resp = d.applyAdmin(ab, entry, msg)
+	} else {
+		resp = d.applyRequests(ab, msg, cb)
+	}
+	BindRespTerm(resp, d.Term())
+	d.callbacks.Done(cb, resp)
+}
+
+// applyRequests applies the normal requests of a command. The reads are only
+// served if the command has a callback, i.e. on the peer which proposed it.
+func (d *peerMsgHandler) applyRequests(ab *applyBatch, msg *raft_cmdpb.RaftCmdRequest, cb *message.Callback) *raft_cmdpb.RaftCmdResponse {
+	resp := newCmdResp()
+	wb := ab.writeBatch()
+	for _, req := range msg.Requests {
+		switch req.CmdType {
+		case raft_cmdpb.CmdType_Put:
+			put := req.Put
+			wb.SetCF(put.Cf, put.Key, put.Value)
+			d.maybeCommit(ab)
+			d.SizeDiffHint += uint64(len(put.Key) + len(put.Value))
+			resp.Responses = append(resp.Responses, &raft_cmdpb.Response{
+				CmdType: raft_cmdpb.CmdType_Put,
+				Put:     &raft_cmdpb.PutResponse{},
+			})
+		case raft_cmdpb.CmdType_Delete:
+			del := req.Delete
+			wb.DeleteCF(del.Cf, del.Key)
+			d.maybeCommit(ab)
+			d.SizeDiffHint += uint64(len(del.Key))
+			resp.Responses = append(resp.Responses, &raft_cmdpb.Response{
+				CmdType: raft_cmdpb.CmdType_Delete,
+				Delete:  &raft_cmdpb.DeleteResponse{},
+			})
+		case raft_cmdpb.CmdType_Get:
+			var value []byte
+			if cb != nil {
+				// The read must see the writes applied before it.
+				d.mustCommit(ab)
+				var err error
+				value, err = engine_util.GetCF(d.ctx.engine.Kv, req.Get.Cf, req.Get.Key)
+				if err != nil && err != badger.ErrKeyNotFound {
+					return ErrResp(err)
+				}
+			}
+			resp.Responses = append(resp.Responses, &raft_cmdpb.Response{
+				CmdType: raft_cmdpb.CmdType_Get,
+				Get:     &raft_cmdpb.GetResponse{Value: value},
+			})
+		case raft_cmdpb.CmdType_Snap:
+			region := new(metapb.Region)
+			if err := util.CloneMsg(d.Region(), region); err != nil {
+				return ErrResp(err)
+			}
+			if cb != nil && cb.Txn == nil {
+				d.mustCommit(ab)
+				cb.Txn = d.ctx.engine.Kv.NewTransaction(false)
+			}
+			resp.Responses = append(resp.Responses, &raft_cmdpb.Response{
+				CmdType: raft_cmdpb.CmdType_Snap,
+				Snap:    &raft_cmdpb.SnapResponse{Region: region},
+			})
+		}
+	}
+	return resp
+}
+
+// mustCommit commits the apply batch, a failure to persist applied entries
+// can't be recovered from.
+func (d *peerMsgHandler) mustCommit(ab *applyBatch) {
+	if err := ab.commit(); err != nil {
+		panic(fmt.Sprintf("%s failed to persist applied entries: %v", d.Tag, err))
+	}
+}
+
+// maybeCommit commits the apply batch once its writes exceed
+// ApplyMaxWriteBatchSize, so a large apply is written in several batches.
+func (d *peerMsgHandler) maybeCommit(ab *applyBatch) {
+	if err := ab.maybeCommit(); err != nil {
+		panic(fmt.Sprintf("%s failed to persist applied entries: %v", d.Tag, err))
+	}
+}
+
+func (d *peerMsgHandler) applyAdmin(ab *applyBatch, entry *eraftpb.Entry, msg *raft_cmdpb.RaftCmdRequest) *raft_cmdpb.RaftCmdResponse {
+	req := msg.AdminRequest
+	resp := newCmdResp()
+	switch req.CmdType {
+	case raft_cmdpb.AdminCmdType_CompactLog:
+		compactLog := req.CompactLog
+		if compactLog.CompactIndex > ab.state.TruncatedState.Index {
+			ab.truncate(compactLog.CompactIndex, compactLog.CompactTerm)
+			// The log is only deleted once its truncation is persisted.
+			ab.advance(entry)
+			d.mustCommit(ab)
+			d.ScheduleCompactLog(compactLog.CompactIndex)
+		}
+		resp.AdminResponse = &raft_cmdpb.AdminResponse{
+			CmdType:    raft_cmdpb.AdminCmdType_CompactLog,
+			CompactLog: &raft_cmdpb.CompactLogResponse{},
+		}
+	case raft_cmdpb.AdminCmdType_Split:
+		return d.applySplit(ab, entry, req.Split)
+	default:
+		return ErrResp(errors.Errorf("%s unexpected admin command %s", d.Tag, req.CmdType))
+	}
+	return resp
+}
+
+// applyConfChange applies a ChangePeer command, the raft group is always told
+// about the conf change, an empty one if the command is rejected.
+func (d *peerMsgHandler) applyConfChange(ab *applyBatch, entry *eraftpb.Entry) {
+	var cc eraftpb.ConfChange
+	if err := cc.Unmarshal(entry.Data); err != nil {
+		panic(fmt.Sprintf("%s failed to decode conf change %d: %v", d.Tag, entry.Index, err))
+	}
+	msg := new(raft_cmdpb.RaftCmdRequest)
+	if err := msg.Unmarshal(cc.Context); err != nil {
+		panic(fmt.Sprintf("%s failed to decode conf change %d: %v", d.Tag, entry.Index, err))
+	}
+	cb := d.takeProposal(entry)
+	if err := util.CheckRegionEpoch(msg, d.Region(), true); err != nil {
+		d.RaftGroup.ApplyConfChange(eraftpb.ConfChange{})
+		d.callbacks.Done(cb, ErrResp(err))
+		return
+	}
+
+	region := new(metapb.Region)
+	if err := util.CloneMsg(d.Region(), region); err != nil {
+		panic(err)
+	}
+	peer := msg.AdminRequest.ChangePeer.Peer
+	switch cc.ChangeType {
+	case eraftpb.ConfChangeType_AddNode:
+		if util.FindPeer(region, peer.StoreId) == nil {
+			region.Peers = append(region.Peers, peer)
+		}
+		d.insertPeerCache(peer)
+	case eraftpb.ConfChangeType_RemoveNode:
+		if p := util.FindPeer(region, peer.StoreId); p != nil && p.Id == peer.Id {
+			util.RemovePeer(region, peer.StoreId)
+		}
+		d.removePeerCache(peer.Id)
+	}
+	region.RegionEpoch.ConfVer++
+	log.Infof("%s applies %s of peer %v, region %v", d.Tag, cc.ChangeType, peer, region)
+
+	meta.WriteRegionState(ab.writeBatch(), region, rspb.PeerState_Normal)
+	ab.advance(entry)
+	d.mustCommit(ab)
+	storeMeta := d.ctx.storeMeta
+	storeMeta.Lock()
+	storeMeta.regionRanges.ReplaceOrInsert(&regionItem{region: region})
+	storeMeta.setRegion(region, d.peer)
+	storeMeta.Unlock()
+	d.RaftGroup.ApplyConfChange(cc)
+
+	resp := newCmdResp()
+	resp.AdminResponse = &raft_cmdpb.AdminResponse{
+		CmdType:    raft_cmdpb.AdminCmdType_ChangePeer,
+		ChangePeer: &raft_cmdpb.ChangePeerResponse{Region: region},
+	}
+	BindRespTerm(resp, d.Term())
+	d.callbacks.Done(cb, resp)
+	if d.IsLeader() {
+		d.HeartbeatScheduler(d.ctx.schedulerTaskSender)
+	}
+}
+
+// applySplit splits the region at the split key, the peer keeps the left part
+// and a peer of the new region is created for the right one.
+func (d *peerMsgHandler) applySplit(ab *applyBatch, entry *eraftpb.Entry, split *raft_cmdpb.SplitRequest) *raft_cmdpb.RaftCmdResponse {
+	if err := util.CheckKeyInRegionExclusive(split.SplitKey, d.Region()); err != nil {
+		return ErrResp(err)
+	}
+	region := new(metapb.Region)
+	if err := util.CloneMsg(d.Region(), region); err != nil {
+		panic(err)
+	}
+	if len(split.NewPeerIds) != len(region.Peers) {
+		return ErrResp(errors.Errorf("%s split needs %d peer ids, got %d",
+			d.Tag, len(region.Peers), len(split.NewPeerIds)))
+	}
+	region.RegionEpoch.Version++
+	newRegion := &metapb.Region{
+		Id:       split.NewRegionId,
+		StartKey: split.SplitKey,
+		EndKey:   region.EndKey,
+		RegionEpoch: &metapb.RegionEpoch{
+			ConfVer: region.RegionEpoch.ConfVer,
+			Version: region.RegionEpoch.Version,
+		},
+	}
+	for i, p := range region.Peers {
+		newRegion.Peers = append(newRegion.Peers, &metapb.Peer{Id: split.NewPeerIds[i], StoreId: p.StoreId})
+	}
+	region.EndKey = split.SplitKey
+	log.Infof("%s splits at %v into %v and %v", d.Tag, split.SplitKey, region, newRegion)
+
+	meta.WriteRegionState(ab.writeBatch(), region, rspb.PeerState_Normal)
+	meta.WriteRegionState(ab.writeBatch(), newRegion, rspb.PeerState_Normal)
+	ab.advance(entry)
+	d.mustCommit(ab)
+	d.SizeDiffHint = 0
+	d.ApproximateSize = nil
+
+	storeMeta := d.ctx.storeMeta
+	storeMeta.Lock()
+	storeMeta.regionRanges.ReplaceOrInsert(&regionItem{region: region})
+	storeMeta.setRegion(region, d.peer)
+	var newPeer *peer
+	if _, ok := storeMeta.regions[newRegion.Id]; ok {
+		// A peer of the new region was created by a message of another
+		// store, it gets the data with a snapshot.
+		log.Infof("%s peer of split region %d exists already", d.Tag, newRegion.Id)
+	} else {
+		var err error
+		newPeer, err = createPeer(d.storeID(), d.ctx.cfg, d.ctx.regionTaskSender, d.ctx.engine, newRegion)
+		if err != nil {
+			panic(fmt.Sprintf("%s failed to create peer of split region %d: %v", d.Tag, newRegion.Id, err))
+		}
+		newPeer.MaybeCampaign(d.IsLeader())
+		storeMeta.regionRanges.ReplaceOrInsert(&regionItem{region: newRegion})
+		storeMeta.regions[newRegion.Id] = newRegion
+		d.ctx.router.register(newPeer)
+		_ = d.ctx.router.send(newRegion.Id, message.Msg{Type: message.MsgTypeStart})
+		votes := storeMeta.pendingVotes[:0]
+		for _, vote := range storeMeta.pendingVotes {
+			if vote.RegionId == newRegion.Id {
+				_ = d.ctx.router.send(newRegion.Id, message.Msg{Type: message.MsgTypeRaftMessage, Data: vote})
+			} else {
+				votes = append(votes, vote)
+			}
+		}
+		storeMeta.pendingVotes = votes
+	}
+	storeMeta.Unlock()
+	if d.IsLeader() {
+		d.HeartbeatScheduler(d.ctx.schedulerTaskSender)
+		if newPeer != nil {
+			newPeer.HeartbeatScheduler(d.ctx.schedulerTaskSender)
+		}
+	}
+
+	resp := newCmdResp()
+	resp.AdminResponse = &raft_cmdpb.AdminResponse{
+		CmdType: raft_cmdpb.AdminCmdType_Split,
+		Split:   &raft_cmdpb.SplitResponse{Regions: []*metapb.Region{region, newRegion}},
+	}
+	return resp
+}
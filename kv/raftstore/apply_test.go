@@ -0,0 +1,79 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyBatchPersistsStateWithData(t *testing.T) {
+	ents := []eraftpb.Entry{
+		newTestEntry(3, 3), newTestEntry(4, 4), newTestEntry(5, 5),
+	}
+	peerStore := newTestPeerStorageFromEnts(t, ents)
+	defer cleanUpTestData(peerStore)
+
+	ab := newApplyBatch(peerStore, 0)
+	replayed := newTestEntry(5, 5)
+	assert.True(t, ab.alreadyApplied(&replayed))
+
+	entry := newTestEntry(6, 5)
+	require.False(t, ab.alreadyApplied(&entry))
+	ab.writeBatch().SetCF(engine_util.CfDefault, []byte("k"), []byte("v"))
+	ab.advance(&entry)
+	ab.truncate(4, 4)
+	// Nothing is visible before the batch is committed.
+	assert.Equal(t, uint64(5), peerStore.AppliedIndex())
+	assert.Equal(t, uint64(3), peerStore.truncatedIndex())
+
+	require.Nil(t, ab.commit())
+	assert.Equal(t, uint64(6), peerStore.AppliedIndex())
+	assert.Equal(t, uint64(4), peerStore.truncatedIndex())
+	assert.True(t, ab.alreadyApplied(&entry))
+
+	state, err := meta.GetApplyState(peerStore.Engines.Kv, peerStore.region.GetId())
+	require.Nil(t, err)
+	assert.Equal(t, uint64(6), state.AppliedIndex)
+	assert.Equal(t, uint64(4), state.TruncatedState.Index)
+	val, err := engine_util.GetCF(peerStore.Engines.Kv, engine_util.CfDefault, []byte("k"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("v"), val)
+}
+
+func TestApplyBatchSplitsLargeApply(t *testing.T) {
+	ents := []eraftpb.Entry{
+		newTestEntry(3, 3), newTestEntry(4, 4), newTestEntry(5, 5),
+	}
+	peerStore := newTestPeerStorageFromEnts(t, ents)
+	defer cleanUpTestData(peerStore)
+
+	ab := newApplyBatch(peerStore, 10)
+	entry := newTestEntry(6, 5)
+	ab.writeBatch().SetCF(engine_util.CfDefault, []byte("k1"), []byte("v1"))
+	require.Nil(t, ab.maybeCommit())
+	// Below the limit, nothing is written yet.
+	_, err := engine_util.GetCF(peerStore.Engines.Kv, engine_util.CfDefault, []byte("k1"))
+	assert.NotNil(t, err)
+
+	ab.writeBatch().SetCF(engine_util.CfDefault, []byte("k2"), []byte("v2"))
+	ab.writeBatch().SetCF(engine_util.CfDefault, []byte("k3"), []byte("v3"))
+	require.Nil(t, ab.maybeCommit())
+	// The writes of the entry are written, but the entry is not applied yet,
+	// so it is applied again after a crash.
+	val, err := engine_util.GetCF(peerStore.Engines.Kv, engine_util.CfDefault, []byte("k1"))
+	require.Nil(t, err)
+	assert.Equal(t, []byte("v1"), val)
+	assert.Equal(t, uint64(5), peerStore.AppliedIndex())
+	assert.False(t, ab.alreadyApplied(&entry))
+
+	ab.advance(&entry)
+	require.Nil(t, ab.commit())
+	assert.Equal(t, uint64(6), peerStore.AppliedIndex())
+	state, err := meta.GetApplyState(peerStore.Engines.Kv, peerStore.region.GetId())
+	require.Nil(t, err)
+	assert.Equal(t, uint64(6), state.AppliedIndex)
+}
@@ -39,10 +39,17 @@ func isRangeEmpty(engine *badger.DB, startKey, endKey []byte) (bool, error) {
 
 func BootstrapStore(engines *engine_util.Engines, clusterID, storeID uint64) error {
 	ident := new(rspb.StoreIdent)
-	empty, err := isRangeEmpty(engines.Kv, meta.MinKey, meta.MaxKey)
+	migrating, err := IsMigratingStandalone(engines)
 	if err != nil {
 		return err
 	}
+	empty := migrating
+	if !migrating {
+		empty, err = isRangeEmpty(engines.Kv, meta.MinKey, meta.MaxKey)
+		if err != nil {
+			return err
+		}
+	}
 	if !empty {
 		return errors.New("kv store is not empty and has already had data.")
 	}
@@ -148,7 +155,52 @@ func ClearPrepareBootstrap(engines *engine_util.Engines, regionID uint64) error
 
 func ClearPrepareBootstrapState(engines *engine_util.Engines) error {
 	err := engines.Kv.Update(func(txn *badger.Txn) error {
+		if err := txn.Delete(meta.MigrateStandaloneKey); err != nil {
+			return err
+		}
 		return txn.Delete(meta.PrepareBootstrapKey)
 	})
 	return errors.WithStack(err)
 }
+
+// PrepareStandaloneMigration marks the kv engine holding the data of a
+// standalone instance so the store started on it bootstraps a new cluster
+// whose first region, covering the whole key space, holds that data. The
+// data is kept as is, the standalone instance stores it with the same
+// encoding as the kv engine of a raft store.
+func PrepareStandaloneMigration(engines *engine_util.Engines) error {
+	empty, err := isRangeEmpty(engines.Kv, meta.LocalMinKey, meta.LocalMaxKey)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return errors.New("kv store already has raft metadata, it is not a standalone dataset")
+	}
+	empty, err = isRangeEmpty(engines.Raft, meta.MinKey, meta.MaxKey)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return errors.New("raft store is not empty and has already had data.")
+	}
+	err = engines.Kv.Update(func(txn *badger.Txn) error {
+		return txn.Set(meta.MigrateStandaloneKey, []byte{})
+	})
+	return errors.WithStack(err)
+}
+
+// IsMigratingStandalone returns whether the store holds a standalone dataset
+// which didn't bootstrap its cluster yet.
+func IsMigratingStandalone(engines *engine_util.Engines) (bool, error) {
+	err := engines.Kv.View(func(txn *badger.Txn) error {
+		_, err := txn.Get(meta.MigrateStandaloneKey)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
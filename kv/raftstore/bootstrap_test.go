@@ -36,3 +36,27 @@ func TestBootstrapStore(t *testing.T) {
 	require.Nil(t, err)
 	require.True(t, empty)
 }
+
+func TestStandaloneMigration(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+	require.Nil(t, engine_util.PutCF(engines.Kv, engine_util.CfDefault, []byte("k"), []byte("v")))
+	require.NotNil(t, BootstrapStore(engines, 1, 1))
+
+	require.Nil(t, PrepareStandaloneMigration(engines))
+	migrating, err := IsMigratingStandalone(engines)
+	require.Nil(t, err)
+	require.True(t, migrating)
+	require.Nil(t, BootstrapStore(engines, 1, 1))
+	require.NotNil(t, PrepareStandaloneMigration(engines))
+	_, err = PrepareBootstrap(engines, 1, 1, 1)
+	require.Nil(t, err)
+
+	require.Nil(t, ClearPrepareBootstrapState(engines))
+	migrating, err = IsMigratingStandalone(engines)
+	require.Nil(t, err)
+	require.False(t, migrating)
+	val, err := engine_util.GetCF(engines.Kv, engine_util.CfDefault, []byte("k"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("v"), val)
+}
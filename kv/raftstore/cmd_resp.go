@@ -1,7 +1,7 @@
 package raftstore
 
 import (
-	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 )
@@ -23,7 +23,7 @@ func BindRespTerm(resp *raft_cmdpb.RaftCmdResponse, term uint64) {
 
 func BindRespError(resp *raft_cmdpb.RaftCmdResponse, err error) {
 	ensureRespHeader(resp)
-	resp.Header.Error = util.RaftstoreErrToPbError(err)
+	resp.Header.Error = kverrors.ToRegionError(err)
 }
 
 func ErrResp(err error) *raft_cmdpb.RaftCmdResponse {
@@ -39,7 +39,7 @@ func ErrRespWithTerm(err error, term uint64) *raft_cmdpb.RaftCmdResponse {
 }
 
 func ErrRespStaleCommand(term uint64) *raft_cmdpb.RaftCmdResponse {
-	return ErrRespWithTerm(new(util.ErrStaleCommand), term)
+	return ErrRespWithTerm(&kverrors.ErrStaleCommand{Class: errorpb.ErrorClass_LeaderChanging}, term)
 }
 
 func ErrRespRegionNotFound(regionID uint64) *raft_cmdpb.RaftCmdResponse {
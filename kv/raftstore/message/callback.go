@@ -8,9 +8,19 @@ import (
 )
 
 type Callback struct {
-	Resp *raft_cmdpb.RaftCmdResponse
-	Txn  *badger.Txn // used for GetSnap
-	done chan struct{}
+	Resp   *raft_cmdpb.RaftCmdResponse
+	Txn    *badger.Txn // used for GetSnap
+	Timing Timing
+	done   chan struct{}
+}
+
+// Timing records when a command reached each stage of the raftstore, the
+// stages it skipped are left zero.
+type Timing struct {
+	Created   time.Time
+	Handled   time.Time
+	Committed time.Time
+	Done      time.Time
 }
 
 func (cb *Callback) Done(resp *raft_cmdpb.RaftCmdResponse) {
@@ -20,9 +30,24 @@ func (cb *Callback) Done(resp *raft_cmdpb.RaftCmdResponse) {
 	if resp != nil {
 		cb.Resp = resp
 	}
+	cb.Timing.Done = time.Now()
 	cb.done <- struct{}{}
 }
 
+// MarkHandled records that the raft worker took the command off its queue.
+func (cb *Callback) MarkHandled() {
+	if cb != nil && cb.Timing.Handled.IsZero() {
+		cb.Timing.Handled = time.Now()
+	}
+}
+
+// MarkCommitted records that the command was committed and is being applied.
+func (cb *Callback) MarkCommitted() {
+	if cb != nil && cb.Timing.Committed.IsZero() {
+		cb.Timing.Committed = time.Now()
+	}
+}
+
 func (cb *Callback) WaitResp() *raft_cmdpb.RaftCmdResponse {
 	select {
 	case <-cb.done:
@@ -39,8 +64,41 @@ func (cb *Callback) WaitRespWithTimeout(timeout time.Duration) *raft_cmdpb.RaftC
 	}
 }
 
+// CallbackBatch collects the responses of the commands handled in one round of
+// the raft worker and completes their callbacks together in Flush, so waiting
+// clients are woken up once the round is over rather than while it still runs.
+type CallbackBatch struct {
+	done []callbackResp
+}
+
+type callbackResp struct {
+	cb   *Callback
+	resp *raft_cmdpb.RaftCmdResponse
+}
+
+// Done records resp for cb. On a nil batch cb is completed right away.
+func (b *CallbackBatch) Done(cb *Callback, resp *raft_cmdpb.RaftCmdResponse) {
+	if b == nil {
+		cb.Done(resp)
+		return
+	}
+	if cb == nil {
+		return
+	}
+	b.done = append(b.done, callbackResp{cb: cb, resp: resp})
+}
+
+// Flush completes all the recorded callbacks and empties the batch.
+func (b *CallbackBatch) Flush() {
+	for i := range b.done {
+		b.done[i].cb.Done(b.done[i].resp)
+		b.done[i] = callbackResp{}
+	}
+	b.done = b.done[:0]
+}
+
 func NewCallback() *Callback {
 	done := make(chan struct{}, 1)
-	cb := &Callback{done: done}
+	cb := &Callback{done: done, Timing: Timing{Created: time.Now()}}
 	return cb
 }
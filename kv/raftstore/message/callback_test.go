@@ -0,0 +1,30 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackBatch(t *testing.T) {
+	b := new(CallbackBatch)
+	cb1, cb2 := NewCallback(), NewCallback()
+	resp1, resp2 := &raft_cmdpb.RaftCmdResponse{}, &raft_cmdpb.RaftCmdResponse{}
+	b.Done(cb1, resp1)
+	b.Done(cb2, resp2)
+	b.Done(nil, resp2)
+	require.Nil(t, cb1.WaitRespWithTimeout(10*time.Millisecond))
+
+	b.Flush()
+	require.True(t, cb1.WaitResp() == resp1)
+	require.True(t, cb2.WaitResp() == resp2)
+	require.Len(t, b.done, 0)
+
+	// A nil batch completes the callback right away.
+	var nilBatch *CallbackBatch
+	cb3 := NewCallback()
+	nilBatch.Done(cb3, resp1)
+	require.True(t, cb3.WaitResp() == resp1)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap-incubator/tinykv/raft"
 )
 
 type MsgType int64
@@ -30,6 +31,10 @@ const (
 	MsgTypeRegionApproximateSize MsgType = 6
 	// message to trigger gc generated snapshots
 	MsgTypeGcSnap MsgType = 7
+	// message to report that a raft message could not be sent to a peer
+	MsgTypeUnreachable MsgType = 8
+	// message to report the outcome of sending a snapshot to a peer
+	MsgTypeSnapshotStatus MsgType = 9
 
 	// message wraps a raft message to the peer not existing on the Store.
 	// It is due to region split or add peer conf change
@@ -54,10 +59,31 @@ func NewPeerMsg(tp MsgType, regionID uint64, data interface{}) Msg {
 	return Msg{Type: tp, RegionID: regionID, Data: data}
 }
 
+// MsgTick carries the ticks of a region which became due since the last tick
+// message. Stalled is set when the tick driver itself woke up late, so the
+// ticks were missed by the whole store rather than elapsed normally.
+type MsgTick struct {
+	Ticks   int
+	Stalled bool
+}
+
 type MsgGCSnap struct {
 	Snaps []snap.SnapKeyWithSending
 }
 
+// MsgUnreachable reports that the transport failed to send a raft message to
+// the peer.
+type MsgUnreachable struct {
+	ToPeerID uint64
+}
+
+// MsgSnapshotStatus reports whether the snapshot sent to the peer was
+// delivered.
+type MsgSnapshotStatus struct {
+	ToPeerID uint64
+	Status   raft.SnapshotStatus
+}
+
 type MsgRaftCmd struct {
 	Request  *raft_cmdpb.RaftCmdRequest
 	Callback *Callback
@@ -41,6 +41,9 @@ var (
 	// Following keys are all local keys, so the first byte must be 0x01.
 	PrepareBootstrapKey = []byte{LocalPrefix, 0x01}
 	StoreIdentKey       = []byte{LocalPrefix, 0x02}
+	// MigrateStandaloneKey marks the kv engine of a store converted from a
+	// standalone dataset, which bootstraps a new cluster with its data.
+	MigrateStandaloneKey = []byte{LocalPrefix, 0x03}
 )
 
 func makeRegionPrefix(regionID uint64, suffix byte) []byte {
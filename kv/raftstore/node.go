@@ -118,6 +118,13 @@ func (n *Node) checkOrPrepareBootstrapCluster(ctx context.Context, engines *engi
 		return nil, err
 	}
 	if bootstrapped {
+		migrating, err := IsMigratingStandalone(engines)
+		if err != nil {
+			return nil, err
+		}
+		if migrating {
+			return nil, errors.Errorf("cluster %d is already bootstrapped, standalone data can only bootstrap a new cluster", n.clusterID)
+		}
 		return nil, nil
 	}
 	return n.prepareBootstrapCluster(ctx, engines, storeID)
@@ -181,6 +188,14 @@ func (n *Node) BootstrapCluster(ctx context.Context, engines *engine_util.Engine
 			if region.GetId() == regionID {
 				return false, ClearPrepareBootstrapState(engines)
 			}
+			migrating, err := IsMigratingStandalone(engines)
+			if err != nil {
+				return false, err
+			}
+			if migrating {
+				// Clearing the first region would leave the data without a region.
+				return false, errors.Errorf("cluster %d was bootstrapped by another store, standalone data can only bootstrap a new cluster", n.clusterID)
+			}
 			log.Infof("cluster is already bootstrapped, clusterID: %v", n.clusterID)
 			return false, ClearPrepareBootstrap(engines, regionID)
 		}
@@ -10,10 +10,12 @@ import (
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/runner"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 	"github.com/pingcap-incubator/tinykv/raft"
 	"github.com/pingcap/errors"
@@ -24,7 +26,7 @@ func NotifyStaleReq(term uint64, cb *message.Callback) {
 }
 
 func NotifyReqRegionRemoved(regionId uint64, cb *message.Callback) {
-	regionNotFound := &util.ErrRegionNotFound{RegionId: regionId}
+	regionNotFound := &kverrors.ErrRegionNotFound{RegionId: regionId}
 	resp := ErrResp(regionNotFound)
 	cb.Done(resp)
 }
@@ -63,6 +65,15 @@ type proposal struct {
 	cb    *message.Callback
 }
 
+// applyWaiter is a request waiting for the peer to apply up to index, it is
+// rejected if the peer hasn't caught up by the deadline.
+type applyWaiter struct {
+	index    uint64
+	deadline time.Time
+	req      *raft_cmdpb.RaftCmdRequest
+	cb       *message.Callback
+}
+
 type peer struct {
 	// The ticker of the peer, used to trigger
 	// * raft tick
@@ -84,6 +95,8 @@ type peer struct {
 	// Record the callback of the proposals
 	// (Used in 2B)
 	proposals []*proposal
+	// Requests held back until the peer has applied the index they ask for.
+	applyWaiters []*applyWaiter
 
 	// Index of last scheduled compacted raft log.
 	// (Used in 2C)
@@ -126,11 +139,21 @@ func NewPeer(storeId uint64, cfg *config.Config, engines *engine_util.Engines, r
 	appliedIndex := ps.AppliedIndex()
 
 	raftCfg := &raft.Config{
-		ID:            meta.GetId(),
-		ElectionTick:  cfg.RaftElectionTimeoutTicks,
-		HeartbeatTick: cfg.RaftHeartbeatTicks,
-		Applied:       appliedIndex,
-		Storage:       ps,
+		ID:              meta.GetId(),
+		ElectionTick:    cfg.RaftElectionTimeoutTicks,
+		HeartbeatTick:   cfg.RaftHeartbeatTicks,
+		Applied:         appliedIndex,
+		Storage:         ps,
+		PreVote:         cfg.RaftPreVote,
+		MaxSizePerMsg:   cfg.RaftMaxSizePerMsg,
+		MaxInflightMsgs: cfg.RaftMaxInflightMsgs,
+		MaxProbeBackoff: cfg.RaftMaxProbeBackoff,
+
+		MaxCommittedSizePerReady:  cfg.RaftMaxCommittedSizePerReady,
+		MaxEntrySize:              cfg.RaftMaxEntrySize,
+		MaxUncommittedEntriesSize: cfg.RaftMaxUncommittedSize,
+		EntryChecksums:            cfg.RaftEntryChecksums,
+		BatchProposals:            cfg.RaftBatchProposals,
 	}
 
 	raftGroup, err := raft.NewRawNode(raftCfg)
@@ -227,6 +250,10 @@ func (p *peer) Destroy(engine *engine_util.Engines, keepData bool) error {
 		NotifyReqRegionRemoved(region.Id, proposal.cb)
 	}
 	p.proposals = nil
+	for _, waiter := range p.applyWaiters {
+		NotifyReqRegionRemoved(region.Id, waiter.cb)
+	}
+	p.applyWaiters = nil
 
 	log.Infof("%v destroy itself, takes %v", p.Tag, time.Now().Sub(start))
 	return nil
@@ -9,10 +9,14 @@ import (
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/runner"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap-incubator/tinykv/raft"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/btree"
 	"github.com/pingcap/errors"
 )
@@ -29,12 +33,16 @@ const (
 type peerMsgHandler struct {
 	*peer
 	ctx *GlobalContext
+	// callbacks collects the responses to deliver at the end of the raft
+	// worker round.
+	callbacks *message.CallbackBatch
 }
 
-func newPeerMsgHandler(peer *peer, ctx *GlobalContext) *peerMsgHandler {
+func newPeerMsgHandler(peer *peer, ctx *GlobalContext, callbacks *message.CallbackBatch) *peerMsgHandler {
 	return &peerMsgHandler{
-		peer: peer,
-		ctx:  ctx,
+		peer:      peer,
+		ctx:       ctx,
+		callbacks: callbacks,
 	}
 }
 
@@ -42,7 +50,43 @@ func (d *peerMsgHandler) HandleRaftReady() {
 	if d.stopped {
 		return
 	}
-	// Your Code Here (2B).
+	// A log-only store keeps committed entries in the log until it is promoted.
+	d.RaftGroup.PauseApply(d.ctx.isLogOnly())
+	if !d.RaftGroup.HasReady() {
+		d.releaseApplyWaiters()
+		return
+	}
+	rd := d.RaftGroup.Ready()
+	result, err := d.peerStorage.SaveReadyState(&rd)
+	if err != nil {
+		panic(fmt.Sprintf("%s failed to save ready state: %v", d.Tag, err))
+	}
+	if result != nil {
+		d.onSnapshotApplied(result)
+	}
+	d.Send(d.ctx.trans, rd.Messages)
+	if len(rd.CommittedEntries) > 0 {
+		d.applyEntries(rd.CommittedEntries)
+	}
+	d.RaftGroup.Advance(rd)
+	if rd.SoftState != nil && rd.SoftState.Removed {
+		// The peer applied its own removal, it is out of the region.
+		d.destroyPeer()
+		return
+	}
+	d.releaseApplyWaiters()
+}
+
+// onSnapshotApplied updates the store meta with the region of the snapshot.
+func (d *peerMsgHandler) onSnapshotApplied(result *ApplySnapResult) {
+	storeMeta := d.ctx.storeMeta
+	storeMeta.Lock()
+	defer storeMeta.Unlock()
+	if len(result.PrevRegion.GetPeers()) > 0 {
+		storeMeta.regionRanges.Delete(&regionItem{region: result.PrevRegion})
+	}
+	storeMeta.regionRanges.ReplaceOrInsert(&regionItem{region: result.Region})
+	storeMeta.setRegion(result.Region, d.peer)
 }
 
 func (d *peerMsgHandler) HandleMsg(msg message.Msg) {
@@ -56,7 +100,7 @@ func (d *peerMsgHandler) HandleMsg(msg message.Msg) {
 		raftCMD := msg.Data.(*message.MsgRaftCmd)
 		d.proposeRaftCommand(raftCMD.Request, raftCMD.Callback)
 	case message.MsgTypeTick:
-		d.onTick()
+		d.onTick(tickBatch(msg))
 	case message.MsgTypeSplitRegion:
 		split := msg.Data.(*message.MsgSplitRegion)
 		log.Infof("%s on split with %v", d.Tag, split.SplitKey)
@@ -68,6 +112,11 @@ func (d *peerMsgHandler) HandleMsg(msg message.Msg) {
 		d.onGCSnap(gcSnap.Snaps)
 	case message.MsgTypeStart:
 		d.startTicker()
+	case message.MsgTypeUnreachable:
+		d.RaftGroup.ReportUnreachable(msg.Data.(*message.MsgUnreachable).ToPeerID)
+	case message.MsgTypeSnapshotStatus:
+		status := msg.Data.(*message.MsgSnapshotStatus)
+		d.RaftGroup.ReportSnapshot(status.ToPeerID, status.Status)
 	}
 }
 
@@ -82,7 +131,7 @@ func (d *peerMsgHandler) preProposeRaftCommand(req *raft_cmdpb.RaftCmdRequest) e
 	leaderID := d.LeaderId()
 	if !d.IsLeader() {
 		leader := d.getPeerFromCache(leaderID)
-		return &util.ErrNotLeader{RegionId: regionID, Leader: leader}
+		return &kverrors.ErrNotLeader{RegionId: regionID, Leader: leader}
 	}
 	// peer_id must be the same as peer's.
 	if err := util.CheckPeerID(req, d.PeerId()); err != nil {
@@ -90,10 +139,13 @@ func (d *peerMsgHandler) preProposeRaftCommand(req *raft_cmdpb.RaftCmdRequest) e
 	}
 	// Check whether the term is stale.
 	if err := util.CheckTerm(req, d.Term()); err != nil {
+		if stale, ok := err.(*kverrors.ErrStaleCommand); ok {
+			stale.RetryAfter = d.retryAfter()
+		}
 		return err
 	}
 	err := util.CheckRegionEpoch(req, d.Region(), true)
-	if errEpochNotMatching, ok := err.(*util.ErrEpochNotMatch); ok {
+	if errEpochNotMatching, ok := err.(*kverrors.ErrEpochNotMatch); ok {
 		// Attach the region which might be split from the current region. But it doesn't
 		// matter if the region is not split from the current region. If the region meta
 		// received by the TiKV driver is newer than the meta cached in the driver, the meta is
@@ -104,34 +156,227 @@ func (d *peerMsgHandler) preProposeRaftCommand(req *raft_cmdpb.RaftCmdRequest) e
 		}
 		return errEpochNotMatching
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	if err := util.CheckRequestCFs(req); err != nil {
+		return err
+	}
+	if err := util.CheckRequestKeysInRegion(req, d.Region()); err != nil {
+		return err
+	}
+	if d.ctx.isLogOnly() {
+		return &kverrors.ErrServerIsBusy{
+			Reason:     "store is a log-only standby",
+			RetryAfter: d.retryAfter(),
+			Class:      errorpb.ErrorClass_ReadOnly,
+		}
+	}
+	if d.ctx.isReadOnly() && util.IsWriteRequest(req) {
+		return &kverrors.ErrServerIsBusy{
+			Reason:     "store is read-only",
+			RetryAfter: d.retryAfter(),
+			Class:      errorpb.ErrorClass_ReadOnly,
+		}
+	}
+	return nil
+}
+
+// maxQueueBackoffFactor bounds how much a full raft worker queue stretches the
+// retry hint returned to clients.
+const maxQueueBackoffFactor = 10
+
+// retryAfter estimates how long a client should wait before retrying a
+// rejected request. It is a base tick, or an election timeout while the region
+// has no leader, stretched by how full the raft worker queue is.
+func (d *peerMsgHandler) retryAfter() time.Duration {
+	cfg := d.ctx.cfg
+	backoff := cfg.RaftBaseTickInterval
+	if d.LeaderId() == raft.None {
+		backoff *= time.Duration(cfg.RaftElectionTimeoutTicks)
+	}
+	queue := d.ctx.router.queue(d.regionId)
+	if c := cap(queue); c > 0 {
+		backoff += backoff * time.Duration(maxQueueBackoffFactor*len(queue)/c)
+	}
+	return backoff
 }
 
 func (d *peerMsgHandler) proposeRaftCommand(msg *raft_cmdpb.RaftCmdRequest, cb *message.Callback) {
+	cb.MarkHandled()
 	err := d.preProposeRaftCommand(msg)
 	if err != nil {
-		cb.Done(ErrResp(err))
+		d.callbacks.Done(cb, ErrResp(err))
 		return
 	}
-	// Your Code Here (2B).
+	if d.waitApplied(msg, cb) {
+		return
+	}
+	if cmdType := msg.GetAdminRequest().GetCmdType(); isRegionChange(cmdType) {
+		if err := d.peerStorage.checkRegionChange(); err != nil {
+			d.callbacks.Done(cb, ErrResp(&kverrors.ErrServerIsBusy{
+				Reason:     err.Error(),
+				RetryAfter: d.retryAfter(),
+			}))
+			return
+		}
+		d.peerStorage.pendingRegionChange = &regionChange{cmdType: cmdType, index: d.nextProposalIndex()}
+	}
+	if msg.GetAdminRequest().GetCmdType() == raft_cmdpb.AdminCmdType_RebuildPeer {
+		d.onRebuildPeer(msg.AdminRequest.RebuildPeer, cb)
+		return
+	}
+	if msg.GetAdminRequest().GetCmdType() == raft_cmdpb.AdminCmdType_TransferLeader {
+		d.onTransferLeader(msg.AdminRequest.TransferLeader, cb)
+		return
+	}
+	index, term := d.nextProposalIndex(), d.Term()
+	if err := d.propose(msg); err != nil {
+		if d.peerStorage.pendingRegionChange != nil && d.peerStorage.pendingRegionChange.index == index {
+			d.peerStorage.pendingRegionChange = nil
+		}
+		d.callbacks.Done(cb, ErrResp(err))
+		return
+	}
+	d.proposals = append(d.proposals, &proposal{index: index, term: term, cb: cb})
 }
 
-func (d *peerMsgHandler) onTick() {
-	if d.stopped {
+// propose appends the command to the raft log, a ChangePeer command as a conf
+// change carrying the command as its context. A command raft refuses is
+// reported with an error the client can act on.
+func (d *peerMsgHandler) propose(msg *raft_cmdpb.RaftCmdRequest) error {
+	data, err := msg.Marshal()
+	if err != nil {
+		return err
+	}
+	if changePeer := msg.GetAdminRequest().GetChangePeer(); changePeer != nil {
+		err = d.RaftGroup.ProposeConfChange(eraftpb.ConfChange{
+			ChangeType: changePeer.ChangeType,
+			NodeId:     changePeer.Peer.GetId(),
+			Context:    data,
+		})
+	} else {
+		err = d.RaftGroup.Propose(data)
+	}
+	switch err {
+	case raft.ErrProposalTooLarge:
+		return errors.Errorf("command of %d bytes is larger than the raft entry limit of %d bytes",
+			len(data), d.ctx.cfg.RaftMaxEntrySize)
+	case raft.ErrProposalDropped:
+		// Raft drops a proposal while the leadership moves, or once the
+		// uncommitted entries reach RaftMaxUncommittedSize.
+		if d.RaftGroup.Status().LeadTransferee != raft.None || d.LeaderId() == raft.None {
+			return &kverrors.ErrServerIsBusy{
+				Reason:     "region leadership is changing",
+				RetryAfter: d.retryAfter(),
+				Class:      errorpb.ErrorClass_LeaderChanging,
+			}
+		}
+		return &kverrors.ErrServerIsBusy{
+			Reason:     "too many uncommitted raft entries",
+			RetryAfter: d.retryAfter(),
+			Class:      errorpb.ErrorClass_Overloaded,
+		}
+	}
+	return err
+}
+
+// onTransferLeader fails the leader transfer: raft doesn't implement
+// MsgTransferLeader yet, so the leadership would never be handed over.
+func (d *peerMsgHandler) onTransferLeader(req *raft_cmdpb.TransferLeaderRequest, cb *message.Callback) {
+	d.callbacks.Done(cb, ErrResp(errors.Errorf("%s can't transfer leadership to %v: leader transfer is not supported", d.Tag, req.GetPeer())))
+}
+
+// onRebuildPeer resends a snapshot to a follower which drops its state to
+// install it. Like a leader transfer the command is executed right away
+// instead of being proposed.
+func (d *peerMsgHandler) onRebuildPeer(req *raft_cmdpb.RebuildPeerRequest, cb *message.Callback) {
+	peer := req.GetPeer()
+	if p := util.FindPeer(d.Region(), peer.GetStoreId()); p == nil || p.Id != peer.GetId() || p.Id == d.PeerId() {
+		d.callbacks.Done(cb, ErrResp(errors.Errorf("%s can't rebuild peer %v", d.Tag, peer)))
 		return
 	}
-	d.ticker.tickClock()
-	if d.ticker.isOnTick(PeerTickRaft) {
-		d.onRaftBaseTick()
+	log.Infof("%s rebuilds peer %v", d.Tag, peer)
+	d.RaftGroup.RebuildPeer(peer.Id)
+	resp := newCmdResp()
+	resp.AdminResponse = &raft_cmdpb.AdminResponse{
+		CmdType:     raft_cmdpb.AdminCmdType_RebuildPeer,
+		RebuildPeer: &raft_cmdpb.RebuildPeerResponse{},
 	}
-	if d.ticker.isOnTick(PeerTickRaftLogGC) {
-		d.onRaftGCLogTick()
+	d.callbacks.Done(cb, resp)
+}
+
+// waitApplied holds the request back if it asks for an applied index the peer
+// hasn't reached yet, so a client reading after its own write sees the write.
+func (d *peerMsgHandler) waitApplied(msg *raft_cmdpb.RaftCmdRequest, cb *message.Callback) bool {
+	index := msg.GetHeader().GetAppliedIndex()
+	if index <= d.peerStorage.AppliedIndex() {
+		return false
 	}
-	if d.ticker.isOnTick(PeerTickSchedulerHeartbeat) {
-		d.onSchedulerHeartbeatTick()
+	d.applyWaiters = append(d.applyWaiters, &applyWaiter{
+		index:    index,
+		deadline: time.Now().Add(d.ctx.cfg.MaxApplyWait),
+		req:      msg,
+		cb:       cb,
+	})
+	return true
+}
+
+// releaseApplyWaiters proposes the held back requests the peer has caught up
+// with, and rejects the ones which waited too long.
+func (d *peerMsgHandler) releaseApplyWaiters() {
+	if len(d.applyWaiters) == 0 {
+		return
+	}
+	applied := d.peerStorage.AppliedIndex()
+	now := time.Now()
+	waiters := d.applyWaiters
+	d.applyWaiters = nil
+	for _, waiter := range waiters {
+		switch {
+		case waiter.index <= applied:
+			d.proposeRaftCommand(waiter.req, waiter.cb)
+		case now.After(waiter.deadline):
+			d.callbacks.Done(waiter.cb, ErrResp(&kverrors.ErrServerIsBusy{
+				Reason:     fmt.Sprintf("applied index %d is behind %d", applied, waiter.index),
+				RetryAfter: d.retryAfter(),
+			}))
+		default:
+			d.applyWaiters = append(d.applyWaiters, waiter)
+		}
+	}
+}
+
+// onTick handles a batch of ticks, there is more than one tick if the tick
+// driver has missed some intervals. Raft ticks missed during a stall are
+// handed to raft in one go so they can't trigger an election storm.
+func (d *peerMsgHandler) onTick(ticks int, stalled bool) {
+	stalledRaftTicks := 0
+	for i := 0; i < ticks; i++ {
+		if d.stopped {
+			return
+		}
+		d.ticker.tickClock()
+		if d.ticker.isOnTick(PeerTickRaft) {
+			if stalled {
+				stalledRaftTicks++
+				d.ticker.schedule(PeerTickRaft)
+			} else {
+				d.onRaftBaseTick()
+			}
+		}
+		if d.ticker.isOnTick(PeerTickRaftLogGC) {
+			d.onRaftGCLogTick()
+		}
+		if d.ticker.isOnTick(PeerTickSchedulerHeartbeat) {
+			d.onSchedulerHeartbeatTick()
+		}
+		if d.ticker.isOnTick(PeerTickSplitRegionCheck) {
+			d.onSplitRegionCheckTick()
+		}
 	}
-	if d.ticker.isOnTick(PeerTickSplitRegionCheck) {
-		d.onSplitRegionCheckTick()
+	if stalledRaftTicks > 0 {
+		d.RaftGroup.TickStalled(stalledRaftTicks)
 	}
 	d.ctx.tickDriverSender <- d.regionId
 }
@@ -147,6 +392,7 @@ func (d *peerMsgHandler) startTicker() {
 
 func (d *peerMsgHandler) onRaftBaseTick() {
 	d.RaftGroup.Tick()
+	d.releaseApplyWaiters()
 	d.ticker.schedule(PeerTickRaft)
 }
 
@@ -391,6 +637,26 @@ func (d *peerMsgHandler) destroyPeer() {
 	delete(meta.regions, regionID)
 }
 
+// quarantine stops the peer after its handling panicked. The peer keeps its
+// data and its range in the store meta, so no other peer is created over it,
+// but it no longer handles messages and its requests fail with
+// RegionNotFound, so clients retry on the other replicas. The scheduler
+// replaces it as a down peer, or it recovers on restart if the panic was
+// transient.
+func (d *peerMsgHandler) quarantine(reason interface{}, stack []byte) {
+	log.Errorf("%s panicked and is quarantined: %v\n%s", d.Tag, reason, stack)
+	d.ctx.router.close(d.regionId)
+	d.stopped = true
+	for _, proposal := range d.proposals {
+		NotifyReqRegionRemoved(d.regionId, proposal.cb)
+	}
+	d.proposals = nil
+	for _, waiter := range d.applyWaiters {
+		NotifyReqRegionRemoved(d.regionId, waiter.cb)
+	}
+	d.applyWaiters = nil
+}
+
 func (d *peerMsgHandler) findSiblingRegion() (result *metapb.Region) {
 	meta := d.ctx.storeMeta
 	meta.RLock()
@@ -462,6 +728,11 @@ func (d *peerMsgHandler) onPrepareSplitRegion(regionEpoch *metapb.RegionEpoch, s
 		cb.Done(ErrResp(err))
 		return
 	}
+	if err := d.peerStorage.checkRegionChange(); err != nil {
+		log.Infof("%s defers split: %v", d.Tag, err)
+		cb.Done(ErrResp(&kverrors.ErrServerIsBusy{Reason: err.Error(), RetryAfter: d.retryAfter()}))
+		return
+	}
 	region := d.Region()
 	d.ctx.schedulerTaskSender <- &runner.SchedulerAskSplitTask{
 		Region:   region,
@@ -481,7 +752,7 @@ func (d *peerMsgHandler) validateSplitRegion(epoch *metapb.RegionEpoch, splitKey
 	if !d.IsLeader() {
 		// region on this store is no longer leader, skipped.
 		log.Infof("%s not leader, skip", d.Tag)
-		return &util.ErrNotLeader{
+		return &kverrors.ErrNotLeader{
 			RegionId: d.regionId,
 			Leader:   d.getPeerFromCache(d.LeaderId()),
 		}
@@ -496,7 +767,7 @@ func (d *peerMsgHandler) validateSplitRegion(epoch *metapb.RegionEpoch, splitKey
 	if latestEpoch.Version != epoch.Version {
 		log.Infof("%s epoch changed, retry later, prev_epoch: %s, epoch %s",
 			d.Tag, latestEpoch, epoch)
-		return &util.ErrEpochNotMatch{
+		return &kverrors.ErrEpochNotMatch{
 			Message: fmt.Sprintf("%s epoch changed %s != %s, retry later", d.Tag, latestEpoch, epoch),
 			Regions: []*metapb.Region{region},
 		}
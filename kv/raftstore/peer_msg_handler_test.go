@@ -0,0 +1,71 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap-incubator/tinykv/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPeerMsgHandler(t *testing.T, peers []uint64) *peerMsgHandler {
+	storage := raft.NewMemoryStorage()
+	require.Nil(t, storage.ApplySnapshot(eraftpb.Snapshot{Metadata: &eraftpb.SnapshotMetadata{
+		Index:     1,
+		Term:      1,
+		ConfState: &eraftpb.ConfState{Nodes: peers},
+	}}))
+	raftGroup, err := raft.NewRawNode(&raft.Config{
+		ID:                        1,
+		ElectionTick:              10,
+		HeartbeatTick:             1,
+		Storage:                   storage,
+		MaxUncommittedEntriesSize: 1024,
+	})
+	require.Nil(t, err)
+	ctx := &GlobalContext{cfg: config.NewTestConfig(), router: newRouter(nil, 0, 1)}
+	p := &peer{regionId: 1, Tag: "[region 1] 1", RaftGroup: raftGroup}
+	return newPeerMsgHandler(p, ctx, nil)
+}
+
+func newTestPutCommand(value []byte) *raft_cmdpb.RaftCmdRequest {
+	return &raft_cmdpb.RaftCmdRequest{
+		Header: &raft_cmdpb.RaftRequestHeader{RegionId: 1},
+		Requests: []*raft_cmdpb.Request{{
+			CmdType: raft_cmdpb.CmdType_Put,
+			Put:     &raft_cmdpb.PutRequest{Key: []byte("k"), Value: value},
+		}},
+	}
+}
+
+func TestProposeDroppedIsServerBusy(t *testing.T) {
+	d := newTestPeerMsgHandler(t, []uint64{1, 2})
+	require.Nil(t, d.RaftGroup.Campaign())
+	require.Nil(t, d.RaftGroup.Step(eraftpb.Message{
+		MsgType: eraftpb.MessageType_MsgRequestVoteResponse,
+		From:    2,
+		To:      1,
+		Term:    d.Term(),
+	}))
+	require.True(t, d.IsLeader())
+
+	// Peer 2 never acknowledges, the first proposal stays uncommitted.
+	require.Nil(t, d.propose(newTestPutCommand(make([]byte, 2048))))
+	err := d.propose(newTestPutCommand([]byte("v")))
+	busy, ok := err.(*kverrors.ErrServerIsBusy)
+	require.True(t, ok, "unexpected error %v", err)
+	assert.Equal(t, errorpb.ErrorClass_Overloaded, busy.Class)
+	assert.NotZero(t, busy.RetryAfter)
+
+	// Without a leader the proposals are dropped as well.
+	d = newTestPeerMsgHandler(t, []uint64{1, 2})
+	err = d.propose(newTestPutCommand([]byte("v")))
+	busy, ok = err.(*kverrors.ErrServerIsBusy)
+	require.True(t, ok, "unexpected error %v", err)
+	assert.Equal(t, errorpb.ErrorClass_LeaderChanging, busy.Class)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 	"github.com/pingcap-incubator/tinykv/raft"
 	"github.com/pingcap/errors"
@@ -44,6 +45,11 @@ type PeerStorage struct {
 	regionSched chan<- worker.Task
 	// generate snapshot tried count
 	snapTriedCnt int
+	// pendingRegionChange is the split or conf change proposed by the peer
+	// and not applied yet. Splits, conf changes and snapshots are
+	// interlocked: each one changes the region meta, so none starts while
+	// another one is in flight.
+	pendingRegionChange *regionChange
 	// Engine include two badger instance: Raft and Kv
 	Engines *engine_util.Engines
 	// Tag used for logging
@@ -111,6 +117,9 @@ func (ps *PeerStorage) Entries(low, high uint64) ([]eraftpb.Entry, error) {
 		if err = entry.Unmarshal(val); err != nil {
 			return nil, err
 		}
+		if err = raft.CheckEntry(&entry); err != nil {
+			return nil, err
+		}
 		// May meet gap or has been compacted.
 		if entry.Index != nextIndex {
 			break
@@ -153,6 +162,11 @@ func (ps *PeerStorage) FirstIndex() (uint64, error) {
 
 func (ps *PeerStorage) Snapshot() (eraftpb.Snapshot, error) {
 	var snapshot eraftpb.Snapshot
+	if ps.regionChangePending() {
+		// The snapshot would carry the region meta of before the change,
+		// generate it once the change is applied.
+		return snapshot, raft.ErrSnapshotTemporarilyUnavailable
+	}
 	if ps.snapState.StateType == snap.SnapState_Generating {
 		select {
 		case s := <-ps.snapState.Receiver:
@@ -194,6 +208,45 @@ func (ps *PeerStorage) Snapshot() (eraftpb.Snapshot, error) {
 	return snapshot, raft.ErrSnapshotTemporarilyUnavailable
 }
 
+// regionChange is a split or conf change proposed to the region.
+type regionChange struct {
+	cmdType raft_cmdpb.AdminCmdType
+	index   uint64
+}
+
+// isRegionChange returns whether the admin command changes the region epoch.
+func isRegionChange(cmdType raft_cmdpb.AdminCmdType) bool {
+	return cmdType == raft_cmdpb.AdminCmdType_Split || cmdType == raft_cmdpb.AdminCmdType_ChangePeer
+}
+
+// regionChangePending returns whether a region change proposed at an index
+// not applied yet is in flight. A proposal dropped by raft is replaced by
+// another entry at its index, so the change is forgotten once that index is
+// applied either way.
+func (ps *PeerStorage) regionChangePending() bool {
+	if ps.pendingRegionChange == nil {
+		return false
+	}
+	if ps.pendingRegionChange.index <= ps.AppliedIndex() {
+		ps.pendingRegionChange = nil
+		return false
+	}
+	return true
+}
+
+// checkRegionChange returns an error if the region can't be changed now,
+// because another change is in flight or a snapshot is being applied.
+func (ps *PeerStorage) checkRegionChange() error {
+	if ps.regionChangePending() {
+		return errors.Errorf("%s %s at index %d is not applied yet", ps.Tag,
+			ps.pendingRegionChange.cmdType, ps.pendingRegionChange.index)
+	}
+	if ps.snapState.StateType == snap.SnapState_Applying {
+		return errors.Errorf("%s is applying a snapshot", ps.Tag)
+	}
+	return nil
+}
+
 func (ps *PeerStorage) isInitialized() bool {
 	return len(ps.region.Peers) > 0
 }
@@ -307,7 +360,29 @@ func ClearMeta(engines *engine_util.Engines, kvWB, raftWB *engine_util.WriteBatc
 // Append the given entries to the raft log and update ps.raftState also delete log entries that will
 // never be committed
 func (ps *PeerStorage) Append(entries []eraftpb.Entry, raftWB *engine_util.WriteBatch) error {
-	// Your Code Here (2B).
+	if len(entries) == 0 {
+		return nil
+	}
+	first := ps.truncatedIndex() + 1
+	last := entries[len(entries)-1].Index
+	if last < first {
+		return nil
+	}
+	if entries[0].Index < first {
+		entries = entries[first-entries[0].Index:]
+	}
+	for i := range entries {
+		if err := raftWB.SetMeta(meta.RaftLogKey(ps.region.Id, entries[i].Index), &entries[i]); err != nil {
+			return err
+		}
+	}
+	// The entries of a previous leader past the new last index are
+	// conflicting, they will never be committed.
+	for i := last + 1; i <= ps.raftState.LastIndex; i++ {
+		raftWB.DeleteMeta(meta.RaftLogKey(ps.region.Id, i))
+	}
+	ps.raftState.LastIndex = last
+	ps.raftState.LastTerm = entries[len(entries)-1].Term
 	return nil
 }
 
@@ -321,17 +396,76 @@ func (ps *PeerStorage) ApplySnapshot(snapshot *eraftpb.Snapshot, kvWB *engine_ut
 
 	// Hint: things need to do here including: update peer storage state like raftState and applyState, etc,
 	// and send RegionTaskApply task to region worker through ps.regionSched, also remember call ps.clearMeta
-	// and ps.clearExtraData to delete stale data
-	// Your Code Here (2C).
-	return nil, nil
+	// and ps.clearExtraData to delete stale data. Keep ps.snapState in SnapState_Applying until the
+	// region worker is done, so no split or conf change is proposed meanwhile.
+	if ps.isInitialized() {
+		if err := ps.clearMeta(kvWB, raftWB); err != nil {
+			return nil, err
+		}
+		ps.clearExtraData(snapData.Region)
+	}
+	index, term := snapshot.Metadata.Index, snapshot.Metadata.Term
+	ps.raftState.LastIndex = index
+	ps.raftState.LastTerm = term
+	ps.applyState.AppliedIndex = index
+	ps.applyState.TruncatedState = &rspb.RaftTruncatedState{Index: index, Term: term}
+	if err := kvWB.SetMeta(meta.ApplyStateKey(snapData.Region.GetId()), ps.applyState); err != nil {
+		return nil, err
+	}
+	meta.WriteRegionState(kvWB, snapData.Region, rspb.PeerState_Normal)
+
+	// The data is ingested before the states above are written, so they
+	// never describe data which is not on disk.
+	ch := make(chan bool, 1)
+	ps.snapState = snap.SnapState{StateType: snap.SnapState_Applying}
+	ps.regionSched <- &runner.RegionTaskApply{
+		RegionId: snapData.Region.GetId(),
+		Notifier: ch,
+		SnapMeta: snapshot.Metadata,
+		StartKey: snapData.Region.GetStartKey(),
+		EndKey:   snapData.Region.GetEndKey(),
+	}
+	<-ch
+	ps.snapState = snap.SnapState{StateType: snap.SnapState_Relax}
+
+	result := &ApplySnapResult{PrevRegion: ps.region, Region: snapData.Region}
+	ps.region = snapData.Region
+	log.Infof("%v applied snapshot at index %d, term %d", ps.Tag, index, term)
+	return result, nil
 }
 
 // Save memory states to disk.
 // Do not modify ready in this function, this is a requirement to advance the ready object properly later.
 func (ps *PeerStorage) SaveReadyState(ready *raft.Ready) (*ApplySnapResult, error) {
 	// Hint: you may call `Append()` and `ApplySnapshot()` in this function
-	// Your Code Here (2B/2C).
-	return nil, nil
+	kvWB, raftWB := new(engine_util.WriteBatch), new(engine_util.WriteBatch)
+	var result *ApplySnapResult
+	if !raft.IsEmptySnap(&ready.Snapshot) {
+		var err error
+		if result, err = ps.ApplySnapshot(&ready.Snapshot, kvWB, raftWB); err != nil {
+			return nil, err
+		}
+	}
+	if err := ps.Append(ready.Entries, raftWB); err != nil {
+		return nil, err
+	}
+	if !raft.IsEmptyHardState(ready.HardState) {
+		hardState := ready.HardState
+		ps.raftState.HardState = &hardState
+	}
+	if result == nil && len(ready.Entries) == 0 && raft.IsEmptyHardState(ready.HardState) {
+		return nil, nil
+	}
+	if err := raftWB.SetMeta(meta.RaftStateKey(ps.region.GetId()), ps.raftState); err != nil {
+		return nil, err
+	}
+	if err := ps.Engines.WriteKV(kvWB); err != nil {
+		return nil, err
+	}
+	if err := ps.Engines.WriteRaft(raftWB); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 func (ps *PeerStorage) ClearData() {
@@ -6,9 +6,12 @@ import (
 
 	"github.com/Connor1996/badger"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 	"github.com/pingcap-incubator/tinykv/raft"
 	"github.com/stretchr/testify/assert"
@@ -245,3 +248,30 @@ func TestPeerStorageAppend(t *testing.T) {
 		assert.Equal(t, tt.results, acutualEntries)
 	}
 }
+
+func TestPeerStorageRegionChangeInterlock(t *testing.T) {
+	ents := []eraftpb.Entry{newTestEntry(3, 3), newTestEntry(4, 4), newTestEntry(5, 5)}
+	peerStore := newTestPeerStorageFromEnts(t, ents)
+	defer cleanUpTestData(peerStore)
+	regionSched := make(chan worker.Task, 1)
+	peerStore.regionSched = regionSched
+	require.Nil(t, peerStore.checkRegionChange())
+
+	// A split proposed at index 6 holds back other changes and snapshots.
+	peerStore.pendingRegionChange = &regionChange{cmdType: raft_cmdpb.AdminCmdType_Split, index: 6}
+	assert.NotNil(t, peerStore.checkRegionChange())
+	_, err := peerStore.Snapshot()
+	assert.Equal(t, raft.ErrSnapshotTemporarilyUnavailable, err)
+	assert.Equal(t, 0, len(regionSched))
+
+	// Once index 6 is applied the snapshot is generated.
+	peerStore.applyState.AppliedIndex = 6
+	assert.Nil(t, peerStore.checkRegionChange())
+	assert.Nil(t, peerStore.pendingRegionChange)
+	_, err = peerStore.Snapshot()
+	assert.Equal(t, raft.ErrSnapshotTemporarilyUnavailable, err)
+	assert.Equal(t, 1, len(regionSched))
+
+	peerStore.snapState.StateType = snap.SnapState_Applying
+	assert.NotNil(t, peerStore.checkRegionChange())
+}
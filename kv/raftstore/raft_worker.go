@@ -1,9 +1,13 @@
 package raftstore
 
 import (
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 )
 
 // raftWorker is responsible for run raft commands and apply raft logs.
@@ -15,15 +19,30 @@ type raftWorker struct {
 	// * raft inner messages from other peers sent by network
 	raftCh chan message.Msg
 	ctx    *GlobalContext
+	// callbacks holds the responses of the current round, they are delivered
+	// after all the peers of the round are handled.
+	callbacks *message.CallbackBatch
+	// readyCallbacks hold the responses of the readies handled on the apply
+	// pool, one batch per peer of the round.
+	readyCallbacks []*message.CallbackBatch
+	// applier handles the raft readies, they are handled by the raft worker
+	// itself without it.
+	applier *applyPool
+	util    *worker.Utilization
 
 	closeCh <-chan struct{}
 }
 
-func newRaftWorker(ctx *GlobalContext, pm *router) *raftWorker {
+// newRaftWorker creates a raft worker handling the messages of raftCh, one
+// of the queues of the router. The raft workers share applier and util.
+func newRaftWorker(ctx *GlobalContext, pm *router, raftCh chan message.Msg, applier *applyPool, util *worker.Utilization) *raftWorker {
 	return &raftWorker{
-		raftCh: pm.peerSender,
-		ctx:    ctx,
-		pr:     pm,
+		raftCh:    raftCh,
+		ctx:       ctx,
+		pr:        pm,
+		callbacks: new(message.CallbackBatch),
+		applier:   applier,
+		util:      util,
 	}
 }
 
@@ -41,6 +60,7 @@ func (rw *raftWorker) run(closeCh <-chan struct{}, wg *sync.WaitGroup) {
 		case msg := <-rw.raftCh:
 			msgs = append(msgs, msg)
 		}
+		start := time.Now()
 		pending := len(rw.raftCh)
 		for i := 0; i < pending; i++ {
 			msgs = append(msgs, <-rw.raftCh)
@@ -51,12 +71,59 @@ func (rw *raftWorker) run(closeCh <-chan struct{}, wg *sync.WaitGroup) {
 			if peerState == nil {
 				continue
 			}
-			newPeerMsgHandler(peerState.peer, rw.ctx).HandleMsg(msg)
+			peerState.dequeue()
+			rw.handle(peerState, func(h *peerMsgHandler) { h.HandleMsg(msg) })
 		}
-		for _, peerState := range peerStateMap {
-			newPeerMsgHandler(peerState.peer, rw.ctx).HandleRaftReady()
+		rw.handleReadies(peerStateMap)
+		rw.callbacks.Flush()
+		rw.util.Record(start, len(msgs))
+	}
+}
+
+// handleReadies handles the raft readies of the peers of the round, in
+// parallel on the apply pool if there is one.
+func (rw *raftWorker) handleReadies(peers map[uint64]*peerState) {
+	if rw.applier == nil {
+		for _, ps := range peers {
+			rw.handle(ps, func(h *peerMsgHandler) { h.HandleRaftReady() })
 		}
+		return
+	}
+	var wg sync.WaitGroup
+	n := 0
+	for _, ps := range peers {
+		if n == len(rw.readyCallbacks) {
+			rw.readyCallbacks = append(rw.readyCallbacks, new(message.CallbackBatch))
+		}
+		wg.Add(1)
+		rw.applier.tasks <- applyTask{rw: rw, ps: ps, callbacks: rw.readyCallbacks[n], done: &wg}
+		n++
+	}
+	wg.Wait()
+	for _, callbacks := range rw.readyCallbacks[:n] {
+		callbacks.Flush()
+	}
+}
+
+// handle runs f with a handler of the peer. A panic, e.g. applying a
+// corrupted command, quarantines the peer instead of crashing the store, so
+// the other regions are still served.
+func (rw *raftWorker) handle(ps *peerState, f func(h *peerMsgHandler)) {
+	rw.handleWith(ps, rw.callbacks, f)
+}
+
+// handleWith is handle collecting the responses in callbacks.
+func (rw *raftWorker) handleWith(ps *peerState, callbacks *message.CallbackBatch, f func(h *peerMsgHandler)) {
+	if atomic.LoadUint32(&ps.closed) == 1 {
+		return
 	}
+	h := newPeerMsgHandler(ps.peer, rw.ctx, callbacks)
+	defer func() {
+		if r := recover(); r != nil {
+			h.quarantine(r, debug.Stack())
+		}
+	}()
+	f(h)
 }
 
 func (rw *raftWorker) getPeerState(peersMap map[uint64]*peerState, regionID uint64) *peerState {
@@ -70,3 +137,54 @@ func (rw *raftWorker) getPeerState(peersMap map[uint64]*peerState, regionID uint
 	}
 	return peer
 }
+
+// applyPool handles the raft readies of the peers for the raft workers, i.e.
+// persists their raft logs and applies their committed entries, so the
+// readies of a round are handled in parallel. The readies of different peers
+// only share the engines and the store meta, which is locked.
+type applyPool struct {
+	tasks chan applyTask
+	util  *worker.Utilization
+	wg    sync.WaitGroup
+}
+
+type applyTask struct {
+	rw        *raftWorker
+	ps        *peerState
+	callbacks *message.CallbackBatch
+	done      *sync.WaitGroup
+}
+
+func newApplyPool(workers int) *applyPool {
+	p := &applyPool{
+		tasks: make(chan applyTask, 1024),
+		util:  worker.NewUtilization(workers),
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.run()
+	}
+	return p
+}
+
+func (p *applyPool) run() {
+	defer p.wg.Done()
+	for t := range p.tasks {
+		p.handle(t)
+	}
+}
+
+// handle handles the ready of the task, the raft worker waiting for it is
+// released whatever happens.
+func (p *applyPool) handle(t applyTask) {
+	defer t.done.Done()
+	start := time.Now()
+	t.rw.handleWith(t.ps, t.callbacks, func(h *peerMsgHandler) { h.HandleRaftReady() })
+	p.util.Record(start, 1)
+}
+
+// stop stops the pool, the raft workers must be stopped before.
+func (p *applyPool) stop() {
+	close(p.tasks)
+	p.wg.Wait()
+}
@@ -0,0 +1,34 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRaftWorkerQuarantinesPanickedPeer(t *testing.T) {
+	ctx := &GlobalContext{router: newRouter(nil, 0, 1)}
+	p := &peer{regionId: 1, Tag: "[region 1] 1"}
+	cb := message.NewCallback()
+	p.proposals = []*proposal{{index: 1, term: 1, cb: cb}}
+	ctx.router.register(p)
+	other := &peer{regionId: 2, Tag: "[region 2] 2"}
+	ctx.router.register(other)
+	rw := newRaftWorker(ctx, ctx.router, ctx.router.queue(1), nil, nil)
+
+	rw.handle(ctx.router.get(1), func(h *peerMsgHandler) { panic("corrupted command") })
+	assert.True(t, p.stopped)
+	assert.Nil(t, ctx.router.get(1))
+	assert.Equal(t, errPeerNotFound, ctx.router.send(1, message.Msg{Type: message.MsgTypeRaftCmd}))
+	resp := cb.WaitResp()
+	require.NotNil(t, resp.GetHeader().GetError().GetRegionNotFound())
+	assert.Empty(t, p.proposals)
+
+	// The other regions are still handled.
+	handled := false
+	rw.handle(ctx.router.get(2), func(h *peerMsgHandler) { handled = true })
+	assert.True(t, handled)
+	assert.False(t, other.stopped)
+}
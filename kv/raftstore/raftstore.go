@@ -3,6 +3,7 @@ package raftstore
 import (
 	"bytes"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Connor1996/badger"
@@ -10,6 +11,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/repair"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/runner"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
@@ -98,6 +100,18 @@ type GlobalContext struct {
 	splitCheckTaskSender chan<- worker.Task
 	schedulerClient      scheduler_client.Client
 	tickDriverSender     chan uint64
+	// readOnly is non-zero when the store rejects writes, see Raftstore.SetReadOnly.
+	readOnly *uint32
+	// logOnly is non-zero when the store does not apply, see Raftstore.SetLogOnly.
+	logOnly *uint32
+}
+
+func (ctx *GlobalContext) isReadOnly() bool {
+	return atomic.LoadUint32(ctx.readOnly) != 0
+}
+
+func (ctx *GlobalContext) isLogOnly() bool {
+	return atomic.LoadUint32(ctx.logOnly) != 0
 }
 
 type Transport interface {
@@ -115,6 +129,11 @@ func (bs *Raftstore) loadPeers() ([]*peer, error) {
 	storeID := ctx.store.Id
 
 	var totalCount, tombStoneCount int
+	// replayCount is the number of committed entries not applied yet. They are
+	// the only entries applied again after the restart: applyBatch writes the
+	// apply state in the same write batch as the data, and the raft group is
+	// restarted from that applied index.
+	var replayCount uint64
 	var regionPeers []*peer
 
 	t := time.Now()
@@ -157,6 +176,10 @@ func (bs *Raftstore) loadPeers() ([]*peer, error) {
 			if err != nil {
 				return err
 			}
+			ps := peer.peerStorage
+			if commit := ps.raftState.HardState.GetCommit(); commit > ps.AppliedIndex() {
+				replayCount += commit - ps.AppliedIndex()
+			}
 			ctx.storeMeta.regionRanges.ReplaceOrInsert(&regionItem{region: region})
 			ctx.storeMeta.regions[regionID] = region
 			// No need to check duplicated here, because we use region id as the key
@@ -171,8 +194,8 @@ func (bs *Raftstore) loadPeers() ([]*peer, error) {
 	kvWB.MustWriteToDB(ctx.engine.Kv)
 	raftWB.MustWriteToDB(ctx.engine.Raft)
 
-	log.Infof("start store %d, region_count %d, tombstone_count %d, takes %v",
-		storeID, totalCount, tombStoneCount, time.Since(t))
+	log.Infof("start store %d, region_count %d, tombstone_count %d, entries_to_apply %d, takes %v",
+		storeID, totalCount, tombStoneCount, replayCount, time.Since(t))
 	return regionPeers, nil
 }
 
@@ -206,8 +229,86 @@ type Raftstore struct {
 	router     *router
 	workers    *workers
 	tickDriver *tickDriver
+	readOnly   *uint32
+	logOnly    *uint32
 	closeCh    chan struct{}
 	wg         *sync.WaitGroup
+
+	applyPool *applyPool
+	pollUtil  *worker.Utilization
+}
+
+// WorkerStats are the counters of the goroutine pools of the store, see
+// config.Config.RaftPollWorkers, ApplyWorkers and TransportSenders.
+type WorkerStats struct {
+	RaftPoll worker.PoolStats
+	Apply    worker.PoolStats
+	// Transport is filled by the storage owning the transport, see
+	// raft_storage.RaftStorage.WorkerStats.
+	Transport worker.PoolStats
+}
+
+// WorkerStats returns the counters of the raft workers and of the apply
+// pool. It must be called after the store is started. Without an apply pool
+// the readies are handled by the raft workers and Apply is empty.
+func (bs *Raftstore) WorkerStats() WorkerStats {
+	stats := WorkerStats{RaftPoll: bs.pollUtil.Stats(bs.router.pending())}
+	if bs.applyPool != nil {
+		stats.Apply = bs.applyPool.util.Stats(len(bs.applyPool.tasks))
+	}
+	return stats
+}
+
+// Regions returns the regions this store has a peer of. It must be called
+// after the store is started.
+func (bs *Raftstore) Regions() []*metapb.Region {
+	meta := bs.ctx.storeMeta
+	meta.RLock()
+	defer meta.RUnlock()
+	regions := make([]*metapb.Region, 0, len(meta.regions))
+	for _, region := range meta.regions {
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+// SetReadOnly switches the store in and out of read-only mode. A read-only
+// store rejects write proposals with ServerIsBusy, but keeps serving reads,
+// replicating logs and handling admin commands. It can be called before the
+// store is started.
+func (bs *Raftstore) SetReadOnly(readOnly bool) {
+	var v uint32
+	if readOnly {
+		v = 1
+	}
+	if atomic.SwapUint32(bs.readOnly, v) != v {
+		log.Infof("store read-only mode set to %v", readOnly)
+	}
+}
+
+// IsReadOnly returns whether the store is in read-only mode.
+func (bs *Raftstore) IsReadOnly() bool {
+	return atomic.LoadUint32(bs.readOnly) != 0
+}
+
+// SetLogOnly switches the store in and out of log-only mode. The peers of a
+// log-only store keep replicating and persisting the raft log, but defer
+// applying committed entries and reject all commands. Leaving log-only mode
+// promotes the store: the peers apply the backlog and start serving. It can
+// be called before the store is started.
+func (bs *Raftstore) SetLogOnly(logOnly bool) {
+	var v uint32
+	if logOnly {
+		v = 1
+	}
+	if atomic.SwapUint32(bs.logOnly, v) != v {
+		log.Infof("store log-only mode set to %v", logOnly)
+	}
+}
+
+// IsLogOnly returns whether the store is in log-only mode.
+func (bs *Raftstore) IsLogOnly() bool {
+	return atomic.LoadUint32(bs.logOnly) != 0
 }
 
 func (bs *Raftstore) start(
@@ -248,6 +349,11 @@ func (bs *Raftstore) start(
 		raftLogGCTaskSender:  bs.workers.raftLogGCWorker.Sender(),
 		schedulerClient:      schedulerClient,
 		tickDriverSender:     bs.tickDriver.newRegionCh,
+		readOnly:             bs.readOnly,
+		logOnly:              bs.logOnly,
+	}
+	if err := checkRaftStates(engines); err != nil {
+		return err
 	}
 	regionPeers, err := bs.loadPeers()
 	if err != nil {
@@ -261,13 +367,49 @@ func (bs *Raftstore) start(
 	return nil
 }
 
+// checkRaftStates verifies the raft states of the regions before the store
+// joins the cluster. A commit index behind the applied index is fixed, any
+// other broken raft state refuses the start, the peer could spread it. The
+// other issues are only logged, they are fixed with region-repair.
+func checkRaftStates(engines *engine_util.Engines) error {
+	issues, err := repair.CheckMeta(engines)
+	if err != nil {
+		return err
+	}
+	var broken []*repair.Issue
+	for _, issue := range issues {
+		switch {
+		case issue.Commit != 0:
+			log.Warnf("fix %s: set commit index to %d", issue, issue.Commit)
+			if err := repair.SetCommit(engines.Raft, issue.Regions[0], issue.Commit); err != nil {
+				return err
+			}
+		case issue.Type == repair.IssueRaftState:
+			log.Errorf("%s", issue)
+			broken = append(broken, issue)
+		default:
+			log.Warnf("%s", issue)
+		}
+	}
+	if len(broken) > 0 {
+		return errors.Errorf("%d regions have a broken raft state, refuse to start", len(broken))
+	}
+	return nil
+}
+
 func (bs *Raftstore) startWorkers(peers []*peer) {
 	ctx := bs.ctx
 	workers := bs.workers
 	router := bs.router
-	bs.wg.Add(2) // raftWorker, storeWorker
-	rw := newRaftWorker(ctx, router)
-	go rw.run(bs.closeCh, bs.wg)
+	if ctx.cfg.ApplyWorkers > 1 {
+		bs.applyPool = newApplyPool(ctx.cfg.ApplyWorkers)
+	}
+	bs.pollUtil = worker.NewUtilization(len(router.peerSenders))
+	bs.wg.Add(len(router.peerSenders) + 1) // raftWorkers, storeWorker
+	for _, raftCh := range router.peerSenders {
+		rw := newRaftWorker(ctx, router, raftCh, bs.applyPool, bs.pollUtil)
+		go rw.run(bs.closeCh, bs.wg)
+	}
 	sw := newStoreWorker(ctx, bs.storeState)
 	go sw.run(bs.closeCh, bs.wg)
 	router.sendStore(message.Msg{Type: message.MsgTypeStoreStart, Data: ctx.store})
@@ -280,13 +422,16 @@ func (bs *Raftstore) startWorkers(peers []*peer) {
 	workers.splitCheckWorker.Start(runner.NewSplitCheckHandler(engines.Kv, NewRaftstoreRouter(router), cfg))
 	workers.regionWorker.Start(runner.NewRegionTaskHandler(engines, ctx.snapMgr))
 	workers.raftLogGCWorker.Start(runner.NewRaftLogGCTaskHandler())
-	workers.schedulerWorker.Start(runner.NewSchedulerTaskHandler(ctx.store.Id, ctx.schedulerClient, NewRaftstoreRouter(router)))
+	workers.schedulerWorker.Start(runner.NewSchedulerTaskHandler(ctx.store.Id, ctx.schedulerClient, NewRaftstoreRouter(router), cfg))
 	go bs.tickDriver.run()
 }
 
 func (bs *Raftstore) shutDown() {
 	close(bs.closeCh)
 	bs.wg.Wait()
+	if bs.applyPool != nil {
+		bs.applyPool.stop()
+	}
 	bs.tickDriver.stop()
 	if bs.workers == nil {
 		return
@@ -302,13 +447,16 @@ func (bs *Raftstore) shutDown() {
 
 func CreateRaftstore(cfg *config.Config) (*RaftstoreRouter, *Raftstore) {
 	storeSender, storeState := newStoreState(cfg)
-	router := newRouter(storeSender)
+	router := newRouter(storeSender, cfg.PeerMailboxCapacity, cfg.RaftPollWorkers)
 	raftstore := &Raftstore{
 		router:     router,
 		storeState: storeState,
 		tickDriver: newTickDriver(cfg.RaftBaseTickInterval, router, storeState.ticker),
+		readOnly:   new(uint32),
+		logOnly:    new(uint32),
 		closeCh:    make(chan struct{}),
 		wg:         new(sync.WaitGroup),
 	}
+	raftstore.SetLogOnly(cfg.LogOnly)
 	return NewRaftstoreRouter(router), raftstore
 }
@@ -0,0 +1,391 @@
+// Package repair checks the region metadata of a stopped store against the
+// data it holds, and rewrites it to recover from operator mistakes.
+package repair
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap/errors"
+)
+
+type IssueType int
+
+const (
+	// IssueInvalidRange is a region whose start key is not before its end key.
+	IssueInvalidRange IssueType = iota
+	// IssueMissingState is a region without its apply state or raft state.
+	IssueMissingState
+	// IssueNotMember is a region which has no peer on this store.
+	IssueNotMember
+	// IssueOverlap is two live regions covering the same keys.
+	IssueOverlap
+	// IssueOrphanData is data which no live region covers.
+	IssueOrphanData
+	// IssueRaftState is a raft state breaking the invariants between the
+	// applied, committed, truncated and last indexes.
+	IssueRaftState
+)
+
+var issueTypeNames = [...]string{"invalid-range", "missing-state", "not-member", "overlap", "orphan-data", "raft-state"}
+
+func (t IssueType) String() string { return issueTypeNames[t] }
+
+// Issue is an inconsistency found in the region metadata.
+type Issue struct {
+	Type    IssueType
+	Regions []uint64
+	// StartKey and EndKey bound the keys concerned by the issue, if any.
+	StartKey, EndKey []byte
+	Msg              string
+	// Tombstone is the region whose removal fixes the issue, or 0 if the
+	// issue must be fixed by hand.
+	Tombstone uint64
+	// Commit is the commit index of Regions[0] which fixes the issue, or 0.
+	Commit uint64
+}
+
+func (i *Issue) String() string {
+	s := fmt.Sprintf("[%s] regions %v: %s", i.Type, i.Regions, i.Msg)
+	if i.StartKey != nil || i.EndKey != nil {
+		s += fmt.Sprintf(" (range [%x, %x))", i.StartKey, i.EndKey)
+	}
+	return s
+}
+
+// LoadRegions returns the local state of every region saved in the kv engine,
+// tombstones included.
+func LoadRegions(kvEngine *badger.DB) ([]*rspb.RegionLocalState, error) {
+	var states []*rspb.RegionLocalState
+	err := kvEngine.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Seek(meta.RegionMetaMinKey); it.Valid(); it.Next() {
+			item := it.Item()
+			if bytes.Compare(item.Key(), meta.RegionMetaMaxKey) >= 0 {
+				break
+			}
+			_, suffix, err := meta.DecodeRegionMetaKey(item.Key())
+			if err != nil {
+				return err
+			}
+			if suffix != meta.RegionStateSuffix {
+				continue
+			}
+			val, err := item.Value()
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			state := new(rspb.RegionLocalState)
+			if err := state.Unmarshal(val); err != nil {
+				return errors.WithStack(err)
+			}
+			states = append(states, state)
+		}
+		return nil
+	})
+	return states, err
+}
+
+// Check scans the region metadata of the store and the data it holds, and
+// returns the inconsistencies found. The store must not be running.
+func Check(engines *engine_util.Engines) ([]*Issue, error) {
+	issues, regions, err := checkRegions(engines)
+	if err != nil {
+		return nil, err
+	}
+	orphans, err := checkOrphanData(engines.Kv, mergeRanges(regions))
+	if err != nil {
+		return nil, err
+	}
+	return append(issues, orphans...), nil
+}
+
+// CheckMeta is Check without the scan of the data, it only reads the
+// metadata of the regions so it is cheap enough to run on every start.
+func CheckMeta(engines *engine_util.Engines) ([]*Issue, error) {
+	issues, _, err := checkRegions(engines)
+	return issues, err
+}
+
+// checkRegions checks the metadata of the live regions, and returns them
+// sorted by start key along with the issues found.
+func checkRegions(engines *engine_util.Engines) ([]*Issue, []*metapb.Region, error) {
+	ident := new(rspb.StoreIdent)
+	if err := engine_util.GetMeta(engines.Kv, meta.StoreIdentKey, ident); err != nil {
+		return nil, nil, errors.Annotate(err, "store is not bootstrapped")
+	}
+	states, err := LoadRegions(engines.Kv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var issues []*Issue
+	var regions []*metapb.Region
+	for _, state := range states {
+		if state.State == rspb.PeerState_Tombstone {
+			continue
+		}
+		region := state.Region
+		if len(region.EndKey) > 0 && bytes.Compare(region.StartKey, region.EndKey) >= 0 {
+			issues = append(issues, &Issue{
+				Type:     IssueInvalidRange,
+				Regions:  []uint64{region.Id},
+				StartKey: region.StartKey,
+				EndKey:   region.EndKey,
+				Msg:      "start key is not before end key",
+			})
+			continue
+		}
+		if util.FindPeer(region, ident.StoreId) == nil {
+			issues = append(issues, &Issue{
+				Type:      IssueNotMember,
+				Regions:   []uint64{region.Id},
+				Msg:       fmt.Sprintf("no peer on store %d", ident.StoreId),
+				Tombstone: region.Id,
+			})
+			continue
+		}
+		applyState, err := meta.GetApplyState(engines.Kv, region.Id)
+		if err != nil {
+			issues = append(issues, &Issue{
+				Type:    IssueMissingState,
+				Regions: []uint64{region.Id},
+				Msg:     fmt.Sprintf("apply state: %v", err),
+			})
+		}
+		raftState, err1 := meta.GetRaftLocalState(engines.Raft, region.Id)
+		if err1 != nil {
+			issues = append(issues, &Issue{
+				Type:    IssueMissingState,
+				Regions: []uint64{region.Id},
+				Msg:     fmt.Sprintf("raft state: %v", err1),
+			})
+		}
+		if err == nil && err1 == nil {
+			issue, err := checkRaftState(engines.Raft, region.Id, applyState, raftState)
+			if err != nil {
+				return nil, nil, err
+			}
+			if issue != nil {
+				issues = append(issues, issue)
+			}
+		}
+		regions = append(regions, region)
+	}
+
+	sort.Slice(regions, func(i, j int) bool {
+		return bytes.Compare(regions[i].StartKey, regions[j].StartKey) < 0
+	})
+	return append(issues, checkOverlaps(regions)...), regions, nil
+}
+
+// checkRaftState checks applied <= committed <= last index, and that the log
+// holds the entries from the truncated index up to the last index.
+func checkRaftState(raftEngine *badger.DB, regionID uint64, applyState *rspb.RaftApplyState, raftState *rspb.RaftLocalState) (*Issue, error) {
+	applied := applyState.AppliedIndex
+	truncated := applyState.GetTruncatedState().GetIndex()
+	committed := raftState.GetHardState().GetCommit()
+	last := raftState.LastIndex
+	issue := &Issue{Type: IssueRaftState, Regions: []uint64{regionID}}
+	switch {
+	case committed > last:
+		issue.Msg = fmt.Sprintf("commit index %d is beyond last index %d", committed, last)
+	case applied > committed:
+		// The applied entries were committed, only the commit index wasn't
+		// persisted.
+		issue.Msg = fmt.Sprintf("applied index %d is beyond commit index %d", applied, committed)
+		if applied <= last {
+			issue.Commit = applied
+		}
+	case truncated > applied:
+		issue.Msg = fmt.Sprintf("truncated index %d is beyond applied index %d", truncated, applied)
+	case truncated > last:
+		issue.Msg = fmt.Sprintf("truncated index %d is beyond last index %d", truncated, last)
+	default:
+		if truncated == last {
+			return nil, nil
+		}
+		for _, idx := range []uint64{truncated + 1, last} {
+			if _, err := meta.GetRaftEntry(raftEngine, regionID, idx); err == badger.ErrKeyNotFound {
+				issue.Msg = fmt.Sprintf("log entry %d is missing, the log is truncated at %d and ends at %d", idx, truncated, last)
+				return issue, nil
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+	return issue, nil
+}
+
+// checkOverlaps reports the regions overlapping a region with a smaller start
+// key. The region with the staler epoch is the one to remove.
+func checkOverlaps(sorted []*metapb.Region) []*Issue {
+	var issues []*Issue
+	for i, region := range sorted {
+		for _, prev := range sorted[:i] {
+			if len(prev.EndKey) > 0 && bytes.Compare(prev.EndKey, region.StartKey) <= 0 {
+				continue
+			}
+			issue := &Issue{
+				Type:     IssueOverlap,
+				Regions:  []uint64{prev.Id, region.Id},
+				StartKey: region.StartKey,
+				EndKey:   minEndKey(prev.EndKey, region.EndKey),
+			}
+			switch {
+			case util.IsEpochStale(prev.RegionEpoch, region.RegionEpoch):
+				issue.Tombstone = prev.Id
+				issue.Msg = fmt.Sprintf("region %d has a stale epoch %v", prev.Id, prev.RegionEpoch)
+			case util.IsEpochStale(region.RegionEpoch, prev.RegionEpoch):
+				issue.Tombstone = region.Id
+				issue.Msg = fmt.Sprintf("region %d has a stale epoch %v", region.Id, region.RegionEpoch)
+			default:
+				issue.Msg = fmt.Sprintf("epochs %v and %v do not tell which region is stale", prev.RegionEpoch, region.RegionEpoch)
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func minEndKey(a, b []byte) []byte {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 || bytes.Compare(a, b) < 0 {
+		return a
+	}
+	return b
+}
+
+// keyRange is a range of keys, an empty end key means the range is unbounded.
+type keyRange struct {
+	start, end []byte
+}
+
+// mergeRanges merges the ranges of the regions sorted by start key.
+func mergeRanges(sorted []*metapb.Region) []keyRange {
+	var ranges []keyRange
+	for _, region := range sorted {
+		if n := len(ranges); n > 0 {
+			last := &ranges[n-1]
+			if len(last.end) == 0 {
+				break
+			}
+			if bytes.Compare(region.StartKey, last.end) <= 0 {
+				if len(region.EndKey) == 0 || bytes.Compare(region.EndKey, last.end) > 0 {
+					last.end = region.EndKey
+				}
+				continue
+			}
+		}
+		ranges = append(ranges, keyRange{start: region.StartKey, end: region.EndKey})
+	}
+	return ranges
+}
+
+// checkOrphanData reports, per column family, the keys falling in each gap
+// between the merged region ranges.
+func checkOrphanData(kvEngine *badger.DB, ranges []keyRange) ([]*Issue, error) {
+	var issues []*Issue
+	err := kvEngine.View(func(txn *badger.Txn) error {
+		for _, cf := range engine_util.CFs {
+			it := engine_util.NewCFIterator(cf, txn)
+			var issue *Issue
+			var count, gap int
+			flush := func() {
+				if issue != nil {
+					issue.Msg = fmt.Sprintf("%d keys in cf %s are not covered by any region", count, cf)
+					issues = append(issues, issue)
+				}
+				issue, count = nil, 0
+			}
+			for it.Seek(nil); it.Valid(); it.Next() {
+				key := it.Item().KeyCopy(nil)
+				// Index of the first range starting after the key, the key is
+				// covered if the range before it contains the key.
+				i := sort.Search(len(ranges), func(i int) bool {
+					return bytes.Compare(ranges[i].start, key) > 0
+				})
+				if i > 0 && (len(ranges[i-1].end) == 0 || bytes.Compare(key, ranges[i-1].end) < 0) {
+					continue
+				}
+				if issue != nil && gap != i {
+					flush()
+				}
+				if issue == nil {
+					issue = &Issue{Type: IssueOrphanData, StartKey: key}
+					gap = i
+				}
+				issue.EndKey = key
+				count++
+			}
+			flush()
+			it.Close()
+		}
+		return nil
+	})
+	return issues, err
+}
+
+// Tombstone marks the region as removed from the store, its data and meta
+// are cleared when the store starts.
+func Tombstone(kvEngine *badger.DB, regionID uint64) error {
+	state, err := meta.GetRegionLocalState(kvEngine, regionID)
+	if err != nil {
+		return err
+	}
+	kvWB := new(engine_util.WriteBatch)
+	meta.WriteRegionState(kvWB, state.Region, rspb.PeerState_Tombstone)
+	return kvWB.WriteToDB(kvEngine)
+}
+
+// SetCommit rewrites the commit index of the region in its raft state.
+func SetCommit(raftEngine *badger.DB, regionID, commit uint64) error {
+	raftState, err := meta.GetRaftLocalState(raftEngine, regionID)
+	if err != nil {
+		return err
+	}
+	if commit > raftState.LastIndex {
+		return errors.Errorf("commit index %d is beyond last index %d", commit, raftState.LastIndex)
+	}
+	if raftState.HardState == nil {
+		raftState.HardState = new(eraftpb.HardState)
+	}
+	raftState.HardState.Commit = commit
+	raftWB := new(engine_util.WriteBatch)
+	raftWB.SetMeta(meta.RaftStateKey(regionID), raftState)
+	return raftWB.WriteToDB(raftEngine)
+}
+
+// SetRange rewrites the key range of the region and bumps its version, so the
+// rest of the cluster sees the new range as the latest one.
+func SetRange(kvEngine *badger.DB, regionID uint64, startKey, endKey []byte) error {
+	if len(endKey) > 0 && bytes.Compare(startKey, endKey) >= 0 {
+		return errors.Errorf("invalid range [%x, %x)", startKey, endKey)
+	}
+	state, err := meta.GetRegionLocalState(kvEngine, regionID)
+	if err != nil {
+		return err
+	}
+	if state.State == rspb.PeerState_Tombstone {
+		return errors.Errorf("region %d is a tombstone", regionID)
+	}
+	region := state.Region
+	region.StartKey = startKey
+	region.EndKey = endKey
+	region.RegionEpoch.Version++
+	kvWB := new(engine_util.WriteBatch)
+	meta.WriteRegionState(kvWB, region, state.State)
+	return kvWB.WriteToDB(kvEngine)
+}
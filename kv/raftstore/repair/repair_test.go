@@ -0,0 +1,118 @@
+package repair
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	rspb "github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+func putRegion(t *testing.T, engines *engine_util.Engines, id uint64, start, end string, version uint64) {
+	region := &metapb.Region{
+		Id:          id,
+		StartKey:    []byte(start),
+		EndKey:      []byte(end),
+		RegionEpoch: &metapb.RegionEpoch{Version: version, ConfVer: 1},
+		Peers:       []*metapb.Peer{{Id: id + 100, StoreId: 1}},
+	}
+	kvWB, raftWB := new(engine_util.WriteBatch), new(engine_util.WriteBatch)
+	meta.WriteRegionState(kvWB, region, rspb.PeerState_Normal)
+	kvWB.SetMeta(meta.ApplyStateKey(id), &rspb.RaftApplyState{
+		AppliedIndex:   meta.RaftInitLogIndex,
+		TruncatedState: &rspb.RaftTruncatedState{Index: meta.RaftInitLogIndex, Term: meta.RaftInitLogTerm},
+	})
+	raftWB.SetMeta(meta.RaftStateKey(id), &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{Term: meta.RaftInitLogTerm, Commit: meta.RaftInitLogIndex},
+		LastIndex: meta.RaftInitLogIndex,
+		LastTerm:  meta.RaftInitLogTerm,
+	})
+	require.Nil(t, engines.WriteKV(kvWB))
+	require.Nil(t, engines.WriteRaft(raftWB))
+}
+
+func TestCheckAndRepair(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+	require.Nil(t, engine_util.PutMeta(engines.Kv, meta.StoreIdentKey, &rspb.StoreIdent{ClusterId: 1, StoreId: 1}))
+
+	putRegion(t, engines, 1, "", "c", 2)
+	putRegion(t, engines, 2, "b", "e", 1)
+	putRegion(t, engines, 3, "g", "", 1)
+	require.Nil(t, engine_util.PutCF(engines.Kv, engine_util.CfDefault, []byte("a"), []byte("v")))
+	require.Nil(t, engine_util.PutCF(engines.Kv, engine_util.CfDefault, []byte("e"), []byte("v")))
+	require.Nil(t, engine_util.PutCF(engines.Kv, engine_util.CfDefault, []byte("f"), []byte("v")))
+
+	issues, err := Check(engines)
+	require.Nil(t, err)
+	require.Len(t, issues, 2)
+	require.Equal(t, IssueOverlap, issues[0].Type)
+	require.Equal(t, uint64(2), issues[0].Tombstone)
+	require.Equal(t, IssueOrphanData, issues[1].Type)
+	require.Equal(t, []byte("e"), issues[1].StartKey)
+	require.Equal(t, []byte("f"), issues[1].EndKey)
+
+	require.Nil(t, Tombstone(engines.Kv, 2))
+	require.Nil(t, SetRange(engines.Kv, 1, nil, []byte("g")))
+	issues, err = Check(engines)
+	require.Nil(t, err)
+	require.Empty(t, issues)
+	state, err := meta.GetRegionLocalState(engines.Kv, 1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), state.Region.RegionEpoch.Version)
+}
+
+func TestCheckRaftState(t *testing.T) {
+	engines := util.NewTestEngines()
+	defer engines.Destroy()
+	require.Nil(t, engine_util.PutMeta(engines.Kv, meta.StoreIdentKey, &rspb.StoreIdent{ClusterId: 1, StoreId: 1}))
+	putRegion(t, engines, 1, "", "c", 1)
+	putRegion(t, engines, 2, "c", "", 1)
+	issues, err := CheckMeta(engines)
+	require.Nil(t, err)
+	require.Empty(t, issues)
+
+	// Region 1 applied entries 6 and 7, but its commit index wasn't saved.
+	raftWB := new(engine_util.WriteBatch)
+	for i := uint64(6); i <= 7; i++ {
+		raftWB.SetMeta(meta.RaftLogKey(1, i), &eraftpb.Entry{Index: i, Term: meta.RaftInitLogTerm})
+	}
+	raftWB.SetMeta(meta.RaftStateKey(1), &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{Term: meta.RaftInitLogTerm, Commit: 6},
+		LastIndex: 7,
+		LastTerm:  meta.RaftInitLogTerm,
+	})
+	// The log of region 2 lost its entries.
+	raftWB.SetMeta(meta.RaftStateKey(2), &rspb.RaftLocalState{
+		HardState: &eraftpb.HardState{Term: meta.RaftInitLogTerm, Commit: meta.RaftInitLogIndex},
+		LastIndex: 8,
+		LastTerm:  meta.RaftInitLogTerm,
+	})
+	require.Nil(t, engines.WriteRaft(raftWB))
+	kvWB := new(engine_util.WriteBatch)
+	kvWB.SetMeta(meta.ApplyStateKey(1), &rspb.RaftApplyState{
+		AppliedIndex:   7,
+		TruncatedState: &rspb.RaftTruncatedState{Index: meta.RaftInitLogIndex, Term: meta.RaftInitLogTerm},
+	})
+	require.Nil(t, engines.WriteKV(kvWB))
+
+	issues, err = CheckMeta(engines)
+	require.Nil(t, err)
+	require.Len(t, issues, 2)
+	require.Equal(t, IssueRaftState, issues[0].Type)
+	require.Equal(t, []uint64{1}, issues[0].Regions)
+	require.Equal(t, uint64(7), issues[0].Commit)
+	require.Equal(t, IssueRaftState, issues[1].Type)
+	require.Equal(t, []uint64{2}, issues[1].Regions)
+	require.Equal(t, uint64(0), issues[1].Commit)
+
+	require.Nil(t, SetCommit(engines.Raft, 1, issues[0].Commit))
+	issues, err = CheckMeta(engines)
+	require.Nil(t, err)
+	require.Len(t, issues, 1)
+	require.Equal(t, []uint64{2}, issues[0].Regions)
+}
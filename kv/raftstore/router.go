@@ -5,6 +5,8 @@ import (
 	"sync/atomic"
 
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 
@@ -15,23 +17,70 @@ import (
 type peerState struct {
 	closed uint32
 	peer   *peer
+	// pending is the number of messages sent to the peer and not taken by
+	// the raft worker yet, see router.mailboxCap.
+	pending int64
+}
+
+// dequeue records that the raft worker took a message sent to the peer.
+func (ps *peerState) dequeue() {
+	for {
+		n := atomic.LoadInt64(&ps.pending)
+		// The message may have been counted by a previous peer of the
+		// region.
+		if n <= 0 || atomic.CompareAndSwapInt64(&ps.pending, n, n-1) {
+			return
+		}
+	}
 }
 
 // router routes a message to a peer.
 type router struct {
-	peers       sync.Map // regionID -> peerState
-	peerSender  chan message.Msg
+	peers sync.Map // regionID -> peerState
+	// peerSenders are the queues of the raft workers, the messages of a
+	// region always go to the same one so they are handled in order.
+	peerSenders []chan message.Msg
 	storeSender chan<- message.Msg
+
+	// mailboxCap bounds the raft messages pending for a peer, so a flooded
+	// region can't fill the memory of the store, see mailboxLimit. 0 means
+	// no limit.
+	mailboxCap int
+	droppedMu  sync.Mutex
+	dropped    map[eraftpb.MessageType]uint64
 }
 
-func newRouter(storeSender chan<- message.Msg) *router {
+// newRouter creates a router for the given number of raft workers.
+func newRouter(storeSender chan<- message.Msg, mailboxCap int, workers int) *router {
+	if workers < 1 {
+		workers = 1
+	}
 	pm := &router{
-		peerSender:  make(chan message.Msg, 40960),
+		peerSenders: make([]chan message.Msg, workers),
 		storeSender: storeSender,
+		mailboxCap:  mailboxCap,
+		dropped:     make(map[eraftpb.MessageType]uint64),
+	}
+	for i := range pm.peerSenders {
+		pm.peerSenders[i] = make(chan message.Msg, 40960)
 	}
 	return pm
 }
 
+// queue returns the queue of the raft worker handling the region.
+func (pr *router) queue(regionID uint64) chan message.Msg {
+	return pr.peerSenders[regionID%uint64(len(pr.peerSenders))]
+}
+
+// pending returns the number of messages waiting for the raft workers.
+func (pr *router) pending() int {
+	n := 0
+	for _, q := range pr.peerSenders {
+		n += len(q)
+	}
+	return n
+}
+
 func (pr *router) get(regionID uint64) *peerState {
 	v, ok := pr.peers.Load(regionID)
 	if ok {
@@ -57,16 +106,52 @@ func (pr *router) close(regionID uint64) {
 	}
 }
 
+// send sends msg to the peer of the region. A raft message is dropped, as if
+// the network lost it, if the mailbox of the peer is full for it.
 func (pr *router) send(regionID uint64, msg message.Msg) error {
 	msg.RegionID = regionID
 	p := pr.get(regionID)
 	if p == nil || atomic.LoadUint32(&p.closed) == 1 {
 		return errPeerNotFound
 	}
-	pr.peerSender <- msg
+	if limit, ok := mailboxLimit(msg, pr.mailboxCap); ok && atomic.LoadInt64(&p.pending) >= int64(limit) {
+		pr.drop(regionID, msg)
+		return nil
+	}
+	atomic.AddInt64(&p.pending, 1)
+	pr.queue(regionID) <- msg
 	return nil
 }
 
+// mailboxLimit returns the number of pending messages past which msg is
+// dropped, or false if it is never dropped. Only raft messages are dropped,
+// the stale heartbeats first: a heartbeat is dropped once the mailbox is half
+// full, other messages once it is full. The votes, which elect a leader for
+// the region, are never dropped, nor are the snapshots and leader transfers.
+func mailboxLimit(msg message.Msg, capacity int) (int, bool) {
+	if capacity <= 0 || msg.Type != message.MsgTypeRaftMessage {
+		return 0, false
+	}
+	switch msg.Data.(*raft_serverpb.RaftMessage).GetMessage().GetMsgType() {
+	case eraftpb.MessageType_MsgRequestVote, eraftpb.MessageType_MsgRequestVoteResponse,
+		eraftpb.MessageType_MsgRequestPreVote, eraftpb.MessageType_MsgRequestPreVoteResponse,
+		eraftpb.MessageType_MsgSnapshot, eraftpb.MessageType_MsgTimeoutNow:
+		return 0, false
+	case eraftpb.MessageType_MsgHeartbeat, eraftpb.MessageType_MsgHeartbeatResponse:
+		return capacity / 2, true
+	default:
+		return capacity, true
+	}
+}
+
+func (pr *router) drop(regionID uint64, msg message.Msg) {
+	tp := msg.Data.(*raft_serverpb.RaftMessage).GetMessage().GetMsgType()
+	log.Debugf("[region %d] mailbox is full, dropping %s", regionID, tp)
+	pr.droppedMu.Lock()
+	pr.dropped[tp]++
+	pr.droppedMu.Unlock()
+}
+
 func (pr *router) sendStore(msg message.Msg) {
 	pr.storeSender <- msg
 }
@@ -81,6 +166,18 @@ func NewRaftstoreRouter(router *router) *RaftstoreRouter {
 	return &RaftstoreRouter{router: router}
 }
 
+// DroppedMessages returns the number of raft messages of each type dropped
+// so far as the mailbox of their peer was full.
+func (r *RaftstoreRouter) DroppedMessages() map[eraftpb.MessageType]uint64 {
+	r.router.droppedMu.Lock()
+	defer r.router.droppedMu.Unlock()
+	dropped := make(map[eraftpb.MessageType]uint64, len(r.router.dropped))
+	for tp, n := range r.router.dropped {
+		dropped[tp] = n
+	}
+	return dropped
+}
+
 func (r *RaftstoreRouter) Send(regionID uint64, msg message.Msg) error {
 	return r.router.send(regionID, msg)
 }
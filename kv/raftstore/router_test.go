@@ -0,0 +1,62 @@
+package raftstore
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouterMailboxDropsStaleHeartbeatsFirst(t *testing.T) {
+	pr := newRouter(nil, 4, 1)
+	pr.register(&peer{regionId: 1})
+	rr := NewRaftstoreRouter(pr)
+	send := func(tp eraftpb.MessageType) {
+		msg := &raft_serverpb.RaftMessage{RegionId: 1, Message: &eraftpb.Message{MsgType: tp}}
+		assert.Nil(t, rr.SendRaftMessage(msg))
+	}
+
+	send(eraftpb.MessageType_MsgAppend)
+	send(eraftpb.MessageType_MsgHeartbeat)
+	// Half full, heartbeats are dropped.
+	send(eraftpb.MessageType_MsgHeartbeat)
+	send(eraftpb.MessageType_MsgAppend)
+	send(eraftpb.MessageType_MsgAppend)
+	// Full, appends are dropped but not votes.
+	send(eraftpb.MessageType_MsgAppend)
+	send(eraftpb.MessageType_MsgRequestVote)
+	assert.Nil(t, pr.send(1, message.Msg{Type: message.MsgTypeTick}))
+	assert.Equal(t, 6, len(pr.queue(1)))
+	assert.Equal(t, map[eraftpb.MessageType]uint64{
+		eraftpb.MessageType_MsgHeartbeat: 1,
+		eraftpb.MessageType_MsgAppend:    1,
+	}, rr.DroppedMessages())
+
+	// The worker taking messages makes room for others.
+	for i := 0; i < 3; i++ {
+		<-pr.queue(1)
+		pr.get(1).dequeue()
+	}
+	send(eraftpb.MessageType_MsgAppend)
+	assert.Equal(t, 4, len(pr.queue(1)))
+	assert.Equal(t, uint64(1), rr.DroppedMessages()[eraftpb.MessageType_MsgAppend])
+}
+
+func TestRouterShardsRegionsOverRaftWorkers(t *testing.T) {
+	pr := newRouter(nil, 0, 2)
+	for id := uint64(1); id <= 3; id++ {
+		pr.register(&peer{regionId: id})
+	}
+	for _, id := range []uint64{1, 2, 3, 1} {
+		assert.Nil(t, pr.send(id, message.Msg{Type: message.MsgTypeTick}))
+	}
+	assert.Equal(t, 4, pr.pending())
+	// The messages of a region always go to the same worker, in order.
+	assert.Equal(t, 3, len(pr.queue(1)))
+	assert.Equal(t, 1, len(pr.queue(2)))
+	for _, id := range []uint64{1, 3, 1} {
+		assert.Equal(t, id, (<-pr.queue(1)).RegionID)
+	}
+}
@@ -22,6 +22,7 @@ import (
 // `RegionTaskGen` which will cause the worker to generate a snapshot according to RegionId,
 // `RegionTaskApply` which will apply a snapshot to the region that id equals RegionId,
 // `RegionTaskDestroy` which will clean up the key range from StartKey to EndKey.
+// `RegionTaskCompact` which will compact the ranges where many keys were deleted.
 
 type RegionTaskGen struct {
 	RegionId uint64                   // specify the region which the task is for.
@@ -42,6 +43,14 @@ type RegionTaskDestroy struct {
 	EndKey   []byte
 }
 
+// RegionTaskCompact compacts the ranges of the kv engine tracked by
+// Engines.Tombstones with at least Threshold keys deleted. It runs on the
+// region worker, so it never races with a snapshot applied to a destroyed
+// range.
+type RegionTaskCompact struct {
+	Threshold int
+}
+
 type regionTaskHandler struct {
 	ctx *snapContext
 }
@@ -67,7 +76,11 @@ func (r *regionTaskHandler) Handle(t worker.Task) {
 		r.ctx.handleApply(task.RegionId, task.Notifier, task.StartKey, task.EndKey, task.SnapMeta)
 	case *RegionTaskDestroy:
 		task := t.(*RegionTaskDestroy)
-		r.ctx.cleanUpRange(task.RegionId, task.StartKey, task.EndKey)
+		n := r.ctx.cleanUpRange(task.RegionId, task.StartKey, task.EndKey)
+		r.ctx.engines.Tombstones.Record(engine_util.CFRanges(task.StartKey, task.EndKey), n)
+	case *RegionTaskCompact:
+		task := t.(*RegionTaskCompact)
+		r.ctx.compactRanges(task.Threshold)
 	}
 }
 
@@ -92,9 +105,6 @@ func (snapCtx *snapContext) handleGen(regionId uint64, notifier chan<- *eraftpb.
 func (snapCtx *snapContext) applySnap(regionId uint64, startKey, endKey []byte, snapMeta *eraftpb.SnapshotMetadata) error {
 	log.Infof("begin apply snap data. [regionId: %d]", regionId)
 
-	// cleanUpOriginData clear up the region data before applying snapshot
-	snapCtx.cleanUpRange(regionId, startKey, endKey)
-
 	snapKey := snap.SnapKey{RegionID: regionId, Index: snapMeta.Index, Term: snapMeta.Term}
 	snapCtx.mgr.Register(snapKey, snap.SnapEntryApplying)
 	defer snapCtx.mgr.Deregister(snapKey, snap.SnapEntryApplying)
@@ -103,13 +113,24 @@ func (snapCtx *snapContext) applySnap(regionId uint64, startKey, endKey []byte,
 	if err != nil {
 		return errors.New(fmt.Sprintf("missing snapshot file %s", err))
 	}
+	total := snapshot.TotalSize()
+
+	// cleanUpOriginData clear up the region data before applying snapshot
+	snapCtx.mgr.UpdateApplyProgress(snapKey, snap.ApplyPhaseCleaning, 0, total)
+	snapCtx.cleanUpRange(regionId, startKey, endKey)
+	// The range is in use again, it must not be compacted.
+	snapCtx.engines.Tombstones.Forget(startKey, endKey)
 
 	t := time.Now()
+	snapCtx.mgr.UpdateApplyProgress(snapKey, snap.ApplyPhaseIngesting, 0, total)
 	applyOptions := snap.NewApplyOptions(snapCtx.engines.Kv, &metapb.Region{
 		Id:       regionId,
 		StartKey: startKey,
 		EndKey:   endKey,
 	})
+	applyOptions.Progress = func(applied uint64) {
+		snapCtx.mgr.UpdateApplyProgress(snapKey, snap.ApplyPhaseIngesting, applied, total)
+	}
 	if err := snapshot.Apply(*applyOptions); err != nil {
 		return err
 	}
@@ -128,14 +149,32 @@ func (snapCtx *snapContext) handleApply(regionId uint64, notifier chan<- bool, s
 	notifier <- true
 }
 
-// cleanUpRange cleans up the data within the range.
-func (snapCtx *snapContext) cleanUpRange(regionId uint64, startKey, endKey []byte) {
-	if err := engine_util.DeleteRange(snapCtx.engines.Kv, startKey, endKey); err != nil {
+// cleanUpRange cleans up the data within the range, it returns the number of
+// keys deleted.
+func (snapCtx *snapContext) cleanUpRange(regionId uint64, startKey, endKey []byte) int {
+	n, err := engine_util.DeleteCFRanges(snapCtx.engines.Kv, engine_util.CFRanges(startKey, endKey))
+	if err != nil {
 		log.Fatalf("failed to delete data in range, [regionId: %d, startKey: %s, endKey: %s, err: %v]", regionId,
 			hex.EncodeToString(startKey), hex.EncodeToString(endKey), err)
 	} else {
-		log.Infof("succeed in deleting data in range. [regionId: %d, startKey: %s, endKey: %s]", regionId,
-			hex.EncodeToString(startKey), hex.EncodeToString(endKey))
+		log.Infof("succeed in deleting data in range. [regionId: %d, startKey: %s, endKey: %s, keys: %d]", regionId,
+			hex.EncodeToString(startKey), hex.EncodeToString(endKey), n)
+	}
+	return n
+}
+
+// compactRanges compacts the tracked ranges with at least threshold keys
+// deleted.
+func (snapCtx *snapContext) compactRanges(threshold int) {
+	for _, ranges := range snapCtx.engines.Tombstones.TakeDense(threshold) {
+		t := time.Now()
+		n, err := engine_util.CompactCFRanges(snapCtx.engines.Kv, ranges)
+		if err != nil {
+			log.Errorf("failed to compact ranges %v: %v", ranges, err)
+			continue
+		}
+		log.Infof("compacted ranges with many deleted keys. [ranges: %v, keysDeletedAgain: %d, timeTakes: %v]",
+			ranges, n, time.Since(t))
 	}
 }
 
@@ -276,3 +276,17 @@ func TestSplitCheck(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, codec.EncodeBytes([]byte("k2")), split.SplitKey)
 }
+
+func TestWriteStallDetector(t *testing.T) {
+	d := writeStallDetector{l0Threshold: 8}
+	assert.False(t, d.update(7))
+	assert.True(t, d.update(8))
+	// Stays busy until the backlog drops below half of the threshold.
+	assert.True(t, d.update(5))
+	assert.True(t, d.update(4))
+	assert.False(t, d.update(3))
+	assert.False(t, d.update(7))
+
+	disabled := writeStallDetector{}
+	assert.False(t, disabled.update(100))
+}
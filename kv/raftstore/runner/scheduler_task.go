@@ -2,8 +2,10 @@ package runner
 
 import (
 	"context"
+	"time"
 
 	"github.com/Connor1996/badger"
+	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
@@ -38,13 +40,19 @@ type SchedulerTaskHandler struct {
 	storeID         uint64
 	SchedulerClient scheduler_client.Client
 	router          message.RaftRouter
+	writeStall      writeStallDetector
+	// startTime tells the scheduler when the store restarted, see
+	// PrepareRestart.
+	startTime uint32
 }
 
-func NewSchedulerTaskHandler(storeID uint64, SchedulerClient scheduler_client.Client, router message.RaftRouter) *SchedulerTaskHandler {
+func NewSchedulerTaskHandler(storeID uint64, SchedulerClient scheduler_client.Client, router message.RaftRouter, cfg *config.Config) *SchedulerTaskHandler {
 	return &SchedulerTaskHandler{
 		storeID:         storeID,
 		SchedulerClient: SchedulerClient,
 		router:          router,
+		writeStall:      writeStallDetector{l0Threshold: cfg.WriteStallL0Tables},
+		startTime:       uint32(time.Now().Unix()),
 	}
 }
 
@@ -135,6 +143,8 @@ func (r *SchedulerTaskHandler) onStoreHeartbeat(t *SchedulerStoreHeartbeatTask)
 	t.Stats.Capacity = capacity
 	t.Stats.UsedSize = usedSize
 	t.Stats.Available = available
+	t.Stats.IsBusy = r.writeStall.update(countL0Tables(t.Engine))
+	t.Stats.StartTime = r.startTime
 
 	r.SchedulerClient.StoreHeartbeat(context.TODO(), t.Stats)
 }
@@ -150,3 +160,38 @@ func (r *SchedulerTaskHandler) sendAdminRequest(regionID uint64, epoch *metapb.R
 	}
 	r.router.SendRaftCommand(cmd, callback)
 }
+
+// writeStallDetector decides whether the store is too far behind on compaction
+// to take new peers or leaders. The store turns busy once the level-0 backlog
+// reaches the threshold and stays busy until it drops below half of it, so
+// the flag does not flap between heartbeats.
+type writeStallDetector struct {
+	l0Threshold int
+	busy        bool
+}
+
+func (d *writeStallDetector) update(l0Tables int) bool {
+	if d.l0Threshold <= 0 {
+		return false
+	}
+	if d.busy {
+		if l0Tables < d.l0Threshold/2 {
+			d.busy = false
+			log.Infof("write stall is over, level-0 tables %d", l0Tables)
+		}
+	} else if l0Tables >= d.l0Threshold {
+		d.busy = true
+		log.Warnf("store is busy, level-0 tables %d reach write stall threshold %d", l0Tables, d.l0Threshold)
+	}
+	return d.busy
+}
+
+func countL0Tables(engine *badger.DB) int {
+	count := 0
+	for _, table := range engine.Tables() {
+		if table.Level == 0 {
+			count++
+		}
+	}
+	return count
+}
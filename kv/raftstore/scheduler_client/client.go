@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/tsoutil"
 	"google.golang.org/grpc"
 )
 
@@ -44,6 +45,12 @@ type Client interface {
 	StoreHeartbeat(ctx context.Context, stats *schedulerpb.StoreStats) error
 	RegionHeartbeat(*schedulerpb.RegionHeartbeatRequest) error
 	SetRegionHeartbeatResponseHandler(storeID uint64, h func(*schedulerpb.RegionHeartbeatResponse))
+	// GetTS returns a new timestamp from the timestamp oracle.
+	GetTS(ctx context.Context) (uint64, error)
+	// UpdateServiceGCSafePoint keeps the GC safe point from passing safePoint
+	// for ttl seconds, a ttl not above 0 removes the service safe point. It
+	// returns the oldest safe point GC is held at.
+	UpdateServiceGCSafePoint(ctx context.Context, serviceID string, ttl int64, safePoint uint64) (uint64, error)
 	Close()
 }
 
@@ -52,6 +59,9 @@ const (
 	retryInterval         = time.Second
 	maxInitClusterRetries = 100
 	maxRetryCount         = 10
+	// maxConnBackoff bounds the reconnect backoff of a connection, so a
+	// restarted scheduler is reconnected to soon after it comes back.
+	maxConnBackoff = 3 * time.Second
 )
 
 var (
@@ -68,6 +78,9 @@ type client struct {
 		sync.RWMutex
 		clientConns map[string]*grpc.ClientConn
 		leader      string
+		// leaderChanged is closed and replaced whenever the leader switches,
+		// requests waiting to be retried wait on it to fail over at once.
+		leaderChanged chan struct{}
 	}
 	checkLeaderCh chan struct{}
 
@@ -105,6 +118,7 @@ func NewClient(pdAddrs []string, tag string) (Client, error) {
 		regionCh:                 make(chan *schedulerpb.RegionHeartbeatRequest, 64),
 	}
 	c.connMu.clientConns = make(map[string]*grpc.ClientConn)
+	c.connMu.leaderChanged = make(chan struct{})
 
 	var (
 		err     error
@@ -136,6 +150,23 @@ func (c *client) schedulerUpdateLeader() {
 	}
 }
 
+// waitLeaderChange asks for the leader to be checked and waits until it
+// switches, or for at most retryInterval. It returns false if ctx is done.
+func (c *client) waitLeaderChange(ctx context.Context) bool {
+	c.connMu.RLock()
+	changed := c.connMu.leaderChanged
+	c.connMu.RUnlock()
+
+	c.schedulerUpdateLeader()
+	select {
+	case <-changed:
+	case <-time.After(retryInterval):
+	case <-ctx.Done():
+		return false
+	}
+	return true
+}
+
 func (c *client) checkLeaderLoop() {
 	defer c.wg.Done()
 
@@ -207,6 +238,8 @@ func (c *client) switchLeader(addrs []string) error {
 
 	c.connMu.Lock()
 	c.connMu.leader = addr
+	close(c.connMu.leaderChanged)
+	c.connMu.leaderChanged = make(chan struct{})
 	c.connMu.Unlock()
 	return nil
 }
@@ -231,7 +264,7 @@ func (c *client) getOrCreateConn(addr string) (*grpc.ClientConn, error) {
 	if err != nil {
 		return nil, err
 	}
-	cc, err := grpc.Dial(u.Host, grpc.WithInsecure())
+	cc, err := grpc.Dial(u.Host, grpc.WithInsecure(), grpc.WithBackoffMaxDelay(maxConnBackoff))
 	if err != nil {
 		return nil, err
 	}
@@ -262,11 +295,7 @@ func (c *client) doRequest(ctx context.Context, f func(context.Context, schedule
 			return nil
 		}
 
-		c.schedulerUpdateLeader()
-		select {
-		case <-time.After(retryInterval):
-			continue
-		case <-ctx.Done():
+		if !c.waitLeaderChange(ctx) {
 			return ctx.Err()
 		}
 	}
@@ -289,8 +318,7 @@ func (c *client) heartbeatStreamLoop() {
 		c.connMu.RUnlock()
 		if err != nil {
 			cancel()
-			c.schedulerUpdateLeader()
-			time.Sleep(retryInterval)
+			c.waitLeaderChange(c.ctx)
 			continue
 		}
 
@@ -304,9 +332,8 @@ func (c *client) heartbeatStreamLoop() {
 		case err := <-errCh:
 			log.Warnf("[%s][scheduler] heartbeat stream get error: %s ", c.tag, err)
 			cancel()
-			c.schedulerUpdateLeader()
-			time.Sleep(retryInterval)
 			wg.Wait()
+			c.waitLeaderChange(c.ctx)
 		case <-c.ctx.Done():
 			log.Info("cancel heartbeat stream loop")
 			cancel()
@@ -395,6 +422,48 @@ func (c *client) AllocID(ctx context.Context) (uint64, error) {
 	return resp.GetId(), nil
 }
 
+func (c *client) GetTS(ctx context.Context) (uint64, error) {
+	var resp *schedulerpb.TsoResponse
+	err := c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+		stream, err := client.Tso(ctx)
+		if err != nil {
+			return err
+		}
+		defer stream.CloseSend()
+		if err = stream.Send(&schedulerpb.TsoRequest{Header: c.requestHeader(), Count: 1}); err != nil {
+			return err
+		}
+		resp, err = stream.Recv()
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	ts := resp.GetTimestamp()
+	return uint64(ts.GetPhysical())<<tsoutil.PhysicalShiftBits + uint64(ts.GetLogical()), nil
+}
+
+func (c *client) UpdateServiceGCSafePoint(ctx context.Context, serviceID string, ttl int64, safePoint uint64) (uint64, error) {
+	var resp *schedulerpb.UpdateServiceGCSafePointResponse
+	err := c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
+		var err1 error
+		resp, err1 = client.UpdateServiceGCSafePoint(ctx, &schedulerpb.UpdateServiceGCSafePointRequest{
+			Header:    c.requestHeader(),
+			ServiceId: []byte(serviceID),
+			Ttl:       ttl,
+			SafePoint: safePoint,
+		})
+		return err1
+	})
+	if err != nil {
+		return 0, err
+	}
+	if herr := resp.Header.GetError(); herr != nil {
+		return 0, errors.New(herr.String())
+	}
+	return resp.MinSafePoint, nil
+}
+
 func (c *client) Bootstrap(ctx context.Context, store *metapb.Store) (resp *schedulerpb.BootstrapResponse, err error) {
 	err = c.doRequest(ctx, func(ctx context.Context, client schedulerpb.SchedulerClient) error {
 		var err1 error
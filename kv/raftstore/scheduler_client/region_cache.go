@@ -0,0 +1,133 @@
+package scheduler_client
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+)
+
+// RegionCache caches the regions and leaders looked up from the scheduler, so
+// routing a request doesn't cost a round trip to the scheduler. An entry
+// expires after the TTL, which bounds how long requests are misrouted after
+// splits, merges and leader changes nobody reported, and region errors
+// invalidate entries at once with OnRegionError.
+type RegionCache struct {
+	client Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu sync.Mutex
+	// regions are sorted by start key and don't overlap.
+	regions []*cachedRegion
+}
+
+type cachedRegion struct {
+	region   *metapb.Region
+	leader   *metapb.Peer
+	deadline time.Time
+}
+
+func (r *cachedRegion) contains(key []byte) bool {
+	return bytes.Compare(r.region.StartKey, key) <= 0 &&
+		(len(r.region.EndKey) == 0 || bytes.Compare(key, r.region.EndKey) < 0)
+}
+
+func NewRegionCache(client Client, ttl time.Duration) *RegionCache {
+	return &RegionCache{client: client, ttl: ttl, now: time.Now}
+}
+
+// GetRegion returns the region containing key and its leader, from the cache
+// if it holds an entry not expired yet.
+func (c *RegionCache) GetRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error) {
+	c.mu.Lock()
+	if i := c.search(key); i < len(c.regions) && c.regions[i].contains(key) {
+		r := c.regions[i]
+		if c.now().Before(r.deadline) {
+			c.mu.Unlock()
+			return r.region, r.leader, nil
+		}
+	}
+	c.mu.Unlock()
+
+	region, leader, err := c.client.GetRegion(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if leader != nil {
+		c.insert(region, leader)
+	}
+	return region, leader, nil
+}
+
+// search returns the index of the last region starting at or before key, or
+// len(c.regions) if there is none.
+func (c *RegionCache) search(key []byte) int {
+	i := sort.Search(len(c.regions), func(i int) bool {
+		return bytes.Compare(c.regions[i].region.StartKey, key) > 0
+	})
+	if i == 0 {
+		return len(c.regions)
+	}
+	return i - 1
+}
+
+// insert caches the region, dropping the cached regions it overlaps.
+func (c *RegionCache) insert(region *metapb.Region, leader *metapb.Peer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeOverlapsLocked(region.StartKey, region.EndKey)
+	i := sort.Search(len(c.regions), func(i int) bool {
+		return bytes.Compare(c.regions[i].region.StartKey, region.StartKey) > 0
+	})
+	c.regions = append(c.regions, nil)
+	copy(c.regions[i+1:], c.regions[i:])
+	c.regions[i] = &cachedRegion{region: region, leader: leader, deadline: c.now().Add(c.ttl)}
+}
+
+func (c *RegionCache) removeOverlapsLocked(start, end []byte) {
+	regions := c.regions[:0]
+	for _, r := range c.regions {
+		overlaps := (len(end) == 0 || bytes.Compare(r.region.StartKey, end) < 0) &&
+			(len(r.region.EndKey) == 0 || bytes.Compare(start, r.region.EndKey) < 0)
+		if !overlaps {
+			regions = append(regions, r)
+		}
+	}
+	c.regions = regions
+}
+
+// Invalidate drops the region from the cache, the next lookup of its keys
+// asks the scheduler.
+func (c *RegionCache) Invalidate(regionID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, r := range c.regions {
+		if r.region.Id == regionID {
+			c.regions = append(c.regions[:i], c.regions[i+1:]...)
+			return
+		}
+	}
+}
+
+// OnRegionError updates the cache with the region error a request to the
+// region got: a new leader is cached right away, any other error drops the
+// region, since its range or epoch may have changed.
+func (c *RegionCache) OnRegionError(regionID uint64, err *errorpb.Error) {
+	if notLeader := err.GetNotLeader(); notLeader != nil && notLeader.Leader != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, r := range c.regions {
+			if r.region.Id == regionID {
+				r.leader = notLeader.Leader
+				return
+			}
+		}
+		return
+	}
+	c.Invalidate(regionID)
+}
@@ -0,0 +1,90 @@
+package scheduler_client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// regionClient serves GetRegion from a fixed list of regions, led by the peer
+// on store 1, and counts the lookups.
+type regionClient struct {
+	Client
+	regions []*metapb.Region
+	lookups int
+}
+
+func (c *regionClient) GetRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error) {
+	c.lookups++
+	for _, r := range c.regions {
+		if (&cachedRegion{region: r}).contains(key) {
+			return r, r.Peers[0], nil
+		}
+	}
+	return nil, nil, nil
+}
+
+func newRegion(id uint64, start, end string) *metapb.Region {
+	return &metapb.Region{
+		Id:       id,
+		StartKey: []byte(start),
+		EndKey:   []byte(end),
+		Peers:    []*metapb.Peer{{Id: id * 10, StoreId: 1}, {Id: id*10 + 1, StoreId: 2}},
+	}
+}
+
+func TestRegionCacheTTL(t *testing.T) {
+	client := &regionClient{regions: []*metapb.Region{newRegion(1, "", "m"), newRegion(2, "m", "")}}
+	cache := NewRegionCache(client, time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	region, _, err := cache.GetRegion(ctx, []byte("a"))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1), region.Id)
+	region, _, _ = cache.GetRegion(ctx, []byte("b"))
+	assert.Equal(t, uint64(1), region.Id)
+	region, _, _ = cache.GetRegion(ctx, []byte("x"))
+	assert.Equal(t, uint64(2), region.Id)
+	assert.Equal(t, 2, client.lookups)
+
+	// Region 1 splits, the cache routes by the old range until it expires.
+	client.regions = []*metapb.Region{newRegion(1, "", "f"), newRegion(3, "f", "m"), newRegion(2, "m", "")}
+	region, _, _ = cache.GetRegion(ctx, []byte("g"))
+	assert.Equal(t, uint64(1), region.Id)
+	now = now.Add(time.Minute)
+	region, _, _ = cache.GetRegion(ctx, []byte("g"))
+	assert.Equal(t, uint64(3), region.Id)
+	region, _, _ = cache.GetRegion(ctx, []byte("a"))
+	assert.Equal(t, uint64(1), region.Id)
+	assert.Equal(t, 4, client.lookups)
+}
+
+func TestRegionCacheRegionError(t *testing.T) {
+	client := &regionClient{regions: []*metapb.Region{newRegion(1, "", "")}}
+	cache := NewRegionCache(client, time.Minute)
+	ctx := context.Background()
+
+	_, leader, _ := cache.GetRegion(ctx, []byte("a"))
+	assert.Equal(t, uint64(1), leader.StoreId)
+
+	// A new leader is cached without asking the scheduler.
+	newLeader := &metapb.Peer{Id: 11, StoreId: 2}
+	cache.OnRegionError(1, &errorpb.Error{NotLeader: &errorpb.NotLeader{RegionId: 1, Leader: newLeader}})
+	_, leader, _ = cache.GetRegion(ctx, []byte("a"))
+	assert.Equal(t, newLeader, leader)
+	assert.Equal(t, 1, client.lookups)
+
+	// Any other error drops the region.
+	client.regions = []*metapb.Region{newRegion(1, "", "m"), newRegion(2, "m", "")}
+	cache.OnRegionError(1, &errorpb.Error{EpochNotMatch: &errorpb.EpochNotMatch{}})
+	region, _, _ := cache.GetRegion(ctx, []byte("x"))
+	assert.Equal(t, uint64(2), region.Id)
+	assert.Equal(t, 2, client.lookups)
+}
@@ -97,6 +97,9 @@ type SnapStatistics struct {
 type ApplyOptions struct {
 	DB     *badger.DB
 	Region *metapb.Region
+	// Progress is called with the bytes ingested so far after each file of
+	// the snapshot, if set.
+	Progress func(appliedBytes uint64)
 }
 
 func NewApplyOptions(db *badger.DB, region *metapb.Region) *ApplyOptions {
@@ -676,7 +679,10 @@ func (s *Snap) Apply(opts ApplyOptions) error {
 		return err
 	}
 
-	externalFiles := make([]*os.File, 0, len(s.CFFiles))
+	// The files are ingested one at a time to report the progress, each cf
+	// has its own key prefix so they don't overlap.
+	var tables int
+	var applied uint64
 	for _, cfFile := range s.CFFiles {
 		if cfFile.Size == 0 {
 			// Skip empty cf file
@@ -687,17 +693,19 @@ func (s *Snap) Apply(opts ApplyOptions) error {
 			log.Errorf("open ingest file %s failed: %s", cfFile.Path, err)
 			return err
 		}
-		externalFiles = append(externalFiles, file)
-	}
-	n, err := opts.DB.IngestExternalFiles(externalFiles)
-	for _, file := range externalFiles {
+		n, err := opts.DB.IngestExternalFiles([]*os.File{file})
 		file.Close()
+		if err != nil {
+			log.Errorf("ingest sst failed (first %d files succeeded): %s", tables, err)
+			return err
+		}
+		tables += n
+		applied += cfFile.Size
+		if opts.Progress != nil {
+			opts.Progress(applied)
+		}
 	}
-	if err != nil {
-		log.Errorf("ingest sst failed (first %d files succeeded): %s", n, err)
-		return err
-	}
-	log.Infof("apply snapshot ingested %d tables", n)
+	log.Infof("apply snapshot ingested %d tables", tables)
 	return nil
 }
 
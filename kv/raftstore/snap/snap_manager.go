@@ -43,6 +43,37 @@ func (e SnapEntry) String() string {
 type SnapStats struct {
 	ReceivingCount int
 	SendingCount   int
+	ApplyingCount  int
+}
+
+// ApplyPhase is the step a snapshot being applied is at.
+type ApplyPhase int
+
+const (
+	// ApplyPhaseCleaning deletes the data of the region before the snapshot.
+	ApplyPhaseCleaning ApplyPhase = 1
+	// ApplyPhaseIngesting ingests the files of the snapshot.
+	ApplyPhaseIngesting ApplyPhase = 2
+)
+
+func (p ApplyPhase) String() string {
+	switch p {
+	case ApplyPhaseCleaning:
+		return "cleaning"
+	case ApplyPhaseIngesting:
+		return "ingesting"
+	}
+	return "unknown"
+}
+
+// ApplyProgress is the progress of a snapshot being applied, so a stuck
+// apply can be told from a slow one.
+type ApplyProgress struct {
+	Key          SnapKey
+	Phase        ApplyPhase
+	AppliedBytes uint64
+	TotalBytes   uint64
+	Start        time.Time
 }
 
 type SnapManager struct {
@@ -50,6 +81,8 @@ type SnapManager struct {
 	snapSize     *int64
 	registryLock sync.RWMutex
 	registry     map[SnapKey][]SnapEntry
+	// applying is the progress of the snapshots registered as applying.
+	applying     map[SnapKey]*ApplyProgress
 	MaxTotalSize uint64
 }
 
@@ -273,6 +306,9 @@ func (sm *SnapManager) Deregister(key SnapKey, entry SnapEntry) {
 			} else {
 				delete(sm.registry, key)
 			}
+			if entry == SnapEntryApplying {
+				delete(sm.applying, key)
+			}
 			return
 		}
 	}
@@ -300,7 +336,36 @@ func (sm *SnapManager) Stats() SnapStats {
 			receivingCount++
 		}
 	}
-	return SnapStats{SendingCount: sendingCount, ReceivingCount: receivingCount}
+	return SnapStats{SendingCount: sendingCount, ReceivingCount: receivingCount, ApplyingCount: len(sm.applying)}
+}
+
+// UpdateApplyProgress records the progress of the snapshot key being applied,
+// which must be registered as SnapEntryApplying. The progress is forgotten
+// when it is deregistered.
+func (sm *SnapManager) UpdateApplyProgress(key SnapKey, phase ApplyPhase, appliedBytes, totalBytes uint64) {
+	sm.registryLock.Lock()
+	defer sm.registryLock.Unlock()
+	p, ok := sm.applying[key]
+	if !ok {
+		p = &ApplyProgress{Key: key, Start: time.Now()}
+		sm.applying[key] = p
+	}
+	p.Phase, p.AppliedBytes, p.TotalBytes = phase, appliedBytes, totalBytes
+}
+
+// ApplyProgresses returns the progress of the snapshots being applied,
+// ordered by region.
+func (sm *SnapManager) ApplyProgresses() []ApplyProgress {
+	sm.registryLock.RLock()
+	defer sm.registryLock.RUnlock()
+	progresses := make([]ApplyProgress, 0, len(sm.applying))
+	for _, p := range sm.applying {
+		progresses = append(progresses, *p)
+	}
+	sort.Slice(progresses, func(i, j int) bool {
+		return progresses[i].Key.RegionID < progresses[j].Key.RegionID
+	})
+	return progresses
 }
 
 func (sm *SnapManager) DeleteSnapshot(key SnapKey, snapshot Snapshot, checkEntry bool) bool {
@@ -340,6 +405,7 @@ func (smb *SnapManagerBuilder) Build(path string) *SnapManager {
 		base:         path,
 		snapSize:     new(int64),
 		registry:     map[SnapKey][]SnapEntry{},
+		applying:     map[SnapKey]*ApplyProgress{},
 		MaxTotalSize: maxTotalSize,
 	}
 }
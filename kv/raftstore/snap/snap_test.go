@@ -221,12 +221,19 @@ func doTestSnapFile(t *testing.T, dbHasData bool) {
 	defer os.RemoveAll(dstDBDir)
 
 	dstDB := openDB(t, dstDBDir)
+	var applied uint64
 	opts := ApplyOptions{
 		DB:     dstDB,
 		Region: region,
+		Progress: func(n uint64) {
+			assert.True(t, n > applied)
+			applied = n
+		},
 	}
 	err = s4.Apply(opts)
 	require.Nil(t, err, errors.ErrorStack(err))
+	// Ensure the progress reaches the size of the snapshot.
+	assert.Equal(t, uint64(size), applied)
 
 	// Ensure `delete()` works to delete the dest snapshot.
 	s4.Delete()
@@ -239,3 +246,34 @@ func doTestSnapFile(t *testing.T, dbHasData bool) {
 		assertEqDB(t, db, dstDB)
 	}
 }
+
+func TestSnapManagerApplyProgress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	mgr := NewSnapManager(dir)
+	key1 := SnapKey{RegionID: 2, Term: 1, Index: 5}
+	key2 := SnapKey{RegionID: 1, Term: 1, Index: 5}
+
+	mgr.Register(key1, SnapEntryApplying)
+	mgr.Register(key2, SnapEntryApplying)
+	mgr.UpdateApplyProgress(key1, ApplyPhaseCleaning, 0, 100)
+	mgr.UpdateApplyProgress(key2, ApplyPhaseCleaning, 0, 50)
+	mgr.UpdateApplyProgress(key1, ApplyPhaseIngesting, 40, 100)
+	progresses := mgr.ApplyProgresses()
+	require.Equal(t, 2, len(progresses))
+	assert.Equal(t, key2, progresses[0].Key)
+	assert.Equal(t, ApplyPhaseCleaning, progresses[0].Phase)
+	assert.Equal(t, key1, progresses[1].Key)
+	assert.Equal(t, ApplyPhaseIngesting, progresses[1].Phase)
+	assert.Equal(t, uint64(40), progresses[1].AppliedBytes)
+	assert.Equal(t, uint64(100), progresses[1].TotalBytes)
+	assert.Equal(t, 2, mgr.Stats().ApplyingCount)
+
+	// The progress is forgotten once the apply is done.
+	mgr.Deregister(key1, SnapEntryApplying)
+	progresses = mgr.ApplyProgresses()
+	require.Equal(t, 1, len(progresses))
+	assert.Equal(t, key2, progresses[0].Key)
+	assert.Equal(t, 1, mgr.Stats().ApplyingCount)
+}
@@ -23,6 +23,7 @@ type StoreTick int
 const (
 	StoreTickSchedulerStoreHeartbeat StoreTick = 1
 	StoreTickSnapGC                  StoreTick = 2
+	StoreTickCompactCheck            StoreTick = 3
 )
 
 type storeState struct {
@@ -72,6 +73,8 @@ func (d *storeWorker) onTick(tick StoreTick) {
 		d.onSchedulerStoreHeartbeatTick()
 	case StoreTickSnapGC:
 		d.onSnapMgrGC()
+	case StoreTickCompactCheck:
+		d.onCompactCheck()
 	}
 }
 
@@ -92,6 +95,7 @@ func (d *storeWorker) start(store *metapb.Store) {
 	d.id = store.Id
 	d.ticker.scheduleStore(StoreTickSchedulerStoreHeartbeat)
 	d.ticker.scheduleStore(StoreTickSnapGC)
+	d.ticker.scheduleStore(StoreTickCompactCheck)
 }
 
 /// Checks if the message is targeting a stale peer.
@@ -314,3 +318,10 @@ func (d *storeWorker) onSnapMgrGC() {
 	}
 	d.ticker.scheduleStore(StoreTickSnapGC)
 }
+
+func (d *storeWorker) onCompactCheck() {
+	if threshold := d.ctx.cfg.CompactTombstoneThreshold; threshold > 0 && d.ctx.engine.Tombstones.Len() > 0 {
+		d.ctx.regionTaskSender <- &runner.RegionTaskCompact{Threshold: threshold}
+	}
+	d.ticker.scheduleStore(StoreTickCompactCheck)
+}
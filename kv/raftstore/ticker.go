@@ -1,10 +1,12 @@
 package raftstore
 
 import (
+	"math/rand"
 	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/log"
 )
 
 type ticker struct {
@@ -40,6 +42,7 @@ func newStoreTicker(cfg *config.Config) *ticker {
 	}
 	t.schedules[int(StoreTickSchedulerStoreHeartbeat)].interval = int64(cfg.SchedulerStoreHeartbeatTickInterval / baseInterval)
 	t.schedules[int(StoreTickSnapGC)].interval = int64(SnapMgrGcTickInterval / baseInterval)
+	t.schedules[int(StoreTickCompactCheck)].interval = int64(cfg.CompactCheckTickInterval / baseInterval)
 	return t
 }
 
@@ -78,40 +81,109 @@ func (t *ticker) scheduleStore(tp StoreTick) {
 	sched.runAt = t.tick + sched.interval
 }
 
+// tickDriverSlots is the number of times per base tick interval the tick
+// driver wakes up. Every region ticks once per base interval, but in the
+// slot picked by its jitter, so thousands of regions don't tick, and time
+// out their elections, in lockstep.
+const tickDriverSlots = 4
+
+// regionClock converts wall-clock time into logical ticks of one region.
+type regionClock struct {
+	// start is the registration time shifted by a random jitter.
+	start time.Time
+	// fired is the number of ticks delivered since start.
+	fired int64
+}
+
+func newRegionClock(now time.Time, baseTickInterval time.Duration) *regionClock {
+	jitter := time.Duration(rand.Int63n(int64(baseTickInterval)))
+	return &regionClock{start: now.Add(jitter)}
+}
+
+// due returns the number of ticks which became due since the last call.
+// It is more than one when the driver missed some intervals.
+func (c *regionClock) due(now time.Time, baseTickInterval time.Duration) int {
+	if now.Before(c.start) {
+		return 0
+	}
+	total := int64(now.Sub(c.start) / baseTickInterval)
+	n := total - c.fired
+	c.fired = total
+	return int(n)
+}
+
+// tickBatch returns the number of logical ticks carried by a tick message
+// and whether they were missed during a stall.
+func tickBatch(msg message.Msg) (int, bool) {
+	if tick, ok := msg.Data.(*message.MsgTick); ok && tick.Ticks > 0 {
+		return tick.Ticks, tick.Stalled
+	}
+	return 1, false
+}
+
+// isStalled reports whether the driver woke up so late that ticks were missed
+// by the whole store, e.g. during a GC pause or CPU starvation, rather than
+// by a single slow region.
+func isStalled(last, now time.Time, baseTickInterval time.Duration) bool {
+	return !last.IsZero() && now.Sub(last) > 2*baseTickInterval
+}
+
 type tickDriver struct {
 	baseTickInterval time.Duration
 	newRegionCh      chan uint64
-	regions          map[uint64]struct{}
+	regions          map[uint64]*regionClock
 	router           *router
 	storeTicker      *ticker
+	storeClock       *regionClock
+	lastWake         time.Time
 }
 
 func newTickDriver(baseTickInterval time.Duration, router *router, storeTicker *ticker) *tickDriver {
 	return &tickDriver{
 		baseTickInterval: baseTickInterval,
 		newRegionCh:      make(chan uint64),
-		regions:          make(map[uint64]struct{}),
+		regions:          make(map[uint64]*regionClock),
 		router:           router,
 		storeTicker:      storeTicker,
+		storeClock:       &regionClock{start: time.Now()},
 	}
 }
 
 func (r *tickDriver) run() {
-	timer := time.Tick(r.baseTickInterval)
+	interval := r.baseTickInterval / tickDriverSlots
+	if interval <= 0 {
+		interval = r.baseTickInterval
+	}
+	timer := time.NewTicker(interval)
+	defer timer.Stop()
 	for {
 		select {
-		case <-timer:
-			for regionID := range r.regions {
-				if r.router.send(regionID, message.NewPeerMsg(message.MsgTypeTick, regionID, nil)) != nil {
+		case now := <-timer.C:
+			stalled := isStalled(r.lastWake, now, r.baseTickInterval)
+			if stalled {
+				log.Warnf("tick driver stalled for %v", now.Sub(r.lastWake))
+			}
+			r.lastWake = now
+			for regionID, clock := range r.regions {
+				n := clock.due(now, r.baseTickInterval)
+				if n == 0 {
+					continue
+				}
+				tick := &message.MsgTick{Ticks: n, Stalled: stalled && n > 1}
+				if r.router.send(regionID, message.NewPeerMsg(message.MsgTypeTick, regionID, tick)) != nil {
 					delete(r.regions, regionID)
 				}
 			}
-			r.tickStore()
+			for n := r.storeClock.due(now, r.baseTickInterval); n > 0; n-- {
+				r.tickStore()
+			}
 		case regionID, ok := <-r.newRegionCh:
 			if !ok {
 				return
 			}
-			r.regions[regionID] = struct{}{}
+			if _, ok := r.regions[regionID]; !ok {
+				r.regions[regionID] = newRegionClock(time.Now(), r.baseTickInterval)
+			}
 		}
 	}
 }
@@ -0,0 +1,40 @@
+package raftstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegionClockBatchesMissedTicks(t *testing.T) {
+	base := 100 * time.Millisecond
+	now := time.Now()
+	clock := newRegionClock(now, base)
+	require.True(t, !clock.start.Before(now) && clock.start.Before(now.Add(base)))
+
+	require.Equal(t, 0, clock.due(clock.start.Add(base/2), base))
+	require.Equal(t, 1, clock.due(clock.start.Add(base), base))
+	require.Equal(t, 0, clock.due(clock.start.Add(base+base/2), base))
+	// The driver stalled for three intervals.
+	require.Equal(t, 3, clock.due(clock.start.Add(4*base), base))
+}
+
+func TestTickBatch(t *testing.T) {
+	n, stalled := tickBatch(message.NewPeerMsg(message.MsgTypeTick, 1, nil))
+	require.Equal(t, 1, n)
+	require.False(t, stalled)
+	n, stalled = tickBatch(message.NewPeerMsg(message.MsgTypeTick, 1, &message.MsgTick{Ticks: 3, Stalled: true}))
+	require.Equal(t, 3, n)
+	require.True(t, stalled)
+}
+
+func TestIsStalled(t *testing.T) {
+	base := 100 * time.Millisecond
+	now := time.Now()
+	require.False(t, isStalled(time.Time{}, now, base))
+	require.False(t, isStalled(now, now.Add(base/4), base))
+	require.False(t, isStalled(now, now.Add(2*base), base))
+	require.True(t, isStalled(now, now.Add(5*base), base))
+}
@@ -6,8 +6,11 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/meta"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	"github.com/pingcap/errors"
@@ -36,16 +39,40 @@ func CheckKeyInRegion(key []byte, region *metapb.Region) error {
 	if bytes.Compare(key, region.StartKey) >= 0 && (len(region.EndKey) == 0 || bytes.Compare(key, region.EndKey) < 0) {
 		return nil
 	} else {
-		return &ErrKeyNotInRegion{Key: key, Region: region}
+		return &kverrors.ErrKeyNotInRegion{Key: key, Region: region}
 	}
 }
 
+// CheckRequestKeysInRegion checks that every key read or written by the normal
+// requests in req falls in the region range [`start_key`, `end_key`). It must
+// be checked again right before a write is applied, as the region may have been
+// split after the write was proposed.
+func CheckRequestKeysInRegion(req *raft_cmdpb.RaftCmdRequest, region *metapb.Region) error {
+	for _, r := range req.Requests {
+		var key []byte
+		switch r.CmdType {
+		case raft_cmdpb.CmdType_Get:
+			key = r.Get.GetKey()
+		case raft_cmdpb.CmdType_Put:
+			key = r.Put.GetKey()
+		case raft_cmdpb.CmdType_Delete:
+			key = r.Delete.GetKey()
+		default:
+			continue
+		}
+		if err := CheckKeyInRegion(key, region); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 /// Check if key in region range (`start_key`, `end_key`).
 func CheckKeyInRegionExclusive(key []byte, region *metapb.Region) error {
 	if bytes.Compare(region.StartKey, key) < 0 && (len(region.EndKey) == 0 || bytes.Compare(key, region.EndKey) < 0) {
 		return nil
 	} else {
-		return &ErrKeyNotInRegion{Key: key, Region: region}
+		return &kverrors.ErrKeyNotInRegion{Key: key, Region: region}
 	}
 }
 
@@ -54,7 +81,7 @@ func CheckKeyInRegionInclusive(key []byte, region *metapb.Region) error {
 	if bytes.Compare(key, region.StartKey) >= 0 && (len(region.EndKey) == 0 || bytes.Compare(key, region.EndKey) <= 0) {
 		return nil
 	} else {
-		return &ErrKeyNotInRegion{Key: key, Region: region}
+		return &kverrors.ErrKeyNotInRegion{Key: key, Region: region}
 	}
 }
 
@@ -124,7 +151,7 @@ func CheckRegionEpoch(req *raft_cmdpb.RaftCmdRequest, region *metapb.Region, inc
 		if includeRegion {
 			regions = []*metapb.Region{region}
 		}
-		return &ErrEpochNotMatch{Message: fmt.Sprintf("current epoch of region %v is %v, but you sent %v",
+		return &kverrors.ErrEpochNotMatch{Message: fmt.Sprintf("current epoch of region %v is %v, but you sent %v",
 			region.Id, currentEpoch, fromEpoch), Regions: regions}
 	}
 
@@ -172,7 +199,39 @@ func CheckTerm(req *raft_cmdpb.RaftCmdRequest, term uint64) error {
 	}
 	// If header's term is 2 verions behind current term,
 	// leadership may have been changed away.
-	return &ErrStaleCommand{}
+	return &kverrors.ErrStaleCommand{Class: errorpb.ErrorClass_LeaderChanging}
+}
+
+// IsWriteRequest returns true if the normal requests in req modify data.
+func IsWriteRequest(req *raft_cmdpb.RaftCmdRequest) bool {
+	for _, r := range req.Requests {
+		switch r.CmdType {
+		case raft_cmdpb.CmdType_Put, raft_cmdpb.CmdType_Delete:
+			return true
+		}
+	}
+	return false
+}
+
+// CheckRequestCFs checks that every write in req targets a known CF. A command
+// is applied atomically, so it is rejected as a whole before being proposed
+// rather than failing half way through applying it.
+func CheckRequestCFs(req *raft_cmdpb.RaftCmdRequest) error {
+	for _, r := range req.Requests {
+		var cf string
+		switch r.CmdType {
+		case raft_cmdpb.CmdType_Put:
+			cf = r.Put.GetCf()
+		case raft_cmdpb.CmdType_Delete:
+			cf = r.Delete.GetCf()
+		default:
+			continue
+		}
+		if !engine_util.IsValidCF(cf) {
+			return errors.Errorf("invalid cf %q", cf)
+		}
+	}
+	return nil
 }
 
 func CheckPeerID(req *raft_cmdpb.RaftCmdRequest, peerID uint64) error {
@@ -3,10 +3,12 @@ package util
 import (
 	"testing"
 
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCheckKeyInRegion(t *testing.T) {
@@ -43,6 +45,23 @@ func TestCheckKeyInRegion(t *testing.T) {
 	}
 }
 
+func TestCheckRequestKeysInRegion(t *testing.T) {
+	region := &metapb.Region{StartKey: []byte("b"), EndKey: []byte("d")}
+	req := new(raft_cmdpb.RaftCmdRequest)
+	req.Requests = append(req.Requests,
+		&raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Snap, Snap: &raft_cmdpb.SnapRequest{}},
+		&raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Get, Get: &raft_cmdpb.GetRequest{Key: []byte("b")}},
+		&raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Key: []byte("c")}},
+	)
+	assert.Nil(t, CheckRequestKeysInRegion(req, region))
+
+	req.Requests = append(req.Requests,
+		&raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Delete, Delete: &raft_cmdpb.DeleteRequest{Key: []byte("d")}})
+	err := CheckRequestKeysInRegion(req, region)
+	require.IsType(t, &kverrors.ErrKeyNotInRegion{}, err)
+	assert.Equal(t, []byte("d"), err.(*kverrors.ErrKeyNotInRegion).Key)
+}
+
 func TestIsInitialMsg(t *testing.T) {
 	type MsgInfo struct {
 		MessageType  eraftpb.MessageType
@@ -63,6 +82,26 @@ func TestIsInitialMsg(t *testing.T) {
 	}
 }
 
+func TestIsWriteRequest(t *testing.T) {
+	req := new(raft_cmdpb.RaftCmdRequest)
+	assert.False(t, IsWriteRequest(req))
+	req.Requests = append(req.Requests, &raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Get})
+	assert.False(t, IsWriteRequest(req))
+	req.Requests = append(req.Requests, &raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Delete})
+	assert.True(t, IsWriteRequest(req))
+}
+
+func TestCheckRequestCFs(t *testing.T) {
+	req := new(raft_cmdpb.RaftCmdRequest)
+	req.Requests = append(req.Requests,
+		&raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Cf: "lock"}},
+		&raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Put, Put: &raft_cmdpb.PutRequest{Cf: "default"}})
+	assert.Nil(t, CheckRequestCFs(req))
+	req.Requests = append(req.Requests,
+		&raft_cmdpb.Request{CmdType: raft_cmdpb.CmdType_Delete, Delete: &raft_cmdpb.DeleteRequest{Cf: "unknown"}})
+	assert.NotNil(t, CheckRequestCFs(req))
+}
+
 func TestEpochStale(t *testing.T) {
 	epoch := new(metapb.RegionEpoch)
 	epoch.Version = 10
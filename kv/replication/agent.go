@@ -0,0 +1,172 @@
+// Package replication replicates the transactions committed in a key range of
+// one cluster to another cluster, asynchronously, by consuming the EventFeed
+// streams of the source stores.
+package replication
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap/errors"
+)
+
+// Txn is a transaction committed on the source cluster.
+type Txn struct {
+	StartTs, CommitTs uint64
+	Rows              []*kvrpcpb.ChangeDataRow
+}
+
+// Sink applies the transactions replicated to the target cluster.
+type Sink interface {
+	Apply(ctx context.Context, txn *Txn) error
+}
+
+// Checkpoint persists the ts up to which the transactions are replicated, so
+// a restarted agent resumes where it stopped.
+type Checkpoint interface {
+	Load() (uint64, error)
+	Save(ts uint64) error
+}
+
+// EventStream is an EventFeed stream of one source store.
+type EventStream interface {
+	Recv() (*kvrpcpb.ChangeDataEvent, error)
+}
+
+// Agent replicates the transactions received on the streams of all the
+// source stores. A transaction is applied once every stream resolved its
+// commit ts, in commit ts order, and the checkpoint is saved after each
+// batch of transactions applied. EventFeed streams start when they are opened,
+// so the transactions committed while no agent runs are not replicated.
+type Agent struct {
+	streams    []EventStream
+	sink       Sink
+	checkpoint Checkpoint
+
+	// resolved is the resolved ts of each stream.
+	resolved []uint64
+	// checkpointTs is the last ts saved to the checkpoint.
+	checkpointTs uint64
+	// pending holds the transactions received but not applied yet, by
+	// start ts and commit ts.
+	pending map[txnKey]*Txn
+}
+
+type txnKey struct {
+	startTs, commitTs uint64
+}
+
+type streamEvent struct {
+	idx   int
+	event *kvrpcpb.ChangeDataEvent
+	err   error
+}
+
+func NewAgent(streams []EventStream, sink Sink, checkpoint Checkpoint) *Agent {
+	return &Agent{
+		streams:    streams,
+		sink:       sink,
+		checkpoint: checkpoint,
+		resolved:   make([]uint64, len(streams)),
+		pending:    make(map[txnKey]*Txn),
+	}
+}
+
+// Run replicates until ctx is done or a stream or the sink fails.
+func (a *Agent) Run(ctx context.Context) error {
+	ts, err := a.checkpoint.Load()
+	if err != nil {
+		return err
+	}
+	a.checkpointTs = ts
+	log.Infof("replication starts from checkpoint %d", ts)
+
+	events := make(chan streamEvent)
+	for i, stream := range a.streams {
+		go func(i int, stream EventStream) {
+			for {
+				event, err := stream.Recv()
+				select {
+				case events <- streamEvent{idx: i, event: event, err: err}:
+				case <-ctx.Done():
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}(i, stream)
+	}
+
+	for {
+		select {
+		case e := <-events:
+			if e.err != nil {
+				return e.err
+			}
+			if err := a.handleEvent(ctx, e.idx, e.event); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (a *Agent) handleEvent(ctx context.Context, idx int, event *kvrpcpb.ChangeDataEvent) error {
+	if event.RegionError != nil {
+		return errors.Errorf("event feed %d failed: %s", idx, event.RegionError)
+	}
+	for _, row := range event.Rows {
+		if row.CommitTs <= a.checkpointTs {
+			// Applied before the restart.
+			continue
+		}
+		key := txnKey{startTs: row.StartTs, commitTs: row.CommitTs}
+		txn := a.pending[key]
+		if txn == nil {
+			txn = &Txn{StartTs: row.StartTs, CommitTs: row.CommitTs}
+			a.pending[key] = txn
+		}
+		txn.Rows = append(txn.Rows, row)
+	}
+	if event.ResolvedTs > a.resolved[idx] {
+		a.resolved[idx] = event.ResolvedTs
+		return a.flush(ctx)
+	}
+	return nil
+}
+
+// flush applies the pending transactions resolved by every stream.
+func (a *Agent) flush(ctx context.Context) error {
+	resolved := a.resolved[0]
+	for _, ts := range a.resolved[1:] {
+		if ts < resolved {
+			resolved = ts
+		}
+	}
+	if resolved <= a.checkpointTs {
+		return nil
+	}
+
+	var txns []*Txn
+	for key, txn := range a.pending {
+		if key.commitTs <= resolved {
+			txns = append(txns, txn)
+			delete(a.pending, key)
+		}
+	}
+	sort.Slice(txns, func(i, j int) bool { return txns[i].CommitTs < txns[j].CommitTs })
+	for _, txn := range txns {
+		if err := a.sink.Apply(ctx, txn); err != nil {
+			return err
+		}
+	}
+	if err := a.checkpoint.Save(resolved); err != nil {
+		return err
+	}
+	a.checkpointTs = resolved
+	return nil
+}
@@ -0,0 +1,179 @@
+package replication
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chanStream chan *kvrpcpb.ChangeDataEvent
+
+func (s chanStream) Recv() (*kvrpcpb.ChangeDataEvent, error) {
+	event, ok := <-s
+	if !ok {
+		return nil, io.EOF
+	}
+	return event, nil
+}
+
+type memSink struct {
+	applied chan *Txn
+}
+
+func (s *memSink) Apply(ctx context.Context, txn *Txn) error {
+	s.applied <- txn
+	return nil
+}
+
+type memCheckpoint struct {
+	mu sync.Mutex
+	ts uint64
+}
+
+func (c *memCheckpoint) Load() (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ts, nil
+}
+
+func (c *memCheckpoint) Save(ts uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ts = ts
+	return nil
+}
+
+func row(key string, startTs, commitTs uint64) *kvrpcpb.ChangeDataRow {
+	return &kvrpcpb.ChangeDataRow{Op: kvrpcpb.Op_Put, Key: []byte(key), Value: []byte(key), StartTs: startTs, CommitTs: commitTs}
+}
+
+func expectApplied(t *testing.T, sink *memSink, commitTs uint64, keys ...string) {
+	select {
+	case txn := <-sink.applied:
+		require.Equal(t, commitTs, txn.CommitTs)
+		require.Len(t, txn.Rows, len(keys))
+		for i, key := range keys {
+			assert.Equal(t, key, string(txn.Rows[i].Key))
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("txn committed at %d is not applied", commitTs)
+	}
+}
+
+func expectNothingApplied(t *testing.T, sink *memSink) {
+	select {
+	case txn := <-sink.applied:
+		t.Fatalf("unexpected txn committed at %d", txn.CommitTs)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAgentAppliesResolvedTxnsInOrder(t *testing.T) {
+	s1, s2 := make(chanStream), make(chanStream)
+	sink := &memSink{applied: make(chan *Txn, 10)}
+	checkpoint := new(memCheckpoint)
+	agent := NewAgent([]EventStream{s1, s2}, sink, checkpoint)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- agent.Run(ctx) }()
+
+	s1 <- &kvrpcpb.ChangeDataEvent{Rows: []*kvrpcpb.ChangeDataRow{row("b", 15, 20), row("a", 5, 10)}}
+	s2 <- &kvrpcpb.ChangeDataEvent{Rows: []*kvrpcpb.ChangeDataRow{row("c", 15, 20)}}
+	s1 <- &kvrpcpb.ChangeDataEvent{ResolvedTs: 30}
+	// The second stream hasn't resolved anything yet.
+	expectNothingApplied(t, sink)
+
+	s2 <- &kvrpcpb.ChangeDataEvent{ResolvedTs: 15}
+	expectApplied(t, sink, 10, "a")
+	expectNothingApplied(t, sink)
+	ts, _ := checkpoint.Load()
+	assert.Equal(t, uint64(15), ts)
+
+	s2 <- &kvrpcpb.ChangeDataEvent{ResolvedTs: 40}
+	expectApplied(t, sink, 20, "b", "c")
+	expectNothingApplied(t, sink)
+	ts, _ = checkpoint.Load()
+	assert.Equal(t, uint64(30), ts)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestAgentResumesFromCheckpoint(t *testing.T) {
+	s := make(chanStream)
+	sink := &memSink{applied: make(chan *Txn, 10)}
+	checkpoint := &memCheckpoint{ts: 15}
+	agent := NewAgent([]EventStream{s}, sink, checkpoint)
+	done := make(chan error)
+	go func() { done <- agent.Run(context.Background()) }()
+
+	s <- &kvrpcpb.ChangeDataEvent{Rows: []*kvrpcpb.ChangeDataRow{row("a", 5, 10), row("b", 15, 20)}, ResolvedTs: 10}
+	expectNothingApplied(t, sink)
+	s <- &kvrpcpb.ChangeDataEvent{ResolvedTs: 20}
+	expectApplied(t, sink, 20, "b")
+	expectNothingApplied(t, sink)
+
+	close(s)
+	assert.Equal(t, io.EOF, <-done)
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	c := NewFileCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+	ts, err := c.Load()
+	require.Nil(t, err)
+	assert.Equal(t, uint64(0), ts)
+
+	require.Nil(t, c.Save(42))
+	require.Nil(t, c.Save(43))
+	ts, err = c.Load()
+	require.Nil(t, err)
+	assert.Equal(t, uint64(43), ts)
+}
+
+// gcScheduler holds GC at the oldest service safe point, or at gcSafePoint.
+type gcScheduler struct {
+	scheduler_client.Client
+	gcSafePoint uint64
+	safePoints  map[string]uint64
+}
+
+func (s *gcScheduler) UpdateServiceGCSafePoint(ctx context.Context, serviceID string, ttl int64, safePoint uint64) (uint64, error) {
+	if safePoint >= s.gcSafePoint {
+		s.safePoints[serviceID] = safePoint
+	}
+	min := s.gcSafePoint
+	for _, ts := range s.safePoints {
+		if ts < min {
+			min = ts
+		}
+	}
+	return min, nil
+}
+
+func TestGCCheckpoint(t *testing.T) {
+	scheduler := &gcScheduler{gcSafePoint: 10, safePoints: make(map[string]uint64)}
+	c := NewGCCheckpoint(new(memCheckpoint), scheduler, "test")
+	ts, err := c.Load()
+	require.Nil(t, err)
+	assert.Equal(t, uint64(0), ts)
+
+	require.Nil(t, c.Save(20))
+	assert.Equal(t, uint64(20), scheduler.safePoints["test"])
+	ts, err = c.Load()
+	require.Nil(t, err)
+	assert.Equal(t, uint64(20), ts)
+
+	// GC passed the checkpoint, the agent can't resume from it.
+	scheduler.gcSafePoint = 30
+	delete(scheduler.safePoints, "test")
+	_, err = c.Load()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,89 @@
+package replication
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
+	"github.com/pingcap/errors"
+)
+
+// serviceSafePointTTL is how long, in seconds, the source cluster keeps the
+// data at a checkpoint after it is saved.
+const serviceSafePointTTL = 10 * 60
+
+// FileCheckpoint saves the checkpoint in a file, replaced atomically.
+type FileCheckpoint struct {
+	path string
+}
+
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load returns the saved checkpoint, or 0 if there is none yet.
+func (c *FileCheckpoint) Load() (uint64, error) {
+	data, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+func (c *FileCheckpoint) Save(ts uint64) error {
+	tmp := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strconv.FormatUint(ts, 10)), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// GCCheckpoint registers the checkpoint as a service safe point of the source
+// cluster, so GC keeps the versions the agent still reads.
+type GCCheckpoint struct {
+	Checkpoint
+	scheduler scheduler_client.Client
+	serviceID string
+}
+
+func NewGCCheckpoint(checkpoint Checkpoint, scheduler scheduler_client.Client, serviceID string) *GCCheckpoint {
+	return &GCCheckpoint{Checkpoint: checkpoint, scheduler: scheduler, serviceID: serviceID}
+}
+
+// Load returns the saved checkpoint, once it is registered. GC may have
+// passed it already while the agent was down, then resuming would miss
+// transactions.
+func (c *GCCheckpoint) Load() (uint64, error) {
+	ts, err := c.Checkpoint.Load()
+	if err != nil || ts == 0 {
+		return ts, err
+	}
+	if err := c.register(ts); err != nil {
+		return 0, err
+	}
+	return ts, nil
+}
+
+func (c *GCCheckpoint) Save(ts uint64) error {
+	if err := c.register(ts); err != nil {
+		return err
+	}
+	return c.Checkpoint.Save(ts)
+}
+
+func (c *GCCheckpoint) register(ts uint64) error {
+	min, err := c.scheduler.UpdateServiceGCSafePoint(context.Background(), c.serviceID, serviceSafePointTTL, ts)
+	if err != nil {
+		return err
+	}
+	if ts < min {
+		return errors.Errorf("checkpoint %d is below the GC safe point %d", ts, min)
+	}
+	return nil
+}
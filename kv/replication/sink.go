@@ -0,0 +1,241 @@
+package replication
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/scheduler_client"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+	"google.golang.org/grpc"
+)
+
+// ConflictPolicy decides what to do with a replicated transaction whose keys
+// are locked or written by a transaction of the target cluster.
+type ConflictPolicy int
+
+const (
+	// ConflictOverwrite retries the transaction until it is applied, the
+	// source cluster wins.
+	ConflictOverwrite ConflictPolicy = iota
+	// ConflictSkip drops the transaction, the target cluster wins.
+	ConflictSkip
+)
+
+const (
+	// lockTTL is the TTL of the locks of the replicated transactions, in
+	// milliseconds.
+	lockTTL = 3000
+	// maxAttempts bounds the retries of a transaction on region errors and
+	// conflicts.
+	maxAttempts = 10
+	// regionCacheTTL bounds how long the sink routes by a region nothing
+	// reported stale.
+	regionCacheTTL = 10 * time.Second
+)
+
+var errConflict = errors.New("replication: transaction conflicts with the target cluster")
+
+// ClusterSink applies the replicated transactions to a TinyKV cluster, as new
+// transactions with timestamps of the target cluster.
+type ClusterSink struct {
+	scheduler scheduler_client.Client
+	regions   *scheduler_client.RegionCache
+	policy    ConflictPolicy
+
+	mu    sync.Mutex
+	conns map[uint64]tinykvpb.TinyKvClient
+}
+
+func NewClusterSink(scheduler scheduler_client.Client, policy ConflictPolicy) *ClusterSink {
+	return &ClusterSink{
+		scheduler: scheduler,
+		regions:   scheduler_client.NewRegionCache(scheduler, regionCacheTTL),
+		policy:    policy,
+		conns:     make(map[uint64]tinykvpb.TinyKvClient),
+	}
+}
+
+func (s *ClusterSink) Apply(ctx context.Context, txn *Txn) error {
+	if len(txn.Rows) == 0 {
+		return nil
+	}
+	var err error
+	for i := 0; i < maxAttempts; i++ {
+		err = s.apply(ctx, txn)
+		if err == errConflict && s.policy == ConflictSkip {
+			log.Warnf("skip replicated txn %d committed at %d, it conflicts with the target cluster", txn.StartTs, txn.CommitTs)
+			return nil
+		}
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+	}
+	return errors.Annotatef(err, "failed to replicate txn %d committed at %d", txn.StartTs, txn.CommitTs)
+}
+
+// regionMutations are the mutations of a transaction in one region.
+type regionMutations struct {
+	ctx       *kvrpcpb.Context
+	client    tinykvpb.TinyKvClient
+	mutations []*kvrpcpb.Mutation
+}
+
+func (rm *regionMutations) keys() [][]byte {
+	keys := make([][]byte, 0, len(rm.mutations))
+	for _, m := range rm.mutations {
+		keys = append(keys, m.Key)
+	}
+	return keys
+}
+
+// apply runs the two phases of a transaction writing the rows of txn. The
+// primary key is the first one, so it is committed first.
+func (s *ClusterSink) apply(ctx context.Context, txn *Txn) error {
+	groups, err := s.groupByRegion(ctx, txn.Rows)
+	if err != nil {
+		return err
+	}
+	startTs, err := s.scheduler.GetTS(ctx)
+	if err != nil {
+		return err
+	}
+	primary := txn.Rows[0].Key
+
+	for i, g := range groups {
+		resp, err := g.client.KvPrewrite(ctx, &kvrpcpb.PrewriteRequest{
+			Context:      g.ctx,
+			Mutations:    g.mutations,
+			PrimaryLock:  primary,
+			StartVersion: startTs,
+			LockTtl:      lockTTL,
+		})
+		if err == nil {
+			err = s.checkResponse(g, resp == nil, resp.GetRegionError())
+		}
+		if err == nil && len(resp.GetErrors()) > 0 {
+			err = errConflict
+		}
+		if err != nil {
+			s.rollback(ctx, groups[:i+1], startTs)
+			return err
+		}
+	}
+
+	commitTs, err := s.scheduler.GetTS(ctx)
+	if err != nil {
+		s.rollback(ctx, groups, startTs)
+		return err
+	}
+	for i, g := range groups {
+		resp, err := g.client.KvCommit(ctx, &kvrpcpb.CommitRequest{
+			Context:       g.ctx,
+			StartVersion:  startTs,
+			Keys:          g.keys(),
+			CommitVersion: commitTs,
+		})
+		if err == nil {
+			err = s.checkResponse(g, resp == nil, resp.GetRegionError())
+		}
+		if err == nil && resp.GetError() != nil {
+			err = errors.Errorf("commit failed: %s", resp.GetError())
+		}
+		if err != nil {
+			if i == 0 {
+				// The primary key is not committed, the transaction can
+				// still be rolled back.
+				s.rollback(ctx, groups, startTs)
+				return err
+			}
+			// The transaction is committed, the remaining locks are
+			// resolved by the readers of the target cluster.
+			log.Warnf("failed to commit the secondary keys of replicated txn %d: %v", startTs, err)
+			return nil
+		}
+	}
+	return nil
+}
+
+// checkResponse returns an error if the response of the region is empty or
+// a region error, which the region cache learns from.
+func (s *ClusterSink) checkResponse(g *regionMutations, empty bool, regionErr *errorpb.Error) error {
+	if empty {
+		return errors.New("empty response")
+	}
+	if regionErr != nil {
+		s.regions.OnRegionError(g.ctx.RegionId, regionErr)
+		return errors.Errorf("region error: %s", regionErr)
+	}
+	return nil
+}
+
+func (s *ClusterSink) rollback(ctx context.Context, groups []*regionMutations, startTs uint64) {
+	for _, g := range groups {
+		_, err := g.client.KvBatchRollback(ctx, &kvrpcpb.BatchRollbackRequest{
+			Context:      g.ctx,
+			StartVersion: startTs,
+			Keys:         g.keys(),
+		})
+		if err != nil {
+			log.Warnf("failed to roll back replicated txn %d: %v", startTs, err)
+		}
+	}
+}
+
+// groupByRegion splits the rows by the region of the target cluster they
+// belong to, the group of the first row first.
+func (s *ClusterSink) groupByRegion(ctx context.Context, rows []*kvrpcpb.ChangeDataRow) ([]*regionMutations, error) {
+	var groups []*regionMutations
+	byRegion := make(map[uint64]*regionMutations)
+	for _, row := range rows {
+		region, leader, err := s.regions.GetRegion(ctx, row.Key)
+		if err != nil {
+			return nil, err
+		}
+		if leader == nil {
+			return nil, errors.Errorf("region %d has no leader", region.GetId())
+		}
+		g := byRegion[region.Id]
+		if g == nil {
+			client, err := s.client(ctx, leader.StoreId)
+			if err != nil {
+				return nil, err
+			}
+			g = &regionMutations{
+				ctx:    &kvrpcpb.Context{RegionId: region.Id, RegionEpoch: region.RegionEpoch, Peer: leader},
+				client: client,
+			}
+			byRegion[region.Id] = g
+			groups = append(groups, g)
+		}
+		g.mutations = append(g.mutations, &kvrpcpb.Mutation{Op: row.Op, Key: row.Key, Value: row.Value})
+	}
+	return groups, nil
+}
+
+func (s *ClusterSink) client(ctx context.Context, storeID uint64) (tinykvpb.TinyKvClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.conns[storeID]; ok {
+		return c, nil
+	}
+	store, err := s.scheduler.GetStore(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	if store.GetState() == metapb.StoreState_Tombstone {
+		return nil, errors.Errorf("store %d is removed", storeID)
+	}
+	cc, err := grpc.Dial(store.Address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	c := tinykvpb.NewTinyKvClient(cc)
+	s.conns[storeID] = c
+	return c, nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// interceptors are the gRPC interceptors added by the embedder of the server,
+// see UseUnaryInterceptor and UseStreamInterceptor.
+type interceptors struct {
+	unary  []grpc.UnaryServerInterceptor
+	stream []grpc.StreamServerInterceptor
+}
+
+// UseUnaryInterceptor adds interceptors around the unary requests of the
+// server, e.g. to authenticate or to enforce quotas. They run in the order
+// they are added, the first one being the outermost. They must be added
+// before the gRPC server is created with GRPCServerOptions.
+func (server *Server) UseUnaryInterceptor(interceptors ...grpc.UnaryServerInterceptor) {
+	server.interceptors.unary = append(server.interceptors.unary, interceptors...)
+}
+
+// UseStreamInterceptor adds interceptors around the streams of the server, in
+// the same way as UseUnaryInterceptor.
+func (server *Server) UseStreamInterceptor(interceptors ...grpc.StreamServerInterceptor) {
+	server.interceptors.stream = append(server.interceptors.stream, interceptors...)
+}
+
+// GRPCServerOptions returns the options installing the interceptors of the
+// server, to pass to grpc.NewServer. gRPC takes a single interceptor of each
+// kind, so the interceptors added are chained into one.
+func (server *Server) GRPCServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if unary := server.interceptors.unary; len(unary) > 0 {
+		opts = append(opts, grpc.UnaryInterceptor(chainUnary(unary)))
+	}
+	if stream := server.interceptors.stream; len(stream) > 0 {
+		opts = append(opts, grpc.StreamInterceptor(chainStream(stream)))
+	}
+	return opts
+}
+
+func chainUnary(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	interceptors = append([]grpc.UnaryServerInterceptor(nil), interceptors...)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+func chainStream(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	interceptors = append([]grpc.StreamServerInterceptor(nil), interceptors...)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+		return next(srv, ss)
+	}
+}
@@ -0,0 +1,270 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/util/clock"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// keyspace is the namespace of the keys of a tenant, see UseKeyspaces. The
+// methods of a nil keyspace leave the keys as they are and admit everything,
+// for the servers without keyspaces.
+type keyspace struct {
+	name   string
+	prefix []byte
+
+	readQuota  byteQuota
+	writeQuota byteQuota
+	// ttl is the default TTL of the raw values and gcRetention the MVCC
+	// history kept, see config.KeyspaceConfig.
+	ttl         time.Duration
+	gcRetention time.Duration
+	// clock reads the time of the quotas and of the deadlines, which are
+	// stored with the values, so it must follow the wall clock.
+	clock clock.Clock
+
+	reads        uint64
+	writes       uint64
+	readBytes    uint64
+	writtenBytes uint64
+	throttled    uint64
+}
+
+// KeyspaceStats counts the requests served for a keyspace.
+type KeyspaceStats struct {
+	Name string
+	// Reads and Writes are the number of raw reads and writes served.
+	Reads  uint64
+	Writes uint64
+	// ReadBytes and WrittenBytes are the bytes of keys and values read and
+	// written, the keys being counted without the prefix.
+	ReadBytes    uint64
+	WrittenBytes uint64
+	// Throttled is the number of requests rejected for exceeding a quota.
+	Throttled uint64
+}
+
+// UseKeyspaces shares the server between tenants, each keyspace storing its
+// keys under its own prefix, see config.KeyspaceConfig. Once set, every raw
+// request must name one of them in its context, and only sees the keys of its
+// keyspace. The transactional and admin requests, e.g. UnsafeDestroyRange,
+// still address the whole key space, but the history of the keys under a
+// prefix is kept as long as its keyspace asks, see StartKeyspaceGC. It must
+// be called before the server serves requests, with a validated config.
+func (server *Server) UseKeyspaces(keyspaces []config.KeyspaceConfig) {
+	server.keyspaces = make(map[string]*keyspace, len(keyspaces))
+	for _, ks := range keyspaces {
+		server.keyspaces[ks.Name] = &keyspace{
+			name:        ks.Name,
+			prefix:      []byte(ks.Prefix),
+			readQuota:   byteQuota{limit: ks.MaxReadBytesPerSec},
+			writeQuota:  byteQuota{limit: ks.MaxWriteBytesPerSec},
+			ttl:         ks.DefaultTTL,
+			gcRetention: ks.GCRetention,
+			clock:       clock.Real,
+		}
+	}
+}
+
+// KeyspaceStats returns the counters of the keyspaces, sorted by name.
+func (server *Server) KeyspaceStats() []KeyspaceStats {
+	stats := make([]KeyspaceStats, 0, len(server.keyspaces))
+	for _, ks := range server.keyspaces {
+		stats = append(stats, KeyspaceStats{
+			Name:         ks.name,
+			Reads:        atomic.LoadUint64(&ks.reads),
+			Writes:       atomic.LoadUint64(&ks.writes),
+			ReadBytes:    atomic.LoadUint64(&ks.readBytes),
+			WrittenBytes: atomic.LoadUint64(&ks.writtenBytes),
+			Throttled:    atomic.LoadUint64(&ks.throttled),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// keyspace returns the keyspace named by ctx, or nil if the server has no
+// keyspaces.
+func (server *Server) keyspace(ctx *kvrpcpb.Context) (*keyspace, error) {
+	name := ctx.GetKeyspace()
+	if len(server.keyspaces) == 0 && name == "" {
+		return nil, nil
+	}
+	if name == "" {
+		return nil, fmt.Errorf("request must name a keyspace")
+	}
+	ks, ok := server.keyspaces[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown keyspace %q", name)
+	}
+	return ks, nil
+}
+
+// encode returns the key stored for key of the keyspace.
+func (ks *keyspace) encode(key []byte) []byte {
+	if ks == nil {
+		return key
+	}
+	encoded := make([]byte, 0, len(ks.prefix)+len(key))
+	return append(append(encoded, ks.prefix...), key...)
+}
+
+// decode returns the key of the keyspace stored as key, which must be
+// contained in it.
+func (ks *keyspace) decode(key []byte) []byte {
+	if ks == nil {
+		return key
+	}
+	return key[len(ks.prefix):]
+}
+
+// contains reports whether the stored key belongs to the keyspace.
+func (ks *keyspace) contains(key []byte) bool {
+	return ks == nil || bytes.HasPrefix(key, ks.prefix)
+}
+
+// The raw values of a keyspace are stored after a flag telling whether they
+// carry a deadline, so the values written before and after its TTL changed
+// are told apart.
+const (
+	valueNoTTL byte = 0
+	// valueTTL is followed by the deadline, in Unix nanoseconds.
+	valueTTL byte = 1
+)
+
+// encodeValue returns the value stored for value of the keyspace, with the
+// deadline of its TTL if it has one.
+func (ks *keyspace) encodeValue(value []byte) []byte {
+	if ks == nil {
+		return value
+	}
+	if ks.ttl == 0 {
+		return append([]byte{valueNoTTL}, value...)
+	}
+	encoded := make([]byte, 9+len(value))
+	encoded[0] = valueTTL
+	binary.BigEndian.PutUint64(encoded[1:], uint64(ks.clock.Now().Add(ks.ttl).UnixNano()))
+	copy(encoded[9:], value)
+	return encoded
+}
+
+// decodeValue returns the value of the keyspace stored as value, and false if
+// its TTL has passed. A missing value is returned as is.
+func (ks *keyspace) decodeValue(value []byte) ([]byte, bool) {
+	if ks == nil || len(value) == 0 {
+		return value, true
+	}
+	if ks.expired(value) {
+		return nil, false
+	}
+	if value[0] == valueTTL {
+		return value[9:], true
+	}
+	return value[1:], true
+}
+
+// expired reports whether the TTL of the stored value has passed.
+func (ks *keyspace) expired(value []byte) bool {
+	if ks == nil || len(value) < 9 || value[0] != valueTTL {
+		return false
+	}
+	deadline := int64(binary.BigEndian.Uint64(value[1:9]))
+	return ks.clock.Now().UnixNano() >= deadline
+}
+
+// admitRead returns an error if the keyspace used up its read quota. The
+// bytes of a read are only known once it is done, see read.
+func (ks *keyspace) admitRead() error {
+	if ks == nil {
+		return nil
+	}
+	if !ks.readQuota.admit(ks.clock.Now(), 0) {
+		atomic.AddUint64(&ks.throttled, 1)
+		return fmt.Errorf("keyspace %q exceeded its read quota", ks.name)
+	}
+	return nil
+}
+
+// read counts a read of n bytes, against the read quota too.
+func (ks *keyspace) read(n uint64) {
+	if ks == nil {
+		return
+	}
+	ks.readQuota.use(ks.clock.Now(), n)
+	atomic.AddUint64(&ks.reads, 1)
+	atomic.AddUint64(&ks.readBytes, n)
+}
+
+// admitWrite takes n bytes from the write quota, or returns an error if they
+// don't fit in it.
+func (ks *keyspace) admitWrite(n uint64) error {
+	if ks == nil {
+		return nil
+	}
+	if !ks.writeQuota.admit(ks.clock.Now(), n) {
+		atomic.AddUint64(&ks.throttled, 1)
+		return fmt.Errorf("keyspace %q exceeded its write quota", ks.name)
+	}
+	return nil
+}
+
+// wrote counts a write of n bytes admitted by admitWrite.
+func (ks *keyspace) wrote(n uint64) {
+	if ks == nil {
+		return
+	}
+	atomic.AddUint64(&ks.writes, 1)
+	atomic.AddUint64(&ks.writtenBytes, n)
+}
+
+// byteQuota admits at most limit bytes per second, counted in windows of a
+// second. A zero limit admits everything.
+type byteQuota struct {
+	limit uint64
+
+	mu     sync.Mutex
+	window int64
+	used   uint64
+}
+
+// admit takes n bytes from the window of now and returns true if they fit in
+// it, or for n of 0, if the window is not used up. A request always fits in
+// an empty window, so one larger than the limit is not rejected forever.
+func (q *byteQuota) admit(now time.Time, n uint64) bool {
+	if q.limit == 0 {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.roll(now)
+	if q.used > 0 && (q.used >= q.limit || q.used+n > q.limit) {
+		return false
+	}
+	q.used += n
+	return true
+}
+
+// use takes n bytes from the window of now, even if they don't fit in it.
+func (q *byteQuota) use(now time.Time, n uint64) {
+	if q.limit == 0 {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.roll(now)
+	q.used += n
+}
+
+func (q *byteQuota) roll(now time.Time) {
+	if window := now.Unix(); window != q.window {
+		q.window, q.used = window, 0
+	}
+}
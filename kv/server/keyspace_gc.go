@@ -0,0 +1,169 @@
+package server
+
+import (
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/tsoutil"
+)
+
+// gcBatchSize is the most modifications the keyspace GC writes in a batch.
+const gcBatchSize = 256
+
+// StartKeyspaceGC starts collecting the garbage of the keyspaces every
+// interval until Stop is called, see GCKeyspaces. Each round goes through the
+// regions returned by regions, skipping those this store doesn't lead.
+func (server *Server) StartKeyspaceGC(regions func() []*kvrpcpb.Context, rawCFs []string, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				for _, ctx := range regions() {
+					if err := server.GCKeyspaces(ctx, rawCFs); err != nil {
+						if _, ok := kverrors.AsRegionError(err); !ok {
+							log.Warnf("keyspace GC of region %d failed: %v", ctx.GetRegionId(), err)
+						}
+					}
+				}
+			case <-server.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// GCKeyspaces collects the garbage of the keyspaces in the region of ctx: the
+// MVCC history older than their GC retention, see mvcc.GC, and the raw values
+// of rawCFs whose TTL has passed. A keyspace is only collected where it
+// overlaps the region. rawCFs must hold only raw values, the MVCC CFs are
+// shared with the transactions, so the expired values written there are only
+// hidden from reads.
+func (server *Server) GCKeyspaces(ctx *kvrpcpb.Context, rawCFs []string) error {
+	reader, err := server.storage.Reader(ctx)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, ks := range server.keyspaces {
+		if safePoint := ks.safePoint(); safePoint > 0 {
+			modifies, err := mvcc.GC(reader, ks.prefix, ks.end(), safePoint)
+			if err != nil {
+				return err
+			}
+			for len(modifies) > 0 {
+				n := len(modifies)
+				if n > gcBatchSize {
+					n = gcBatchSize
+				}
+				if err := server.storage.Write(ctx, modifies[:n]); err != nil {
+					return err
+				}
+				modifies = modifies[n:]
+			}
+		}
+		for _, cf := range rawCFs {
+			if err := server.deleteExpired(ctx, reader, ks, cf); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteExpired deletes the raw values of ks in cf whose TTL has passed. A
+// value is checked again under the latch of its key, so one written since
+// reader was taken is kept. Only the keys reader sees are deleted, see
+// storage.RangeReader.
+func (server *Server) deleteExpired(ctx *kvrpcpb.Context, reader storage.StorageReader, ks *keyspace, cf string) error {
+	start, end, ok := storage.ClampRange(reader, ks.prefix, ks.end())
+	if !ok {
+		return nil
+	}
+	it := reader.IterCF(cf)
+	defer it.Close()
+	var keys [][]byte
+	for it.Seek(start); it.Valid() && !engine_util.ExceedEndKey(it.Item().Key(), end); it.Next() {
+		value, err := it.Item().Value()
+		if err != nil {
+			return err
+		}
+		if ks.expired(value) {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+	}
+
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > gcBatchSize {
+			n = gcBatchSize
+		}
+		if err := server.deleteIfExpired(ctx, ks, cf, keys[:n]); err != nil {
+			return err
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+func (server *Server) deleteIfExpired(ctx *kvrpcpb.Context, ks *keyspace, cf string, keys [][]byte) error {
+	server.Latches.WaitForLatches(keys)
+	defer server.Latches.ReleaseLatches(keys)
+	reader, err := server.storage.Reader(ctx)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var batch []storage.Modify
+	for _, key := range keys {
+		value, err := reader.GetCF(cf, key)
+		if err != nil {
+			return err
+		}
+		if ks.expired(value) {
+			batch = append(batch, storage.Modify{Data: storage.Delete{Key: key, Cf: cf}})
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := server.storage.Write(ctx, batch); err != nil {
+		return err
+	}
+	server.cdc.ObserveRaw(batch)
+	return nil
+}
+
+// safePoint returns the timestamp the keyspace keeps the MVCC history after,
+// or 0 if it keeps all of it.
+func (ks *keyspace) safePoint() uint64 {
+	if ks.gcRetention == 0 {
+		return 0
+	}
+	physical := ks.clock.Now().Add(-ks.gcRetention).UnixNano() / int64(time.Millisecond)
+	if physical <= 0 {
+		return 0
+	}
+	return uint64(physical) << tsoutil.PhysicalShiftBits
+}
+
+// end returns the first key after the keys of the keyspace, or nil if there
+// is none.
+func (ks *keyspace) end() []byte {
+	end := append([]byte(nil), ks.prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
+	"google.golang.org/grpc"
+)
+
+// RegionLocator finds the region of a key with its leader, and the address of
+// a store. It is implemented by the scheduler client.
+type RegionLocator interface {
+	GetRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error)
+	GetStore(ctx context.Context, storeID uint64) (*metapb.Store, error)
+}
+
+// proxy forwards the requests for a region this store doesn't serve to the
+// store of its leader, so a client without a region cache can send any
+// request to any store.
+type proxy struct {
+	locator RegionLocator
+	// maxHops is the most stores a request is proxied through, it bounds the
+	// forwarding loops caused by stale region information.
+	maxHops uint32
+
+	mu    sync.Mutex
+	conns map[uint64]*grpc.ClientConn
+}
+
+// EnableProxy makes the server proxy the raw requests for the regions it
+// doesn't serve to the store of their leader, found with locator. A request
+// is proxied at most maxHops times, after which the region error is returned
+// to the client.
+func (server *Server) EnableProxy(locator RegionLocator, maxHops uint32) {
+	server.proxy = &proxy{
+		locator: locator,
+		maxHops: maxHops,
+		conns:   make(map[uint64]*grpc.ClientConn),
+	}
+}
+
+// forward sends a request which failed here with err to the leader of the
+// region of key by calling send with a client of its store and the context
+// to send. It returns false if the request is not proxied, the error is
+// returned to the client then.
+func (p *proxy) forward(ctx context.Context, reqCtx *kvrpcpb.Context, key []byte, err error,
+	send func(client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) error) bool {
+	regionErr, ok := kverrors.AsRegionError(err)
+	if p == nil || !ok || !isRoutingError(regionErr) || reqCtx.GetProxyHops() >= p.maxHops {
+		return false
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	region, leader, err := p.locator.GetRegion(ctx, key)
+	if err != nil || region == nil || leader == nil {
+		log.Debugf("can't proxy request for key %x: region %v, leader %v, err %v", key, region, leader, err)
+		return false
+	}
+	client, err := p.client(ctx, leader.StoreId)
+	if err != nil {
+		log.Warnf("can't proxy request to store %d: %v", leader.StoreId, err)
+		return false
+	}
+	fwd := &kvrpcpb.Context{
+		RegionId:         region.Id,
+		RegionEpoch:      region.RegionEpoch,
+		Peer:             leader,
+		AppliedIndex:     reqCtx.GetAppliedIndex(),
+		RecordTimeDetail: reqCtx.GetRecordTimeDetail(),
+		ProxyHops:        reqCtx.GetProxyHops() + 1,
+		Keyspace:         reqCtx.GetKeyspace(),
+	}
+	if err := send(client, fwd); err != nil {
+		log.Warnf("failed to proxy request to store %d: %v", leader.StoreId, err)
+		p.dropClient(leader.StoreId)
+		return false
+	}
+	return true
+}
+
+// isRoutingError reports whether err means the request was sent to the wrong
+// store or with stale region information.
+func isRoutingError(err *errorpb.Error) bool {
+	return err.GetNotLeader() != nil || err.GetRegionNotFound() != nil || err.GetKeyNotInRegion() != nil ||
+		err.GetEpochNotMatch() != nil || err.GetStoreNotMatch() != nil
+}
+
+func (p *proxy) client(ctx context.Context, storeID uint64) (tinykvpb.TinyKvClient, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if cc, ok := p.conns[storeID]; ok {
+		return tinykvpb.NewTinyKvClient(cc), nil
+	}
+	store, err := p.locator.GetStore(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	if store.GetState() == metapb.StoreState_Tombstone {
+		return nil, errors.Errorf("store %d is removed", storeID)
+	}
+	cc, err := grpc.Dial(store.Address, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	p.conns[storeID] = cc
+	return tinykvpb.NewTinyKvClient(cc), nil
+}
+
+func (p *proxy) dropClient(storeID uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if cc, ok := p.conns[storeID]; ok {
+		cc.Close()
+		delete(p.conns, storeID)
+	}
+}
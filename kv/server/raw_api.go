@@ -2,99 +2,224 @@ package server
 
 import (
 	"context"
+	"time"
+
 	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
 )
 
 // The functions below are Server's Raw API. (implements TinyKvServer).
 // Some helper methods can be found in sever.go in the current directory
 
 // RawGet return the corresponding Get response based on RawGetRequest's CF and Key fields
-func (server *Server) RawGet(_ context.Context, req *kvrpcpb.RawGetRequest) (*kvrpcpb.RawGetResponse, error) {
+func (server *Server) RawGet(ctx context.Context, req *kvrpcpb.RawGetRequest) (*kvrpcpb.RawGetResponse, error) {
 	// Your Code Here (1).
 	resp := &kvrpcpb.RawGetResponse{}
 
+	ks, err := server.keyspace(req.Context)
+	if err == nil {
+		err = ks.admitRead()
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, err
+	}
+
 	reader, err := server.storage.Reader(req.Context)
 	if err != nil {
+		if server.proxy.forward(ctx, req.Context, ks.encode(req.Key), err, func(client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) error {
+			fwd := *req
+			fwd.Context = reqCtx
+			fwdResp, err := client.RawGet(ctx, &fwd)
+			if err == nil {
+				resp = fwdResp
+			}
+			return err
+		}) {
+			return resp, nil
+		}
+		if regionErr, ok := kverrors.AsRegionError(err); ok {
+			resp.RegionError = regionErr
+			return resp, nil
+		}
 		resp.Error = err.Error()
 		return resp, err
 	}
 	defer reader.Close()
 
-	resp.Value, err = reader.GetCF(req.Cf, req.Key)
-	if resp.Value == nil {
-		resp.NotFound = true
-		return resp, nil
-	}
+	start := time.Now()
+	value, err := reader.GetCF(req.Cf, ks.encode(req.Key))
+	resp.TimeDetail = readTimeDetail(req.Context, reader, start)
+	ks.read(uint64(len(req.Key) + len(value)))
 	if err != nil {
 		resp.Error = err.Error()
 		return resp, err
 	}
+	value, ok := ks.decodeValue(value)
+	if value == nil || !ok {
+		resp.NotFound = true
+		return resp, nil
+	}
+	resp.Value = value
 
 	return resp, nil
 }
 
 // RawPut puts the target data into storage and returns the corresponding response
-func (server *Server) RawPut(_ context.Context, req *kvrpcpb.RawPutRequest) (*kvrpcpb.RawPutResponse, error) {
+func (server *Server) RawPut(ctx context.Context, req *kvrpcpb.RawPutRequest) (*kvrpcpb.RawPutResponse, error) {
 	// Your Code Here (1).
 	// Hint: Consider using Storage.Modify to store data to be modified
 	resp := &kvrpcpb.RawPutResponse{}
 
+	size := uint64(len(req.Key) + len(req.Value))
+	ks, err := server.keyspace(req.Context)
+	if err == nil {
+		err = ks.admitWrite(size)
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, err
+	}
+
 	put := storage.Modify{
 		Data: storage.Put{
-			Key:   req.Key,
-			Value: req.Value,
+			Key:   ks.encode(req.Key),
+			Value: ks.encodeValue(req.Value),
 			Cf:    req.Cf,
 		},
 	}
-	err := server.storage.Write(req.Context, []storage.Modify{put})
+	err = server.writeRaw(req.Context, []storage.Modify{put})
 	if err != nil {
+		if server.proxy.forward(ctx, req.Context, ks.encode(req.Key), err, func(client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) error {
+			fwd := *req
+			fwd.Context = reqCtx
+			fwdResp, err := client.RawPut(ctx, &fwd)
+			if err == nil {
+				resp = fwdResp
+			}
+			return err
+		}) {
+			return resp, nil
+		}
+		if regionErr, ok := kverrors.AsRegionError(err); ok {
+			resp.RegionError = regionErr
+			return resp, nil
+		}
 		resp.Error = err.Error()
 		return resp, err
 	}
+	ks.wrote(size)
 	return resp, nil
 }
 
 // RawDelete delete the target data from storage and returns the corresponding response
-func (server *Server) RawDelete(_ context.Context, req *kvrpcpb.RawDeleteRequest) (*kvrpcpb.RawDeleteResponse, error) {
+func (server *Server) RawDelete(ctx context.Context, req *kvrpcpb.RawDeleteRequest) (*kvrpcpb.RawDeleteResponse, error) {
 	// Your Code Here (1).
 	// Hint: Consider using Storage.Modify to store data to be deleted
 	resp := &kvrpcpb.RawDeleteResponse{}
 
+	size := uint64(len(req.Key))
+	ks, err := server.keyspace(req.Context)
+	if err == nil {
+		err = ks.admitWrite(size)
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, err
+	}
+
 	del := storage.Modify{
 		Data: storage.Delete{
-			Key: req.Key,
+			Key: ks.encode(req.Key),
 			Cf:  req.Cf,
 		},
 	}
-	err := server.storage.Write(req.Context, []storage.Modify{del})
+	err = server.writeRaw(req.Context, []storage.Modify{del})
 	if err != nil {
+		if server.proxy.forward(ctx, req.Context, ks.encode(req.Key), err, func(client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) error {
+			fwd := *req
+			fwd.Context = reqCtx
+			fwdResp, err := client.RawDelete(ctx, &fwd)
+			if err == nil {
+				resp = fwdResp
+			}
+			return err
+		}) {
+			return resp, nil
+		}
+		if regionErr, ok := kverrors.AsRegionError(err); ok {
+			resp.RegionError = regionErr
+			return resp, nil
+		}
 		resp.Error = err.Error()
 		return resp, err
 	}
+	ks.wrote(size)
 	return resp, nil
 }
 
 // RawScan scan the data starting from the start key up to limit. and return the corresponding result
-func (server *Server) RawScan(_ context.Context, req *kvrpcpb.RawScanRequest) (*kvrpcpb.RawScanResponse, error) {
+func (server *Server) RawScan(ctx context.Context, req *kvrpcpb.RawScanRequest) (*kvrpcpb.RawScanResponse, error) {
 	// Your Code Here (1).
 	// Hint: Consider using reader.IterCF
 	resp := &kvrpcpb.RawScanResponse{}
 
+	ks, err := server.keyspace(req.Context)
+	if err == nil {
+		err = ks.admitRead()
+	}
+	if err != nil {
+		resp.Error = err.Error()
+		return resp, err
+	}
+
 	reader, err := server.storage.Reader(req.Context)
 	if err != nil {
+		if server.proxy.forward(ctx, req.Context, ks.encode(req.StartKey), err, func(client tinykvpb.TinyKvClient, reqCtx *kvrpcpb.Context) error {
+			fwd := *req
+			fwd.Context = reqCtx
+			fwdResp, err := client.RawScan(ctx, &fwd)
+			if err == nil {
+				resp = fwdResp
+			}
+			return err
+		}) {
+			return resp, nil
+		}
+		if regionErr, ok := kverrors.AsRegionError(err); ok {
+			resp.RegionError = regionErr
+			return resp, nil
+		}
 		resp.Error = err.Error()
 		return resp, err
 	}
 	defer reader.Close()
 
+	start := time.Now()
 	iter := reader.IterCF(req.Cf)
 	defer iter.Close()
 
-	n := req.Limit
-	for iter.Seek(req.StartKey); iter.Valid(); iter.Next() {
-		key := iter.Item().KeyCopy(nil)
-		value, _ := iter.Item().ValueCopy(nil)
+	limit := scanLimit{limit: req.Limit, maxBytes: req.MaxBytes, budget: server.scanMaxBytes}
+	for iter.Seek(ks.encode(req.StartKey)); iter.Valid(); iter.Next() {
+		item := iter.Item()
+		if !ks.contains(item.Key()) {
+			break
+		}
+		// The expired values are skipped before they are charged to the page.
+		if stored, err := item.Value(); err == nil && ks.expired(stored) {
+			continue
+		}
+		if !limit.fits(ks.decode(item.Key()), item.ValueSize()) {
+			break
+		}
+		value, _ := item.ValueCopy(nil)
+		value, ok := ks.decodeValue(value)
+		if !ok {
+			continue
+		}
+		key := ks.decode(item.KeyCopy(nil))
 		kv := &kvrpcpb.KvPair{
 			Error: nil,
 			Key:   key,
@@ -102,11 +227,12 @@ func (server *Server) RawScan(_ context.Context, req *kvrpcpb.RawScanRequest) (*
 		}
 		resp.Kvs = append(resp.Kvs, kv)
 
-		n--
-		if n <= 0 {
+		if limit.add(key, value) {
 			break
 		}
 	}
+	resp.TimeDetail = readTimeDetail(req.Context, reader, start)
+	ks.read(limit.bytes)
 
 	return resp, nil
 }
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// TSOracle hands out timestamps, larger than any handed out before.
+type TSOracle interface {
+	GetTS(ctx context.Context) (uint64, error)
+}
+
+// StartResolvedTs starts advancing the resolved ts of the regions and of the
+// EventFeed streams every interval, with a timestamp taken from oracle, until
+// Stop is called.
+func (server *Server) StartResolvedTs(oracle TSOracle, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ts, err := oracle.GetTS(context.TODO())
+				if err != nil {
+					log.Warnf("failed to get ts to advance resolved ts: %v", err)
+					continue
+				}
+				server.resolver.Advance(ts)
+				server.cdc.Advance(ts)
+			case <-server.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the background work of the server.
+func (server *Server) Stop() {
+	close(server.stopCh)
+}
+
+// ResolvedTs returns the resolved ts of the region of ctx: every transaction
+// committed in the region with a commit ts not above it is visible. It is 0
+// until StartResolvedTs advanced it once. The locks of the region are read
+// from its leader, since only the leader has them all applied.
+func (server *Server) ResolvedTs(ctx *kvrpcpb.Context) (uint64, error) {
+	reader, err := server.storage.Reader(ctx)
+	if err != nil {
+		if _, ok := kverrors.AsRegionError(err); ok {
+			server.resolver.Deregister(ctx.GetRegionId())
+		}
+		return 0, err
+	}
+	defer reader.Close()
+	return server.resolver.ResolvedTs(ctx.GetRegionId(), reader)
+}
@@ -1,12 +1,18 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/pingcap-incubator/tinykv/kv/cdc"
 	"github.com/pingcap-incubator/tinykv/kv/coprocessor"
 	"github.com/pingcap-incubator/tinykv/kv/storage"
 	"github.com/pingcap-incubator/tinykv/kv/storage/raft_storage"
 	"github.com/pingcap-incubator/tinykv/kv/transaction/latches"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	coppb "github.com/pingcap-incubator/tinykv/proto/pkg/coprocessor"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
@@ -24,15 +30,47 @@ type Server struct {
 
 	// coprocessor API handler, out of course scope
 	copHandler *coprocessor.CopHandler
+
+	// cdc dispatches the transactions committed to the EventFeed streams.
+	cdc *cdc.Hub
+	// resolver tracks the resolved ts of the regions, see ResolvedTs.
+	resolver *cdc.Resolver
+	stopCh   chan struct{}
+
+	// proxy forwards the requests for other stores, see EnableProxy.
+	proxy *proxy
+
+	interceptors interceptors
+
+	// scanMaxBytes is the budget of a scan page, see LimitScanBytes.
+	scanMaxBytes uint64
+
+	// keyspaces are the namespaces of the tenants by name, see UseKeyspaces.
+	keyspaces map[string]*keyspace
 }
 
+// eventFeedBufSize is the number of events buffered for an EventFeed stream
+// before it is closed for being too slow.
+const eventFeedBufSize = 1024
+
 func NewServer(storage storage.Storage) *Server {
 	return &Server{
-		storage: storage,
-		Latches: latches.NewLatches(),
+		storage:  storage,
+		Latches:  latches.NewLatches(),
+		cdc:      cdc.NewHub(),
+		resolver: cdc.NewResolver(),
+		stopCh:   make(chan struct{}),
 	}
 }
 
+// LimitScanBytes bounds the bytes of keys and values RawScan returns in one
+// response to maxBytes, whatever the request asks. A scan stops before the
+// pair which would exceed it, unless the page is empty, and the client
+// continues from the last key returned. 0 means no limit.
+func (server *Server) LimitScanBytes(maxBytes uint64) {
+	server.scanMaxBytes = maxBytes
+}
+
 // The below functions are Server's gRPC API (implements TinyKvServer).
 
 // Raft commands (tinykv <-> tinykv)
@@ -83,13 +121,59 @@ func (server *Server) KvResolveLock(_ context.Context, req *kvrpcpb.ResolveLockR
 	return nil, nil
 }
 
+// UnsafeDestroyRange deletes all the MVCC data in a key range from the engine
+// of this store, see kvrpcpb.UnsafeDestroyRangeRequest.
+func (server *Server) UnsafeDestroyRange(_ context.Context, req *kvrpcpb.UnsafeDestroyRangeRequest) (*kvrpcpb.UnsafeDestroyRangeResponse, error) {
+	resp := new(kvrpcpb.UnsafeDestroyRangeResponse)
+	if len(req.EndKey) > 0 && bytes.Compare(req.StartKey, req.EndKey) >= 0 {
+		resp.Error = fmt.Sprintf("invalid range [%q, %q)", req.StartKey, req.EndKey)
+		return resp, nil
+	}
+	destroyer, ok := server.storage.(storage.RangeDestroyer)
+	if !ok {
+		resp.Error = "storage cannot destroy ranges"
+		return resp, nil
+	}
+	if err := mvcc.DestroyRange(destroyer, req.StartKey, req.EndKey); err != nil {
+		resp.Error = err.Error()
+	}
+	return resp, nil
+}
+
+// EventFeed streams the transactions committed in a key range of this store,
+// and the raw writes of the default CF, see kvrpcpb.ChangeDataRequest.
+func (server *Server) EventFeed(req *kvrpcpb.ChangeDataRequest, stream tinykvpb.TinyKv_EventFeedServer) error {
+	sub, err := server.cdc.Subscribe(req.Context, server.storage, req.StartKey, req.EndKey, eventFeedBufSize)
+	if err != nil {
+		if regionErr, ok := kverrors.AsRegionError(err); ok {
+			return stream.Send(&kvrpcpb.ChangeDataEvent{RegionError: regionErr})
+		}
+		return err
+	}
+	defer server.cdc.Unsubscribe(sub)
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return sub.Err()
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
 // SQL push down commands.
 func (server *Server) Coprocessor(_ context.Context, req *coppb.Request) (*coppb.Response, error) {
 	resp := new(coppb.Response)
 	reader, err := server.storage.Reader(req.Context)
 	if err != nil {
-		if regionErr, ok := err.(*raft_storage.RegionError); ok {
-			resp.RegionError = regionErr.RequestErr
+		if regionErr, ok := kverrors.AsRegionError(err); ok {
+			resp.RegionError = regionErr
 			return resp, nil
 		}
 		return nil, err
@@ -102,3 +186,74 @@ func (server *Server) Coprocessor(_ context.Context, req *coppb.Request) (*coppb
 	}
 	return nil, nil
 }
+
+// write writes batch to the storage, and hands it to change data capture once
+// it is written. The caller holds the latches of the keys of batch, as the
+// transactional commands do, so the writes of a key are observed in the order
+// they are applied.
+func (server *Server) write(ctx *kvrpcpb.Context, batch []storage.Modify) error {
+	if err := server.storage.Write(ctx, batch); err != nil {
+		return err
+	}
+	return server.cdc.Observe(ctx, batch, server.storage)
+}
+
+// writeRaw is write for the batches of the raw API, whose values are not
+// MVCC records. It takes the latches of the keys itself.
+func (server *Server) writeRaw(ctx *kvrpcpb.Context, batch []storage.Modify) error {
+	keys := make([][]byte, 0, len(batch))
+	for _, m := range batch {
+		keys = append(keys, m.Key())
+	}
+	server.Latches.WaitForLatches(keys)
+	defer server.Latches.ReleaseLatches(keys)
+	if err := server.storage.Write(ctx, batch); err != nil {
+		return err
+	}
+	server.cdc.ObserveRaw(batch)
+	return nil
+}
+
+// readTimeDetail returns the time details of a read served by reader, or nil
+// if the client didn't ask for them. engineStart is when the read of the
+// engine started.
+func readTimeDetail(ctx *kvrpcpb.Context, reader storage.StorageReader, engineStart time.Time) *kvrpcpb.TimeDetail {
+	if !ctx.GetRecordTimeDetail() {
+		return nil
+	}
+	detail := new(kvrpcpb.TimeDetail)
+	if r, ok := reader.(storage.TimeDetailReader); ok {
+		*detail = r.TimeDetail()
+	}
+	detail.EngineReadNs = uint64(time.Since(engineStart))
+	return detail
+}
+
+// scanLimit ends a scan page at limit pairs or once maxBytes of keys and
+// values are read, whichever comes first. A zero bound is unlimited.
+type scanLimit struct {
+	limit    uint32
+	maxBytes uint64
+	// budget is the most bytes of a page set by the server, see
+	// Server.LimitScanBytes. Unlike maxBytes, the pair which would exceed it
+	// is left out of the page, unless it is the first one.
+	budget uint64
+
+	pairs uint32
+	bytes uint64
+}
+
+// fits returns whether a pair of a key and a value of valueSize bytes can be
+// added to the page without exceeding the budget. It is checked before the
+// value is read, so a large value past the budget is never copied.
+func (l *scanLimit) fits(key []byte, valueSize int) bool {
+	return l.budget == 0 || l.pairs == 0 || l.bytes+uint64(len(key)+valueSize) <= l.budget
+}
+
+// add counts a pair read and returns true if the page is full.
+func (l *scanLimit) add(key, value []byte) bool {
+	l.pairs++
+	l.bytes += uint64(len(key) + len(value))
+	return (l.limit > 0 && l.pairs >= l.limit) || (l.maxBytes > 0 && l.bytes >= l.maxBytes) ||
+		(l.budget > 0 && l.bytes >= l.budget)
+}
@@ -1,15 +1,31 @@
 package server
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/config"
 	"github.com/pingcap-incubator/tinykv/kv/storage"
 	"github.com/pingcap-incubator/tinykv/kv/storage/standalone_storage"
+	"github.com/pingcap-incubator/tinykv/kv/transaction/mvcc"
+	"github.com/pingcap-incubator/tinykv/kv/util/clock"
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/tsoutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func Set(s *standalone_storage.StandAloneStorage, cf string, key []byte, value []byte) error {
@@ -67,6 +83,32 @@ func TestRawGet1(t *testing.T) {
 	assert.Equal(t, []byte{42}, resp.Value)
 }
 
+func TestRawGetTimeDetail1(t *testing.T) {
+	conf := config.NewTestConfig()
+	s := standalone_storage.NewStandAloneStorage(conf)
+	s.Start()
+	server := NewServer(s)
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+
+	cf := engine_util.CfDefault
+	Set(s, cf, []byte{99}, []byte{42})
+
+	req := &kvrpcpb.RawGetRequest{
+		Key: []byte{99},
+		Cf:  cf,
+	}
+	resp, err := server.RawGet(nil, req)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.TimeDetail)
+
+	req.Context = &kvrpcpb.Context{RecordTimeDetail: true}
+	resp, err = server.RawGet(nil, req)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{42}, resp.Value)
+	assert.NotNil(t, resp.TimeDetail)
+}
+
 func TestRawGetNotFound1(t *testing.T) {
 	conf := config.NewTestConfig()
 	s := standalone_storage.NewStandAloneStorage(conf)
@@ -200,6 +242,30 @@ func TestRawDelete1(t *testing.T) {
 	assert.Equal(t, []byte(nil), val)
 }
 
+func TestRawWritesStreamed1(t *testing.T) {
+	conf := config.NewTestConfig()
+	s := standalone_storage.NewStandAloneStorage(conf)
+	s.Start()
+	server := NewServer(s)
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+
+	sub, err := server.cdc.Subscribe(nil, s, nil, nil, 8)
+	require.Nil(t, err)
+	defer server.cdc.Unsubscribe(sub)
+
+	cf := engine_util.CfDefault
+	_, err = server.RawPut(nil, &kvrpcpb.RawPutRequest{Key: []byte{99}, Value: []byte{42}, Cf: cf})
+	require.Nil(t, err)
+	_, err = server.RawDelete(nil, &kvrpcpb.RawDeleteRequest{Key: []byte{99}, Cf: cf})
+	require.Nil(t, err)
+
+	event := <-sub.Events()
+	assert.Equal(t, []*kvrpcpb.ChangeDataRow{{Op: kvrpcpb.Op_Put, Key: []byte{99}, Value: []byte{42}}}, event.Rows)
+	event = <-sub.Events()
+	assert.Equal(t, []*kvrpcpb.ChangeDataRow{{Op: kvrpcpb.Op_Del, Key: []byte{99}}}, event.Rows)
+}
+
 func TestRawScan1(t *testing.T) {
 	conf := config.NewTestConfig()
 	s := standalone_storage.NewStandAloneStorage(conf)
@@ -233,6 +299,78 @@ func TestRawScan1(t *testing.T) {
 	}
 }
 
+func TestRawScanMaxBytes1(t *testing.T) {
+	conf := config.NewTestConfig()
+	s := standalone_storage.NewStandAloneStorage(conf)
+	s.Start()
+	server := NewServer(s)
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+
+	cf := engine_util.CfDefault
+	assert.Nil(t, Set(s, cf, []byte{1}, make([]byte, 10)))
+	assert.Nil(t, Set(s, cf, []byte{2}, make([]byte, 100)))
+	assert.Nil(t, Set(s, cf, []byte{3}, make([]byte, 10)))
+
+	tests := []struct {
+		limit    uint32
+		maxBytes uint64
+		keys     int
+	}{
+		// The page ends at the pair reaching the byte budget.
+		{10, 50, 2},
+		// A page holds at least one pair.
+		{10, 5, 1},
+		// The count limit is reached first.
+		{1, 1000, 1},
+		{0, 0, 3},
+	}
+	for i, tt := range tests {
+		resp, err := server.RawScan(nil, &kvrpcpb.RawScanRequest{
+			StartKey: []byte{1},
+			Limit:    tt.limit,
+			MaxBytes: tt.maxBytes,
+			Cf:       cf,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, tt.keys, len(resp.Kvs), "#%d", i)
+	}
+}
+
+func TestRawScanServerLimit1(t *testing.T) {
+	conf := config.NewTestConfig()
+	s := standalone_storage.NewStandAloneStorage(conf)
+	s.Start()
+	server := NewServer(s)
+	server.LimitScanBytes(50)
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+
+	cf := engine_util.CfDefault
+	assert.Nil(t, Set(s, cf, []byte{1}, make([]byte, 10)))
+	assert.Nil(t, Set(s, cf, []byte{2}, make([]byte, 100)))
+	assert.Nil(t, Set(s, cf, []byte{3}, make([]byte, 10)))
+
+	// The pair exceeding the budget of the server is left for the next page,
+	// whatever the request asks.
+	var pages [][]byte
+	start := []byte{1}
+	for {
+		resp, err := server.RawScan(nil, &kvrpcpb.RawScanRequest{StartKey: start, Limit: 10, Cf: cf})
+		assert.Nil(t, err)
+		if len(resp.Kvs) == 0 {
+			break
+		}
+		var page []byte
+		for _, kv := range resp.Kvs {
+			page = append(page, kv.Key[0])
+		}
+		pages = append(pages, page)
+		start = []byte{resp.Kvs[len(resp.Kvs)-1].Key[0] + 1}
+	}
+	assert.Equal(t, [][]byte{{1}, {2}, {3}}, pages)
+}
+
 func TestRawScanAfterRawPut1(t *testing.T) {
 	conf := config.NewTestConfig()
 	s := standalone_storage.NewStandAloneStorage(conf)
@@ -346,3 +484,524 @@ func TestIterWithRawDelete1(t *testing.T) {
 		i++
 	}
 }
+
+func TestRawRegionError1(t *testing.T) {
+	conf := config.NewTestConfig()
+	s := standalone_storage.NewStandAloneStorage(conf)
+	s.Start()
+	server := NewServer(s)
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+
+	cf := engine_util.CfDefault
+	put := &kvrpcpb.RawPutRequest{
+		Context: &kvrpcpb.Context{RegionId: 1, RegionEpoch: &metapb.RegionEpoch{Version: 1, ConfVer: 1}},
+		Key:     []byte{1},
+		Value:   []byte{233},
+		Cf:      cf,
+	}
+	resp, err := server.RawPut(nil, put)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.RegionError)
+
+	put.Context = &kvrpcpb.Context{RegionId: 2}
+	resp, err = server.RawPut(nil, put)
+	assert.Nil(t, err)
+	assert.NotNil(t, resp.RegionError.GetRegionNotFound())
+
+	get := &kvrpcpb.RawGetRequest{
+		Context: &kvrpcpb.Context{RegionId: 1, RegionEpoch: &metapb.RegionEpoch{Version: 2, ConfVer: 1}},
+		Key:     []byte{1},
+		Cf:      cf,
+	}
+	getResp, err := server.RawGet(nil, get)
+	assert.Nil(t, err)
+	assert.NotNil(t, getResp.RegionError.GetEpochNotMatch())
+
+	get.Context = &kvrpcpb.Context{RegionId: 1, Peer: &metapb.Peer{Id: 1, StoreId: 3}}
+	getResp, err = server.RawGet(nil, get)
+	assert.Nil(t, err)
+	assert.NotNil(t, getResp.RegionError.GetStoreNotMatch())
+}
+
+type testLocator struct {
+	addr string
+}
+
+func (l *testLocator) GetRegion(ctx context.Context, key []byte) (*metapb.Region, *metapb.Peer, error) {
+	leader := &metapb.Peer{Id: 1, StoreId: 1}
+	region := &metapb.Region{Id: 1, RegionEpoch: &metapb.RegionEpoch{Version: 1, ConfVer: 1}, Peers: []*metapb.Peer{leader}}
+	return region, leader, nil
+}
+
+func (l *testLocator) GetStore(ctx context.Context, storeID uint64) (*metapb.Store, error) {
+	return &metapb.Store{Id: storeID, Address: l.addr}, nil
+}
+
+func TestRawProxy1(t *testing.T) {
+	newStorage := func() (*standalone_storage.StandAloneStorage, *config.Config) {
+		conf := config.NewTestConfig()
+		dir, err := ioutil.TempDir("", "proxy")
+		require.Nil(t, err)
+		conf.DBPath = dir
+		s := standalone_storage.NewStandAloneStorage(conf)
+		require.Nil(t, s.Start())
+		return s, conf
+	}
+
+	target, targetConf := newStorage()
+	defer cleanUpTestData(targetConf)
+	defer target.Stop()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	grpcServer := grpc.NewServer()
+	tinykvpb.RegisterTinyKvServer(grpcServer, NewServer(target))
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	s, conf := newStorage()
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+	server := NewServer(s)
+	server.EnableProxy(&testLocator{addr: lis.Addr().String()}, 1)
+
+	cf := engine_util.CfDefault
+	put := &kvrpcpb.RawPutRequest{
+		Context: &kvrpcpb.Context{RegionId: 2},
+		Key:     []byte{1},
+		Value:   []byte{42},
+		Cf:      cf,
+	}
+	resp, err := server.RawPut(context.Background(), put)
+	require.Nil(t, err)
+	assert.Nil(t, resp.RegionError)
+	got, err := Get(target, cf, []byte{1})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{42}, got)
+
+	get := &kvrpcpb.RawGetRequest{Context: &kvrpcpb.Context{RegionId: 2}, Key: []byte{1}, Cf: cf}
+	getResp, err := server.RawGet(context.Background(), get)
+	require.Nil(t, err)
+	assert.Nil(t, getResp.RegionError)
+	assert.Equal(t, []byte{42}, getResp.Value)
+
+	// The request was proxied as many times as allowed already.
+	get.Context.ProxyHops = 1
+	getResp, err = server.RawGet(context.Background(), get)
+	require.Nil(t, err)
+	assert.NotNil(t, getResp.RegionError.GetRegionNotFound())
+}
+
+func TestInterceptors1(t *testing.T) {
+	conf := config.NewTestConfig()
+	dir, err := ioutil.TempDir("", "interceptors")
+	require.Nil(t, err)
+	conf.DBPath = dir
+	s := standalone_storage.NewStandAloneStorage(conf)
+	require.Nil(t, s.Start())
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name+" "+info.FullMethod)
+			return handler(ctx, req)
+		}
+	}
+	deny := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := req.(*kvrpcpb.RawDeleteRequest); ok {
+			return nil, status.Error(codes.PermissionDenied, "denied")
+		}
+		return handler(ctx, req)
+	}
+	server := NewServer(s)
+	server.UseUnaryInterceptor(record("first"), record("second"))
+	server.UseUnaryInterceptor(deny)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	grpcServer := grpc.NewServer(server.GRPCServerOptions()...)
+	tinykvpb.RegisterTinyKvServer(grpcServer, server)
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+	cc, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.Nil(t, err)
+	defer cc.Close()
+	client := tinykvpb.NewTinyKvClient(cc)
+
+	cf := engine_util.CfDefault
+	_, err = client.RawPut(context.Background(), &kvrpcpb.RawPutRequest{Key: []byte{1}, Value: []byte{42}, Cf: cf})
+	require.Nil(t, err)
+	assert.Equal(t, []string{"first /tinykvpb.TinyKv/RawPut", "second /tinykvpb.TinyKv/RawPut"}, calls)
+
+	_, err = client.RawDelete(context.Background(), &kvrpcpb.RawDeleteRequest{Key: []byte{1}, Cf: cf})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	got, err := Get(s, cf, []byte{1})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{42}, got)
+}
+
+func TestShadow1(t *testing.T) {
+	newStorage := func() (*standalone_storage.StandAloneStorage, *config.Config) {
+		conf := config.NewTestConfig()
+		dir, err := ioutil.TempDir("", "shadow")
+		require.Nil(t, err)
+		conf.DBPath = dir
+		s := standalone_storage.NewStandAloneStorage(conf)
+		require.Nil(t, s.Start())
+		return s, conf
+	}
+
+	target, targetConf := newStorage()
+	defer cleanUpTestData(targetConf)
+	defer target.Stop()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	grpcServer := grpc.NewServer()
+	tinykvpb.RegisterTinyKvServer(grpcServer, NewServer(target))
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+	cc, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.Nil(t, err)
+	defer cc.Close()
+
+	s, conf := newStorage()
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+	server := NewServer(s)
+	shadow := NewShadow(tinykvpb.NewTinyKvClient(cc), 1, engine_util.CfLock)
+	interceptor := shadow.UnaryInterceptor()
+	call := func(req interface{}, handler grpc.UnaryHandler) {
+		_, err := interceptor(context.Background(), req, &grpc.UnaryServerInfo{}, handler)
+		require.Nil(t, err)
+		// Wait for the request mirrored.
+		for i := 0; len(shadow.inflight) > 0 && i < 100; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	rawGet := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.RawGet(ctx, req.(*kvrpcpb.RawGetRequest))
+	}
+	rawPut := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.RawPut(ctx, req.(*kvrpcpb.RawPutRequest))
+	}
+
+	cf := engine_util.CfDefault
+	require.Nil(t, Set(s, cf, []byte{1}, []byte{42}))
+	require.Nil(t, Set(target, cf, []byte{1}, []byte{42}))
+	require.Nil(t, Set(s, cf, []byte{2}, []byte{42}))
+	require.Nil(t, Set(target, cf, []byte{2}, []byte{43}))
+
+	call(&kvrpcpb.RawGetRequest{Key: []byte{1}, Cf: cf}, rawGet)
+	assert.Equal(t, ShadowStats{Mirrored: 1}, shadow.Stats())
+	call(&kvrpcpb.RawGetRequest{Key: []byte{2}, Cf: cf}, rawGet)
+	assert.Equal(t, ShadowStats{Mirrored: 2, Diverged: 1}, shadow.Stats())
+
+	// A write is mirrored to the scratch CF.
+	call(&kvrpcpb.RawPutRequest{Key: []byte{3}, Value: []byte{44}, Cf: cf}, rawPut)
+	assert.Equal(t, ShadowStats{Mirrored: 3, Diverged: 1}, shadow.Stats())
+	got, err := Get(target, engine_util.CfLock, []byte{3})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{44}, got)
+	got, err = Get(target, cf, []byte{3})
+	assert.Nil(t, err)
+	assert.Nil(t, got)
+}
+
+func TestKeyspaces1(t *testing.T) {
+	conf := config.NewTestConfig()
+	s := standalone_storage.NewStandAloneStorage(conf)
+	s.Start()
+	server := NewServer(s)
+	server.UseKeyspaces([]config.KeyspaceConfig{{Name: "a", Prefix: "a/"}, {Name: "b", Prefix: "b/"}})
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+
+	cf := engine_util.CfDefault
+	put := func(keyspace string, key, value []byte) error {
+		_, err := server.RawPut(nil, &kvrpcpb.RawPutRequest{
+			Context: &kvrpcpb.Context{Keyspace: keyspace},
+			Key:     key,
+			Value:   value,
+			Cf:      cf,
+		})
+		return err
+	}
+	assert.Nil(t, put("a", []byte{1}, []byte{10}))
+	assert.Nil(t, put("a", []byte{2}, []byte{20}))
+	assert.Nil(t, put("b", []byte{1}, []byte{11}))
+	// The requests must name a known keyspace.
+	assert.NotNil(t, put("", []byte{1}, []byte{0}))
+	assert.NotNil(t, put("c", []byte{1}, []byte{0}))
+
+	// The keys are stored under the prefix of their keyspace.
+	got, err := Get(s, cf, []byte("b/\x01"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{11}, got)
+
+	getResp, err := server.RawGet(nil, &kvrpcpb.RawGetRequest{Context: &kvrpcpb.Context{Keyspace: "b"}, Key: []byte{1}, Cf: cf})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{11}, getResp.Value)
+	getResp, err = server.RawGet(nil, &kvrpcpb.RawGetRequest{Context: &kvrpcpb.Context{Keyspace: "b"}, Key: []byte{2}, Cf: cf})
+	assert.Nil(t, err)
+	assert.True(t, getResp.NotFound)
+
+	// A scan stops at the end of its keyspace.
+	scanResp, err := server.RawScan(nil, &kvrpcpb.RawScanRequest{Context: &kvrpcpb.Context{Keyspace: "a"}, Limit: 10, Cf: cf})
+	assert.Nil(t, err)
+	assert.Equal(t, []*kvrpcpb.KvPair{{Key: []byte{1}, Value: []byte{10}}, {Key: []byte{2}, Value: []byte{20}}}, scanResp.Kvs)
+
+	assert.Equal(t, []KeyspaceStats{
+		{Name: "a", Reads: 1, Writes: 2, ReadBytes: 4, WrittenBytes: 4},
+		{Name: "b", Reads: 2, Writes: 1, ReadBytes: 3, WrittenBytes: 2},
+	}, server.KeyspaceStats())
+}
+
+func TestKeyspaceTTL1(t *testing.T) {
+	conf := config.NewTestConfig()
+	s := standalone_storage.NewStandAloneStorage(conf)
+	s.Start()
+	server := NewServer(s)
+	server.UseKeyspaces([]config.KeyspaceConfig{{Name: "a", Prefix: "a/", DefaultTTL: time.Minute}, {Name: "b", Prefix: "b/"}})
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+	clk := clock.NewFake(time.Unix(100, 0))
+	server.keyspaces["a"].clock = clk
+
+	cf := engine_util.CfDefault
+	put := func(keyspace string, key, value []byte) {
+		_, err := server.RawPut(nil, &kvrpcpb.RawPutRequest{Context: &kvrpcpb.Context{Keyspace: keyspace}, Key: key, Value: value, Cf: cf})
+		require.Nil(t, err)
+	}
+	get := func(keyspace string, key []byte) *kvrpcpb.RawGetResponse {
+		resp, err := server.RawGet(nil, &kvrpcpb.RawGetRequest{Context: &kvrpcpb.Context{Keyspace: keyspace}, Key: key, Cf: cf})
+		require.Nil(t, err)
+		return resp
+	}
+	put("a", []byte{1}, []byte{10})
+	clk.Advance(30 * time.Second)
+	put("a", []byte{2}, []byte{20})
+	put("b", []byte{1}, []byte{11})
+	assert.Equal(t, []byte{10}, get("a", []byte{1}).Value)
+
+	// The first value expired, the one written later and the ones of the
+	// keyspace without TTL are still there.
+	clk.Advance(30 * time.Second)
+	assert.True(t, get("a", []byte{1}).NotFound)
+	scanResp, err := server.RawScan(nil, &kvrpcpb.RawScanRequest{Context: &kvrpcpb.Context{Keyspace: "a"}, Limit: 10, Cf: cf})
+	assert.Nil(t, err)
+	assert.Equal(t, []*kvrpcpb.KvPair{{Key: []byte{2}, Value: []byte{20}}}, scanResp.Kvs)
+	assert.Equal(t, []byte{11}, get("b", []byte{1}).Value)
+
+	// An expired value doesn't count against the budget of a page.
+	clk.Advance(-time.Minute)
+	put("a", []byte{1}, make([]byte, 100))
+	clk.Advance(time.Minute)
+	put("a", []byte{0}, []byte{0})
+	server.LimitScanBytes(20)
+	scanResp, err = server.RawScan(nil, &kvrpcpb.RawScanRequest{Context: &kvrpcpb.Context{Keyspace: "a"}, Limit: 10, Cf: cf})
+	assert.Nil(t, err)
+	assert.Equal(t, []*kvrpcpb.KvPair{{Key: []byte{0}, Value: []byte{0}}, {Key: []byte{2}, Value: []byte{20}}}, scanResp.Kvs)
+	server.LimitScanBytes(0)
+
+	// The values written before the TTL changed keep theirs.
+	server.keyspaces["a"].ttl = 0
+	server.keyspaces["b"].ttl = time.Minute
+	server.keyspaces["b"].clock = clk
+	put("a", []byte{3}, []byte{30})
+	put("b", []byte{2}, []byte{12})
+	assert.Equal(t, []byte{20}, get("a", []byte{2}).Value)
+	assert.Equal(t, []byte{11}, get("b", []byte{1}).Value)
+	clk.Advance(time.Hour)
+	assert.True(t, get("a", []byte{2}).NotFound)
+	assert.Equal(t, []byte{30}, get("a", []byte{3}).Value)
+	assert.Equal(t, []byte{11}, get("b", []byte{1}).Value)
+	assert.True(t, get("b", []byte{2}).NotFound)
+}
+
+func TestKeyspaceGC1(t *testing.T) {
+	conf := config.NewTestConfig()
+	s := standalone_storage.NewStandAloneStorage(conf)
+	s.Start()
+	server := NewServer(s)
+	server.UseKeyspaces([]config.KeyspaceConfig{
+		{Name: "a", Prefix: "a/", DefaultTTL: time.Minute, GCRetention: time.Hour},
+		{Name: "b", Prefix: "b/"},
+	})
+	defer cleanUpTestData(conf)
+	defer s.Stop()
+	clk := clock.NewFake(time.Unix(10000, 0))
+	server.keyspaces["a"].clock = clk
+
+	// The raw values of the keyspace with a TTL expire.
+	for _, ks := range []string{"a", "b"} {
+		_, err := server.RawPut(nil, &kvrpcpb.RawPutRequest{Context: &kvrpcpb.Context{Keyspace: ks}, Key: []byte{1}, Value: []byte{1}, Cf: engine_util.CfLock})
+		require.Nil(t, err)
+	}
+	// a/2 and b/2 are overwritten three and two hours ago, before the GC
+	// retention of a, b keeps all its history.
+	ts := func(d time.Duration) uint64 {
+		return uint64(clk.Now().Add(d).UnixNano()/int64(time.Millisecond)) << tsoutil.PhysicalShiftBits
+	}
+	older, old := ts(-3*time.Hour), ts(-2*time.Hour)
+	for _, key := range [][]byte{[]byte("a/2"), []byte("b/2")} {
+		for _, startTs := range []uint64{older, old} {
+			require.Nil(t, Set(s, engine_util.CfDefault, mvcc.EncodeKey(key, startTs), []byte{42}))
+			require.Nil(t, Set(s, engine_util.CfWrite, mvcc.EncodeKey(key, startTs+1), (&mvcc.Write{StartTS: startTs, Kind: mvcc.WriteKindPut}).ToBytes()))
+		}
+	}
+
+	clk.Advance(time.Minute)
+	require.Nil(t, server.GCKeyspaces(nil, []string{engine_util.CfLock}))
+	get := func(cf string, key []byte) []byte {
+		value, err := Get(s, cf, key)
+		require.Nil(t, err)
+		return value
+	}
+	assert.Nil(t, get(engine_util.CfLock, []byte{'a', '/', 1}))
+	assert.NotNil(t, get(engine_util.CfLock, []byte{'b', '/', 1}))
+	assert.Nil(t, get(engine_util.CfWrite, mvcc.EncodeKey([]byte("a/2"), older+1)))
+	assert.Nil(t, get(engine_util.CfDefault, mvcc.EncodeKey([]byte("a/2"), older)))
+	assert.NotNil(t, get(engine_util.CfWrite, mvcc.EncodeKey([]byte("a/2"), old+1)))
+	assert.NotNil(t, get(engine_util.CfDefault, mvcc.EncodeKey([]byte("a/2"), old)))
+	assert.NotNil(t, get(engine_util.CfWrite, mvcc.EncodeKey([]byte("b/2"), older+1)))
+}
+
+// regionStorage serves a standalone storage as the regions of its contexts,
+// whose readers, like those of the raftstore, panic when seeking outside of
+// their region.
+type regionStorage struct {
+	*standalone_storage.StandAloneStorage
+	regions map[uint64]*metapb.Region
+}
+
+func (s *regionStorage) Reader(ctx *kvrpcpb.Context) (storage.StorageReader, error) {
+	reader, err := s.StandAloneStorage.Reader(nil)
+	if err != nil {
+		return nil, err
+	}
+	region, ok := s.regions[ctx.GetRegionId()]
+	if !ok {
+		return reader, nil
+	}
+	return &regionReader{StorageReader: reader, region: region}, nil
+}
+
+func (s *regionStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify) error {
+	return s.StandAloneStorage.Write(nil, batch)
+}
+
+type regionReader struct {
+	storage.StorageReader
+	region *metapb.Region
+}
+
+func (r *regionReader) KeyRange() ([]byte, []byte) {
+	return r.region.StartKey, r.region.EndKey
+}
+
+func (r *regionReader) IterCF(cf string) engine_util.DBIterator {
+	return &regionIterator{DBIterator: r.StorageReader.IterCF(cf), region: r.region}
+}
+
+type regionIterator struct {
+	engine_util.DBIterator
+	region *metapb.Region
+}
+
+func (it *regionIterator) Valid() bool {
+	return it.DBIterator.Valid() && !engine_util.ExceedEndKey(it.Item().Key(), it.region.EndKey)
+}
+
+func (it *regionIterator) Seek(key []byte) {
+	if bytes.Compare(key, it.region.StartKey) < 0 || engine_util.ExceedEndKey(key, it.region.EndKey) {
+		panic(fmt.Sprintf("seek %q outside of region %d", key, it.region.Id))
+	}
+	it.DBIterator.Seek(key)
+}
+
+func TestKeyspaceGCRegions1(t *testing.T) {
+	conf := config.NewTestConfig()
+	standalone := standalone_storage.NewStandAloneStorage(conf)
+	standalone.Start()
+	// a/5 splits keyspace a, b lies in region 2 only.
+	split := codec.EncodeBytes([]byte("a/5"))
+	s := &regionStorage{StandAloneStorage: standalone, regions: map[uint64]*metapb.Region{
+		1: {Id: 1, EndKey: split},
+		2: {Id: 2, StartKey: split},
+	}}
+	server := NewServer(s)
+	server.UseKeyspaces([]config.KeyspaceConfig{
+		{Name: "a", Prefix: "a/", DefaultTTL: time.Minute, GCRetention: time.Hour},
+		{Name: "b", Prefix: "b/", DefaultTTL: time.Minute, GCRetention: time.Hour},
+	})
+	defer cleanUpTestData(conf)
+	defer standalone.Stop()
+	clk := clock.NewFake(time.Unix(10000, 0))
+	for _, ks := range server.keyspaces {
+		ks.clock = clk
+	}
+
+	rawKeys := map[string][][]byte{"a": {[]byte("1"), []byte("9")}, "b": {[]byte("1")}}
+	for ks, keys := range rawKeys {
+		for _, key := range keys {
+			_, err := server.RawPut(nil, &kvrpcpb.RawPutRequest{Context: &kvrpcpb.Context{Keyspace: ks}, Key: key, Value: []byte{1}, Cf: engine_util.CfLock})
+			require.Nil(t, err)
+		}
+	}
+	ts := func(d time.Duration) uint64 {
+		return uint64(clk.Now().Add(d).UnixNano()/int64(time.Millisecond)) << tsoutil.PhysicalShiftBits
+	}
+	older, old := ts(-3*time.Hour), ts(-2*time.Hour)
+	mvccKeys := [][]byte{[]byte("a/2"), []byte("a/7"), []byte("b/2")}
+	for _, key := range mvccKeys {
+		for _, startTs := range []uint64{older, old} {
+			require.Nil(t, Set(standalone, engine_util.CfDefault, mvcc.EncodeKey(key, startTs), []byte{42}))
+			require.Nil(t, Set(standalone, engine_util.CfWrite, mvcc.EncodeKey(key, startTs+1), (&mvcc.Write{StartTS: startTs, Kind: mvcc.WriteKindPut}).ToBytes()))
+		}
+	}
+
+	clk.Advance(time.Minute)
+	get := func(cf string, key []byte) []byte {
+		value, err := Get(standalone, cf, key)
+		require.Nil(t, err)
+		return value
+	}
+	// Region 1 only collects the keys before the split.
+	require.Nil(t, server.GCKeyspaces(&kvrpcpb.Context{RegionId: 1}, []string{engine_util.CfLock}))
+	assert.Nil(t, get(engine_util.CfLock, []byte("a/1")))
+	assert.NotNil(t, get(engine_util.CfLock, []byte("a/9")))
+	assert.NotNil(t, get(engine_util.CfLock, []byte("b/1")))
+	assert.Nil(t, get(engine_util.CfWrite, mvcc.EncodeKey([]byte("a/2"), older+1)))
+	assert.NotNil(t, get(engine_util.CfWrite, mvcc.EncodeKey([]byte("a/7"), older+1)))
+	assert.NotNil(t, get(engine_util.CfWrite, mvcc.EncodeKey([]byte("b/2"), older+1)))
+
+	require.Nil(t, server.GCKeyspaces(&kvrpcpb.Context{RegionId: 2}, []string{engine_util.CfLock}))
+	assert.Nil(t, get(engine_util.CfLock, []byte("a/9")))
+	assert.Nil(t, get(engine_util.CfLock, []byte("b/1")))
+	for _, key := range mvccKeys {
+		assert.Nil(t, get(engine_util.CfWrite, mvcc.EncodeKey(key, older+1)))
+		assert.Nil(t, get(engine_util.CfDefault, mvcc.EncodeKey(key, older)))
+		assert.NotNil(t, get(engine_util.CfWrite, mvcc.EncodeKey(key, old+1)))
+	}
+}
+
+func TestByteQuota1(t *testing.T) {
+	q := byteQuota{limit: 10}
+	now := time.Unix(100, 0)
+	assert.True(t, q.admit(now, 6))
+	assert.False(t, q.admit(now, 6))
+	assert.True(t, q.admit(now, 4))
+	// Reads are admitted while the window is not used up.
+	assert.False(t, q.admit(now, 0))
+	// A new window starts every second, admitting a request over the limit.
+	now = now.Add(time.Second)
+	assert.True(t, q.admit(now, 0))
+	assert.True(t, q.admit(now, 20))
+	q.use(now.Add(time.Second), 10)
+	assert.False(t, q.admit(now.Add(time.Second), 0))
+
+	unlimited := byteQuota{}
+	assert.True(t, unlimited.admit(now, 1<<40))
+}
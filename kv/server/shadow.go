@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"google.golang.org/grpc"
+)
+
+const (
+	// shadowTimeout bounds a request mirrored to the shadow cluster.
+	shadowTimeout = time.Second
+	// shadowMaxInflight is the most requests mirrored at the same time,
+	// requests sampled beyond it are skipped so a slow shadow cluster can't
+	// pile up goroutines.
+	shadowMaxInflight = 64
+)
+
+// Shadow mirrors a sample of the requests served to a second cluster, e.g.
+// one running a new version, and counts the responses which differ. Reads are
+// mirrored as is, raw writes are mirrored to a scratch CF of the shadow
+// cluster so they don't overwrite the data compared. The shadow cluster must
+// accept the region information of this one, e.g. a standalone store or a
+// store proxying requests with EnableProxy.
+type Shadow struct {
+	client     tinykvpb.TinyKvClient
+	sampleRate float64
+	scratchCF  string
+	inflight   chan struct{}
+
+	mirrored uint64
+	diverged uint64
+	failed   uint64
+	skipped  uint64
+}
+
+// ShadowStats counts the requests mirrored by a Shadow.
+type ShadowStats struct {
+	// Mirrored is the number of requests sent to the shadow cluster.
+	Mirrored uint64
+	// Diverged is the number of reads answered differently by the clusters.
+	Diverged uint64
+	// Failed is the number of requests the shadow cluster failed.
+	Failed uint64
+	// Skipped is the number of sampled requests dropped as too many were
+	// in flight.
+	Skipped uint64
+}
+
+// NewShadow mirrors sampleRate of the reads, between 0 and 1, to client. The
+// raw writes are mirrored as well to scratchCF if it is not empty.
+func NewShadow(client tinykvpb.TinyKvClient, sampleRate float64, scratchCF string) *Shadow {
+	return &Shadow{
+		client:     client,
+		sampleRate: sampleRate,
+		scratchCF:  scratchCF,
+		inflight:   make(chan struct{}, shadowMaxInflight),
+	}
+}
+
+// UnaryInterceptor returns the interceptor mirroring the requests, to add
+// with Server.UseUnaryInterceptor.
+func (s *Shadow) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil && rand.Float64() < s.sampleRate {
+			s.mirror(req, resp)
+		}
+		return resp, err
+	}
+}
+
+// Stats returns the counters of the requests mirrored so far.
+func (s *Shadow) Stats() ShadowStats {
+	return ShadowStats{
+		Mirrored: atomic.LoadUint64(&s.mirrored),
+		Diverged: atomic.LoadUint64(&s.diverged),
+		Failed:   atomic.LoadUint64(&s.failed),
+		Skipped:  atomic.LoadUint64(&s.skipped),
+	}
+}
+
+// mirror sends req, which was answered here with resp, to the shadow cluster
+// in the background.
+func (s *Shadow) mirror(req, resp interface{}) {
+	send, ok := s.request(req)
+	if !ok || shadowResult(resp) == nil {
+		// Not mirrored, or failed here: there is nothing to compare.
+		return
+	}
+	select {
+	case s.inflight <- struct{}{}:
+	default:
+		atomic.AddUint64(&s.skipped, 1)
+		return
+	}
+	go func() {
+		defer func() { <-s.inflight }()
+		ctx, cancel := context.WithTimeout(context.Background(), shadowTimeout)
+		defer cancel()
+		atomic.AddUint64(&s.mirrored, 1)
+		shadowResp, err := send(ctx)
+		got := shadowResult(shadowResp)
+		if err != nil || got == nil {
+			atomic.AddUint64(&s.failed, 1)
+			log.Debugf("shadow request %v failed: %v, %v", req, shadowResp, err)
+			return
+		}
+		if !isWrite(req) && !proto.Equal(shadowResult(resp), got) {
+			atomic.AddUint64(&s.diverged, 1)
+			log.Warnf("shadow response diverged for %v: %v, want %v", req, got, shadowResult(resp))
+		}
+	}()
+}
+
+// request returns the function sending req to the shadow cluster, or false
+// if req is not mirrored.
+func (s *Shadow) request(req interface{}) (func(ctx context.Context) (proto.Message, error), bool) {
+	switch req := req.(type) {
+	case *kvrpcpb.RawGetRequest:
+		return func(ctx context.Context) (proto.Message, error) { return s.client.RawGet(ctx, req) }, true
+	case *kvrpcpb.RawScanRequest:
+		return func(ctx context.Context) (proto.Message, error) { return s.client.RawScan(ctx, req) }, true
+	case *kvrpcpb.GetRequest:
+		return func(ctx context.Context) (proto.Message, error) { return s.client.KvGet(ctx, req) }, true
+	case *kvrpcpb.ScanRequest:
+		return func(ctx context.Context) (proto.Message, error) { return s.client.KvScan(ctx, req) }, true
+	}
+	if s.scratchCF == "" {
+		return nil, false
+	}
+	switch req := req.(type) {
+	case *kvrpcpb.RawPutRequest:
+		scratch := *req
+		scratch.Cf = s.scratchCF
+		return func(ctx context.Context) (proto.Message, error) { return s.client.RawPut(ctx, &scratch) }, true
+	case *kvrpcpb.RawDeleteRequest:
+		scratch := *req
+		scratch.Cf = s.scratchCF
+		return func(ctx context.Context) (proto.Message, error) { return s.client.RawDelete(ctx, &scratch) }, true
+	}
+	return nil, false
+}
+
+func isWrite(req interface{}) bool {
+	switch req.(type) {
+	case *kvrpcpb.RawPutRequest, *kvrpcpb.RawDeleteRequest:
+		return true
+	}
+	return false
+}
+
+// shadowResult returns the part of resp compared between the clusters, or
+// nil if resp is an error.
+func shadowResult(resp interface{}) proto.Message {
+	switch resp := resp.(type) {
+	case *kvrpcpb.RawGetResponse:
+		if resp == nil || resp.RegionError != nil || resp.Error != "" {
+			return nil
+		}
+		return &kvrpcpb.RawGetResponse{Value: resp.Value, NotFound: resp.NotFound}
+	case *kvrpcpb.RawScanResponse:
+		if resp == nil || resp.RegionError != nil || resp.Error != "" {
+			return nil
+		}
+		return &kvrpcpb.RawScanResponse{Kvs: resp.Kvs}
+	case *kvrpcpb.GetResponse:
+		if resp == nil || resp.RegionError != nil {
+			return nil
+		}
+		return &kvrpcpb.GetResponse{Error: resp.Error, Value: resp.Value, NotFound: resp.NotFound}
+	case *kvrpcpb.ScanResponse:
+		if resp == nil || resp.RegionError != nil {
+			return nil
+		}
+		return &kvrpcpb.ScanResponse{Pairs: resp.Pairs}
+	case *kvrpcpb.RawPutResponse:
+		if resp == nil || resp.RegionError != nil || resp.Error != "" {
+			return nil
+		}
+		return resp
+	case *kvrpcpb.RawDeleteResponse:
+		if resp == nil || resp.RegionError != nil || resp.Error != "" {
+			return nil
+		}
+		return resp
+	}
+	return nil
+}
@@ -39,6 +39,9 @@ func (s *MemStorage) Reader(ctx *kvrpcpb.Context) (StorageReader, error) {
 }
 
 func (s *MemStorage) Write(ctx *kvrpcpb.Context, batch []Modify) error {
+	if err := ValidateBatch(batch); err != nil {
+		return err
+	}
 	for _, m := range batch {
 		switch data := m.Data.(type) {
 		case Put:
@@ -116,6 +119,34 @@ func (s *MemStorage) HasChanged(cf string, key []byte) bool {
 	return !result.(memItem).fresh
 }
 
+func (s *MemStorage) DestroyRanges(ranges []engine_util.CFRange) error {
+	for _, r := range ranges {
+		var data *llrb.LLRB
+		switch r.CF {
+		case engine_util.CfDefault:
+			data = s.CfDefault
+		case engine_util.CfLock:
+			data = s.CfLock
+		case engine_util.CfWrite:
+			data = s.CfWrite
+		default:
+			return fmt.Errorf("mem-server: bad CF %s", r.CF)
+		}
+		var keys []llrb.Item
+		data.AscendGreaterOrEqual(memItem{key: r.Start}, func(item llrb.Item) bool {
+			if engine_util.ExceedEndKey(item.(memItem).key, r.End) {
+				return false
+			}
+			keys = append(keys, item)
+			return true
+		})
+		for _, key := range keys {
+			data.Delete(key)
+		}
+	}
+	return nil
+}
+
 func (s *MemStorage) Len(cf string) int {
 	switch cf {
 	case engine_util.CfDefault:
@@ -1,5 +1,11 @@
 package storage
 
+import (
+	"fmt"
+
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+)
+
 // Modify is a single modification to TinyKV's underlying storage.
 type Modify struct {
 	Data interface{}
@@ -43,3 +49,20 @@ func (m *Modify) Cf() string {
 	}
 	return ""
 }
+
+// ValidateBatch checks that every modification in batch is a Put or a Delete on
+// a known CF. Storage implementations call it before writing anything, so an
+// invalid modification fails the whole batch instead of leaving it half applied.
+func ValidateBatch(batch []Modify) error {
+	for i := range batch {
+		switch batch[i].Data.(type) {
+		case Put, Delete:
+		default:
+			return fmt.Errorf("invalid modify %T at %d", batch[i].Data, i)
+		}
+		if !engine_util.IsValidCF(batch[i].Cf()) {
+			return fmt.Errorf("invalid cf %q at %d", batch[i].Cf(), i)
+		}
+	}
+	return nil
+}
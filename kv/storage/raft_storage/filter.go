@@ -0,0 +1,122 @@
+package raft_storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+)
+
+// MessageFilter inspects a raft message before ServerTransport sends it. It
+// returns the message to send, which is msg itself, a modified copy or nil to
+// drop it, and how long to hold the message back before sending it.
+type MessageFilter interface {
+	Filter(msg *raft_serverpb.RaftMessage) (*raft_serverpb.RaftMessage, time.Duration)
+}
+
+// MessageFilterFunc adapts a function to a MessageFilter.
+type MessageFilterFunc func(msg *raft_serverpb.RaftMessage) (*raft_serverpb.RaftMessage, time.Duration)
+
+func (f MessageFilterFunc) Filter(msg *raft_serverpb.RaftMessage) (*raft_serverpb.RaftMessage, time.Duration) {
+	return f(msg)
+}
+
+// DropTypeFilter drops every raft message of the given types.
+type DropTypeFilter struct {
+	Types []eraftpb.MessageType
+}
+
+func (f *DropTypeFilter) Filter(msg *raft_serverpb.RaftMessage) (*raft_serverpb.RaftMessage, time.Duration) {
+	for _, tp := range f.Types {
+		if msg.GetMessage().GetMsgType() == tp {
+			return nil, 0
+		}
+	}
+	return msg, 0
+}
+
+// DelayPeerFilter delays every raft message sent to the given peer.
+type DelayPeerFilter struct {
+	PeerID uint64
+	Delay  time.Duration
+}
+
+func (f *DelayPeerFilter) Filter(msg *raft_serverpb.RaftMessage) (*raft_serverpb.RaftMessage, time.Duration) {
+	if msg.GetToPeer().GetId() == f.PeerID {
+		return msg, f.Delay
+	}
+	return msg, 0
+}
+
+// IsolateStoreFilter drops every raft message sent to the given store, it is
+// used to cut a misbehaving store off temporarily.
+type IsolateStoreFilter struct {
+	StoreID uint64
+}
+
+func (f *IsolateStoreFilter) Filter(msg *raft_serverpb.RaftMessage) (*raft_serverpb.RaftMessage, time.Duration) {
+	if msg.GetToPeer().GetStoreId() == f.StoreID {
+		return nil, 0
+	}
+	return msg, 0
+}
+
+type namedFilter struct {
+	name   string
+	filter MessageFilter
+}
+
+// messageFilters is the set of filters registered on a transport, in the
+// order they were added.
+type messageFilters struct {
+	mu      sync.RWMutex
+	filters []namedFilter
+}
+
+// add registers filter under name, replacing the filter with the same name.
+func (fs *messageFilters) add(name string, filter MessageFilter) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	for i := range fs.filters {
+		if fs.filters[i].name == name {
+			fs.filters[i].filter = filter
+			return
+		}
+	}
+	fs.filters = append(fs.filters, namedFilter{name: name, filter: filter})
+}
+
+func (fs *messageFilters) remove(name string) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	for i := range fs.filters {
+		if fs.filters[i].name == name {
+			fs.filters = append(fs.filters[:i], fs.filters[i+1:]...)
+			return
+		}
+	}
+}
+
+func (fs *messageFilters) clear() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.filters = nil
+}
+
+// apply runs msg through all the filters. The delays of the filters add up, a
+// nil message means one of them dropped it.
+func (fs *messageFilters) apply(msg *raft_serverpb.RaftMessage) (*raft_serverpb.RaftMessage, time.Duration) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	var delay time.Duration
+	for _, f := range fs.filters {
+		var d time.Duration
+		msg, d = f.filter.Filter(msg)
+		if msg == nil {
+			return nil, 0
+		}
+		delay += d
+	}
+	return msg, delay
+}
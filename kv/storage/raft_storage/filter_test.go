@@ -0,0 +1,42 @@
+package raft_storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageFilters(t *testing.T) {
+	newMsg := func(tp eraftpb.MessageType, peerID uint64) *raft_serverpb.RaftMessage {
+		return &raft_serverpb.RaftMessage{
+			ToPeer:  &metapb.Peer{Id: peerID, StoreId: peerID},
+			Message: &eraftpb.Message{MsgType: tp},
+		}
+	}
+	var fs messageFilters
+	fs.add("drop", &DropTypeFilter{Types: []eraftpb.MessageType{eraftpb.MessageType_MsgHeartbeat}})
+	fs.add("delay", &DelayPeerFilter{PeerID: 2, Delay: time.Second})
+
+	msg, delay := fs.apply(newMsg(eraftpb.MessageType_MsgHeartbeat, 2))
+	require.Nil(t, msg)
+	msg, delay = fs.apply(newMsg(eraftpb.MessageType_MsgAppend, 2))
+	require.NotNil(t, msg)
+	require.Equal(t, time.Second, delay)
+	msg, delay = fs.apply(newMsg(eraftpb.MessageType_MsgAppend, 3))
+	require.NotNil(t, msg)
+	require.Equal(t, time.Duration(0), delay)
+
+	// A filter added under an existing name replaces it.
+	fs.add("delay", &IsolateStoreFilter{StoreID: 3})
+	msg, _ = fs.apply(newMsg(eraftpb.MessageType_MsgAppend, 3))
+	require.Nil(t, msg)
+
+	fs.remove("delay")
+	fs.remove("drop")
+	msg, _ = fs.apply(newMsg(eraftpb.MessageType_MsgHeartbeat, 3))
+	require.NotNil(t, msg)
+}
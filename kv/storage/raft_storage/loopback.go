@@ -0,0 +1,151 @@
+package raft_storage
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
+	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+)
+
+// Transport sends the raft messages of a store to the other stores.
+// ServerTransport sends them over gRPC, LoopbackTransport hands them to stores
+// running in the same process.
+type Transport interface {
+	raftstore.Transport
+	AddFilter(name string, filter MessageFilter)
+	RemoveFilter(name string)
+	ClearFilters()
+}
+
+var (
+	_ Transport = new(ServerTransport)
+	_ Transport = new(LoopbackTransport)
+)
+
+// LoopbackNetwork connects the stores of one process, so a whole cluster can
+// run in a single binary without listening on any port.
+type LoopbackNetwork struct {
+	mu       sync.RWMutex
+	routers  map[uint64]message.RaftRouter
+	snapMgrs map[uint64]*snap.SnapManager
+}
+
+// DefaultLoopbackNetwork is the network joined by the stores configured with
+// the loopback transport.
+var DefaultLoopbackNetwork = NewLoopbackNetwork()
+
+func NewLoopbackNetwork() *LoopbackNetwork {
+	return &LoopbackNetwork{
+		routers:  make(map[uint64]message.RaftRouter),
+		snapMgrs: make(map[uint64]*snap.SnapManager),
+	}
+}
+
+// AddStore connects a store to the network.
+func (n *LoopbackNetwork) AddStore(storeID uint64, router message.RaftRouter, snapMgr *snap.SnapManager) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.routers[storeID] = router
+	n.snapMgrs[storeID] = snapMgr
+}
+
+// RemoveStore disconnects a store, messages sent to it afterwards are dropped.
+func (n *LoopbackNetwork) RemoveStore(storeID uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.routers, storeID)
+	delete(n.snapMgrs, storeID)
+}
+
+func (n *LoopbackNetwork) deliver(msg *raft_serverpb.RaftMessage) error {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	toStore := msg.GetToPeer().GetStoreId()
+	router, ok := n.routers[toStore]
+	if !ok {
+		return fmt.Errorf("store %d is not connected", toStore)
+	}
+	if msg.GetMessage().GetMsgType() == eraftpb.MessageType_MsgSnapshot {
+		if err := n.copySnapshot(msg.GetFromPeer().GetStoreId(), toStore, msg.Message.Snapshot); err != nil {
+			return err
+		}
+	}
+	return router.SendRaftMessage(msg)
+}
+
+// copySnapshot copies the snapshot files from the snap manager of the sending
+// store to the one of the receiving store, as the snap worker does over gRPC.
+func (n *LoopbackNetwork) copySnapshot(fromStore, toStore uint64, snapshot *eraftpb.Snapshot) error {
+	key, err := snap.SnapKeyFromSnap(snapshot)
+	if err != nil {
+		return err
+	}
+	fromSnapMgr, ok := n.snapMgrs[fromStore]
+	if !ok {
+		return fmt.Errorf("store %d is not connected", fromStore)
+	}
+	toSnapMgr := n.snapMgrs[toStore]
+
+	fromSnapMgr.Register(key, snap.SnapEntrySending)
+	defer fromSnapMgr.Deregister(key, snap.SnapEntrySending)
+	fromSnap, err := fromSnapMgr.GetSnapshotForSending(key)
+	if err != nil {
+		return err
+	}
+	toSnapMgr.Register(key, snap.SnapEntryReceiving)
+	defer toSnapMgr.Deregister(key, snap.SnapEntryReceiving)
+	toSnap, err := toSnapMgr.GetSnapshotForReceiving(key, snapshot.GetData())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(toSnap, fromSnap); err != nil {
+		return err
+	}
+	return toSnap.Save()
+}
+
+// LoopbackTransport is the Transport of a store on a LoopbackNetwork.
+type LoopbackTransport struct {
+	network *LoopbackNetwork
+	filters messageFilters
+}
+
+func NewLoopbackTransport(network *LoopbackNetwork) *LoopbackTransport {
+	return &LoopbackTransport{network: network}
+}
+
+func (t *LoopbackTransport) AddFilter(name string, filter MessageFilter) {
+	t.filters.add(name, filter)
+}
+
+func (t *LoopbackTransport) RemoveFilter(name string) {
+	t.filters.remove(name)
+}
+
+func (t *LoopbackTransport) ClearFilters() {
+	t.filters.clear()
+}
+
+func (t *LoopbackTransport) Send(msg *raft_serverpb.RaftMessage) error {
+	msg, delay := t.filters.apply(msg)
+	if msg == nil {
+		return nil
+	}
+	if delay > 0 {
+		time.AfterFunc(delay, func() {
+			if err := t.network.deliver(msg); err != nil {
+				log.Debugf("send raft msg err. err: %v", err)
+			}
+		})
+		return nil
+	}
+	return t.network.deliver(msg)
+}
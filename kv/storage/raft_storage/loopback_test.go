@@ -0,0 +1,51 @@
+package raft_storage
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+type recordRouter struct {
+	msgs []*raft_serverpb.RaftMessage
+}
+
+func (r *recordRouter) Send(regionID uint64, msg message.Msg) error {
+	return nil
+}
+
+func (r *recordRouter) SendRaftMessage(msg *raft_serverpb.RaftMessage) error {
+	r.msgs = append(r.msgs, msg)
+	return nil
+}
+
+func (r *recordRouter) SendRaftCommand(req *raft_cmdpb.RaftCmdRequest, cb *message.Callback) error {
+	return nil
+}
+
+func TestLoopbackTransport(t *testing.T) {
+	network := NewLoopbackNetwork()
+	router := new(recordRouter)
+	network.AddStore(2, router, nil)
+	trans := NewLoopbackTransport(network)
+
+	msg := &raft_serverpb.RaftMessage{
+		ToPeer:  &metapb.Peer{Id: 2, StoreId: 2},
+		Message: &eraftpb.Message{MsgType: eraftpb.MessageType_MsgAppend},
+	}
+	require.Nil(t, trans.Send(msg))
+	require.Equal(t, []*raft_serverpb.RaftMessage{msg}, router.msgs)
+
+	trans.AddFilter("isolate", &IsolateStoreFilter{StoreID: 2})
+	require.Nil(t, trans.Send(msg))
+	require.Len(t, router.msgs, 1)
+	trans.ClearFilters()
+
+	network.RemoveStore(2)
+	require.NotNil(t, trans.Send(msg))
+}
@@ -0,0 +1,40 @@
+package raft_storage
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap/errors"
+)
+
+// MigrateStandalone turns the db of a stopped standalone instance at
+// standalonePath into the db of a raft store at dbPath, without copying the
+// data: the standalone db becomes the kv engine of the store. A store started
+// on dbPath bootstraps a new cluster, whose single region holds the data, and
+// fails if its scheduler is already bootstrapped. Other stores can join the
+// cluster afterwards as usual.
+func MigrateStandalone(standalonePath, dbPath string) error {
+	kvPath := filepath.Join(dbPath, "kv")
+	raftPath := filepath.Join(dbPath, "raft")
+	if _, err := os.Stat(kvPath); !os.IsNotExist(err) {
+		return errors.Errorf("%s already exists", kvPath)
+	}
+	if err := os.MkdirAll(dbPath, os.ModePerm); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.Rename(standalonePath, kvPath); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.MkdirAll(raftPath, os.ModePerm); err != nil {
+		return errors.WithStack(err)
+	}
+
+	engines := engine_util.NewEngines(engine_util.CreateDB(kvPath, false), engine_util.CreateDB(raftPath, true), kvPath, raftPath)
+	err := raftstore.PrepareStandaloneMigration(engines)
+	if cerr := engines.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
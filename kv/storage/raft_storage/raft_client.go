@@ -6,21 +6,66 @@ import (
 	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/log"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
+	"github.com/pingcap/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 )
 
+// msgPriority classifies outbound raft messages so that a backlog of bulky
+// messages can't delay the ones keeping leadership alive.
+type msgPriority int
+
+const (
+	// priorityElection covers votes, heartbeats and leadership transfer.
+	priorityElection msgPriority = iota
+	// priorityAppend covers log replication.
+	priorityAppend
+	// prioritySnapshot covers snapshot messages.
+	prioritySnapshot
+	numPriorities
+)
+
+// priorityWeights is the number of messages drained from each queue per round.
+var priorityWeights = [numPriorities]int{8, 4, 1}
+
+// raftConnQueueSize is the capacity of every per-priority queue of a connection.
+const raftConnQueueSize = 4096
+
+var errRaftConnQueueFull = errors.New("raft connection queue is full")
+
+func messagePriority(msg *raft_serverpb.RaftMessage) msgPriority {
+	switch msg.GetMessage().GetMsgType() {
+	case eraftpb.MessageType_MsgSnapshot:
+		return prioritySnapshot
+	case eraftpb.MessageType_MsgAppend, eraftpb.MessageType_MsgAppendResponse:
+		return priorityAppend
+	default:
+		return priorityElection
+	}
+}
+
 type raftConn struct {
-	streamMu sync.Mutex
-	stream   tinykvpb.TinyKv_RaftClient
-	ctx      context.Context
-	cancel   context.CancelFunc
+	stream tinykvpb.TinyKv_RaftClient
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// queues holds the messages waiting to be sent, one queue per priority.
+	queues [numPriorities]chan *raft_serverpb.RaftMessage
+	// notify wakes up the sending goroutine after a message is queued.
+	notify chan struct{}
+	// err is set once the stream fails, the connection must be dropped then.
+	errMu sync.Mutex
+	err   error
+
+	util *worker.Utilization
 }
 
-func newRaftConn(addr string, cfg *config.Config) (*raftConn, error) {
+func newRaftConn(addr string, cfg *config.Config, util *worker.Utilization) (*raftConn, error) {
 	cc, err := grpc.Dial(addr, grpc.WithInsecure(),
 		grpc.WithInitialWindowSize(2*1024*1024),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
@@ -37,62 +82,184 @@ func newRaftConn(addr string, cfg *config.Config) (*raftConn, error) {
 		cancel()
 		return nil, err
 	}
-	return &raftConn{
+	conn := &raftConn{
 		stream: stream,
 		ctx:    ctx,
 		cancel: cancel,
-	}, nil
+		notify: make(chan struct{}, 1),
+		util:   util,
+	}
+	for i := range conn.queues {
+		conn.queues[i] = make(chan *raft_serverpb.RaftMessage, raftConnQueueSize)
+	}
+	go conn.run()
+	return conn, nil
 }
 
 func (c *raftConn) Stop() {
 	c.cancel()
 }
 
+// Send queues the message by its priority. It returns an error if the
+// connection is broken, or errRaftConnQueueFull if the queue is full in which
+// case the message is dropped and raft will retry it later.
 func (c *raftConn) Send(msg *raft_serverpb.RaftMessage) error {
-	c.streamMu.Lock()
-	defer c.streamMu.Unlock()
-	return c.stream.Send(msg)
+	if err := c.getErr(); err != nil {
+		return err
+	}
+	select {
+	case c.queues[messagePriority(msg)] <- msg:
+	default:
+		return errRaftConnQueueFull
+	}
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (c *raftConn) getErr() error {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	return c.err
+}
+
+func (c *raftConn) setErr(err error) {
+	c.errMu.Lock()
+	defer c.errMu.Unlock()
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+// run drains the queues with weighted round robin until the connection is
+// stopped or the stream breaks.
+func (c *raftConn) run() {
+	for {
+		select {
+		case <-c.ctx.Done():
+			c.setErr(c.ctx.Err())
+			return
+		case <-c.notify:
+		}
+		if err := c.drain(); err != nil {
+			log.Errorf("raft connection failed to send: %v", err)
+			c.setErr(err)
+			c.cancel()
+			return
+		}
+	}
+}
+
+// drain sends the queued messages until the queues are empty.
+func (c *raftConn) drain() error {
+	start, total := time.Now(), 0
+	defer func() { c.util.Record(start, total) }()
+	for {
+		sent, err := c.drainRound()
+		total += sent
+		if err != nil || sent == 0 {
+			return err
+		}
+	}
+}
+
+// pending returns the number of messages waiting to be sent.
+func (c *raftConn) pending() int {
+	n := 0
+	for _, q := range c.queues {
+		n += len(q)
+	}
+	return n
+}
+
+// drainRound sends at most priorityWeights[p] messages from every queue p,
+// higher priorities first, and returns the number of messages sent.
+func (c *raftConn) drainRound() (int, error) {
+	sent := 0
+	for p := msgPriority(0); p < numPriorities; p++ {
+		for i := 0; i < priorityWeights[p]; i++ {
+			var msg *raft_serverpb.RaftMessage
+			select {
+			case msg = <-c.queues[p]:
+			default:
+			}
+			if msg == nil {
+				break
+			}
+			if err := c.stream.Send(msg); err != nil {
+				return sent, err
+			}
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// connKey identifies one of the connections to a store, see
+// config.Config.TransportSenders.
+type connKey struct {
+	addr  string
+	index uint64
 }
 
 type RaftClient struct {
 	config *config.Config
 	sync.RWMutex
-	conns map[string]*raftConn
+	conns map[connKey]*raftConn
 	addrs map[uint64]string
+	util  *worker.Utilization
+
+	droppedMu sync.Mutex
+	dropped   map[eraftpb.MessageType]uint64
 }
 
 func newRaftClient(config *config.Config) *RaftClient {
 	return &RaftClient{
-		config: config,
-		conns:  make(map[string]*raftConn),
-		addrs:  make(map[uint64]string),
+		config:  config,
+		conns:   make(map[connKey]*raftConn),
+		addrs:   make(map[uint64]string),
+		util:    worker.NewUtilization(0),
+		dropped: make(map[eraftpb.MessageType]uint64),
+	}
+}
+
+// connKey returns the key of the connection the messages of the region are
+// sent over.
+func (c *RaftClient) connKey(addr string, regionID uint64) connKey {
+	senders := uint64(1)
+	if c.config.TransportSenders > 1 {
+		senders = uint64(c.config.TransportSenders)
 	}
+	return connKey{addr: addr, index: regionID % senders}
 }
 
-func (c *RaftClient) getConn(addr string, regionID uint64) (*raftConn, error) {
+func (c *RaftClient) getConn(key connKey) (*raftConn, error) {
 	c.RLock()
-	conn, ok := c.conns[addr]
+	conn, ok := c.conns[key]
 	if ok {
 		c.RUnlock()
 		return conn, nil
 	}
 	c.RUnlock()
-	newConn, err := newRaftConn(addr, c.config)
+	newConn, err := newRaftConn(key.addr, c.config, c.util)
 	if err != nil {
 		return nil, err
 	}
 	c.Lock()
 	defer c.Unlock()
-	if conn, ok := c.conns[addr]; ok {
+	if conn, ok := c.conns[key]; ok {
 		newConn.Stop()
 		return conn, nil
 	}
-	c.conns[addr] = newConn
+	c.conns[key] = newConn
 	return newConn, nil
 }
 
 func (c *RaftClient) Send(storeID uint64, addr string, msg *raft_serverpb.RaftMessage) error {
-	conn, err := c.getConn(addr, msg.GetRegionId())
+	key := c.connKey(addr, msg.GetRegionId())
+	conn, err := c.getConn(key)
 	if err != nil {
 		return err
 	}
@@ -100,18 +267,45 @@ func (c *RaftClient) Send(storeID uint64, addr string, msg *raft_serverpb.RaftMe
 	if err == nil {
 		return nil
 	}
+	if err == errRaftConnQueueFull {
+		// The connection is fine, only the queue of the message is full.
+		// The message is dropped, as if the network lost it, and the
+		// queues of the other priorities are kept.
+		c.drop(storeID, msg)
+		return nil
+	}
 
 	log.Error("raft client failed to send")
 	c.Lock()
 	defer c.Unlock()
 	conn.Stop()
-	delete(c.conns, addr)
+	delete(c.conns, key)
 	if oldAddr, ok := c.addrs[storeID]; ok && oldAddr == addr {
 		delete(c.addrs, storeID)
 	}
 	return err
 }
 
+func (c *RaftClient) drop(storeID uint64, msg *raft_serverpb.RaftMessage) {
+	tp := msg.GetMessage().GetMsgType()
+	log.Debugf("raft connection queue to store %d is full, dropping %s", storeID, tp)
+	c.droppedMu.Lock()
+	c.dropped[tp]++
+	c.droppedMu.Unlock()
+}
+
+// DroppedMessages returns the number of raft messages of each type dropped
+// so far as the queue of their connection was full.
+func (c *RaftClient) DroppedMessages() map[eraftpb.MessageType]uint64 {
+	c.droppedMu.Lock()
+	defer c.droppedMu.Unlock()
+	dropped := make(map[eraftpb.MessageType]uint64, len(c.dropped))
+	for tp, n := range c.dropped {
+		dropped[tp] = n
+	}
+	return dropped
+}
+
 func (c *RaftClient) GetAddr(storeID uint64) string {
 	c.RLock()
 	defer c.RUnlock()
@@ -125,6 +319,20 @@ func (c *RaftClient) InsertAddr(storeID uint64, addr string) {
 	c.addrs[storeID] = addr
 }
 
+// Stats returns the counters of the goroutines sending the messages, one per
+// open connection.
+func (c *RaftClient) Stats() worker.PoolStats {
+	c.RLock()
+	defer c.RUnlock()
+	pending := 0
+	for _, conn := range c.conns {
+		pending += conn.pending()
+	}
+	stats := c.util.Stats(pending)
+	stats.Workers = len(c.conns)
+	return stats
+}
+
 func (c *RaftClient) Flush() {
 	// Not support BufferHint
 }
@@ -0,0 +1,37 @@
+package raft_storage
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRaftClientDropsOnFullQueue tests that a message whose queue is full is
+// dropped and counted, and that the connection, with the messages queued in
+// the other priorities, is kept.
+func TestRaftClientDropsOnFullQueue(t *testing.T) {
+	client := newRaftClient(config.NewTestConfig())
+	conn := &raftConn{notify: make(chan struct{}, 1)}
+	for i := range conn.queues {
+		conn.queues[i] = make(chan *raft_serverpb.RaftMessage, 1)
+	}
+	key := client.connKey("store2", 1)
+	client.conns[key] = conn
+	client.InsertAddr(2, "store2")
+
+	msg := func(tp eraftpb.MessageType) *raft_serverpb.RaftMessage {
+		return &raft_serverpb.RaftMessage{RegionId: 1, Message: &eraftpb.Message{MsgType: tp}}
+	}
+	require.Nil(t, client.Send(2, "store2", msg(eraftpb.MessageType_MsgAppend)))
+	require.Nil(t, client.Send(2, "store2", msg(eraftpb.MessageType_MsgAppend)))
+	require.Nil(t, client.Send(2, "store2", msg(eraftpb.MessageType_MsgHeartbeat)))
+
+	require.Equal(t, map[eraftpb.MessageType]uint64{eraftpb.MessageType_MsgAppend: 1}, client.DroppedMessages())
+	require.Equal(t, conn, client.conns[key])
+	require.Equal(t, "store2", client.GetAddr(2))
+	require.Len(t, conn.queues[priorityAppend], 1)
+	require.Len(t, conn.queues[priorityElection], 1)
+}
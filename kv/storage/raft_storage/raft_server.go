@@ -14,8 +14,8 @@ import (
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
 	"github.com/pingcap-incubator/tinykv/kv/storage"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
-	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_cmdpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/tinykvpb"
@@ -28,27 +28,24 @@ type RaftStorage struct {
 	engines *engine_util.Engines
 	config  *config.Config
 
-	node          *raftstore.Node
-	snapManager   *snap.SnapManager
-	raftRouter    *raftstore.RaftstoreRouter
-	raftSystem    *raftstore.Raftstore
-	resolveWorker *worker.Worker
-	snapWorker    *worker.Worker
+	node            *raftstore.Node
+	schedulerClient scheduler_client.Client
+	snapManager     *snap.SnapManager
+	raftRouter      *raftstore.RaftstoreRouter
+	raftSystem      *raftstore.Raftstore
+	resolveWorker   *worker.Worker
+	snapWorker      *worker.Worker
+	trans           Transport
+	// raftClient sends the raft messages of the grpc transport, it is nil
+	// with the loopback one.
+	raftClient *RaftClient
 
 	wg sync.WaitGroup
 }
 
-type RegionError struct {
-	RequestErr *errorpb.Error
-}
-
-func (re *RegionError) Error() string {
-	return re.RequestErr.String()
-}
-
 func (rs *RaftStorage) checkResponse(resp *raft_cmdpb.RaftCmdResponse, reqCount int) error {
 	if resp.Header.Error != nil {
-		return &RegionError{RequestErr: resp.Header.Error}
+		return &kverrors.RegionError{RequestErr: resp.Header.Error}
 	}
 	if len(resp.Responses) != reqCount {
 		return errors.Errorf("responses count %d is not equal to requests count %d",
@@ -75,7 +72,12 @@ func NewRaftStorage(conf *config.Config) *RaftStorage {
 	return &RaftStorage{engines: engines, config: conf}
 }
 
+// Write proposes the whole batch as a single raft command, whose requests are
+// applied in one write batch.
 func (rs *RaftStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify) error {
+	if err := storage.ValidateBatch(batch); err != nil {
+		return err
+	}
 	var reqs []*raft_cmdpb.Request
 	for _, m := range batch {
 		switch m.Data.(type) {
@@ -117,12 +119,25 @@ func (rs *RaftStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify) error
 	return rs.checkResponse(cb.WaitResp(), len(reqs))
 }
 
+// DestroyRanges deletes the ranges from the kv engine of this store only,
+// see storage.RangeDestroyer. The ranges are compacted in the background if
+// enough keys were deleted.
+func (rs *RaftStorage) DestroyRanges(ranges []engine_util.CFRange) error {
+	n, err := engine_util.DeleteCFRanges(rs.engines.Kv, ranges)
+	if err != nil {
+		return err
+	}
+	rs.engines.Tombstones.Record(ranges, n)
+	return nil
+}
+
 func (rs *RaftStorage) Reader(ctx *kvrpcpb.Context) (storage.StorageReader, error) {
 	header := &raft_cmdpb.RaftRequestHeader{
-		RegionId:    ctx.RegionId,
-		Peer:        ctx.Peer,
-		RegionEpoch: ctx.RegionEpoch,
-		Term:        ctx.Term,
+		RegionId:     ctx.RegionId,
+		Peer:         ctx.Peer,
+		RegionEpoch:  ctx.RegionEpoch,
+		Term:         ctx.Term,
+		AppliedIndex: ctx.AppliedIndex,
 	}
 	request := &raft_cmdpb.RaftCmdRequest{
 		Header: header,
@@ -146,7 +161,26 @@ func (rs *RaftStorage) Reader(ctx *kvrpcpb.Context) (storage.StorageReader, erro
 	if cb.Txn == nil {
 		panic("can not found region snap")
 	}
-	return NewRegionReader(cb.Txn, *resp.Responses[0].GetSnap().Region), nil
+	reader := NewRegionReader(cb.Txn, *resp.Responses[0].GetSnap().Region)
+	reader.timeDetail = timeDetail(cb.Timing)
+	return reader, nil
+}
+
+// timeDetail breaks the time a command took through the raftstore down into
+// its stages.
+func timeDetail(t message.Timing) kvrpcpb.TimeDetail {
+	var detail kvrpcpb.TimeDetail
+	if t.Handled.IsZero() {
+		return detail
+	}
+	detail.QueueWaitNs = uint64(t.Handled.Sub(t.Created))
+	if t.Committed.IsZero() {
+		detail.RaftWaitNs = uint64(t.Done.Sub(t.Handled))
+		return detail
+	}
+	detail.RaftWaitNs = uint64(t.Committed.Sub(t.Handled))
+	detail.ApplyWaitNs = uint64(t.Done.Sub(t.Committed))
+	return detail
 }
 
 func (rs *RaftStorage) Raft(stream tinykvpb.TinyKv_RaftServer) error {
@@ -179,6 +213,7 @@ func (rs *RaftStorage) Start() error {
 	if err != nil {
 		return err
 	}
+	rs.schedulerClient = schedulerClient
 	rs.raftRouter, rs.raftSystem = raftstore.CreateRaftstore(cfg)
 
 	rs.resolveWorker = worker.NewWorker("resolver", &rs.wg)
@@ -192,19 +227,98 @@ func (rs *RaftStorage) Start() error {
 	snapRunner := newSnapRunner(rs.snapManager, rs.config, rs.raftRouter)
 	rs.snapWorker.Start(snapRunner)
 
-	raftClient := newRaftClient(cfg)
-	trans := NewServerTransport(raftClient, snapSender, rs.raftRouter, resolveSender)
+	if cfg.Transport == config.TransportLoopback {
+		rs.trans = NewLoopbackTransport(DefaultLoopbackNetwork)
+	} else {
+		rs.raftClient = newRaftClient(cfg)
+		rs.trans = NewServerTransport(rs.raftClient, snapSender, rs.raftRouter, resolveSender)
+	}
 
 	rs.node = raftstore.NewNode(rs.raftSystem, rs.config, schedulerClient)
-	err = rs.node.Start(context.TODO(), rs.engines, trans, rs.snapManager)
+	err = rs.node.Start(context.TODO(), rs.engines, rs.trans, rs.snapManager)
 	if err != nil {
 		return err
 	}
+	if cfg.Transport == config.TransportLoopback {
+		DefaultLoopbackNetwork.AddStore(rs.node.GetStoreID(), rs.raftRouter, rs.snapManager)
+	}
 
 	return nil
 }
 
+// SchedulerClient returns the client of the scheduler, it must be called
+// after Start.
+func (rs *RaftStorage) SchedulerClient() scheduler_client.Client {
+	return rs.schedulerClient
+}
+
+// SetReadOnly puts the store in or out of read-only mode, in which writes are
+// rejected with ServerIsBusy while reads and replication go on. It must be
+// called after Start.
+func (rs *RaftStorage) SetReadOnly(readOnly bool) {
+	rs.raftSystem.SetReadOnly(readOnly)
+}
+
+// IsReadOnly returns whether the store is in read-only mode.
+func (rs *RaftStorage) IsReadOnly() bool {
+	return rs.raftSystem.IsReadOnly()
+}
+
+// SetLogOnly puts the store in or out of log-only mode, see
+// raftstore.Raftstore.SetLogOnly. Leaving it promotes a warm standby. It must
+// be called after Start.
+func (rs *RaftStorage) SetLogOnly(logOnly bool) {
+	rs.raftSystem.SetLogOnly(logOnly)
+}
+
+// SnapApplyProgress returns the progress of the snapshots the store is
+// applying. It must be called after Start.
+func (rs *RaftStorage) SnapApplyProgress() []snap.ApplyProgress {
+	return rs.snapManager.ApplyProgresses()
+}
+
+// WorkerStats returns the counters of the raft workers, of the apply pool
+// and of the transport. It must be called after Start.
+func (rs *RaftStorage) WorkerStats() raftstore.WorkerStats {
+	stats := rs.raftSystem.WorkerStats()
+	if rs.raftClient != nil {
+		stats.Transport = rs.raftClient.Stats()
+	}
+	return stats
+}
+
+// RegionContexts returns the request contexts of the regions this store has
+// a peer of, e.g. for the background work of the server going through them.
+// It must be called after Start.
+func (rs *RaftStorage) RegionContexts() []*kvrpcpb.Context {
+	storeID := rs.node.GetStoreID()
+	var contexts []*kvrpcpb.Context
+	for _, region := range rs.raftSystem.Regions() {
+		for _, peer := range region.Peers {
+			if peer.StoreId == storeID {
+				contexts = append(contexts, &kvrpcpb.Context{RegionId: region.Id, RegionEpoch: region.RegionEpoch, Peer: peer})
+				break
+			}
+		}
+	}
+	return contexts
+}
+
+// AddMessageFilter registers a filter on the raft messages this store sends,
+// e.g. to isolate a misbehaving peer for a while. It must be called after Start.
+func (rs *RaftStorage) AddMessageFilter(name string, filter MessageFilter) {
+	rs.trans.AddFilter(name, filter)
+}
+
+// RemoveMessageFilter unregisters the filter added under name.
+func (rs *RaftStorage) RemoveMessageFilter(name string) {
+	rs.trans.RemoveFilter(name)
+}
+
 func (rs *RaftStorage) Stop() error {
+	if rs.config.Transport == config.TransportLoopback {
+		DefaultLoopbackNetwork.RemoveStore(rs.node.GetStoreID())
+	}
 	rs.snapWorker.Stop()
 	rs.node.Stop()
 	rs.resolveWorker.Stop()
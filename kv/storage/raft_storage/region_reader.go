@@ -4,12 +4,14 @@ import (
 	"github.com/Connor1996/badger"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 )
 
 type RegionReader struct {
-	txn    *badger.Txn
-	region *metapb.Region
+	txn        *badger.Txn
+	region     *metapb.Region
+	timeDetail kvrpcpb.TimeDetail
 }
 
 func NewRegionReader(txn *badger.Txn, region metapb.Region) *RegionReader {
@@ -34,6 +36,17 @@ func (r *RegionReader) IterCF(cf string) engine_util.DBIterator {
 	return NewRegionIterator(engine_util.NewCFIterator(cf, r.txn), r.region)
 }
 
+// TimeDetail returns how long the snapshot of the reader took through the
+// raftstore.
+func (r *RegionReader) TimeDetail() kvrpcpb.TimeDetail {
+	return r.timeDetail
+}
+
+// KeyRange returns the range of the region of the reader.
+func (r *RegionReader) KeyRange() ([]byte, []byte) {
+	return r.region.StartKey, r.region.EndKey
+}
+
 func (r *RegionReader) Close() {
 	r.txn.Discard()
 }
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/config"
@@ -16,6 +18,7 @@ import (
 	"github.com/pingcap/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 )
 
 type sendSnapTask struct {
@@ -29,10 +32,42 @@ type recvSnapTask struct {
 	callback func(error)
 }
 
+// snapBackoffKey is the trailer key a receiver sets to ask the sender to hold
+// back further snapshot data, the value is a duration in milliseconds.
+const snapBackoffKey = "snapshot-backoff-ms"
+
+const (
+	// snapBusyReceivingCount is the number of snapshots being received or
+	// applied above which a store considers itself under snapshot pressure.
+	snapBusyReceivingCount = 4
+	// snapBackoffUnit is the backoff asked for per snapshot above the limit.
+	snapBackoffUnit = time.Second
+	// snapMaxBackoff caps the backoff a sender honors.
+	snapMaxBackoff = 30 * time.Second
+	// snapRecvThrottle is the pause between chunks while under pressure, which
+	// slows the sender down through gRPC flow control.
+	snapRecvThrottle = 10 * time.Millisecond
+)
+
+// snapQueueSize is the number of snapshot tasks of each direction which may
+// wait for a free stream before the snap worker blocks.
+const snapQueueSize = 256
+
+// snapRunner sends and receives snapshots. At most config.SnapMaxConcurrentSend
+// snapshots are sent and config.SnapMaxConcurrentRecv received at the same
+// time, the other tasks wait in FIFO queues.
 type snapRunner struct {
 	config      *config.Config
 	snapManager *snap.SnapManager
 	router      message.RaftRouter
+	sendQueue   chan *sendSnapTask
+	recvQueue   chan *recvSnapTask
+	wg          sync.WaitGroup
+
+	mu sync.Mutex
+	// pauseUntil records, by address, until when the receiver asked not to
+	// be sent more snapshot data.
+	pauseUntil map[string]time.Time
 }
 
 func newSnapRunner(snapManager *snap.SnapManager, config *config.Config, router message.RaftRouter) *snapRunner {
@@ -40,15 +75,97 @@ func newSnapRunner(snapManager *snap.SnapManager, config *config.Config, router
 		config:      config,
 		snapManager: snapManager,
 		router:      router,
+		sendQueue:   make(chan *sendSnapTask, snapQueueSize),
+		recvQueue:   make(chan *recvSnapTask, snapQueueSize),
+		pauseUntil:  make(map[string]time.Time),
 	}
 }
 
+func (r *snapRunner) Start() {
+	for i := 0; i < r.config.SnapMaxConcurrentSend; i++ {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			for t := range r.sendQueue {
+				r.send(t)
+			}
+		}()
+	}
+	for i := 0; i < r.config.SnapMaxConcurrentRecv; i++ {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			for t := range r.recvQueue {
+				r.recv(t)
+			}
+		}()
+	}
+}
+
+// Stop waits for the snapshots in flight and the queued ones to finish.
+func (r *snapRunner) Stop() {
+	close(r.sendQueue)
+	close(r.recvQueue)
+	r.wg.Wait()
+}
+
+// waitBackoff blocks until the backoff requested by the receiver at addr,
+// if any, has passed.
+func (r *snapRunner) waitBackoff(addr string) {
+	r.mu.Lock()
+	until := r.pauseUntil[addr]
+	r.mu.Unlock()
+	if d := time.Until(until); d > 0 {
+		log.Infof("snapshot receiver %v is busy, pause sending for %v", addr, d)
+		time.Sleep(d)
+	}
+}
+
+// recordBackoff honors the backoff hint the receiver at addr left in trailer.
+func (r *snapRunner) recordBackoff(addr string, trailer metadata.MD) {
+	vals := trailer.Get(snapBackoffKey)
+	if len(vals) == 0 {
+		return
+	}
+	ms, err := strconv.ParseInt(vals[0], 10, 64)
+	if err != nil || ms <= 0 {
+		return
+	}
+	backoff := time.Duration(ms) * time.Millisecond
+	if backoff > snapMaxBackoff {
+		backoff = snapMaxBackoff
+	}
+	r.mu.Lock()
+	r.pauseUntil[addr] = time.Now().Add(backoff)
+	r.mu.Unlock()
+}
+
+// receivePressure returns how long senders should back off given the
+// snapshots this store is receiving and applying, zero if it isn't busy.
+func (r *snapRunner) receivePressure() time.Duration {
+	var backoff time.Duration
+	if n := r.snapManager.Stats().ReceivingCount; n > snapBusyReceivingCount {
+		backoff += time.Duration(n-snapBusyReceivingCount) * snapBackoffUnit
+	}
+	if r.snapManager.GetTotalSnapSize() > r.snapManager.MaxTotalSize {
+		backoff += snapBusyReceivingCount * snapBackoffUnit
+	}
+	return backoff
+}
+
+func setBackoffTrailer(stream tinykvpb.TinyKv_SnapshotServer, backoff time.Duration) {
+	if backoff <= 0 {
+		return
+	}
+	stream.SetTrailer(metadata.Pairs(snapBackoffKey, strconv.FormatInt(int64(backoff/time.Millisecond), 10)))
+}
+
 func (r *snapRunner) Handle(t worker.Task) {
 	switch t.(type) {
 	case *sendSnapTask:
-		r.send(t.(*sendSnapTask))
+		r.sendQueue <- t.(*sendSnapTask)
 	case *recvSnapTask:
-		r.recv(t.(*recvSnapTask))
+		r.recvQueue <- t.(*recvSnapTask)
 	}
 }
 
@@ -77,6 +194,7 @@ func (r *snapRunner) sendSnap(addr string, msg *raft_serverpb.RaftMessage) error
 		return errors.Errorf("missing snap file: %v", snap.Path())
 	}
 
+	r.waitBackoff(addr)
 	cc, err := grpc.Dial(addr, grpc.WithInsecure(),
 		grpc.WithInitialWindowSize(2*1024*1024),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
@@ -93,10 +211,12 @@ func (r *snapRunner) sendSnap(addr string, msg *raft_serverpb.RaftMessage) error
 	}
 	err = stream.Send(&raft_serverpb.SnapshotChunk{Message: msg})
 	if err != nil {
+		r.recordBackoff(addr, stream.Trailer())
 		return err
 	}
 
 	buf := make([]byte, snapChunkLen)
+	var offset uint64
 	for remain := snap.TotalSize(); remain > 0; remain -= uint64(len(buf)) {
 		if remain < uint64(len(buf)) {
 			buf = buf[:remain]
@@ -105,12 +225,20 @@ func (r *snapRunner) sendSnap(addr string, msg *raft_serverpb.RaftMessage) error
 		if err != nil {
 			return errors.Errorf("failed to read snapshot chunk: %v", err)
 		}
-		err = stream.Send(&raft_serverpb.SnapshotChunk{Data: buf})
+		err = stream.Send(&raft_serverpb.SnapshotChunk{Data: buf, Offset: offset})
 		if err != nil {
+			r.recordBackoff(addr, stream.Trailer())
 			return err
 		}
+		offset += uint64(len(buf))
+	}
+	err = stream.Send(&raft_serverpb.SnapshotChunk{Offset: offset, Commit: true})
+	if err != nil {
+		r.recordBackoff(addr, stream.Trailer())
+		return err
 	}
 	_, err = stream.CloseAndRecv()
+	r.recordBackoff(addr, stream.Trailer())
 	if err != nil {
 		return err
 	}
@@ -154,7 +282,12 @@ func (r *snapRunner) recvSnap(stream tinykvpb.TinyKv_SnapshotServer) (*raft_serv
 	r.snapManager.Register(snapKey, snap.SnapEntryReceiving)
 	defer r.snapManager.Deregister(snapKey, snap.SnapEntryReceiving)
 
+	var received uint64
+	committed := false
 	for {
+		if r.receivePressure() > 0 {
+			time.Sleep(snapRecvThrottle)
+		}
 		chunk, err := stream.Recv()
 		if err != nil {
 			if err == io.EOF {
@@ -162,6 +295,19 @@ func (r *snapRunner) recvSnap(stream tinykvpb.TinyKv_SnapshotServer) (*raft_serv
 			}
 			return nil, err
 		}
+		if committed {
+			return nil, errors.Errorf("%v receive chunk after commit", snapKey)
+		}
+		if chunk.GetOffset() != received {
+			return nil, errors.Errorf("%v receive chunk at offset %v, expect %v", snapKey, chunk.GetOffset(), received)
+		}
+		if chunk.GetCommit() {
+			if received != snapshot.TotalSize() {
+				return nil, errors.Errorf("%v commit after %v bytes, expect %v", snapKey, received, snapshot.TotalSize())
+			}
+			committed = true
+			continue
+		}
 		data := chunk.GetData()
 		if len(data) == 0 {
 			return nil, errors.Errorf("%v receive chunk with empty data", snapKey)
@@ -170,6 +316,11 @@ func (r *snapRunner) recvSnap(stream tinykvpb.TinyKv_SnapshotServer) (*raft_serv
 		if err != nil {
 			return nil, errors.Errorf("%v failed to write snapshot file %v: %v", snapKey, snapshot.Path(), err)
 		}
+		received += uint64(len(data))
+	}
+	if !committed {
+		// The sender gave up half way, don't keep a partial snapshot.
+		return nil, errors.Errorf("%v stream closed before commit, received %v bytes", snapKey, received)
 	}
 
 	err = snapshot.Save()
@@ -177,6 +328,7 @@ func (r *snapRunner) recvSnap(stream tinykvpb.TinyKv_SnapshotServer) (*raft_serv
 		return nil, err
 	}
 
+	setBackoffTrailer(stream, r.receivePressure())
 	stream.SendAndClose(&raft_serverpb.Done{})
 	return head.GetMessage(), nil
 }
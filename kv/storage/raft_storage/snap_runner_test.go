@@ -0,0 +1,106 @@
+package raft_storage
+
+import (
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore/snap"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type chunkStream struct {
+	grpc.ServerStream
+	chunks []*raft_serverpb.SnapshotChunk
+}
+
+func (s *chunkStream) Recv() (*raft_serverpb.SnapshotChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := s.chunks[0]
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *chunkStream) SendAndClose(*raft_serverpb.Done) error { return nil }
+
+func (s *chunkStream) SetTrailer(metadata.MD) {}
+
+// snapHead returns the first chunk of a snapshot whose default CF holds data.
+func snapHead(t *testing.T, index uint64, data []byte) *raft_serverpb.SnapshotChunk {
+	meta := new(raft_serverpb.SnapshotMeta)
+	for _, cf := range engine_util.CFs {
+		cfFile := &raft_serverpb.SnapshotCFFile{Cf: cf}
+		if cf == engine_util.CfDefault {
+			cfFile.Size_ = uint64(len(data))
+			cfFile.Checksum = crc32.ChecksumIEEE(data)
+		}
+		meta.CfFiles = append(meta.CfFiles, cfFile)
+	}
+	snapData, err := (&raft_serverpb.RaftSnapshotData{Region: &metapb.Region{Id: 1}, Meta: meta}).Marshal()
+	require.Nil(t, err)
+	return &raft_serverpb.SnapshotChunk{Message: &raft_serverpb.RaftMessage{
+		RegionId: 1,
+		Message: &eraftpb.Message{
+			MsgType: eraftpb.MessageType_MsgSnapshot,
+			Snapshot: &eraftpb.Snapshot{
+				Data:     snapData,
+				Metadata: &eraftpb.SnapshotMetadata{Index: index, Term: 1},
+			},
+		},
+	}}
+}
+
+func TestRecvSnapChunks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snap_runner")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	r := newSnapRunner(snap.NewSnapManager(dir), config.NewTestConfig(), new(recordRouter))
+
+	data := []byte("0123456789")
+	// Committed after all the data in order.
+	stream := &chunkStream{chunks: []*raft_serverpb.SnapshotChunk{
+		snapHead(t, 10, data),
+		{Data: data[:4], Offset: 0},
+		{Data: data[4:], Offset: 4},
+		{Offset: 10, Commit: true},
+	}}
+	msg, err := r.recvSnap(stream)
+	require.Nil(t, err)
+	require.Equal(t, uint64(10), msg.GetMessage().GetSnapshot().GetMetadata().GetIndex())
+
+	// Chunks out of order.
+	stream = &chunkStream{chunks: []*raft_serverpb.SnapshotChunk{
+		snapHead(t, 11, data),
+		{Data: data[4:], Offset: 4},
+	}}
+	_, err = r.recvSnap(stream)
+	require.NotNil(t, err)
+
+	// The sender stopped before the commit.
+	stream = &chunkStream{chunks: []*raft_serverpb.SnapshotChunk{
+		snapHead(t, 12, data),
+		{Data: data, Offset: 0},
+	}}
+	_, err = r.recvSnap(stream)
+	require.NotNil(t, err)
+
+	// Committed before all the data.
+	stream = &chunkStream{chunks: []*raft_serverpb.SnapshotChunk{
+		snapHead(t, 13, data),
+		{Data: data[:4], Offset: 0},
+		{Offset: 4, Commit: true},
+	}}
+	_, err = r.recvSnap(stream)
+	require.NotNil(t, err)
+}
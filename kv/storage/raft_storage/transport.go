@@ -2,11 +2,13 @@ package raft_storage
 
 import (
 	"sync"
+	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/message"
 	"github.com/pingcap-incubator/tinykv/kv/util/worker"
 	"github.com/pingcap-incubator/tinykv/log"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/raft_serverpb"
+	"github.com/pingcap-incubator/tinykv/raft"
 )
 
 type ServerTransport struct {
@@ -15,6 +17,7 @@ type ServerTransport struct {
 	resolverScheduler chan<- worker.Task
 	snapScheduler     chan<- worker.Task
 	resolving         sync.Map
+	filters           messageFilters
 }
 
 func NewServerTransport(raftClient *RaftClient, snapScheduler chan<- worker.Task, raftRouter message.RaftRouter, resolverScheduler chan<- worker.Task) *ServerTransport {
@@ -26,8 +29,35 @@ func NewServerTransport(raftClient *RaftClient, snapScheduler chan<- worker.Task
 	}
 }
 
+// AddFilter registers filter under name, every message sent afterwards goes
+// through it. A filter registered under the same name is replaced.
+func (t *ServerTransport) AddFilter(name string, filter MessageFilter) {
+	t.filters.add(name, filter)
+}
+
+// RemoveFilter unregisters the filter registered under name.
+func (t *ServerTransport) RemoveFilter(name string) {
+	t.filters.remove(name)
+}
+
+// ClearFilters unregisters all the filters.
+func (t *ServerTransport) ClearFilters() {
+	t.filters.clear()
+}
+
 func (t *ServerTransport) Send(msg *raft_serverpb.RaftMessage) error {
+	msg, delay := t.filters.apply(msg)
+	if msg == nil {
+		return nil
+	}
 	storeID := msg.GetToPeer().GetStoreId()
+	if delay > 0 {
+		time.AfterFunc(delay, func() {
+			t.SendStore(storeID, msg)
+			t.Flush()
+		})
+		return nil
+	}
 	t.SendStore(storeID, msg)
 	return nil
 }
@@ -53,6 +83,7 @@ func (t *ServerTransport) Resolve(storeID uint64, msg *raft_serverpb.RaftMessage
 		t.resolving.Delete(storeID)
 		if err != nil {
 			log.Errorf("resolve store address failed. storeID: %v, err: %v", storeID, err)
+			t.reportUnreachable(msg)
 			return
 		}
 		t.raftClient.InsertAddr(storeID, addr)
@@ -72,15 +103,30 @@ func (t *ServerTransport) WriteData(storeID uint64, addr string, msg *raft_serve
 	}
 	if err := t.raftClient.Send(storeID, addr, msg); err != nil {
 		log.Errorf("send raft msg err. err: %v", err)
+		t.reportUnreachable(msg)
 	}
 }
 
+// reportUnreachable tells the sender peer of msg that the receiver can't be
+// reached, so it stops streaming appends to it.
+func (t *ServerTransport) reportUnreachable(msg *raft_serverpb.RaftMessage) {
+	regionID := msg.GetRegionId()
+	_ = t.raftRouter.Send(regionID, message.NewPeerMsg(message.MsgTypeUnreachable, regionID,
+		&message.MsgUnreachable{ToPeerID: msg.GetToPeer().GetId()}))
+}
+
 func (t *ServerTransport) SendSnapshotSock(addr string, msg *raft_serverpb.RaftMessage) {
 	callback := func(err error) {
 		regionID := msg.GetRegionId()
 		toPeerID := msg.GetToPeer().GetId()
 		toStoreID := msg.GetToPeer().GetStoreId()
 		log.Debugf("send snapshot. toPeerID: %v, toStoreID: %v, regionID: %v, status: %v", toPeerID, toStoreID, regionID, err)
+		status := raft.SnapshotFinish
+		if err != nil {
+			status = raft.SnapshotFailure
+		}
+		_ = t.raftRouter.Send(regionID, message.NewPeerMsg(message.MsgTypeSnapshotStatus, regionID,
+			&message.MsgSnapshotStatus{ToPeerID: toPeerID, Status: status}))
 	}
 
 	t.snapScheduler <- &sendSnapTask{
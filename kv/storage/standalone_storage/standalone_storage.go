@@ -1,27 +1,75 @@
 package standalone_storage
 
 import (
+	"fmt"
+
 	"github.com/Connor1996/badger"
 	"github.com/pingcap-incubator/tinykv/kv/config"
+	"github.com/pingcap-incubator/tinykv/kv/raftstore"
 	"github.com/pingcap-incubator/tinykv/kv/storage"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+)
+
+// A standalone instance presents itself to clients as a single region covering the whole key space, with
+// one peer on one store, shaped like the first region of a freshly bootstrapped cluster.
+const (
+	standaloneStoreID  uint64 = 1
+	standaloneRegionID uint64 = 1
+	standalonePeerID   uint64 = 1
 )
 
 // StandAloneStorage is an implementation of `Storage` for a single-node TinyKV instance. It does not
 // communicate with other nodes and all data is stored locally.
 type StandAloneStorage struct {
 	// Your Data Here (1).
-	db *badger.DB
+	db     *badger.DB
+	region *metapb.Region
 }
 
 func NewStandAloneStorage(conf *config.Config) *StandAloneStorage {
 	// Your Code Here (1).
 	return &StandAloneStorage{
 		db: engine_util.CreateDB(conf.DBPath, conf.Raft),
+		region: &metapb.Region{
+			Id: standaloneRegionID,
+			RegionEpoch: &metapb.RegionEpoch{
+				Version: raftstore.InitEpochVer,
+				ConfVer: raftstore.InitEpochConfVer,
+			},
+			Peers: []*metapb.Peer{{Id: standalonePeerID, StoreId: standaloneStoreID}},
+		},
 	}
 }
 
+// checkContext validates the region fields of a request context against the single region served by this
+// instance, so clients written against RaftStorage get the same region errors back. A nil context, or one
+// without a region id, is accepted as is.
+func (s *StandAloneStorage) checkContext(ctx *kvrpcpb.Context) error {
+	if ctx == nil || ctx.RegionId == 0 {
+		return nil
+	}
+	var err error
+	if ctx.RegionId != s.region.Id {
+		err = &kverrors.ErrRegionNotFound{RegionId: ctx.RegionId}
+	} else if ctx.Peer != nil && ctx.Peer.StoreId != standaloneStoreID {
+		err = &kverrors.ErrStoreNotMatch{RequestStoreId: ctx.Peer.StoreId, ActualStoreId: standaloneStoreID}
+	} else if epoch := ctx.RegionEpoch; epoch != nil && (epoch.Version != s.region.RegionEpoch.Version ||
+		epoch.ConfVer != s.region.RegionEpoch.ConfVer) {
+		err = &kverrors.ErrEpochNotMatch{
+			Message: fmt.Sprintf("current epoch of region %v is %v, but you sent %v",
+				s.region.Id, s.region.RegionEpoch, epoch),
+			Regions: []*metapb.Region{s.region},
+		}
+	}
+	if err != nil {
+		return &kverrors.RegionError{RequestErr: kverrors.ToRegionError(err)}
+	}
+	return nil
+}
+
 func (s *StandAloneStorage) Start() error {
 	// Your Code Here (1).
 	return nil
@@ -34,6 +82,9 @@ func (s *StandAloneStorage) Stop() error {
 
 func (s *StandAloneStorage) Reader(ctx *kvrpcpb.Context) (storage.StorageReader, error) {
 	// Your Code Here (1).
+	if err := s.checkContext(ctx); err != nil {
+		return nil, err
+	}
 	return &badgerReader{
 		txn: s.db.NewTransaction(false),
 	}, nil
@@ -41,6 +92,14 @@ func (s *StandAloneStorage) Reader(ctx *kvrpcpb.Context) (storage.StorageReader,
 
 func (s *StandAloneStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify) error {
 	// Your Code Here (1).
+	if err := s.checkContext(ctx); err != nil {
+		return err
+	}
+	if err := storage.ValidateBatch(batch); err != nil {
+		return err
+	}
+	// All CFs live in the same badger DB, so one write batch commits the whole
+	// batch atomically.
 	wb := &engine_util.WriteBatch{}
 	for _, modify := range batch {
 		switch modify.Data.(type) {
@@ -53,6 +112,11 @@ func (s *StandAloneStorage) Write(ctx *kvrpcpb.Context, batch []storage.Modify)
 	return wb.WriteToDB(s.db)
 }
 
+func (s *StandAloneStorage) DestroyRanges(ranges []engine_util.CFRange) error {
+	_, err := engine_util.DeleteCFRanges(s.db, ranges)
+	return err
+}
+
 type badgerReader struct {
 	txn *badger.Txn
 }
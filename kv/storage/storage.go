@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"bytes"
+
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
 )
@@ -10,6 +12,9 @@ import (
 type Storage interface {
 	Start() error
 	Stop() error
+	// Write applies batch atomically: either every modification is visible,
+	// whichever CFs they touch, or none is. The MVCC layer relies on this to
+	// write a lock together with its value in the default CF.
 	Write(ctx *kvrpcpb.Context, batch []Modify) error
 	Reader(ctx *kvrpcpb.Context) (StorageReader, error)
 }
@@ -20,3 +25,39 @@ type StorageReader interface {
 	IterCF(cf string) engine_util.DBIterator
 	Close()
 }
+
+// RangeDestroyer is implemented by storages which can delete whole key ranges
+// straight from their engine. The deletion bypasses replication, so it has to
+// be done on every store, and only once nothing may read the ranges anymore.
+type RangeDestroyer interface {
+	DestroyRanges(ranges []engine_util.CFRange) error
+}
+
+// TimeDetailReader is implemented by the readers which record how long the
+// storage took to provide them, for the TimeDetail of read responses.
+type TimeDetailReader interface {
+	TimeDetail() kvrpcpb.TimeDetail
+}
+
+// RangeReader is implemented by the readers which only see a key range, like
+// those of a region. Their iterators panic when seeking outside of it.
+type RangeReader interface {
+	// KeyRange returns the range the reader sees, an empty endKey is
+	// unbounded.
+	KeyRange() (startKey, endKey []byte)
+}
+
+// ClampRange returns the part of [startKey, endKey) which reader sees, see
+// RangeReader, and false if there is none. An empty endKey is unbounded.
+func ClampRange(reader StorageReader, startKey, endKey []byte) ([]byte, []byte, bool) {
+	if r, ok := reader.(RangeReader); ok {
+		start, end := r.KeyRange()
+		if bytes.Compare(startKey, start) < 0 {
+			startKey = start
+		}
+		if len(end) > 0 && (len(endKey) == 0 || bytes.Compare(end, endKey) < 0) {
+			endKey = end
+		}
+	}
+	return startKey, endKey, len(endKey) == 0 || bytes.Compare(startKey, endKey) < 0
+}
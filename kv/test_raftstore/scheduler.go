@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"sync"
+	"time"
 
 	"github.com/google/btree"
 	"github.com/pingcap-incubator/tinykv/kv/raftstore/util"
@@ -12,6 +13,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/schedulerpb"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/tsoutil"
 	"github.com/pingcap/errors"
 )
 
@@ -82,6 +84,10 @@ type MockSchedulerClient struct {
 	regionsKey   map[uint64][]byte // regionID -> startKey
 
 	baseID uint64
+	// lastTS is the last timestamp returned by GetTS.
+	lastTS uint64
+	// serviceSafePoints are the service GC safe points by service ID.
+	serviceSafePoints map[string]serviceSafePoint
 
 	operators    map[uint64]*Operator
 	leaders      map[uint64]*metapb.Peer // regionID -> peer
@@ -103,9 +109,16 @@ func NewMockSchedulerClient(clusterID uint64, baseID uint64) *MockSchedulerClien
 		operators:    make(map[uint64]*Operator),
 		leaders:      make(map[uint64]*metapb.Peer),
 		pendingPeers: make(map[uint64]*metapb.Peer),
+
+		serviceSafePoints: make(map[string]serviceSafePoint),
 	}
 }
 
+type serviceSafePoint struct {
+	safePoint uint64
+	expiredAt time.Time
+}
+
 // Implement SchedulerClient interface
 func (m *MockSchedulerClient) GetClusterID(ctx context.Context) uint64 {
 	m.RLock()
@@ -121,6 +134,39 @@ func (m *MockSchedulerClient) AllocID(ctx context.Context) (uint64, error) {
 	return ret, nil
 }
 
+func (m *MockSchedulerClient) GetTS(ctx context.Context) (uint64, error) {
+	m.Lock()
+	defer m.Unlock()
+	ts := uint64(time.Now().UnixNano()/int64(time.Millisecond)) << tsoutil.PhysicalShiftBits
+	if ts <= m.lastTS {
+		ts = m.lastTS + 1
+	}
+	m.lastTS = ts
+	return ts, nil
+}
+
+func (m *MockSchedulerClient) UpdateServiceGCSafePoint(ctx context.Context, serviceID string, ttl int64, safePoint uint64) (uint64, error) {
+	m.Lock()
+	defer m.Unlock()
+	now := time.Now()
+	if ttl <= 0 {
+		delete(m.serviceSafePoints, serviceID)
+	} else {
+		m.serviceSafePoints[serviceID] = serviceSafePoint{safePoint: safePoint, expiredAt: now.Add(time.Duration(ttl) * time.Second)}
+	}
+	var min uint64
+	for id, ssp := range m.serviceSafePoints {
+		if ssp.expiredAt.Before(now) {
+			delete(m.serviceSafePoints, id)
+			continue
+		}
+		if min == 0 || ssp.safePoint < min {
+			min = ssp.safePoint
+		}
+	}
+	return min, nil
+}
+
 func (m *MockSchedulerClient) Bootstrap(ctx context.Context, store *metapb.Store) (*schedulerpb.BootstrapResponse, error) {
 	m.Lock()
 	defer m.Unlock()
@@ -433,6 +433,29 @@ func TestPersistPartitionUnreliable2B(t *testing.T) {
 	GenericTest(t, "2B", 5, true, true, true, -1, false, false)
 }
 
+func TestProposalTooLarge2B(t *testing.T) {
+	cfg := config.NewTestConfig()
+	cfg.RaftMaxEntrySize = 1024
+	cluster := NewTestCluster(3, cfg)
+	cluster.Start()
+	defer cluster.Shutdown()
+
+	cluster.MustPut([]byte("k1"), []byte("v1"))
+
+	key := []byte("k2")
+	region := cluster.GetRegion(key)
+	req := NewRequest(region.GetId(), region.RegionEpoch, []*raft_cmdpb.Request{
+		NewPutCfCmd(engine_util.CfDefault, key, make([]byte, 2048)),
+	})
+	resp, _ := cluster.CallCommandOnLeader(&req, 5*time.Second)
+	if resp == nil {
+		t.Fatal("can't call command on leader")
+	}
+	assert.Contains(t, resp.Header.GetError().GetMessage(), "larger than the raft entry limit")
+	cluster.MustGet([]byte("k1"), []byte("v1"))
+	MustGetNone(cluster.engines[1], key)
+}
+
 func TestOneSnapshot2C(t *testing.T) {
 	cfg := config.NewTestConfig()
 	cfg.RaftLogGcCountLimit = 10
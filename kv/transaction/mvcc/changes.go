@@ -0,0 +1,100 @@
+package mvcc
+
+import (
+	"bytes"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+)
+
+// Change is a write committed to a key.
+type Change struct {
+	Key      []byte
+	StartTs  uint64
+	CommitTs uint64
+	// Kind is WriteKindPut or WriteKindDelete.
+	Kind WriteKind
+	// Value is the value put, nil for a delete.
+	Value []byte
+}
+
+// ChangeScanner reads the writes committed in a time range, e.g. to export
+// the changes made since the last export. The versions of a key are encoded
+// after each other by commit ts, so the versions out of the range are skipped
+// with a seek instead of being read one by one. Changes are returned by key,
+// then from the most to the least recent.
+type ChangeScanner struct {
+	reader           storage.StorageReader
+	iter             engine_util.DBIterator
+	endKey           []byte
+	sinceTs, untilTs uint64
+}
+
+// NewChangeScanner creates a scanner of the writes to the keys in [startKey,
+// endKey) committed in (sinceTs, untilTs]. An empty endKey is unbounded.
+func NewChangeScanner(reader storage.StorageReader, startKey, endKey []byte, sinceTs, untilTs uint64) *ChangeScanner {
+	iter := reader.IterCF(engine_util.CfWrite)
+	iter.Seek(EncodeKey(startKey, untilTs))
+	return &ChangeScanner{
+		reader:  reader,
+		iter:    iter,
+		endKey:  endKey,
+		sinceTs: sinceTs,
+		untilTs: untilTs,
+	}
+}
+
+func (scan *ChangeScanner) Close() {
+	scan.iter.Close()
+}
+
+// Next returns the next change, or nil once the scanner is exhausted.
+func (scan *ChangeScanner) Next() (*Change, error) {
+	for scan.iter.Valid() {
+		item := scan.iter.Item()
+		key := item.KeyCopy(nil)
+		userKey := DecodeUserKey(key)
+		if len(scan.endKey) > 0 && bytes.Compare(userKey, scan.endKey) >= 0 {
+			return nil, nil
+		}
+		commitTs := decodeTimestamp(key)
+		if commitTs > scan.untilTs {
+			scan.iter.Seek(EncodeKey(userKey, scan.untilTs))
+			continue
+		}
+		if commitTs <= scan.sinceTs {
+			scan.skipKey(userKey)
+			continue
+		}
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return nil, err
+		}
+		scan.iter.Next()
+		write, err := ParseWrite(value)
+		if err != nil {
+			return nil, err
+		}
+		if write.Kind == WriteKindRollback {
+			continue
+		}
+		change := &Change{Key: userKey, StartTs: write.StartTS, CommitTs: commitTs, Kind: write.Kind}
+		if write.Kind == WriteKindPut {
+			change.Value, err = scan.reader.GetCF(engine_util.CfDefault, EncodeKey(userKey, write.StartTS))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return change, nil
+	}
+	return nil, nil
+}
+
+// skipKey moves the iterator past the remaining versions of userKey.
+func (scan *ChangeScanner) skipKey(userKey []byte) {
+	// The version at ts 0 is the last one of the key.
+	scan.iter.Seek(EncodeKey(userKey, 0))
+	if scan.iter.Valid() && bytes.Equal(DecodeUserKey(scan.iter.Item().Key()), userKey) {
+		scan.iter.Next()
+	}
+}
@@ -0,0 +1,32 @@
+package mvcc
+
+import (
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+)
+
+// DestroyRange deletes all versions of the keys in [startKey, endKey) from
+// the engine of s, an empty endKey is unbounded. The write CF is cleared in the
+// same batch as the values and locks, so a reader never finds a committed
+// write whose value is gone.
+func DestroyRange(s storage.RangeDestroyer, startKey, endKey []byte) error {
+	return s.DestroyRanges(DestroyRangeBounds(startKey, endKey))
+}
+
+// DestroyRangeBounds returns the ranges of the engine keys holding the user
+// keys in [startKey, endKey) in each CF.
+func DestroyRangeBounds(startKey, endKey []byte) []engine_util.CFRange {
+	// The versions of a key are encoded after the encoded key, so the encoded
+	// bounds cover all of them.
+	encStart := codec.EncodeBytes(startKey)
+	var encEnd []byte
+	if len(endKey) > 0 {
+		encEnd = codec.EncodeBytes(endKey)
+	}
+	return []engine_util.CFRange{
+		{CF: engine_util.CfWrite, Start: encStart, End: encEnd},
+		{CF: engine_util.CfDefault, Start: encStart, End: encEnd},
+		{CF: engine_util.CfLock, Start: startKey, End: endKey},
+	}
+}
@@ -0,0 +1,64 @@
+package mvcc
+
+import (
+	"bytes"
+
+	"github.com/pingcap-incubator/tinykv/kv/storage"
+	"github.com/pingcap-incubator/tinykv/kv/util/codec"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+)
+
+// GC returns the modifications removing the versions of the keys in
+// [startKey, endKey) which no read at or after safePoint can see: of the
+// writes committed at or before safePoint, all but the newest put, with their
+// values. An empty endKey is unbounded. The write of a version is deleted
+// before its value, so a batch split between them never leaves a write
+// without its value. If reader only sees a key range, see storage.RangeReader,
+// only the keys in it are collected.
+func GC(reader storage.StorageReader, startKey, endKey []byte, safePoint uint64) ([]storage.Modify, error) {
+	var end []byte
+	if len(endKey) > 0 {
+		end = codec.EncodeBytes(endKey)
+	}
+	start, end, ok := storage.ClampRange(reader, codec.EncodeBytes(startKey), end)
+	if !ok {
+		return nil, nil
+	}
+	it := reader.IterCF(engine_util.CfWrite)
+	defer it.Close()
+
+	var modifies []storage.Modify
+	var userKey []byte
+	// covered is set once the newest put or delete at or before safePoint
+	// of userKey is found, every older write is hidden by it.
+	covered := false
+	for it.Seek(start); it.Valid() && !engine_util.ExceedEndKey(it.Item().Key(), end); it.Next() {
+		item := it.Item()
+		key := DecodeUserKey(item.Key())
+		if !bytes.Equal(key, userKey) {
+			userKey, covered = key, false
+		}
+		if decodeTimestamp(item.Key()) > safePoint {
+			continue
+		}
+		value, err := item.Value()
+		if err != nil {
+			return nil, err
+		}
+		write, err := ParseWrite(value)
+		if err != nil {
+			return nil, err
+		}
+		if !covered && write.Kind != WriteKindRollback {
+			covered = true
+			if write.Kind == WriteKindPut {
+				continue
+			}
+		}
+		modifies = append(modifies, storage.Modify{Data: storage.Delete{Key: item.KeyCopy(nil), Cf: engine_util.CfWrite}})
+		if write.Kind == WriteKindPut {
+			modifies = append(modifies, storage.Modify{Data: storage.Delete{Key: EncodeKey(userKey, write.StartTS), Cf: engine_util.CfDefault}})
+		}
+	}
+	return modifies, nil
+}
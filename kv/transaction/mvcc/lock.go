@@ -5,7 +5,9 @@ import (
 	"encoding/binary"
 	"fmt"
 	"reflect"
+	"time"
 
+	"github.com/pingcap-incubator/tinykv/kv/util/clock"
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
 )
@@ -57,6 +59,15 @@ func ParseLock(input []byte) (*Lock, error) {
 	return &Lock{Primary: primary, Ts: ts, Ttl: ttl, Kind: kind}, nil
 }
 
+// IsExpired returns true if the TTL of lock, in milliseconds, has certainly
+// passed at currentTs, allowing for maxDrift between the clocks of the nodes
+// which allocated the two timestamps.
+func (lock *Lock) IsExpired(currentTs uint64, maxDrift time.Duration) bool {
+	start := time.Unix(0, int64(PhysicalTime(lock.Ts))*int64(time.Millisecond))
+	now := time.Unix(0, int64(PhysicalTime(currentTs))*int64(time.Millisecond))
+	return clock.TTLExpired(start, now, time.Duration(lock.Ttl)*time.Millisecond, maxDrift)
+}
+
 // IsLockedFor checks if lock locks key at txnStartTs.
 func (lock *Lock) IsLockedFor(key []byte, txnStartTs uint64, resp interface{}) bool {
 	if lock == nil {
@@ -1,22 +1,14 @@
 package mvcc
 
 import (
-	"encoding/binary"
-
 	"github.com/pingcap-incubator/tinykv/kv/storage"
 	"github.com/pingcap-incubator/tinykv/kv/util/codec"
-	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/kv/util/kverrors"
 	"github.com/pingcap-incubator/tinykv/scheduler/pkg/tsoutil"
 )
 
 // KeyError is a wrapper type so we can implement the `error` interface.
-type KeyError struct {
-	kvrpcpb.KeyError
-}
-
-func (ke *KeyError) Error() string {
-	return ke.String()
-}
+type KeyError = kverrors.KeyError
 
 // MvccTxn groups together writes as part of a single transaction. It also provides an abstraction over low-level
 // storage, lowering the concepts of timestamps, writes, and locks into plain keys and values.
@@ -95,10 +87,7 @@ func (txn *MvccTxn) MostRecentWrite(key []byte) (*Write, uint64, error) {
 // timestamped keys are sorted first by key (ascending), then by timestamp (descending). The encoding is based on
 // https://github.com/facebook/mysql-5.6/wiki/MyRocks-record-format#memcomparable-format.
 func EncodeKey(key []byte, ts uint64) []byte {
-	encodedKey := codec.EncodeBytes(key)
-	newKey := append(encodedKey, make([]byte, 8)...)
-	binary.BigEndian.PutUint64(newKey[len(encodedKey):], ^ts)
-	return newKey
+	return codec.EncodeMvccKey(key, ts)
 }
 
 // DecodeUserKey takes a key + timestamp and returns the key part.
@@ -112,11 +101,11 @@ func DecodeUserKey(key []byte) []byte {
 
 // decodeTimestamp takes a key + timestamp and returns the timestamp part.
 func decodeTimestamp(key []byte) uint64 {
-	left, _, err := codec.DecodeBytes(key)
+	_, ts, err := codec.DecodeMvccKey(key)
 	if err != nil {
 		panic(err)
 	}
-	return ^binary.BigEndian.Uint64(left)
+	return ts
 }
 
 // PhysicalTime returns the physical time part of the timestamp.
@@ -3,9 +3,11 @@ package mvcc
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/scheduler/pkg/tsoutil"
 
 	"github.com/pingcap-incubator/tinykv/kv/storage"
 	"github.com/stretchr/testify/assert"
@@ -31,6 +33,80 @@ func TestDecodeKey(t *testing.T) {
 	assert.Equal(t, []byte{42, 0, 5}, DecodeUserKey(EncodeKey([]byte{42, 0, 5}, 234234)))
 }
 
+func TestLockIsExpired(t *testing.T) {
+	ts := func(ms uint64) uint64 { return ms << tsoutil.PhysicalShiftBits }
+	lock := &Lock{Ts: ts(1000), Ttl: 100}
+	assert.False(t, lock.IsExpired(ts(1100), 0))
+	assert.True(t, lock.IsExpired(ts(1101), 0))
+	assert.False(t, lock.IsExpired(ts(1101), 10*time.Millisecond))
+	assert.True(t, lock.IsExpired(ts(1111), 10*time.Millisecond))
+}
+
+func TestDestroyRange(t *testing.T) {
+	mem := storage.NewMemStorage()
+	for _, key := range [][]byte{{1}, {2}, {2, 0}, {3}} {
+		mem.Set(engine_util.CfDefault, EncodeKey(key, 10), []byte{42})
+		mem.Set(engine_util.CfWrite, EncodeKey(key, 11), (&Write{StartTS: 10, Kind: WriteKindPut}).ToBytes())
+		mem.Set(engine_util.CfLock, key, (&Lock{Primary: key, Ts: 12, Kind: WriteKindPut}).ToBytes())
+	}
+
+	assert.Nil(t, DestroyRange(mem, []byte{2}, []byte{3}))
+	for _, cf := range engine_util.CFs {
+		assert.Equal(t, 2, mem.Len(cf))
+	}
+	assert.NotNil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{1}, 11)))
+	assert.NotNil(t, mem.Get(engine_util.CfLock, []byte{3}))
+
+	assert.Nil(t, DestroyRange(mem, []byte{}, nil))
+	for _, cf := range engine_util.CFs {
+		assert.Equal(t, 0, mem.Len(cf))
+	}
+}
+
+func TestGC(t *testing.T) {
+	mem := storage.NewMemStorage()
+	write := func(key []byte, startTs, commitTs uint64, kind WriteKind) {
+		if kind == WriteKindPut {
+			mem.Set(engine_util.CfDefault, EncodeKey(key, startTs), []byte{byte(startTs)})
+		}
+		mem.Set(engine_util.CfWrite, EncodeKey(key, commitTs), (&Write{StartTS: startTs, Kind: kind}).ToBytes())
+	}
+	// Key 1 is overwritten before and after the safe point, key 2 deleted
+	// before it, the rollback of key 3 hides no put, and key 4 is out of the
+	// range.
+	write([]byte{1}, 10, 11, WriteKindPut)
+	write([]byte{1}, 20, 21, WriteKindPut)
+	write([]byte{1}, 22, 22, WriteKindRollback)
+	write([]byte{1}, 30, 31, WriteKindPut)
+	write([]byte{2}, 10, 11, WriteKindPut)
+	write([]byte{2}, 20, 21, WriteKindDelete)
+	write([]byte{3}, 10, 11, WriteKindPut)
+	write([]byte{3}, 20, 20, WriteKindRollback)
+	write([]byte{4}, 10, 11, WriteKindPut)
+	write([]byte{4}, 20, 21, WriteKindPut)
+
+	reader, _ := mem.Reader(&kvrpcpb.Context{})
+	modifies, err := GC(reader, []byte{1}, []byte{4}, 25)
+	reader.Close()
+	assert.Nil(t, err)
+	assert.Nil(t, mem.Write(nil, modifies))
+
+	assert.Nil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{1}, 11)))
+	assert.Nil(t, mem.Get(engine_util.CfDefault, EncodeKey([]byte{1}, 10)))
+	assert.Nil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{1}, 22)))
+	assert.NotNil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{1}, 21)))
+	assert.NotNil(t, mem.Get(engine_util.CfDefault, EncodeKey([]byte{1}, 20)))
+	assert.NotNil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{1}, 31)))
+	assert.Nil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{2}, 21)))
+	assert.Nil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{2}, 11)))
+	assert.Nil(t, mem.Get(engine_util.CfDefault, EncodeKey([]byte{2}, 10)))
+	assert.Nil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{3}, 20)))
+	assert.NotNil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{3}, 11)))
+	assert.NotNil(t, mem.Get(engine_util.CfWrite, EncodeKey([]byte{4}, 11)))
+	assert.Equal(t, 5, mem.Len(engine_util.CfWrite))
+	assert.Equal(t, 5, mem.Len(engine_util.CfDefault))
+}
+
 func testTxn(startTs uint64, f func(m *storage.MemStorage)) *MvccTxn {
 	mem := storage.NewMemStorage()
 	if f != nil {
@@ -299,3 +375,48 @@ func TestMostRecentWrite4A(t *testing.T) {
 	}, *write)
 	assert.Equal(t, uint64(52), ts)
 }
+
+func TestChangeScanner(t *testing.T) {
+	mem := storage.NewMemStorage()
+	put := func(key []byte, startTs, commitTs uint64, kind WriteKind) {
+		if kind == WriteKindPut {
+			mem.Set(engine_util.CfDefault, EncodeKey(key, startTs), []byte{byte(commitTs)})
+		}
+		mem.Set(engine_util.CfWrite, EncodeKey(key, commitTs), (&Write{StartTS: startTs, Kind: kind}).ToBytes())
+	}
+	put([]byte{1}, 10, 11, WriteKindPut)
+	put([]byte{1}, 20, 21, WriteKindPut)
+	put([]byte{1}, 30, 31, WriteKindPut)
+	put([]byte{1}, 40, 41, WriteKindPut)
+	put([]byte{2}, 15, 15, WriteKindRollback)
+	put([]byte{2}, 22, 23, WriteKindDelete)
+	put([]byte{3}, 5, 6, WriteKindPut)
+	put([]byte{4}, 25, 26, WriteKindPut)
+	reader, _ := mem.Reader(nil)
+
+	scan := func(startKey, endKey []byte, sinceTs, untilTs uint64) []Change {
+		scanner := NewChangeScanner(reader, startKey, endKey, sinceTs, untilTs)
+		defer scanner.Close()
+		var changes []Change
+		for {
+			change, err := scanner.Next()
+			assert.Nil(t, err)
+			if change == nil {
+				return changes
+			}
+			changes = append(changes, *change)
+		}
+	}
+
+	assert.Equal(t, []Change{
+		{Key: []byte{1}, StartTs: 30, CommitTs: 31, Kind: WriteKindPut, Value: []byte{31}},
+		{Key: []byte{1}, StartTs: 20, CommitTs: 21, Kind: WriteKindPut, Value: []byte{21}},
+		{Key: []byte{2}, StartTs: 22, CommitTs: 23, Kind: WriteKindDelete},
+		{Key: []byte{4}, StartTs: 25, CommitTs: 26, Kind: WriteKindPut, Value: []byte{26}},
+	}, scan([]byte{}, nil, 11, 31))
+	assert.Equal(t, []Change{
+		{Key: []byte{2}, StartTs: 22, CommitTs: 23, Kind: WriteKindDelete},
+		{Key: []byte{3}, StartTs: 5, CommitTs: 6, Kind: WriteKindPut, Value: []byte{6}},
+	}, scan([]byte{2}, []byte{4}, 0, 40))
+	assert.Empty(t, scan([]byte{}, nil, 41, 100))
+}
@@ -0,0 +1,104 @@
+// Package clock provides the time source used for leader leases and lock TTLs.
+//
+// Code reasoning about leases must not compare wall-clock readings of
+// different nodes. Instead each node measures durations on its own monotonic
+// clock, and the rate at which two clocks may drift apart during a lease is
+// bounded by a configured maximum drift.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a monotonic time source. Only the difference of two readings of
+// the same Clock is meaningful.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+// Now returns time.Now, whose reading carries the monotonic clock.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Real is the Clock backed by the monotonic clock of the process.
+var Real Clock = realClock{}
+
+// Fake is a Clock which only moves when told to, for tests.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake creates a Fake clock reading start.
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Advance moves the clock forward by d.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Lease is a time bound during which a leader may serve reads without
+// talking to its followers.
+//
+// A lease is renewed with the time a heartbeat round was sent, not when its
+// quorum of responses came back, so the followers could not have started an
+// election before that point. The lease is then cut short by the maximum
+// drift, which bounds how much faster than ours a follower's clock may run
+// over one lease.
+type Lease struct {
+	clock    Clock
+	duration time.Duration
+	bound    time.Time
+}
+
+// NewLease creates an expired lease. maxLease is the longest a follower waits
+// before starting an election, typically the election timeout, and maxDrift
+// is the drift assumed between clocks over that period.
+func NewLease(clock Clock, maxLease, maxDrift time.Duration) *Lease {
+	duration := maxLease - maxDrift
+	if duration < 0 {
+		duration = 0
+	}
+	return &Lease{clock: clock, duration: duration}
+}
+
+// Renew extends the lease from sendTime, the reading of the lease's clock
+// taken when the heartbeats acknowledged by a quorum were sent. A lease is
+// never shortened by a renewal.
+func (l *Lease) Renew(sendTime time.Time) {
+	bound := sendTime.Add(l.duration)
+	if bound.After(l.bound) {
+		l.bound = bound
+	}
+}
+
+// Expire invalidates the lease, e.g. when the leader steps down.
+func (l *Lease) Expire() {
+	l.bound = time.Time{}
+}
+
+// Valid returns true if the lease still holds now.
+func (l *Lease) Valid() bool {
+	return l.clock.Now().Before(l.bound)
+}
+
+// TTLExpired returns true if a TTL of ttl started at start has certainly
+// passed at now, given start and now may come from clocks up to maxDrift
+// apart. It errs on the side of the owner of the TTL.
+func TTLExpired(start, now time.Time, ttl, maxDrift time.Duration) bool {
+	return now.Sub(start) > ttl+maxDrift
+}
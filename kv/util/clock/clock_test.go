@@ -0,0 +1,39 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLease(t *testing.T) {
+	c := NewFake(time.Unix(100, 0))
+	l := NewLease(c, 10*time.Second, time.Second)
+	assert.False(t, l.Valid())
+
+	sent := c.Now()
+	c.Advance(time.Second)
+	l.Renew(sent)
+	assert.True(t, l.Valid())
+
+	// The lease lasts maxLease - maxDrift from the send time.
+	c.Advance(7*time.Second + 999*time.Millisecond)
+	assert.True(t, l.Valid())
+	c.Advance(time.Millisecond)
+	assert.False(t, l.Valid())
+
+	// An older renewal doesn't shorten the lease.
+	l.Renew(c.Now())
+	l.Renew(sent)
+	assert.True(t, l.Valid())
+	l.Expire()
+	assert.False(t, l.Valid())
+}
+
+func TestTTLExpired(t *testing.T) {
+	start := time.Unix(100, 0)
+	assert.False(t, TTLExpired(start, start.Add(3*time.Second), 3*time.Second, 0))
+	assert.True(t, TTLExpired(start, start.Add(3*time.Second+1), 3*time.Second, 0))
+	assert.False(t, TTLExpired(start, start.Add(3*time.Second+1), 3*time.Second, time.Second))
+}
@@ -1,7 +1,9 @@
 package codec
 
 import (
+	"encoding/binary"
 	"fmt"
+
 	"github.com/pingcap/errors"
 )
 
@@ -82,3 +84,59 @@ func DecodeBytes(b []byte) ([]byte, []byte, error) {
 	}
 	return b, data, nil
 }
+
+// tsLen is the length of an encoded timestamp.
+const tsLen = 8
+
+// EncodeTs encodes a timestamp so that larger timestamps sort first.
+func EncodeTs(ts uint64) []byte {
+	b := make([]byte, tsLen)
+	binary.BigEndian.PutUint64(b, ^ts)
+	return b
+}
+
+// DecodeTs decodes a timestamp encoded by EncodeTs.
+func DecodeTs(b []byte) (uint64, error) {
+	if len(b) != tsLen {
+		return 0, errors.Errorf("invalid encoded timestamp length: %d", len(b))
+	}
+	return ^binary.BigEndian.Uint64(b), nil
+}
+
+// EncodeMvccKey encodes a user key and appends an encoded timestamp, so that
+// keys are sorted first by user key (ascending), then by timestamp (descending).
+func EncodeMvccKey(key []byte, ts uint64) []byte {
+	encodedKey := EncodeBytes(key)
+	newKey := append(encodedKey, make([]byte, tsLen)...)
+	binary.BigEndian.PutUint64(newKey[len(encodedKey):], ^ts)
+	return newKey
+}
+
+// DecodeMvccKey splits a key encoded by EncodeMvccKey into the user key and
+// the timestamp.
+func DecodeMvccKey(b []byte) ([]byte, uint64, error) {
+	left, userKey, err := DecodeBytes(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	ts, err := DecodeTs(left)
+	if err != nil {
+		return nil, 0, err
+	}
+	return userKey, ts, nil
+}
+
+// MvccKeyPrefix returns the prefix shared by all the versions of a user key.
+// Seeking to it positions an iterator on the newest version of the key.
+func MvccKeyPrefix(key []byte) []byte {
+	return EncodeBytes(key)
+}
+
+// TruncateTs strips the timestamp from a key encoded by EncodeMvccKey and
+// returns the MvccKeyPrefix of its user key without decoding it.
+func TruncateTs(b []byte) ([]byte, error) {
+	if len(b) < encGroupSize+1+tsLen {
+		return nil, errors.Errorf("insufficient bytes to truncate timestamp: %d", len(b))
+	}
+	return b[:len(b)-tsLen], nil
+}
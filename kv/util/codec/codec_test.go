@@ -0,0 +1,42 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMvccKeyRoundTrip(t *testing.T) {
+	for _, key := range [][]byte{{}, {42}, {42, 0, 5}, []byte("some longer user key")} {
+		encoded := EncodeMvccKey(key, 1234)
+		userKey, ts, err := DecodeMvccKey(encoded)
+		assert.Nil(t, err)
+		assert.Equal(t, key, userKey)
+		assert.Equal(t, uint64(1234), ts)
+
+		prefix, err := TruncateTs(encoded)
+		assert.Nil(t, err)
+		assert.Equal(t, MvccKeyPrefix(key), prefix)
+		assert.True(t, bytes.HasPrefix(encoded, prefix))
+	}
+}
+
+func TestMvccKeyOrder(t *testing.T) {
+	// Newer versions of the same key sort first.
+	assert.True(t, bytes.Compare(EncodeMvccKey([]byte{42}, 20), EncodeMvccKey([]byte{42}, 10)) < 0)
+	// User keys sort before timestamps.
+	assert.True(t, bytes.Compare(EncodeMvccKey([]byte{42}, 10), EncodeMvccKey([]byte{42, 0}, 20)) < 0)
+	assert.True(t, bytes.Compare(EncodeMvccKey([]byte{42}, 10), EncodeMvccKey([]byte{43}, 20)) < 0)
+}
+
+func TestDecodeTs(t *testing.T) {
+	ts, err := DecodeTs(EncodeTs(98753868))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(98753868), ts)
+
+	_, err = DecodeTs([]byte{1, 2, 3})
+	assert.NotNil(t, err)
+	_, err = TruncateTs([]byte{1, 2, 3})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,122 @@
+package engine_util
+
+import (
+	"sync"
+
+	"github.com/Connor1996/badger"
+)
+
+// TombstoneTracker tracks the ranges where many keys were deleted at once, by
+// destroying a region or a range of data. Badger only drops the tombstones of
+// such a range with the compactions of the levels holding them, until then
+// every read over the range skips them, so the dense ranges are compacted
+// with CompactCFRanges instead of waiting for the engine. A nil tracker
+// tracks nothing.
+type TombstoneTracker struct {
+	mu     sync.Mutex
+	groups []*tombstoneGroup
+}
+
+// tombstoneGroup is the ranges deleted together and the number of keys
+// deleted from them.
+type tombstoneGroup struct {
+	ranges     []CFRange
+	tombstones int
+}
+
+func NewTombstoneTracker() *TombstoneTracker {
+	return new(TombstoneTracker)
+}
+
+// Record records that tombstones keys were deleted from ranges.
+func (t *TombstoneTracker) Record(ranges []CFRange, tombstones int) {
+	if t == nil || tombstones == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.groups = append(t.groups, &tombstoneGroup{ranges: ranges, tombstones: tombstones})
+}
+
+// TakeDense removes and returns the ranges of the groups which had at least
+// threshold keys deleted, the others are kept until more keys are deleted
+// from them.
+func (t *TombstoneTracker) TakeDense(threshold int) [][]CFRange {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var dense [][]CFRange
+	kept := t.groups[:0]
+	for _, g := range t.groups {
+		if g.tombstones >= threshold {
+			dense = append(dense, g.ranges)
+		} else {
+			kept = append(kept, g)
+		}
+	}
+	for i := len(kept); i < len(t.groups); i++ {
+		t.groups[i] = nil
+	}
+	t.groups = kept
+	return dense
+}
+
+// Forget stops tracking the groups with a range overlapping [startKey,
+// endKey), as the range is in use again and must not be compacted. An empty
+// endKey is unbounded.
+func (t *TombstoneTracker) Forget(startKey, endKey []byte) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	kept := t.groups[:0]
+	for _, g := range t.groups {
+		if !g.overlaps(startKey, endKey) {
+			kept = append(kept, g)
+		}
+	}
+	for i := len(kept); i < len(t.groups); i++ {
+		t.groups[i] = nil
+	}
+	t.groups = kept
+}
+
+func (g *tombstoneGroup) overlaps(startKey, endKey []byte) bool {
+	for _, r := range g.ranges {
+		if !ExceedEndKey(r.Start, endKey) && !ExceedEndKey(startKey, r.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of groups of ranges tracked.
+func (t *TombstoneTracker) Len() int {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.groups)
+}
+
+// CompactCFRanges reclaims the space and the read cost of the deleted keys of
+// ranges. Badger can't compact a key range, so the tables holding nothing but
+// keys of a range are dropped, then the keys whose tombstones were dropped
+// with them are deleted again. A key of the ranges may reappear for a moment,
+// so they must be ranges no one reads or writes anymore, like the range of a
+// destroyed region. It returns the number of keys deleted again.
+func CompactCFRanges(db *badger.DB, ranges []CFRange) (int, error) {
+	for _, r := range ranges {
+		end := KeyWithCF(r.CF, r.End)
+		if len(r.End) == 0 {
+			// The first key after the CF.
+			end = []byte(r.CF + string('_'+1))
+		}
+		db.DeleteFilesInRange(KeyWithCF(r.CF, r.Start), end)
+	}
+	return DeleteCFRanges(db, ranges)
+}
@@ -3,6 +3,7 @@ package engine_util
 import (
 	"bytes"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/Connor1996/badger"
@@ -91,3 +92,70 @@ func TestEngineUtil(t *testing.T) {
 	require.False(t, lockIter.Valid())
 	lockIter.Close()
 }
+
+func TestRegisterCFs(t *testing.T) {
+	defer func(cfs []string) { CFs = cfs }(CFs)
+	CFs = append([]string(nil), CFs...)
+
+	require.Nil(t, RegisterCFs("index", CfWrite, "index"))
+	require.Equal(t, []string{CfDefault, CfWrite, CfLock, "index"}, CFs)
+	require.True(t, IsValidCF("index"))
+	require.False(t, IsValidCF("meta"))
+
+	require.NotNil(t, RegisterCFs(""))
+	require.NotNil(t, RegisterCFs("my_cf"))
+	require.Len(t, CFs, 4)
+}
+
+func TestTombstoneTracker(t *testing.T) {
+	tracker := NewTombstoneTracker()
+	a := CFRanges([]byte("a"), []byte("b"))
+	c := CFRanges([]byte("c"), []byte("d"))
+	e := CFRanges([]byte("e"), nil)
+	tracker.Record(a, 100)
+	tracker.Record(c, 10)
+	tracker.Record(e, 1000)
+	tracker.Record(e, 0)
+	require.Equal(t, 3, tracker.Len())
+
+	tracker.Forget([]byte("x"), nil)
+	require.Equal(t, [][]CFRange{a}, tracker.TakeDense(50))
+	require.Equal(t, [][]CFRange{c}, tracker.TakeDense(10))
+	require.Equal(t, 0, tracker.Len())
+}
+
+func TestCompactCFRanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "engine_util")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	opts := badger.DefaultOptions
+	opts.Dir = dir
+	opts.ValueDir = dir
+	db, err := badger.Open(opts)
+	require.Nil(t, err)
+	defer db.Close()
+
+	batch := new(WriteBatch)
+	for i := 0; i < 100; i++ {
+		batch.SetCF(CfDefault, []byte{'a', byte(i)}, []byte("v"))
+	}
+	batch.SetCF(CfDefault, []byte("b"), []byte("v"))
+	batch.SetCF(CfWrite, []byte("a"), []byte("v"))
+	require.Nil(t, batch.WriteToDB(db))
+
+	ranges := []CFRange{{CF: CfDefault, Start: []byte("a"), End: []byte("b")}}
+	n, err := DeleteCFRanges(db, ranges)
+	require.Nil(t, err)
+	require.Equal(t, 100, n)
+	_, err = CompactCFRanges(db, ranges)
+	require.Nil(t, err)
+
+	_, err = GetCF(db, CfDefault, []byte{'a', 1})
+	require.Equal(t, badger.ErrKeyNotFound, err)
+	val, err := GetCF(db, CfDefault, []byte("b"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("v"), val)
+	val, err = GetCF(db, CfWrite, []byte("a"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("v"), val)
+}
@@ -18,14 +18,18 @@ type Engines struct {
 	// Metadata used by Raft.
 	Raft     *badger.DB
 	RaftPath string
+	// Tombstones tracks the ranges of the kv engine where many keys were
+	// deleted, to compact them.
+	Tombstones *TombstoneTracker
 }
 
 func NewEngines(kvEngine, raftEngine *badger.DB, kvPath, raftPath string) *Engines {
 	return &Engines{
-		Kv:       kvEngine,
-		KvPath:   kvPath,
-		Raft:     raftEngine,
-		RaftPath: raftPath,
+		Kv:         kvEngine,
+		KvPath:     kvPath,
+		Raft:       raftEngine,
+		RaftPath:   raftPath,
+		Tombstones: NewTombstoneTracker(),
 	}
 }
 
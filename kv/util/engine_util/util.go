@@ -79,14 +79,38 @@ func DeleteCF(engine *badger.DB, cf string, key []byte) error {
 }
 
 func DeleteRange(db *badger.DB, startKey, endKey []byte) error {
+	_, err := DeleteCFRanges(db, CFRanges(startKey, endKey))
+	return err
+}
+
+// CFRange is the range [Start, End) of the keys of a CF, an empty End is
+// unbounded.
+type CFRange struct {
+	CF         string
+	Start, End []byte
+}
+
+// CFRanges returns the range [startKey, endKey) in every CF.
+func CFRanges(startKey, endKey []byte) []CFRange {
+	ranges := make([]CFRange, 0, len(CFs))
+	for _, cf := range CFs {
+		ranges = append(ranges, CFRange{CF: cf, Start: startKey, End: endKey})
+	}
+	return ranges
+}
+
+// DeleteCFRanges deletes all the keys in ranges in a single write batch, so
+// a reader sees either all of them or none. It returns the number of keys
+// deleted.
+func DeleteCFRanges(db *badger.DB, ranges []CFRange) (int, error) {
 	batch := new(WriteBatch)
 	txn := db.NewTransaction(false)
 	defer txn.Discard()
-	for _, cf := range CFs {
-		deleteRangeCF(txn, batch, cf, startKey, endKey)
+	for _, r := range ranges {
+		deleteRangeCF(txn, batch, r.CF, r.Start, r.End)
 	}
 
-	return batch.WriteToDB(db)
+	return batch.Len(), batch.WriteToDB(db)
 }
 
 func deleteRangeCF(txn *badger.Txn, batch *WriteBatch, cf string, startKey, endKey []byte) {
@@ -1,6 +1,8 @@
 package engine_util
 
 import (
+	"strings"
+
 	"github.com/Connor1996/badger"
 	"github.com/golang/protobuf/proto"
 	"github.com/pingcap/errors"
@@ -20,12 +22,45 @@ const (
 	CfLock    string = "lock"
 )
 
-var CFs [3]string = [3]string{CfDefault, CfWrite, CfLock}
+// CFs are the column families known to the store, the built-in ones followed
+// by the ones added with RegisterCFs.
+var CFs = []string{CfDefault, CfWrite, CfLock}
+
+// RegisterCFs adds column families to CFs, the names already known are
+// skipped. It must be called before the engines are opened, as CFs is read
+// without synchronization afterwards. A name can't be empty or contain '_',
+// the separator between the CF and the key in the engine.
+func RegisterCFs(cfs ...string) error {
+	for _, cf := range cfs {
+		if cf == "" || strings.Contains(cf, "_") {
+			return errors.Errorf("invalid cf name %q", cf)
+		}
+		if !IsValidCF(cf) {
+			CFs = append(CFs, cf)
+		}
+	}
+	return nil
+}
+
+// IsValidCF reports whether cf is a known column family.
+func IsValidCF(cf string) bool {
+	for _, c := range CFs {
+		if c == cf {
+			return true
+		}
+	}
+	return false
+}
 
 func (wb *WriteBatch) Len() int {
 	return len(wb.entries)
 }
 
+// Size returns the size of the keys and values in the batch.
+func (wb *WriteBatch) Size() int {
+	return wb.size
+}
+
 func (wb *WriteBatch) SetCF(cf string, key, val []byte) {
 	wb.entries = append(wb.entries, &badger.Entry{
 		Key:   KeyWithCF(cf, key),
@@ -0,0 +1,80 @@
+// Package hedge sends hedged requests: a request goes to the next replica
+// when the previous ones haven't answered within a delay, and the first good
+// answer wins. A leader stalled by a GC pause then costs a read the delay
+// rather than the whole pause.
+package hedge
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoRequest is returned by Do without any request to send.
+var ErrNoRequest = errors.New("hedge: no request")
+
+// Request sends a request to one replica. It should return promptly once ctx
+// is cancelled, which happens when another replica has answered.
+type Request func(ctx context.Context) (interface{}, error)
+
+type result struct {
+	resp interface{}
+	err  error
+}
+
+// Do sends the first request, then the next one after every delay without a
+// good answer, or right away when the requests sent so far all failed. It
+// returns the first answer without an error, or the last error if every
+// request failed. A delay of 0 disables hedging, the requests are only sent
+// in turn on failure.
+func Do(ctx context.Context, delay time.Duration, reqs ...Request) (interface{}, error) {
+	if len(reqs) == 0 {
+		return nil, ErrNoRequest
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Buffered so the requests still running when Do returns don't block.
+	results := make(chan result, len(reqs))
+	send := func(req Request) {
+		go func() {
+			resp, err := req(ctx)
+			results <- result{resp, err}
+		}()
+	}
+
+	var err error
+	sent, done := 0, 0
+	for done < len(reqs) {
+		if sent == done {
+			send(reqs[sent])
+			sent++
+		}
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if delay > 0 && sent < len(reqs) {
+			timer = time.NewTimer(delay)
+			timeout = timer.C
+		}
+		select {
+		case r := <-results:
+			done++
+			if r.err == nil {
+				return r.resp, nil
+			}
+			err = r.err
+		case <-timeout:
+			send(reqs[sent])
+			sent++
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return nil, err
+}
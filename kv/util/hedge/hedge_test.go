@@ -0,0 +1,71 @@
+package hedge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func answer(after time.Duration, resp interface{}, err error) Request {
+	return func(ctx context.Context) (interface{}, error) {
+		select {
+		case <-time.After(after):
+			return resp, err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func TestDoHedgesSlowRequest(t *testing.T) {
+	start := time.Now()
+	resp, err := Do(context.Background(), 10*time.Millisecond,
+		answer(time.Second, "leader", nil),
+		answer(0, "follower", nil))
+	assert.Nil(t, err)
+	assert.Equal(t, "follower", resp)
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+}
+
+func TestDoPrefersFastFirstRequest(t *testing.T) {
+	sent := false
+	resp, err := Do(context.Background(), time.Second,
+		answer(0, "leader", nil),
+		func(ctx context.Context) (interface{}, error) {
+			sent = true
+			return "follower", nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, "leader", resp)
+	assert.False(t, sent)
+}
+
+func TestDoFailsOver(t *testing.T) {
+	errLeader := errors.New("leader failed")
+	errFollower := errors.New("follower failed")
+
+	// Without hedging the next request is sent on failure.
+	resp, err := Do(context.Background(), 0,
+		answer(0, nil, errLeader),
+		answer(0, "follower", nil))
+	assert.Nil(t, err)
+	assert.Equal(t, "follower", resp)
+
+	_, err = Do(context.Background(), 0,
+		answer(0, nil, errLeader),
+		answer(0, nil, errFollower))
+	assert.Equal(t, errFollower, err)
+
+	_, err = Do(context.Background(), 0)
+	assert.Equal(t, ErrNoRequest, err)
+}
+
+func TestDoCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := Do(ctx, 0, answer(time.Second, "leader", nil))
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,171 @@
+// Package kverrors defines the errors a store returns to its clients, from the
+// raftstore up to the server handlers. They are plain values which may be
+// wrapped with github.com/pingcap/errors on the way up, the handlers find them
+// with errors.Cause and convert them to the errors of the protocol.
+package kverrors
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap/errors"
+)
+
+type ErrNotLeader struct {
+	RegionId uint64
+	Leader   *metapb.Peer
+}
+
+func (e *ErrNotLeader) Error() string {
+	return fmt.Sprintf("region %v is not leader", e.RegionId)
+}
+
+type ErrRegionNotFound struct {
+	RegionId uint64
+}
+
+func (e *ErrRegionNotFound) Error() string {
+	return fmt.Sprintf("region %v is not found", e.RegionId)
+}
+
+type ErrKeyNotInRegion struct {
+	Key    []byte
+	Region *metapb.Region
+}
+
+func (e *ErrKeyNotInRegion) Error() string {
+	return fmt.Sprintf("key %v is not in region %v", e.Key, e.Region)
+}
+
+type ErrEpochNotMatch struct {
+	Message string
+	Regions []*metapb.Region
+}
+
+func (e *ErrEpochNotMatch) Error() string {
+	return fmt.Sprintf("epoch not match, error msg %v, regions %v", e.Message, e.Regions)
+}
+
+// ErrStaleCommand means the command was proposed in a stale term. RetryAfter and
+// Class are hints for the client, they may be left empty.
+type ErrStaleCommand struct {
+	RetryAfter time.Duration
+	Class      errorpb.ErrorClass
+}
+
+func (e *ErrStaleCommand) Error() string {
+	return fmt.Sprintf("stale command")
+}
+
+type ErrStoreNotMatch struct {
+	RequestStoreId uint64
+	ActualStoreId  uint64
+}
+
+func (e *ErrStoreNotMatch) Error() string {
+	return fmt.Sprintf("store not match, request store id is %v, but actual store id is %v", e.RequestStoreId, e.ActualStoreId)
+}
+
+// ErrServerIsBusy means the store can't take the request for now, the client
+// should retry it later.
+type ErrServerIsBusy struct {
+	Reason     string
+	RetryAfter time.Duration
+	Class      errorpb.ErrorClass
+}
+
+func (e *ErrServerIsBusy) Error() string {
+	return fmt.Sprintf("server is busy, reason %v", e.Reason)
+}
+
+// ErrKeyLocked means a key is locked by a transaction which is not committed
+// yet, the client must resolve the lock before retrying.
+type ErrKeyLocked struct {
+	Lock *kvrpcpb.LockInfo
+}
+
+func (e *ErrKeyLocked) Error() string {
+	return fmt.Sprintf("key %v is locked by transaction %v", e.Lock.GetKey(), e.Lock.GetLockVersion())
+}
+
+// RegionError carries a region error already converted for the protocol, e.g.
+// the one a raftstore answered a command with.
+type RegionError struct {
+	RequestErr *errorpb.Error
+}
+
+func (re *RegionError) Error() string {
+	return re.RequestErr.String()
+}
+
+// KeyError carries a key error already converted for the protocol, e.g. one
+// found by a transactional command.
+type KeyError struct {
+	kvrpcpb.KeyError
+}
+
+func (ke *KeyError) Error() string {
+	return ke.String()
+}
+
+// AsRegionError returns the region error carried by e, or false if e is not a
+// region error and must be reported otherwise.
+func AsRegionError(e error) (*errorpb.Error, bool) {
+	switch err := errors.Cause(e).(type) {
+	case *RegionError:
+		return err.RequestErr, true
+	case *ErrNotLeader, *ErrRegionNotFound, *ErrKeyNotInRegion, *ErrEpochNotMatch,
+		*ErrStaleCommand, *ErrStoreNotMatch, *ErrServerIsBusy:
+		return ToRegionError(err), true
+	}
+	return nil, false
+}
+
+// AsKeyError returns the key error carried by e, or false if e is not one.
+func AsKeyError(e error) (*kvrpcpb.KeyError, bool) {
+	switch err := errors.Cause(e).(type) {
+	case *KeyError:
+		return &err.KeyError, true
+	case *ErrKeyLocked:
+		return &kvrpcpb.KeyError{Locked: err.Lock}, true
+	}
+	return nil, false
+}
+
+// ToRegionError converts e to a region error, an error which is not one of
+// the region errors above is carried as a message.
+func ToRegionError(e error) *errorpb.Error {
+	ret := new(errorpb.Error)
+	switch err := errors.Cause(e).(type) {
+	case *ErrNotLeader:
+		ret.NotLeader = &errorpb.NotLeader{RegionId: err.RegionId, Leader: err.Leader}
+	case *ErrRegionNotFound:
+		ret.RegionNotFound = &errorpb.RegionNotFound{RegionId: err.RegionId}
+	case *ErrKeyNotInRegion:
+		ret.KeyNotInRegion = &errorpb.KeyNotInRegion{Key: err.Key, RegionId: err.Region.Id,
+			StartKey: err.Region.StartKey, EndKey: err.Region.EndKey}
+	case *ErrEpochNotMatch:
+		ret.EpochNotMatch = &errorpb.EpochNotMatch{CurrentRegions: err.Regions}
+	case *ErrStaleCommand:
+		ret.StaleCommand = &errorpb.StaleCommand{
+			RetryAfterMs: uint64(err.RetryAfter / time.Millisecond),
+			ErrorClass:   err.Class,
+		}
+	case *ErrStoreNotMatch:
+		ret.StoreNotMatch = &errorpb.StoreNotMatch{RequestStoreId: err.RequestStoreId, ActualStoreId: err.ActualStoreId}
+	case *ErrServerIsBusy:
+		ret.ServerIsBusy = &errorpb.ServerIsBusy{
+			Reason:       err.Reason,
+			RetryAfterMs: uint64(err.RetryAfter / time.Millisecond),
+			ErrorClass:   err.Class,
+		}
+	case *RegionError:
+		return err.RequestErr
+	default:
+		ret.Message = e.Error()
+	}
+	return ret
+}
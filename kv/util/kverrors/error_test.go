@@ -0,0 +1,91 @@
+package kverrors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/errorpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToRegionError(t *testing.T) {
+	regionId := uint64(1)
+	notLeader := &ErrNotLeader{RegionId: regionId, Leader: nil}
+	pbErr := ToRegionError(notLeader)
+	require.NotNil(t, pbErr.NotLeader)
+	assert.Equal(t, regionId, pbErr.NotLeader.RegionId)
+
+	regionNotFound := &ErrRegionNotFound{RegionId: regionId}
+	pbErr = ToRegionError(regionNotFound)
+	require.NotNil(t, pbErr.RegionNotFound)
+	assert.Equal(t, regionId, pbErr.RegionNotFound.RegionId)
+
+	region := &metapb.Region{Id: regionId, StartKey: []byte{0}, EndKey: []byte{1}}
+
+	keyNotInRegion := &ErrKeyNotInRegion{Key: []byte{2}, Region: region}
+	pbErr = ToRegionError(keyNotInRegion)
+	require.NotNil(t, pbErr.KeyNotInRegion)
+	assert.Equal(t, []byte{0}, pbErr.KeyNotInRegion.StartKey)
+	assert.Equal(t, []byte{1}, pbErr.KeyNotInRegion.EndKey)
+	assert.Equal(t, []byte{2}, pbErr.KeyNotInRegion.Key)
+
+	epochNotMatch := &ErrEpochNotMatch{Regions: []*metapb.Region{region}}
+	pbErr = ToRegionError(epochNotMatch)
+	require.NotNil(t, pbErr.EpochNotMatch)
+	assert.Equal(t, []*metapb.Region{region}, pbErr.EpochNotMatch.CurrentRegions)
+
+	staleCommand := &ErrStaleCommand{RetryAfter: 2 * time.Second, Class: errorpb.ErrorClass_LeaderChanging}
+	pbErr = ToRegionError(staleCommand)
+	require.NotNil(t, pbErr.StaleCommand)
+	assert.Equal(t, uint64(2000), pbErr.StaleCommand.RetryAfterMs)
+	assert.Equal(t, errorpb.ErrorClass_LeaderChanging, pbErr.StaleCommand.ErrorClass)
+
+	requestStoreId, actualStoreId := uint64(1), uint64(2)
+	storeNotMatch := &ErrStoreNotMatch{RequestStoreId: requestStoreId, ActualStoreId: actualStoreId}
+	pbErr = ToRegionError(storeNotMatch)
+	require.NotNil(t, pbErr.StoreNotMatch)
+	assert.Equal(t, requestStoreId, pbErr.StoreNotMatch.RequestStoreId)
+	assert.Equal(t, actualStoreId, pbErr.StoreNotMatch.ActualStoreId)
+
+	serverIsBusy := &ErrServerIsBusy{Reason: "store is read-only", RetryAfter: 50 * time.Millisecond, Class: errorpb.ErrorClass_ReadOnly}
+	pbErr = ToRegionError(serverIsBusy)
+	require.NotNil(t, pbErr.ServerIsBusy)
+	assert.Equal(t, "store is read-only", pbErr.ServerIsBusy.Reason)
+	assert.Equal(t, uint64(50), pbErr.ServerIsBusy.RetryAfterMs)
+	assert.Equal(t, errorpb.ErrorClass_ReadOnly, pbErr.ServerIsBusy.ErrorClass)
+}
+
+func TestAsRegionError(t *testing.T) {
+	wrapped := errors.Annotate(&ErrNotLeader{RegionId: 1}, "read")
+	pbErr, ok := AsRegionError(wrapped)
+	require.True(t, ok)
+	require.NotNil(t, pbErr.NotLeader)
+
+	carried := &errorpb.Error{RegionNotFound: &errorpb.RegionNotFound{RegionId: 1}}
+	pbErr, ok = AsRegionError(errors.WithStack(&RegionError{RequestErr: carried}))
+	require.True(t, ok)
+	assert.Equal(t, carried, pbErr)
+
+	_, ok = AsRegionError(errors.New("io error"))
+	assert.False(t, ok)
+	_, ok = AsRegionError(&ErrKeyLocked{Lock: &kvrpcpb.LockInfo{Key: []byte{1}}})
+	assert.False(t, ok)
+}
+
+func TestAsKeyError(t *testing.T) {
+	lock := &kvrpcpb.LockInfo{PrimaryLock: []byte{1}, LockVersion: 10, Key: []byte{2}}
+	keyErr, ok := AsKeyError(errors.WithStack(&ErrKeyLocked{Lock: lock}))
+	require.True(t, ok)
+	assert.Equal(t, lock, keyErr.Locked)
+
+	keyErr, ok = AsKeyError(errors.WithStack(&KeyError{kvrpcpb.KeyError{Retryable: "retry"}}))
+	require.True(t, ok)
+	assert.Equal(t, "retry", keyErr.Retryable)
+
+	_, ok = AsKeyError(&ErrNotLeader{RegionId: 1})
+	assert.False(t, ok)
+}
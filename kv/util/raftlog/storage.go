@@ -0,0 +1,420 @@
+// Package raftlog implements the raft.Storage of a raft group on a badger DB,
+// so the users of the raft package outside of the raftstore get a durable log
+// without writing their own. It persists the HardState, the ConfState, the
+// entries and the last snapshot, and several groups can share a DB.
+package raftlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/Connor1996/badger"
+	"github.com/golang/protobuf/proto"
+	"github.com/pingcap-incubator/tinykv/kv/util/engine_util"
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/raft"
+)
+
+// keyPrefix is the prefix of the keys of every group, followed by the id of
+// the group and a suffix telling what the key holds.
+var keyPrefix = []byte("raftlog/")
+
+const (
+	entrySuffix     byte = 0x01
+	hardStateSuffix byte = 0x02
+	confStateSuffix byte = 0x03
+	snapshotSuffix  byte = 0x04
+	// truncatedSuffix holds the index and term of the entry before the
+	// first one, which are kept for matching like the dummy entry of
+	// raft.MemoryStorage.
+	truncatedSuffix byte = 0x05
+)
+
+func groupKey(id uint64, suffix byte) []byte {
+	key := make([]byte, len(keyPrefix)+9)
+	copy(key, keyPrefix)
+	binary.BigEndian.PutUint64(key[len(keyPrefix):], id)
+	key[len(keyPrefix)+8] = suffix
+	return key
+}
+
+func entryKey(id, index uint64) []byte {
+	key := make([]byte, len(keyPrefix)+17)
+	copy(key, groupKey(id, entrySuffix))
+	binary.BigEndian.PutUint64(key[len(keyPrefix)+9:], index)
+	return key
+}
+
+// Storage is a raft.SnapshotStorage keeping the log of a raft group in a
+// badger DB. Every change is written to the DB before the method returns.
+// It is safe for concurrent use, like raft.MemoryStorage, whose methods it
+// mirrors.
+type Storage struct {
+	db *badger.DB
+	id uint64
+
+	mu        sync.Mutex
+	hardState pb.HardState
+	confState pb.ConfState
+	// snapMeta is the metadata of the last snapshot, its data is only read
+	// by Snapshot.
+	snapMeta  pb.SnapshotMetadata
+	truncated pb.Entry
+	lastIndex uint64
+}
+
+var _ raft.SnapshotStorage = new(Storage)
+
+// NewStorage returns the storage of the raft group id in db, loading the
+// state persisted by a previous storage of the group if any.
+func NewStorage(db *badger.DB, id uint64) (*Storage, error) {
+	s := &Storage{db: db, id: id}
+	err := db.View(func(txn *badger.Txn) error {
+		if err := getMeta(txn, groupKey(id, hardStateSuffix), &s.hardState); err != nil {
+			return err
+		}
+		if err := getMeta(txn, groupKey(id, confStateSuffix), &s.confState); err != nil {
+			return err
+		}
+		var snap pb.Snapshot
+		if err := getMeta(txn, groupKey(id, snapshotSuffix), &snap); err != nil {
+			return err
+		}
+		if snap.Metadata != nil {
+			s.snapMeta = *snap.Metadata
+		}
+		if err := getMeta(txn, groupKey(id, truncatedSuffix), &s.truncated); err != nil {
+			return err
+		}
+
+		s.lastIndex = s.truncated.Index
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		iter := txn.NewIterator(opts)
+		defer iter.Close()
+		prefix := groupKey(id, entrySuffix)
+		if iter.Seek(entryKey(id, ^uint64(0))); iter.ValidForPrefix(prefix) {
+			s.lastIndex = binary.BigEndian.Uint64(iter.Item().Key()[len(prefix):])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// getMeta reads the message at key, leaving msg empty if there is none.
+func getMeta(txn *badger.Txn, key []byte, msg proto.Message) error {
+	err := engine_util.GetMetaFromTxn(txn, key, msg)
+	if err == badger.ErrKeyNotFound {
+		return nil
+	}
+	return err
+}
+
+// InitialState implements the raft.Storage interface.
+func (s *Storage) InitialState() (pb.HardState, pb.ConfState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hardState, s.confState, nil
+}
+
+// SetHardState saves the current HardState.
+func (s *Storage) SetHardState(st pb.HardState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := engine_util.PutMeta(s.db, groupKey(s.id, hardStateSuffix), &st); err != nil {
+		return err
+	}
+	s.hardState = st
+	return nil
+}
+
+// SetConfState saves the ConfState of the group, which is the result of the
+// last ApplyConfChange, so it is known on restart even if no snapshot was
+// created since.
+func (s *Storage) SetConfState(cs pb.ConfState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := engine_util.PutMeta(s.db, groupKey(s.id, confStateSuffix), &cs); err != nil {
+		return err
+	}
+	s.confState = cs
+	return nil
+}
+
+// Entries implements the raft.Storage interface.
+func (s *Storage) Entries(lo, hi uint64) ([]pb.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lo <= s.truncated.Index {
+		return nil, raft.ErrCompacted
+	}
+	if lo > hi {
+		return nil, fmt.Errorf("raftlog: invalid entries range [%d, %d)", lo, hi)
+	}
+	if hi > s.lastIndex+1 {
+		return nil, raft.ErrUnavailable
+	}
+	if lo == hi {
+		return nil, nil
+	}
+
+	ents := make([]pb.Entry, 0, hi-lo)
+	err := s.db.View(func(txn *badger.Txn) error {
+		iter := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer iter.Close()
+		endKey := entryKey(s.id, hi)
+		for iter.Seek(entryKey(s.id, lo)); iter.Valid(); iter.Next() {
+			item := iter.Item()
+			if bytes.Compare(item.Key(), endKey) >= 0 {
+				break
+			}
+			val, err := item.Value()
+			if err != nil {
+				return err
+			}
+			var ent pb.Entry
+			if err := ent.Unmarshal(val); err != nil {
+				return err
+			}
+			if err := raft.CheckEntry(&ent); err != nil {
+				return err
+			}
+			if ent.Index != lo+uint64(len(ents)) {
+				break
+			}
+			ents = append(ents, ent)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ents) != int(hi-lo) {
+		return nil, raft.ErrUnavailable
+	}
+	return ents, nil
+}
+
+// Term implements the raft.Storage interface.
+func (s *Storage) Term(i uint64) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.term(i)
+}
+
+func (s *Storage) term(i uint64) (uint64, error) {
+	if i < s.truncated.Index {
+		return 0, raft.ErrCompacted
+	}
+	if i == s.truncated.Index {
+		return s.truncated.Term, nil
+	}
+	if i > s.lastIndex {
+		return 0, raft.ErrUnavailable
+	}
+	var ent pb.Entry
+	if err := engine_util.GetMeta(s.db, entryKey(s.id, i), &ent); err != nil {
+		return 0, err
+	}
+	return ent.Term, nil
+}
+
+// LastIndex implements the raft.Storage interface.
+func (s *Storage) LastIndex() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastIndex, nil
+}
+
+// FirstIndex implements the raft.Storage interface.
+func (s *Storage) FirstIndex() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.truncated.Index + 1, nil
+}
+
+// Snapshot implements the raft.Storage interface.
+func (s *Storage) Snapshot() (pb.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var snap pb.Snapshot
+	err := s.db.View(func(txn *badger.Txn) error {
+		return getMeta(txn, groupKey(s.id, snapshotSuffix), &snap)
+	})
+	if err != nil {
+		return pb.Snapshot{}, err
+	}
+	if snap.Metadata == nil {
+		snap.Metadata = &pb.SnapshotMetadata{ConfState: &pb.ConfState{}}
+	}
+	return snap, nil
+}
+
+// Append persists the new entries, which must be continuous. Those already
+// compacted are skipped, and those conflicting with the entries of the
+// storage replace them along with the entries after them.
+func (s *Storage) Append(entries []pb.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Index != entries[i-1].Index+1 {
+			return fmt.Errorf("raftlog: entries are not continuous [%d after %d]",
+				entries[i].Index, entries[i-1].Index)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	first := s.truncated.Index + 1
+	last := entries[len(entries)-1].Index
+	// shortcut if there is no new entry.
+	if last < first {
+		return nil
+	}
+	// truncate compacted entries
+	if first > entries[0].Index {
+		entries = entries[first-entries[0].Index:]
+	}
+	if entries[0].Index > s.lastIndex+1 {
+		return fmt.Errorf("raftlog: missing log entry [last: %d, append at: %d]",
+			s.lastIndex, entries[0].Index)
+	}
+
+	wb := new(engine_util.WriteBatch)
+	for i := range entries {
+		if err := wb.SetMeta(entryKey(s.id, entries[i].Index), &entries[i]); err != nil {
+			return err
+		}
+	}
+	// Delete the entries after the conflicting ones.
+	for i := last + 1; i <= s.lastIndex; i++ {
+		wb.DeleteMeta(entryKey(s.id, i))
+	}
+	if err := wb.WriteToDB(s.db); err != nil {
+		return err
+	}
+	s.lastIndex = last
+	return nil
+}
+
+// Compact discards all log entries prior to compactIndex.
+// It is the application's responsibility to not attempt to compact an index
+// greater than raftLog.applied.
+func (s *Storage) Compact(compactIndex uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if compactIndex <= s.truncated.Index {
+		return raft.ErrCompacted
+	}
+	if compactIndex > s.lastIndex {
+		return raft.ErrUnavailable
+	}
+	term, err := s.term(compactIndex)
+	if err != nil {
+		return err
+	}
+
+	truncated := pb.Entry{Index: compactIndex, Term: term}
+	wb := new(engine_util.WriteBatch)
+	if err := wb.SetMeta(groupKey(s.id, truncatedSuffix), &truncated); err != nil {
+		return err
+	}
+	for i := s.truncated.Index + 1; i <= compactIndex; i++ {
+		wb.DeleteMeta(entryKey(s.id, i))
+	}
+	if err := wb.WriteToDB(s.db); err != nil {
+		return err
+	}
+	s.truncated = truncated
+	return nil
+}
+
+// CreateSnapshot makes a snapshot which can be retrieved with Snapshot() and
+// can be used to reconstruct the state at that point. The entries are kept
+// until they are compacted.
+// If any configuration changes have been made since the last compaction,
+// the result of the last ApplyConfChange must be passed in.
+func (s *Storage) CreateSnapshot(i uint64, cs *pb.ConfState, data []byte) (pb.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i <= s.snapMeta.Index {
+		return pb.Snapshot{}, raft.ErrSnapOutOfDate
+	}
+	if i > s.lastIndex {
+		return pb.Snapshot{}, raft.ErrUnavailable
+	}
+	term, err := s.term(i)
+	if err != nil {
+		return pb.Snapshot{}, err
+	}
+
+	confState := s.confState
+	if cs != nil {
+		confState = *cs
+	}
+	snap := pb.Snapshot{
+		Data: data,
+		Metadata: &pb.SnapshotMetadata{
+			Index:     i,
+			Term:      term,
+			ConfState: &confState,
+		},
+	}
+	wb := new(engine_util.WriteBatch)
+	if err := wb.SetMeta(groupKey(s.id, snapshotSuffix), &snap); err != nil {
+		return pb.Snapshot{}, err
+	}
+	if err := wb.SetMeta(groupKey(s.id, confStateSuffix), &confState); err != nil {
+		return pb.Snapshot{}, err
+	}
+	if err := wb.WriteToDB(s.db); err != nil {
+		return pb.Snapshot{}, err
+	}
+	s.snapMeta = *snap.Metadata
+	s.confState = confState
+	return snap, nil
+}
+
+// ApplySnapshot overwrites the contents of this Storage object with
+// those of the given snapshot.
+func (s *Storage) ApplySnapshot(snap pb.Snapshot) error {
+	if snap.Metadata == nil || snap.Metadata.ConfState == nil {
+		return fmt.Errorf("raftlog: snapshot without metadata")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.snapMeta.Index >= snap.Metadata.Index {
+		return raft.ErrSnapOutOfDate
+	}
+
+	truncated := pb.Entry{Index: snap.Metadata.Index, Term: snap.Metadata.Term}
+	wb := new(engine_util.WriteBatch)
+	if err := wb.SetMeta(groupKey(s.id, snapshotSuffix), &snap); err != nil {
+		return err
+	}
+	if err := wb.SetMeta(groupKey(s.id, confStateSuffix), snap.Metadata.ConfState); err != nil {
+		return err
+	}
+	if err := wb.SetMeta(groupKey(s.id, truncatedSuffix), &truncated); err != nil {
+		return err
+	}
+	for i := s.truncated.Index + 1; i <= s.lastIndex; i++ {
+		wb.DeleteMeta(entryKey(s.id, i))
+	}
+	if err := wb.WriteToDB(s.db); err != nil {
+		return err
+	}
+	s.snapMeta = *snap.Metadata
+	s.confState = *snap.Metadata.ConfState
+	s.truncated = truncated
+	s.lastIndex = truncated.Index
+	return nil
+}
@@ -0,0 +1,180 @@
+package raftlog
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/Connor1996/badger"
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) (*badger.DB, func()) {
+	dir, err := ioutil.TempDir("", "raftlog")
+	require.Nil(t, err)
+	opts := badger.DefaultOptions
+	opts.Dir = dir
+	opts.ValueDir = dir
+	db, err := badger.Open(opts)
+	require.Nil(t, err)
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestStorageAppend(t *testing.T) {
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+	s, err := NewStorage(db, 1)
+	require.Nil(t, err)
+
+	require.Nil(t, s.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 2}}))
+	// A conflict replaces the entries from it on.
+	require.Nil(t, s.Append([]pb.Entry{{Index: 2, Term: 3}}))
+	ents, err := s.Entries(1, 3)
+	require.Nil(t, err)
+	assert.Equal(t, []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 3}}, ents)
+	last, _ := s.LastIndex()
+	assert.Equal(t, uint64(2), last)
+	_, err = s.Entries(1, 4)
+	assert.Equal(t, raft.ErrUnavailable, err)
+
+	assert.NotNil(t, s.Append([]pb.Entry{{Index: 4, Term: 3}}))
+	assert.NotNil(t, s.Append([]pb.Entry{{Index: 3, Term: 3}, {Index: 5, Term: 3}}))
+
+	// The groups sharing the DB don't see each other's entries.
+	other, err := NewStorage(db, 2)
+	require.Nil(t, err)
+	last, _ = other.LastIndex()
+	assert.Equal(t, uint64(0), last)
+}
+
+func TestStorageRestart(t *testing.T) {
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+	s, err := NewStorage(db, 1)
+	require.Nil(t, err)
+
+	hs := pb.HardState{Term: 2, Vote: 1, Commit: 4}
+	cs := pb.ConfState{Nodes: []uint64{1, 2, 3}}
+	require.Nil(t, s.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 2}, {Index: 4, Term: 2}}))
+	require.Nil(t, s.SetHardState(hs))
+	require.Nil(t, s.SetConfState(cs))
+	_, err = s.CreateSnapshot(3, nil, []byte("data"))
+	require.Nil(t, err)
+	require.Nil(t, s.Compact(2))
+
+	s, err = NewStorage(db, 1)
+	require.Nil(t, err)
+	ghs, gcs, err := s.InitialState()
+	require.Nil(t, err)
+	assert.Equal(t, hs, ghs)
+	assert.Equal(t, cs, gcs)
+	first, _ := s.FirstIndex()
+	last, _ := s.LastIndex()
+	assert.Equal(t, uint64(3), first)
+	assert.Equal(t, uint64(4), last)
+	term, err := s.Term(2)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1), term)
+	_, err = s.Term(1)
+	assert.Equal(t, raft.ErrCompacted, err)
+	ents, err := s.Entries(3, 5)
+	require.Nil(t, err)
+	assert.Equal(t, []pb.Entry{{Index: 3, Term: 2}, {Index: 4, Term: 2}}, ents)
+
+	snap, err := s.Snapshot()
+	require.Nil(t, err)
+	assert.Equal(t, []byte("data"), snap.Data)
+	assert.Equal(t, uint64(3), snap.Metadata.Index)
+	assert.Equal(t, uint64(2), snap.Metadata.Term)
+	assert.Equal(t, cs, *snap.Metadata.ConfState)
+	_, err = s.CreateSnapshot(3, nil, nil)
+	assert.Equal(t, raft.ErrSnapOutOfDate, err)
+}
+
+func TestStorageApplySnapshot(t *testing.T) {
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+	s, err := NewStorage(db, 1)
+	require.Nil(t, err)
+	require.Nil(t, s.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}}))
+
+	cs := pb.ConfState{Nodes: []uint64{1, 2}}
+	snap := pb.Snapshot{Data: []byte("data"), Metadata: &pb.SnapshotMetadata{Index: 5, Term: 3, ConfState: &cs}}
+	require.Nil(t, s.ApplySnapshot(snap))
+	assert.Equal(t, raft.ErrSnapOutOfDate, s.ApplySnapshot(snap))
+
+	s, err = NewStorage(db, 1)
+	require.Nil(t, err)
+	_, gcs, _ := s.InitialState()
+	assert.Equal(t, cs, gcs)
+	first, _ := s.FirstIndex()
+	last, _ := s.LastIndex()
+	assert.Equal(t, uint64(6), first)
+	assert.Equal(t, uint64(5), last)
+	term, err := s.Term(5)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(3), term)
+	_, err = s.Entries(2, 3)
+	assert.Equal(t, raft.ErrCompacted, err)
+
+	// The log goes on after the snapshot.
+	require.Nil(t, s.Append([]pb.Entry{{Index: 6, Term: 3}}))
+	ents, err := s.Entries(6, 7)
+	require.Nil(t, err)
+	assert.Equal(t, []pb.Entry{{Index: 6, Term: 3}}, ents)
+}
+
+// TestStorageRawNode drives a single node group on the storage and restarts
+// it from what was persisted.
+func TestStorageRawNode(t *testing.T) {
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+	s, err := NewStorage(db, 1)
+	require.Nil(t, err)
+	require.Nil(t, s.SetConfState(pb.ConfState{Nodes: []uint64{1}}))
+
+	newRawNode := func() *raft.RawNode {
+		rn, err := raft.NewRawNode(&raft.Config{
+			ID:            1,
+			ElectionTick:  10,
+			HeartbeatTick: 1,
+			Storage:       s,
+		})
+		require.Nil(t, err)
+		return rn
+	}
+	handleReady := func(rn *raft.RawNode) {
+		for rn.HasReady() {
+			rd := rn.Ready()
+			require.Nil(t, s.Append(rd.Entries))
+			if !raft.IsEmptyHardState(rd.HardState) {
+				require.Nil(t, s.SetHardState(rd.HardState))
+			}
+			rn.Advance(rd)
+		}
+	}
+
+	rn := newRawNode()
+	require.Nil(t, rn.Campaign())
+	handleReady(rn)
+	require.Nil(t, rn.Propose([]byte("foo")))
+	handleReady(rn)
+
+	s, err = NewStorage(db, 1)
+	require.Nil(t, err)
+	hs, _, _ := s.InitialState()
+	last, _ := s.LastIndex()
+	assert.Equal(t, last, hs.Commit)
+	ents, err := s.Entries(last, last+1)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("foo"), ents[0].Data)
+
+	rn = newRawNode()
+	assert.Equal(t, hs, rn.Status().HardState)
+}
@@ -0,0 +1,148 @@
+// Package txnbuf buffers the writes of a transaction on the client until it
+// is committed with 2PC. Reads within the transaction see its own writes, and
+// the writes of a failed statement can be rolled back to a savepoint without
+// aborting the whole transaction, as SQL layers expect.
+package txnbuf
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+)
+
+// ErrInvalidSavepoint is returned by RollbackTo for a savepoint which was
+// rolled back already or doesn't belong to the buffer.
+var ErrInvalidSavepoint = errors.New("txnbuf: invalid savepoint")
+
+// Savepoint marks a state of a Buffer the writes can be rolled back to.
+type Savepoint int
+
+type entry struct {
+	value   []byte
+	deleted bool
+}
+
+// undo restores the entry of key as it was before a write.
+type undo struct {
+	key   string
+	prev  entry
+	found bool
+}
+
+// Buffer holds the writes of a transaction, the last write of a key wins.
+// A Buffer is not safe for concurrent use.
+type Buffer struct {
+	entries map[string]entry
+	// undos records how to revert every write, in order, back to the
+	// oldest savepoint still valid.
+	undos []undo
+	// savepoints are the lengths of undos when each savepoint was taken.
+	savepoints []int
+	size       int
+}
+
+func New() *Buffer {
+	return &Buffer{entries: make(map[string]entry)}
+}
+
+// Set buffers a put of key.
+func (b *Buffer) Set(key, value []byte) {
+	b.write(key, entry{value: value})
+}
+
+// Delete buffers a delete of key.
+func (b *Buffer) Delete(key []byte) {
+	b.write(key, entry{deleted: true})
+}
+
+func (b *Buffer) write(key []byte, e entry) {
+	k := string(key)
+	prev, found := b.entries[k]
+	if len(b.savepoints) > 0 {
+		b.undos = append(b.undos, undo{key: k, prev: prev, found: found})
+	}
+	if found {
+		b.size -= len(prev.value)
+	} else {
+		b.size += len(k)
+	}
+	b.size += len(e.value)
+	b.entries[k] = e
+}
+
+// Get returns the buffered write of key: its value, or deleted if the
+// transaction deleted it. found is false if the transaction didn't write key,
+// the value must be read from the store then.
+func (b *Buffer) Get(key []byte) (value []byte, deleted, found bool) {
+	e, found := b.entries[string(key)]
+	return e.value, e.deleted, found
+}
+
+// Savepoint returns a savepoint of the current writes.
+func (b *Buffer) Savepoint() Savepoint {
+	b.savepoints = append(b.savepoints, len(b.undos))
+	return Savepoint(len(b.savepoints) - 1)
+}
+
+// RollbackTo reverts the writes made since sp, like a failed statement. sp
+// and the savepoints taken after it are released.
+func (b *Buffer) RollbackTo(sp Savepoint) error {
+	if sp < 0 || int(sp) >= len(b.savepoints) {
+		return ErrInvalidSavepoint
+	}
+	mark := b.savepoints[sp]
+	for i := len(b.undos) - 1; i >= mark; i-- {
+		u := b.undos[i]
+		if cur, ok := b.entries[u.key]; ok {
+			b.size -= len(u.key) + len(cur.value)
+		}
+		if u.found {
+			b.entries[u.key] = u.prev
+			b.size += len(u.key) + len(u.prev.value)
+		} else {
+			delete(b.entries, u.key)
+		}
+	}
+	b.undos = b.undos[:mark]
+	b.savepoints = b.savepoints[:sp]
+	return nil
+}
+
+// Release releases sp and the savepoints taken after it, keeping the writes
+// made since, like a statement which succeeded.
+func (b *Buffer) Release(sp Savepoint) error {
+	if sp < 0 || int(sp) >= len(b.savepoints) {
+		return ErrInvalidSavepoint
+	}
+	b.savepoints = b.savepoints[:sp]
+	if len(b.savepoints) == 0 {
+		b.undos = b.undos[:0]
+	}
+	return nil
+}
+
+// Len returns the number of keys written.
+func (b *Buffer) Len() int {
+	return len(b.entries)
+}
+
+// Size returns the size of the keys and values written.
+func (b *Buffer) Size() int {
+	return b.size
+}
+
+// Mutations returns the writes to prewrite, sorted by key.
+func (b *Buffer) Mutations() []*kvrpcpb.Mutation {
+	muts := make([]*kvrpcpb.Mutation, 0, len(b.entries))
+	for k, e := range b.entries {
+		m := &kvrpcpb.Mutation{Op: kvrpcpb.Op_Put, Key: []byte(k), Value: e.value}
+		if e.deleted {
+			m.Op = kvrpcpb.Op_Del
+		}
+		muts = append(muts, m)
+	}
+	sort.Slice(muts, func(i, j int) bool { return bytes.Compare(muts[i].Key, muts[j].Key) < 0 })
+	return muts
+}
@@ -0,0 +1,79 @@
+package txnbuf
+
+import (
+	"testing"
+
+	"github.com/pingcap-incubator/tinykv/proto/pkg/kvrpcpb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferReadYourWrites(t *testing.T) {
+	b := New()
+	_, _, found := b.Get([]byte("a"))
+	assert.False(t, found)
+
+	b.Set([]byte("a"), []byte("1"))
+	b.Set([]byte("a"), []byte("2"))
+	value, deleted, found := b.Get([]byte("a"))
+	assert.True(t, found)
+	assert.False(t, deleted)
+	assert.Equal(t, []byte("2"), value)
+
+	b.Delete([]byte("a"))
+	_, deleted, found = b.Get([]byte("a"))
+	assert.True(t, found)
+	assert.True(t, deleted)
+	assert.Equal(t, 1, b.Len())
+	assert.Equal(t, 1, b.Size())
+}
+
+func TestBufferRollbackTo(t *testing.T) {
+	b := New()
+	b.Set([]byte("a"), []byte("1"))
+
+	sp := b.Savepoint()
+	b.Set([]byte("a"), []byte("22"))
+	b.Set([]byte("b"), []byte("3"))
+	inner := b.Savepoint()
+	b.Delete([]byte("a"))
+	assert.Nil(t, b.RollbackTo(inner))
+	value, deleted, _ := b.Get([]byte("a"))
+	assert.False(t, deleted)
+	assert.Equal(t, []byte("22"), value)
+	assert.Equal(t, ErrInvalidSavepoint, b.RollbackTo(inner))
+
+	assert.Nil(t, b.RollbackTo(sp))
+	value, _, _ = b.Get([]byte("a"))
+	assert.Equal(t, []byte("1"), value)
+	_, _, found := b.Get([]byte("b"))
+	assert.False(t, found)
+	assert.Equal(t, 1, b.Len())
+	assert.Equal(t, 2, b.Size())
+}
+
+func TestBufferRelease(t *testing.T) {
+	b := New()
+	sp := b.Savepoint()
+	b.Set([]byte("a"), []byte("1"))
+	assert.Nil(t, b.Release(sp))
+	assert.Equal(t, ErrInvalidSavepoint, b.RollbackTo(sp))
+
+	sp = b.Savepoint()
+	b.Delete([]byte("a"))
+	assert.Nil(t, b.RollbackTo(sp))
+	value, _, _ := b.Get([]byte("a"))
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestBufferMutations(t *testing.T) {
+	b := New()
+	b.Set([]byte("c"), []byte("3"))
+	b.Delete([]byte("a"))
+	b.Set([]byte("b"), []byte("2"))
+	muts := b.Mutations()
+	assert.Equal(t, []*kvrpcpb.Mutation{
+		{Op: kvrpcpb.Op_Del, Key: []byte("a")},
+		{Op: kvrpcpb.Op_Put, Key: []byte("b"), Value: []byte("2")},
+		{Op: kvrpcpb.Op_Put, Key: []byte("c"), Value: []byte("3")},
+	}, muts)
+}
@@ -0,0 +1,615 @@
+package wal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/raft"
+)
+
+// entryLoc locates the record of an entry in the segments.
+type entryLoc struct {
+	term uint64
+	seq  uint64
+	off  int64
+	size int64
+}
+
+// Storage is a raft.SnapshotStorage keeping the log of a raft group in a
+// directory of segments. Every change is synced to disk before the method
+// returns. It is safe for concurrent use, like raft.MemoryStorage, whose
+// methods it mirrors. The entries are read from the segments, only their
+// locations are kept in memory.
+type Storage struct {
+	dir         string
+	segmentSize int64
+
+	mu        sync.Mutex
+	segments  []*segment
+	hardState pb.HardState
+	confState pb.ConfState
+	// snapMeta is the metadata of the snapshot of the snap file, its data is
+	// only read by Snapshot.
+	snapMeta  pb.SnapshotMetadata
+	truncated pb.Entry
+	// locs locates the entries after truncated.
+	locs []entryLoc
+}
+
+var _ raft.SnapshotStorage = new(Storage)
+
+// Open returns the storage of the log in dir, replaying the segments written
+// by a previous storage if any. A record torn by a crash at the end of the
+// last segment is discarded, one in another segment is an error. A
+// segmentSize of 0 means DefaultSegmentSize.
+func Open(dir string, segmentSize int64) (*Storage, error) {
+	if segmentSize <= 0 {
+		segmentSize = DefaultSegmentSize
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	s := &Storage{dir: dir, segmentSize: segmentSize}
+	if err := s.load(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Storage) load() error {
+	snap, err := readSnapFile(s.dir)
+	if err != nil {
+		return err
+	}
+	if snap.Metadata != nil {
+		s.snapMeta = *snap.Metadata
+	}
+
+	seqs, err := listSegments(s.dir)
+	if err != nil {
+		return err
+	}
+	for i, seq := range seqs {
+		name := filepath.Join(s.dir, segmentName(seq))
+		f, err := os.OpenFile(name, os.O_RDWR, 0644)
+		if err != nil {
+			return err
+		}
+		seg := &segment{seq: seq, f: f}
+		s.segments = append(s.segments, seg)
+		buf, err := ioutil.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		for seg.size < int64(len(buf)) {
+			r, n, err := decodeRecord(buf[seg.size:])
+			if err == errTornRecord && i == len(seqs)-1 {
+				// The tail the last write didn't finish.
+				if err := f.Truncate(seg.size); err != nil {
+					return err
+				}
+				break
+			} else if err != nil {
+				return fmt.Errorf("%v in %s at %d", err, name, seg.size)
+			}
+			if err := s.replay(r, entryLoc{seq: seq, off: seg.size, size: int64(n)}); err != nil {
+				return err
+			}
+			seg.size += int64(n)
+		}
+	}
+
+	// A snapshot not matching the log was being applied by ApplySnapshot.
+	if s.snapMeta.Index > s.truncated.Index {
+		if term, err := s.term(s.snapMeta.Index); err != nil || term != s.snapMeta.Term {
+			return s.reset(s.snapMeta)
+		}
+	}
+	return nil
+}
+
+// replay applies the record to the state, loc locates the record.
+func (s *Storage) replay(r record, loc entryLoc) error {
+	switch r.typ {
+	case recordEntry:
+		var ent pb.Entry
+		if err := proto.Unmarshal(r.data, &ent); err != nil {
+			return err
+		}
+		loc.term = ent.Term
+		if ent.Index > s.lastIndex()+1 {
+			// The entries before it are in the segments removed after
+			// they were compacted, a compaction record follows.
+			s.truncated = pb.Entry{Index: ent.Index - 1}
+			s.locs = nil
+		}
+		s.appendLoc(ent.Index, loc)
+	case recordHardState:
+		return proto.Unmarshal(r.data, &s.hardState)
+	case recordConfState:
+		s.confState = pb.ConfState{}
+		return proto.Unmarshal(r.data, &s.confState)
+	case recordCompact:
+		var truncated pb.Entry
+		if err := proto.Unmarshal(r.data, &truncated); err != nil {
+			return err
+		}
+		s.compactLocs(truncated)
+	case recordReset:
+		var meta pb.SnapshotMetadata
+		if err := proto.Unmarshal(r.data, &meta); err != nil {
+			return err
+		}
+		s.resetLocs(meta)
+	default:
+		return fmt.Errorf("wal: unknown record type %d", r.typ)
+	}
+	return nil
+}
+
+func (s *Storage) lastIndex() uint64 {
+	return s.truncated.Index + uint64(len(s.locs))
+}
+
+// appendLoc records the entry at index, replacing those from it on.
+func (s *Storage) appendLoc(index uint64, loc entryLoc) {
+	if index <= s.truncated.Index {
+		return
+	}
+	s.locs = append(s.locs[:index-s.truncated.Index-1], loc)
+}
+
+// compactLocs discards the entries up to truncated. The term of truncated is
+// taken even at the current index, replay doesn't know it after a gap.
+func (s *Storage) compactLocs(truncated pb.Entry) {
+	if truncated.Index < s.truncated.Index {
+		return
+	}
+	if truncated.Index >= s.lastIndex() {
+		s.locs = nil
+	} else {
+		s.locs = append([]entryLoc(nil), s.locs[truncated.Index-s.truncated.Index:]...)
+	}
+	s.truncated = truncated
+}
+
+// resetLocs discards all the entries for the snapshot of meta.
+func (s *Storage) resetLocs(meta pb.SnapshotMetadata) {
+	s.truncated = pb.Entry{Index: meta.Index, Term: meta.Term}
+	s.locs = nil
+	s.confState = pb.ConfState{}
+	if meta.ConfState != nil {
+		s.confState = *meta.ConfState
+	}
+}
+
+// write appends the records to the last segment, starting a new one first if
+// it is full, and syncs it. It returns the locations of the records.
+func (s *Storage) write(recs ...record) ([]entryLoc, error) {
+	if len(s.segments) == 0 || s.segments[len(s.segments)-1].size >= s.segmentSize {
+		if err := s.rotate(); err != nil {
+			return nil, err
+		}
+	}
+	seg := s.segments[len(s.segments)-1]
+	var buf []byte
+	locs := make([]entryLoc, len(recs))
+	off := seg.size
+	for i, r := range recs {
+		buf = r.encodeTo(buf)
+		locs[i] = entryLoc{seq: seg.seq, off: off, size: r.size()}
+		off += r.size()
+	}
+	if _, err := seg.f.WriteAt(buf, seg.size); err != nil {
+		return nil, err
+	}
+	if err := seg.f.Sync(); err != nil {
+		return nil, err
+	}
+	seg.size = off
+	return locs, nil
+}
+
+// rotate starts a new segment with the current state, so the segments before
+// it are not needed to know it.
+func (s *Storage) rotate() error {
+	var seq uint64
+	if len(s.segments) > 0 {
+		seq = s.segments[len(s.segments)-1].seq + 1
+	}
+	var recs []record
+	for _, m := range []struct {
+		typ byte
+		msg proto.Message
+	}{
+		{recordHardState, &s.hardState},
+		{recordConfState, &s.confState},
+		{recordCompact, &s.truncated},
+	} {
+		r, err := newRecord(m.typ, m.msg)
+		if err != nil {
+			return err
+		}
+		recs = append(recs, r)
+	}
+	var buf []byte
+	for _, r := range recs {
+		buf = r.encodeTo(buf)
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, segmentName(seq)), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := syncDir(s.dir); err != nil {
+		f.Close()
+		return err
+	}
+	s.segments = append(s.segments, &segment{seq: seq, f: f, size: int64(len(buf))})
+	return nil
+}
+
+// removeSegments removes the segments before the last one which hold no
+// entry after truncated. Only the oldest are removed, so the replay of the
+// others still discards the entries they replaced.
+func (s *Storage) removeSegments() error {
+	keep := s.segments[len(s.segments)-1].seq
+	for _, loc := range s.locs {
+		if loc.seq < keep {
+			keep = loc.seq
+		}
+	}
+	removed := 0
+	for _, seg := range s.segments {
+		if seg.seq >= keep {
+			break
+		}
+		seg.f.Close()
+		if err := os.Remove(filepath.Join(s.dir, segmentName(seg.seq))); err != nil {
+			return err
+		}
+		removed++
+	}
+	if removed == 0 {
+		return nil
+	}
+	s.segments = append([]*segment(nil), s.segments[removed:]...)
+	return syncDir(s.dir)
+}
+
+// Close closes the segments.
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	for _, seg := range s.segments {
+		if e := seg.f.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	s.segments = nil
+	return err
+}
+
+// InitialState implements the raft.Storage interface.
+func (s *Storage) InitialState() (pb.HardState, pb.ConfState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hardState, s.confState, nil
+}
+
+// SetHardState saves the current HardState.
+func (s *Storage) SetHardState(st pb.HardState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, err := newRecord(recordHardState, &st)
+	if err != nil {
+		return err
+	}
+	if _, err := s.write(r); err != nil {
+		return err
+	}
+	s.hardState = st
+	return nil
+}
+
+// SetConfState saves the ConfState of the group, which is the result of the
+// last ApplyConfChange, so it is known on restart even if no snapshot was
+// created since.
+func (s *Storage) SetConfState(cs pb.ConfState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, err := newRecord(recordConfState, &cs)
+	if err != nil {
+		return err
+	}
+	if _, err := s.write(r); err != nil {
+		return err
+	}
+	s.confState = cs
+	return nil
+}
+
+// Entries implements the raft.Storage interface.
+func (s *Storage) Entries(lo, hi uint64) ([]pb.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lo <= s.truncated.Index {
+		return nil, raft.ErrCompacted
+	}
+	if lo > hi {
+		return nil, fmt.Errorf("wal: invalid entries range [%d, %d)", lo, hi)
+	}
+	if hi > s.lastIndex()+1 {
+		return nil, raft.ErrUnavailable
+	}
+	if lo == hi {
+		return nil, nil
+	}
+
+	ents := make([]pb.Entry, 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		ent, err := s.readEntry(s.locs[i-s.truncated.Index-1])
+		if err != nil {
+			return nil, err
+		}
+		if ent.Index != i {
+			return nil, fmt.Errorf("wal: found entry %d in place of %d", ent.Index, i)
+		}
+		ents = append(ents, ent)
+	}
+	return ents, nil
+}
+
+func (s *Storage) readEntry(loc entryLoc) (pb.Entry, error) {
+	var ent pb.Entry
+	var seg *segment
+	for _, sg := range s.segments {
+		if sg.seq == loc.seq {
+			seg = sg
+			break
+		}
+	}
+	if seg == nil {
+		return ent, fmt.Errorf("wal: segment %d is missing", loc.seq)
+	}
+	buf := make([]byte, loc.size)
+	if _, err := seg.f.ReadAt(buf, loc.off); err != nil {
+		return ent, err
+	}
+	r, _, err := decodeRecord(buf)
+	if err != nil {
+		return ent, fmt.Errorf("%v in segment %d at %d", err, loc.seq, loc.off)
+	}
+	if err := proto.Unmarshal(r.data, &ent); err != nil {
+		return ent, err
+	}
+	if err := raft.CheckEntry(&ent); err != nil {
+		return ent, err
+	}
+	return ent, nil
+}
+
+// Term implements the raft.Storage interface.
+func (s *Storage) Term(i uint64) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.term(i)
+}
+
+func (s *Storage) term(i uint64) (uint64, error) {
+	if i < s.truncated.Index {
+		return 0, raft.ErrCompacted
+	}
+	if i == s.truncated.Index {
+		return s.truncated.Term, nil
+	}
+	if i > s.lastIndex() {
+		return 0, raft.ErrUnavailable
+	}
+	return s.locs[i-s.truncated.Index-1].term, nil
+}
+
+// LastIndex implements the raft.Storage interface.
+func (s *Storage) LastIndex() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastIndex(), nil
+}
+
+// FirstIndex implements the raft.Storage interface.
+func (s *Storage) FirstIndex() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.truncated.Index + 1, nil
+}
+
+// Snapshot implements the raft.Storage interface.
+func (s *Storage) Snapshot() (pb.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snap, err := readSnapFile(s.dir)
+	if err != nil {
+		return pb.Snapshot{}, err
+	}
+	if snap.Metadata == nil {
+		snap.Metadata = &pb.SnapshotMetadata{ConfState: &pb.ConfState{}}
+	}
+	return snap, nil
+}
+
+// Append persists the new entries, which must be continuous. Those already
+// compacted are skipped, and those conflicting with the entries of the
+// storage replace them along with the entries after them.
+func (s *Storage) Append(entries []pb.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Index != entries[i-1].Index+1 {
+			return fmt.Errorf("wal: entries are not continuous [%d after %d]",
+				entries[i].Index, entries[i-1].Index)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	first := s.truncated.Index + 1
+	last := entries[len(entries)-1].Index
+	// shortcut if there is no new entry.
+	if last < first {
+		return nil
+	}
+	// truncate compacted entries
+	if first > entries[0].Index {
+		entries = entries[first-entries[0].Index:]
+	}
+	if entries[0].Index > s.lastIndex()+1 {
+		return fmt.Errorf("wal: missing log entry [last: %d, append at: %d]",
+			s.lastIndex(), entries[0].Index)
+	}
+
+	recs := make([]record, len(entries))
+	for i := range entries {
+		r, err := newRecord(recordEntry, &entries[i])
+		if err != nil {
+			return err
+		}
+		recs[i] = r
+	}
+	locs, err := s.write(recs...)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		locs[i].term = entries[i].Term
+		s.appendLoc(entries[i].Index, locs[i])
+	}
+	return nil
+}
+
+// Compact discards all log entries prior to compactIndex, and removes the
+// segments left without entries.
+// It is the application's responsibility to not attempt to compact an index
+// greater than raftLog.applied.
+func (s *Storage) Compact(compactIndex uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if compactIndex <= s.truncated.Index {
+		return raft.ErrCompacted
+	}
+	if compactIndex > s.lastIndex() {
+		return raft.ErrUnavailable
+	}
+	term, err := s.term(compactIndex)
+	if err != nil {
+		return err
+	}
+
+	truncated := pb.Entry{Index: compactIndex, Term: term}
+	r, err := newRecord(recordCompact, &truncated)
+	if err != nil {
+		return err
+	}
+	if _, err := s.write(r); err != nil {
+		return err
+	}
+	s.compactLocs(truncated)
+	return s.removeSegments()
+}
+
+// CreateSnapshot makes a snapshot which can be retrieved with Snapshot() and
+// can be used to reconstruct the state at that point. The entries are kept
+// until they are compacted.
+// If any configuration changes have been made since the last compaction,
+// the result of the last ApplyConfChange must be passed in.
+func (s *Storage) CreateSnapshot(i uint64, cs *pb.ConfState, data []byte) (pb.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i <= s.snapMeta.Index {
+		return pb.Snapshot{}, raft.ErrSnapOutOfDate
+	}
+	if i > s.lastIndex() {
+		return pb.Snapshot{}, raft.ErrUnavailable
+	}
+	term, err := s.term(i)
+	if err != nil {
+		return pb.Snapshot{}, err
+	}
+
+	confState := s.confState
+	if cs != nil {
+		r, err := newRecord(recordConfState, cs)
+		if err != nil {
+			return pb.Snapshot{}, err
+		}
+		if _, err := s.write(r); err != nil {
+			return pb.Snapshot{}, err
+		}
+		confState = *cs
+		s.confState = confState
+	}
+	snap := pb.Snapshot{
+		Data: data,
+		Metadata: &pb.SnapshotMetadata{
+			Index:     i,
+			Term:      term,
+			ConfState: &confState,
+		},
+	}
+	if err := writeSnapFile(s.dir, &snap); err != nil {
+		return pb.Snapshot{}, err
+	}
+	s.snapMeta = *snap.Metadata
+	return snap, nil
+}
+
+// ApplySnapshot overwrites the contents of this Storage object with
+// those of the given snapshot.
+func (s *Storage) ApplySnapshot(snap pb.Snapshot) error {
+	if snap.Metadata == nil || snap.Metadata.ConfState == nil {
+		return fmt.Errorf("wal: snapshot without metadata")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.snapMeta.Index >= snap.Metadata.Index {
+		return raft.ErrSnapOutOfDate
+	}
+
+	// The snap file goes first, Open completes the reset if the store
+	// crashes before it is logged.
+	if err := writeSnapFile(s.dir, &snap); err != nil {
+		return err
+	}
+	s.snapMeta = *snap.Metadata
+	return s.reset(*snap.Metadata)
+}
+
+// reset logs the reset of the log to the snapshot of meta and applies it.
+func (s *Storage) reset(meta pb.SnapshotMetadata) error {
+	r, err := newRecord(recordReset, &meta)
+	if err != nil {
+		return err
+	}
+	if _, err := s.write(r); err != nil {
+		return err
+	}
+	s.resetLocs(meta)
+	return s.removeSegments()
+}
@@ -0,0 +1,255 @@
+package wal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+	"github.com/pingcap-incubator/tinykv/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "wal")
+	require.Nil(t, err)
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func reopen(t *testing.T, s *Storage, dir string, segmentSize int64) *Storage {
+	require.Nil(t, s.Close())
+	s, err := Open(dir, segmentSize)
+	require.Nil(t, err)
+	return s
+}
+
+func TestStorageAppend(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	s, err := Open(dir, 0)
+	require.Nil(t, err)
+	defer s.Close()
+
+	require.Nil(t, s.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 2}}))
+	// A conflict replaces the entries from it on.
+	require.Nil(t, s.Append([]pb.Entry{{Index: 2, Term: 3}}))
+	ents, err := s.Entries(1, 3)
+	require.Nil(t, err)
+	assert.Equal(t, []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 3}}, ents)
+	last, _ := s.LastIndex()
+	assert.Equal(t, uint64(2), last)
+	_, err = s.Entries(1, 4)
+	assert.Equal(t, raft.ErrUnavailable, err)
+
+	assert.NotNil(t, s.Append([]pb.Entry{{Index: 4, Term: 3}}))
+	assert.NotNil(t, s.Append([]pb.Entry{{Index: 3, Term: 3}, {Index: 5, Term: 3}}))
+
+	// The conflict is resolved the same way on replay.
+	s = reopen(t, s, dir, 0)
+	ents, err = s.Entries(1, 3)
+	require.Nil(t, err)
+	assert.Equal(t, []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 3}}, ents)
+}
+
+func TestStorageRestart(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	s, err := Open(dir, 0)
+	require.Nil(t, err)
+
+	hs := pb.HardState{Term: 2, Vote: 1, Commit: 4}
+	cs := pb.ConfState{Nodes: []uint64{1, 2, 3}}
+	require.Nil(t, s.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}, {Index: 3, Term: 2}, {Index: 4, Term: 2}}))
+	require.Nil(t, s.SetHardState(hs))
+	require.Nil(t, s.SetConfState(cs))
+	_, err = s.CreateSnapshot(3, nil, []byte("data"))
+	require.Nil(t, err)
+	require.Nil(t, s.Compact(2))
+
+	s = reopen(t, s, dir, 0)
+	defer s.Close()
+	ghs, gcs, err := s.InitialState()
+	require.Nil(t, err)
+	assert.Equal(t, hs, ghs)
+	assert.Equal(t, cs, gcs)
+	first, _ := s.FirstIndex()
+	last, _ := s.LastIndex()
+	assert.Equal(t, uint64(3), first)
+	assert.Equal(t, uint64(4), last)
+	term, err := s.Term(2)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1), term)
+	_, err = s.Term(1)
+	assert.Equal(t, raft.ErrCompacted, err)
+	ents, err := s.Entries(3, 5)
+	require.Nil(t, err)
+	assert.Equal(t, []pb.Entry{{Index: 3, Term: 2}, {Index: 4, Term: 2}}, ents)
+
+	snap, err := s.Snapshot()
+	require.Nil(t, err)
+	assert.Equal(t, []byte("data"), snap.Data)
+	assert.Equal(t, uint64(3), snap.Metadata.Index)
+	assert.Equal(t, uint64(2), snap.Metadata.Term)
+	assert.Equal(t, cs, *snap.Metadata.ConfState)
+	_, err = s.CreateSnapshot(3, nil, nil)
+	assert.Equal(t, raft.ErrSnapOutOfDate, err)
+}
+
+// TestStorageSegments checks that the log goes on in new segments, and that
+// the compacted ones are removed.
+func TestStorageSegments(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	s, err := Open(dir, 256)
+	require.Nil(t, err)
+
+	hs := pb.HardState{Term: 3, Commit: 90}
+	data := make([]byte, 64)
+	for i := uint64(1); i <= 100; i++ {
+		require.Nil(t, s.Append([]pb.Entry{{Index: i, Term: 1 + i/50, Data: data}}))
+	}
+	require.Nil(t, s.SetHardState(hs))
+	seqs, err := listSegments(dir)
+	require.Nil(t, err)
+	assert.True(t, len(seqs) > 10)
+
+	require.Nil(t, s.Compact(90))
+	after, err := listSegments(dir)
+	require.Nil(t, err)
+	assert.True(t, len(after) < 10)
+	assert.True(t, after[0] > seqs[0])
+
+	s = reopen(t, s, dir, 256)
+	defer s.Close()
+	ghs, _, _ := s.InitialState()
+	assert.Equal(t, hs, ghs)
+	first, _ := s.FirstIndex()
+	last, _ := s.LastIndex()
+	assert.Equal(t, uint64(91), first)
+	assert.Equal(t, uint64(100), last)
+	term, err := s.Term(90)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(2), term)
+	ents, err := s.Entries(91, 101)
+	require.Nil(t, err)
+	assert.Len(t, ents, 10)
+	assert.Equal(t, uint64(91), ents[0].Index)
+	assert.Equal(t, data, ents[0].Data)
+}
+
+// TestStorageTornRecord checks that a record cut short by a crash at the end
+// of the log is dropped.
+func TestStorageTornRecord(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	s, err := Open(dir, 0)
+	require.Nil(t, err)
+	require.Nil(t, s.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1, Data: []byte("foo")}}))
+	require.Nil(t, s.Close())
+
+	seqs, err := listSegments(dir)
+	require.Nil(t, err)
+	name := filepath.Join(dir, segmentName(seqs[len(seqs)-1]))
+	fi, err := os.Stat(name)
+	require.Nil(t, err)
+	require.Nil(t, os.Truncate(name, fi.Size()-2))
+
+	s, err = Open(dir, 0)
+	require.Nil(t, err)
+	last, _ := s.LastIndex()
+	assert.Equal(t, uint64(1), last)
+	// The log goes on after the torn record.
+	require.Nil(t, s.Append([]pb.Entry{{Index: 2, Term: 2}}))
+	s = reopen(t, s, dir, 0)
+	defer s.Close()
+	ents, err := s.Entries(1, 3)
+	require.Nil(t, err)
+	assert.Equal(t, []pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 2}}, ents)
+}
+
+func TestStorageApplySnapshot(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	s, err := Open(dir, 0)
+	require.Nil(t, err)
+	require.Nil(t, s.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 1}}))
+
+	cs := pb.ConfState{Nodes: []uint64{1, 2}}
+	snap := pb.Snapshot{Data: []byte("data"), Metadata: &pb.SnapshotMetadata{Index: 5, Term: 3, ConfState: &cs}}
+	require.Nil(t, s.ApplySnapshot(snap))
+	assert.Equal(t, raft.ErrSnapOutOfDate, s.ApplySnapshot(snap))
+
+	s = reopen(t, s, dir, 0)
+	defer s.Close()
+	_, gcs, _ := s.InitialState()
+	assert.Equal(t, cs, gcs)
+	first, _ := s.FirstIndex()
+	last, _ := s.LastIndex()
+	assert.Equal(t, uint64(6), first)
+	assert.Equal(t, uint64(5), last)
+	term, err := s.Term(5)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(3), term)
+	_, err = s.Entries(2, 3)
+	assert.Equal(t, raft.ErrCompacted, err)
+	gsnap, err := s.Snapshot()
+	require.Nil(t, err)
+	assert.Equal(t, snap, gsnap)
+
+	// The log goes on after the snapshot.
+	require.Nil(t, s.Append([]pb.Entry{{Index: 6, Term: 3}}))
+	ents, err := s.Entries(6, 7)
+	require.Nil(t, err)
+	assert.Equal(t, []pb.Entry{{Index: 6, Term: 3}}, ents)
+}
+
+// TestStorageRawNode drives a single node group on the storage and restarts
+// it from what was persisted.
+func TestStorageRawNode(t *testing.T) {
+	dir, cleanup := newTestDir(t)
+	defer cleanup()
+	s, err := Open(dir, 0)
+	require.Nil(t, err)
+	require.Nil(t, s.SetConfState(pb.ConfState{Nodes: []uint64{1}}))
+
+	newRawNode := func() *raft.RawNode {
+		rn, err := raft.NewRawNode(&raft.Config{
+			ID:            1,
+			ElectionTick:  10,
+			HeartbeatTick: 1,
+			Storage:       s,
+		})
+		require.Nil(t, err)
+		return rn
+	}
+	handleReady := func(rn *raft.RawNode) {
+		for rn.HasReady() {
+			rd := rn.Ready()
+			require.Nil(t, s.Append(rd.Entries))
+			if !raft.IsEmptyHardState(rd.HardState) {
+				require.Nil(t, s.SetHardState(rd.HardState))
+			}
+			rn.Advance(rd)
+		}
+	}
+
+	rn := newRawNode()
+	require.Nil(t, rn.Campaign())
+	handleReady(rn)
+	require.Nil(t, rn.Propose([]byte("foo")))
+	handleReady(rn)
+
+	s = reopen(t, s, dir, 0)
+	defer s.Close()
+	hs, _, _ := s.InitialState()
+	last, _ := s.LastIndex()
+	assert.Equal(t, last, hs.Commit)
+	ents, err := s.Entries(last, last+1)
+	require.Nil(t, err)
+	assert.Equal(t, []byte("foo"), ents[0].Data)
+
+	rn = newRawNode()
+	assert.Equal(t, hs, rn.Status().HardState)
+}
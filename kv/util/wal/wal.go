@@ -0,0 +1,196 @@
+// Package wal implements the raft.Storage of a raft group on an append-only
+// write-ahead log of segment files, which appends entries much faster than
+// storing each one as a key of a badger DB, see kv/util/raftlog.
+//
+// The log is a directory of segments, each one a sequence of records checked
+// with a CRC. Every change of the storage is a record appended to the last
+// segment, the state is rebuilt by replaying them in order on Open: an entry
+// replaces the entries from its index on, so a conflict is resolved by
+// appending the new entries. Once the last segment is full a new one is
+// started with the current state, and the segments holding only compacted
+// entries are removed. The last snapshot is kept apart in the snap file.
+package wal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+// DefaultSegmentSize is the size past which a new segment is started.
+const DefaultSegmentSize int64 = 64 << 20
+
+const (
+	segmentExt = ".wal"
+	snapFile   = "snap"
+)
+
+// The types of the records. The payload of each one is a protobuf message.
+const (
+	// recordEntry is a pb.Entry, it replaces the entries from its index on.
+	recordEntry byte = iota + 1
+	recordHardState
+	recordConfState
+	// recordCompact is a pb.Entry with the index and term of the last entry
+	// discarded by a compaction.
+	recordCompact
+	// recordReset is the pb.SnapshotMetadata of an applied snapshot, which
+	// discards all the entries.
+	recordReset
+	// recordSnapshot is the pb.Snapshot of the snap file.
+	recordSnapshot
+)
+
+// A record is made of the length of the payload, the CRC of the type and the
+// payload, the type and the payload.
+const recordHeaderSize = 9
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+type record struct {
+	typ  byte
+	data []byte
+}
+
+func newRecord(typ byte, msg proto.Message) (record, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return record{}, err
+	}
+	return record{typ: typ, data: data}, nil
+}
+
+func (r record) size() int64 {
+	return recordHeaderSize + int64(len(r.data))
+}
+
+func (r record) encodeTo(buf []byte) []byte {
+	var header [recordHeaderSize]byte
+	binary.LittleEndian.PutUint32(header[0:], uint32(len(r.data)))
+	crc := crc32.Update(0, crcTable, []byte{r.typ})
+	binary.LittleEndian.PutUint32(header[4:], crc32.Update(crc, crcTable, r.data))
+	header[8] = r.typ
+	return append(append(buf, header[:]...), r.data...)
+}
+
+// errTornRecord is returned by decodeRecord for a record cut short or not
+// matching its CRC, e.g. as the store crashed while writing it.
+var errTornRecord = fmt.Errorf("wal: torn or corrupted record")
+
+// decodeRecord decodes the record at the start of buf, it returns the record
+// and its size.
+func decodeRecord(buf []byte) (record, int, error) {
+	if len(buf) < recordHeaderSize {
+		return record{}, 0, errTornRecord
+	}
+	n := int(binary.LittleEndian.Uint32(buf[0:]))
+	if n > len(buf)-recordHeaderSize {
+		return record{}, 0, errTornRecord
+	}
+	r := record{typ: buf[8], data: buf[recordHeaderSize : recordHeaderSize+n]}
+	crc := crc32.Update(0, crcTable, []byte{r.typ})
+	if binary.LittleEndian.Uint32(buf[4:]) != crc32.Update(crc, crcTable, r.data) {
+		return record{}, 0, errTornRecord
+	}
+	return r, recordHeaderSize + n, nil
+}
+
+// segment is a file of the log, named after its sequence number.
+type segment struct {
+	seq  uint64
+	f    *os.File
+	size int64
+}
+
+func segmentName(seq uint64) string {
+	return fmt.Sprintf("%016x%s", seq, segmentExt)
+}
+
+// listSegments returns the sequence numbers of the segments in dir, sorted.
+func listSegments(dir string) ([]uint64, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var seqs []uint64
+	for _, fi := range files {
+		name := fi.Name()
+		if !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("wal: unexpected segment %s", name)
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+// syncDir persists the creation and removal of the files of dir.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// writeSnapFile replaces the snap file of dir with snap.
+func writeSnapFile(dir string, snap *pb.Snapshot) error {
+	r, err := newRecord(recordSnapshot, snap)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, snapFile+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(r.encodeTo(nil)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, snapFile)); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// readSnapFile reads the snap file of dir, it returns an empty snapshot if
+// there is none.
+func readSnapFile(dir string) (pb.Snapshot, error) {
+	var snap pb.Snapshot
+	buf, err := ioutil.ReadFile(filepath.Join(dir, snapFile))
+	if os.IsNotExist(err) {
+		return snap, nil
+	} else if err != nil {
+		return snap, err
+	}
+	r, _, err := decodeRecord(buf)
+	if err != nil {
+		return snap, fmt.Errorf("wal: snap file: %v", err)
+	}
+	if err := proto.Unmarshal(r.data, &snap); err != nil {
+		return snap, err
+	}
+	return snap, nil
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Utilization measures the time a pool of goroutines spends handling work,
+// as opposed to waiting for it. It is safe for concurrent use.
+type Utilization struct {
+	workers int
+	busy    int64
+	handled uint64
+}
+
+func NewUtilization(workers int) *Utilization {
+	return &Utilization{workers: workers}
+}
+
+// Record counts n tasks handled by a goroutine of the pool from start to now.
+func (u *Utilization) Record(start time.Time, n int) {
+	atomic.AddInt64(&u.busy, int64(time.Since(start)))
+	atomic.AddUint64(&u.handled, uint64(n))
+}
+
+// Stats returns the counters of the pool, pending is the number of tasks
+// waiting for it.
+func (u *Utilization) Stats(pending int) PoolStats {
+	return PoolStats{
+		Workers: u.workers,
+		Busy:    time.Duration(atomic.LoadInt64(&u.busy)),
+		Handled: atomic.LoadUint64(&u.handled),
+		Pending: pending,
+	}
+}
+
+// PoolStats are the counters of a pool of goroutines, see Utilization.
+type PoolStats struct {
+	Workers int
+	// Busy is the time spent handling tasks by all the goroutines, and
+	// Handled the number of tasks they handled, since the pool started.
+	Busy    time.Duration
+	Handled uint64
+	// Pending is the number of tasks waiting for the pool.
+	Pending int
+}
+
+// Utilization returns the share of the time the goroutines of the pool were
+// busy between prev and s, taken elapsed apart. A pool close to 1 is
+// saturated and needs more goroutines.
+func (s PoolStats) Utilization(prev PoolStats, elapsed time.Duration) float64 {
+	if elapsed <= 0 || s.Workers == 0 {
+		return 0
+	}
+	return float64(s.Busy-prev.Busy) / float64(elapsed*time.Duration(s.Workers))
+}
@@ -22,6 +22,12 @@ type Starter interface {
 	Start()
 }
 
+// Stopper is implemented by handlers which own resources, such as goroutines,
+// to release when the worker stops.
+type Stopper interface {
+	Stop()
+}
+
 func (w *Worker) Start(handler TaskHandler) {
 	w.wg.Add(1)
 	go func() {
@@ -32,6 +38,9 @@ func (w *Worker) Start(handler TaskHandler) {
 		for {
 			Task := <-w.receiver
 			if _, ok := Task.(TaskStop); ok {
+				if s, ok := handler.(Stopper); ok {
+					s.Stop()
+				}
 				return
 			}
 			handler.Handle(Task)
@@ -27,22 +27,30 @@ type EntryType int32
 const (
 	EntryType_EntryNormal     EntryType = 0
 	EntryType_EntryConfChange EntryType = 1
+	// EntryNoOp is the empty entry a new leader appends to commit entries from previous terms.
+	EntryType_EntryNoOp EntryType = 2
+	// EntryConfChangeV2 carries a ConfChangeV2, which may hold several changes.
+	EntryType_EntryConfChangeV2 EntryType = 3
 )
 
 var EntryType_name = map[int32]string{
 	0: "EntryNormal",
 	1: "EntryConfChange",
+	2: "EntryNoOp",
+	3: "EntryConfChangeV2",
 }
 var EntryType_value = map[string]int32{
-	"EntryNormal":     0,
-	"EntryConfChange": 1,
+	"EntryNormal":       0,
+	"EntryConfChange":   1,
+	"EntryNoOp":         2,
+	"EntryConfChangeV2": 3,
 }
 
 func (x EntryType) String() string {
 	return proto.EnumName(EntryType_name, int32(x))
 }
 func (EntryType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{0}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{0}
 }
 
 // Some MessageType defined here are local messages which not come from the network, but should
@@ -77,6 +85,23 @@ const (
 	// 'MessageType_MsgTimeoutNow' send from the leader to the leadership transfer target, to let
 	// the transfer target timeout immediately and start a new election.
 	MessageType_MsgTimeoutNow MessageType = 12
+	// 'MessageType_MsgRequestPreVote' asks whether the sender could win an election in the
+	// next term, without the receivers changing their term or vote.
+	MessageType_MsgRequestPreVote MessageType = 13
+	// 'MessageType_MsgRequestPreVoteResponse' contains responses from pre-vote requests.
+	MessageType_MsgRequestPreVoteResponse MessageType = 14
+	// 'MessageType_MsgReadIndex' asks the leader for the read index of a read-only request,
+	// the request context is carried in the data of the only entry.
+	MessageType_MsgReadIndex MessageType = 15
+	// 'MessageType_MsgReadIndexResp' returns the read index of a 'MessageType_MsgReadIndex'.
+	MessageType_MsgReadIndexResp MessageType = 16
+	// 'MessageType_MsgUnreachable' is a local message telling the leader that a message to the
+	// sender peer failed to be sent, e.g. because the peer is down.
+	MessageType_MsgUnreachable MessageType = 17
+	// 'MessageType_MsgSnapStatus' is a local message telling the leader whether the snapshot
+	// sent to the sender peer was delivered, reject is set if it failed. A follower which
+	// discards a snapshot failing Config.ValidateSnapshot sends it to the leader as well.
+	MessageType_MsgSnapStatus MessageType = 18
 )
 
 var MessageType_name = map[int32]string{
@@ -92,27 +117,39 @@ var MessageType_name = map[int32]string{
 	9:  "MsgHeartbeatResponse",
 	11: "MsgTransferLeader",
 	12: "MsgTimeoutNow",
+	13: "MsgRequestPreVote",
+	14: "MsgRequestPreVoteResponse",
+	15: "MsgReadIndex",
+	16: "MsgReadIndexResp",
+	17: "MsgUnreachable",
+	18: "MsgSnapStatus",
 }
 var MessageType_value = map[string]int32{
-	"MsgHup":                 0,
-	"MsgBeat":                1,
-	"MsgPropose":             2,
-	"MsgAppend":              3,
-	"MsgAppendResponse":      4,
-	"MsgRequestVote":         5,
-	"MsgRequestVoteResponse": 6,
-	"MsgSnapshot":            7,
-	"MsgHeartbeat":           8,
-	"MsgHeartbeatResponse":   9,
-	"MsgTransferLeader":      11,
-	"MsgTimeoutNow":          12,
+	"MsgHup":                    0,
+	"MsgBeat":                   1,
+	"MsgPropose":                2,
+	"MsgAppend":                 3,
+	"MsgAppendResponse":         4,
+	"MsgRequestVote":            5,
+	"MsgRequestVoteResponse":    6,
+	"MsgSnapshot":               7,
+	"MsgHeartbeat":              8,
+	"MsgHeartbeatResponse":      9,
+	"MsgTransferLeader":         11,
+	"MsgTimeoutNow":             12,
+	"MsgRequestPreVote":         13,
+	"MsgRequestPreVoteResponse": 14,
+	"MsgReadIndex":              15,
+	"MsgReadIndexResp":          16,
+	"MsgUnreachable":            17,
+	"MsgSnapStatus":             18,
 }
 
 func (x MessageType) String() string {
 	return proto.EnumName(MessageType_name, int32(x))
 }
 func (MessageType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{1}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{1}
 }
 
 type ConfChangeType int32
@@ -135,7 +172,40 @@ func (x ConfChangeType) String() string {
 	return proto.EnumName(ConfChangeType_name, int32(x))
 }
 func (ConfChangeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{2}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{2}
+}
+
+// ConfChangeTransition tells how a ConfChangeV2 moves the group to the new
+// configuration.
+type ConfChangeTransition int32
+
+const (
+	// Apply the change directly if it touches a single node, and through a
+	// joint configuration that is left automatically otherwise.
+	ConfChangeTransition_ConfChangeTransitionAuto ConfChangeTransition = 0
+	// Go through a joint configuration that is left automatically.
+	ConfChangeTransition_ConfChangeTransitionJointImplicit ConfChangeTransition = 1
+	// Go through a joint configuration that is left by proposing an empty
+	// ConfChangeV2.
+	ConfChangeTransition_ConfChangeTransitionJointExplicit ConfChangeTransition = 2
+)
+
+var ConfChangeTransition_name = map[int32]string{
+	0: "ConfChangeTransitionAuto",
+	1: "ConfChangeTransitionJointImplicit",
+	2: "ConfChangeTransitionJointExplicit",
+}
+var ConfChangeTransition_value = map[string]int32{
+	"ConfChangeTransitionAuto":          0,
+	"ConfChangeTransitionJointImplicit": 1,
+	"ConfChangeTransitionJointExplicit": 2,
+}
+
+func (x ConfChangeTransition) String() string {
+	return proto.EnumName(ConfChangeTransition_name, int32(x))
+}
+func (ConfChangeTransition) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{3}
 }
 
 // The entry is a type of change that needs to be applied. It contains two data fields.
@@ -145,24 +215,32 @@ func (ConfChangeType) EnumDescriptor() ([]byte, []int) {
 // The context field can be used for any contextual data that might be relevant to the
 // application of the data.
 //
-// For configuration changes, the data will contain the ConfChange message and the
+// For configuration changes, the data will contain the ConfChange message, or the
+// ConfChangeV2 message for EntryConfChangeV2 entries, and the
 // context will provide anything needed to assist the configuration change. The context
 // is for the user to set and use in this case.
+//
+// No-op entries carry no data and only need to advance the applied index.
 type Entry struct {
-	EntryType            EntryType `protobuf:"varint,1,opt,name=entry_type,json=entryType,proto3,enum=eraftpb.EntryType" json:"entry_type,omitempty"`
-	Term                 uint64    `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
-	Index                uint64    `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
-	Data                 []byte    `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	EntryType EntryType `protobuf:"varint,1,opt,name=entry_type,json=entryType,proto3,enum=eraftpb.EntryType" json:"entry_type,omitempty"`
+	Term      uint64    `protobuf:"varint,2,opt,name=term,proto3" json:"term,omitempty"`
+	Index     uint64    `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Data      []byte    `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// CRC32 (Castagnoli) of the other fields, 0 if the entry has none.
+	Checksum uint32 `protobuf:"varint,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// Identifies the request proposing the entry, so the leader can collapse
+	// the retries of a proposal still uncommitted. Empty if not set.
+	RequestId            []byte   `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Entry) Reset()         { *m = Entry{} }
 func (m *Entry) String() string { return proto.CompactTextString(m) }
 func (*Entry) ProtoMessage()    {}
 func (*Entry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{0}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{0}
 }
 func (m *Entry) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -219,6 +297,20 @@ func (m *Entry) GetData() []byte {
 	return nil
 }
 
+func (m *Entry) GetChecksum() uint32 {
+	if m != nil {
+		return m.Checksum
+	}
+	return 0
+}
+
+func (m *Entry) GetRequestId() []byte {
+	if m != nil {
+		return m.RequestId
+	}
+	return nil
+}
+
 // SnapshotMetadata contains the log index and term of the last log applied to this
 // Snapshot, along with the membership information of the time the last log applied.
 type SnapshotMetadata struct {
@@ -234,7 +326,7 @@ func (m *SnapshotMetadata) Reset()         { *m = SnapshotMetadata{} }
 func (m *SnapshotMetadata) String() string { return proto.CompactTextString(m) }
 func (*SnapshotMetadata) ProtoMessage()    {}
 func (*SnapshotMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{1}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{1}
 }
 func (m *SnapshotMetadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -296,7 +388,7 @@ func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{2}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{2}
 }
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -340,26 +432,32 @@ func (m *Snapshot) GetMetadata() *SnapshotMetadata {
 }
 
 type Message struct {
-	MsgType              MessageType `protobuf:"varint,1,opt,name=msg_type,json=msgType,proto3,enum=eraftpb.MessageType" json:"msg_type,omitempty"`
-	To                   uint64      `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
-	From                 uint64      `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
-	Term                 uint64      `protobuf:"varint,4,opt,name=term,proto3" json:"term,omitempty"`
-	LogTerm              uint64      `protobuf:"varint,5,opt,name=log_term,json=logTerm,proto3" json:"log_term,omitempty"`
-	Index                uint64      `protobuf:"varint,6,opt,name=index,proto3" json:"index,omitempty"`
-	Entries              []*Entry    `protobuf:"bytes,7,rep,name=entries" json:"entries,omitempty"`
-	Commit               uint64      `protobuf:"varint,8,opt,name=commit,proto3" json:"commit,omitempty"`
-	Snapshot             *Snapshot   `protobuf:"bytes,9,opt,name=snapshot" json:"snapshot,omitempty"`
-	Reject               bool        `protobuf:"varint,10,opt,name=reject,proto3" json:"reject,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	MsgType  MessageType `protobuf:"varint,1,opt,name=msg_type,json=msgType,proto3,enum=eraftpb.MessageType" json:"msg_type,omitempty"`
+	To       uint64      `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	From     uint64      `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	Term     uint64      `protobuf:"varint,4,opt,name=term,proto3" json:"term,omitempty"`
+	LogTerm  uint64      `protobuf:"varint,5,opt,name=log_term,json=logTerm,proto3" json:"log_term,omitempty"`
+	Index    uint64      `protobuf:"varint,6,opt,name=index,proto3" json:"index,omitempty"`
+	Entries  []*Entry    `protobuf:"bytes,7,rep,name=entries" json:"entries,omitempty"`
+	Commit   uint64      `protobuf:"varint,8,opt,name=commit,proto3" json:"commit,omitempty"`
+	Snapshot *Snapshot   `protobuf:"bytes,9,opt,name=snapshot" json:"snapshot,omitempty"`
+	Reject   bool        `protobuf:"varint,10,opt,name=reject,proto3" json:"reject,omitempty"`
+	// context is the read-only request context a leader attaches to its heartbeats,
+	// and the followers echo back in their responses.
+	Context []byte `protobuf:"bytes,11,opt,name=context,proto3" json:"context,omitempty"`
+	// rebuild is set on a MsgSnapshot the receiver must install even if its log already
+	// covers the snapshot, dropping its own state.
+	Rebuild              bool     `protobuf:"varint,12,opt,name=rebuild,proto3" json:"rebuild,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{3}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{3}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -458,6 +556,20 @@ func (m *Message) GetReject() bool {
 	return false
 }
 
+func (m *Message) GetContext() []byte {
+	if m != nil {
+		return m.Context
+	}
+	return nil
+}
+
+func (m *Message) GetRebuild() bool {
+	if m != nil {
+		return m.Rebuild
+	}
+	return false
+}
+
 // HardState contains the state of a node need to be peristed, including the current term, commit index
 // and the vote record
 type HardState struct {
@@ -473,7 +585,7 @@ func (m *HardState) Reset()         { *m = HardState{} }
 func (m *HardState) String() string { return proto.CompactTextString(m) }
 func (*HardState) ProtoMessage()    {}
 func (*HardState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{4}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{4}
 }
 func (m *HardState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -536,7 +648,7 @@ func (m *ConfState) Reset()         { *m = ConfState{} }
 func (m *ConfState) String() string { return proto.CompactTextString(m) }
 func (*ConfState) ProtoMessage()    {}
 func (*ConfState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{5}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{5}
 }
 func (m *ConfState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -587,7 +699,7 @@ func (m *ConfChange) Reset()         { *m = ConfChange{} }
 func (m *ConfChange) String() string { return proto.CompactTextString(m) }
 func (*ConfChange) ProtoMessage()    {}
 func (*ConfChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_eraftpb_2f2e0bcef614736b, []int{6}
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{6}
 }
 func (m *ConfChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -637,6 +749,128 @@ func (m *ConfChange) GetContext() []byte {
 	return nil
 }
 
+// ConfChangeSingle is one of the changes of a ConfChangeV2.
+type ConfChangeSingle struct {
+	ChangeType           ConfChangeType `protobuf:"varint,1,opt,name=change_type,json=changeType,proto3,enum=eraftpb.ConfChangeType" json:"change_type,omitempty"`
+	NodeId               uint64         `protobuf:"varint,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ConfChangeSingle) Reset()         { *m = ConfChangeSingle{} }
+func (m *ConfChangeSingle) String() string { return proto.CompactTextString(m) }
+func (*ConfChangeSingle) ProtoMessage()    {}
+func (*ConfChangeSingle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{7}
+}
+func (m *ConfChangeSingle) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConfChangeSingle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConfChangeSingle.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ConfChangeSingle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfChangeSingle.Merge(dst, src)
+}
+func (m *ConfChangeSingle) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConfChangeSingle) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfChangeSingle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfChangeSingle proto.InternalMessageInfo
+
+func (m *ConfChangeSingle) GetChangeType() ConfChangeType {
+	if m != nil {
+		return m.ChangeType
+	}
+	return ConfChangeType_AddNode
+}
+
+func (m *ConfChangeSingle) GetNodeId() uint64 {
+	if m != nil {
+		return m.NodeId
+	}
+	return 0
+}
+
+// ConfChangeV2 is the data that attach on entry with EntryConfChangeV2 type.
+// It carries any number of changes applied together, an empty one leaves a
+// joint configuration.
+type ConfChangeV2 struct {
+	Transition           ConfChangeTransition `protobuf:"varint,1,opt,name=transition,proto3,enum=eraftpb.ConfChangeTransition" json:"transition,omitempty"`
+	Changes              []*ConfChangeSingle  `protobuf:"bytes,2,rep,name=changes" json:"changes,omitempty"`
+	Context              []byte               `protobuf:"bytes,3,opt,name=context,proto3" json:"context,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConfChangeV2) Reset()         { *m = ConfChangeV2{} }
+func (m *ConfChangeV2) String() string { return proto.CompactTextString(m) }
+func (*ConfChangeV2) ProtoMessage()    {}
+func (*ConfChangeV2) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eraftpb_8ea04ecae3d575f2, []int{8}
+}
+func (m *ConfChangeV2) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConfChangeV2) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConfChangeV2.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ConfChangeV2) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfChangeV2.Merge(dst, src)
+}
+func (m *ConfChangeV2) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConfChangeV2) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfChangeV2.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfChangeV2 proto.InternalMessageInfo
+
+func (m *ConfChangeV2) GetTransition() ConfChangeTransition {
+	if m != nil {
+		return m.Transition
+	}
+	return ConfChangeTransition_ConfChangeTransitionAuto
+}
+
+func (m *ConfChangeV2) GetChanges() []*ConfChangeSingle {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *ConfChangeV2) GetContext() []byte {
+	if m != nil {
+		return m.Context
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Entry)(nil), "eraftpb.Entry")
 	proto.RegisterType((*SnapshotMetadata)(nil), "eraftpb.SnapshotMetadata")
@@ -645,9 +879,12 @@ func init() {
 	proto.RegisterType((*HardState)(nil), "eraftpb.HardState")
 	proto.RegisterType((*ConfState)(nil), "eraftpb.ConfState")
 	proto.RegisterType((*ConfChange)(nil), "eraftpb.ConfChange")
+	proto.RegisterType((*ConfChangeSingle)(nil), "eraftpb.ConfChangeSingle")
+	proto.RegisterType((*ConfChangeV2)(nil), "eraftpb.ConfChangeV2")
 	proto.RegisterEnum("eraftpb.EntryType", EntryType_name, EntryType_value)
 	proto.RegisterEnum("eraftpb.MessageType", MessageType_name, MessageType_value)
 	proto.RegisterEnum("eraftpb.ConfChangeType", ConfChangeType_name, ConfChangeType_value)
+	proto.RegisterEnum("eraftpb.ConfChangeTransition", ConfChangeTransition_name, ConfChangeTransition_value)
 }
 func (m *Entry) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -685,6 +922,17 @@ func (m *Entry) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintEraftpb(dAtA, i, uint64(len(m.Data)))
 		i += copy(dAtA[i:], m.Data)
 	}
+	if m.Checksum != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(m.Checksum))
+	}
+	if len(m.RequestId) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(len(m.RequestId)))
+		i += copy(dAtA[i:], m.RequestId)
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -851,6 +1099,22 @@ func (m *Message) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if len(m.Context) > 0 {
+		dAtA[i] = 0x5a
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(len(m.Context)))
+		i += copy(dAtA[i:], m.Context)
+	}
+	if m.Rebuild {
+		dAtA[i] = 0x60
+		i++
+		if m.Rebuild {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -968,6 +1232,81 @@ func (m *ConfChange) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ConfChangeSingle) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfChangeSingle) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ChangeType != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(m.ChangeType))
+	}
+	if m.NodeId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(m.NodeId))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ConfChangeV2) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfChangeV2) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Transition != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(m.Transition))
+	}
+	if len(m.Changes) > 0 {
+		for _, msg := range m.Changes {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintEraftpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Context) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintEraftpb(dAtA, i, uint64(len(m.Context)))
+		i += copy(dAtA[i:], m.Context)
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func encodeVarintEraftpb(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -993,6 +1332,13 @@ func (m *Entry) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovEraftpb(uint64(l))
 	}
+	if m.Checksum != 0 {
+		n += 1 + sovEraftpb(uint64(m.Checksum))
+	}
+	l = len(m.RequestId)
+	if l > 0 {
+		n += 1 + l + sovEraftpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1072,6 +1418,13 @@ func (m *Message) Size() (n int) {
 	if m.Reject {
 		n += 2
 	}
+	l = len(m.Context)
+	if l > 0 {
+		n += 1 + l + sovEraftpb(uint64(l))
+	}
+	if m.Rebuild {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1131,6 +1484,43 @@ func (m *ConfChange) Size() (n int) {
 	return n
 }
 
+func (m *ConfChangeSingle) Size() (n int) {
+	var l int
+	_ = l
+	if m.ChangeType != 0 {
+		n += 1 + sovEraftpb(uint64(m.ChangeType))
+	}
+	if m.NodeId != 0 {
+		n += 1 + sovEraftpb(uint64(m.NodeId))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConfChangeV2) Size() (n int) {
+	var l int
+	_ = l
+	if m.Transition != 0 {
+		n += 1 + sovEraftpb(uint64(m.Transition))
+	}
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovEraftpb(uint64(l))
+		}
+	}
+	l = len(m.Context)
+	if l > 0 {
+		n += 1 + l + sovEraftpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovEraftpb(x uint64) (n int) {
 	for {
 		n++
@@ -1261,17 +1651,67 @@ func (m *Entry) Unmarshal(dAtA []byte) error {
 				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipEraftpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksum", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthEraftpb
+			m.Checksum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Checksum |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEraftpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestId = append(m.RequestId[:0], dAtA[iNdEx:postIndex]...)
+			if m.RequestId == nil {
+				m.RequestId = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEraftpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEraftpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
 			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
 			iNdEx += skippy
@@ -1766,6 +2206,57 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Reject = bool(v != 0)
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEraftpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Context = append(m.Context[:0], dAtA[iNdEx:postIndex]...)
+			if m.Context == nil {
+				m.Context = []byte{}
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rebuild", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Rebuild = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEraftpb(dAtA[iNdEx:])
@@ -2129,6 +2620,227 @@ func (m *ConfChange) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ConfChangeSingle) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEraftpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfChangeSingle: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfChangeSingle: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+			}
+			m.ChangeType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChangeType |= (ConfChangeType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+			}
+			m.NodeId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NodeId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEraftpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEraftpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfChangeV2) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEraftpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfChangeV2: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfChangeV2: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Transition", wireType)
+			}
+			m.Transition = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Transition |= (ConfChangeTransition(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEraftpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changes = append(m.Changes, &ConfChangeSingle{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEraftpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthEraftpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Context = append(m.Context[:0], dAtA[iNdEx:postIndex]...)
+			if m.Context == nil {
+				m.Context = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEraftpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthEraftpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipEraftpb(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2234,50 +2946,64 @@ var (
 	ErrIntOverflowEraftpb   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("eraftpb.proto", fileDescriptor_eraftpb_2f2e0bcef614736b) }
-
-var fileDescriptor_eraftpb_2f2e0bcef614736b = []byte{
-	// 666 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x94, 0x5f, 0x4e, 0xdb, 0x4e,
-	0x10, 0xc7, 0xb3, 0xf9, 0x67, 0x7b, 0x1c, 0xc2, 0xb2, 0x3f, 0x7e, 0x60, 0xfa, 0x10, 0xa5, 0x7e,
-	0x8a, 0x90, 0xa0, 0x82, 0xaa, 0x52, 0x5f, 0x01, 0x55, 0xa2, 0x6a, 0x8d, 0x2a, 0x43, 0xfb, 0x1a,
-	0x2d, 0xf6, 0xc4, 0xa4, 0xc2, 0x5e, 0xd7, 0xbb, 0x50, 0x72, 0x93, 0x1e, 0xa2, 0x07, 0xe9, 0x63,
-	0x8f, 0x50, 0xd1, 0x23, 0xf4, 0x02, 0xd5, 0x6e, 0x6c, 0xc7, 0xa1, 0x6f, 0xf3, 0x1d, 0xcf, 0xee,
-	0x7c, 0xe6, 0x3b, 0x9b, 0xc0, 0x06, 0x16, 0x7c, 0xa6, 0xf2, 0xeb, 0xc3, 0xbc, 0x10, 0x4a, 0x30,
-	0xab, 0x94, 0xfe, 0x03, 0xf4, 0xde, 0x64, 0xaa, 0x58, 0xb0, 0x23, 0x00, 0xd4, 0xc1, 0x54, 0x2d,
-	0x72, 0xf4, 0xc8, 0x98, 0x4c, 0x86, 0xc7, 0xec, 0xb0, 0x3a, 0x65, 0x6a, 0xae, 0x16, 0x39, 0x86,
-	0x0e, 0x56, 0x21, 0x63, 0xd0, 0x55, 0x58, 0xa4, 0x5e, 0x7b, 0x4c, 0x26, 0xdd, 0xd0, 0xc4, 0x6c,
-	0x1b, 0x7a, 0xf3, 0x2c, 0xc6, 0x07, 0xaf, 0x63, 0x92, 0x4b, 0xa1, 0x2b, 0x63, 0xae, 0xb8, 0xd7,
-	0x1d, 0x93, 0xc9, 0x20, 0x34, 0xb1, 0x2f, 0x80, 0x5e, 0x66, 0x3c, 0x97, 0x37, 0x42, 0x05, 0xa8,
-	0xb8, 0xce, 0x69, 0x88, 0x48, 0x64, 0xb3, 0xa9, 0x54, 0x5c, 0x2d, 0x21, 0xdc, 0x06, 0xc4, 0x99,
-	0xc8, 0x66, 0x97, 0xfa, 0x4b, 0xe8, 0x44, 0x55, 0xb8, 0x6a, 0xd8, 0x7e, 0xd2, 0xd0, 0xa0, 0x75,
-	0x56, 0x68, 0xfe, 0x47, 0xb0, 0xab, 0x86, 0x35, 0x10, 0x59, 0x01, 0xb1, 0x57, 0x60, 0xa7, 0x25,
-	0x88, 0xb9, 0xcc, 0x3d, 0xde, 0xab, 0x5b, 0x3f, 0x25, 0x0d, 0xeb, 0x52, 0xff, 0x7b, 0x1b, 0xac,
-	0x00, 0xa5, 0xe4, 0x09, 0xb2, 0x17, 0x60, 0xa7, 0x32, 0x69, 0x5a, 0xb8, 0x5d, 0x5f, 0x51, 0xd6,
-	0x18, 0x13, 0xad, 0x54, 0x26, 0xc6, 0xc2, 0x21, 0xb4, 0x95, 0x28, 0xd1, 0xdb, 0x4a, 0x68, 0xae,
-	0x59, 0x21, 0x6a, 0x6e, 0x1d, 0xd7, 0xb3, 0x74, 0x1b, 0x36, 0xef, 0x81, 0x7d, 0x2b, 0x92, 0xa9,
-	0xc9, 0xf7, 0x4c, 0xde, 0xba, 0x15, 0xc9, 0xd5, 0xda, 0x06, 0xfa, 0x4d, 0x43, 0x26, 0x60, 0xe9,
-	0xc5, 0xcd, 0x51, 0x7a, 0xd6, 0xb8, 0x33, 0x71, 0x8f, 0x87, 0xeb, 0xbb, 0x0d, 0xab, 0xcf, 0x6c,
-	0x07, 0xfa, 0x91, 0x48, 0xd3, 0xb9, 0xf2, 0x6c, 0x73, 0x41, 0xa9, 0xd8, 0x01, 0xd8, 0xb2, 0x74,
-	0xc1, 0x73, 0x8c, 0x3d, 0x5b, 0xff, 0xd8, 0x13, 0xd6, 0x25, 0xfa, 0x9a, 0x02, 0x3f, 0x63, 0xa4,
-	0x3c, 0x18, 0x93, 0x89, 0x1d, 0x96, 0xca, 0x7f, 0x07, 0xce, 0x39, 0x2f, 0xe2, 0xe5, 0xf2, 0xaa,
-	0xd1, 0x48, 0x63, 0x34, 0x06, 0xdd, 0x7b, 0xa1, 0xb0, 0x7a, 0x55, 0x3a, 0x6e, 0x30, 0x75, 0x9a,
-	0x4c, 0xfe, 0x73, 0x70, 0xce, 0x9a, 0x2f, 0x21, 0x13, 0x31, 0x4a, 0x8f, 0x8c, 0x3b, 0x7a, 0x70,
-	0x23, 0xfc, 0x05, 0x80, 0x2e, 0x39, 0xbb, 0xe1, 0x59, 0x82, 0xec, 0x35, 0xb8, 0x91, 0x89, 0x9a,
-	0x3b, 0xda, 0x5d, 0x7b, 0x61, 0xcb, 0x4a, 0xb3, 0x26, 0x88, 0xea, 0x98, 0xed, 0x82, 0xa5, 0x2f,
-	0x9c, 0xce, 0xe3, 0x92, 0xac, 0xaf, 0xe5, 0xdb, 0x98, 0x79, 0x60, 0x45, 0x22, 0x53, 0xf8, 0xb0,
-	0x84, 0x1b, 0x84, 0x95, 0xdc, 0x3f, 0x02, 0xa7, 0xfe, 0xdd, 0xb0, 0x4d, 0x70, 0x8d, 0xb8, 0x10,
-	0x45, 0xca, 0x6f, 0x69, 0x8b, 0xfd, 0x07, 0x9b, 0x26, 0xb1, 0xea, 0x49, 0xc9, 0xfe, 0x1f, 0x02,
-	0x6e, 0xe3, 0xa1, 0x30, 0x80, 0x7e, 0x20, 0x93, 0xf3, 0xbb, 0x9c, 0xb6, 0x98, 0x0b, 0x56, 0x20,
-	0x93, 0x53, 0xe4, 0x8a, 0x12, 0x36, 0x04, 0x08, 0x64, 0xf2, 0xa1, 0x10, 0xb9, 0x90, 0x48, 0xdb,
-	0x6c, 0x03, 0x9c, 0x40, 0x26, 0x27, 0x79, 0x8e, 0x59, 0x4c, 0x3b, 0xec, 0x7f, 0xd8, 0xaa, 0x65,
-	0x88, 0x32, 0x17, 0x99, 0x44, 0xda, 0x65, 0x0c, 0x86, 0x81, 0x4c, 0x42, 0xfc, 0x72, 0x87, 0x52,
-	0x7d, 0x12, 0x0a, 0x69, 0x8f, 0x3d, 0x83, 0x9d, 0xf5, 0x5c, 0x5d, 0xdf, 0xd7, 0xd0, 0x81, 0x4c,
-	0xaa, 0xed, 0x52, 0x8b, 0x51, 0x18, 0x68, 0x1e, 0xe4, 0x85, 0xba, 0xd6, 0x20, 0x36, 0xf3, 0x60,
-	0xbb, 0x99, 0xa9, 0x0f, 0x3b, 0x25, 0xc3, 0x55, 0xc1, 0x33, 0x39, 0xc3, 0xe2, 0x3d, 0xf2, 0x18,
-	0x0b, 0xea, 0xb2, 0x2d, 0xd8, 0xd0, 0xe9, 0x79, 0x8a, 0xe2, 0x4e, 0x5d, 0x88, 0xaf, 0x74, 0xb0,
-	0x7f, 0x00, 0xc3, 0x75, 0xe7, 0xf5, 0xac, 0x27, 0x71, 0x7c, 0x21, 0x62, 0xa4, 0x2d, 0x3d, 0x6b,
-	0x88, 0xa9, 0xb8, 0x47, 0xa3, 0xc9, 0x29, 0xfd, 0xf1, 0x38, 0x22, 0x3f, 0x1f, 0x47, 0xe4, 0xd7,
-	0xe3, 0x88, 0x7c, 0xfb, 0x3d, 0x6a, 0x5d, 0xf7, 0xcd, 0xbf, 0xda, 0xcb, 0xbf, 0x01, 0x00, 0x00,
-	0xff, 0xff, 0x2c, 0x70, 0xc6, 0xf6, 0xe6, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("eraftpb.proto", fileDescriptor_eraftpb_8ea04ecae3d575f2) }
+
+var fileDescriptor_eraftpb_8ea04ecae3d575f2 = []byte{
+	// 895 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0xf6, 0x52, 0x3f, 0x94, 0x46, 0x3f, 0x5e, 0x4f, 0xd5, 0x84, 0x0e, 0x6a, 0x43, 0x21, 0x50,
+	0x40, 0x30, 0x90, 0x14, 0x51, 0x50, 0xa0, 0x97, 0x1e, 0x1c, 0x23, 0x40, 0xdc, 0x56, 0x6e, 0x40,
+	0x27, 0xee, 0xd1, 0xa0, 0xc8, 0x11, 0xcd, 0x56, 0xe4, 0xb2, 0xdc, 0x55, 0x6a, 0x5f, 0xfb, 0x14,
+	0x3d, 0xf4, 0x2d, 0xfa, 0x12, 0x3d, 0xf6, 0x11, 0x02, 0xf7, 0x25, 0x7a, 0x2c, 0x76, 0x45, 0x52,
+	0x94, 0xe3, 0xf6, 0x96, 0xdb, 0xcc, 0xec, 0x37, 0xb3, 0xdf, 0x7c, 0x33, 0x4b, 0xc2, 0x80, 0x72,
+	0x7f, 0xa1, 0xb2, 0xf9, 0xd3, 0x2c, 0x17, 0x4a, 0xa0, 0x5d, 0xb8, 0xee, 0x1f, 0x0c, 0x5a, 0x2f,
+	0x53, 0x95, 0xdf, 0xe0, 0x33, 0x00, 0xd2, 0xc6, 0xa5, 0xba, 0xc9, 0xc8, 0x61, 0x63, 0x36, 0x19,
+	0x4e, 0xf1, 0x69, 0x99, 0x66, 0x30, 0x6f, 0x6e, 0x32, 0xf2, 0xba, 0x54, 0x9a, 0x88, 0xd0, 0x54,
+	0x94, 0x27, 0x8e, 0x35, 0x66, 0x93, 0xa6, 0x67, 0x6c, 0x1c, 0x41, 0x2b, 0x4e, 0x43, 0xba, 0x76,
+	0x1a, 0x26, 0xb8, 0x76, 0x34, 0x32, 0xf4, 0x95, 0xef, 0x34, 0xc7, 0x6c, 0xd2, 0xf7, 0x8c, 0x8d,
+	0x8f, 0xa0, 0x13, 0x5c, 0x51, 0xf0, 0x93, 0x5c, 0x25, 0x4e, 0x6b, 0xcc, 0x26, 0x03, 0xaf, 0xf2,
+	0xf1, 0x00, 0x20, 0xa7, 0x9f, 0x57, 0x24, 0xd5, 0x65, 0x1c, 0x3a, 0x6d, 0x93, 0xd5, 0x2d, 0x22,
+	0xa7, 0xa1, 0x2b, 0x80, 0x9f, 0xa7, 0x7e, 0x26, 0xaf, 0x84, 0x9a, 0x91, 0xf2, 0x4d, 0xb9, 0x67,
+	0x00, 0x81, 0x48, 0x17, 0x97, 0x52, 0xf9, 0x6a, 0xcd, 0xbf, 0x57, 0xe3, 0x7f, 0x22, 0xd2, 0xc5,
+	0xb9, 0x3e, 0xf1, 0xba, 0x41, 0x69, 0x6e, 0xb8, 0x5a, 0x77, 0xb8, 0x9a, 0xae, 0x1a, 0x9b, 0xae,
+	0xdc, 0xb7, 0xd0, 0x29, 0x2f, 0xac, 0x7a, 0x61, 0xb5, 0x5e, 0xbe, 0x84, 0x4e, 0x52, 0x10, 0x31,
+	0xc5, 0x7a, 0xd3, 0xfd, 0xea, 0xea, 0xbb, 0x4c, 0xbd, 0x0a, 0xea, 0xbe, 0xb7, 0xc0, 0x9e, 0x91,
+	0x94, 0x7e, 0x44, 0xf8, 0x05, 0x74, 0x12, 0x19, 0xd5, 0xd5, 0x1f, 0x55, 0x25, 0x0a, 0x8c, 0xd1,
+	0xdf, 0x4e, 0x64, 0xa4, 0x0d, 0x1c, 0x82, 0xa5, 0x44, 0x41, 0xdd, 0x52, 0x42, 0xf3, 0x5a, 0xe4,
+	0xa2, 0xe2, 0xad, 0xed, 0xaa, 0x97, 0x66, 0x6d, 0x42, 0xfb, 0xd0, 0x59, 0x8a, 0xe8, 0xd2, 0xc4,
+	0x5b, 0x26, 0x6e, 0x2f, 0x45, 0xf4, 0x66, 0x6b, 0x78, 0xed, 0xba, 0x20, 0x13, 0xb0, 0xf5, 0xcc,
+	0x63, 0x92, 0x8e, 0x3d, 0x6e, 0x4c, 0x7a, 0xd3, 0xe1, 0xf6, 0x5a, 0x78, 0xe5, 0x31, 0x3e, 0x80,
+	0x76, 0x20, 0x92, 0x24, 0x56, 0x4e, 0xc7, 0x14, 0x28, 0x3c, 0x7c, 0x02, 0x1d, 0x59, 0xa8, 0xe0,
+	0x74, 0x8d, 0x3c, 0x7b, 0x1f, 0xc8, 0xe3, 0x55, 0x10, 0x5d, 0x26, 0xa7, 0x1f, 0x29, 0x50, 0x0e,
+	0x8c, 0xd9, 0xa4, 0xe3, 0x15, 0x1e, 0x3a, 0x60, 0x07, 0x22, 0x55, 0x74, 0xad, 0x9c, 0x9e, 0x11,
+	0xbf, 0x74, 0xf5, 0x49, 0x4e, 0xf3, 0x55, 0xbc, 0x0c, 0x9d, 0xbe, 0x49, 0x29, 0x5d, 0xf7, 0x5b,
+	0xe8, 0xbe, 0xf2, 0xf3, 0x70, 0x3d, 0xf0, 0x52, 0x0e, 0x56, 0x93, 0x03, 0xa1, 0xf9, 0x4e, 0x28,
+	0x2a, 0x97, 0x58, 0xdb, 0xb5, 0x3e, 0x1a, 0xf5, 0x3e, 0xdc, 0xc7, 0xd0, 0x3d, 0xa9, 0x6f, 0x4f,
+	0x2a, 0x42, 0x92, 0x0e, 0x1b, 0x37, 0xb4, 0x58, 0xc6, 0x71, 0x6f, 0x00, 0x34, 0xe4, 0xe4, 0xca,
+	0x4f, 0x23, 0xc2, 0xaf, 0xa0, 0x17, 0x18, 0xab, 0x3e, 0xd7, 0x87, 0x5b, 0x5b, 0xb9, 0x46, 0x9a,
+	0xd1, 0x42, 0x50, 0xd9, 0xf8, 0x10, 0x6c, 0x5d, 0x50, 0xaf, 0xff, 0x9a, 0x59, 0x5b, 0xbb, 0xa7,
+	0x61, 0x5d, 0x84, 0xc6, 0x96, 0x08, 0x2e, 0x01, 0xdf, 0x14, 0x3c, 0x8f, 0xd3, 0x68, 0xf9, 0x31,
+	0x08, 0xb8, 0xbf, 0x33, 0xe8, 0x6f, 0xf2, 0x2e, 0xa6, 0xf8, 0x35, 0x80, 0xca, 0xfd, 0x54, 0xc6,
+	0x2a, 0x16, 0x69, 0x71, 0xc5, 0xc1, 0x7d, 0x57, 0x54, 0x20, 0xaf, 0x96, 0x80, 0xcf, 0xc1, 0x5e,
+	0x5f, 0x2b, 0x1d, 0x6b, 0xdc, 0xd8, 0x7a, 0x3a, 0x77, 0xdb, 0xf1, 0x4a, 0xe4, 0x7f, 0xab, 0x70,
+	0xf4, 0x03, 0x74, 0xab, 0x8f, 0x15, 0xee, 0x42, 0xcf, 0x38, 0x67, 0x22, 0x4f, 0xfc, 0x25, 0xdf,
+	0xc1, 0x4f, 0x60, 0xd7, 0x04, 0x36, 0x95, 0x39, 0xc3, 0x41, 0x91, 0x72, 0x26, 0xbe, 0xcf, 0xb8,
+	0x85, 0x9f, 0xc2, 0xde, 0x1d, 0xcc, 0xc5, 0x94, 0x37, 0x8e, 0xfe, 0xb1, 0xa0, 0x57, 0x7b, 0x88,
+	0x08, 0xd0, 0x9e, 0xc9, 0xe8, 0xd5, 0x2a, 0xe3, 0x3b, 0xd8, 0x03, 0x7b, 0x26, 0xa3, 0x17, 0xe4,
+	0x2b, 0xce, 0x70, 0x08, 0x30, 0x93, 0xd1, 0xeb, 0x5c, 0x64, 0x42, 0x12, 0xb7, 0x74, 0xf9, 0x99,
+	0x8c, 0x8e, 0xb3, 0x8c, 0xd2, 0x90, 0x37, 0x74, 0xf9, 0xca, 0xf5, 0x48, 0x66, 0x22, 0x95, 0xc4,
+	0x9b, 0x88, 0x30, 0x9c, 0xc9, 0xc8, 0x5b, 0x7f, 0xe3, 0x2e, 0x84, 0x22, 0xde, 0xc2, 0x47, 0xf0,
+	0x60, 0x3b, 0x56, 0xe1, 0xdb, 0xba, 0xb5, 0x99, 0x8c, 0xca, 0xd7, 0xc3, 0x6d, 0xe4, 0xd0, 0xd7,
+	0x7c, 0xc8, 0xcf, 0xd5, 0x5c, 0x13, 0xe9, 0xa0, 0x03, 0xa3, 0x7a, 0xa4, 0x4a, 0xee, 0x16, 0x1c,
+	0xcc, 0x40, 0x16, 0x94, 0x7f, 0x47, 0x7e, 0x48, 0x39, 0xef, 0xe1, 0x1e, 0x0c, 0x74, 0x38, 0x4e,
+	0x48, 0xac, 0xd4, 0x99, 0xf8, 0x85, 0xf7, 0x0b, 0x64, 0x41, 0xe1, 0x75, 0x4e, 0x86, 0xd9, 0x00,
+	0x0f, 0x60, 0xff, 0x83, 0x70, 0x55, 0x7f, 0x58, 0x70, 0xf1, 0xc8, 0x0f, 0x4f, 0xf5, 0x27, 0x84,
+	0xef, 0xe2, 0x08, 0x78, 0x3d, 0xa2, 0xb1, 0x9c, 0x17, 0x4d, 0xbf, 0x4d, 0x73, 0xf2, 0x83, 0x2b,
+	0x7f, 0xbe, 0x24, 0xbe, 0x57, 0x90, 0xd0, 0x8d, 0xe9, 0x77, 0xb6, 0x92, 0x1c, 0x8f, 0x9e, 0xc0,
+	0x70, 0x7b, 0x53, 0xb5, 0xe0, 0xc7, 0x61, 0x78, 0x26, 0x42, 0xe2, 0x3b, 0x5a, 0x70, 0x8f, 0x12,
+	0xf1, 0x8e, 0x8c, 0xcf, 0x8e, 0x7e, 0x65, 0x30, 0xba, 0x6f, 0xed, 0xf0, 0x33, 0x70, 0xee, 0x8b,
+	0x1f, 0xaf, 0x94, 0xe0, 0x3b, 0xf8, 0x39, 0x3c, 0xbe, 0xef, 0xf4, 0x1b, 0x11, 0xa7, 0xea, 0x34,
+	0xc9, 0x96, 0x71, 0x10, 0xeb, 0xf1, 0xfe, 0x1f, 0xec, 0xe5, 0x75, 0x01, 0xb3, 0x5e, 0xf0, 0x3f,
+	0x6f, 0x0f, 0xd9, 0x5f, 0xb7, 0x87, 0xec, 0xfd, 0xed, 0x21, 0xfb, 0xed, 0xef, 0xc3, 0x9d, 0x79,
+	0xdb, 0xfc, 0x7b, 0x9f, 0xff, 0x3b, 0x00, 0x6a, 0x91, 0xde, 0x65, 0x8c, 0x07, 0x00, 0x00,
 }
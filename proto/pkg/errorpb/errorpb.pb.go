@@ -26,6 +26,40 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// ErrorClass tells the client how to retry a request which failed with a
+// retryable error.
+type ErrorClass int32
+
+const (
+	ErrorClass_Unspecified ErrorClass = 0
+	// The store is overloaded, back off before retrying on the same store.
+	ErrorClass_Overloaded ErrorClass = 1
+	// The store rejects writes for now, e.g. it is in read-only mode.
+	ErrorClass_ReadOnly ErrorClass = 2
+	// The leadership of the region is changing, refresh the leader before retrying.
+	ErrorClass_LeaderChanging ErrorClass = 3
+)
+
+var ErrorClass_name = map[int32]string{
+	0: "Unspecified",
+	1: "Overloaded",
+	2: "ReadOnly",
+	3: "LeaderChanging",
+}
+var ErrorClass_value = map[string]int32{
+	"Unspecified":    0,
+	"Overloaded":     1,
+	"ReadOnly":       2,
+	"LeaderChanging": 3,
+}
+
+func (x ErrorClass) String() string {
+	return proto.EnumName(ErrorClass_name, int32(x))
+}
+func (ErrorClass) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_errorpb_bfd6b1253d4cb4e3, []int{0}
+}
+
 type NotLeader struct {
 	RegionId             uint64       `protobuf:"varint,1,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
 	Leader               *metapb.Peer `protobuf:"bytes,2,opt,name=leader" json:"leader,omitempty"`
@@ -38,7 +72,7 @@ func (m *NotLeader) Reset()         { *m = NotLeader{} }
 func (m *NotLeader) String() string { return proto.CompactTextString(m) }
 func (*NotLeader) ProtoMessage()    {}
 func (*NotLeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_errorpb_6ea187258f91197d, []int{0}
+	return fileDescriptor_errorpb_bfd6b1253d4cb4e3, []int{0}
 }
 func (m *NotLeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -93,7 +127,7 @@ func (m *StoreNotMatch) Reset()         { *m = StoreNotMatch{} }
 func (m *StoreNotMatch) String() string { return proto.CompactTextString(m) }
 func (*StoreNotMatch) ProtoMessage()    {}
 func (*StoreNotMatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_errorpb_6ea187258f91197d, []int{1}
+	return fileDescriptor_errorpb_bfd6b1253d4cb4e3, []int{1}
 }
 func (m *StoreNotMatch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -147,7 +181,7 @@ func (m *RegionNotFound) Reset()         { *m = RegionNotFound{} }
 func (m *RegionNotFound) String() string { return proto.CompactTextString(m) }
 func (*RegionNotFound) ProtoMessage()    {}
 func (*RegionNotFound) Descriptor() ([]byte, []int) {
-	return fileDescriptor_errorpb_6ea187258f91197d, []int{2}
+	return fileDescriptor_errorpb_bfd6b1253d4cb4e3, []int{2}
 }
 func (m *RegionNotFound) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -197,7 +231,7 @@ func (m *KeyNotInRegion) Reset()         { *m = KeyNotInRegion{} }
 func (m *KeyNotInRegion) String() string { return proto.CompactTextString(m) }
 func (*KeyNotInRegion) ProtoMessage()    {}
 func (*KeyNotInRegion) Descriptor() ([]byte, []int) {
-	return fileDescriptor_errorpb_6ea187258f91197d, []int{3}
+	return fileDescriptor_errorpb_bfd6b1253d4cb4e3, []int{3}
 }
 func (m *KeyNotInRegion) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -265,7 +299,7 @@ func (m *EpochNotMatch) Reset()         { *m = EpochNotMatch{} }
 func (m *EpochNotMatch) String() string { return proto.CompactTextString(m) }
 func (*EpochNotMatch) ProtoMessage()    {}
 func (*EpochNotMatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_errorpb_6ea187258f91197d, []int{4}
+	return fileDescriptor_errorpb_bfd6b1253d4cb4e3, []int{4}
 }
 func (m *EpochNotMatch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -302,16 +336,19 @@ func (m *EpochNotMatch) GetCurrentRegions() []*metapb.Region {
 }
 
 type StaleCommand struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// retry_after_ms is how long the client should wait before retrying.
+	RetryAfterMs         uint64     `protobuf:"varint,1,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"`
+	ErrorClass           ErrorClass `protobuf:"varint,2,opt,name=error_class,json=errorClass,proto3,enum=errorpb.ErrorClass" json:"error_class,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *StaleCommand) Reset()         { *m = StaleCommand{} }
 func (m *StaleCommand) String() string { return proto.CompactTextString(m) }
 func (*StaleCommand) ProtoMessage()    {}
 func (*StaleCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_errorpb_6ea187258f91197d, []int{5}
+	return fileDescriptor_errorpb_bfd6b1253d4cb4e3, []int{5}
 }
 func (m *StaleCommand) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -340,6 +377,86 @@ func (m *StaleCommand) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StaleCommand proto.InternalMessageInfo
 
+func (m *StaleCommand) GetRetryAfterMs() uint64 {
+	if m != nil {
+		return m.RetryAfterMs
+	}
+	return 0
+}
+
+func (m *StaleCommand) GetErrorClass() ErrorClass {
+	if m != nil {
+		return m.ErrorClass
+	}
+	return ErrorClass_Unspecified
+}
+
+// ServerIsBusy is returned when the store can't take the request right now, the
+// request may be retried later.
+type ServerIsBusy struct {
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	// retry_after_ms is how long the client should wait before retrying.
+	RetryAfterMs         uint64     `protobuf:"varint,2,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"`
+	ErrorClass           ErrorClass `protobuf:"varint,3,opt,name=error_class,json=errorClass,proto3,enum=errorpb.ErrorClass" json:"error_class,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ServerIsBusy) Reset()         { *m = ServerIsBusy{} }
+func (m *ServerIsBusy) String() string { return proto.CompactTextString(m) }
+func (*ServerIsBusy) ProtoMessage()    {}
+func (*ServerIsBusy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_errorpb_bfd6b1253d4cb4e3, []int{6}
+}
+func (m *ServerIsBusy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ServerIsBusy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ServerIsBusy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *ServerIsBusy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerIsBusy.Merge(dst, src)
+}
+func (m *ServerIsBusy) XXX_Size() int {
+	return m.Size()
+}
+func (m *ServerIsBusy) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerIsBusy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerIsBusy proto.InternalMessageInfo
+
+func (m *ServerIsBusy) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ServerIsBusy) GetRetryAfterMs() uint64 {
+	if m != nil {
+		return m.RetryAfterMs
+	}
+	return 0
+}
+
+func (m *ServerIsBusy) GetErrorClass() ErrorClass {
+	if m != nil {
+		return m.ErrorClass
+	}
+	return ErrorClass_Unspecified
+}
+
 type Error struct {
 	Message              string          `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	NotLeader            *NotLeader      `protobuf:"bytes,2,opt,name=not_leader,json=notLeader" json:"not_leader,omitempty"`
@@ -348,6 +465,7 @@ type Error struct {
 	EpochNotMatch        *EpochNotMatch  `protobuf:"bytes,5,opt,name=epoch_not_match,json=epochNotMatch" json:"epoch_not_match,omitempty"`
 	StaleCommand         *StaleCommand   `protobuf:"bytes,7,opt,name=stale_command,json=staleCommand" json:"stale_command,omitempty"`
 	StoreNotMatch        *StoreNotMatch  `protobuf:"bytes,8,opt,name=store_not_match,json=storeNotMatch" json:"store_not_match,omitempty"`
+	ServerIsBusy         *ServerIsBusy   `protobuf:"bytes,9,opt,name=server_is_busy,json=serverIsBusy" json:"server_is_busy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -357,7 +475,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_errorpb_6ea187258f91197d, []int{6}
+	return fileDescriptor_errorpb_bfd6b1253d4cb4e3, []int{7}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -435,6 +553,13 @@ func (m *Error) GetStoreNotMatch() *StoreNotMatch {
 	return nil
 }
 
+func (m *Error) GetServerIsBusy() *ServerIsBusy {
+	if m != nil {
+		return m.ServerIsBusy
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*NotLeader)(nil), "errorpb.NotLeader")
 	proto.RegisterType((*StoreNotMatch)(nil), "errorpb.StoreNotMatch")
@@ -442,7 +567,9 @@ func init() {
 	proto.RegisterType((*KeyNotInRegion)(nil), "errorpb.KeyNotInRegion")
 	proto.RegisterType((*EpochNotMatch)(nil), "errorpb.EpochNotMatch")
 	proto.RegisterType((*StaleCommand)(nil), "errorpb.StaleCommand")
+	proto.RegisterType((*ServerIsBusy)(nil), "errorpb.ServerIsBusy")
 	proto.RegisterType((*Error)(nil), "errorpb.Error")
+	proto.RegisterEnum("errorpb.ErrorClass", ErrorClass_name, ErrorClass_value)
 }
 func (m *NotLeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -629,6 +756,53 @@ func (m *StaleCommand) MarshalTo(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RetryAfterMs != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.RetryAfterMs))
+	}
+	if m.ErrorClass != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ErrorClass))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ServerIsBusy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ServerIsBusy) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(len(m.Reason)))
+		i += copy(dAtA[i:], m.Reason)
+	}
+	if m.RetryAfterMs != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.RetryAfterMs))
+	}
+	if m.ErrorClass != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ErrorClass))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -716,6 +890,16 @@ func (m *Error) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n7
 	}
+	if m.ServerIsBusy != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintErrorpb(dAtA, i, uint64(m.ServerIsBusy.Size()))
+		n8, err := m.ServerIsBusy.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -816,6 +1000,31 @@ func (m *EpochNotMatch) Size() (n int) {
 func (m *StaleCommand) Size() (n int) {
 	var l int
 	_ = l
+	if m.RetryAfterMs != 0 {
+		n += 1 + sovErrorpb(uint64(m.RetryAfterMs))
+	}
+	if m.ErrorClass != 0 {
+		n += 1 + sovErrorpb(uint64(m.ErrorClass))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ServerIsBusy) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
+	if m.RetryAfterMs != 0 {
+		n += 1 + sovErrorpb(uint64(m.RetryAfterMs))
+	}
+	if m.ErrorClass != 0 {
+		n += 1 + sovErrorpb(uint64(m.ErrorClass))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -853,6 +1062,10 @@ func (m *Error) Size() (n int) {
 		l = m.StoreNotMatch.Size()
 		n += 1 + l + sovErrorpb(uint64(l))
 	}
+	if m.ServerIsBusy != nil {
+		l = m.ServerIsBusy.Size()
+		n += 1 + l + sovErrorpb(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1408,6 +1621,162 @@ func (m *StaleCommand) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: StaleCommand: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryAfterMs", wireType)
+			}
+			m.RetryAfterMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetryAfterMs |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorClass", wireType)
+			}
+			m.ErrorClass = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorClass |= (ErrorClass(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipErrorpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ServerIsBusy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowErrorpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ServerIsBusy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ServerIsBusy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetryAfterMs", wireType)
+			}
+			m.RetryAfterMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetryAfterMs |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorClass", wireType)
+			}
+			m.ErrorClass = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorClass |= (ErrorClass(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -1686,6 +2055,39 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerIsBusy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowErrorpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthErrorpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ServerIsBusy == nil {
+				m.ServerIsBusy = &ServerIsBusy{}
+			}
+			if err := m.ServerIsBusy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipErrorpb(dAtA[iNdEx:])
@@ -1813,40 +2215,49 @@ var (
 	ErrIntOverflowErrorpb   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("errorpb.proto", fileDescriptor_errorpb_6ea187258f91197d) }
-
-var fileDescriptor_errorpb_6ea187258f91197d = []byte{
-	// 499 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xcd, 0x8e, 0x12, 0x4d,
-	0x14, 0xfd, 0x1a, 0x66, 0xf8, 0xb9, 0xd0, 0x0d, 0x5f, 0x47, 0x9d, 0xce, 0x4c, 0x42, 0x48, 0xc7,
-	0x18, 0x36, 0x62, 0xc4, 0x85, 0x89, 0x0b, 0x13, 0xc7, 0x8c, 0x91, 0xa0, 0xc4, 0xd4, 0x3c, 0x40,
-	0xa7, 0x86, 0xbe, 0x32, 0x04, 0xa8, 0xc2, 0xaa, 0x62, 0xc1, 0x9b, 0xf8, 0x00, 0x3e, 0x8c, 0x4b,
-	0x1f, 0xc1, 0xe0, 0x8b, 0x98, 0xba, 0xd5, 0x34, 0xd4, 0x2c, 0xdc, 0xf5, 0xfd, 0x39, 0xe7, 0xde,
-	0x7b, 0x4e, 0x35, 0x84, 0xa8, 0x94, 0x54, 0x9b, 0xbb, 0xe1, 0x46, 0x49, 0x23, 0xe3, 0x7a, 0x11,
-	0x5e, 0xb6, 0xd7, 0x68, 0xf8, 0x21, 0x7d, 0xf9, 0x68, 0x2e, 0xe7, 0x92, 0x3e, 0x5f, 0xd8, 0x2f,
-	0x97, 0x4d, 0xa7, 0xd0, 0x9c, 0x4a, 0xf3, 0x09, 0x79, 0x8e, 0x2a, 0xbe, 0x82, 0xa6, 0xc2, 0xf9,
-	0x42, 0x8a, 0x6c, 0x91, 0x27, 0x41, 0x3f, 0x18, 0x9c, 0xb1, 0x86, 0x4b, 0x8c, 0xf3, 0xf8, 0x29,
-	0xd4, 0x56, 0xd4, 0x96, 0x54, 0xfa, 0xc1, 0xa0, 0x35, 0x6a, 0x0f, 0x0b, 0xfa, 0x2f, 0x88, 0x8a,
-	0x15, 0xb5, 0x94, 0x43, 0x78, 0x6b, 0xa4, 0xc2, 0xa9, 0x34, 0x9f, 0xb9, 0x99, 0xdd, 0xc7, 0x03,
-	0xe8, 0x2a, 0xfc, 0xb6, 0x45, 0x6d, 0x32, 0x6d, 0x0b, 0x47, 0xea, 0xa8, 0xc8, 0x53, 0xff, 0x38,
-	0x8f, 0x9f, 0x41, 0x87, 0xcf, 0xcc, 0x96, 0xaf, 0x8e, 0x8d, 0x15, 0x6a, 0x0c, 0x5d, 0xba, 0xe8,
-	0x4b, 0x9f, 0x43, 0xc4, 0x68, 0xa9, 0xa9, 0x34, 0x1f, 0xe4, 0x56, 0xe4, 0xff, 0xdc, 0x3b, 0xdd,
-	0x42, 0x34, 0xc1, 0xdd, 0x54, 0x9a, 0xb1, 0x70, 0xb0, 0xb8, 0x0b, 0xd5, 0x25, 0xee, 0xa8, 0xb1,
-	0xcd, 0xec, 0xa7, 0x4f, 0x50, 0x79, 0x70, 0xf8, 0x15, 0x34, 0xb5, 0xe1, 0xca, 0x64, 0x16, 0x54,
-	0x25, 0x50, 0x83, 0x12, 0x13, 0xdc, 0xc5, 0x17, 0x50, 0x47, 0x91, 0x53, 0xe9, 0x8c, 0x4a, 0x35,
-	0x14, 0xf9, 0x04, 0x77, 0xe9, 0x47, 0x08, 0x6f, 0x36, 0x72, 0x76, 0x5f, 0x0a, 0xf1, 0x1a, 0x3a,
-	0xb3, 0xad, 0x52, 0x28, 0x4c, 0xe6, 0xa8, 0x75, 0x12, 0xf4, 0xab, 0x83, 0xd6, 0x28, 0x3a, 0x08,
-	0xe9, 0xd6, 0x63, 0x51, 0xd1, 0xe6, 0x42, 0x9d, 0x46, 0xd0, 0xbe, 0x35, 0x7c, 0x85, 0xef, 0xe5,
-	0x7a, 0xcd, 0x45, 0x9e, 0xfe, 0xa8, 0xc2, 0xf9, 0x8d, 0xb5, 0x38, 0x4e, 0xa0, 0xbe, 0x46, 0xad,
-	0xf9, 0x1c, 0xe9, 0x98, 0x26, 0x3b, 0x84, 0xf1, 0x4b, 0x00, 0x21, 0x4d, 0xe6, 0x19, 0x16, 0x0f,
-	0x0f, 0xef, 0xa4, 0x74, 0x9c, 0x35, 0x45, 0x69, 0xfe, 0x3b, 0x6b, 0x14, 0x69, 0x60, 0x91, 0x5f,
-	0xad, 0xb0, 0x74, 0x6d, 0x6b, 0x74, 0x51, 0x02, 0x7d, 0xdd, 0xad, 0x83, 0x9e, 0x0f, 0xd7, 0xf0,
-	0xff, 0x12, 0x77, 0x84, 0x5f, 0x88, 0xe2, 0x4a, 0x92, 0xe5, 0x94, 0xc3, 0x37, 0x83, 0x45, 0x4b,
-	0xdf, 0x9c, 0xb7, 0xd0, 0x41, 0xab, 0x1b, 0xb1, 0xac, 0xad, 0x72, 0xc9, 0x39, 0x31, 0x3c, 0x29,
-	0x19, 0x3c, 0x5d, 0x59, 0x88, 0x9e, 0xcc, 0x6f, 0x20, 0xd4, 0x56, 0xad, 0x6c, 0xe6, 0xe4, 0x4a,
-	0xea, 0x84, 0x7e, 0x5c, 0xa2, 0x4f, 0xb5, 0x64, 0x6d, 0x7d, 0x12, 0xd9, 0xd9, 0xee, 0xe9, 0x1d,
-	0x67, 0x37, 0x1e, 0xcc, 0xf6, 0x1e, 0x37, 0x0b, 0xb5, 0x17, 0xb6, 0xdc, 0x64, 0x5a, 0xe8, 0xba,
-	0xfb, 0x73, 0xdf, 0x0b, 0x7e, 0xed, 0x7b, 0xc1, 0xef, 0x7d, 0x2f, 0xf8, 0xfe, 0xa7, 0xf7, 0xdf,
-	0x5d, 0x8d, 0x7e, 0xb9, 0x57, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x26, 0x03, 0xaa, 0x44, 0xb0,
-	0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("errorpb.proto", fileDescriptor_errorpb_bfd6b1253d4cb4e3) }
+
+var fileDescriptor_errorpb_bfd6b1253d4cb4e3 = []byte{
+	// 651 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xdd, 0x6a, 0x13, 0x4f,
+	0x14, 0xc0, 0xbb, 0x49, 0x9b, 0x8f, 0x93, 0xcd, 0x26, 0xff, 0xf9, 0x6b, 0xbb, 0xb4, 0x10, 0x4a,
+	0x10, 0x09, 0x82, 0x15, 0xa3, 0x20, 0x28, 0x08, 0x6d, 0xa9, 0x18, 0x6a, 0x53, 0x99, 0xe2, 0xf5,
+	0x32, 0xdd, 0x3d, 0x4d, 0xd7, 0x26, 0x33, 0x71, 0x66, 0xb6, 0xb0, 0xb7, 0x3e, 0x85, 0x8f, 0xe4,
+	0xa5, 0x8f, 0xa0, 0xf5, 0x45, 0x64, 0x66, 0x37, 0xc9, 0x6e, 0x10, 0xf5, 0x2a, 0xe7, 0xfb, 0x63,
+	0xce, 0x6f, 0x03, 0x6d, 0x94, 0x52, 0xc8, 0xf9, 0xe5, 0xc1, 0x5c, 0x0a, 0x2d, 0x48, 0x3d, 0x57,
+	0x77, 0xdd, 0x19, 0x6a, 0xb6, 0x30, 0xef, 0xde, 0x9b, 0x88, 0x89, 0xb0, 0xe2, 0x13, 0x23, 0x65,
+	0xd6, 0xfe, 0x18, 0x9a, 0x63, 0xa1, 0xdf, 0x21, 0x8b, 0x50, 0x92, 0x3d, 0x68, 0x4a, 0x9c, 0xc4,
+	0x82, 0x07, 0x71, 0xe4, 0x3b, 0xfb, 0xce, 0x60, 0x93, 0x36, 0x32, 0xc3, 0x28, 0x22, 0x0f, 0xa0,
+	0x36, 0xb5, 0x61, 0x7e, 0x65, 0xdf, 0x19, 0xb4, 0x86, 0xee, 0x41, 0x5e, 0xfe, 0x3d, 0xa2, 0xa4,
+	0xb9, 0xaf, 0xcf, 0xa0, 0x7d, 0xa1, 0x85, 0xc4, 0xb1, 0xd0, 0x67, 0x4c, 0x87, 0xd7, 0x64, 0x00,
+	0x5d, 0x89, 0x9f, 0x12, 0x54, 0x3a, 0x50, 0xc6, 0xb1, 0x2a, 0xed, 0xe5, 0x76, 0x1b, 0x3f, 0x8a,
+	0xc8, 0x43, 0xe8, 0xb0, 0x50, 0x27, 0x6c, 0xba, 0x0a, 0xac, 0xd8, 0xc0, 0x76, 0x66, 0xce, 0xe3,
+	0xfa, 0x8f, 0xc1, 0xa3, 0x76, 0xa8, 0xb1, 0xd0, 0x6f, 0x44, 0xc2, 0xa3, 0x3f, 0xce, 0xdd, 0x4f,
+	0xc0, 0x3b, 0xc5, 0x74, 0x2c, 0xf4, 0x88, 0x67, 0x69, 0xa4, 0x0b, 0xd5, 0x1b, 0x4c, 0x6d, 0xa0,
+	0x4b, 0x8d, 0x58, 0x2e, 0x50, 0x59, 0x5b, 0x7c, 0x0f, 0x9a, 0x4a, 0x33, 0xa9, 0x03, 0x93, 0x54,
+	0xb5, 0x49, 0x0d, 0x6b, 0x38, 0xc5, 0x94, 0xec, 0x40, 0x1d, 0x79, 0x64, 0x5d, 0x9b, 0xd6, 0x55,
+	0x43, 0x1e, 0x9d, 0x62, 0xda, 0x7f, 0x0b, 0xed, 0x93, 0xb9, 0x08, 0xaf, 0x97, 0x0f, 0xf1, 0x02,
+	0x3a, 0x61, 0x22, 0x25, 0x72, 0x1d, 0x64, 0xa5, 0x95, 0xef, 0xec, 0x57, 0x07, 0xad, 0xa1, 0xb7,
+	0x78, 0xc8, 0x6c, 0x3c, 0xea, 0xe5, 0x61, 0x99, 0xaa, 0xfa, 0x1f, 0xc1, 0xbd, 0xd0, 0x6c, 0x8a,
+	0xc7, 0x62, 0x36, 0x63, 0xdc, 0x1c, 0xc2, 0x93, 0xa8, 0x65, 0x1a, 0xb0, 0x2b, 0x8d, 0x32, 0x98,
+	0xa9, 0x7c, 0x65, 0xd7, 0x5a, 0x0f, 0x8d, 0xf1, 0x4c, 0x91, 0xe7, 0xd0, 0xb2, 0x1c, 0x04, 0xe1,
+	0x94, 0x29, 0x65, 0x97, 0xf2, 0x86, 0xff, 0x1f, 0x2c, 0x50, 0x39, 0x31, 0xbf, 0xc7, 0xc6, 0x45,
+	0x01, 0x97, 0x72, 0xff, 0xb3, 0x03, 0xee, 0x05, 0xca, 0x5b, 0x94, 0x23, 0x75, 0x94, 0xa8, 0x94,
+	0x6c, 0x43, 0x4d, 0x22, 0x53, 0x82, 0xdb, 0x26, 0x4d, 0x9a, 0x6b, 0xbf, 0x19, 0xa2, 0xf2, 0xf7,
+	0x21, 0xaa, 0xff, 0x36, 0xc4, 0x8f, 0x2a, 0x6c, 0x59, 0x17, 0xf1, 0xa1, 0x3e, 0x43, 0xa5, 0xd8,
+	0x04, 0xf3, 0xf6, 0x0b, 0x95, 0x3c, 0x05, 0xe0, 0x42, 0x07, 0x25, 0x22, 0xc9, 0xb2, 0xf0, 0x12,
+	0x69, 0xda, 0xe4, 0x0b, 0x91, 0x1c, 0x1a, 0x12, 0xed, 0x91, 0x4d, 0xe6, 0x95, 0x21, 0xc7, 0x4e,
+	0xd4, 0x1a, 0xee, 0x2c, 0x13, 0xcb, 0x60, 0x19, 0x44, 0x4b, 0xa0, 0x1d, 0xc1, 0x7f, 0x37, 0x98,
+	0xda, 0xfc, 0x98, 0xe7, 0x67, 0xf4, 0x37, 0xd7, 0x6a, 0x94, 0x69, 0xa3, 0xde, 0x4d, 0x99, 0xbe,
+	0xd7, 0xd0, 0x41, 0x03, 0x86, 0xad, 0x32, 0x33, 0x68, 0xf8, 0x5b, 0xb6, 0xc2, 0xf6, 0xea, 0x5d,
+	0x8a, 0xe0, 0xd0, 0x36, 0x16, 0x55, 0xf2, 0x12, 0xda, 0xca, 0xe0, 0x10, 0x84, 0x19, 0x0f, 0x7e,
+	0xdd, 0x66, 0xdf, 0x5f, 0x66, 0x17, 0x61, 0xa1, 0xae, 0x2a, 0x68, 0xa6, 0x77, 0xf6, 0x6d, 0xad,
+	0x7a, 0x37, 0xd6, 0x7a, 0x97, 0xbe, 0x5e, 0xda, 0x56, 0x45, 0x95, 0xbc, 0x02, 0x4f, 0x59, 0x3a,
+	0x82, 0x58, 0x05, 0x97, 0x89, 0x4a, 0xfd, 0xe6, 0x7a, 0xf3, 0x02, 0x3c, 0xd4, 0x55, 0x45, 0xad,
+	0x95, 0x8d, 0x6d, 0xb7, 0x79, 0x74, 0x0e, 0xb0, 0xba, 0x3e, 0xe9, 0x40, 0xeb, 0x03, 0x57, 0x73,
+	0x0c, 0xe3, 0xab, 0x18, 0xa3, 0xee, 0x06, 0xf1, 0x00, 0xce, 0x6f, 0x51, 0x4e, 0x05, 0x8b, 0x30,
+	0xea, 0x3a, 0xc4, 0x85, 0x06, 0x45, 0x16, 0x9d, 0xf3, 0x69, 0xda, 0xad, 0x10, 0x02, 0x5e, 0x76,
+	0xd3, 0xe3, 0x6b, 0xc6, 0x27, 0x31, 0x9f, 0x74, 0xab, 0x47, 0xdd, 0xaf, 0x77, 0x3d, 0xe7, 0xdb,
+	0x5d, 0xcf, 0xf9, 0x7e, 0xd7, 0x73, 0xbe, 0xfc, 0xec, 0x6d, 0x5c, 0xd6, 0xec, 0x3f, 0xdc, 0xb3,
+	0x5f, 0x03, 0x00, 0xc3, 0x4c, 0x97, 0xdc, 0x1f, 0x05, 0x00, 0x00,
 }
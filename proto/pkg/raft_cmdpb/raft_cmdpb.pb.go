@@ -56,7 +56,7 @@ func (x CmdType) String() string {
 	return proto.EnumName(CmdType_name, int32(x))
 }
 func (CmdType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{0}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{0}
 }
 
 type AdminCmdType int32
@@ -66,6 +66,7 @@ const (
 	AdminCmdType_ChangePeer     AdminCmdType = 1
 	AdminCmdType_CompactLog     AdminCmdType = 3
 	AdminCmdType_TransferLeader AdminCmdType = 4
+	AdminCmdType_RebuildPeer    AdminCmdType = 5
 	AdminCmdType_Split          AdminCmdType = 10
 )
 
@@ -74,6 +75,7 @@ var AdminCmdType_name = map[int32]string{
 	1:  "ChangePeer",
 	3:  "CompactLog",
 	4:  "TransferLeader",
+	5:  "RebuildPeer",
 	10: "Split",
 }
 var AdminCmdType_value = map[string]int32{
@@ -81,6 +83,7 @@ var AdminCmdType_value = map[string]int32{
 	"ChangePeer":     1,
 	"CompactLog":     3,
 	"TransferLeader": 4,
+	"RebuildPeer":    5,
 	"Split":          10,
 }
 
@@ -88,7 +91,7 @@ func (x AdminCmdType) String() string {
 	return proto.EnumName(AdminCmdType_name, int32(x))
 }
 func (AdminCmdType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{1}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{1}
 }
 
 type GetRequest struct {
@@ -103,7 +106,7 @@ func (m *GetRequest) Reset()         { *m = GetRequest{} }
 func (m *GetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()    {}
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{0}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{0}
 }
 func (m *GetRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -157,7 +160,7 @@ func (m *GetResponse) Reset()         { *m = GetResponse{} }
 func (m *GetResponse) String() string { return proto.CompactTextString(m) }
 func (*GetResponse) ProtoMessage()    {}
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{1}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{1}
 }
 func (m *GetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -206,7 +209,7 @@ func (m *PutRequest) Reset()         { *m = PutRequest{} }
 func (m *PutRequest) String() string { return proto.CompactTextString(m) }
 func (*PutRequest) ProtoMessage()    {}
 func (*PutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{2}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{2}
 }
 func (m *PutRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -266,7 +269,7 @@ func (m *PutResponse) Reset()         { *m = PutResponse{} }
 func (m *PutResponse) String() string { return proto.CompactTextString(m) }
 func (*PutResponse) ProtoMessage()    {}
 func (*PutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{3}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{3}
 }
 func (m *PutResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -307,7 +310,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{4}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{4}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -360,7 +363,7 @@ func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
 func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteResponse) ProtoMessage()    {}
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{5}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{5}
 }
 func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -399,7 +402,7 @@ func (m *SnapRequest) Reset()         { *m = SnapRequest{} }
 func (m *SnapRequest) String() string { return proto.CompactTextString(m) }
 func (*SnapRequest) ProtoMessage()    {}
 func (*SnapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{6}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{6}
 }
 func (m *SnapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -439,7 +442,7 @@ func (m *SnapResponse) Reset()         { *m = SnapResponse{} }
 func (m *SnapResponse) String() string { return proto.CompactTextString(m) }
 func (*SnapResponse) ProtoMessage()    {}
 func (*SnapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{7}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{7}
 }
 func (m *SnapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -490,7 +493,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{8}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{8}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -569,7 +572,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{9}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{9}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -646,7 +649,7 @@ func (m *ChangePeerRequest) Reset()         { *m = ChangePeerRequest{} }
 func (m *ChangePeerRequest) String() string { return proto.CompactTextString(m) }
 func (*ChangePeerRequest) ProtoMessage()    {}
 func (*ChangePeerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{10}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{10}
 }
 func (m *ChangePeerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -700,7 +703,7 @@ func (m *ChangePeerResponse) Reset()         { *m = ChangePeerResponse{} }
 func (m *ChangePeerResponse) String() string { return proto.CompactTextString(m) }
 func (*ChangePeerResponse) ProtoMessage()    {}
 func (*ChangePeerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{11}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{11}
 }
 func (m *ChangePeerResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -755,7 +758,7 @@ func (m *SplitRequest) Reset()         { *m = SplitRequest{} }
 func (m *SplitRequest) String() string { return proto.CompactTextString(m) }
 func (*SplitRequest) ProtoMessage()    {}
 func (*SplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{12}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{12}
 }
 func (m *SplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -817,7 +820,7 @@ func (m *SplitResponse) Reset()         { *m = SplitResponse{} }
 func (m *SplitResponse) String() string { return proto.CompactTextString(m) }
 func (*SplitResponse) ProtoMessage()    {}
 func (*SplitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{13}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{13}
 }
 func (m *SplitResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -865,7 +868,7 @@ func (m *CompactLogRequest) Reset()         { *m = CompactLogRequest{} }
 func (m *CompactLogRequest) String() string { return proto.CompactTextString(m) }
 func (*CompactLogRequest) ProtoMessage()    {}
 func (*CompactLogRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{14}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{14}
 }
 func (m *CompactLogRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -918,7 +921,7 @@ func (m *CompactLogResponse) Reset()         { *m = CompactLogResponse{} }
 func (m *CompactLogResponse) String() string { return proto.CompactTextString(m) }
 func (*CompactLogResponse) ProtoMessage()    {}
 func (*CompactLogResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{15}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{15}
 }
 func (m *CompactLogResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -958,7 +961,7 @@ func (m *TransferLeaderRequest) Reset()         { *m = TransferLeaderRequest{} }
 func (m *TransferLeaderRequest) String() string { return proto.CompactTextString(m) }
 func (*TransferLeaderRequest) ProtoMessage()    {}
 func (*TransferLeaderRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{16}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{16}
 }
 func (m *TransferLeaderRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1004,7 +1007,7 @@ func (m *TransferLeaderResponse) Reset()         { *m = TransferLeaderResponse{}
 func (m *TransferLeaderResponse) String() string { return proto.CompactTextString(m) }
 func (*TransferLeaderResponse) ProtoMessage()    {}
 func (*TransferLeaderResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{17}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{17}
 }
 func (m *TransferLeaderResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1033,11 +1036,101 @@ func (m *TransferLeaderResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_TransferLeaderResponse proto.InternalMessageInfo
 
+// RebuildPeerRequest makes the leader send a snapshot to the peer, which drops
+// its state and installs it, so a corrupted or lagging replica is repaired
+// without removing and adding it back.
+type RebuildPeerRequest struct {
+	Peer                 *metapb.Peer `protobuf:"bytes,1,opt,name=peer" json:"peer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RebuildPeerRequest) Reset()         { *m = RebuildPeerRequest{} }
+func (m *RebuildPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*RebuildPeerRequest) ProtoMessage()    {}
+func (*RebuildPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{18}
+}
+func (m *RebuildPeerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RebuildPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RebuildPeerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *RebuildPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildPeerRequest.Merge(dst, src)
+}
+func (m *RebuildPeerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RebuildPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildPeerRequest proto.InternalMessageInfo
+
+func (m *RebuildPeerRequest) GetPeer() *metapb.Peer {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
+}
+
+type RebuildPeerResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RebuildPeerResponse) Reset()         { *m = RebuildPeerResponse{} }
+func (m *RebuildPeerResponse) String() string { return proto.CompactTextString(m) }
+func (*RebuildPeerResponse) ProtoMessage()    {}
+func (*RebuildPeerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{19}
+}
+func (m *RebuildPeerResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RebuildPeerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RebuildPeerResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *RebuildPeerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RebuildPeerResponse.Merge(dst, src)
+}
+func (m *RebuildPeerResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RebuildPeerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RebuildPeerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RebuildPeerResponse proto.InternalMessageInfo
+
 type AdminRequest struct {
 	CmdType              AdminCmdType           `protobuf:"varint,1,opt,name=cmd_type,json=cmdType,proto3,enum=raft_cmdpb.AdminCmdType" json:"cmd_type,omitempty"`
 	ChangePeer           *ChangePeerRequest     `protobuf:"bytes,2,opt,name=change_peer,json=changePeer" json:"change_peer,omitempty"`
 	CompactLog           *CompactLogRequest     `protobuf:"bytes,4,opt,name=compact_log,json=compactLog" json:"compact_log,omitempty"`
 	TransferLeader       *TransferLeaderRequest `protobuf:"bytes,5,opt,name=transfer_leader,json=transferLeader" json:"transfer_leader,omitempty"`
+	RebuildPeer          *RebuildPeerRequest    `protobuf:"bytes,6,opt,name=rebuild_peer,json=rebuildPeer" json:"rebuild_peer,omitempty"`
 	Split                *SplitRequest          `protobuf:"bytes,10,opt,name=split" json:"split,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
@@ -1048,7 +1141,7 @@ func (m *AdminRequest) Reset()         { *m = AdminRequest{} }
 func (m *AdminRequest) String() string { return proto.CompactTextString(m) }
 func (*AdminRequest) ProtoMessage()    {}
 func (*AdminRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{18}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{20}
 }
 func (m *AdminRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1105,6 +1198,13 @@ func (m *AdminRequest) GetTransferLeader() *TransferLeaderRequest {
 	return nil
 }
 
+func (m *AdminRequest) GetRebuildPeer() *RebuildPeerRequest {
+	if m != nil {
+		return m.RebuildPeer
+	}
+	return nil
+}
+
 func (m *AdminRequest) GetSplit() *SplitRequest {
 	if m != nil {
 		return m.Split
@@ -1117,6 +1217,7 @@ type AdminResponse struct {
 	ChangePeer           *ChangePeerResponse     `protobuf:"bytes,2,opt,name=change_peer,json=changePeer" json:"change_peer,omitempty"`
 	CompactLog           *CompactLogResponse     `protobuf:"bytes,4,opt,name=compact_log,json=compactLog" json:"compact_log,omitempty"`
 	TransferLeader       *TransferLeaderResponse `protobuf:"bytes,5,opt,name=transfer_leader,json=transferLeader" json:"transfer_leader,omitempty"`
+	RebuildPeer          *RebuildPeerResponse    `protobuf:"bytes,6,opt,name=rebuild_peer,json=rebuildPeer" json:"rebuild_peer,omitempty"`
 	Split                *SplitResponse          `protobuf:"bytes,10,opt,name=split" json:"split,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
 	XXX_unrecognized     []byte                  `json:"-"`
@@ -1127,7 +1228,7 @@ func (m *AdminResponse) Reset()         { *m = AdminResponse{} }
 func (m *AdminResponse) String() string { return proto.CompactTextString(m) }
 func (*AdminResponse) ProtoMessage()    {}
 func (*AdminResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{19}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{21}
 }
 func (m *AdminResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1184,6 +1285,13 @@ func (m *AdminResponse) GetTransferLeader() *TransferLeaderResponse {
 	return nil
 }
 
+func (m *AdminResponse) GetRebuildPeer() *RebuildPeerResponse {
+	if m != nil {
+		return m.RebuildPeer
+	}
+	return nil
+}
+
 func (m *AdminResponse) GetSplit() *SplitResponse {
 	if m != nil {
 		return m.Split
@@ -1192,20 +1300,23 @@ func (m *AdminResponse) GetSplit() *SplitResponse {
 }
 
 type RaftRequestHeader struct {
-	RegionId             uint64              `protobuf:"varint,1,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
-	Peer                 *metapb.Peer        `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
-	RegionEpoch          *metapb.RegionEpoch `protobuf:"bytes,4,opt,name=region_epoch,json=regionEpoch" json:"region_epoch,omitempty"`
-	Term                 uint64              `protobuf:"varint,5,opt,name=term,proto3" json:"term,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	RegionId    uint64              `protobuf:"varint,1,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	Peer        *metapb.Peer        `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
+	RegionEpoch *metapb.RegionEpoch `protobuf:"bytes,4,opt,name=region_epoch,json=regionEpoch" json:"region_epoch,omitempty"`
+	Term        uint64              `protobuf:"varint,5,opt,name=term,proto3" json:"term,omitempty"`
+	// If non-zero, the command is held back until the peer has applied at least
+	// this index.
+	AppliedIndex         uint64   `protobuf:"varint,6,opt,name=applied_index,json=appliedIndex,proto3" json:"applied_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *RaftRequestHeader) Reset()         { *m = RaftRequestHeader{} }
 func (m *RaftRequestHeader) String() string { return proto.CompactTextString(m) }
 func (*RaftRequestHeader) ProtoMessage()    {}
 func (*RaftRequestHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{20}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{22}
 }
 func (m *RaftRequestHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1262,6 +1373,13 @@ func (m *RaftRequestHeader) GetTerm() uint64 {
 	return 0
 }
 
+func (m *RaftRequestHeader) GetAppliedIndex() uint64 {
+	if m != nil {
+		return m.AppliedIndex
+	}
+	return 0
+}
+
 type RaftResponseHeader struct {
 	Error                *errorpb.Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
 	Uuid                 []byte         `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
@@ -1275,7 +1393,7 @@ func (m *RaftResponseHeader) Reset()         { *m = RaftResponseHeader{} }
 func (m *RaftResponseHeader) String() string { return proto.CompactTextString(m) }
 func (*RaftResponseHeader) ProtoMessage()    {}
 func (*RaftResponseHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{21}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{23}
 }
 func (m *RaftResponseHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1340,7 +1458,7 @@ func (m *RaftCmdRequest) Reset()         { *m = RaftCmdRequest{} }
 func (m *RaftCmdRequest) String() string { return proto.CompactTextString(m) }
 func (*RaftCmdRequest) ProtoMessage()    {}
 func (*RaftCmdRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{22}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{24}
 }
 func (m *RaftCmdRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1403,7 +1521,7 @@ func (m *RaftCmdResponse) Reset()         { *m = RaftCmdResponse{} }
 func (m *RaftCmdResponse) String() string { return proto.CompactTextString(m) }
 func (*RaftCmdResponse) ProtoMessage()    {}
 func (*RaftCmdResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_cmdpb_409ff26e8ae8c248, []int{23}
+	return fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1, []int{25}
 }
 func (m *RaftCmdResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1472,6 +1590,8 @@ func init() {
 	proto.RegisterType((*CompactLogResponse)(nil), "raft_cmdpb.CompactLogResponse")
 	proto.RegisterType((*TransferLeaderRequest)(nil), "raft_cmdpb.TransferLeaderRequest")
 	proto.RegisterType((*TransferLeaderResponse)(nil), "raft_cmdpb.TransferLeaderResponse")
+	proto.RegisterType((*RebuildPeerRequest)(nil), "raft_cmdpb.RebuildPeerRequest")
+	proto.RegisterType((*RebuildPeerResponse)(nil), "raft_cmdpb.RebuildPeerResponse")
 	proto.RegisterType((*AdminRequest)(nil), "raft_cmdpb.AdminRequest")
 	proto.RegisterType((*AdminResponse)(nil), "raft_cmdpb.AdminResponse")
 	proto.RegisterType((*RaftRequestHeader)(nil), "raft_cmdpb.RaftRequestHeader")
@@ -2092,6 +2212,58 @@ func (m *TransferLeaderResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *RebuildPeerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RebuildPeerRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Peer != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.Peer.Size()))
+		n15, err := m.Peer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n15
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RebuildPeerResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RebuildPeerResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *AdminRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2116,41 +2288,51 @@ func (m *AdminRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.ChangePeer.Size()))
-		n15, err := m.ChangePeer.MarshalTo(dAtA[i:])
+		n16, err := m.ChangePeer.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n15
+		i += n16
 	}
 	if m.CompactLog != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.CompactLog.Size()))
-		n16, err := m.CompactLog.MarshalTo(dAtA[i:])
+		n17, err := m.CompactLog.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n16
+		i += n17
 	}
 	if m.TransferLeader != nil {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n17, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		n18, err := m.TransferLeader.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n17
+		i += n18
+	}
+	if m.RebuildPeer != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.RebuildPeer.Size()))
+		n19, err := m.RebuildPeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n19
 	}
 	if m.Split != nil {
 		dAtA[i] = 0x52
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.Split.Size()))
-		n18, err := m.Split.MarshalTo(dAtA[i:])
+		n20, err := m.Split.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n18
+		i += n20
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -2182,41 +2364,51 @@ func (m *AdminResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.ChangePeer.Size()))
-		n19, err := m.ChangePeer.MarshalTo(dAtA[i:])
+		n21, err := m.ChangePeer.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n19
+		i += n21
 	}
 	if m.CompactLog != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.CompactLog.Size()))
-		n20, err := m.CompactLog.MarshalTo(dAtA[i:])
+		n22, err := m.CompactLog.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n20
+		i += n22
 	}
 	if m.TransferLeader != nil {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n21, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		n23, err := m.TransferLeader.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n21
+		i += n23
+	}
+	if m.RebuildPeer != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.RebuildPeer.Size()))
+		n24, err := m.RebuildPeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
 	}
 	if m.Split != nil {
 		dAtA[i] = 0x52
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.Split.Size()))
-		n22, err := m.Split.MarshalTo(dAtA[i:])
+		n25, err := m.Split.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n22
+		i += n25
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -2248,27 +2440,32 @@ func (m *RaftRequestHeader) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.Peer.Size()))
-		n23, err := m.Peer.MarshalTo(dAtA[i:])
+		n26, err := m.Peer.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n23
+		i += n26
 	}
 	if m.RegionEpoch != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.RegionEpoch.Size()))
-		n24, err := m.RegionEpoch.MarshalTo(dAtA[i:])
+		n27, err := m.RegionEpoch.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n24
+		i += n27
 	}
 	if m.Term != 0 {
 		dAtA[i] = 0x28
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.Term))
 	}
+	if m.AppliedIndex != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.AppliedIndex))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -2294,11 +2491,11 @@ func (m *RaftResponseHeader) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.Error.Size()))
-		n25, err := m.Error.MarshalTo(dAtA[i:])
+		n28, err := m.Error.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n25
+		i += n28
 	}
 	if len(m.Uuid) > 0 {
 		dAtA[i] = 0x12
@@ -2336,11 +2533,11 @@ func (m *RaftCmdRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.Header.Size()))
-		n26, err := m.Header.MarshalTo(dAtA[i:])
+		n29, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n26
+		i += n29
 	}
 	if len(m.Requests) > 0 {
 		for _, msg := range m.Requests {
@@ -2358,11 +2555,11 @@ func (m *RaftCmdRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.AdminRequest.Size()))
-		n27, err := m.AdminRequest.MarshalTo(dAtA[i:])
+		n30, err := m.AdminRequest.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n27
+		i += n30
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -2389,11 +2586,11 @@ func (m *RaftCmdResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.Header.Size()))
-		n28, err := m.Header.MarshalTo(dAtA[i:])
+		n31, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n28
+		i += n31
 	}
 	if len(m.Responses) > 0 {
 		for _, msg := range m.Responses {
@@ -2411,11 +2608,11 @@ func (m *RaftCmdResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintRaftCmdpb(dAtA, i, uint64(m.AdminResponse.Size()))
-		n29, err := m.AdminResponse.MarshalTo(dAtA[i:])
+		n32, err := m.AdminResponse.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n29
+		i += n32
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -2709,6 +2906,28 @@ func (m *TransferLeaderResponse) Size() (n int) {
 	return n
 }
 
+func (m *RebuildPeerRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovRaftCmdpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RebuildPeerResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *AdminRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -2727,6 +2946,10 @@ func (m *AdminRequest) Size() (n int) {
 		l = m.TransferLeader.Size()
 		n += 1 + l + sovRaftCmdpb(uint64(l))
 	}
+	if m.RebuildPeer != nil {
+		l = m.RebuildPeer.Size()
+		n += 1 + l + sovRaftCmdpb(uint64(l))
+	}
 	if m.Split != nil {
 		l = m.Split.Size()
 		n += 1 + l + sovRaftCmdpb(uint64(l))
@@ -2755,6 +2978,10 @@ func (m *AdminResponse) Size() (n int) {
 		l = m.TransferLeader.Size()
 		n += 1 + l + sovRaftCmdpb(uint64(l))
 	}
+	if m.RebuildPeer != nil {
+		l = m.RebuildPeer.Size()
+		n += 1 + l + sovRaftCmdpb(uint64(l))
+	}
 	if m.Split != nil {
 		l = m.Split.Size()
 		n += 1 + l + sovRaftCmdpb(uint64(l))
@@ -2782,6 +3009,9 @@ func (m *RaftRequestHeader) Size() (n int) {
 	if m.Term != 0 {
 		n += 1 + sovRaftCmdpb(uint64(m.Term))
 	}
+	if m.AppliedIndex != 0 {
+		n += 1 + sovRaftCmdpb(uint64(m.AppliedIndex))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -4661,6 +4891,141 @@ func (m *TransferLeaderResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RebuildPeerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftCmdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RebuildPeerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RebuildPeerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftCmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftCmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Peer == nil {
+				m.Peer = &metapb.Peer{}
+			}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftCmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftCmdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RebuildPeerResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftCmdpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RebuildPeerResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RebuildPeerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftCmdpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthRaftCmdpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4808,6 +5173,39 @@ func (m *AdminRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RebuildPeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftCmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftCmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RebuildPeer == nil {
+				m.RebuildPeer = &RebuildPeerRequest{}
+			}
+			if err := m.RebuildPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 10:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Split", wireType)
@@ -5010,6 +5408,39 @@ func (m *AdminResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RebuildPeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftCmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftCmdpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RebuildPeer == nil {
+				m.RebuildPeer = &RebuildPeerResponse{}
+			}
+			if err := m.RebuildPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 10:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Split", wireType)
@@ -5198,6 +5629,25 @@ func (m *RaftRequestHeader) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedIndex", wireType)
+			}
+			m.AppliedIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftCmdpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppliedIndex |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftCmdpb(dAtA[iNdEx:])
@@ -5755,75 +6205,80 @@ var (
 	ErrIntOverflowRaftCmdpb   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("raft_cmdpb.proto", fileDescriptor_raft_cmdpb_409ff26e8ae8c248) }
-
-var fileDescriptor_raft_cmdpb_409ff26e8ae8c248 = []byte{
-	// 1068 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x0e, 0x45, 0xea, 0xc7, 0x43, 0x52, 0xa1, 0x37, 0x6e, 0x4c, 0x3b, 0xa8, 0xa0, 0x30, 0x45,
-	0xe1, 0xa4, 0x85, 0x82, 0x38, 0xa8, 0xd1, 0x00, 0x6d, 0xd2, 0xd6, 0x09, 0x52, 0x37, 0x39, 0x18,
-	0x1b, 0xdf, 0x7a, 0x20, 0x18, 0x72, 0x25, 0x0b, 0x95, 0x48, 0x9a, 0xa4, 0xe2, 0xfa, 0x4d, 0x7a,
-	0xea, 0x6b, 0xf4, 0x98, 0x4b, 0x0f, 0x3d, 0xf6, 0x11, 0x0a, 0xf7, 0xdc, 0x4b, 0x9f, 0xa0, 0xd8,
-	0xdd, 0x59, 0xfe, 0x48, 0x72, 0x9b, 0xf4, 0x24, 0xee, 0xec, 0xcc, 0xc7, 0x6f, 0xbe, 0x9d, 0x6f,
-	0x45, 0x70, 0xb2, 0x60, 0x5c, 0xf8, 0xe1, 0x3c, 0x4a, 0x5f, 0x8f, 0xd2, 0x2c, 0x29, 0x12, 0x02,
-	0x55, 0x64, 0xd7, 0x9a, 0xb3, 0x22, 0x50, 0x3b, 0xbb, 0x36, 0xcb, 0xb2, 0x24, 0xab, 0x2f, 0x83,
-	0x71, 0xa1, 0x96, 0xde, 0x08, 0xe0, 0x39, 0x2b, 0x28, 0x3b, 0x5b, 0xb0, 0xbc, 0x20, 0x7d, 0x68,
-	0x85, 0x63, 0x57, 0x1b, 0x6a, 0x7b, 0x1b, 0xb4, 0x15, 0x8e, 0x89, 0x03, 0xfa, 0x0f, 0xec, 0xc2,
-	0x6d, 0x0d, 0xb5, 0x3d, 0x8b, 0xf2, 0x47, 0xef, 0x0e, 0x98, 0x22, 0x3f, 0x4f, 0x93, 0x38, 0x67,
-	0x64, 0x0b, 0xda, 0x6f, 0x82, 0xd9, 0x82, 0x89, 0x1a, 0x8b, 0xca, 0x85, 0xf7, 0x14, 0xe0, 0x78,
-	0xf1, 0xee, 0xa0, 0x15, 0x8a, 0x5e, 0x47, 0xb1, 0xc1, 0x14, 0x28, 0xf2, 0x55, 0xde, 0x03, 0xb0,
-	0x9f, 0xb2, 0x19, 0x2b, 0xd8, 0xbb, 0x93, 0x75, 0xa0, 0xaf, 0x4a, 0x10, 0xc4, 0x06, 0xf3, 0x55,
-	0x1c, 0xa4, 0x08, 0xe1, 0x1d, 0x80, 0x25, 0x97, 0xd8, 0xce, 0xc7, 0xd0, 0xc9, 0xd8, 0x64, 0x9a,
-	0xc4, 0x02, 0xd6, 0xdc, 0xef, 0x8f, 0x50, 0x4a, 0x2a, 0xa2, 0x14, 0x77, 0xbd, 0xbf, 0x34, 0xe8,
-	0x2a, 0x1a, 0x23, 0xe8, 0x85, 0xf3, 0xc8, 0x2f, 0x2e, 0x52, 0xa9, 0x42, 0x7f, 0xff, 0xc6, 0xa8,
-	0x76, 0x3c, 0x87, 0xf3, 0xe8, 0xe4, 0x22, 0x65, 0xb4, 0x1b, 0xca, 0x07, 0xb2, 0x07, 0xfa, 0x84,
-	0x15, 0x82, 0xa6, 0xb9, 0x7f, 0xb3, 0x9e, 0x5a, 0x1d, 0x04, 0xe5, 0x29, 0x3c, 0x33, 0x5d, 0x14,
-	0xae, 0xb1, 0x9a, 0x59, 0xa9, 0x4b, 0x79, 0x0a, 0x79, 0x00, 0x9d, 0x48, 0x34, 0xea, 0xb6, 0x45,
-	0xf2, 0x4e, 0x3d, 0xb9, 0xa1, 0x1a, 0xc5, 0x44, 0xf2, 0x09, 0x18, 0x79, 0x1c, 0xa4, 0x6e, 0x47,
-	0x14, 0x6c, 0xd7, 0x0b, 0x6a, 0x0a, 0x51, 0x91, 0xe4, 0xfd, 0xad, 0x41, 0xaf, 0x14, 0xe9, 0x7d,
-	0x1b, 0xbe, 0x5b, 0x6f, 0x78, 0x7b, 0xa5, 0x61, 0x89, 0x2a, 0x3b, 0xbe, 0x5b, 0xef, 0x78, 0x7b,
-	0xa5, 0x63, 0x95, 0xca, 0x5b, 0xde, 0x5f, 0x6a, 0x79, 0x77, 0x5d, 0xcb, 0x58, 0xa0, 0x7a, 0xfe,
-	0xb4, 0xd1, 0xb3, 0xbb, 0xda, 0x33, 0xe6, 0xcb, 0xa6, 0x13, 0xd8, 0x3c, 0x3c, 0x0d, 0xe2, 0x09,
-	0x3b, 0x66, 0x2c, 0x53, 0xa7, 0xfd, 0x39, 0x98, 0xa1, 0x08, 0xd6, 0xfb, 0xdf, 0x1e, 0x29, 0x53,
-	0x1d, 0x26, 0xf1, 0x58, 0x16, 0x09, 0x0d, 0x20, 0x2c, 0x9f, 0xc9, 0x10, 0x8c, 0x94, 0xb1, 0x0c,
-	0x75, 0xb0, 0xd4, 0x64, 0x09, 0x70, 0xb1, 0xe3, 0x7d, 0x01, 0xa4, 0xfe, 0xc2, 0xf7, 0x9c, 0xc9,
-	0x33, 0xb0, 0x5e, 0xa5, 0xb3, 0x69, 0x69, 0xbb, 0x5b, 0xb0, 0x91, 0xf3, 0xb5, 0xcf, 0x4d, 0x21,
-	0xed, 0xd9, 0x13, 0x81, 0x17, 0xec, 0x82, 0x78, 0x60, 0xc7, 0xec, 0xdc, 0x97, 0xa5, 0xfe, 0x34,
-	0x12, 0xac, 0x0c, 0x6a, 0xc6, 0xec, 0x5c, 0xc2, 0x1e, 0x45, 0x64, 0x08, 0x16, 0xcf, 0xe1, 0xd4,
-	0xfc, 0x69, 0x94, 0xbb, 0xfa, 0x50, 0xdf, 0x33, 0x28, 0xc4, 0xec, 0x9c, 0xf3, 0x3b, 0x8a, 0x72,
-	0xef, 0x11, 0xd8, 0xf8, 0x4a, 0xe4, 0xba, 0x07, 0x5d, 0x09, 0x99, 0xbb, 0xda, 0x50, 0x5f, 0x43,
-	0x56, 0x6d, 0x7b, 0xdf, 0xc3, 0xe6, 0x61, 0x32, 0x4f, 0x83, 0xb0, 0x78, 0x99, 0x4c, 0x14, 0xe5,
-	0x3b, 0x60, 0x87, 0x32, 0xe8, 0x4f, 0xe3, 0x88, 0xfd, 0x28, 0x68, 0x1b, 0xd4, 0xc2, 0xe0, 0x11,
-	0x8f, 0x91, 0xdb, 0xa0, 0xd6, 0x7e, 0xc1, 0xb2, 0xb9, 0x62, 0x8e, 0xb1, 0x13, 0x96, 0xcd, 0xbd,
-	0x2d, 0x20, 0x75, 0x70, 0xf4, 0xfe, 0x23, 0xf8, 0xe0, 0x24, 0x0b, 0xe2, 0x7c, 0xcc, 0xb2, 0x97,
-	0x2c, 0x88, 0xaa, 0x33, 0x55, 0x27, 0xa3, 0x5d, 0x79, 0x32, 0x2e, 0xdc, 0x5c, 0x2e, 0x45, 0xd0,
-	0xb7, 0x2d, 0xb0, 0xbe, 0x8e, 0xe6, 0xd3, 0x58, 0x81, 0x3d, 0x5c, 0x71, 0x47, 0x63, 0xce, 0x44,
-	0xee, 0x8a, 0x45, 0x1e, 0x97, 0x53, 0x55, 0x1b, 0x91, 0x0f, 0x1b, 0xae, 0x5a, 0x9e, 0x44, 0x35,
-	0x5b, 0x3c, 0x24, 0xea, 0x51, 0x93, 0x59, 0x32, 0x41, 0xff, 0x34, 0xeb, 0x97, 0xc5, 0xa6, 0x10,
-	0x96, 0x21, 0xf2, 0x1d, 0x5c, 0x2f, 0xb0, 0x3f, 0x7f, 0x26, 0x1a, 0x44, 0x57, 0xdd, 0xae, 0x63,
-	0xac, 0x55, 0x8f, 0xf6, 0x8b, 0x46, 0x98, 0x8c, 0xa0, 0x2d, 0xc6, 0xcc, 0x85, 0x35, 0x2e, 0xab,
-	0x0d, 0x28, 0x95, 0x69, 0xde, 0xaf, 0x2d, 0xb0, 0x51, 0x41, 0x9c, 0xa2, 0xff, 0x25, 0xe1, 0x93,
-	0x75, 0x12, 0x0e, 0xae, 0x92, 0x10, 0x8d, 0x5e, 0xd7, 0xf0, 0xc9, 0x3a, 0x0d, 0x07, 0x57, 0x69,
-	0x58, 0x02, 0x54, 0x22, 0xbe, 0xb8, 0x4a, 0x44, 0xef, 0xdf, 0x44, 0x44, 0xa0, 0x65, 0x15, 0xef,
-	0x37, 0x55, 0xdc, 0x59, 0xa3, 0x22, 0x56, 0xa2, 0x8c, 0x3f, 0x6b, 0xb0, 0x49, 0x83, 0xb1, 0x52,
-	0xf7, 0x5b, 0x09, 0x73, 0x0b, 0x36, 0x2a, 0x8f, 0x4b, 0x37, 0xf5, 0xb2, 0xca, 0xe0, 0xff, 0x71,
-	0x23, 0x91, 0x03, 0xb0, 0xb0, 0x9c, 0xa5, 0x49, 0x78, 0x8a, 0xa2, 0xdc, 0x68, 0x9a, 0xfa, 0x19,
-	0xdf, 0xa2, 0x66, 0x56, 0x2d, 0x08, 0x01, 0x43, 0x78, 0xb3, 0x2d, 0xde, 0x28, 0x9e, 0xbd, 0x33,
-	0x20, 0x92, 0x9f, 0xe4, 0x8d, 0x04, 0x3f, 0x82, 0xb6, 0xf8, 0x3e, 0x29, 0x2f, 0x37, 0xf5, 0xb5,
-	0xf2, 0x8c, 0xff, 0x52, 0xb9, 0xc9, 0xf1, 0x16, 0x0b, 0xbc, 0xa5, 0x2c, 0x2a, 0x9e, 0xc5, 0x3d,
-	0xb0, 0xc8, 0x32, 0x16, 0xe3, 0x3d, 0xa0, 0xe3, 0x3d, 0x20, 0x63, 0xe2, 0x1e, 0xf8, 0x45, 0x83,
-	0x3e, 0x7f, 0xe7, 0xe1, 0x3c, 0x52, 0xf6, 0xfc, 0x0c, 0x3a, 0xa7, 0xf2, 0x6c, 0xb4, 0x55, 0x93,
-	0xac, 0xe8, 0x47, 0x31, 0x99, 0xdc, 0x87, 0x5e, 0x26, 0x37, 0x72, 0xb7, 0x25, 0x6e, 0xb6, 0xc6,
-	0x7f, 0x9e, 0x1a, 0xe9, 0x32, 0x89, 0x7c, 0x09, 0x76, 0xc0, 0xe7, 0xd4, 0xc7, 0x88, 0xa0, 0x67,
-	0xae, 0x19, 0x64, 0x55, 0x6a, 0x05, 0xb5, 0x95, 0xf7, 0x56, 0x83, 0xeb, 0x25, 0x73, 0xb4, 0xc5,
-	0xc1, 0x12, 0xf5, 0xc1, 0x2a, 0xf5, 0xba, 0xb4, 0x25, 0xf7, 0x7d, 0x3e, 0x03, 0x72, 0x47, 0x91,
-	0xdf, 0x6a, 0x92, 0xc7, 0x49, 0xaa, 0xd2, 0xc8, 0x57, 0xd0, 0x57, 0xf4, 0x65, 0x08, 0xf9, 0xef,
-	0xac, 0xe1, 0x8f, 0xd5, 0x76, 0x50, 0x5f, 0xde, 0x7b, 0x0c, 0x5d, 0xf4, 0x28, 0x31, 0xa1, 0x7b,
-	0x14, 0xbf, 0x09, 0x66, 0xd3, 0xc8, 0xb9, 0x46, 0xba, 0xa0, 0x3f, 0x67, 0x85, 0xa3, 0xf1, 0x87,
-	0xe3, 0x45, 0xe1, 0xe8, 0x04, 0xa0, 0x23, 0xff, 0xaf, 0x1d, 0x83, 0xf4, 0xc0, 0xe0, 0xff, 0xc4,
-	0x4e, 0xfb, 0x9e, 0x8f, 0xf7, 0xaa, 0x02, 0x71, 0xc0, 0x42, 0x10, 0x11, 0x76, 0xae, 0x91, 0x3e,
-	0x40, 0x65, 0x69, 0x47, 0x13, 0xeb, 0xd2, 0x8d, 0x8e, 0x4e, 0x08, 0xf4, 0x9b, 0x66, 0x73, 0x0c,
-	0xb2, 0x01, 0x6d, 0xe1, 0x1e, 0x07, 0xbe, 0x71, 0x7e, 0xbb, 0x1c, 0x68, 0xbf, 0x5f, 0x0e, 0xb4,
-	0x3f, 0x2e, 0x07, 0xda, 0x4f, 0x7f, 0x0e, 0xae, 0xbd, 0xee, 0x88, 0x4f, 0xe2, 0x87, 0xff, 0x04,
-	0x00, 0x00, 0xff, 0xff, 0x26, 0xb1, 0x93, 0xfc, 0x5e, 0x0b, 0x00, 0x00,
+func init() { proto.RegisterFile("raft_cmdpb.proto", fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1) }
+
+var fileDescriptor_raft_cmdpb_e66673d7e9cd0ee1 = []byte{
+	// 1142 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xcd, 0x72, 0xdc, 0x44,
+	0x10, 0xb6, 0x2c, 0xed, 0x8f, 0x7b, 0xa5, 0xb5, 0x3c, 0x76, 0x62, 0xc5, 0x29, 0x96, 0x8d, 0x42,
+	0x51, 0x4e, 0xa0, 0x36, 0x15, 0xa7, 0x70, 0x91, 0x2a, 0x48, 0x48, 0x9c, 0x54, 0x30, 0xc9, 0xc1,
+	0x35, 0xf1, 0x8d, 0x83, 0x4a, 0x96, 0x66, 0xed, 0x2d, 0x76, 0x25, 0x59, 0x3f, 0x31, 0xbe, 0x72,
+	0xe2, 0x11, 0x78, 0x13, 0x8e, 0x1c, 0xb8, 0x70, 0xe4, 0x11, 0x28, 0x73, 0xe6, 0xc2, 0x13, 0x50,
+	0xf3, 0x27, 0x8d, 0x56, 0xbb, 0x10, 0x73, 0xb2, 0xa6, 0xa7, 0xfb, 0x53, 0x77, 0x7f, 0xdd, 0xdf,
+	0xca, 0x60, 0xa7, 0xfe, 0x38, 0xf7, 0x82, 0x59, 0x98, 0x9c, 0x8c, 0x92, 0x34, 0xce, 0x63, 0x04,
+	0x95, 0x65, 0xc7, 0x9c, 0x91, 0xdc, 0x97, 0x37, 0x3b, 0x16, 0x49, 0xd3, 0x38, 0x55, 0x8f, 0xfe,
+	0x38, 0x97, 0x47, 0x77, 0x04, 0xf0, 0x8a, 0xe4, 0x98, 0x9c, 0x17, 0x24, 0xcb, 0x51, 0x1f, 0x56,
+	0x83, 0xb1, 0xa3, 0x0d, 0xb5, 0xdd, 0x35, 0xbc, 0x1a, 0x8c, 0x91, 0x0d, 0xfa, 0x77, 0xe4, 0xd2,
+	0x59, 0x1d, 0x6a, 0xbb, 0x26, 0xa6, 0x8f, 0xee, 0x5d, 0xe8, 0x31, 0xff, 0x2c, 0x89, 0xa3, 0x8c,
+	0xa0, 0x2d, 0x68, 0xbd, 0xf3, 0xa7, 0x05, 0x61, 0x31, 0x26, 0xe6, 0x07, 0xf7, 0x05, 0xc0, 0x51,
+	0xf1, 0xfe, 0xa0, 0x15, 0x8a, 0xae, 0xa2, 0x58, 0xd0, 0x3b, 0x2a, 0xca, 0x57, 0xb9, 0x0f, 0xc1,
+	0x7a, 0x41, 0xa6, 0x24, 0x27, 0xef, 0x9f, 0xac, 0x0d, 0x7d, 0x19, 0x22, 0x40, 0x2c, 0xe8, 0xbd,
+	0x8d, 0xfc, 0x44, 0x40, 0xb8, 0xfb, 0x60, 0xf2, 0xa3, 0x28, 0xe7, 0x63, 0x68, 0xa7, 0xe4, 0x74,
+	0x12, 0x47, 0x0c, 0xb6, 0xb7, 0xd7, 0x1f, 0x89, 0x56, 0x62, 0x66, 0xc5, 0xe2, 0xd6, 0xfd, 0x4b,
+	0x83, 0x8e, 0x4c, 0x63, 0x04, 0xdd, 0x60, 0x16, 0x7a, 0xf9, 0x65, 0xc2, 0xbb, 0xd0, 0xdf, 0xdb,
+	0x1c, 0x29, 0xf4, 0x1c, 0xcc, 0xc2, 0xe3, 0xcb, 0x84, 0xe0, 0x4e, 0xc0, 0x1f, 0xd0, 0x2e, 0xe8,
+	0xa7, 0x24, 0x67, 0x69, 0xf6, 0xf6, 0x6e, 0xaa, 0xae, 0x15, 0x11, 0x98, 0xba, 0x50, 0xcf, 0xa4,
+	0xc8, 0x1d, 0xa3, 0xe9, 0x59, 0x75, 0x17, 0x53, 0x17, 0xf4, 0x10, 0xda, 0x21, 0x2b, 0xd4, 0x69,
+	0x31, 0xe7, 0x5b, 0xaa, 0x73, 0xad, 0x6b, 0x58, 0x38, 0xa2, 0x4f, 0xc0, 0xc8, 0x22, 0x3f, 0x71,
+	0xda, 0x2c, 0x60, 0x5b, 0x0d, 0x50, 0x3a, 0x84, 0x99, 0x93, 0xfb, 0xb7, 0x06, 0xdd, 0xb2, 0x49,
+	0xd7, 0x2d, 0xf8, 0x9e, 0x5a, 0xf0, 0x76, 0xa3, 0x60, 0x8e, 0xca, 0x2b, 0xbe, 0xa7, 0x56, 0xbc,
+	0xdd, 0xa8, 0x58, 0xba, 0xd2, 0x92, 0xf7, 0xe6, 0x4a, 0xde, 0x59, 0x54, 0xb2, 0x08, 0x90, 0x35,
+	0x7f, 0x5a, 0xab, 0xd9, 0x69, 0xd6, 0x2c, 0xfc, 0x79, 0xd1, 0x31, 0x6c, 0x1c, 0x9c, 0xf9, 0xd1,
+	0x29, 0x39, 0x22, 0x24, 0x95, 0x6c, 0x7f, 0x0e, 0xbd, 0x80, 0x19, 0xd5, 0xfa, 0xb7, 0x47, 0x72,
+	0xa9, 0x0e, 0xe2, 0x68, 0xcc, 0x83, 0x58, 0x0f, 0x20, 0x28, 0x9f, 0xd1, 0x10, 0x8c, 0x84, 0x90,
+	0x54, 0xf4, 0xc1, 0x94, 0x93, 0xc5, 0xc0, 0xd9, 0x8d, 0xfb, 0x05, 0x20, 0xf5, 0x85, 0xd7, 0x9c,
+	0xc9, 0x73, 0x30, 0xdf, 0x26, 0xd3, 0x49, 0xb9, 0x76, 0xb7, 0x61, 0x2d, 0xa3, 0x67, 0x8f, 0x2e,
+	0x05, 0x5f, 0xcf, 0x2e, 0x33, 0xbc, 0x26, 0x97, 0xc8, 0x05, 0x2b, 0x22, 0x17, 0x1e, 0x0f, 0xf5,
+	0x26, 0x21, 0xcb, 0xca, 0xc0, 0xbd, 0x88, 0x5c, 0x70, 0xd8, 0xc3, 0x10, 0x0d, 0xc1, 0xa4, 0x3e,
+	0x34, 0x35, 0x6f, 0x12, 0x66, 0x8e, 0x3e, 0xd4, 0x77, 0x0d, 0x0c, 0x11, 0xb9, 0xa0, 0xf9, 0x1d,
+	0x86, 0x99, 0xfb, 0x18, 0x2c, 0xf1, 0x4a, 0x91, 0xeb, 0x2e, 0x74, 0x38, 0x64, 0xe6, 0x68, 0x43,
+	0x7d, 0x41, 0xb2, 0xf2, 0xda, 0xfd, 0x16, 0x36, 0x0e, 0xe2, 0x59, 0xe2, 0x07, 0xf9, 0x9b, 0xf8,
+	0x54, 0xa6, 0x7c, 0x17, 0xac, 0x80, 0x1b, 0xbd, 0x49, 0x14, 0x92, 0xef, 0x59, 0xda, 0x06, 0x36,
+	0x85, 0xf1, 0x90, 0xda, 0xd0, 0x1d, 0x90, 0x67, 0x2f, 0x27, 0xe9, 0x4c, 0x66, 0x2e, 0x6c, 0xc7,
+	0x24, 0x9d, 0xb9, 0x5b, 0x80, 0x54, 0x70, 0xb1, 0xfb, 0x8f, 0xe1, 0xc6, 0x71, 0xea, 0x47, 0xd9,
+	0x98, 0xa4, 0x6f, 0x88, 0x1f, 0x56, 0x9c, 0x4a, 0x66, 0xb4, 0xa5, 0xcc, 0x38, 0x70, 0x73, 0x3e,
+	0x54, 0x80, 0xee, 0x03, 0xc2, 0xe4, 0xa4, 0x98, 0x4c, 0xc3, 0x23, 0x72, 0x1d, 0xc4, 0x1b, 0xb0,
+	0x59, 0x8b, 0x13, 0x70, 0x3f, 0xe8, 0x60, 0x3e, 0x0b, 0x67, 0x93, 0x48, 0x22, 0x3d, 0x6a, 0x2c,
+	0x5b, 0x6d, 0x6c, 0x99, 0x6f, 0x63, 0xe3, 0x9e, 0x94, 0x43, 0xaa, 0x4c, 0xdc, 0x07, 0xb5, 0x25,
+	0x9d, 0x1f, 0x6c, 0x39, 0xaa, 0xd4, 0xc4, 0xe2, 0x45, 0x8b, 0xa7, 0xf1, 0xa9, 0x63, 0x2c, 0x88,
+	0x9f, 0xe7, 0x0e, 0x43, 0x50, 0x9a, 0xd0, 0x37, 0xb0, 0x9e, 0x8b, 0x76, 0x79, 0x53, 0xd6, 0x2f,
+	0xb1, 0xa4, 0x77, 0x54, 0x8c, 0x85, 0x64, 0xe0, 0x7e, 0x5e, 0x33, 0xa3, 0x67, 0x60, 0xa6, 0xbc,
+	0x51, 0xbc, 0x18, 0xbe, 0xbb, 0x03, 0x15, 0xa8, 0x49, 0x00, 0xee, 0xa5, 0x95, 0x0d, 0x8d, 0xa0,
+	0xc5, 0x06, 0xdf, 0x81, 0x05, 0x7b, 0xaf, 0xac, 0x0c, 0xe6, 0x6e, 0xee, 0x8f, 0x3a, 0x58, 0x82,
+	0x04, 0x31, 0xd7, 0xff, 0x8b, 0x85, 0xa7, 0x8b, 0x58, 0x18, 0x2c, 0x63, 0x41, 0x48, 0x8f, 0x4a,
+	0xc3, 0xd3, 0x45, 0x34, 0x0c, 0x96, 0xd1, 0x50, 0x02, 0x54, 0x3c, 0xbc, 0x5e, 0xc6, 0x83, 0xfb,
+	0x6f, 0x3c, 0x08, 0xa0, 0x79, 0x22, 0x9e, 0x2f, 0x24, 0xe2, 0xc3, 0xa5, 0x44, 0x08, 0x98, 0x1a,
+	0x13, 0x0f, 0xea, 0x4c, 0xdc, 0x5a, 0xc0, 0x84, 0x08, 0x13, 0x54, 0xfc, 0xaa, 0xc1, 0x06, 0xf6,
+	0xc7, 0x92, 0xa1, 0xaf, 0x79, 0x2a, 0xb7, 0x61, 0xad, 0x52, 0x2e, 0xae, 0x11, 0xdd, 0xb4, 0x92,
+	0xad, 0xff, 0xd0, 0x59, 0xb4, 0x0f, 0x26, 0xf7, 0xf6, 0x48, 0x12, 0x07, 0x67, 0xa2, 0xb1, 0x9b,
+	0x75, 0xa9, 0x7a, 0x49, 0xaf, 0x68, 0xf6, 0xe5, 0x01, 0x21, 0x30, 0x98, 0xe2, 0xb4, 0xd8, 0x1b,
+	0xd9, 0x33, 0x95, 0x2c, 0x3f, 0x49, 0xa6, 0x13, 0x12, 0x0a, 0xc9, 0x6a, 0x73, 0xc9, 0x12, 0x46,
+	0x26, 0x59, 0xee, 0x39, 0x20, 0x5e, 0x04, 0x2f, 0x4e, 0x54, 0xf1, 0x11, 0xb4, 0xd8, 0xa7, 0x59,
+	0xa9, 0xeb, 0xf2, 0x43, 0xed, 0x25, 0xfd, 0x8b, 0xf9, 0x25, 0x7d, 0x69, 0x51, 0x08, 0x81, 0x36,
+	0x31, 0x7b, 0x66, 0x12, 0x58, 0xa4, 0x29, 0x89, 0x84, 0x04, 0xea, 0x42, 0x02, 0xb9, 0x8d, 0x49,
+	0xe0, 0xcf, 0x1a, 0xf4, 0xe9, 0x3b, 0x0f, 0x66, 0xa1, 0x94, 0x92, 0xcf, 0xa0, 0x7d, 0xc6, 0x87,
+	0x40, 0x6b, 0x2e, 0x74, 0xa3, 0xc9, 0x58, 0x38, 0xa3, 0x07, 0xd0, 0x4d, 0xf9, 0x45, 0xe6, 0xac,
+	0x32, 0x51, 0xaf, 0xfd, 0xdc, 0xcb, 0xdd, 0x29, 0x9d, 0xd0, 0x97, 0x60, 0xf9, 0x74, 0x21, 0x3c,
+	0x61, 0x71, 0xf4, 0xe6, 0xda, 0xa9, 0x1a, 0x87, 0x4d, 0x5f, 0x39, 0xb9, 0xbf, 0x68, 0xb0, 0x5e,
+	0x66, 0x2e, 0xf6, 0x6f, 0x7f, 0x2e, 0xf5, 0x41, 0x33, 0x75, 0xb5, 0xb5, 0x65, 0xee, 0x7b, 0x74,
+	0x50, 0xf8, 0x8d, 0x4c, 0x7e, 0xab, 0x9e, 0x3c, 0xbf, 0xc4, 0x95, 0x1b, 0xfa, 0x0a, 0xfa, 0x32,
+	0x7d, 0x6e, 0x72, 0xf4, 0xe6, 0xb0, 0xd6, 0xe4, 0x01, 0x5b, 0xbe, 0x7a, 0xbc, 0xff, 0x04, 0x3a,
+	0x42, 0x0c, 0x50, 0x0f, 0x3a, 0x87, 0xd1, 0x3b, 0x7f, 0x3a, 0x09, 0xed, 0x15, 0xd4, 0x01, 0xfd,
+	0x15, 0xc9, 0x6d, 0x8d, 0x3e, 0x1c, 0x15, 0xb9, 0xad, 0x23, 0x80, 0x36, 0xff, 0x54, 0xb1, 0x0d,
+	0xd4, 0x05, 0x83, 0x7e, 0x84, 0xd8, 0xad, 0xfb, 0x89, 0xf8, 0x0d, 0x90, 0x20, 0x36, 0x98, 0x02,
+	0x84, 0x99, 0xed, 0x15, 0xd4, 0x07, 0xa8, 0xb4, 0xc3, 0xd6, 0xd8, 0xb9, 0x5c, 0x7b, 0x5b, 0x47,
+	0x08, 0xfa, 0xf5, 0xad, 0xb6, 0x0d, 0xb4, 0x0e, 0x3d, 0x65, 0x3f, 0xed, 0x16, 0x5a, 0x83, 0x16,
+	0xdb, 0x39, 0x1b, 0x9e, 0xdb, 0xbf, 0x5d, 0x0d, 0xb4, 0xdf, 0xaf, 0x06, 0xda, 0x1f, 0x57, 0x03,
+	0xed, 0xa7, 0x3f, 0x07, 0x2b, 0x27, 0x6d, 0xf6, 0xef, 0xc1, 0xa3, 0x7f, 0x06, 0x00, 0x5b, 0x84,
+	0x87, 0xcf, 0x6a, 0x0c, 0x00, 0x00,
 }
@@ -47,7 +47,7 @@ func (x PeerState) String() string {
 	return proto.EnumName(PeerState_name, int32(x))
 }
 func (PeerState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{0}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{0}
 }
 
 // The message sent between Raft peer, it wraps the raft meessage with some meta information.
@@ -71,7 +71,7 @@ func (m *RaftMessage) Reset()         { *m = RaftMessage{} }
 func (m *RaftMessage) String() string { return proto.CompactTextString(m) }
 func (*RaftMessage) ProtoMessage()    {}
 func (*RaftMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{0}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{0}
 }
 func (m *RaftMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -170,7 +170,7 @@ func (m *RaftLocalState) Reset()         { *m = RaftLocalState{} }
 func (m *RaftLocalState) String() string { return proto.CompactTextString(m) }
 func (*RaftLocalState) ProtoMessage()    {}
 func (*RaftLocalState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{1}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{1}
 }
 func (m *RaftLocalState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -236,7 +236,7 @@ func (m *RaftApplyState) Reset()         { *m = RaftApplyState{} }
 func (m *RaftApplyState) String() string { return proto.CompactTextString(m) }
 func (*RaftApplyState) ProtoMessage()    {}
 func (*RaftApplyState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{2}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{2}
 }
 func (m *RaftApplyState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -292,7 +292,7 @@ func (m *RaftTruncatedState) Reset()         { *m = RaftTruncatedState{} }
 func (m *RaftTruncatedState) String() string { return proto.CompactTextString(m) }
 func (*RaftTruncatedState) ProtoMessage()    {}
 func (*RaftTruncatedState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{3}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{3}
 }
 func (m *RaftTruncatedState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -348,7 +348,7 @@ func (m *RegionLocalState) Reset()         { *m = RegionLocalState{} }
 func (m *RegionLocalState) String() string { return proto.CompactTextString(m) }
 func (*RegionLocalState) ProtoMessage()    {}
 func (*RegionLocalState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{4}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{4}
 }
 func (m *RegionLocalState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -405,7 +405,7 @@ func (m *StoreIdent) Reset()         { *m = StoreIdent{} }
 func (m *StoreIdent) String() string { return proto.CompactTextString(m) }
 func (*StoreIdent) ProtoMessage()    {}
 func (*StoreIdent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{5}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{5}
 }
 func (m *StoreIdent) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -462,7 +462,7 @@ func (m *KeyValue) Reset()         { *m = KeyValue{} }
 func (m *KeyValue) String() string { return proto.CompactTextString(m) }
 func (*KeyValue) ProtoMessage()    {}
 func (*KeyValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{6}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{6}
 }
 func (m *KeyValue) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -519,7 +519,7 @@ func (m *RaftSnapshotData) Reset()         { *m = RaftSnapshotData{} }
 func (m *RaftSnapshotData) String() string { return proto.CompactTextString(m) }
 func (*RaftSnapshotData) ProtoMessage()    {}
 func (*RaftSnapshotData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{7}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{7}
 }
 func (m *RaftSnapshotData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -589,7 +589,7 @@ func (m *SnapshotCFFile) Reset()         { *m = SnapshotCFFile{} }
 func (m *SnapshotCFFile) String() string { return proto.CompactTextString(m) }
 func (*SnapshotCFFile) ProtoMessage()    {}
 func (*SnapshotCFFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{8}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{8}
 }
 func (m *SnapshotCFFile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -650,7 +650,7 @@ func (m *SnapshotMeta) Reset()         { *m = SnapshotMeta{} }
 func (m *SnapshotMeta) String() string { return proto.CompactTextString(m) }
 func (*SnapshotMeta) ProtoMessage()    {}
 func (*SnapshotMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{9}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{9}
 }
 func (m *SnapshotMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -686,19 +686,26 @@ func (m *SnapshotMeta) GetCfFiles() []*SnapshotCFFile {
 	return nil
 }
 
+// A snapshot is streamed as a chunk carrying the raft message, the chunks of
+// its data in order, then a commit chunk without data.
 type SnapshotChunk struct {
-	Message              *RaftMessage `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
-	Data                 []byte       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Message *RaftMessage `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
+	Data    []byte       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// The position of data in the snapshot. For the commit chunk, the size of
+	// the snapshot.
+	Offset uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Set on the commit chunk, the receiver only saves a snapshot committed.
+	Commit               bool     `protobuf:"varint,4,opt,name=commit,proto3" json:"commit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SnapshotChunk) Reset()         { *m = SnapshotChunk{} }
 func (m *SnapshotChunk) String() string { return proto.CompactTextString(m) }
 func (*SnapshotChunk) ProtoMessage()    {}
 func (*SnapshotChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{10}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{10}
 }
 func (m *SnapshotChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -741,6 +748,20 @@ func (m *SnapshotChunk) GetData() []byte {
 	return nil
 }
 
+func (m *SnapshotChunk) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *SnapshotChunk) GetCommit() bool {
+	if m != nil {
+		return m.Commit
+	}
+	return false
+}
+
 type Done struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -751,7 +772,7 @@ func (m *Done) Reset()         { *m = Done{} }
 func (m *Done) String() string { return proto.CompactTextString(m) }
 func (*Done) ProtoMessage()    {}
 func (*Done) Descriptor() ([]byte, []int) {
-	return fileDescriptor_raft_serverpb_9d4bf28a94e26664, []int{11}
+	return fileDescriptor_raft_serverpb_991967c147184ad3, []int{11}
 }
 func (m *Done) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1250,6 +1271,21 @@ func (m *SnapshotChunk) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintRaftServerpb(dAtA, i, uint64(len(m.Data)))
 		i += copy(dAtA[i:], m.Data)
 	}
+	if m.Offset != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintRaftServerpb(dAtA, i, uint64(m.Offset))
+	}
+	if m.Commit {
+		dAtA[i] = 0x20
+		i++
+		if m.Commit {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
@@ -1494,6 +1530,12 @@ func (m *SnapshotChunk) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovRaftServerpb(uint64(l))
 	}
+	if m.Offset != 0 {
+		n += 1 + sovRaftServerpb(uint64(m.Offset))
+	}
+	if m.Commit {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2885,6 +2927,45 @@ func (m *SnapshotChunk) Unmarshal(dAtA []byte) error {
 				m.Data = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftServerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Commit = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRaftServerpb(dAtA[iNdEx:])
@@ -3063,55 +3144,57 @@ var (
 	ErrIntOverflowRaftServerpb   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("raft_serverpb.proto", fileDescriptor_raft_serverpb_9d4bf28a94e26664) }
-
-var fileDescriptor_raft_serverpb_9d4bf28a94e26664 = []byte{
-	// 751 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xdd, 0x6e, 0xeb, 0x44,
-	0x10, 0x3e, 0x4e, 0xdc, 0xc4, 0x9e, 0x38, 0x21, 0xda, 0x83, 0x74, 0x4c, 0x8e, 0x4e, 0x94, 0x1a,
-	0x51, 0x85, 0x22, 0x05, 0x11, 0x10, 0xe2, 0x0a, 0x09, 0x28, 0x55, 0x43, 0x29, 0xaa, 0x36, 0x15,
-	0x12, 0x57, 0xd6, 0xd6, 0x1e, 0x37, 0xa6, 0x8e, 0x6d, 0xed, 0x6e, 0x2a, 0xd2, 0x1b, 0xc4, 0x5b,
-	0xf0, 0x22, 0x5c, 0xf0, 0x06, 0x5c, 0xf2, 0x08, 0xa8, 0xbc, 0x08, 0xda, 0x5d, 0x3b, 0x3f, 0x6d,
-	0x39, 0x57, 0xd9, 0x99, 0xef, 0xf3, 0xec, 0x37, 0xdf, 0x4c, 0x16, 0x5e, 0x72, 0x96, 0xc8, 0x50,
-	0x20, 0xbf, 0x43, 0x5e, 0x5e, 0x4f, 0x4a, 0x5e, 0xc8, 0x82, 0x74, 0xf7, 0x92, 0x83, 0x2e, 0xaa,
-	0xb8, 0x46, 0x07, 0xde, 0x12, 0x25, 0xab, 0xa3, 0xe0, 0xcf, 0x06, 0x74, 0x28, 0x4b, 0xe4, 0x05,
-	0x0a, 0xc1, 0x6e, 0x90, 0xbc, 0x06, 0x97, 0xe3, 0x4d, 0x5a, 0xe4, 0x61, 0x1a, 0xfb, 0xd6, 0xc8,
-	0x1a, 0xdb, 0xd4, 0x31, 0x89, 0x59, 0x4c, 0x3e, 0x04, 0x37, 0xe1, 0xc5, 0x32, 0x2c, 0x11, 0xb9,
-	0xdf, 0x18, 0x59, 0xe3, 0xce, 0xd4, 0x9b, 0x54, 0xe5, 0x2e, 0x11, 0x39, 0x75, 0x14, 0xac, 0x4e,
-	0xe4, 0x03, 0x68, 0xcb, 0xc2, 0x10, 0x9b, 0xcf, 0x10, 0x5b, 0xb2, 0xd0, 0xb4, 0x63, 0x68, 0x2f,
-	0xcd, 0xcd, 0xbe, 0xad, 0x69, 0xfd, 0x49, 0xad, 0xb6, 0x52, 0x44, 0x6b, 0x02, 0xf9, 0x1c, 0xbc,
-	0x4a, 0x1a, 0x96, 0x45, 0xb4, 0xf0, 0x0f, 0xf4, 0x07, 0x2f, 0xeb, 0xba, 0x54, 0x63, 0xdf, 0x2a,
-	0x88, 0x76, 0xf8, 0x36, 0x20, 0x87, 0xe0, 0xa5, 0x22, 0x94, 0xc5, 0xf2, 0x5a, 0xc8, 0x22, 0x47,
-	0xbf, 0x35, 0xb2, 0xc6, 0x0e, 0xed, 0xa4, 0xe2, 0xaa, 0x4e, 0xa9, 0xae, 0x85, 0x64, 0x5c, 0x86,
-	0xb7, 0xb8, 0xf6, 0xdb, 0x23, 0x6b, 0xec, 0x51, 0x47, 0x27, 0xce, 0x71, 0x4d, 0x5e, 0x41, 0x1b,
-	0xf3, 0x58, 0x43, 0x8e, 0x86, 0x5a, 0x98, 0xc7, 0xe7, 0xb8, 0x0e, 0x7e, 0x85, 0x9e, 0xb2, 0xee,
-	0xfb, 0x22, 0x62, 0xd9, 0x5c, 0x32, 0x89, 0xe4, 0x13, 0x80, 0x05, 0xe3, 0x71, 0x28, 0x54, 0xa4,
-	0xed, 0xeb, 0x4c, 0xc9, 0xa6, 0xa3, 0x33, 0xc6, 0x63, 0xcd, 0xa3, 0xee, 0xa2, 0x3e, 0x92, 0x37,
-	0x00, 0x19, 0x13, 0x32, 0x4c, 0xf3, 0x18, 0x7f, 0xd1, 0xa6, 0xda, 0xd4, 0x55, 0x99, 0x99, 0x4a,
-	0x28, 0x65, 0x1a, 0x96, 0xc8, 0x97, 0xda, 0x49, 0x9b, 0x3a, 0x2a, 0x71, 0x85, 0x7c, 0x19, 0xfc,
-	0x66, 0x19, 0x05, 0x5f, 0x95, 0x65, 0xb6, 0x36, 0xe5, 0xde, 0x87, 0x2e, 0x2b, 0xcb, 0x2c, 0xc5,
-	0xb8, 0xaa, 0x68, 0x66, 0xe8, 0x55, 0x49, 0x53, 0xf4, 0x3b, 0x78, 0x47, 0xf2, 0x55, 0x1e, 0x31,
-	0x89, 0xb5, 0x56, 0x33, 0xcd, 0xc3, 0xc9, 0xfe, 0x3e, 0xa9, 0xe2, 0x57, 0x35, 0xd3, 0x48, 0xef,
-	0xc9, 0xbd, 0x38, 0xf8, 0x12, 0xc8, 0x53, 0x16, 0x79, 0x17, 0x0e, 0x76, 0xaf, 0x37, 0x01, 0x21,
-	0x60, 0xeb, 0x3e, 0x4c, 0x97, 0xfa, 0x1c, 0xfc, 0x0c, 0x7d, 0x33, 0xb9, 0x1d, 0x1b, 0x27, 0x70,
-	0xb0, 0x75, 0xb0, 0x37, 0xf5, 0x1f, 0xa9, 0x52, 0x9b, 0x63, 0xc4, 0x18, 0x1a, 0x39, 0x82, 0x96,
-	0x19, 0x78, 0xd5, 0x46, 0x6f, 0x7f, 0x27, 0x68, 0x85, 0x06, 0xa7, 0x00, 0x73, 0x59, 0x70, 0x9c,
-	0xc5, 0x98, 0x4b, 0xe5, 0x7c, 0x94, 0xad, 0x84, 0x44, 0xbe, 0xdd, 0x75, 0xb7, 0xca, 0xcc, 0x62,
-	0xf2, 0x1e, 0x38, 0x42, 0x91, 0x15, 0x68, 0x04, 0xb7, 0x85, 0xf9, 0x38, 0x98, 0x82, 0x73, 0x8e,
-	0xeb, 0x1f, 0x59, 0xb6, 0x42, 0xd2, 0x87, 0xa6, 0xda, 0x0c, 0x4b, 0x6f, 0x86, 0x3a, 0xaa, 0xde,
-	0xef, 0x14, 0xa4, 0xbf, 0xf2, 0xa8, 0x09, 0x82, 0x3f, 0x2c, 0xe8, 0x2b, 0xa3, 0xe6, 0x39, 0x2b,
-	0xc5, 0xa2, 0x90, 0x27, 0x4c, 0xb2, 0x1d, 0xe1, 0xd6, 0xdb, 0x84, 0xab, 0x2d, 0x48, 0xd2, 0x0c,
-	0x43, 0x91, 0xde, 0x63, 0x25, 0xc6, 0x51, 0x89, 0x79, 0x7a, 0x8f, 0xe4, 0x23, 0xb0, 0x63, 0x26,
-	0x99, 0xdf, 0x1c, 0x35, 0xc7, 0x9d, 0xe9, 0xab, 0x47, 0x66, 0xd5, 0x42, 0xa9, 0x26, 0x91, 0x8f,
-	0xc1, 0x56, 0x57, 0x54, 0x7f, 0x9e, 0xd7, 0x8f, 0xc8, 0xb5, 0xb8, 0x0b, 0x94, 0x8c, 0x6a, 0x62,
-	0x70, 0x09, 0xbd, 0x3a, 0xfb, 0xcd, 0xe9, 0x69, 0x9a, 0x21, 0xe9, 0x41, 0x23, 0x4a, 0xb4, 0x60,
-	0x97, 0x36, 0xa2, 0x44, 0x4d, 0x75, 0x47, 0x97, 0x3e, 0x93, 0x01, 0x38, 0xd1, 0x02, 0xa3, 0x5b,
-	0xb1, 0x32, 0x5b, 0xdb, 0xa5, 0x9b, 0x38, 0x38, 0x03, 0x6f, 0xf7, 0x1e, 0xf2, 0x05, 0x38, 0x51,
-	0x12, 0xaa, 0x76, 0x84, 0x6f, 0xe9, 0x1e, 0xde, 0xfc, 0x8f, 0x2c, 0x23, 0x80, 0xb6, 0xa3, 0x44,
-	0xfd, 0x8a, 0xe0, 0x27, 0xe8, 0x6e, 0xa0, 0xc5, 0x2a, 0xbf, 0x25, 0x9f, 0x6d, 0x9f, 0x13, 0x63,
-	0xe8, 0xe0, 0x99, 0x85, 0x7e, 0xf2, 0xb0, 0x90, 0xca, 0x40, 0x33, 0x2f, 0x7d, 0x0e, 0x5a, 0x60,
-	0x9f, 0x14, 0x39, 0x1e, 0x1f, 0x81, 0xbb, 0x59, 0x37, 0x02, 0xd0, 0xfa, 0xa1, 0xe0, 0x4b, 0x96,
-	0xf5, 0x5f, 0x90, 0x2e, 0xb8, 0x9b, 0xf7, 0xa3, 0xdf, 0xf8, 0xba, 0xff, 0xd7, 0xc3, 0xd0, 0xfa,
-	0xfb, 0x61, 0x68, 0xfd, 0xf3, 0x30, 0xb4, 0x7e, 0xff, 0x77, 0xf8, 0xe2, 0xba, 0xa5, 0x1f, 0xd8,
-	0x4f, 0xff, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x79, 0xcc, 0xfd, 0xcf, 0xa3, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("raft_serverpb.proto", fileDescriptor_raft_serverpb_991967c147184ad3) }
+
+var fileDescriptor_raft_serverpb_991967c147184ad3 = []byte{
+	// 771 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0xae, 0xb3, 0x5e, 0xc7, 0x3e, 0x71, 0x42, 0x34, 0x45, 0xd4, 0x6c, 0xd5, 0x28, 0x35, 0xa2,
+	0x0a, 0x45, 0x0a, 0x22, 0x20, 0xc4, 0x15, 0x12, 0x50, 0x56, 0x0d, 0x4b, 0x51, 0x35, 0x59, 0x71,
+	0x6b, 0xcd, 0xda, 0xc7, 0x8d, 0x59, 0xdb, 0x63, 0xcd, 0x4c, 0x2a, 0xd2, 0x1b, 0xc4, 0x25, 0x6f,
+	0xc0, 0x8b, 0x70, 0xc1, 0x1b, 0x70, 0xc9, 0x23, 0xa0, 0xe5, 0x45, 0xd0, 0xcc, 0xd8, 0xf9, 0xd9,
+	0x16, 0xae, 0x7c, 0xce, 0x77, 0xbe, 0x39, 0xf3, 0x9d, 0x1f, 0x0f, 0xdc, 0x15, 0x2c, 0x57, 0x89,
+	0x44, 0xf1, 0x12, 0x45, 0x73, 0x35, 0x6f, 0x04, 0x57, 0x9c, 0x0c, 0x8f, 0xc0, 0xb3, 0x21, 0x6a,
+	0xbf, 0x8b, 0x9e, 0x85, 0x15, 0x2a, 0xd6, 0x79, 0xf1, 0x1f, 0x3d, 0x18, 0x50, 0x96, 0xab, 0x67,
+	0x28, 0x25, 0x7b, 0x81, 0xe4, 0x3e, 0x04, 0x02, 0x5f, 0x14, 0xbc, 0x4e, 0x8a, 0x2c, 0x72, 0xa6,
+	0xce, 0xcc, 0xa5, 0xbe, 0x05, 0x96, 0x19, 0xf9, 0x00, 0x82, 0x5c, 0xf0, 0x2a, 0x69, 0x10, 0x45,
+	0xd4, 0x9b, 0x3a, 0xb3, 0xc1, 0x22, 0x9c, 0xb7, 0xe9, 0x9e, 0x23, 0x0a, 0xea, 0xeb, 0xb0, 0xb6,
+	0xc8, 0xfb, 0xd0, 0x57, 0xdc, 0x12, 0x4f, 0xde, 0x40, 0xf4, 0x14, 0x37, 0xb4, 0xc7, 0xd0, 0xaf,
+	0xec, 0xcd, 0x91, 0x6b, 0x68, 0xe3, 0x79, 0xa7, 0xb6, 0x55, 0x44, 0x3b, 0x02, 0xf9, 0x0c, 0xc2,
+	0x56, 0x1a, 0x36, 0x3c, 0x5d, 0x47, 0xa7, 0xe6, 0xc0, 0xdd, 0x2e, 0x2f, 0x35, 0xb1, 0x6f, 0x74,
+	0x88, 0x0e, 0xc4, 0xde, 0x21, 0x0f, 0x21, 0x2c, 0x64, 0xa2, 0x78, 0x75, 0x25, 0x15, 0xaf, 0x31,
+	0xf2, 0xa6, 0xce, 0xcc, 0xa7, 0x83, 0x42, 0x5e, 0x76, 0x90, 0xae, 0x5a, 0x2a, 0x26, 0x54, 0x72,
+	0x8d, 0xdb, 0xa8, 0x3f, 0x75, 0x66, 0x21, 0xf5, 0x0d, 0x70, 0x81, 0x5b, 0x72, 0x0f, 0xfa, 0x58,
+	0x67, 0x26, 0xe4, 0x9b, 0x90, 0x87, 0x75, 0x76, 0x81, 0xdb, 0xf8, 0x67, 0x18, 0xe9, 0xd6, 0x7d,
+	0xc7, 0x53, 0x56, 0xae, 0x14, 0x53, 0x48, 0x3e, 0x06, 0x58, 0x33, 0x91, 0x25, 0x52, 0x7b, 0xa6,
+	0x7d, 0x83, 0x05, 0xd9, 0x55, 0xf4, 0x94, 0x89, 0xcc, 0xf0, 0x68, 0xb0, 0xee, 0x4c, 0xf2, 0x00,
+	0xa0, 0x64, 0x52, 0x25, 0x45, 0x9d, 0xe1, 0x4f, 0xa6, 0xa9, 0x2e, 0x0d, 0x34, 0xb2, 0xd4, 0x80,
+	0x56, 0x66, 0xc2, 0x0a, 0x45, 0x65, 0x3a, 0xe9, 0x52, 0x5f, 0x03, 0x97, 0x28, 0xaa, 0xf8, 0x17,
+	0xc7, 0x2a, 0xf8, 0xb2, 0x69, 0xca, 0xad, 0x4d, 0xf7, 0x1e, 0x0c, 0x59, 0xd3, 0x94, 0x05, 0x66,
+	0x6d, 0x46, 0x3b, 0xc3, 0xb0, 0x05, 0x6d, 0xd2, 0x6f, 0xe1, 0x2d, 0x25, 0x36, 0x75, 0xca, 0x14,
+	0x76, 0x5a, 0xed, 0x34, 0x1f, 0xce, 0x8f, 0xf7, 0x49, 0x27, 0xbf, 0xec, 0x98, 0x56, 0xfa, 0x48,
+	0x1d, 0xf9, 0xf1, 0x17, 0x40, 0x5e, 0x67, 0x91, 0xb7, 0xe1, 0xf4, 0xf0, 0x7a, 0xeb, 0x10, 0x02,
+	0xae, 0xa9, 0xc3, 0x56, 0x69, 0xec, 0xf8, 0x47, 0x18, 0xdb, 0xc9, 0x1d, 0xb4, 0x71, 0x0e, 0xa7,
+	0xfb, 0x0e, 0x8e, 0x16, 0xd1, 0x2d, 0x55, 0x7a, 0x73, 0xac, 0x18, 0x4b, 0x23, 0x8f, 0xc0, 0xb3,
+	0x03, 0x6f, 0xcb, 0x18, 0x1d, 0xef, 0x04, 0x6d, 0xa3, 0xf1, 0x39, 0xc0, 0x4a, 0x71, 0x81, 0xcb,
+	0x0c, 0x6b, 0xa5, 0x3b, 0x9f, 0x96, 0x1b, 0xa9, 0x50, 0xec, 0x77, 0x3d, 0x68, 0x91, 0x65, 0x46,
+	0xde, 0x05, 0x5f, 0x6a, 0xb2, 0x0e, 0x5a, 0xc1, 0x7d, 0x69, 0x0f, 0xc7, 0x0b, 0xf0, 0x2f, 0x70,
+	0xfb, 0x03, 0x2b, 0x37, 0x48, 0xc6, 0x70, 0xa2, 0x37, 0xc3, 0x31, 0x9b, 0xa1, 0x4d, 0x5d, 0xfb,
+	0x4b, 0x1d, 0x32, 0xa7, 0x42, 0x6a, 0x9d, 0xf8, 0x77, 0x07, 0xc6, 0xba, 0x51, 0xab, 0x9a, 0x35,
+	0x72, 0xcd, 0xd5, 0x13, 0xa6, 0xd8, 0x81, 0x70, 0xe7, 0xff, 0x84, 0xeb, 0x2d, 0xc8, 0x8b, 0x12,
+	0x13, 0x59, 0xbc, 0xc2, 0x56, 0x8c, 0xaf, 0x81, 0x55, 0xf1, 0x0a, 0xc9, 0x87, 0xe0, 0x66, 0x4c,
+	0xb1, 0xe8, 0x64, 0x7a, 0x32, 0x1b, 0x2c, 0xee, 0xdd, 0x6a, 0x56, 0x27, 0x94, 0x1a, 0x12, 0xf9,
+	0x08, 0x5c, 0x7d, 0x45, 0xfb, 0xf3, 0xdc, 0xbf, 0x45, 0xee, 0xc4, 0x3d, 0x43, 0xc5, 0xa8, 0x21,
+	0xc6, 0xcf, 0x61, 0xd4, 0xa1, 0x5f, 0x9f, 0x9f, 0x17, 0x25, 0x92, 0x11, 0xf4, 0xd2, 0xdc, 0x08,
+	0x0e, 0x68, 0x2f, 0xcd, 0xf5, 0x54, 0x0f, 0x74, 0x19, 0x9b, 0x9c, 0x81, 0x9f, 0xae, 0x31, 0xbd,
+	0x96, 0x1b, 0xbb, 0xb5, 0x43, 0xba, 0xf3, 0xe3, 0xa7, 0x10, 0x1e, 0xde, 0x43, 0x3e, 0x07, 0x3f,
+	0xcd, 0x13, 0x5d, 0x8e, 0x8c, 0x1c, 0x53, 0xc3, 0x83, 0xff, 0x90, 0x65, 0x05, 0xd0, 0x7e, 0x9a,
+	0xeb, 0xaf, 0x8c, 0x7f, 0x75, 0x60, 0xb8, 0x8b, 0xad, 0x37, 0xf5, 0x35, 0xf9, 0x74, 0xff, 0x9e,
+	0xd8, 0x8e, 0x9e, 0xbd, 0x61, 0xa3, 0x5f, 0x7b, 0x59, 0x48, 0xdb, 0x41, 0x3b, 0x30, 0x63, 0x93,
+	0x77, 0xc0, 0xe3, 0x79, 0x2e, 0x51, 0xb5, 0x7f, 0x5d, 0xeb, 0x69, 0x3c, 0xe5, 0x55, 0x55, 0x28,
+	0xf3, 0x60, 0xf9, 0xb4, 0xf5, 0x62, 0x0f, 0xdc, 0x27, 0xbc, 0xc6, 0xc7, 0x8f, 0x20, 0xd8, 0xed,
+	0x27, 0x01, 0xf0, 0xbe, 0xe7, 0xa2, 0x62, 0xe5, 0xf8, 0x0e, 0x19, 0x42, 0xb0, 0x7b, 0x70, 0xc6,
+	0xbd, 0xaf, 0xc6, 0x7f, 0xde, 0x4c, 0x9c, 0xbf, 0x6e, 0x26, 0xce, 0xdf, 0x37, 0x13, 0xe7, 0xb7,
+	0x7f, 0x26, 0x77, 0xae, 0x3c, 0xf3, 0x22, 0x7f, 0xf2, 0xef, 0x00, 0x22, 0x19, 0x80, 0x10, 0xd4,
+	0x05, 0x00, 0x00,
 }
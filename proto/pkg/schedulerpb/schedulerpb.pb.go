@@ -67,7 +67,7 @@ func (x ErrorType) String() string {
 	return proto.EnumName(ErrorType_name, int32(x))
 }
 func (ErrorType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{0}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{0}
 }
 
 type OperatorStatus int32
@@ -99,7 +99,7 @@ func (x OperatorStatus) String() string {
 	return proto.EnumName(OperatorStatus_name, int32(x))
 }
 func (OperatorStatus) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{1}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{1}
 }
 
 type RequestHeader struct {
@@ -114,7 +114,7 @@ func (m *RequestHeader) Reset()         { *m = RequestHeader{} }
 func (m *RequestHeader) String() string { return proto.CompactTextString(m) }
 func (*RequestHeader) ProtoMessage()    {}
 func (*RequestHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{0}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{0}
 }
 func (m *RequestHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -163,7 +163,7 @@ func (m *ResponseHeader) Reset()         { *m = ResponseHeader{} }
 func (m *ResponseHeader) String() string { return proto.CompactTextString(m) }
 func (*ResponseHeader) ProtoMessage()    {}
 func (*ResponseHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{1}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{1}
 }
 func (m *ResponseHeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -218,7 +218,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{2}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{2}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -273,7 +273,7 @@ func (m *TsoRequest) Reset()         { *m = TsoRequest{} }
 func (m *TsoRequest) String() string { return proto.CompactTextString(m) }
 func (*TsoRequest) ProtoMessage()    {}
 func (*TsoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{3}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{3}
 }
 func (m *TsoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -328,7 +328,7 @@ func (m *Timestamp) Reset()         { *m = Timestamp{} }
 func (m *Timestamp) String() string { return proto.CompactTextString(m) }
 func (*Timestamp) ProtoMessage()    {}
 func (*Timestamp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{4}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{4}
 }
 func (m *Timestamp) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -384,7 +384,7 @@ func (m *TsoResponse) Reset()         { *m = TsoResponse{} }
 func (m *TsoResponse) String() string { return proto.CompactTextString(m) }
 func (*TsoResponse) ProtoMessage()    {}
 func (*TsoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{5}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{5}
 }
 func (m *TsoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -446,7 +446,7 @@ func (m *BootstrapRequest) Reset()         { *m = BootstrapRequest{} }
 func (m *BootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*BootstrapRequest) ProtoMessage()    {}
 func (*BootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{6}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{6}
 }
 func (m *BootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -500,7 +500,7 @@ func (m *BootstrapResponse) Reset()         { *m = BootstrapResponse{} }
 func (m *BootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*BootstrapResponse) ProtoMessage()    {}
 func (*BootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{7}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{7}
 }
 func (m *BootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -547,7 +547,7 @@ func (m *IsBootstrappedRequest) Reset()         { *m = IsBootstrappedRequest{} }
 func (m *IsBootstrappedRequest) String() string { return proto.CompactTextString(m) }
 func (*IsBootstrappedRequest) ProtoMessage()    {}
 func (*IsBootstrappedRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{8}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{8}
 }
 func (m *IsBootstrappedRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -595,7 +595,7 @@ func (m *IsBootstrappedResponse) Reset()         { *m = IsBootstrappedResponse{}
 func (m *IsBootstrappedResponse) String() string { return proto.CompactTextString(m) }
 func (*IsBootstrappedResponse) ProtoMessage()    {}
 func (*IsBootstrappedResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{9}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{9}
 }
 func (m *IsBootstrappedResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -649,7 +649,7 @@ func (m *AllocIDRequest) Reset()         { *m = AllocIDRequest{} }
 func (m *AllocIDRequest) String() string { return proto.CompactTextString(m) }
 func (*AllocIDRequest) ProtoMessage()    {}
 func (*AllocIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{10}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{10}
 }
 func (m *AllocIDRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -697,7 +697,7 @@ func (m *AllocIDResponse) Reset()         { *m = AllocIDResponse{} }
 func (m *AllocIDResponse) String() string { return proto.CompactTextString(m) }
 func (*AllocIDResponse) ProtoMessage()    {}
 func (*AllocIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{11}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{11}
 }
 func (m *AllocIDResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -752,7 +752,7 @@ func (m *GetStoreRequest) Reset()         { *m = GetStoreRequest{} }
 func (m *GetStoreRequest) String() string { return proto.CompactTextString(m) }
 func (*GetStoreRequest) ProtoMessage()    {}
 func (*GetStoreRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{12}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{12}
 }
 func (m *GetStoreRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -808,7 +808,7 @@ func (m *GetStoreResponse) Reset()         { *m = GetStoreResponse{} }
 func (m *GetStoreResponse) String() string { return proto.CompactTextString(m) }
 func (*GetStoreResponse) ProtoMessage()    {}
 func (*GetStoreResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{13}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{13}
 }
 func (m *GetStoreResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -870,7 +870,7 @@ func (m *PutStoreRequest) Reset()         { *m = PutStoreRequest{} }
 func (m *PutStoreRequest) String() string { return proto.CompactTextString(m) }
 func (*PutStoreRequest) ProtoMessage()    {}
 func (*PutStoreRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{14}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{14}
 }
 func (m *PutStoreRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -924,7 +924,7 @@ func (m *PutStoreResponse) Reset()         { *m = PutStoreResponse{} }
 func (m *PutStoreResponse) String() string { return proto.CompactTextString(m) }
 func (*PutStoreResponse) ProtoMessage()    {}
 func (*PutStoreResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{15}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{15}
 }
 func (m *PutStoreResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -973,7 +973,7 @@ func (m *GetAllStoresRequest) Reset()         { *m = GetAllStoresRequest{} }
 func (m *GetAllStoresRequest) String() string { return proto.CompactTextString(m) }
 func (*GetAllStoresRequest) ProtoMessage()    {}
 func (*GetAllStoresRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{16}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{16}
 }
 func (m *GetAllStoresRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1028,7 +1028,7 @@ func (m *GetAllStoresResponse) Reset()         { *m = GetAllStoresResponse{} }
 func (m *GetAllStoresResponse) String() string { return proto.CompactTextString(m) }
 func (*GetAllStoresResponse) ProtoMessage()    {}
 func (*GetAllStoresResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{17}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{17}
 }
 func (m *GetAllStoresResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1083,7 +1083,7 @@ func (m *GetRegionRequest) Reset()         { *m = GetRegionRequest{} }
 func (m *GetRegionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRegionRequest) ProtoMessage()    {}
 func (*GetRegionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{18}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{18}
 }
 func (m *GetRegionRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1140,7 +1140,7 @@ func (m *GetRegionResponse) Reset()         { *m = GetRegionResponse{} }
 func (m *GetRegionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetRegionResponse) ProtoMessage()    {}
 func (*GetRegionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{19}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{19}
 }
 func (m *GetRegionResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1209,7 +1209,7 @@ func (m *GetRegionByIDRequest) Reset()         { *m = GetRegionByIDRequest{} }
 func (m *GetRegionByIDRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRegionByIDRequest) ProtoMessage()    {}
 func (*GetRegionByIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{20}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{20}
 }
 func (m *GetRegionByIDRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1266,7 +1266,7 @@ func (m *ScanRegionsRequest) Reset()         { *m = ScanRegionsRequest{} }
 func (m *ScanRegionsRequest) String() string { return proto.CompactTextString(m) }
 func (*ScanRegionsRequest) ProtoMessage()    {}
 func (*ScanRegionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{21}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{21}
 }
 func (m *ScanRegionsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1336,7 +1336,7 @@ func (m *ScanRegionsResponse) Reset()         { *m = ScanRegionsResponse{} }
 func (m *ScanRegionsResponse) String() string { return proto.CompactTextString(m) }
 func (*ScanRegionsResponse) ProtoMessage()    {}
 func (*ScanRegionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{22}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{22}
 }
 func (m *ScanRegionsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1386,6 +1386,271 @@ func (m *ScanRegionsResponse) GetLeaders() []*metapb.Peer {
 	return nil
 }
 
+type GetRegionTopologyRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetRegionTopologyRequest) Reset()         { *m = GetRegionTopologyRequest{} }
+func (m *GetRegionTopologyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRegionTopologyRequest) ProtoMessage()    {}
+func (*GetRegionTopologyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{23}
+}
+func (m *GetRegionTopologyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRegionTopologyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRegionTopologyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *GetRegionTopologyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRegionTopologyRequest.Merge(dst, src)
+}
+func (m *GetRegionTopologyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRegionTopologyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRegionTopologyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRegionTopologyRequest proto.InternalMessageInfo
+
+func (m *GetRegionTopologyRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+type GetRegionTopologyResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// The version of the topology returned, to pass to GetRegionTopologyDiff.
+	Version              uint64           `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Regions              []*metapb.Region `protobuf:"bytes,3,rep,name=regions" json:"regions,omitempty"`
+	Leaders              []*metapb.Peer   `protobuf:"bytes,4,rep,name=leaders" json:"leaders,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetRegionTopologyResponse) Reset()         { *m = GetRegionTopologyResponse{} }
+func (m *GetRegionTopologyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRegionTopologyResponse) ProtoMessage()    {}
+func (*GetRegionTopologyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{24}
+}
+func (m *GetRegionTopologyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRegionTopologyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRegionTopologyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *GetRegionTopologyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRegionTopologyResponse.Merge(dst, src)
+}
+func (m *GetRegionTopologyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRegionTopologyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRegionTopologyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRegionTopologyResponse proto.InternalMessageInfo
+
+func (m *GetRegionTopologyResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetRegionTopologyResponse) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetRegionTopologyResponse) GetRegions() []*metapb.Region {
+	if m != nil {
+		return m.Regions
+	}
+	return nil
+}
+
+func (m *GetRegionTopologyResponse) GetLeaders() []*metapb.Peer {
+	if m != nil {
+		return m.Leaders
+	}
+	return nil
+}
+
+type GetRegionTopologyDiffRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	SinceVersion         uint64         `protobuf:"varint,2,opt,name=since_version,json=sinceVersion,proto3" json:"since_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetRegionTopologyDiffRequest) Reset()         { *m = GetRegionTopologyDiffRequest{} }
+func (m *GetRegionTopologyDiffRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRegionTopologyDiffRequest) ProtoMessage()    {}
+func (*GetRegionTopologyDiffRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{25}
+}
+func (m *GetRegionTopologyDiffRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRegionTopologyDiffRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRegionTopologyDiffRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *GetRegionTopologyDiffRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRegionTopologyDiffRequest.Merge(dst, src)
+}
+func (m *GetRegionTopologyDiffRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRegionTopologyDiffRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRegionTopologyDiffRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRegionTopologyDiffRequest proto.InternalMessageInfo
+
+func (m *GetRegionTopologyDiffRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetRegionTopologyDiffRequest) GetSinceVersion() uint64 {
+	if m != nil {
+		return m.SinceVersion
+	}
+	return 0
+}
+
+type GetRegionTopologyDiffResponse struct {
+	Header  *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	Version uint64          `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// The regions changed since since_version, as they are now.
+	Regions []*metapb.Region `protobuf:"bytes,3,rep,name=regions" json:"regions,omitempty"`
+	Leaders []*metapb.Peer   `protobuf:"bytes,4,rep,name=leaders" json:"leaders,omitempty"`
+	// The regions removed since since_version, e.g. merged.
+	RemovedRegionIds []uint64 `protobuf:"varint,5,rep,packed,name=removed_region_ids,json=removedRegionIds" json:"removed_region_ids,omitempty"`
+	// Set if the changes since since_version are no longer known, the
+	// topology must be read again with GetRegionTopology.
+	Stale                bool     `protobuf:"varint,6,opt,name=stale,proto3" json:"stale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRegionTopologyDiffResponse) Reset()         { *m = GetRegionTopologyDiffResponse{} }
+func (m *GetRegionTopologyDiffResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRegionTopologyDiffResponse) ProtoMessage()    {}
+func (*GetRegionTopologyDiffResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{26}
+}
+func (m *GetRegionTopologyDiffResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRegionTopologyDiffResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRegionTopologyDiffResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *GetRegionTopologyDiffResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRegionTopologyDiffResponse.Merge(dst, src)
+}
+func (m *GetRegionTopologyDiffResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRegionTopologyDiffResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRegionTopologyDiffResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRegionTopologyDiffResponse proto.InternalMessageInfo
+
+func (m *GetRegionTopologyDiffResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetRegionTopologyDiffResponse) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *GetRegionTopologyDiffResponse) GetRegions() []*metapb.Region {
+	if m != nil {
+		return m.Regions
+	}
+	return nil
+}
+
+func (m *GetRegionTopologyDiffResponse) GetLeaders() []*metapb.Peer {
+	if m != nil {
+		return m.Leaders
+	}
+	return nil
+}
+
+func (m *GetRegionTopologyDiffResponse) GetRemovedRegionIds() []uint64 {
+	if m != nil {
+		return m.RemovedRegionIds
+	}
+	return nil
+}
+
+func (m *GetRegionTopologyDiffResponse) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
 type GetClusterConfigRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
@@ -1397,7 +1662,7 @@ func (m *GetClusterConfigRequest) Reset()         { *m = GetClusterConfigRequest
 func (m *GetClusterConfigRequest) String() string { return proto.CompactTextString(m) }
 func (*GetClusterConfigRequest) ProtoMessage()    {}
 func (*GetClusterConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{23}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{27}
 }
 func (m *GetClusterConfigRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1445,7 +1710,7 @@ func (m *GetClusterConfigResponse) Reset()         { *m = GetClusterConfigRespon
 func (m *GetClusterConfigResponse) String() string { return proto.CompactTextString(m) }
 func (*GetClusterConfigResponse) ProtoMessage()    {}
 func (*GetClusterConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{24}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{28}
 }
 func (m *GetClusterConfigResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1500,7 +1765,7 @@ func (m *PutClusterConfigRequest) Reset()         { *m = PutClusterConfigRequest
 func (m *PutClusterConfigRequest) String() string { return proto.CompactTextString(m) }
 func (*PutClusterConfigRequest) ProtoMessage()    {}
 func (*PutClusterConfigRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{25}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{29}
 }
 func (m *PutClusterConfigRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1554,7 +1819,7 @@ func (m *PutClusterConfigResponse) Reset()         { *m = PutClusterConfigRespon
 func (m *PutClusterConfigResponse) String() string { return proto.CompactTextString(m) }
 func (*PutClusterConfigResponse) ProtoMessage()    {}
 func (*PutClusterConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{26}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{30}
 }
 func (m *PutClusterConfigResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1607,7 +1872,7 @@ func (m *Member) Reset()         { *m = Member{} }
 func (m *Member) String() string { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()    {}
 func (*Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{27}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{31}
 }
 func (m *Member) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1682,7 +1947,7 @@ func (m *GetMembersRequest) Reset()         { *m = GetMembersRequest{} }
 func (m *GetMembersRequest) String() string { return proto.CompactTextString(m) }
 func (*GetMembersRequest) ProtoMessage()    {}
 func (*GetMembersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{28}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{32}
 }
 func (m *GetMembersRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1732,7 +1997,7 @@ func (m *GetMembersResponse) Reset()         { *m = GetMembersResponse{} }
 func (m *GetMembersResponse) String() string { return proto.CompactTextString(m) }
 func (*GetMembersResponse) ProtoMessage()    {}
 func (*GetMembersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{29}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{33}
 }
 func (m *GetMembersResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1808,7 +2073,7 @@ func (m *RegionHeartbeatRequest) Reset()         { *m = RegionHeartbeatRequest{}
 func (m *RegionHeartbeatRequest) String() string { return proto.CompactTextString(m) }
 func (*RegionHeartbeatRequest) ProtoMessage()    {}
 func (*RegionHeartbeatRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{30}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{34}
 }
 func (m *RegionHeartbeatRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1884,7 +2149,7 @@ func (m *ChangePeer) Reset()         { *m = ChangePeer{} }
 func (m *ChangePeer) String() string { return proto.CompactTextString(m) }
 func (*ChangePeer) ProtoMessage()    {}
 func (*ChangePeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{31}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{35}
 }
 func (m *ChangePeer) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1938,7 +2203,7 @@ func (m *TransferLeader) Reset()         { *m = TransferLeader{} }
 func (m *TransferLeader) String() string { return proto.CompactTextString(m) }
 func (*TransferLeader) ProtoMessage()    {}
 func (*TransferLeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{32}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{36}
 }
 func (m *TransferLeader) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2007,7 +2272,7 @@ func (m *RegionHeartbeatResponse) Reset()         { *m = RegionHeartbeatResponse
 func (m *RegionHeartbeatResponse) String() string { return proto.CompactTextString(m) }
 func (*RegionHeartbeatResponse) ProtoMessage()    {}
 func (*RegionHeartbeatResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{33}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{37}
 }
 func (m *RegionHeartbeatResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2090,7 +2355,7 @@ func (m *AskSplitRequest) Reset()         { *m = AskSplitRequest{} }
 func (m *AskSplitRequest) String() string { return proto.CompactTextString(m) }
 func (*AskSplitRequest) ProtoMessage()    {}
 func (*AskSplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{34}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{38}
 }
 func (m *AskSplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2150,7 +2415,7 @@ func (m *AskSplitResponse) Reset()         { *m = AskSplitResponse{} }
 func (m *AskSplitResponse) String() string { return proto.CompactTextString(m) }
 func (*AskSplitResponse) ProtoMessage()    {}
 func (*AskSplitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{35}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{39}
 }
 func (m *AskSplitResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2213,7 +2478,7 @@ func (m *ReportSplitRequest) Reset()         { *m = ReportSplitRequest{} }
 func (m *ReportSplitRequest) String() string { return proto.CompactTextString(m) }
 func (*ReportSplitRequest) ProtoMessage()    {}
 func (*ReportSplitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{36}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{40}
 }
 func (m *ReportSplitRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2274,7 +2539,7 @@ func (m *ReportSplitResponse) Reset()         { *m = ReportSplitResponse{} }
 func (m *ReportSplitResponse) String() string { return proto.CompactTextString(m) }
 func (*ReportSplitResponse) ProtoMessage()    {}
 func (*ReportSplitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{37}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{41}
 }
 func (m *ReportSplitResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2322,7 +2587,7 @@ func (m *SplitID) Reset()         { *m = SplitID{} }
 func (m *SplitID) String() string { return proto.CompactTextString(m) }
 func (*SplitID) ProtoMessage()    {}
 func (*SplitID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{38}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{42}
 }
 func (m *SplitID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2379,7 +2644,7 @@ func (m *TimeInterval) Reset()         { *m = TimeInterval{} }
 func (m *TimeInterval) String() string { return proto.CompactTextString(m) }
 func (*TimeInterval) ProtoMessage()    {}
 func (*TimeInterval) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{39}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{43}
 }
 func (m *TimeInterval) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2434,7 +2699,7 @@ func (m *RecordPair) Reset()         { *m = RecordPair{} }
 func (m *RecordPair) String() string { return proto.CompactTextString(m) }
 func (*RecordPair) ProtoMessage()    {}
 func (*RecordPair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{40}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{44}
 }
 func (m *RecordPair) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2516,7 +2781,7 @@ func (m *StoreStats) Reset()         { *m = StoreStats{} }
 func (m *StoreStats) String() string { return proto.CompactTextString(m) }
 func (*StoreStats) ProtoMessage()    {}
 func (*StoreStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{41}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{45}
 }
 func (m *StoreStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2662,7 +2927,7 @@ func (m *StoreHeartbeatRequest) Reset()         { *m = StoreHeartbeatRequest{} }
 func (m *StoreHeartbeatRequest) String() string { return proto.CompactTextString(m) }
 func (*StoreHeartbeatRequest) ProtoMessage()    {}
 func (*StoreHeartbeatRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{42}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{46}
 }
 func (m *StoreHeartbeatRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2716,7 +2981,7 @@ func (m *StoreHeartbeatResponse) Reset()         { *m = StoreHeartbeatResponse{}
 func (m *StoreHeartbeatResponse) String() string { return proto.CompactTextString(m) }
 func (*StoreHeartbeatResponse) ProtoMessage()    {}
 func (*StoreHeartbeatResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{43}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{47}
 }
 func (m *StoreHeartbeatResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2768,7 +3033,7 @@ func (m *ScatterRegionRequest) Reset()         { *m = ScatterRegionRequest{} }
 func (m *ScatterRegionRequest) String() string { return proto.CompactTextString(m) }
 func (*ScatterRegionRequest) ProtoMessage()    {}
 func (*ScatterRegionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{44}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{48}
 }
 func (m *ScatterRegionRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2836,7 +3101,7 @@ func (m *ScatterRegionResponse) Reset()         { *m = ScatterRegionResponse{} }
 func (m *ScatterRegionResponse) String() string { return proto.CompactTextString(m) }
 func (*ScatterRegionResponse) ProtoMessage()    {}
 func (*ScatterRegionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{45}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{49}
 }
 func (m *ScatterRegionResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2883,7 +3148,7 @@ func (m *GetGCSafePointRequest) Reset()         { *m = GetGCSafePointRequest{} }
 func (m *GetGCSafePointRequest) String() string { return proto.CompactTextString(m) }
 func (*GetGCSafePointRequest) ProtoMessage()    {}
 func (*GetGCSafePointRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{46}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{50}
 }
 func (m *GetGCSafePointRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2931,7 +3196,7 @@ func (m *GetGCSafePointResponse) Reset()         { *m = GetGCSafePointResponse{}
 func (m *GetGCSafePointResponse) String() string { return proto.CompactTextString(m) }
 func (*GetGCSafePointResponse) ProtoMessage()    {}
 func (*GetGCSafePointResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{47}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{51}
 }
 func (m *GetGCSafePointResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2986,7 +3251,7 @@ func (m *UpdateGCSafePointRequest) Reset()         { *m = UpdateGCSafePointReque
 func (m *UpdateGCSafePointRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateGCSafePointRequest) ProtoMessage()    {}
 func (*UpdateGCSafePointRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{48}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{52}
 }
 func (m *UpdateGCSafePointRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3041,7 +3306,7 @@ func (m *UpdateGCSafePointResponse) Reset()         { *m = UpdateGCSafePointResp
 func (m *UpdateGCSafePointResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateGCSafePointResponse) ProtoMessage()    {}
 func (*UpdateGCSafePointResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{49}
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{53}
 }
 func (m *UpdateGCSafePointResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -3084,26 +3349,31 @@ func (m *UpdateGCSafePointResponse) GetNewSafePoint() uint64 {
 	return 0
 }
 
-type GetOperatorRequest struct {
+// UpdateServiceGCSafePointRequest registers the oldest ts a service, e.g. a backup or a CDC
+// feed, still reads at. The GC safe point never passes it until it expires after ttl seconds.
+// A ttl not above 0 removes the service safe point.
+type UpdateServiceGCSafePointRequest struct {
 	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
-	RegionId             uint64         `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	ServiceId            []byte         `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Ttl                  int64          `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	SafePoint            uint64         `protobuf:"varint,4,opt,name=safe_point,json=safePoint,proto3" json:"safe_point,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *GetOperatorRequest) Reset()         { *m = GetOperatorRequest{} }
-func (m *GetOperatorRequest) String() string { return proto.CompactTextString(m) }
-func (*GetOperatorRequest) ProtoMessage()    {}
-func (*GetOperatorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{50}
+func (m *UpdateServiceGCSafePointRequest) Reset()         { *m = UpdateServiceGCSafePointRequest{} }
+func (m *UpdateServiceGCSafePointRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateServiceGCSafePointRequest) ProtoMessage()    {}
+func (*UpdateServiceGCSafePointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{54}
 }
-func (m *GetOperatorRequest) XXX_Unmarshal(b []byte) error {
+func (m *UpdateServiceGCSafePointRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetOperatorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateServiceGCSafePointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetOperatorRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateServiceGCSafePointRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3113,55 +3383,70 @@ func (m *GetOperatorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *GetOperatorRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetOperatorRequest.Merge(dst, src)
+func (dst *UpdateServiceGCSafePointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateServiceGCSafePointRequest.Merge(dst, src)
 }
-func (m *GetOperatorRequest) XXX_Size() int {
+func (m *UpdateServiceGCSafePointRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetOperatorRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetOperatorRequest.DiscardUnknown(m)
+func (m *UpdateServiceGCSafePointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateServiceGCSafePointRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetOperatorRequest proto.InternalMessageInfo
+var xxx_messageInfo_UpdateServiceGCSafePointRequest proto.InternalMessageInfo
 
-func (m *GetOperatorRequest) GetHeader() *RequestHeader {
+func (m *UpdateServiceGCSafePointRequest) GetHeader() *RequestHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetOperatorRequest) GetRegionId() uint64 {
+func (m *UpdateServiceGCSafePointRequest) GetServiceId() []byte {
 	if m != nil {
-		return m.RegionId
+		return m.ServiceId
+	}
+	return nil
+}
+
+func (m *UpdateServiceGCSafePointRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
 	}
 	return 0
 }
 
-type GetOperatorResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
-	RegionId             uint64          `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
-	Desc                 []byte          `protobuf:"bytes,3,opt,name=desc,proto3" json:"desc,omitempty"`
-	Status               OperatorStatus  `protobuf:"varint,4,opt,name=status,proto3,enum=schedulerpb.OperatorStatus" json:"status,omitempty"`
-	Kind                 []byte          `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *UpdateServiceGCSafePointRequest) GetSafePoint() uint64 {
+	if m != nil {
+		return m.SafePoint
+	}
+	return 0
 }
 
-func (m *GetOperatorResponse) Reset()         { *m = GetOperatorResponse{} }
-func (m *GetOperatorResponse) String() string { return proto.CompactTextString(m) }
-func (*GetOperatorResponse) ProtoMessage()    {}
-func (*GetOperatorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_schedulerpb_4e333137f5959f12, []int{51}
+type UpdateServiceGCSafePointResponse struct {
+	Header    *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	ServiceId []byte          `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Ttl       int64           `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// min_safe_point is the oldest service safe point registered, or the GC safe point if it is
+	// older. A service safe point below it is not registered, the data is already collected.
+	MinSafePoint         uint64   `protobuf:"varint,4,opt,name=min_safe_point,json=minSafePoint,proto3" json:"min_safe_point,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *GetOperatorResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *UpdateServiceGCSafePointResponse) Reset()         { *m = UpdateServiceGCSafePointResponse{} }
+func (m *UpdateServiceGCSafePointResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateServiceGCSafePointResponse) ProtoMessage()    {}
+func (*UpdateServiceGCSafePointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{55}
+}
+func (m *UpdateServiceGCSafePointResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetOperatorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateServiceGCSafePointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetOperatorResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateServiceGCSafePointResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -3171,59 +3456,315 @@ func (m *GetOperatorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (dst *GetOperatorResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetOperatorResponse.Merge(dst, src)
+func (dst *UpdateServiceGCSafePointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateServiceGCSafePointResponse.Merge(dst, src)
 }
-func (m *GetOperatorResponse) XXX_Size() int {
+func (m *UpdateServiceGCSafePointResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetOperatorResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetOperatorResponse.DiscardUnknown(m)
+func (m *UpdateServiceGCSafePointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateServiceGCSafePointResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetOperatorResponse proto.InternalMessageInfo
+var xxx_messageInfo_UpdateServiceGCSafePointResponse proto.InternalMessageInfo
 
-func (m *GetOperatorResponse) GetHeader() *ResponseHeader {
+func (m *UpdateServiceGCSafePointResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetOperatorResponse) GetRegionId() uint64 {
+func (m *UpdateServiceGCSafePointResponse) GetServiceId() []byte {
 	if m != nil {
-		return m.RegionId
+		return m.ServiceId
 	}
-	return 0
+	return nil
 }
 
-func (m *GetOperatorResponse) GetDesc() []byte {
+func (m *UpdateServiceGCSafePointResponse) GetTtl() int64 {
 	if m != nil {
-		return m.Desc
+		return m.Ttl
 	}
-	return nil
+	return 0
 }
 
-func (m *GetOperatorResponse) GetStatus() OperatorStatus {
+func (m *UpdateServiceGCSafePointResponse) GetMinSafePoint() uint64 {
 	if m != nil {
-		return m.Status
+		return m.MinSafePoint
 	}
-	return OperatorStatus_SUCCESS
+	return 0
 }
 
-func (m *GetOperatorResponse) GetKind() []byte {
-	if m != nil {
-		return m.Kind
-	}
-	return nil
+type GetOperatorRequest struct {
+	Header               *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	RegionId             uint64         `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func init() {
-	proto.RegisterType((*RequestHeader)(nil), "schedulerpb.RequestHeader")
-	proto.RegisterType((*ResponseHeader)(nil), "schedulerpb.ResponseHeader")
-	proto.RegisterType((*Error)(nil), "schedulerpb.Error")
-	proto.RegisterType((*TsoRequest)(nil), "schedulerpb.TsoRequest")
-	proto.RegisterType((*Timestamp)(nil), "schedulerpb.Timestamp")
+func (m *GetOperatorRequest) Reset()         { *m = GetOperatorRequest{} }
+func (m *GetOperatorRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOperatorRequest) ProtoMessage()    {}
+func (*GetOperatorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{56}
+}
+func (m *GetOperatorRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetOperatorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetOperatorRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *GetOperatorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperatorRequest.Merge(dst, src)
+}
+func (m *GetOperatorRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetOperatorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperatorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOperatorRequest proto.InternalMessageInfo
+
+func (m *GetOperatorRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetOperatorRequest) GetRegionId() uint64 {
+	if m != nil {
+		return m.RegionId
+	}
+	return 0
+}
+
+type GetOperatorResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	RegionId             uint64          `protobuf:"varint,2,opt,name=region_id,json=regionId,proto3" json:"region_id,omitempty"`
+	Desc                 []byte          `protobuf:"bytes,3,opt,name=desc,proto3" json:"desc,omitempty"`
+	Status               OperatorStatus  `protobuf:"varint,4,opt,name=status,proto3,enum=schedulerpb.OperatorStatus" json:"status,omitempty"`
+	Kind                 []byte          `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetOperatorResponse) Reset()         { *m = GetOperatorResponse{} }
+func (m *GetOperatorResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOperatorResponse) ProtoMessage()    {}
+func (*GetOperatorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{57}
+}
+func (m *GetOperatorResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetOperatorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetOperatorResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *GetOperatorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperatorResponse.Merge(dst, src)
+}
+func (m *GetOperatorResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetOperatorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperatorResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOperatorResponse proto.InternalMessageInfo
+
+func (m *GetOperatorResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetOperatorResponse) GetRegionId() uint64 {
+	if m != nil {
+		return m.RegionId
+	}
+	return 0
+}
+
+func (m *GetOperatorResponse) GetDesc() []byte {
+	if m != nil {
+		return m.Desc
+	}
+	return nil
+}
+
+func (m *GetOperatorResponse) GetStatus() OperatorStatus {
+	if m != nil {
+		return m.Status
+	}
+	return OperatorStatus_SUCCESS
+}
+
+func (m *GetOperatorResponse) GetKind() []byte {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+type PrepareRestartRequest struct {
+	Header  *RequestHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	StoreId uint64         `protobuf:"varint,2,opt,name=store_id,json=storeId,proto3" json:"store_id,omitempty"`
+	// Set to undo the preparation of a store which is not restarted after all.
+	Cancel               bool     `protobuf:"varint,3,opt,name=cancel,proto3" json:"cancel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrepareRestartRequest) Reset()         { *m = PrepareRestartRequest{} }
+func (m *PrepareRestartRequest) String() string { return proto.CompactTextString(m) }
+func (*PrepareRestartRequest) ProtoMessage()    {}
+func (*PrepareRestartRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{58}
+}
+func (m *PrepareRestartRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrepareRestartRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrepareRestartRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *PrepareRestartRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareRestartRequest.Merge(dst, src)
+}
+func (m *PrepareRestartRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrepareRestartRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareRestartRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrepareRestartRequest proto.InternalMessageInfo
+
+func (m *PrepareRestartRequest) GetHeader() *RequestHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *PrepareRestartRequest) GetStoreId() uint64 {
+	if m != nil {
+		return m.StoreId
+	}
+	return 0
+}
+
+func (m *PrepareRestartRequest) GetCancel() bool {
+	if m != nil {
+		return m.Cancel
+	}
+	return false
+}
+
+type PrepareRestartResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header" json:"header,omitempty"`
+	// The number of leaders still on the store.
+	LeaderCount uint64 `protobuf:"varint,2,opt,name=leader_count,json=leaderCount,proto3" json:"leader_count,omitempty"`
+	// Set once the store holds no leaders, it is safe to stop it then.
+	Ready                bool     `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrepareRestartResponse) Reset()         { *m = PrepareRestartResponse{} }
+func (m *PrepareRestartResponse) String() string { return proto.CompactTextString(m) }
+func (*PrepareRestartResponse) ProtoMessage()    {}
+func (*PrepareRestartResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_schedulerpb_8e72294cef10d252, []int{59}
+}
+func (m *PrepareRestartResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrepareRestartResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrepareRestartResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *PrepareRestartResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareRestartResponse.Merge(dst, src)
+}
+func (m *PrepareRestartResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrepareRestartResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareRestartResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrepareRestartResponse proto.InternalMessageInfo
+
+func (m *PrepareRestartResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *PrepareRestartResponse) GetLeaderCount() uint64 {
+	if m != nil {
+		return m.LeaderCount
+	}
+	return 0
+}
+
+func (m *PrepareRestartResponse) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*RequestHeader)(nil), "schedulerpb.RequestHeader")
+	proto.RegisterType((*ResponseHeader)(nil), "schedulerpb.ResponseHeader")
+	proto.RegisterType((*Error)(nil), "schedulerpb.Error")
+	proto.RegisterType((*TsoRequest)(nil), "schedulerpb.TsoRequest")
+	proto.RegisterType((*Timestamp)(nil), "schedulerpb.Timestamp")
 	proto.RegisterType((*TsoResponse)(nil), "schedulerpb.TsoResponse")
 	proto.RegisterType((*BootstrapRequest)(nil), "schedulerpb.BootstrapRequest")
 	proto.RegisterType((*BootstrapResponse)(nil), "schedulerpb.BootstrapResponse")
@@ -3242,6 +3783,10 @@ func init() {
 	proto.RegisterType((*GetRegionByIDRequest)(nil), "schedulerpb.GetRegionByIDRequest")
 	proto.RegisterType((*ScanRegionsRequest)(nil), "schedulerpb.ScanRegionsRequest")
 	proto.RegisterType((*ScanRegionsResponse)(nil), "schedulerpb.ScanRegionsResponse")
+	proto.RegisterType((*GetRegionTopologyRequest)(nil), "schedulerpb.GetRegionTopologyRequest")
+	proto.RegisterType((*GetRegionTopologyResponse)(nil), "schedulerpb.GetRegionTopologyResponse")
+	proto.RegisterType((*GetRegionTopologyDiffRequest)(nil), "schedulerpb.GetRegionTopologyDiffRequest")
+	proto.RegisterType((*GetRegionTopologyDiffResponse)(nil), "schedulerpb.GetRegionTopologyDiffResponse")
 	proto.RegisterType((*GetClusterConfigRequest)(nil), "schedulerpb.GetClusterConfigRequest")
 	proto.RegisterType((*GetClusterConfigResponse)(nil), "schedulerpb.GetClusterConfigResponse")
 	proto.RegisterType((*PutClusterConfigRequest)(nil), "schedulerpb.PutClusterConfigRequest")
@@ -3269,8 +3814,12 @@ func init() {
 	proto.RegisterType((*GetGCSafePointResponse)(nil), "schedulerpb.GetGCSafePointResponse")
 	proto.RegisterType((*UpdateGCSafePointRequest)(nil), "schedulerpb.UpdateGCSafePointRequest")
 	proto.RegisterType((*UpdateGCSafePointResponse)(nil), "schedulerpb.UpdateGCSafePointResponse")
+	proto.RegisterType((*UpdateServiceGCSafePointRequest)(nil), "schedulerpb.UpdateServiceGCSafePointRequest")
+	proto.RegisterType((*UpdateServiceGCSafePointResponse)(nil), "schedulerpb.UpdateServiceGCSafePointResponse")
 	proto.RegisterType((*GetOperatorRequest)(nil), "schedulerpb.GetOperatorRequest")
 	proto.RegisterType((*GetOperatorResponse)(nil), "schedulerpb.GetOperatorResponse")
+	proto.RegisterType((*PrepareRestartRequest)(nil), "schedulerpb.PrepareRestartRequest")
+	proto.RegisterType((*PrepareRestartResponse)(nil), "schedulerpb.PrepareRestartResponse")
 	proto.RegisterEnum("schedulerpb.ErrorType", ErrorType_name, ErrorType_value)
 	proto.RegisterEnum("schedulerpb.OperatorStatus", OperatorStatus_name, OperatorStatus_value)
 }
@@ -3308,7 +3857,11 @@ type SchedulerClient interface {
 	ScatterRegion(ctx context.Context, in *ScatterRegionRequest, opts ...grpc.CallOption) (*ScatterRegionResponse, error)
 	GetGCSafePoint(ctx context.Context, in *GetGCSafePointRequest, opts ...grpc.CallOption) (*GetGCSafePointResponse, error)
 	UpdateGCSafePoint(ctx context.Context, in *UpdateGCSafePointRequest, opts ...grpc.CallOption) (*UpdateGCSafePointResponse, error)
+	UpdateServiceGCSafePoint(ctx context.Context, in *UpdateServiceGCSafePointRequest, opts ...grpc.CallOption) (*UpdateServiceGCSafePointResponse, error)
 	GetOperator(ctx context.Context, in *GetOperatorRequest, opts ...grpc.CallOption) (*GetOperatorResponse, error)
+	GetRegionTopology(ctx context.Context, in *GetRegionTopologyRequest, opts ...grpc.CallOption) (*GetRegionTopologyResponse, error)
+	GetRegionTopologyDiff(ctx context.Context, in *GetRegionTopologyDiffRequest, opts ...grpc.CallOption) (*GetRegionTopologyDiffResponse, error)
+	PrepareRestart(ctx context.Context, in *PrepareRestartRequest, opts ...grpc.CallOption) (*PrepareRestartResponse, error)
 }
 
 type schedulerClient struct {
@@ -3543,6 +4096,15 @@ func (c *schedulerClient) UpdateGCSafePoint(ctx context.Context, in *UpdateGCSaf
 	return out, nil
 }
 
+func (c *schedulerClient) UpdateServiceGCSafePoint(ctx context.Context, in *UpdateServiceGCSafePointRequest, opts ...grpc.CallOption) (*UpdateServiceGCSafePointResponse, error) {
+	out := new(UpdateServiceGCSafePointResponse)
+	err := c.cc.Invoke(ctx, "/schedulerpb.Scheduler/UpdateServiceGCSafePoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *schedulerClient) GetOperator(ctx context.Context, in *GetOperatorRequest, opts ...grpc.CallOption) (*GetOperatorResponse, error) {
 	out := new(GetOperatorResponse)
 	err := c.cc.Invoke(ctx, "/schedulerpb.Scheduler/GetOperator", in, out, opts...)
@@ -3552,6 +4114,33 @@ func (c *schedulerClient) GetOperator(ctx context.Context, in *GetOperatorReques
 	return out, nil
 }
 
+func (c *schedulerClient) GetRegionTopology(ctx context.Context, in *GetRegionTopologyRequest, opts ...grpc.CallOption) (*GetRegionTopologyResponse, error) {
+	out := new(GetRegionTopologyResponse)
+	err := c.cc.Invoke(ctx, "/schedulerpb.Scheduler/GetRegionTopology", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) GetRegionTopologyDiff(ctx context.Context, in *GetRegionTopologyDiffRequest, opts ...grpc.CallOption) (*GetRegionTopologyDiffResponse, error) {
+	out := new(GetRegionTopologyDiffResponse)
+	err := c.cc.Invoke(ctx, "/schedulerpb.Scheduler/GetRegionTopologyDiff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) PrepareRestart(ctx context.Context, in *PrepareRestartRequest, opts ...grpc.CallOption) (*PrepareRestartResponse, error) {
+	out := new(PrepareRestartResponse)
+	err := c.cc.Invoke(ctx, "/schedulerpb.Scheduler/PrepareRestart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Scheduler service
 
 type SchedulerServer interface {
@@ -3577,7 +4166,11 @@ type SchedulerServer interface {
 	ScatterRegion(context.Context, *ScatterRegionRequest) (*ScatterRegionResponse, error)
 	GetGCSafePoint(context.Context, *GetGCSafePointRequest) (*GetGCSafePointResponse, error)
 	UpdateGCSafePoint(context.Context, *UpdateGCSafePointRequest) (*UpdateGCSafePointResponse, error)
+	UpdateServiceGCSafePoint(context.Context, *UpdateServiceGCSafePointRequest) (*UpdateServiceGCSafePointResponse, error)
 	GetOperator(context.Context, *GetOperatorRequest) (*GetOperatorResponse, error)
+	GetRegionTopology(context.Context, *GetRegionTopologyRequest) (*GetRegionTopologyResponse, error)
+	GetRegionTopologyDiff(context.Context, *GetRegionTopologyDiffRequest) (*GetRegionTopologyDiffResponse, error)
+	PrepareRestart(context.Context, *PrepareRestartRequest) (*PrepareRestartResponse, error)
 }
 
 func RegisterSchedulerServer(s *grpc.Server, srv SchedulerServer) {
@@ -3960,6 +4553,24 @@ func _Scheduler_UpdateGCSafePoint_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Scheduler_UpdateServiceGCSafePoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceGCSafePointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).UpdateServiceGCSafePoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerpb.Scheduler/UpdateServiceGCSafePoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).UpdateServiceGCSafePoint(ctx, req.(*UpdateServiceGCSafePointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Scheduler_GetOperator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetOperatorRequest)
 	if err := dec(in); err != nil {
@@ -3978,13 +4589,67 @@ func _Scheduler_GetOperator_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Scheduler_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "schedulerpb.Scheduler",
-	HandlerType: (*SchedulerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetMembers",
-			Handler:    _Scheduler_GetMembers_Handler,
+func _Scheduler_GetRegionTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegionTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetRegionTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerpb.Scheduler/GetRegionTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetRegionTopology(ctx, req.(*GetRegionTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_GetRegionTopologyDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegionTopologyDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).GetRegionTopologyDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerpb.Scheduler/GetRegionTopologyDiff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).GetRegionTopologyDiff(ctx, req.(*GetRegionTopologyDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_PrepareRestart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareRestartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).PrepareRestart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/schedulerpb.Scheduler/PrepareRestart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).PrepareRestart(ctx, req.(*PrepareRestartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Scheduler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "schedulerpb.Scheduler",
+	HandlerType: (*SchedulerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMembers",
+			Handler:    _Scheduler_GetMembers_Handler,
 		},
 		{
 			MethodName: "Bootstrap",
@@ -4054,10 +4719,26 @@ var _Scheduler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateGCSafePoint",
 			Handler:    _Scheduler_UpdateGCSafePoint_Handler,
 		},
+		{
+			MethodName: "UpdateServiceGCSafePoint",
+			Handler:    _Scheduler_UpdateServiceGCSafePoint_Handler,
+		},
 		{
 			MethodName: "GetOperator",
 			Handler:    _Scheduler_GetOperator_Handler,
 		},
+		{
+			MethodName: "GetRegionTopology",
+			Handler:    _Scheduler_GetRegionTopology_Handler,
+		},
+		{
+			MethodName: "GetRegionTopologyDiff",
+			Handler:    _Scheduler_GetRegionTopologyDiff_Handler,
+		},
+		{
+			MethodName: "PrepareRestart",
+			Handler:    _Scheduler_PrepareRestart_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4976,7 +5657,7 @@ func (m *ScanRegionsResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *GetClusterConfigRequest) Marshal() (dAtA []byte, err error) {
+func (m *GetRegionTopologyRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -4986,7 +5667,7 @@ func (m *GetClusterConfigRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetRegionTopologyRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5007,7 +5688,7 @@ func (m *GetClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *GetClusterConfigResponse) Marshal() (dAtA []byte, err error) {
+func (m *GetRegionTopologyResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5017,7 +5698,7 @@ func (m *GetClusterConfigResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetRegionTopologyResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5032,15 +5713,34 @@ func (m *GetClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n29
 	}
-	if m.Cluster != nil {
-		dAtA[i] = 0x12
+	if m.Version != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Cluster.Size()))
-		n30, err := m.Cluster.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Version))
+	}
+	if len(m.Regions) > 0 {
+		for _, msg := range m.Regions {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Leaders) > 0 {
+		for _, msg := range m.Leaders {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n30
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5048,7 +5748,7 @@ func (m *GetClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *PutClusterConfigRequest) Marshal() (dAtA []byte, err error) {
+func (m *GetRegionTopologyDiffRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5058,7 +5758,7 @@ func (m *PutClusterConfigRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PutClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetRegionTopologyDiffRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5067,21 +5767,16 @@ func (m *PutClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n31, err := m.Header.MarshalTo(dAtA[i:])
+		n30, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n31
+		i += n30
 	}
-	if m.Cluster != nil {
-		dAtA[i] = 0x12
+	if m.SinceVersion != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Cluster.Size()))
-		n32, err := m.Cluster.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n32
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.SinceVersion))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5089,7 +5784,7 @@ func (m *PutClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *PutClusterConfigResponse) Marshal() (dAtA []byte, err error) {
+func (m *GetRegionTopologyDiffResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5099,7 +5794,7 @@ func (m *PutClusterConfigResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PutClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetRegionTopologyDiffResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5108,78 +5803,67 @@ func (m *PutClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n33, err := m.Header.MarshalTo(dAtA[i:])
+		n31, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n33
-	}
-	if m.XXX_unrecognized != nil {
-		i += copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	return i, nil
-}
-
-func (m *Member) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *Member) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+		i += n31
 	}
-	if m.MemberId != 0 {
+	if m.Version != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.MemberId))
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Version))
 	}
-	if len(m.PeerUrls) > 0 {
-		for _, s := range m.PeerUrls {
+	if len(m.Regions) > 0 {
+		for _, msg := range m.Regions {
 			dAtA[i] = 0x1a
 			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+			i += n
 		}
 	}
-	if len(m.ClientUrls) > 0 {
-		for _, s := range m.ClientUrls {
+	if len(m.Leaders) > 0 {
+		for _, msg := range m.Leaders {
 			dAtA[i] = 0x22
 			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+			i += n
 		}
 	}
-	if m.LeaderPriority != 0 {
-		dAtA[i] = 0x28
+	if len(m.RemovedRegionIds) > 0 {
+		dAtA33 := make([]byte, len(m.RemovedRegionIds)*10)
+		var j32 int
+		for _, num := range m.RemovedRegionIds {
+			for num >= 1<<7 {
+				dAtA33[j32] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j32++
+			}
+			dAtA33[j32] = uint8(num)
+			j32++
+		}
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(j32))
+		i += copy(dAtA[i:], dAtA33[:j32])
+	}
+	if m.Stale {
+		dAtA[i] = 0x30
+		i++
+		if m.Stale {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.LeaderPriority))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5187,7 +5871,7 @@ func (m *Member) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *GetMembersRequest) Marshal() (dAtA []byte, err error) {
+func (m *GetClusterConfigRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5197,7 +5881,7 @@ func (m *GetMembersRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetMembersRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5218,7 +5902,7 @@ func (m *GetMembersRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *GetMembersResponse) Marshal() (dAtA []byte, err error) {
+func (m *GetClusterConfigResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5228,7 +5912,7 @@ func (m *GetMembersResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetMembersResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5243,45 +5927,23 @@ func (m *GetMembersResponse) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n35
 	}
-	if len(m.Members) > 0 {
-		for _, msg := range m.Members {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.Leader != nil {
-		dAtA[i] = 0x1a
+	if m.Cluster != nil {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Leader.Size()))
-		n36, err := m.Leader.MarshalTo(dAtA[i:])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Cluster.Size()))
+		n36, err := m.Cluster.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
 		i += n36
 	}
-	if m.EtcdLeader != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.EtcdLeader.Size()))
-		n37, err := m.EtcdLeader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n37
-	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RegionHeartbeatRequest) Marshal() (dAtA []byte, err error) {
+func (m *PutClusterConfigRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5291,7 +5953,7 @@ func (m *RegionHeartbeatRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RegionHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *PutClusterConfigRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5300,48 +5962,21 @@ func (m *RegionHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n38, err := m.Header.MarshalTo(dAtA[i:])
+		n37, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n38
+		i += n37
 	}
-	if m.Region != nil {
+	if m.Cluster != nil {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Region.Size()))
-		n39, err := m.Region.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n39
-	}
-	if m.Leader != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Leader.Size()))
-		n40, err := m.Leader.MarshalTo(dAtA[i:])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Cluster.Size()))
+		n38, err := m.Cluster.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n40
-	}
-	if len(m.PendingPeers) > 0 {
-		for _, msg := range m.PendingPeers {
-			dAtA[i] = 0x2a
-			i++
-			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if m.ApproximateSize != 0 {
-		dAtA[i] = 0x50
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ApproximateSize))
+		i += n38
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5349,7 +5984,7 @@ func (m *RegionHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ChangePeer) Marshal() (dAtA []byte, err error) {
+func (m *PutClusterConfigResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5359,25 +5994,20 @@ func (m *ChangePeer) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
+func (m *PutClusterConfigResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Peer != nil {
+	if m.Header != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Peer.Size()))
-		n41, err := m.Peer.MarshalTo(dAtA[i:])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n39, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n41
-	}
-	if m.ChangeType != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ChangeType))
+		i += n39
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5385,7 +6015,7 @@ func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
+func (m *Member) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5395,20 +6025,56 @@ func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
+func (m *Member) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Peer != nil {
+	if len(m.Name) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Peer.Size()))
-		n42, err := m.Peer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.MemberId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.MemberId))
+	}
+	if len(m.PeerUrls) > 0 {
+		for _, s := range m.PeerUrls {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
-		i += n42
+	}
+	if len(m.ClientUrls) > 0 {
+		for _, s := range m.ClientUrls {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.LeaderPriority != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.LeaderPriority))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5416,7 +6082,7 @@ func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *RegionHeartbeatResponse) Marshal() (dAtA []byte, err error) {
+func (m *GetMembersRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5426,7 +6092,7 @@ func (m *RegionHeartbeatResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RegionHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetMembersRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5435,56 +6101,11 @@ func (m *RegionHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n43, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n43
-	}
-	if m.ChangePeer != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ChangePeer.Size()))
-		n44, err := m.ChangePeer.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n44
-	}
-	if m.TransferLeader != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.TransferLeader.Size()))
-		n45, err := m.TransferLeader.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n45
-	}
-	if m.RegionId != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.RegionId))
-	}
-	if m.RegionEpoch != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.RegionEpoch.Size()))
-		n46, err := m.RegionEpoch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n46
-	}
-	if m.TargetPeer != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.TargetPeer.Size()))
-		n47, err := m.TargetPeer.MarshalTo(dAtA[i:])
+		n40, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n47
+		i += n40
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5492,7 +6113,7 @@ func (m *RegionHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *AskSplitRequest) Marshal() (dAtA []byte, err error) {
+func (m *GetMembersResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5502,7 +6123,7 @@ func (m *AskSplitRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AskSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetMembersResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5511,21 +6132,43 @@ func (m *AskSplitRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n48, err := m.Header.MarshalTo(dAtA[i:])
+		n41, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n48
+		i += n41
 	}
-	if m.Region != nil {
-		dAtA[i] = 0x12
+	if len(m.Members) > 0 {
+		for _, msg := range m.Members {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Leader != nil {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Region.Size()))
-		n49, err := m.Region.MarshalTo(dAtA[i:])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Leader.Size()))
+		n42, err := m.Leader.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n49
+		i += n42
+	}
+	if m.EtcdLeader != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.EtcdLeader.Size()))
+		n43, err := m.EtcdLeader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n43
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5533,7 +6176,7 @@ func (m *AskSplitRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *AskSplitResponse) Marshal() (dAtA []byte, err error) {
+func (m *RegionHeartbeatRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5543,7 +6186,7 @@ func (m *AskSplitResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *AskSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *RegionHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5552,33 +6195,48 @@ func (m *AskSplitResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n50, err := m.Header.MarshalTo(dAtA[i:])
+		n44, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n50
+		i += n44
 	}
-	if m.NewRegionId != 0 {
-		dAtA[i] = 0x10
+	if m.Region != nil {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.NewRegionId))
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Region.Size()))
+		n45, err := m.Region.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n45
 	}
-	if len(m.NewPeerIds) > 0 {
-		dAtA52 := make([]byte, len(m.NewPeerIds)*10)
-		var j51 int
-		for _, num := range m.NewPeerIds {
-			for num >= 1<<7 {
-				dAtA52[j51] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j51++
+	if m.Leader != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Leader.Size()))
+		n46, err := m.Leader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n46
+	}
+	if len(m.PendingPeers) > 0 {
+		for _, msg := range m.PendingPeers {
+			dAtA[i] = 0x2a
+			i++
+			i = encodeVarintSchedulerpb(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
-			dAtA52[j51] = uint8(num)
-			j51++
+			i += n
 		}
-		dAtA[i] = 0x1a
+	}
+	if m.ApproximateSize != 0 {
+		dAtA[i] = 0x50
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(j51))
-		i += copy(dAtA[i:], dAtA52[:j51])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ApproximateSize))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5586,7 +6244,7 @@ func (m *AskSplitResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ReportSplitRequest) Marshal() (dAtA []byte, err error) {
+func (m *ChangePeer) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5596,40 +6254,25 @@ func (m *ReportSplitRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ReportSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ChangePeer) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
+	if m.Peer != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n53, err := m.Header.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n53
-	}
-	if m.Left != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Left.Size()))
-		n54, err := m.Left.MarshalTo(dAtA[i:])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Peer.Size()))
+		n47, err := m.Peer.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n54
+		i += n47
 	}
-	if m.Right != nil {
-		dAtA[i] = 0x1a
+	if m.ChangeType != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Right.Size()))
-		n55, err := m.Right.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n55
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ChangeType))
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5637,7 +6280,7 @@ func (m *ReportSplitRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *ReportSplitResponse) Marshal() (dAtA []byte, err error) {
+func (m *TransferLeader) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5647,20 +6290,20 @@ func (m *ReportSplitResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ReportSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *TransferLeader) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
+	if m.Peer != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n56, err := m.Header.MarshalTo(dAtA[i:])
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Peer.Size()))
+		n48, err := m.Peer.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n56
+		i += n48
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5668,7 +6311,7 @@ func (m *ReportSplitResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *SplitID) Marshal() (dAtA []byte, err error) {
+func (m *RegionHeartbeatResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5678,29 +6321,156 @@ func (m *SplitID) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SplitID) MarshalTo(dAtA []byte) (int, error) {
+func (m *RegionHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.NewRegionId != 0 {
-		dAtA[i] = 0x8
+	if m.Header != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.NewRegionId))
-	}
-	if len(m.NewPeerIds) > 0 {
-		dAtA58 := make([]byte, len(m.NewPeerIds)*10)
-		var j57 int
-		for _, num := range m.NewPeerIds {
-			for num >= 1<<7 {
-				dAtA58[j57] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j57++
-			}
-			dAtA58[j57] = uint8(num)
-			j57++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n49, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-		dAtA[i] = 0x12
+		i += n49
+	}
+	if m.ChangePeer != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.ChangePeer.Size()))
+		n50, err := m.ChangePeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n50
+	}
+	if m.TransferLeader != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.TransferLeader.Size()))
+		n51, err := m.TransferLeader.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n51
+	}
+	if m.RegionId != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.RegionId))
+	}
+	if m.RegionEpoch != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.RegionEpoch.Size()))
+		n52, err := m.RegionEpoch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n52
+	}
+	if m.TargetPeer != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.TargetPeer.Size()))
+		n53, err := m.TargetPeer.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n53
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *AskSplitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AskSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n54, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
+	}
+	if m.Region != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Region.Size()))
+		n55, err := m.Region.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n55
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *AskSplitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AskSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n56, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n56
+	}
+	if m.NewRegionId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.NewRegionId))
+	}
+	if len(m.NewPeerIds) > 0 {
+		dAtA58 := make([]byte, len(m.NewPeerIds)*10)
+		var j57 int
+		for _, num := range m.NewPeerIds {
+			for num >= 1<<7 {
+				dAtA58[j57] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j57++
+			}
+			dAtA58[j57] = uint8(num)
+			j57++
+		}
+		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(j57))
 		i += copy(dAtA[i:], dAtA58[:j57])
@@ -5711,6 +6481,131 @@ func (m *SplitID) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *ReportSplitRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReportSplitRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n59, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n59
+	}
+	if m.Left != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Left.Size()))
+		n60, err := m.Left.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n60
+	}
+	if m.Right != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Right.Size()))
+		n61, err := m.Right.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n61
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *ReportSplitResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReportSplitResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n62, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n62
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *SplitID) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SplitID) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.NewRegionId != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.NewRegionId))
+	}
+	if len(m.NewPeerIds) > 0 {
+		dAtA64 := make([]byte, len(m.NewPeerIds)*10)
+		var j63 int
+		for _, num := range m.NewPeerIds {
+			for num >= 1<<7 {
+				dAtA64[j63] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j63++
+			}
+			dAtA64[j63] = uint8(num)
+			j63++
+		}
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(j63))
+		i += copy(dAtA[i:], dAtA64[:j63])
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *TimeInterval) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -5848,11 +6743,11 @@ func (m *StoreStats) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x7a
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Interval.Size()))
-		n59, err := m.Interval.MarshalTo(dAtA[i:])
+		n65, err := m.Interval.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n59
+		i += n65
 	}
 	if len(m.CpuUsages) > 0 {
 		for _, msg := range m.CpuUsages {
@@ -5935,21 +6830,21 @@ func (m *StoreHeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n60, err := m.Header.MarshalTo(dAtA[i:])
+		n66, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n60
+		i += n66
 	}
 	if m.Stats != nil {
 		dAtA[i] = 0x12
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Stats.Size()))
-		n61, err := m.Stats.MarshalTo(dAtA[i:])
+		n67, err := m.Stats.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n61
+		i += n67
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -5976,11 +6871,11 @@ func (m *StoreHeartbeatResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n62, err := m.Header.MarshalTo(dAtA[i:])
+		n68, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n62
+		i += n68
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -6007,11 +6902,11 @@ func (m *ScatterRegionRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n63, err := m.Header.MarshalTo(dAtA[i:])
+		n69, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n63
+		i += n69
 	}
 	if m.RegionId != 0 {
 		dAtA[i] = 0x10
@@ -6022,21 +6917,21 @@ func (m *ScatterRegionRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x1a
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Region.Size()))
-		n64, err := m.Region.MarshalTo(dAtA[i:])
+		n70, err := m.Region.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n64
+		i += n70
 	}
 	if m.Leader != nil {
 		dAtA[i] = 0x22
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Leader.Size()))
-		n65, err := m.Leader.MarshalTo(dAtA[i:])
+		n71, err := m.Leader.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n65
+		i += n71
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -6063,11 +6958,11 @@ func (m *ScatterRegionResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n66, err := m.Header.MarshalTo(dAtA[i:])
+		n72, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n66
+		i += n72
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -6094,11 +6989,11 @@ func (m *GetGCSafePointRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n67, err := m.Header.MarshalTo(dAtA[i:])
+		n73, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n67
+		i += n73
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(dAtA[i:], m.XXX_unrecognized)
@@ -6125,11 +7020,11 @@ func (m *GetGCSafePointResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n68, err := m.Header.MarshalTo(dAtA[i:])
+		n74, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n68
+		i += n74
 	}
 	if m.SafePoint != 0 {
 		dAtA[i] = 0x10
@@ -6161,11 +7056,11 @@ func (m *UpdateGCSafePointRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n69, err := m.Header.MarshalTo(dAtA[i:])
+		n75, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n69
+		i += n75
 	}
 	if m.SafePoint != 0 {
 		dAtA[i] = 0x10
@@ -6197,11 +7092,11 @@ func (m *UpdateGCSafePointResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n70, err := m.Header.MarshalTo(dAtA[i:])
+		n76, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n70
+		i += n76
 	}
 	if m.NewSafePoint != 0 {
 		dAtA[i] = 0x10
@@ -6214,6 +7109,100 @@ func (m *UpdateGCSafePointResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *UpdateServiceGCSafePointRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateServiceGCSafePointRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n77, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n77
+	}
+	if len(m.ServiceId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(len(m.ServiceId)))
+		i += copy(dAtA[i:], m.ServiceId)
+	}
+	if m.Ttl != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Ttl))
+	}
+	if m.SafePoint != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.SafePoint))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *UpdateServiceGCSafePointResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateServiceGCSafePointResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n78, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n78
+	}
+	if len(m.ServiceId) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(len(m.ServiceId)))
+		i += copy(dAtA[i:], m.ServiceId)
+	}
+	if m.Ttl != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Ttl))
+	}
+	if m.MinSafePoint != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.MinSafePoint))
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *GetOperatorRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -6233,11 +7222,11 @@ func (m *GetOperatorRequest) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n71, err := m.Header.MarshalTo(dAtA[i:])
+		n79, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n71
+		i += n79
 	}
 	if m.RegionId != 0 {
 		dAtA[i] = 0x10
@@ -6269,11 +7258,11 @@ func (m *GetOperatorResponse) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
-		n72, err := m.Header.MarshalTo(dAtA[i:])
+		n80, err := m.Header.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n72
+		i += n80
 	}
 	if m.RegionId != 0 {
 		dAtA[i] = 0x10
@@ -6303,6 +7292,98 @@ func (m *GetOperatorResponse) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *PrepareRestartRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrepareRestartRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n81, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n81
+	}
+	if m.StoreId != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.StoreId))
+	}
+	if m.Cancel {
+		dAtA[i] = 0x18
+		i++
+		if m.Cancel {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *PrepareRestartResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrepareRestartResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Header != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.Header.Size()))
+		n82, err := m.Header.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n82
+	}
+	if m.LeaderCount != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintSchedulerpb(dAtA, i, uint64(m.LeaderCount))
+	}
+	if m.Ready {
+		dAtA[i] = 0x18
+		i++
+		if m.Ready {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func encodeVarintSchedulerpb(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -6706,6 +7787,101 @@ func (m *ScanRegionsResponse) Size() (n int) {
 	return n
 }
 
+func (m *GetRegionTopologyRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetRegionTopologyResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.Version))
+	}
+	if len(m.Regions) > 0 {
+		for _, e := range m.Regions {
+			l = e.Size()
+			n += 1 + l + sovSchedulerpb(uint64(l))
+		}
+	}
+	if len(m.Leaders) > 0 {
+		for _, e := range m.Leaders {
+			l = e.Size()
+			n += 1 + l + sovSchedulerpb(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetRegionTopologyDiffRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.SinceVersion != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.SinceVersion))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetRegionTopologyDiffResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.Version))
+	}
+	if len(m.Regions) > 0 {
+		for _, e := range m.Regions {
+			l = e.Size()
+			n += 1 + l + sovSchedulerpb(uint64(l))
+		}
+	}
+	if len(m.Leaders) > 0 {
+		for _, e := range m.Leaders {
+			l = e.Size()
+			n += 1 + l + sovSchedulerpb(uint64(l))
+		}
+	}
+	if len(m.RemovedRegionIds) > 0 {
+		l = 0
+		for _, e := range m.RemovedRegionIds {
+			l += sovSchedulerpb(uint64(e))
+		}
+		n += 1 + sovSchedulerpb(uint64(l)) + l
+	}
+	if m.Stale {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *GetClusterConfigRequest) Size() (n int) {
 	var l int
 	_ = l
@@ -7247,17 +8423,63 @@ func (m *UpdateGCSafePointResponse) Size() (n int) {
 	return n
 }
 
-func (m *GetOperatorRequest) Size() (n int) {
+func (m *UpdateServiceGCSafePointRequest) Size() (n int) {
 	var l int
 	_ = l
 	if m.Header != nil {
 		l = m.Header.Size()
 		n += 1 + l + sovSchedulerpb(uint64(l))
 	}
-	if m.RegionId != 0 {
-		n += 1 + sovSchedulerpb(uint64(m.RegionId))
+	l = len(m.ServiceId)
+	if l > 0 {
+		n += 1 + l + sovSchedulerpb(uint64(l))
 	}
-	if m.XXX_unrecognized != nil {
+	if m.Ttl != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.Ttl))
+	}
+	if m.SafePoint != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.SafePoint))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *UpdateServiceGCSafePointResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	l = len(m.ServiceId)
+	if l > 0 {
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.Ttl != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.Ttl))
+	}
+	if m.MinSafePoint != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.MinSafePoint))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GetOperatorRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.RegionId != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.RegionId))
+	}
+	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
@@ -7290,6 +8512,44 @@ func (m *GetOperatorResponse) Size() (n int) {
 	return n
 }
 
+func (m *PrepareRestartRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.StoreId != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.StoreId))
+	}
+	if m.Cancel {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PrepareRestartResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovSchedulerpb(uint64(l))
+	}
+	if m.LeaderCount != 0 {
+		n += 1 + sovSchedulerpb(uint64(m.LeaderCount))
+	}
+	if m.Ready {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovSchedulerpb(x uint64) (n int) {
 	for {
 		n++
@@ -9862,7 +11122,7 @@ func (m *ScanRegionsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetClusterConfigRequest) Unmarshal(dAtA []byte) error {
+func (m *GetRegionTopologyRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9885,10 +11145,10 @@ func (m *GetClusterConfigRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetClusterConfigRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRegionTopologyRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetClusterConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRegionTopologyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -9946,7 +11206,7 @@ func (m *GetClusterConfigRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
+func (m *GetRegionTopologyResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9969,10 +11229,10 @@ func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetClusterConfigResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRegionTopologyResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetClusterConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRegionTopologyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10009,10 +11269,10 @@ func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var msglen int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10022,79 +11282,14 @@ func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Version |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Cluster == nil {
-				m.Cluster = &metapb.Cluster{}
-			}
-			if err := m.Cluster.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSchedulerpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PutClusterConfigRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutClusterConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Regions", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10118,16 +11313,14 @@ func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &RequestHeader{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Regions = append(m.Regions, &metapb.Region{})
+			if err := m.Regions[len(m.Regions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leaders", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10151,10 +11344,8 @@ func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Cluster == nil {
-				m.Cluster = &metapb.Cluster{}
-			}
-			if err := m.Cluster.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Leaders = append(m.Leaders, &metapb.Peer{})
+			if err := m.Leaders[len(m.Leaders)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -10180,7 +11371,7 @@ func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PutClusterConfigResponse) Unmarshal(dAtA []byte) error {
+func (m *GetRegionTopologyDiffRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10203,10 +11394,10 @@ func (m *PutClusterConfigResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PutClusterConfigResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRegionTopologyDiffRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PutClusterConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRegionTopologyDiffRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10236,12 +11427,31 @@ func (m *PutClusterConfigResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+				m.Header = &RequestHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SinceVersion", wireType)
+			}
+			m.SinceVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SinceVersion |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -10264,7 +11474,7 @@ func (m *PutClusterConfigResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Member) Unmarshal(dAtA []byte) error {
+func (m *GetRegionTopologyDiffResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10287,17 +11497,17 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Member: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetRegionTopologyDiffResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Member: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetRegionTopologyDiffResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10307,26 +11517,30 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemberId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			m.MemberId = 0
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10336,16 +11550,16 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MemberId |= (uint64(b) & 0x7F) << shift
+				m.Version |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerUrls", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Regions", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10355,26 +11569,28 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeerUrls = append(m.PeerUrls, string(dAtA[iNdEx:postIndex]))
+			m.Regions = append(m.Regions, &metapb.Region{})
+			if err := m.Regions[len(m.Regions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClientUrls", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leaders", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10384,40 +11600,105 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ClientUrls = append(m.ClientUrls, string(dAtA[iNdEx:postIndex]))
+			m.Leaders = append(m.Leaders, &metapb.Peer{})
+			if err := m.Leaders[len(m.Leaders)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LeaderPriority", wireType)
-			}
-			m.LeaderPriority = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.LeaderPriority |= (int32(b) & 0x7F) << shift
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSchedulerpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RemovedRegionIds = append(m.RemovedRegionIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSchedulerpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthSchedulerpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSchedulerpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RemovedRegionIds = append(m.RemovedRegionIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedRegionIds", wireType)
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stale", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Stale = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -10440,7 +11721,7 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetMembersRequest) Unmarshal(dAtA []byte) error {
+func (m *GetClusterConfigRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10463,10 +11744,10 @@ func (m *GetMembersRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetMembersRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetClusterConfigRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetMembersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetClusterConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10524,7 +11805,7 @@ func (m *GetMembersRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
+func (m *GetClusterConfigResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10547,10 +11828,10 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetMembersResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetClusterConfigResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetMembersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetClusterConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10588,7 +11869,7 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10612,14 +11893,67 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Members = append(m.Members, &Member{})
-			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Cluster == nil {
+				m.Cluster = &metapb.Cluster{}
+			}
+			if err := m.Cluster.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PutClusterConfigRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PutClusterConfigRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PutClusterConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10643,16 +11977,16 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Leader == nil {
-				m.Leader = &Member{}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
 			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EtcdLeader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10676,10 +12010,10 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.EtcdLeader == nil {
-				m.EtcdLeader = &Member{}
+			if m.Cluster == nil {
+				m.Cluster = &metapb.Cluster{}
 			}
-			if err := m.EtcdLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Cluster.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -10705,7 +12039,7 @@ func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
+func (m *PutClusterConfigResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10728,10 +12062,10 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegionHeartbeatRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PutClusterConfigResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegionHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PutClusterConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -10761,17 +12095,68 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Member) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Member: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Member: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10781,30 +12166,26 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Region == nil {
-				m.Region = &metapb.Region{}
-			}
-			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberId", wireType)
 			}
-			var msglen int
+			m.MemberId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10814,30 +12195,16 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.MemberId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Leader == nil {
-				m.Leader = &metapb.Peer{}
-			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PendingPeers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerUrls", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10847,98 +12214,26 @@ func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PendingPeers = append(m.PendingPeers, &metapb.Peer{})
-			if err := m.PendingPeers[len(m.PendingPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.PeerUrls = append(m.PeerUrls, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ApproximateSize", wireType)
-			}
-			m.ApproximateSize = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ApproximateSize |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ChangePeer) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSchedulerpb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ChangePeer: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChangePeer: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ClientUrls", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10948,30 +12243,26 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Peer == nil {
-				m.Peer = &metapb.Peer{}
-			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ClientUrls = append(m.ClientUrls, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderPriority", wireType)
 			}
-			m.ChangeType = 0
+			m.LeaderPriority = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -10981,7 +12272,7 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ChangeType |= (eraftpb.ConfChangeType(b) & 0x7F) << shift
+				m.LeaderPriority |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -11008,7 +12299,7 @@ func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TransferLeader) Unmarshal(dAtA []byte) error {
+func (m *GetMembersRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11031,15 +12322,15 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetMembersRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetMembersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11063,10 +12354,10 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Peer == nil {
-				m.Peer = &metapb.Peer{}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
 			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11092,7 +12383,7 @@ func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
+func (m *GetMembersResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11115,10 +12406,10 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegionHeartbeatResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetMembersResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegionHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetMembersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11156,7 +12447,7 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11180,16 +12471,14 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangePeer == nil {
-				m.ChangePeer = &ChangePeer{}
-			}
-			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Members = append(m.Members, &Member{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11213,68 +12502,16 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TransferLeader == nil {
-				m.TransferLeader = &TransferLeader{}
+			if m.Leader == nil {
+				m.Leader = &Member{}
 			}
-			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
-			}
-			m.RegionId = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.RegionId |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionEpoch", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.RegionEpoch == nil {
-				m.RegionEpoch = &metapb.RegionEpoch{}
-			}
-			if err := m.RegionEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TargetPeer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EtcdLeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11298,10 +12535,10 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TargetPeer == nil {
-				m.TargetPeer = &metapb.Peer{}
+			if m.EtcdLeader == nil {
+				m.EtcdLeader = &Member{}
 			}
-			if err := m.TargetPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.EtcdLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11327,7 +12564,7 @@ func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
+func (m *RegionHeartbeatRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11350,10 +12587,10 @@ func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AskSplitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RegionHeartbeatRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RegionHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11422,60 +12659,42 @@ func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSchedulerpb
-			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Leader == nil {
+				m.Leader = &metapb.Peer{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AskSplitResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AskSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingPeers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11499,18 +12718,16 @@ func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &ResponseHeader{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.PendingPeers = append(m.PendingPeers, &metapb.Peer{})
+			if err := m.PendingPeers[len(m.PendingPeers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 10:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewRegionId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ApproximateSize", wireType)
 			}
-			m.NewRegionId = 0
+			m.ApproximateSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -11520,73 +12737,11 @@ func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NewRegionId |= (uint64(b) & 0x7F) << shift
+				m.ApproximateSize |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowSchedulerpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.NewPeerIds = append(m.NewPeerIds, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowSchedulerpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthSchedulerpb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowSchedulerpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.NewPeerIds = append(m.NewPeerIds, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIds", wireType)
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -11609,7 +12764,7 @@ func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
+func (m *ChangePeer) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11632,15 +12787,15 @@ func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReportSplitRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ChangePeer: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReportSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ChangePeer: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11664,51 +12819,18 @@ func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &RequestHeader{}
+			if m.Peer == nil {
+				m.Peer = &metapb.Peer{}
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Left", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Left == nil {
-				m.Left = &metapb.Region{}
-			}
-			if err := m.Left.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Right", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
 			}
-			var msglen int
+			m.ChangeType = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -11718,25 +12840,11 @@ func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.ChangeType |= (eraftpb.ConfChangeType(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Right == nil {
-				m.Right = &metapb.Region{}
-			}
-			if err := m.Right.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -11759,7 +12867,7 @@ func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReportSplitResponse) Unmarshal(dAtA []byte) error {
+func (m *TransferLeader) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11782,15 +12890,15 @@ func (m *ReportSplitResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReportSplitResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferLeader: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReportSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferLeader: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11814,10 +12922,10 @@ func (m *ReportSplitResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+			if m.Peer == nil {
+				m.Peer = &metapb.Peer{}
 			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11843,7 +12951,7 @@ func (m *ReportSplitResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SplitID) Unmarshal(dAtA []byte) error {
+func (m *RegionHeartbeatResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11866,17 +12974,17 @@ func (m *SplitID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SplitID: wiretype end group for non-group")
+			return fmt.Errorf("proto: RegionHeartbeatResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SplitID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RegionHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewRegionId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			m.NewRegionId = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -11886,129 +12994,96 @@ func (m *SplitID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NewRegionId |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowSchedulerpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.NewPeerIds = append(m.NewPeerIds, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowSchedulerpb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= (int(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthSchedulerpb
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangePeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowSchedulerpb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.NewPeerIds = append(m.NewPeerIds, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIds", wireType)
-			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
-			if err != nil {
-				return err
 			}
-			if skippy < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TimeInterval) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowSchedulerpb
+			if m.ChangePeer == nil {
+				m.ChangePeer = &ChangePeer{}
 			}
-			if iNdEx >= l {
+			if err := m.ChangePeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TransferLeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.TransferLeader == nil {
+				m.TransferLeader = &TransferLeader{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TimeInterval: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TimeInterval: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			if err := m.TransferLeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			m.StartTimestamp = 0
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12018,16 +13093,16 @@ func (m *TimeInterval) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTimestamp |= (uint64(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestamp", wireType)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionEpoch", wireType)
 			}
-			m.EndTimestamp = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12037,11 +13112,58 @@ func (m *TimeInterval) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EndTimestamp |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RegionEpoch == nil {
+				m.RegionEpoch = &metapb.RegionEpoch{}
+			}
+			if err := m.RegionEpoch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetPeer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TargetPeer == nil {
+				m.TargetPeer = &metapb.Peer{}
+			}
+			if err := m.TargetPeer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -12064,7 +13186,7 @@ func (m *TimeInterval) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RecordPair) Unmarshal(dAtA []byte) error {
+func (m *AskSplitRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12087,17 +13209,17 @@ func (m *RecordPair) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RecordPair: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskSplitRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RecordPair: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12107,26 +13229,30 @@ func (m *RecordPair) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthSchedulerpb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = string(dAtA[iNdEx:postIndex])
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
 			}
-			m.Value = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12136,11 +13262,25 @@ func (m *RecordPair) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Value |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -12163,7 +13303,7 @@ func (m *RecordPair) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreStats) Unmarshal(dAtA []byte) error {
+func (m *AskSplitResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12186,17 +13326,17 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreStats: wiretype end group for non-group")
+			return fmt.Errorf("proto: AskSplitResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreStats: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AskSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
-			m.StoreId = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12206,7 +13346,726 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StoreId |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewRegionId", wireType)
+			}
+			m.NewRegionId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NewRegionId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSchedulerpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.NewPeerIds = append(m.NewPeerIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSchedulerpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthSchedulerpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSchedulerpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NewPeerIds = append(m.NewPeerIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIds", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReportSplitRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReportSplitRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReportSplitRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Left", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Left == nil {
+				m.Left = &metapb.Region{}
+			}
+			if err := m.Left.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Right", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Right == nil {
+				m.Right = &metapb.Region{}
+			}
+			if err := m.Right.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReportSplitResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReportSplitResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReportSplitResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SplitID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewRegionId", wireType)
+			}
+			m.NewRegionId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NewRegionId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSchedulerpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.NewPeerIds = append(m.NewPeerIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSchedulerpb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthSchedulerpb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSchedulerpb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.NewPeerIds = append(m.NewPeerIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPeerIds", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TimeInterval) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TimeInterval: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TimeInterval: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestamp", wireType)
+			}
+			m.StartTimestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTimestamp |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestamp", wireType)
+			}
+			m.EndTimestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndTimestamp |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RecordPair) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecordPair: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecordPair: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
+			}
+			m.StoreId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoreId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -12344,11 +14203,176 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsBusy", wireType)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsBusy", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsBusy = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UsedSize", wireType)
+			}
+			m.UsedSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UsedSize |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Interval == nil {
+				m.Interval = &TimeInterval{}
+			}
+			if err := m.Interval.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CpuUsages", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CpuUsages = append(m.CpuUsages, &RecordPair{})
+			if err := m.CpuUsages[len(m.CpuUsages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadIoRates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReadIoRates = append(m.ReadIoRates, &RecordPair{})
+			if err := m.ReadIoRates[len(m.ReadIoRates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteIoRates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WriteIoRates = append(m.WriteIoRates, &RecordPair{})
+			if err := m.WriteIoRates[len(m.WriteIoRates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			var v int
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpLatencies", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12358,17 +14382,79 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.IsBusy = bool(v != 0)
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UsedSize", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
 			}
-			m.UsedSize = 0
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OpLatencies = append(m.OpLatencies, &RecordPair{})
+			if err := m.OpLatencies[len(m.OpLatencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreHeartbeatRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12378,14 +14464,28 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.UsedSize |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 15:
+			if msglen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12409,16 +14509,67 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Interval == nil {
-				m.Interval = &TimeInterval{}
+			if m.Stats == nil {
+				m.Stats = &StoreStats{}
 			}
-			if err := m.Interval.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 16:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreHeartbeatResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CpuUsages", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12442,14 +14593,67 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CpuUsages = append(m.CpuUsages, &RecordPair{})
-			if err := m.CpuUsages[len(m.CpuUsages)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Header == nil {
+				m.Header = &ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 17:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScatterRegionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScatterRegionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ReadIoRates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12473,14 +14677,35 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ReadIoRates = append(m.ReadIoRates, &RecordPair{})
-			if err := m.ReadIoRates[len(m.ReadIoRates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 18:
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+			}
+			m.RegionId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RegionId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WriteIoRates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12504,14 +14729,16 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.WriteIoRates = append(m.WriteIoRates, &RecordPair{})
-			if err := m.WriteIoRates[len(m.WriteIoRates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Region == nil {
+				m.Region = &metapb.Region{}
+			}
+			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 19:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OpLatencies", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12535,8 +14762,10 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OpLatencies = append(m.OpLatencies, &RecordPair{})
-			if err := m.OpLatencies[len(m.OpLatencies)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Leader == nil {
+				m.Leader = &metapb.Peer{}
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -12562,7 +14791,7 @@ func (m *StoreStats) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
+func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12585,10 +14814,10 @@ func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreHeartbeatRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ScatterRegionResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreHeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ScatterRegionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12618,45 +14847,12 @@ func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Stats == nil {
-				m.Stats = &StoreStats{}
-			}
-			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -12679,7 +14875,7 @@ func (m *StoreHeartbeatRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
+func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12702,10 +14898,10 @@ func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StoreHeartbeatResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetGCSafePointRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StoreHeartbeatResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12735,7 +14931,7 @@ func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &ResponseHeader{}
+				m.Header = &RequestHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -12763,7 +14959,7 @@ func (m *StoreHeartbeatResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
+func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12786,10 +14982,10 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ScatterRegionRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetGCSafePointResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ScatterRegionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12819,7 +15015,7 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Header == nil {
-				m.Header = &RequestHeader{}
+				m.Header = &ResponseHeader{}
 			}
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
@@ -12827,9 +15023,9 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
 			}
-			m.RegionId = 0
+			m.SafePoint = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12839,14 +15035,65 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RegionId |= (uint64(b) & 0x7F) << shift
+				m.SafePoint |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSchedulerpb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdateGCSafePointRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdateGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12870,18 +15117,18 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Region == nil {
-				m.Region = &metapb.Region{}
+			if m.Header == nil {
+				m.Header = &RequestHeader{}
 			}
-			if err := m.Region.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
 			}
-			var msglen int
+			m.SafePoint = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -12891,25 +15138,11 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.SafePoint |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Leader == nil {
-				m.Leader = &metapb.Peer{}
-			}
-			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -12932,7 +15165,7 @@ func (m *ScatterRegionRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
+func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12955,10 +15188,10 @@ func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ScatterRegionResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateGCSafePointResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ScatterRegionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12994,6 +15227,25 @@ func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewSafePoint", wireType)
+			}
+			m.NewSafePoint = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NewSafePoint |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -13016,7 +15268,7 @@ func (m *ScatterRegionResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateServiceGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13039,10 +15291,10 @@ func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetGCSafePointRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateServiceGCSafePointRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateServiceGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13077,7 +15329,76 @@ func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
 			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			iNdEx = postIndex
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServiceId = append(m.ServiceId[:0], dAtA[iNdEx:postIndex]...)
+			if m.ServiceId == nil {
+				m.ServiceId = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
+			}
+			m.Ttl = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ttl |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
+			}
+			m.SafePoint = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SafePoint |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -13100,7 +15421,7 @@ func (m *GetGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
+func (m *UpdateServiceGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13123,10 +15444,10 @@ func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetGCSafePointResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateServiceGCSafePointResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateServiceGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13163,10 +15484,41 @@ func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ServiceId = append(m.ServiceId[:0], dAtA[iNdEx:postIndex]...)
+			if m.ServiceId == nil {
+				m.ServiceId = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
 			}
-			m.SafePoint = 0
+			m.Ttl = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13176,7 +15528,26 @@ func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SafePoint |= (uint64(b) & 0x7F) << shift
+				m.Ttl |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinSafePoint", wireType)
+			}
+			m.MinSafePoint = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinSafePoint |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -13203,7 +15574,7 @@ func (m *GetGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
+func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13226,10 +15597,10 @@ func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateGCSafePointRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperatorRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateGCSafePointRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperatorRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13267,9 +15638,9 @@ func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SafePoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			m.SafePoint = 0
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13279,7 +15650,7 @@ func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SafePoint |= (uint64(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -13306,7 +15677,7 @@ func (m *UpdateGCSafePointRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
+func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13329,10 +15700,10 @@ func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UpdateGCSafePointResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetOperatorResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UpdateGCSafePointResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetOperatorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13370,9 +15741,9 @@ func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NewSafePoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
 			}
-			m.NewSafePoint = 0
+			m.RegionId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13382,11 +15753,92 @@ func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NewSafePoint |= (uint64(b) & 0x7F) << shift
+				m.RegionId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Desc", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Desc = append(m.Desc[:0], dAtA[iNdEx:postIndex]...)
+			if m.Desc == nil {
+				m.Desc = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			m.Status = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Status |= (OperatorStatus(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSchedulerpb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kind = append(m.Kind[:0], dAtA[iNdEx:postIndex]...)
+			if m.Kind == nil {
+				m.Kind = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -13409,7 +15861,7 @@ func (m *UpdateGCSafePointResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
+func (m *PrepareRestartRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13432,10 +15884,10 @@ func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetOperatorRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PrepareRestartRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetOperatorRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PrepareRestartRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13473,9 +15925,9 @@ func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StoreId", wireType)
 			}
-			m.RegionId = 0
+			m.StoreId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13485,11 +15937,31 @@ func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RegionId |= (uint64(b) & 0x7F) << shift
+				m.StoreId |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cancel", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSchedulerpb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Cancel = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -13512,7 +15984,7 @@ func (m *GetOperatorRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
+func (m *PrepareRestartResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13535,10 +16007,10 @@ func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetOperatorResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: PrepareRestartResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetOperatorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PrepareRestartResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -13576,9 +16048,9 @@ func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RegionId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaderCount", wireType)
 			}
-			m.RegionId = 0
+			m.LeaderCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13588,66 +16060,16 @@ func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RegionId |= (uint64(b) & 0x7F) << shift
+				m.LeaderCount |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Desc", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Desc = append(m.Desc[:0], dAtA[iNdEx:postIndex]...)
-			if m.Desc == nil {
-				m.Desc = []byte{}
-			}
-			iNdEx = postIndex
-		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
-			}
-			m.Status = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowSchedulerpb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Status |= (OperatorStatus(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ready", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowSchedulerpb
@@ -13657,23 +16079,12 @@ func (m *GetOperatorResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthSchedulerpb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Kind = append(m.Kind[:0], dAtA[iNdEx:postIndex]...)
-			if m.Kind == nil {
-				m.Kind = []byte{}
-			}
-			iNdEx = postIndex
+			m.Ready = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSchedulerpb(dAtA[iNdEx:])
@@ -13801,155 +16212,174 @@ var (
 	ErrIntOverflowSchedulerpb   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("schedulerpb.proto", fileDescriptor_schedulerpb_4e333137f5959f12) }
-
-var fileDescriptor_schedulerpb_4e333137f5959f12 = []byte{
-	// 2343 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0xcf, 0x6f, 0x23, 0x49,
-	0xf5, 0x4f, 0x3b, 0x8e, 0x63, 0x3f, 0x3b, 0xb6, 0x53, 0xc9, 0x26, 0x5e, 0xef, 0x4c, 0x36, 0x53,
-	0x33, 0x3b, 0xdf, 0xd9, 0xf9, 0x32, 0x61, 0xc9, 0x0e, 0xab, 0x15, 0x08, 0xa4, 0xc4, 0xf1, 0x66,
-	0xcc, 0x24, 0xb6, 0xd5, 0x76, 0x06, 0x56, 0x20, 0x35, 0x1d, 0x77, 0xc5, 0x69, 0xa6, 0xdd, 0xdd,
-	0xdb, 0x55, 0xce, 0x8c, 0xe7, 0xca, 0x89, 0x03, 0x08, 0x21, 0x90, 0x90, 0xe0, 0xc0, 0x3f, 0xc1,
-	0x8d, 0x23, 0x07, 0x8e, 0x88, 0x2b, 0x17, 0x34, 0xfc, 0x13, 0x1c, 0x51, 0x55, 0x75, 0xb7, 0xbb,
-	0xdb, 0x76, 0x12, 0xd4, 0x03, 0x37, 0x57, 0xbd, 0x4f, 0xbd, 0xdf, 0x55, 0xf5, 0xea, 0xb5, 0x61,
-	0x9d, 0x0e, 0x2e, 0x89, 0x31, 0xb6, 0x88, 0xe7, 0x9e, 0xef, 0xb9, 0x9e, 0xc3, 0x1c, 0x54, 0x8c,
-	0x4c, 0xd5, 0x4b, 0x23, 0xc2, 0xf4, 0x80, 0x54, 0x5f, 0x23, 0x9e, 0x7e, 0xc1, 0xc2, 0xe1, 0xe6,
-	0xd0, 0x19, 0x3a, 0xe2, 0xe7, 0xd7, 0xf9, 0x2f, 0x39, 0x8b, 0xf7, 0x60, 0x4d, 0x25, 0x5f, 0x8d,
-	0x09, 0x65, 0xcf, 0x88, 0x6e, 0x10, 0x0f, 0xdd, 0x05, 0x18, 0x58, 0x63, 0xca, 0x88, 0xa7, 0x99,
-	0x46, 0x4d, 0xd9, 0x55, 0x1e, 0x65, 0xd5, 0x82, 0x3f, 0xd3, 0x32, 0xf0, 0x97, 0x50, 0x56, 0x09,
-	0x75, 0x1d, 0x9b, 0x92, 0x5b, 0x2d, 0x40, 0x8f, 0x60, 0x85, 0x78, 0x9e, 0xe3, 0xd5, 0x32, 0xbb,
-	0xca, 0xa3, 0xe2, 0x3e, 0xda, 0x8b, 0xda, 0xd0, 0xe4, 0x14, 0x55, 0x02, 0xf0, 0x29, 0xac, 0x88,
-	0x31, 0x7a, 0x0c, 0x59, 0x36, 0x71, 0x89, 0xe0, 0x55, 0xde, 0xdf, 0x9a, 0x5d, 0xd1, 0x9f, 0xb8,
-	0x44, 0x15, 0x18, 0x54, 0x83, 0xd5, 0x11, 0xa1, 0x54, 0x1f, 0x12, 0x21, 0xa0, 0xa0, 0x06, 0x43,
-	0xfc, 0x02, 0xa0, 0x4f, 0x1d, 0xdf, 0x38, 0xb4, 0x0f, 0xb9, 0x4b, 0xa1, 0xaf, 0xe0, 0x5a, 0xdc,
-	0xaf, 0xc7, 0xb8, 0xc6, 0x5c, 0xa0, 0xfa, 0x48, 0xb4, 0x09, 0x2b, 0x03, 0x67, 0x6c, 0x33, 0xc1,
-	0x79, 0x4d, 0x95, 0x03, 0x7c, 0x00, 0x85, 0xbe, 0x39, 0x22, 0x94, 0xe9, 0x23, 0x17, 0xd5, 0x21,
-	0xef, 0x5e, 0x4e, 0xa8, 0x39, 0xd0, 0x2d, 0xc1, 0x78, 0x59, 0x0d, 0xc7, 0x5c, 0x35, 0xcb, 0x19,
-	0x0a, 0x52, 0x46, 0x90, 0x82, 0x21, 0xfe, 0x85, 0x02, 0x45, 0xa1, 0x9b, 0x74, 0x24, 0xfa, 0x34,
-	0xa1, 0xdc, 0x07, 0x09, 0xe5, 0xa2, 0xfe, 0xbe, 0x5e, 0x3b, 0xf4, 0x14, 0x0a, 0x2c, 0xd0, 0xae,
-	0xb6, 0x2c, 0xb8, 0xc5, 0x1d, 0x18, 0xea, 0xae, 0x4e, 0x81, 0xf8, 0x25, 0x54, 0x0f, 0x1d, 0x87,
-	0x51, 0xe6, 0xe9, 0x6e, 0x1a, 0x8f, 0xdd, 0x87, 0x15, 0xca, 0x1c, 0x8f, 0xf8, 0xc1, 0x5e, 0xdb,
-	0xf3, 0x13, 0xb2, 0xc7, 0x27, 0x55, 0x49, 0xc3, 0xcf, 0x60, 0x3d, 0x22, 0x2c, 0x85, 0x0b, 0xf0,
-	0x73, 0x78, 0xaf, 0x45, 0x43, 0x5e, 0x2e, 0x31, 0x52, 0xe8, 0x8e, 0xbf, 0x82, 0xad, 0x24, 0xb3,
-	0x34, 0xe1, 0xc1, 0x50, 0x3a, 0x8f, 0x30, 0x13, 0x1e, 0xc9, 0xab, 0xb1, 0x39, 0x7c, 0x04, 0xe5,
-	0x03, 0xcb, 0x72, 0x06, 0xad, 0xa3, 0x34, 0x8a, 0xbf, 0x80, 0x4a, 0xc8, 0x25, 0x8d, 0xc6, 0x65,
-	0xc8, 0x98, 0x52, 0xcf, 0xac, 0x9a, 0x31, 0x0d, 0xfc, 0x63, 0xa8, 0x1c, 0x13, 0x26, 0x43, 0x97,
-	0x22, 0x27, 0xde, 0x87, 0xbc, 0x88, 0xbb, 0x16, 0x32, 0x5f, 0x15, 0xe3, 0x96, 0x81, 0x7f, 0xa7,
-	0x40, 0x75, 0x2a, 0x22, 0x8d, 0xee, 0xb7, 0x49, 0x3c, 0xf4, 0x84, 0x83, 0x74, 0x46, 0xfd, 0x7d,
-	0xb1, 0x1d, 0x63, 0x2c, 0x90, 0x3d, 0x4e, 0x56, 0x25, 0x0a, 0xff, 0x04, 0x2a, 0xdd, 0x71, 0x7a,
-	0xfb, 0x6f, 0xb5, 0x27, 0x8e, 0xa1, 0x3a, 0x95, 0x95, 0x66, 0x4b, 0xfc, 0x54, 0x81, 0x8d, 0x63,
-	0xc2, 0x0e, 0x2c, 0x4b, 0x30, 0xa3, 0x69, 0x34, 0xff, 0x1c, 0x6a, 0xe4, 0xf5, 0xc0, 0x1a, 0x1b,
-	0x44, 0x63, 0xce, 0xe8, 0x9c, 0x32, 0xc7, 0x26, 0x9a, 0xd0, 0x97, 0xfa, 0xe9, 0xbc, 0xe5, 0xd3,
-	0xfb, 0x01, 0x59, 0x0a, 0xc5, 0x1e, 0x6c, 0xc6, 0x95, 0x48, 0x13, 0xdb, 0x8f, 0x20, 0x17, 0x0a,
-	0x5d, 0x9e, 0xf5, 0xa0, 0x4f, 0xc4, 0x44, 0xe4, 0x92, 0x4a, 0x86, 0xa6, 0x63, 0xa7, 0xb1, 0xfa,
-	0x2e, 0x80, 0x27, 0x98, 0x68, 0x2f, 0xc9, 0x44, 0xd8, 0x59, 0x52, 0x0b, 0x72, 0xe6, 0x39, 0x99,
-	0xe0, 0x3f, 0x29, 0xb0, 0x1e, 0x91, 0x93, 0xc6, 0xb0, 0x87, 0x90, 0x93, 0x7c, 0xfd, 0xd4, 0x28,
-	0x07, 0x86, 0xf9, 0xcc, 0x7d, 0x2a, 0x7a, 0x00, 0x39, 0x4b, 0x32, 0x97, 0x89, 0x5b, 0x0a, 0x70,
-	0x5d, 0xc2, 0xb9, 0x49, 0x1a, 0x47, 0x51, 0x4b, 0xbf, 0x22, 0xb4, 0x96, 0x15, 0x6e, 0x4a, 0xa0,
-	0x24, 0x0d, 0x0f, 0x45, 0x64, 0xa4, 0x80, 0xc3, 0x49, 0xaa, 0x83, 0x07, 0x7d, 0x00, 0xbe, 0x5f,
-	0xa6, 0x5b, 0x3b, 0x2f, 0x27, 0x5a, 0x06, 0xfe, 0xb5, 0x02, 0xa8, 0x37, 0xd0, 0x6d, 0x29, 0x8a,
-	0xa6, 0x94, 0x43, 0x99, 0xee, 0xb1, 0x48, 0x40, 0xf2, 0x62, 0xe2, 0x39, 0x99, 0xf0, 0x6b, 0xd0,
-	0x32, 0x47, 0x26, 0x13, 0xbe, 0x59, 0x51, 0xe5, 0x00, 0x6d, 0xc3, 0x2a, 0xb1, 0x0d, 0xb1, 0x20,
-	0x2b, 0x16, 0xe4, 0x88, 0x6d, 0xf0, 0xf0, 0xfd, 0x5e, 0x81, 0x8d, 0x98, 0x5a, 0x69, 0x02, 0xf8,
-	0x08, 0x56, 0xa5, 0xbd, 0x41, 0x6a, 0x26, 0x23, 0x18, 0x90, 0xd1, 0x43, 0x58, 0x95, 0x61, 0xe2,
-	0x87, 0xcf, 0x6c, 0x74, 0x02, 0x22, 0x3e, 0x85, 0xed, 0x63, 0xc2, 0x1a, 0xb2, 0x7a, 0x6a, 0x38,
-	0xf6, 0x85, 0x39, 0x4c, 0x73, 0x35, 0xbc, 0x81, 0xda, 0x2c, 0xbb, 0x34, 0x16, 0x7f, 0x0c, 0xab,
-	0x7e, 0x69, 0xe7, 0xe7, 0x6c, 0x25, 0xb0, 0xc3, 0x17, 0xa2, 0x06, 0x74, 0xfc, 0x1a, 0xb6, 0xbb,
-	0xe3, 0x77, 0x66, 0xca, 0x7f, 0x22, 0xb9, 0x03, 0xb5, 0x59, 0xc9, 0x69, 0x0e, 0xd5, 0x3f, 0x28,
-	0x90, 0x3b, 0x25, 0xa3, 0x73, 0xe2, 0x21, 0x04, 0x59, 0x5b, 0x1f, 0xc9, 0xda, 0xb4, 0xa0, 0x8a,
-	0xdf, 0x3c, 0x3f, 0x47, 0x82, 0x1a, 0xd9, 0x07, 0x72, 0xa2, 0x65, 0x70, 0xa2, 0x4b, 0x88, 0xa7,
-	0x8d, 0x3d, 0x4b, 0xc6, 0xbe, 0xa0, 0xe6, 0xf9, 0xc4, 0x99, 0x67, 0x51, 0xf4, 0x21, 0x14, 0x07,
-	0x96, 0x49, 0x6c, 0x26, 0xc9, 0x59, 0x41, 0x06, 0x39, 0x25, 0x00, 0xff, 0x07, 0x15, 0x99, 0x1a,
-	0x9a, 0xeb, 0x99, 0x8e, 0x67, 0xb2, 0x49, 0x6d, 0x45, 0xe4, 0x79, 0x59, 0x4e, 0x77, 0xfd, 0x59,
-	0x7c, 0x2c, 0x4e, 0x25, 0xa9, 0x64, 0x9a, 0xcd, 0x86, 0xff, 0xae, 0x00, 0x8a, 0x72, 0x4a, 0x93,
-	0x2d, 0x4f, 0x78, 0x71, 0x2e, 0xf8, 0xf8, 0xfb, 0x63, 0x23, 0xb6, 0x4a, 0xca, 0x50, 0x03, 0x0c,
-	0xfa, 0xff, 0xc4, 0x39, 0x37, 0x17, 0x1d, 0x1c, 0x77, 0x4f, 0xa1, 0x48, 0xd8, 0xc0, 0xd0, 0xfc,
-	0x15, 0xd9, 0xc5, 0x2b, 0x80, 0xe3, 0x4e, 0xa4, 0x75, 0xff, 0x52, 0x60, 0x4b, 0xee, 0xcd, 0x67,
-	0x44, 0xf7, 0xd8, 0x39, 0xd1, 0x59, 0x9a, 0xa4, 0x7c, 0xb7, 0x27, 0xf8, 0x37, 0x60, 0xcd, 0x25,
-	0xb6, 0x61, 0xda, 0x43, 0x8d, 0x67, 0x08, 0xad, 0xad, 0xcc, 0x39, 0x2a, 0x4a, 0x3e, 0x84, 0x0f,
-	0x28, 0xfa, 0x18, 0xaa, 0xba, 0xeb, 0x7a, 0xce, 0x6b, 0x73, 0xa4, 0x33, 0xa2, 0x51, 0xf3, 0x0d,
-	0xa9, 0x81, 0xc8, 0xc0, 0x4a, 0x64, 0xbe, 0x67, 0xbe, 0x21, 0xf8, 0x12, 0xa0, 0x71, 0xa9, 0xdb,
-	0x43, 0xc2, 0x57, 0xa2, 0x5d, 0xc8, 0x72, 0x19, 0xbe, 0xad, 0x71, 0x11, 0x82, 0x82, 0x3e, 0x87,
-	0xe2, 0x40, 0xe0, 0x35, 0xf1, 0x18, 0xcb, 0x88, 0xc7, 0xd8, 0xf6, 0x5e, 0xf0, 0xa8, 0xe4, 0xfb,
-	0x4a, 0xf2, 0x13, 0xaf, 0x31, 0x18, 0x84, 0xbf, 0xf1, 0x3e, 0x94, 0xfb, 0x9e, 0x6e, 0xd3, 0x0b,
-	0xe2, 0x49, 0xb7, 0xdf, 0x2c, 0x0d, 0xff, 0x2d, 0x03, 0xdb, 0x33, 0x81, 0x49, 0x93, 0x7b, 0x53,
-	0xf5, 0x85, 0xe4, 0xcc, 0x9c, 0x92, 0x6f, 0xea, 0x8e, 0x40, 0x7d, 0xe1, 0x9a, 0x23, 0xa8, 0x30,
-	0x5f, 0x7d, 0x2d, 0x16, 0xb5, 0xb8, 0xdc, 0xb8, 0x89, 0x6a, 0x99, 0xc5, 0x4d, 0x8e, 0x5d, 0x8e,
-	0xd9, 0xf8, 0xe5, 0x88, 0x3e, 0x83, 0x92, 0x4f, 0x24, 0xae, 0x33, 0xb8, 0x14, 0x7b, 0x9a, 0x67,
-	0x6f, 0x2c, 0x7b, 0x9a, 0x9c, 0xa4, 0x16, 0xbd, 0xe9, 0x00, 0x3d, 0x81, 0x22, 0xd3, 0xbd, 0x21,
-	0x61, 0xd2, 0xa8, 0xdc, 0x1c, 0x77, 0x82, 0x04, 0xf0, 0xdf, 0x78, 0x04, 0x95, 0x03, 0xfa, 0xb2,
-	0xe7, 0x5a, 0xe6, 0xff, 0x22, 0xcb, 0xf1, 0xcf, 0x15, 0xa8, 0x4e, 0xe5, 0xa5, 0x7b, 0x3c, 0xad,
-	0xd9, 0xe4, 0x95, 0x96, 0xac, 0x2e, 0x8a, 0x36, 0x79, 0xa5, 0x06, 0x3e, 0xdc, 0x85, 0x12, 0xc7,
-	0x88, 0xc3, 0xd5, 0x34, 0xe4, 0xd9, 0x9a, 0x55, 0xc1, 0x26, 0xaf, 0xb8, 0xed, 0x2d, 0x83, 0xe2,
-	0x5f, 0x29, 0x80, 0x54, 0xe2, 0x3a, 0x1e, 0x4b, 0xed, 0x02, 0x0c, 0x59, 0x8b, 0x5c, 0xb0, 0x05,
-	0x0e, 0x10, 0x34, 0xf4, 0x00, 0x56, 0x3c, 0x73, 0x78, 0xc9, 0xfc, 0x6c, 0x49, 0x82, 0x24, 0x11,
-	0x7f, 0x0f, 0x36, 0x62, 0x3a, 0xa5, 0xb9, 0x97, 0x3a, 0xb0, 0x2a, 0xb8, 0xb4, 0x8e, 0x66, 0x3d,
-	0xa6, 0xdc, 0xec, 0xb1, 0xcc, 0x8c, 0xc7, 0x7e, 0x04, 0xa5, 0xbe, 0x39, 0x22, 0x2d, 0x9b, 0x11,
-	0xef, 0x4a, 0xb7, 0xf8, 0xf5, 0x23, 0x2b, 0xaf, 0x69, 0x4f, 0x41, 0xf2, 0x2d, 0x8b, 0xe9, 0x69,
-	0x1f, 0xe4, 0x3e, 0xac, 0xf1, 0x7a, 0x6b, 0x0a, 0x93, 0x01, 0x2b, 0x11, 0xdb, 0x08, 0x41, 0xf8,
-	0x29, 0x80, 0x4a, 0x06, 0x8e, 0x67, 0x74, 0x75, 0xd3, 0x43, 0x55, 0x58, 0xe6, 0xe5, 0x99, 0xbc,
-	0x48, 0xf9, 0x4f, 0x5e, 0xca, 0x5d, 0xe9, 0xd6, 0x98, 0xf8, 0x8b, 0xe5, 0x00, 0xff, 0x72, 0x05,
-	0x60, 0xfa, 0x38, 0x8b, 0x3d, 0x27, 0x95, 0xd8, 0x73, 0x12, 0xd5, 0x21, 0x3f, 0xd0, 0x5d, 0x7d,
-	0xc0, 0x6f, 0x49, 0xff, 0x1a, 0x0e, 0xc6, 0xe8, 0x0e, 0x14, 0xf4, 0x2b, 0xdd, 0xb4, 0xf4, 0x73,
-	0x8b, 0x88, 0x00, 0x65, 0xd5, 0xe9, 0x04, 0xba, 0x17, 0xee, 0x47, 0xd9, 0x52, 0xc9, 0x8a, 0x96,
-	0x8a, 0xbf, 0xf5, 0x1a, 0xa2, 0xb1, 0xf2, 0x35, 0x40, 0xd4, 0x3f, 0x9c, 0xa9, 0xad, 0xbb, 0x3e,
-	0x70, 0x45, 0x00, 0xab, 0x3e, 0xa5, 0x67, 0xeb, 0xae, 0x44, 0x7f, 0x02, 0x9b, 0x1e, 0x19, 0x10,
-	0xf3, 0x2a, 0x81, 0xcf, 0x09, 0x3c, 0x0a, 0x69, 0xd3, 0x15, 0x77, 0x01, 0xa6, 0xae, 0xae, 0xad,
-	0x0a, 0x5c, 0x21, 0xf4, 0x32, 0xda, 0x83, 0x0d, 0xdd, 0x75, 0xad, 0x49, 0x82, 0x5f, 0x5e, 0xe0,
-	0xd6, 0x03, 0xd2, 0x94, 0xdd, 0x36, 0xac, 0x9a, 0x54, 0x3b, 0x1f, 0xd3, 0x49, 0xad, 0x20, 0x9e,
-	0x6a, 0x39, 0x93, 0x1e, 0x8e, 0xe9, 0x84, 0x9f, 0x4b, 0x63, 0x4a, 0x8c, 0xe8, 0x55, 0x91, 0xe7,
-	0x13, 0xfc, 0x8e, 0x40, 0xdf, 0x84, 0xbc, 0xe9, 0xc7, 0xbe, 0x56, 0x11, 0x79, 0xf8, 0xfe, 0x4c,
-	0xf3, 0x28, 0x48, 0x0e, 0x35, 0x84, 0xa2, 0xcf, 0x00, 0x06, 0xee, 0x58, 0x1b, 0x53, 0x7d, 0x48,
-	0x68, 0xad, 0x2a, 0x6e, 0xad, 0xed, 0x44, 0x02, 0x07, 0x71, 0x57, 0x0b, 0x03, 0x77, 0x7c, 0x26,
-	0x90, 0xe8, 0xdb, 0xb0, 0xe6, 0x11, 0xdd, 0xd0, 0x4c, 0x47, 0xf3, 0x74, 0x46, 0x68, 0x6d, 0xfd,
-	0xfa, 0xa5, 0x45, 0x8e, 0x6e, 0x39, 0x2a, 0xc7, 0xa2, 0xef, 0x40, 0xf9, 0x95, 0x67, 0x32, 0x32,
-	0x5d, 0x8d, 0xae, 0x5f, 0x5d, 0x12, 0xf0, 0x60, 0xf9, 0xb7, 0xa0, 0xe4, 0xb8, 0x9a, 0xa5, 0x33,
-	0x62, 0x0f, 0x4c, 0x42, 0x6b, 0x1b, 0x37, 0x88, 0x76, 0xdc, 0x93, 0x00, 0x8b, 0xdf, 0xc0, 0x7b,
-	0x22, 0x23, 0xdf, 0x49, 0x0d, 0x11, 0x76, 0x25, 0x32, 0xb7, 0xea, 0x4a, 0x9c, 0xc2, 0x56, 0x52,
-	0x76, 0x9a, 0x23, 0xe4, 0x8f, 0x0a, 0x6c, 0xf6, 0x06, 0x3a, 0xe3, 0x05, 0x74, 0xea, 0xa7, 0xf3,
-	0x75, 0x0f, 0xc2, 0xc8, 0x2d, 0xb2, 0x7c, 0xcb, 0x5a, 0x29, 0xbb, 0xb8, 0x56, 0xc2, 0x27, 0xf0,
-	0x5e, 0x42, 0xed, 0x94, 0x8d, 0xc4, 0x63, 0xc2, 0x8e, 0x1b, 0x3d, 0xfd, 0x82, 0x74, 0x1d, 0xd3,
-	0x4e, 0x13, 0x50, 0x6c, 0xc1, 0x56, 0x92, 0x59, 0x9a, 0xbb, 0x90, 0x1f, 0x0c, 0xfa, 0x05, 0xd1,
-	0x5c, 0xce, 0xca, 0xf7, 0x6a, 0x81, 0x06, 0xbc, 0xf1, 0x08, 0x6a, 0x67, 0xae, 0xa1, 0x33, 0xf2,
-	0x6e, 0xb4, 0xbf, 0x49, 0xdc, 0x15, 0xbc, 0x3f, 0x47, 0x5c, 0x1a, 0xfb, 0x1e, 0x40, 0x99, 0xdf,
-	0x4a, 0x33, 0x42, 0xf9, 0x5d, 0x15, 0x8a, 0xc0, 0x44, 0xbc, 0x4a, 0x3a, 0x2e, 0xf1, 0x74, 0xe6,
-	0x78, 0xff, 0xb5, 0xae, 0xc5, 0x9f, 0x65, 0xfb, 0x6c, 0x2a, 0x27, 0x8d, 0x65, 0xd7, 0x6e, 0x07,
-	0x04, 0x59, 0x83, 0xd0, 0x81, 0xd8, 0x0c, 0x25, 0x55, 0xfc, 0xe6, 0x52, 0xf8, 0x26, 0x1f, 0x53,
-	0x91, 0xfa, 0xe5, 0x84, 0x94, 0x40, 0xa9, 0x9e, 0x80, 0xa8, 0x3e, 0x94, 0x33, 0x7a, 0x69, 0xda,
-	0x86, 0xb8, 0x8a, 0x4a, 0xaa, 0xf8, 0xfd, 0xf8, 0x37, 0x0a, 0x14, 0xc2, 0x2f, 0x25, 0x28, 0x07,
-	0x99, 0xce, 0xf3, 0xea, 0x12, 0x2a, 0xc2, 0xea, 0x59, 0xfb, 0x79, 0xbb, 0xf3, 0xfd, 0x76, 0x55,
-	0x41, 0x9b, 0x50, 0x6d, 0x77, 0xfa, 0xda, 0x61, 0xa7, 0xd3, 0xef, 0xf5, 0xd5, 0x83, 0x6e, 0xb7,
-	0x79, 0x54, 0xcd, 0xa0, 0x0d, 0xa8, 0xf4, 0xfa, 0x1d, 0xb5, 0xa9, 0xf5, 0x3b, 0xa7, 0x87, 0xbd,
-	0x7e, 0xa7, 0xdd, 0xac, 0x2e, 0xa3, 0x1a, 0x6c, 0x1e, 0x9c, 0xa8, 0xcd, 0x83, 0xa3, 0x2f, 0xe3,
-	0xf0, 0x2c, 0xa7, 0xb4, 0xda, 0x8d, 0xce, 0x69, 0xf7, 0xa0, 0xdf, 0x3a, 0x3c, 0x69, 0x6a, 0x2f,
-	0x9a, 0x6a, 0xaf, 0xd5, 0x69, 0x57, 0x57, 0x38, 0x7b, 0xb5, 0x79, 0xdc, 0xea, 0xb4, 0x35, 0x2e,
-	0xe5, 0x8b, 0xce, 0x59, 0xfb, 0xa8, 0x9a, 0x7b, 0xdc, 0x85, 0x72, 0xdc, 0x0a, 0xae, 0x53, 0xef,
-	0xac, 0xd1, 0x68, 0xf6, 0x7a, 0x52, 0xc1, 0x7e, 0xeb, 0xb4, 0xd9, 0x39, 0xeb, 0x57, 0x15, 0x04,
-	0x90, 0x6b, 0x1c, 0xb4, 0x1b, 0xcd, 0x93, 0x6a, 0x86, 0x13, 0xd4, 0x66, 0xf7, 0xe4, 0xa0, 0xc1,
-	0xd5, 0xe1, 0x83, 0xb3, 0x76, 0xbb, 0xd5, 0x3e, 0xae, 0x66, 0xf7, 0x7f, 0x56, 0x86, 0x42, 0x2f,
-	0x70, 0x12, 0xea, 0x00, 0x4c, 0xdf, 0xae, 0x68, 0x27, 0xe6, 0xbe, 0x99, 0xe7, 0x71, 0xfd, 0xc3,
-	0x85, 0x74, 0x19, 0x4e, 0xbc, 0x84, 0xbe, 0x0b, 0xcb, 0x7d, 0xea, 0xa0, 0xf8, 0xa1, 0x3c, 0xfd,
-	0xac, 0x54, 0xaf, 0xcd, 0x12, 0x82, 0xb5, 0x8f, 0x94, 0x4f, 0x14, 0x74, 0x02, 0x85, 0xf0, 0x93,
-	0x02, 0xba, 0x1b, 0x03, 0x27, 0x3f, 0xb8, 0xd4, 0x77, 0x16, 0x91, 0x43, 0x6d, 0x7e, 0x08, 0xe5,
-	0xf8, 0x27, 0x0a, 0x84, 0x63, 0x6b, 0xe6, 0x7e, 0x0c, 0xa9, 0xdf, 0xbf, 0x16, 0x13, 0x32, 0xff,
-	0x02, 0x56, 0xfd, 0xcf, 0x08, 0x28, 0x9e, 0x77, 0xf1, 0x4f, 0x14, 0xf5, 0x3b, 0xf3, 0x89, 0x21,
-	0x9f, 0x16, 0xe4, 0x83, 0x9e, 0x3e, 0xba, 0x93, 0xf4, 0x70, 0xb4, 0x9b, 0x5e, 0xbf, 0xbb, 0x80,
-	0x1a, 0x65, 0x15, 0x74, 0xc5, 0x13, 0xac, 0x12, 0x8d, 0xf9, 0x04, 0xab, 0x64, 0x2b, 0x1d, 0x2f,
-	0xa1, 0x33, 0x28, 0x45, 0x3b, 0xd2, 0x68, 0x37, 0x29, 0x3b, 0xd9, 0x31, 0xaf, 0xdf, 0xbb, 0x06,
-	0x11, 0x8d, 0x48, 0xfc, 0x36, 0x4e, 0x44, 0x64, 0x6e, 0x99, 0x90, 0x88, 0xc8, 0xfc, 0xeb, 0x1c,
-	0x2f, 0xa1, 0x73, 0xa8, 0x24, 0x9e, 0xc4, 0xe8, 0x7e, 0xe2, 0xdc, 0x99, 0xd7, 0xc9, 0xa8, 0x3f,
-	0xb8, 0x1e, 0x94, 0x4c, 0xd0, 0xb0, 0x1f, 0x8c, 0x66, 0x02, 0x12, 0x2b, 0x09, 0xea, 0x3b, 0x8b,
-	0xc8, 0xa1, 0xc6, 0x5d, 0x58, 0x3b, 0x26, 0xac, 0xeb, 0x91, 0xab, 0x77, 0xc5, 0xb1, 0x2f, 0x38,
-	0x4e, 0xfb, 0xd5, 0xe8, 0xde, 0xfc, 0x25, 0x91, 0x5e, 0xf6, 0x2d, 0xb8, 0xaa, 0x50, 0x8c, 0x34,
-	0x81, 0x51, 0xfc, 0x20, 0x98, 0xed, 0x5a, 0xd7, 0x77, 0x17, 0x03, 0xa2, 0xc9, 0x1a, 0x3c, 0x7e,
-	0x13, 0xc9, 0x9a, 0x78, 0x83, 0x27, 0x92, 0x35, 0xf9, 0x62, 0xc6, 0x4b, 0x48, 0x17, 0x9f, 0x32,
-	0x62, 0x0d, 0x4c, 0xf4, 0x20, 0x69, 0xd4, 0xbc, 0xce, 0x6a, 0xfd, 0xa3, 0x1b, 0x50, 0x51, 0x11,
-	0xc9, 0x1e, 0x69, 0x42, 0xc4, 0x82, 0xe6, 0x6d, 0x42, 0xc4, 0xa2, 0x46, 0x2b, 0x5e, 0x42, 0x3f,
-	0x80, 0xb5, 0x58, 0x89, 0x96, 0x08, 0xdd, 0xbc, 0xaa, 0xb3, 0x8e, 0xaf, 0x83, 0x44, 0x77, 0x5d,
-	0xbc, 0xc2, 0x4a, 0xec, 0xba, 0xb9, 0xb5, 0x5c, 0x62, 0xd7, 0xcd, 0x2f, 0xd1, 0xf0, 0x12, 0x32,
-	0x60, 0x7d, 0xa6, 0xc2, 0x41, 0x71, 0xa3, 0x17, 0x15, 0x5c, 0xf5, 0x87, 0x37, 0xc1, 0xa2, 0x19,
-	0x18, 0xa9, 0x33, 0xd0, 0xcc, 0x55, 0x94, 0xa8, 0x74, 0xea, 0xbb, 0x8b, 0x01, 0x01, 0xcf, 0xc3,
-	0xea, 0x5f, 0xde, 0xee, 0x28, 0x7f, 0x7d, 0xbb, 0xa3, 0xfc, 0xe3, 0xed, 0x8e, 0xf2, 0xdb, 0x7f,
-	0xee, 0x2c, 0x9d, 0xe7, 0xc4, 0x9f, 0x3c, 0x3e, 0xfd, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x40,
-	0x5d, 0x19, 0x5a, 0x39, 0x22, 0x00, 0x00,
+func init() { proto.RegisterFile("schedulerpb.proto", fileDescriptor_schedulerpb_8e72294cef10d252) }
+
+var fileDescriptor_schedulerpb_8e72294cef10d252 = []byte{
+	// 2647 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0xcf, 0x6f, 0xe4, 0x48,
+	0xf5, 0x1f, 0xf7, 0xaf, 0xa4, 0x5f, 0xff, 0x48, 0xa7, 0x92, 0x49, 0x3c, 0xde, 0x49, 0x36, 0x53,
+	0x99, 0x9d, 0xef, 0xec, 0x7c, 0x77, 0x86, 0x65, 0x76, 0x59, 0xad, 0x40, 0x20, 0xe5, 0x47, 0x6f,
+	0xb6, 0x99, 0xa4, 0xbb, 0xe5, 0xee, 0x0c, 0xac, 0x40, 0x32, 0x8e, 0x5d, 0xe9, 0x98, 0x71, 0xdb,
+	0x5e, 0xdb, 0x9d, 0xd9, 0x9e, 0x03, 0x17, 0x10, 0x37, 0x10, 0x42, 0x20, 0x21, 0xc1, 0x81, 0x0b,
+	0x57, 0x6e, 0x1c, 0x90, 0xf6, 0xc8, 0x81, 0x23, 0xe2, 0xca, 0x05, 0x0d, 0xff, 0x04, 0x47, 0x54,
+	0x55, 0xb6, 0xdb, 0x76, 0xff, 0x48, 0x56, 0xce, 0x22, 0x71, 0xeb, 0xaa, 0xf7, 0xa9, 0xf7, 0x5e,
+	0xbd, 0xf7, 0xaa, 0xea, 0xf9, 0xbd, 0x86, 0x55, 0x4f, 0xbb, 0x20, 0xfa, 0xc8, 0x24, 0xae, 0x73,
+	0xf6, 0xc4, 0x71, 0x6d, 0xdf, 0x46, 0x95, 0xd8, 0x94, 0x54, 0x1d, 0x12, 0x5f, 0x0d, 0x49, 0x52,
+	0x8d, 0xb8, 0xea, 0xb9, 0x1f, 0x0d, 0xd7, 0x07, 0xf6, 0xc0, 0x66, 0x3f, 0xbf, 0x42, 0x7f, 0xf1,
+	0x59, 0xfc, 0x04, 0x6a, 0x32, 0xf9, 0x74, 0x44, 0x3c, 0xff, 0x63, 0xa2, 0xea, 0xc4, 0x45, 0x5b,
+	0x00, 0x9a, 0x39, 0xf2, 0x7c, 0xe2, 0x2a, 0x86, 0x2e, 0x0a, 0x3b, 0xc2, 0xc3, 0x82, 0x5c, 0x0e,
+	0x66, 0x5a, 0x3a, 0xfe, 0x04, 0xea, 0x32, 0xf1, 0x1c, 0xdb, 0xf2, 0xc8, 0xb5, 0x16, 0xa0, 0x87,
+	0x50, 0x24, 0xae, 0x6b, 0xbb, 0x62, 0x6e, 0x47, 0x78, 0x58, 0x79, 0x8a, 0x9e, 0xc4, 0xf7, 0xd0,
+	0xa4, 0x14, 0x99, 0x03, 0xf0, 0x09, 0x14, 0xd9, 0x18, 0x3d, 0x82, 0x82, 0x3f, 0x76, 0x08, 0xe3,
+	0x55, 0x7f, 0xba, 0x31, 0xbd, 0xa2, 0x3f, 0x76, 0x88, 0xcc, 0x30, 0x48, 0x84, 0xa5, 0x21, 0xf1,
+	0x3c, 0x75, 0x40, 0x98, 0x80, 0xb2, 0x1c, 0x0e, 0xf1, 0x73, 0x80, 0xbe, 0x67, 0x07, 0x9b, 0x43,
+	0x4f, 0xa1, 0x74, 0xc1, 0xf4, 0x65, 0x5c, 0x2b, 0x4f, 0xa5, 0x04, 0xd7, 0x84, 0x09, 0xe4, 0x00,
+	0x89, 0xd6, 0xa1, 0xa8, 0xd9, 0x23, 0xcb, 0x67, 0x9c, 0x6b, 0x32, 0x1f, 0xe0, 0x3d, 0x28, 0xf7,
+	0x8d, 0x21, 0xf1, 0x7c, 0x75, 0xe8, 0x20, 0x09, 0x96, 0x9d, 0x8b, 0xb1, 0x67, 0x68, 0xaa, 0xc9,
+	0x18, 0xe7, 0xe5, 0x68, 0x4c, 0x55, 0x33, 0xed, 0x01, 0x23, 0xe5, 0x18, 0x29, 0x1c, 0xe2, 0x9f,
+	0x0b, 0x50, 0x61, 0xba, 0x71, 0x43, 0xa2, 0xf7, 0x52, 0xca, 0xbd, 0x91, 0x52, 0x2e, 0x6e, 0xef,
+	0xc5, 0xda, 0xa1, 0xf7, 0xa1, 0xec, 0x87, 0xda, 0x89, 0x79, 0xc6, 0x2d, 0x69, 0xc0, 0x48, 0x77,
+	0x79, 0x02, 0xc4, 0x2f, 0xa0, 0xb1, 0x6f, 0xdb, 0xbe, 0xe7, 0xbb, 0xaa, 0x93, 0xc5, 0x62, 0xbb,
+	0x50, 0xf4, 0x7c, 0xdb, 0x25, 0x81, 0xb3, 0x6b, 0x4f, 0x82, 0x80, 0xec, 0xd1, 0x49, 0x99, 0xd3,
+	0xf0, 0xc7, 0xb0, 0x1a, 0x13, 0x96, 0xc1, 0x04, 0xf8, 0x19, 0xdc, 0x6e, 0x79, 0x11, 0x2f, 0x87,
+	0xe8, 0x19, 0x74, 0xc7, 0x9f, 0xc2, 0x46, 0x9a, 0x59, 0x16, 0xf7, 0x60, 0xa8, 0x9e, 0xc5, 0x98,
+	0x31, 0x8b, 0x2c, 0xcb, 0x89, 0x39, 0x7c, 0x08, 0xf5, 0x3d, 0xd3, 0xb4, 0xb5, 0xd6, 0x61, 0x16,
+	0xc5, 0x9f, 0xc3, 0x4a, 0xc4, 0x25, 0x8b, 0xc6, 0x75, 0xc8, 0x19, 0x5c, 0xcf, 0x82, 0x9c, 0x33,
+	0x74, 0xfc, 0x03, 0x58, 0x39, 0x22, 0x3e, 0x77, 0x5d, 0x86, 0x98, 0xb8, 0x03, 0xcb, 0xcc, 0xef,
+	0x4a, 0xc4, 0x7c, 0x89, 0x8d, 0x5b, 0x3a, 0xfe, 0xad, 0x00, 0x8d, 0x89, 0x88, 0x2c, 0xba, 0x5f,
+	0x27, 0xf0, 0xd0, 0x63, 0x0a, 0x52, 0x7d, 0x2f, 0x38, 0x17, 0x9b, 0x09, 0xc6, 0x0c, 0xd9, 0xa3,
+	0x64, 0x99, 0xa3, 0xf0, 0x0f, 0x61, 0xa5, 0x3b, 0xca, 0xbe, 0xff, 0x6b, 0x9d, 0x89, 0x23, 0x68,
+	0x4c, 0x64, 0x65, 0x39, 0x12, 0x3f, 0x16, 0x60, 0xed, 0x88, 0xf8, 0x7b, 0xa6, 0xc9, 0x98, 0x79,
+	0x59, 0x34, 0xff, 0x10, 0x44, 0xf2, 0x99, 0x66, 0x8e, 0x74, 0xa2, 0xf8, 0xf6, 0xf0, 0xcc, 0xf3,
+	0x6d, 0x8b, 0x28, 0x4c, 0x5f, 0x2f, 0x08, 0xe7, 0x8d, 0x80, 0xde, 0x0f, 0xc9, 0x5c, 0x28, 0x76,
+	0x61, 0x3d, 0xa9, 0x44, 0x16, 0xdf, 0xbe, 0x05, 0xa5, 0x48, 0x68, 0x7e, 0xda, 0x82, 0x01, 0x11,
+	0x13, 0x16, 0x4b, 0x32, 0x19, 0x18, 0xb6, 0x95, 0x65, 0xd7, 0x5b, 0x00, 0x2e, 0x63, 0xa2, 0xbc,
+	0x20, 0x63, 0xb6, 0xcf, 0xaa, 0x5c, 0xe6, 0x33, 0xcf, 0xc8, 0x18, 0x7f, 0x2e, 0xc0, 0x6a, 0x4c,
+	0x4e, 0x96, 0x8d, 0x3d, 0x80, 0x12, 0xe7, 0x1b, 0x84, 0x46, 0x3d, 0xdc, 0x58, 0xc0, 0x3c, 0xa0,
+	0xa2, 0xfb, 0x50, 0x32, 0x39, 0x73, 0x1e, 0xb8, 0xd5, 0x10, 0xd7, 0x25, 0x94, 0x1b, 0xa7, 0x51,
+	0x94, 0x67, 0xaa, 0x97, 0xc4, 0x13, 0x0b, 0x3b, 0xf9, 0x69, 0x14, 0xa7, 0xe1, 0x01, 0xf3, 0x0c,
+	0x17, 0xb0, 0x3f, 0xce, 0x74, 0xf1, 0xa0, 0x37, 0x20, 0xb0, 0xcb, 0xe4, 0x68, 0x2f, 0xf3, 0x89,
+	0x96, 0x8e, 0x7f, 0x25, 0x00, 0xea, 0x69, 0xaa, 0xc5, 0x45, 0x79, 0x19, 0xe5, 0x78, 0xbe, 0xea,
+	0xfa, 0x31, 0x87, 0x2c, 0xb3, 0x89, 0x67, 0x64, 0x4c, 0x9f, 0x41, 0xd3, 0x18, 0x1a, 0x3e, 0xb3,
+	0x4d, 0x51, 0xe6, 0x03, 0xb4, 0x09, 0x4b, 0xc4, 0xd2, 0xd9, 0x82, 0x02, 0x5b, 0x50, 0x22, 0x96,
+	0x4e, 0xdd, 0xf7, 0x3b, 0x01, 0xd6, 0x12, 0x6a, 0x65, 0x71, 0xe0, 0x43, 0x58, 0xe2, 0xfb, 0x0d,
+	0x43, 0x33, 0xed, 0xc1, 0x90, 0x8c, 0x1e, 0xc0, 0x12, 0x77, 0x13, 0xbd, 0x7c, 0xa6, 0xbd, 0x13,
+	0x12, 0x71, 0x1b, 0xc4, 0xc8, 0x3d, 0x7d, 0xdb, 0xb1, 0x4d, 0x7b, 0x30, 0xce, 0xf2, 0x36, 0x7c,
+	0x2e, 0xc0, 0x9d, 0x19, 0x0c, 0xb3, 0x6c, 0x5a, 0x84, 0xa5, 0x4b, 0xe2, 0x7a, 0x61, 0xd8, 0x16,
+	0xe4, 0x70, 0x18, 0x37, 0x47, 0xfe, 0xda, 0xe6, 0x28, 0x2c, 0x32, 0xc7, 0x4b, 0xb8, 0x3b, 0xa5,
+	0xfd, 0xa1, 0x71, 0x7e, 0x9e, 0xed, 0x3e, 0xae, 0x79, 0x86, 0xa5, 0x11, 0x25, 0xb9, 0x8b, 0x2a,
+	0x9b, 0x7c, 0xce, 0xe7, 0xf0, 0x4f, 0x72, 0xb0, 0x35, 0x47, 0xf2, 0xff, 0x88, 0xed, 0xd0, 0x3b,
+	0x80, 0x5c, 0x32, 0xb4, 0x2f, 0x89, 0xae, 0x44, 0xa7, 0xd4, 0x13, 0x8b, 0x3b, 0xf9, 0x87, 0x05,
+	0xb9, 0x11, 0x50, 0xe4, 0xe0, 0xb4, 0x7a, 0xf4, 0x18, 0x79, 0xbe, 0x6a, 0x12, 0xb1, 0xc4, 0x2e,
+	0x76, 0x3e, 0xc0, 0x27, 0xb0, 0x79, 0x44, 0xfc, 0x03, 0x9e, 0xcc, 0x1f, 0xd8, 0xd6, 0xb9, 0x31,
+	0xc8, 0x12, 0x8d, 0xaf, 0x40, 0x9c, 0x66, 0x97, 0xc5, 0x9e, 0x6f, 0xc3, 0x52, 0xf0, 0xa5, 0x11,
+	0x5c, 0xa1, 0x2b, 0xa1, 0x2d, 0x02, 0x21, 0x72, 0x48, 0xc7, 0x9f, 0xc1, 0x66, 0x77, 0x74, 0x63,
+	0x5b, 0xf9, 0x22, 0x92, 0x3b, 0x20, 0x4e, 0x4b, 0xce, 0xf2, 0xc6, 0xff, 0x5e, 0x80, 0xd2, 0x09,
+	0x19, 0x9e, 0x11, 0x17, 0x21, 0x28, 0x58, 0xea, 0x90, 0x7f, 0x2a, 0x95, 0x65, 0xf6, 0x9b, 0x5e,
+	0x97, 0x43, 0x46, 0x8d, 0x5d, 0xcb, 0x7c, 0xa2, 0xa5, 0x53, 0xa2, 0x43, 0x88, 0xab, 0x8c, 0x5c,
+	0x93, 0x47, 0x5a, 0x59, 0x5e, 0xa6, 0x13, 0xa7, 0xae, 0xe9, 0xa1, 0x37, 0xa1, 0xa2, 0x99, 0x06,
+	0xb1, 0x7c, 0x4e, 0x2e, 0x30, 0x32, 0xf0, 0x29, 0x06, 0xf8, 0x3f, 0x58, 0xe1, 0xe1, 0xa5, 0x38,
+	0xae, 0x61, 0xbb, 0x86, 0x3f, 0x16, 0x8b, 0xec, 0xda, 0xad, 0xf3, 0xe9, 0x6e, 0x30, 0x8b, 0x8f,
+	0xd8, 0x23, 0xc9, 0x95, 0xcc, 0x72, 0xf7, 0xe3, 0x7f, 0x08, 0x80, 0xe2, 0x9c, 0xb2, 0x44, 0xcb,
+	0x63, 0xfa, 0xad, 0xc8, 0xf8, 0x04, 0xd7, 0xf5, 0x5a, 0x62, 0x15, 0x97, 0x21, 0x87, 0x18, 0xf4,
+	0xff, 0xa9, 0x67, 0x77, 0x26, 0x3a, 0x80, 0xa0, 0xf7, 0xa1, 0x42, 0x7c, 0x4d, 0x57, 0x82, 0x15,
+	0x85, 0xf9, 0x2b, 0x80, 0xe2, 0x8e, 0xf9, 0xee, 0xfe, 0x2d, 0xc0, 0x06, 0x3f, 0x83, 0x1f, 0x13,
+	0xd5, 0xf5, 0xcf, 0x88, 0xea, 0x67, 0x09, 0xca, 0x9b, 0x4d, 0x28, 0xbe, 0x0a, 0x35, 0x87, 0x58,
+	0xba, 0x61, 0x0d, 0x14, 0x87, 0x10, 0x97, 0xdf, 0x1d, 0x69, 0x70, 0x35, 0x80, 0xd0, 0x81, 0x87,
+	0xde, 0x86, 0x86, 0xea, 0x38, 0xae, 0xfd, 0x99, 0x31, 0x54, 0x7d, 0xa2, 0x78, 0xc6, 0x2b, 0x22,
+	0x02, 0x8b, 0xc0, 0x95, 0xd8, 0x7c, 0xcf, 0x78, 0x45, 0xf0, 0x05, 0xc0, 0xc1, 0x85, 0x6a, 0x0d,
+	0x08, 0x5d, 0x89, 0x76, 0xa0, 0xe0, 0x90, 0x68, 0xaf, 0x49, 0x11, 0x8c, 0x82, 0x3e, 0x84, 0x8a,
+	0xc6, 0xf0, 0x0a, 0xab, 0x0d, 0xe4, 0x58, 0x6d, 0x60, 0xf3, 0x49, 0x58, 0xe3, 0xa0, 0xe7, 0x8a,
+	0xf3, 0x63, 0xc5, 0x01, 0xd0, 0xa2, 0xdf, 0xf8, 0x29, 0xd4, 0xfb, 0xae, 0x6a, 0x79, 0xe7, 0xc4,
+	0xe5, 0x66, 0xbf, 0x5a, 0x1a, 0xfe, 0x7b, 0x0e, 0x36, 0xa7, 0x1c, 0x93, 0x25, 0xf6, 0x26, 0xea,
+	0x33, 0xc9, 0xb9, 0x19, 0x5f, 0x20, 0x13, 0x73, 0x84, 0xea, 0xd3, 0xdf, 0xe8, 0x10, 0x56, 0xfc,
+	0x40, 0x7d, 0x25, 0xe1, 0xb5, 0xa4, 0xdc, 0xe4, 0x16, 0xe5, 0xba, 0x9f, 0xdc, 0x72, 0x22, 0x57,
+	0x2b, 0x24, 0x73, 0x35, 0xf4, 0x01, 0x54, 0x03, 0x22, 0x71, 0x6c, 0xed, 0x42, 0x2c, 0x06, 0xd1,
+	0x9b, 0x88, 0x9e, 0x26, 0x25, 0xc9, 0x15, 0x77, 0x32, 0x40, 0x8f, 0xa1, 0xe2, 0xab, 0xee, 0x80,
+	0xf8, 0x7c, 0x53, 0xa5, 0x19, 0xe6, 0x04, 0x0e, 0xa0, 0xbf, 0xf1, 0x10, 0x56, 0xf6, 0xbc, 0x17,
+	0x3d, 0xc7, 0x34, 0xfe, 0x1b, 0x51, 0x8e, 0x7f, 0x26, 0x40, 0x63, 0x22, 0x2f, 0xdb, 0xb7, 0x7c,
+	0xcd, 0x22, 0x2f, 0x95, 0x74, 0xb2, 0x5b, 0xb1, 0xc8, 0xcb, 0xf0, 0x05, 0x45, 0x3b, 0x50, 0xa5,
+	0x18, 0x76, 0xb9, 0x1a, 0x3a, 0xbf, 0x5b, 0x0b, 0x32, 0x58, 0xe4, 0x25, 0xdd, 0x7b, 0x4b, 0xf7,
+	0xf0, 0x2f, 0x05, 0x40, 0x32, 0x71, 0x6c, 0xd7, 0xcf, 0x6c, 0x02, 0x0c, 0x05, 0x93, 0x9c, 0xfb,
+	0x73, 0x0c, 0xc0, 0x68, 0xe8, 0x3e, 0x14, 0x5d, 0x63, 0x70, 0xe1, 0x8b, 0xf9, 0x99, 0x20, 0x4e,
+	0xc4, 0xdf, 0x86, 0xb5, 0x84, 0x4e, 0x59, 0xde, 0xa5, 0x0e, 0x2c, 0x31, 0x2e, 0xad, 0xc3, 0x69,
+	0x8b, 0x09, 0x57, 0x5b, 0x2c, 0x37, 0x65, 0xb1, 0xef, 0x43, 0x95, 0x96, 0xab, 0x5a, 0x96, 0x4f,
+	0xdc, 0x4b, 0xd5, 0xa4, 0xcf, 0x0f, 0xff, 0x10, 0x98, 0x94, 0xb8, 0x38, 0xdf, 0x3a, 0x9b, 0x9e,
+	0x94, 0xe5, 0x76, 0xa1, 0x46, 0xd3, 0xff, 0x09, 0x2c, 0xc8, 0xf1, 0x88, 0xa5, 0x47, 0x20, 0xfc,
+	0x3e, 0x80, 0x4c, 0x34, 0xdb, 0xd5, 0xbb, 0xaa, 0xe1, 0xa2, 0x06, 0xe4, 0xe9, 0xd7, 0x02, 0x7f,
+	0x48, 0xe9, 0x4f, 0x9a, 0x12, 0x5d, 0xaa, 0xe6, 0x88, 0x04, 0x8b, 0xf9, 0x00, 0xff, 0xa2, 0x08,
+	0x30, 0xa9, 0x15, 0x24, 0xaa, 0x1b, 0x42, 0xa2, 0xba, 0x41, 0x6b, 0x83, 0x9a, 0xea, 0xa8, 0x1a,
+	0x7d, 0x25, 0x83, 0x67, 0x38, 0x1c, 0xa3, 0xbb, 0x50, 0x56, 0x2f, 0x55, 0xc3, 0x54, 0xcf, 0x4c,
+	0xc2, 0x1c, 0x54, 0x90, 0x27, 0x13, 0xe8, 0x5e, 0x74, 0x1e, 0x79, 0x85, 0xaf, 0xc0, 0x2a, 0x7c,
+	0xc1, 0xd1, 0x3b, 0xa0, 0x53, 0x34, 0xbb, 0xf3, 0x82, 0xcb, 0xd9, 0xb3, 0x54, 0x27, 0x00, 0x16,
+	0x19, 0xb0, 0x11, 0x50, 0x7a, 0x96, 0xea, 0x70, 0xf4, 0xbb, 0xb0, 0xee, 0x12, 0x8d, 0x18, 0x97,
+	0x29, 0x7c, 0x89, 0xe1, 0x51, 0x44, 0x9b, 0xac, 0xd8, 0x02, 0x98, 0x98, 0x5a, 0x5c, 0x62, 0xb8,
+	0x72, 0x64, 0x65, 0xf4, 0x04, 0xd6, 0x54, 0xc7, 0x31, 0xc7, 0x29, 0x7e, 0xcb, 0x0c, 0xb7, 0x1a,
+	0x92, 0x26, 0xec, 0x36, 0x61, 0xc9, 0xf0, 0x94, 0xb3, 0x91, 0x37, 0x16, 0xcb, 0x2c, 0xc1, 0x2c,
+	0x19, 0xde, 0xfe, 0xc8, 0x1b, 0xd3, 0x7b, 0x69, 0xe4, 0x11, 0x3d, 0xfe, 0x54, 0x2c, 0xd3, 0x09,
+	0xfa, 0x46, 0xa0, 0xaf, 0xc1, 0xb2, 0x11, 0xf8, 0x5e, 0x5c, 0x61, 0x71, 0x78, 0x67, 0xaa, 0x96,
+	0x19, 0x06, 0x87, 0x1c, 0x41, 0xd1, 0x07, 0x00, 0x9a, 0x33, 0x52, 0x46, 0x9e, 0x3a, 0x20, 0x9e,
+	0xd8, 0xd8, 0xc9, 0x4f, 0x5d, 0xb5, 0x13, 0xbf, 0xcb, 0x65, 0xcd, 0x19, 0x9d, 0x32, 0x24, 0xfa,
+	0x06, 0xd4, 0x5c, 0xa2, 0xea, 0x8a, 0x61, 0x2b, 0xae, 0xea, 0x13, 0x4f, 0x5c, 0x5d, 0xbc, 0xb4,
+	0x42, 0xd1, 0x2d, 0x5b, 0xa6, 0x58, 0xf4, 0x4d, 0xa8, 0xbf, 0x74, 0x0d, 0x9f, 0x4c, 0x56, 0xa3,
+	0xc5, 0xab, 0xab, 0x0c, 0x1e, 0x2e, 0xff, 0x3a, 0x54, 0x6d, 0x47, 0x31, 0x55, 0x9f, 0x58, 0x9a,
+	0x41, 0x3c, 0x71, 0xed, 0x0a, 0xd1, 0xb6, 0x73, 0x1c, 0x62, 0xf1, 0x2b, 0xb8, 0xcd, 0x22, 0xf2,
+	0x46, 0x72, 0x88, 0xa8, 0x48, 0x96, 0xbb, 0x56, 0x91, 0xec, 0x04, 0x36, 0xd2, 0xb2, 0xb3, 0x5c,
+	0x21, 0x7f, 0x12, 0x60, 0xbd, 0xa7, 0xa9, 0xbe, 0x4f, 0xdc, 0xec, 0x95, 0x9c, 0x45, 0xf5, 0x89,
+	0xd8, 0x2b, 0x92, 0xbf, 0x66, 0xae, 0x54, 0x98, 0x9f, 0x2b, 0xe1, 0x63, 0xb8, 0x9d, 0x52, 0x3b,
+	0x63, 0x5d, 0xfb, 0x88, 0xf8, 0x47, 0x07, 0x3d, 0xf5, 0x9c, 0x74, 0x6d, 0xc3, 0xca, 0xe2, 0x50,
+	0x6c, 0xc2, 0x46, 0x9a, 0x59, 0x96, 0xb7, 0x90, 0x5e, 0x0c, 0xea, 0x39, 0x51, 0x1c, 0xca, 0x2a,
+	0xb0, 0x6a, 0xd9, 0x0b, 0x79, 0xe3, 0x21, 0x88, 0xa7, 0x8e, 0xae, 0xfa, 0xe4, 0x66, 0xb4, 0xbf,
+	0x4a, 0xdc, 0x25, 0xdc, 0x99, 0x21, 0x2e, 0xcb, 0xfe, 0xee, 0x43, 0x9d, 0xbe, 0x4a, 0x53, 0x42,
+	0xe9, 0x5b, 0x15, 0x89, 0xc0, 0x7f, 0x10, 0xe0, 0x4d, 0x2e, 0xb8, 0x47, 0xdc, 0x4b, 0x43, 0xbb,
+	0xc9, 0xed, 0x72, 0x86, 0x61, 0xcc, 0x56, 0xe5, 0x72, 0x30, 0xd3, 0xd2, 0xe9, 0x23, 0xe5, 0xfb,
+	0x26, 0x8b, 0xd8, 0xbc, 0x4c, 0x7f, 0xa6, 0xec, 0x53, 0x48, 0xdb, 0xe7, 0x8f, 0x02, 0xec, 0xcc,
+	0xd7, 0x33, 0x6b, 0x1c, 0x7c, 0x21, 0x4d, 0xef, 0x43, 0x7d, 0x68, 0x58, 0xca, 0x94, 0xb6, 0xd5,
+	0xa1, 0x61, 0x4d, 0x0c, 0x4b, 0xd8, 0xe7, 0x5e, 0xc7, 0x21, 0xae, 0xea, 0xdb, 0xee, 0x97, 0x56,
+	0x9d, 0xfc, 0x0b, 0x2f, 0x93, 0x4f, 0xe4, 0x64, 0x31, 0xc5, 0xc2, 0x7b, 0x06, 0x41, 0x41, 0x27,
+	0x9e, 0xc6, 0x2c, 0x51, 0x95, 0xd9, 0x6f, 0x2a, 0x85, 0xde, 0x9e, 0x23, 0x8f, 0x99, 0xa0, 0x9e,
+	0x92, 0x12, 0x2a, 0xd5, 0x63, 0x10, 0x39, 0x80, 0x52, 0x46, 0x2f, 0x0c, 0x4b, 0x67, 0x6f, 0x7c,
+	0x55, 0x66, 0xbf, 0xf1, 0x8f, 0xe0, 0x76, 0xd7, 0x25, 0x8e, 0xca, 0xba, 0x06, 0xf4, 0x71, 0xfe,
+	0x72, 0x1a, 0x35, 0x68, 0x03, 0x4a, 0x9a, 0x6a, 0x69, 0x84, 0x3b, 0x74, 0x59, 0x0e, 0x46, 0xf8,
+	0xa7, 0x02, 0x6c, 0xa4, 0x15, 0xc8, 0x62, 0xc9, 0x7b, 0x50, 0x0d, 0xea, 0x0b, 0x93, 0xd6, 0x66,
+	0x41, 0xae, 0xf0, 0x39, 0x9e, 0x49, 0xac, 0x43, 0x91, 0x3e, 0xbb, 0xe3, 0x40, 0x13, 0x3e, 0x78,
+	0xf4, 0x6b, 0x01, 0xca, 0x51, 0x6b, 0x18, 0x95, 0x20, 0xd7, 0x79, 0xd6, 0xb8, 0x85, 0x2a, 0xb0,
+	0x74, 0xda, 0x7e, 0xd6, 0xee, 0x7c, 0xa7, 0xdd, 0x10, 0xd0, 0x3a, 0x34, 0xda, 0x9d, 0xbe, 0xb2,
+	0xdf, 0xe9, 0xf4, 0x7b, 0x7d, 0x79, 0xaf, 0xdb, 0x6d, 0x1e, 0x36, 0x72, 0x68, 0x0d, 0x56, 0x7a,
+	0xfd, 0x8e, 0xdc, 0x54, 0xfa, 0x9d, 0x93, 0xfd, 0x5e, 0xbf, 0xd3, 0x6e, 0x36, 0xf2, 0x48, 0x84,
+	0xf5, 0xbd, 0x63, 0xb9, 0xb9, 0x77, 0xf8, 0x49, 0x12, 0x5e, 0xa0, 0x94, 0x56, 0xfb, 0xa0, 0x73,
+	0xd2, 0xdd, 0xeb, 0xb7, 0xf6, 0x8f, 0x9b, 0xca, 0xf3, 0xa6, 0xdc, 0x6b, 0x75, 0xda, 0x8d, 0x22,
+	0x65, 0x2f, 0x37, 0x8f, 0x5a, 0x9d, 0xb6, 0x42, 0xa5, 0x7c, 0xd4, 0x39, 0x6d, 0x1f, 0x36, 0x4a,
+	0x8f, 0xba, 0x50, 0x4f, 0xba, 0x93, 0xea, 0xd4, 0x3b, 0x3d, 0x38, 0x68, 0xf6, 0x7a, 0x5c, 0xc1,
+	0x7e, 0xeb, 0xa4, 0xd9, 0x39, 0xed, 0x37, 0x04, 0x04, 0x50, 0x3a, 0xd8, 0x6b, 0x1f, 0x34, 0x8f,
+	0x1b, 0x39, 0x4a, 0x90, 0x9b, 0xdd, 0xe3, 0xbd, 0x03, 0xaa, 0x0e, 0x1d, 0x9c, 0xb6, 0xdb, 0xad,
+	0xf6, 0x51, 0xa3, 0xf0, 0xf4, 0xcf, 0xab, 0x50, 0xee, 0x85, 0x96, 0x44, 0x1d, 0x80, 0x49, 0x75,
+	0x04, 0x6d, 0x27, 0x6c, 0x3c, 0x55, 0x80, 0x91, 0xde, 0x9c, 0x4b, 0xe7, 0xde, 0xc0, 0xb7, 0xd0,
+	0xb7, 0x20, 0xdf, 0xf7, 0x6c, 0x94, 0x7c, 0xf6, 0x27, 0x7d, 0x74, 0x49, 0x9c, 0x26, 0x84, 0x6b,
+	0x1f, 0x0a, 0xef, 0x0a, 0xe8, 0x18, 0xca, 0x51, 0x0f, 0x15, 0x6d, 0x25, 0xc0, 0xe9, 0x0e, 0xb3,
+	0xb4, 0x3d, 0x8f, 0x1c, 0x69, 0xf3, 0x3d, 0xa8, 0x27, 0x7b, 0xb2, 0x08, 0x27, 0xd6, 0xcc, 0xec,
+	0xfe, 0x4a, 0xbb, 0x0b, 0x31, 0x11, 0xf3, 0x8f, 0x60, 0x29, 0xe8, 0x9b, 0xa2, 0x64, 0x70, 0x26,
+	0x7b, 0xb2, 0xd2, 0xdd, 0xd9, 0xc4, 0x88, 0x4f, 0x0b, 0x96, 0xc3, 0x26, 0x26, 0xba, 0x9b, 0xb6,
+	0x70, 0xbc, 0x7d, 0x28, 0x6d, 0xcd, 0xa1, 0xc6, 0x59, 0x75, 0x47, 0x33, 0x59, 0x75, 0x47, 0x8b,
+	0x58, 0xa5, 0x7b, 0x87, 0xf8, 0x16, 0x3a, 0x85, 0x6a, 0xbc, 0x05, 0x87, 0x76, 0xd2, 0xb2, 0xd3,
+	0x2d, 0x42, 0xe9, 0xde, 0x02, 0x44, 0xdc, 0x23, 0xc9, 0x7c, 0x2f, 0xe5, 0x91, 0x99, 0x89, 0xa8,
+	0xb4, 0xbb, 0x10, 0x13, 0x31, 0x3f, 0x83, 0x95, 0x54, 0xd1, 0x05, 0xed, 0xa6, 0xae, 0x8d, 0x59,
+	0xb5, 0x32, 0xe9, 0xfe, 0x62, 0x50, 0x3a, 0x40, 0xa3, 0xc2, 0x3e, 0x9a, 0x72, 0x48, 0x22, 0xe9,
+	0x94, 0xb6, 0xe7, 0x91, 0x23, 0x8d, 0xbb, 0x50, 0x3b, 0x22, 0x7e, 0xd7, 0x25, 0x97, 0x37, 0xc5,
+	0xb1, 0x0f, 0xb5, 0x68, 0x9a, 0x36, 0xe8, 0xd0, 0xbd, 0xd9, 0x4b, 0x62, 0xcd, 0xbb, 0x6b, 0x70,
+	0x95, 0xa1, 0x12, 0xeb, 0x7a, 0xa1, 0xe4, 0x45, 0x30, 0xdd, 0xa6, 0x93, 0x76, 0xe6, 0x03, 0xe2,
+	0xc1, 0x1a, 0x96, 0x57, 0x52, 0xc1, 0x9a, 0xaa, 0xf2, 0x48, 0x5b, 0x73, 0xa8, 0x11, 0x2b, 0x95,
+	0xf5, 0x6e, 0x13, 0x25, 0x72, 0x74, 0x3f, 0xbd, 0xa9, 0x59, 0xb5, 0x7b, 0xe9, 0xad, 0x2b, 0x50,
+	0x71, 0x11, 0xdd, 0xd1, 0x42, 0x11, 0xdd, 0xd1, 0x75, 0x44, 0xcc, 0x2b, 0xe5, 0xe3, 0x5b, 0xe8,
+	0xbb, 0x50, 0x4b, 0x7c, 0x04, 0xa4, 0x5c, 0x37, 0xeb, 0xbb, 0x46, 0xc2, 0x8b, 0x20, 0xf1, 0x53,
+	0x97, 0xcc, 0xe1, 0x53, 0xa7, 0x6e, 0xe6, 0xd7, 0x82, 0xb4, 0xbb, 0x10, 0x13, 0x31, 0xd7, 0x61,
+	0x75, 0x2a, 0x87, 0x46, 0xc9, 0x4d, 0xcf, 0x4b, 0xe9, 0xa5, 0x07, 0x57, 0xc1, 0x22, 0x29, 0x63,
+	0x10, 0xe7, 0x25, 0xa2, 0xe8, 0x9d, 0x19, 0x5c, 0xe6, 0xe6, 0xd5, 0xd2, 0xe3, 0x6b, 0xa2, 0xe3,
+	0xc1, 0x1f, 0xcb, 0xf5, 0xd0, 0xd4, 0x2b, 0x98, 0xca, 0x36, 0xa5, 0x9d, 0xf9, 0x80, 0xb8, 0xd1,
+	0xa6, 0xfa, 0x83, 0xe8, 0xad, 0xd9, 0xe7, 0x30, 0xd5, 0xc8, 0x95, 0x1e, 0x5c, 0x05, 0x8b, 0xa4,
+	0x38, 0x70, 0x7b, 0x8a, 0x4c, 0xbb, 0x90, 0xe8, 0xed, 0xc5, 0x2c, 0x62, 0x3d, 0x52, 0xe9, 0xd1,
+	0x75, 0xa0, 0xf1, 0x48, 0x4b, 0x26, 0x74, 0xa9, 0x48, 0x9b, 0x99, 0x6e, 0x4a, 0xbb, 0x0b, 0x31,
+	0x21, 0xf3, 0xfd, 0xc6, 0x5f, 0x5f, 0x6f, 0x0b, 0x7f, 0x7b, 0xbd, 0x2d, 0xfc, 0xf3, 0xf5, 0xb6,
+	0xf0, 0x9b, 0x7f, 0x6d, 0xdf, 0x3a, 0x2b, 0xb1, 0x7f, 0x21, 0xbe, 0xf7, 0x9f, 0x01, 0x00, 0x3c,
+	0x71, 0x73, 0x37, 0xda, 0x28, 0x00, 0x00,
 }
@@ -52,6 +52,8 @@ type TinyKvClient interface {
 	KvCheckTxnStatus(ctx context.Context, in *kvrpcpb.CheckTxnStatusRequest, opts ...grpc.CallOption) (*kvrpcpb.CheckTxnStatusResponse, error)
 	KvBatchRollback(ctx context.Context, in *kvrpcpb.BatchRollbackRequest, opts ...grpc.CallOption) (*kvrpcpb.BatchRollbackResponse, error)
 	KvResolveLock(ctx context.Context, in *kvrpcpb.ResolveLockRequest, opts ...grpc.CallOption) (*kvrpcpb.ResolveLockResponse, error)
+	UnsafeDestroyRange(ctx context.Context, in *kvrpcpb.UnsafeDestroyRangeRequest, opts ...grpc.CallOption) (*kvrpcpb.UnsafeDestroyRangeResponse, error)
+	EventFeed(ctx context.Context, in *kvrpcpb.ChangeDataRequest, opts ...grpc.CallOption) (TinyKv_EventFeedClient, error)
 	// RawKV commands.
 	RawGet(ctx context.Context, in *kvrpcpb.RawGetRequest, opts ...grpc.CallOption) (*kvrpcpb.RawGetResponse, error)
 	RawPut(ctx context.Context, in *kvrpcpb.RawPutRequest, opts ...grpc.CallOption) (*kvrpcpb.RawPutResponse, error)
@@ -135,6 +137,47 @@ func (c *tinyKvClient) KvResolveLock(ctx context.Context, in *kvrpcpb.ResolveLoc
 	return out, nil
 }
 
+func (c *tinyKvClient) UnsafeDestroyRange(ctx context.Context, in *kvrpcpb.UnsafeDestroyRangeRequest, opts ...grpc.CallOption) (*kvrpcpb.UnsafeDestroyRangeResponse, error) {
+	out := new(kvrpcpb.UnsafeDestroyRangeResponse)
+	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/UnsafeDestroyRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tinyKvClient) EventFeed(ctx context.Context, in *kvrpcpb.ChangeDataRequest, opts ...grpc.CallOption) (TinyKv_EventFeedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[0], "/tinykvpb.TinyKv/EventFeed", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tinyKvEventFeedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TinyKv_EventFeedClient interface {
+	Recv() (*kvrpcpb.ChangeDataEvent, error)
+	grpc.ClientStream
+}
+
+type tinyKvEventFeedClient struct {
+	grpc.ClientStream
+}
+
+func (x *tinyKvEventFeedClient) Recv() (*kvrpcpb.ChangeDataEvent, error) {
+	m := new(kvrpcpb.ChangeDataEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *tinyKvClient) RawGet(ctx context.Context, in *kvrpcpb.RawGetRequest, opts ...grpc.CallOption) (*kvrpcpb.RawGetResponse, error) {
 	out := new(kvrpcpb.RawGetResponse)
 	err := c.cc.Invoke(ctx, "/tinykvpb.TinyKv/RawGet", in, out, opts...)
@@ -172,7 +215,7 @@ func (c *tinyKvClient) RawScan(ctx context.Context, in *kvrpcpb.RawScanRequest,
 }
 
 func (c *tinyKvClient) Raft(ctx context.Context, opts ...grpc.CallOption) (TinyKv_RaftClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[0], "/tinykvpb.TinyKv/Raft", opts...)
+	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[1], "/tinykvpb.TinyKv/Raft", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +249,7 @@ func (x *tinyKvRaftClient) CloseAndRecv() (*raft_serverpb.Done, error) {
 }
 
 func (c *tinyKvClient) Snapshot(ctx context.Context, opts ...grpc.CallOption) (TinyKv_SnapshotClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[1], "/tinykvpb.TinyKv/Snapshot", opts...)
+	stream, err := c.cc.NewStream(ctx, &_TinyKv_serviceDesc.Streams[2], "/tinykvpb.TinyKv/Snapshot", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -259,6 +302,8 @@ type TinyKvServer interface {
 	KvCheckTxnStatus(context.Context, *kvrpcpb.CheckTxnStatusRequest) (*kvrpcpb.CheckTxnStatusResponse, error)
 	KvBatchRollback(context.Context, *kvrpcpb.BatchRollbackRequest) (*kvrpcpb.BatchRollbackResponse, error)
 	KvResolveLock(context.Context, *kvrpcpb.ResolveLockRequest) (*kvrpcpb.ResolveLockResponse, error)
+	UnsafeDestroyRange(context.Context, *kvrpcpb.UnsafeDestroyRangeRequest) (*kvrpcpb.UnsafeDestroyRangeResponse, error)
+	EventFeed(*kvrpcpb.ChangeDataRequest, TinyKv_EventFeedServer) error
 	// RawKV commands.
 	RawGet(context.Context, *kvrpcpb.RawGetRequest) (*kvrpcpb.RawGetResponse, error)
 	RawPut(context.Context, *kvrpcpb.RawPutRequest) (*kvrpcpb.RawPutResponse, error)
@@ -401,6 +446,45 @@ func _TinyKv_KvResolveLock_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TinyKv_UnsafeDestroyRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(kvrpcpb.UnsafeDestroyRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TinyKvServer).UnsafeDestroyRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tinykvpb.TinyKv/UnsafeDestroyRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TinyKvServer).UnsafeDestroyRange(ctx, req.(*kvrpcpb.UnsafeDestroyRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TinyKv_EventFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(kvrpcpb.ChangeDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TinyKvServer).EventFeed(m, &tinyKvEventFeedServer{stream})
+}
+
+type TinyKv_EventFeedServer interface {
+	Send(*kvrpcpb.ChangeDataEvent) error
+	grpc.ServerStream
+}
+
+type tinyKvEventFeedServer struct {
+	grpc.ServerStream
+}
+
+func (x *tinyKvEventFeedServer) Send(m *kvrpcpb.ChangeDataEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _TinyKv_RawGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(kvrpcpb.RawGetRequest)
 	if err := dec(in); err != nil {
@@ -575,6 +659,10 @@ var _TinyKv_serviceDesc = grpc.ServiceDesc{
 			MethodName: "KvResolveLock",
 			Handler:    _TinyKv_KvResolveLock_Handler,
 		},
+		{
+			MethodName: "UnsafeDestroyRange",
+			Handler:    _TinyKv_UnsafeDestroyRange_Handler,
+		},
 		{
 			MethodName: "RawGet",
 			Handler:    _TinyKv_RawGet_Handler,
@@ -597,6 +685,11 @@ var _TinyKv_serviceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "EventFeed",
+			Handler:       _TinyKv_EventFeed_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Raft",
 			Handler:       _TinyKv_Raft_Handler,
@@ -611,37 +704,40 @@ var _TinyKv_serviceDesc = grpc.ServiceDesc{
 	Metadata: "tinykvpb.proto",
 }
 
-func init() { proto.RegisterFile("tinykvpb.proto", fileDescriptor_tinykvpb_71a6ae942ac295c5) }
-
-var fileDescriptor_tinykvpb_71a6ae942ac295c5 = []byte{
-	// 452 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xdb, 0x6e, 0xd3, 0x30,
-	0x18, 0xc7, 0x5b, 0x09, 0xba, 0x62, 0x34, 0x18, 0x6e, 0x81, 0x2d, 0x8c, 0x20, 0xed, 0x8a, 0xab,
-	0x22, 0x01, 0x12, 0x17, 0x1c, 0x24, 0x96, 0x4a, 0xbb, 0xf0, 0x90, 0x2a, 0x77, 0x5c, 0x23, 0xd7,
-	0xfa, 0xd6, 0x56, 0xe9, 0xec, 0x60, 0x3b, 0x2e, 0x7d, 0x13, 0x9e, 0x83, 0xa7, 0xe0, 0x92, 0x47,
-	0x40, 0xe5, 0x45, 0x50, 0x92, 0xd9, 0x39, 0x34, 0xbd, 0xb3, 0x7f, 0xff, 0x83, 0x5b, 0xc7, 0x1f,
-	0x7a, 0x60, 0x96, 0x62, 0x13, 0xdb, 0x64, 0x36, 0x4a, 0x94, 0x34, 0x12, 0xf7, 0xdd, 0x3e, 0x38,
-	0x8c, 0xad, 0x4a, 0xb8, 0x13, 0x82, 0x81, 0x62, 0xd7, 0xe6, 0x9b, 0x06, 0x65, 0x41, 0x79, 0xf8,
-	0x88, 0xcb, 0x44, 0x49, 0x0e, 0x5a, 0x4b, 0x75, 0x8b, 0x86, 0x73, 0x39, 0x97, 0xf9, 0xf2, 0x55,
-	0xb6, 0x2a, 0xe8, 0xeb, 0x5f, 0x07, 0xa8, 0x77, 0xb5, 0x14, 0x1b, 0x62, 0xf1, 0x5b, 0x74, 0x97,
-	0xd8, 0x0b, 0x30, 0x78, 0x30, 0x72, 0x27, 0x5c, 0x80, 0xa1, 0xf0, 0x3d, 0x05, 0x6d, 0x82, 0x61,
-	0x1d, 0xea, 0x44, 0x0a, 0x0d, 0x67, 0x1d, 0xfc, 0x0e, 0xf5, 0x88, 0x9d, 0x72, 0x26, 0x70, 0xe9,
-	0xc8, 0xb6, 0x2e, 0xf7, 0xb8, 0x41, 0x7d, 0x30, 0x42, 0x88, 0xd8, 0x89, 0x82, 0xb5, 0x5a, 0x1a,
-	0xc0, 0xc7, 0xde, 0xe6, 0x90, 0x2b, 0x38, 0x69, 0x51, 0x7c, 0xc9, 0x47, 0xd4, 0x27, 0x36, 0x92,
-	0x37, 0x37, 0x4b, 0x83, 0x9f, 0x78, 0x63, 0x01, 0x5c, 0xc1, 0xd3, 0x1d, 0xee, 0xe3, 0x5f, 0xd1,
-	0x11, 0xb1, 0xd1, 0x02, 0x78, 0x7c, 0xf5, 0x43, 0x4c, 0x0d, 0x33, 0xa9, 0xc6, 0x61, 0x69, 0xaf,
-	0x09, 0xae, 0xee, 0xc5, 0x5e, 0xdd, 0xd7, 0x52, 0xf4, 0x90, 0xd8, 0x73, 0x66, 0xf8, 0x82, 0xca,
-	0xd5, 0x6a, 0xc6, 0x78, 0x8c, 0x9f, 0xfb, 0x54, 0x8d, 0xbb, 0xd2, 0x70, 0x9f, 0xec, 0x3b, 0x2f,
-	0xd1, 0x21, 0xb1, 0x14, 0xb4, 0x5c, 0x59, 0xb8, 0x94, 0x3c, 0xc6, 0xcf, 0x7c, 0xa4, 0x42, 0x5d,
-	0xdf, 0x69, 0xbb, 0xe8, 0xdb, 0xde, 0xa3, 0x1e, 0x65, 0xeb, 0xec, 0x63, 0x97, 0xb7, 0x56, 0x80,
-	0xdd, 0x5b, 0x73, 0xbc, 0x11, 0x9e, 0xa4, 0x8d, 0xf0, 0x24, 0x6d, 0x0f, 0xe7, 0xdc, 0x87, 0xc7,
-	0xe8, 0x1e, 0x65, 0xeb, 0x31, 0xac, 0xc0, 0x00, 0x3e, 0xa9, 0xfa, 0x0a, 0xe6, 0x2a, 0x82, 0x36,
-	0xc9, 0xb7, 0x7c, 0x42, 0x07, 0x94, 0xad, 0xf3, 0x67, 0x57, 0x3b, 0xab, 0xfa, 0xf2, 0x8e, 0x77,
-	0x85, 0xca, 0x5f, 0xb8, 0x43, 0xd9, 0xb5, 0xc1, 0xc1, 0xa8, 0x3e, 0x3d, 0x19, 0xfc, 0x02, 0x5a,
-	0xb3, 0x39, 0x04, 0x83, 0x86, 0x36, 0x96, 0x02, 0xce, 0x3a, 0x2f, 0xbb, 0xf8, 0x33, 0xea, 0x4f,
-	0x05, 0x4b, 0xf4, 0x42, 0x1a, 0x7c, 0xda, 0x30, 0x39, 0x21, 0x5a, 0xa4, 0x22, 0xde, 0x5f, 0xf1,
-	0x01, 0xdd, 0x8f, 0xca, 0x09, 0xc5, 0xc3, 0x51, 0x75, 0x5e, 0xcb, 0xd1, 0xa9, 0x53, 0xf7, 0xeb,
-	0xcf, 0x8f, 0x7e, 0x6f, 0xc3, 0xee, 0x9f, 0x6d, 0xd8, 0xfd, 0xbb, 0x0d, 0xbb, 0x3f, 0xff, 0x85,
-	0x9d, 0x59, 0x2f, 0x9f, 0xe6, 0x37, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xc2, 0x6c, 0xc6, 0xd2,
-	0x36, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("tinykvpb.proto", fileDescriptor_tinykvpb_fc4e67ee09e85741) }
+
+var fileDescriptor_tinykvpb_fc4e67ee09e85741 = []byte{
+	// 505 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x94, 0x4d, 0x6f, 0xd3, 0x30,
+	0x18, 0xc7, 0x1b, 0x09, 0x4a, 0x67, 0x34, 0x18, 0x4f, 0x0b, 0x74, 0x61, 0x04, 0xa9, 0x5c, 0x38,
+	0x15, 0x04, 0x48, 0x1c, 0x78, 0x91, 0x58, 0x32, 0x7a, 0xc8, 0x90, 0xaa, 0x74, 0x3b, 0x4f, 0x6e,
+	0x78, 0xfa, 0xa2, 0x74, 0x76, 0xb0, 0x1d, 0x97, 0x7e, 0x13, 0x3e, 0x12, 0x47, 0xce, 0x9c, 0x50,
+	0xf9, 0x22, 0xa8, 0x0d, 0x76, 0x93, 0x36, 0xe5, 0x16, 0xff, 0xfe, 0x2f, 0x76, 0x6b, 0x3d, 0x26,
+	0x77, 0xd4, 0x94, 0x2d, 0x12, 0x9d, 0x0e, 0xbb, 0xa9, 0xe0, 0x8a, 0x43, 0xc3, 0xac, 0xdd, 0xc3,
+	0x44, 0x8b, 0x34, 0x36, 0x82, 0xdb, 0x14, 0x74, 0xa4, 0xae, 0x24, 0x0a, 0x8d, 0xc2, 0xc2, 0x7b,
+	0x31, 0x4f, 0x05, 0x8f, 0x51, 0x4a, 0x2e, 0xfe, 0xa1, 0xd6, 0x98, 0x8f, 0xf9, 0xfa, 0xf3, 0xf9,
+	0xea, 0x2b, 0xa7, 0x2f, 0x7f, 0x35, 0x48, 0xfd, 0x62, 0xca, 0x16, 0xa1, 0x86, 0xd7, 0xe4, 0x66,
+	0xa8, 0x7b, 0xa8, 0xa0, 0xd9, 0x35, 0x3b, 0xf4, 0x50, 0x45, 0xf8, 0x35, 0x43, 0xa9, 0xdc, 0x56,
+	0x19, 0xca, 0x94, 0x33, 0x89, 0x9d, 0x1a, 0xbc, 0x21, 0xf5, 0x50, 0x0f, 0x62, 0xca, 0x60, 0xe3,
+	0x58, 0x2d, 0x4d, 0xee, 0xfe, 0x16, 0xb5, 0x41, 0x9f, 0x90, 0x50, 0xf7, 0x05, 0xce, 0xc5, 0x54,
+	0x21, 0xb4, 0xad, 0xcd, 0x20, 0x53, 0x70, 0x5c, 0xa1, 0xd8, 0x92, 0xf7, 0xa4, 0x11, 0x6a, 0x9f,
+	0x5f, 0x5f, 0x4f, 0x15, 0x3c, 0xb0, 0xc6, 0x1c, 0x98, 0x82, 0x87, 0x3b, 0xdc, 0xc6, 0x2f, 0xc9,
+	0x51, 0xa8, 0xfd, 0x09, 0xc6, 0xc9, 0xc5, 0x37, 0x36, 0x50, 0x54, 0x65, 0x12, 0xbc, 0x8d, 0xbd,
+	0x24, 0x98, 0xba, 0x27, 0x7b, 0x75, 0x5b, 0x1b, 0x91, 0xbb, 0xa1, 0x3e, 0xa5, 0x2a, 0x9e, 0x44,
+	0x7c, 0x36, 0x1b, 0xd2, 0x38, 0x81, 0xc7, 0x36, 0x55, 0xe2, 0xa6, 0xd4, 0xdb, 0x27, 0xdb, 0xce,
+	0x73, 0x72, 0x18, 0xea, 0x08, 0x25, 0x9f, 0x69, 0x3c, 0xe7, 0x71, 0x02, 0x8f, 0x6c, 0xa4, 0x40,
+	0x4d, 0xdf, 0x49, 0xb5, 0x68, 0xdb, 0xae, 0x08, 0x5c, 0x32, 0x49, 0x47, 0x18, 0xa0, 0x54, 0x82,
+	0x2f, 0x22, 0xca, 0xc6, 0x08, 0x1d, 0x9b, 0xda, 0x15, 0x4d, 0xf3, 0xd3, 0xff, 0x7a, 0xec, 0x06,
+	0x67, 0xe4, 0xe0, 0x4c, 0x23, 0x53, 0x9f, 0x10, 0xbf, 0x80, 0x5b, 0xf8, 0xcb, 0x56, 0xbe, 0x80,
+	0x2a, 0x6a, 0xfa, 0xda, 0x15, 0xda, 0x3a, 0xd9, 0xa9, 0xbd, 0x70, 0xe0, 0x2d, 0xa9, 0x47, 0x74,
+	0xde, 0xc3, 0xe2, 0xed, 0xe6, 0x60, 0xf7, 0x76, 0x0d, 0xb7, 0x67, 0xc8, 0xc3, 0xfd, 0x6c, 0x2b,
+	0xdc, 0xcf, 0xaa, 0xc3, 0xfd, 0xac, 0x18, 0x0e, 0xc8, 0x41, 0x44, 0xe7, 0x01, 0xce, 0x50, 0x21,
+	0x1c, 0x17, 0x7d, 0x39, 0x33, 0x15, 0x6e, 0x95, 0x64, 0x5b, 0x3e, 0x90, 0x5b, 0x11, 0x9d, 0xaf,
+	0xc7, 0xa3, 0xb4, 0x57, 0x71, 0x42, 0xda, 0xbb, 0x42, 0xe1, 0x27, 0xdc, 0x88, 0xe8, 0x48, 0x81,
+	0xdb, 0x2d, 0x4f, 0xf9, 0x0a, 0x7e, 0x46, 0x29, 0xe9, 0x18, 0xdd, 0xe6, 0x96, 0x16, 0x70, 0x86,
+	0x9d, 0xda, 0x33, 0x07, 0x3e, 0x92, 0xc6, 0x80, 0xd1, 0x54, 0x4e, 0xb8, 0x82, 0x93, 0x2d, 0x93,
+	0x11, 0xfc, 0x49, 0xc6, 0x92, 0xfd, 0x15, 0xef, 0xc8, 0x6d, 0x7f, 0xf3, 0x92, 0x40, 0xab, 0x5b,
+	0x7c, 0x57, 0x36, 0x23, 0x5e, 0xa6, 0xe6, 0xf4, 0xa7, 0x47, 0x3f, 0x96, 0x9e, 0xf3, 0x73, 0xe9,
+	0x39, 0xbf, 0x97, 0x9e, 0xf3, 0xfd, 0x8f, 0x57, 0x1b, 0xd6, 0xd7, 0xaf, 0xce, 0xab, 0xbf, 0x03,
+	0x00, 0x0f, 0xdf, 0xd9, 0x79, 0xde, 0x04, 0x00, 0x00,
 }
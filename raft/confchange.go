@@ -0,0 +1,73 @@
+// Copyright 2019 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"errors"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+// ErrJointConfChange is returned when proposing a ConfChangeV2 which needs a
+// joint configuration, joint consensus is not supported yet.
+var ErrJointConfChange = errors.New("raft: joint configuration changes are not supported")
+
+// ConfChangeToV2 converts a ConfChange to the equivalent ConfChangeV2. A
+// ConfChange cancelled by setting its NodeId to zero yields an empty one.
+func ConfChangeToV2(cc pb.ConfChange) pb.ConfChangeV2 {
+	ccv2 := pb.ConfChangeV2{Context: cc.Context}
+	if cc.NodeId != None {
+		ccv2.Changes = []*pb.ConfChangeSingle{{ChangeType: cc.ChangeType, NodeId: cc.NodeId}}
+	}
+	return ccv2
+}
+
+// ConfChangeFromEntry decodes the configuration change carried by an entry
+// of type EntryConfChange or EntryConfChangeV2.
+func ConfChangeFromEntry(ent pb.Entry) (pb.ConfChangeV2, error) {
+	switch ent.EntryType {
+	case pb.EntryType_EntryConfChange:
+		var cc pb.ConfChange
+		if err := cc.Unmarshal(ent.Data); err != nil {
+			return pb.ConfChangeV2{}, err
+		}
+		return ConfChangeToV2(cc), nil
+	case pb.EntryType_EntryConfChangeV2:
+		var cc pb.ConfChangeV2
+		if err := cc.Unmarshal(ent.Data); err != nil {
+			return pb.ConfChangeV2{}, err
+		}
+		return cc, nil
+	}
+	return pb.ConfChangeV2{}, errors.New("raft: entry is not a configuration change")
+}
+
+// isSimpleConfChange reports whether cc can be applied directly, without
+// going through a joint configuration.
+func isSimpleConfChange(cc pb.ConfChangeV2) bool {
+	return cc.Transition == pb.ConfChangeTransition_ConfChangeTransitionAuto && len(cc.Changes) <= 1
+}
+
+// applyConfChangeSingle applies one change to the raft group.
+func (r *Raft) applyConfChangeSingle(cc *pb.ConfChangeSingle) {
+	switch cc.ChangeType {
+	case pb.ConfChangeType_AddNode:
+		r.addNode(cc.NodeId)
+	case pb.ConfChangeType_RemoveNode:
+		r.removeNode(cc.NodeId)
+	default:
+		panic("unexpected conf type")
+	}
+}
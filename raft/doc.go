@@ -81,11 +81,14 @@ your main raft loop.
 
 3. Apply Snapshot (if any) and CommittedEntries to the state machine.
 If any committed Entry has Type EntryType_EntryConfChange, call Node.ApplyConfChange()
-to apply it to the node. The configuration change may be cancelled at this point
+to apply it to the node, entries of Type EntryType_EntryConfChangeV2 are applied
+with Node.ApplyConfChangeV2(). The configuration change may be cancelled at this point
 by setting the NodeId field to zero before calling ApplyConfChange
 (but ApplyConfChange must be called one way or the other, and the decision to cancel
 must be based solely on the state machine and not external information such as
 the observed health of the node).
+Entries with Type EntryType_EntryNoOp are appended by a new leader, carry no data
+and only need to advance the applied index.
 
 4. Call Node.Advance() to signal readiness for the next batch of updates.
 This may be done at any time after step 1, although all updates must be processed
@@ -120,11 +123,13 @@ The total state machine handling loop will look something like this:
         processSnapshot(rd.Snapshot)
       }
       for _, entry := range rd.CommittedEntries {
-        process(entry)
-        if entry.Type == eraftpb.EntryType_EntryConfChange {
-          var cc eraftpb.ConfChange
-          cc.Unmarshal(entry.Data)
-          s.Node.ApplyConfChange(cc)
+        switch entry.EntryType {
+        case eraftpb.EntryType_EntryNoOp:
+        case eraftpb.EntryType_EntryConfChange, eraftpb.EntryType_EntryConfChangeV2:
+          cc, _ := raft.ConfChangeFromEntry(entry)
+          s.Node.ApplyConfChangeV2(cc)
+        default:
+          process(entry)
         }
       }
       s.Node.Advance()
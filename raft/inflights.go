@@ -0,0 +1,138 @@
+// Copyright 2019 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+// inflights limits the number of MsgAppend sent to a follower but not yet
+// acknowledged. Each inflight message is tracked by the last index of its
+// entries, and freed once the follower acknowledges that index. A nil
+// inflights has no limit.
+type inflights struct {
+	// the starting index in the buffer
+	start int
+	// number of inflights in the buffer
+	count int
+
+	// the size of the buffer
+	size int
+
+	// buffer contains the index of the last entry
+	// inside one message.
+	buffer []uint64
+}
+
+func newInflights(size int) *inflights {
+	return &inflights{size: size}
+}
+
+// clone returns a copy of the inflights that doesn't share the buffer.
+func (in *inflights) clone() *inflights {
+	if in == nil {
+		return nil
+	}
+	ins := *in
+	ins.buffer = append([]uint64(nil), in.buffer...)
+	return &ins
+}
+
+// add notifies the inflights that a new message with the given index is being
+// dispatched. full() must be called prior to add() to verify that there is
+// room for one more message, and consecutive calls to add() must provide a
+// monotonic sequence of indexes.
+func (in *inflights) add(inflight uint64) {
+	if in == nil {
+		return
+	}
+	if in.full() {
+		panic("cannot add into a full inflights")
+	}
+	next := in.start + in.count
+	size := in.size
+	if next >= size {
+		next -= size
+	}
+	if next >= len(in.buffer) {
+		in.grow()
+	}
+	in.buffer[next] = inflight
+	in.count++
+}
+
+// grow the inflight buffer by doubling up to inflights.size. We grow on demand
+// instead of preallocating to inflights.size to handle systems which have
+// thousands of Raft groups per process.
+func (in *inflights) grow() {
+	newSize := len(in.buffer) * 2
+	if newSize == 0 {
+		newSize = 1
+	} else if newSize > in.size {
+		newSize = in.size
+	}
+	newBuffer := make([]uint64, newSize)
+	copy(newBuffer, in.buffer)
+	in.buffer = newBuffer
+}
+
+// freeTo frees the inflights smaller or equal to the given `to` flight.
+func (in *inflights) freeTo(to uint64) {
+	if in == nil || in.count == 0 || to < in.buffer[in.start] {
+		// out of the left side of the window
+		return
+	}
+
+	idx := in.start
+	var i int
+	for i = 0; i < in.count; i++ {
+		if to < in.buffer[idx] { // found the first large inflight
+			break
+		}
+
+		// increase index and maybe rotate
+		size := in.size
+		if idx++; idx >= size {
+			idx -= size
+		}
+	}
+	// free i inflights and set new start index
+	in.count -= i
+	in.start = idx
+	if in.count == 0 {
+		// inflights is empty, reset the start index so that we don't grow the
+		// buffer unnecessarily.
+		in.start = 0
+	}
+}
+
+// freeFirstOne releases the first inflight. This is a no-op if nothing is
+// inflight.
+func (in *inflights) freeFirstOne() {
+	if in == nil || in.count == 0 {
+		return
+	}
+	in.freeTo(in.buffer[in.start])
+}
+
+// full returns true if no more messages can be sent at the moment.
+func (in *inflights) full() bool {
+	return in != nil && in.count == in.size
+}
+
+// reset frees all inflights.
+func (in *inflights) reset() {
+	if in == nil {
+		return
+	}
+	in.count = 0
+	in.start = 0
+}
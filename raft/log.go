@@ -15,6 +15,9 @@
 package raft
 
 import (
+	"fmt"
+	"sort"
+
 	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 )
 
@@ -24,8 +27,9 @@ import (
 //  --------|------------------------------------------------|
 //                            log entries
 //
-// for simplify the RaftLog implement should manage all log entries
-// that not truncated
+// Only the entries from offset on are kept in memory: the unstable entries
+// and a bounded cache of the latest stable ones. The stable entries before
+// offset are read back from storage when they are needed.
 type RaftLog struct {
 	// storage contains all stable entries since the last snapshot.
 	storage Storage
@@ -39,13 +43,37 @@ type RaftLog struct {
 	// Invariant: applied <= committed
 	applied uint64
 
+	// applying is the highest log position handed out to the application
+	// by a Ready, which it may not have applied yet.
+	// Invariant: applied <= applying <= committed
+	applying uint64
+
+	// maxNextEntsSize limits the size in bytes of the entries returned by
+	// nextEnts, 0 means no limit.
+	maxNextEntsSize uint64
+
+	// maxCacheSize limits the size in bytes of the stable and applied
+	// entries kept in memory, 0 means they are all dropped.
+	maxCacheSize uint64
+
 	// log entries with index <= stabled are persisted to storage.
 	// It is used to record the logs that are not persisted by storage yet.
 	// Everytime handling `Ready`, the unstabled logs will be included.
 	stabled uint64
 
-	// all entries that have not yet compact.
+	// persisting is the highest log position handed out for persistence by a
+	// Ready with Config.AsyncStorageWrites, which may not be durable yet.
+	// Invariant: stabled <= persisting <= last
+	persisting uint64
+
+	// persistBeforeApply holds committed entries back from nextEnts until
+	// they are persisted locally, set with Config.AsyncStorageWrites.
+	persistBeforeApply bool
+
+	// the entries from offset on, the unstable ones and the cached stable
+	// ones. Invariant: first <= offset <= stabled+1
 	entries []pb.Entry
+	offset  uint64
 
 	// the incoming unstable snapshot, if any.
 	// (Used in 2C)
@@ -57,20 +85,19 @@ type RaftLog struct {
 
 // newLog returns log using the given storage. It recovers the log
 // to the state that it just commits and applies the latest snapshot.
+// No entry is loaded, they are read from storage once needed.
 func newLog(storage Storage) *RaftLog {
 	// Your Code Here (2A).
 	lo, _ := storage.FirstIndex()
 	hi, _ := storage.LastIndex()
-	entries, err := storage.Entries(lo, hi+1)
-	if err != nil {
-		panic(err)
-	}
 	return &RaftLog{
-		storage: storage,
-		applied: lo - 1,
-		stabled: hi,
-		entries: entries,
-		first:   lo,
+		storage:    storage,
+		applied:    lo - 1,
+		applying:   lo - 1,
+		stabled:    hi,
+		persisting: hi,
+		offset:     hi + 1,
+		first:      lo,
 	}
 }
 
@@ -81,31 +108,206 @@ func (l *RaftLog) maybeCompact() {
 	// Your Code Here (2C).
 	idx, _ := l.storage.FirstIndex()
 	if idx > l.first {
-		if len(l.entries) > 0 {
-			l.entries = append([]pb.Entry{}, l.entries[l.toSliceIndex(idx):]...)
-		}
 		l.first = idx
+		if idx > l.offset {
+			l.dropBefore(idx)
+		}
+	}
+	l.shrinkCache()
+}
+
+// compactTo discards the entries up to i from the storage and the memory,
+// the first entry of the log is i+1 then.
+func (l *RaftLog) compactTo(i uint64) error {
+	if i < l.first {
+		return ErrCompacted
+	}
+	if i > l.applied || i > l.stabled {
+		return ErrCompactNotApplied
+	}
+	if s, ok := l.storage.(CompactableStorage); ok {
+		if err := s.Compact(i); err != nil {
+			return err
+		}
+	}
+	l.first = i + 1
+	if i >= l.offset {
+		l.dropBefore(i + 1)
+	}
+	return nil
+}
+
+// shrinkCache drops the in-memory entries which are persisted and applied,
+// keeping the latest of them as long as they fit in maxCacheSize.
+func (l *RaftLog) shrinkCache() {
+	hi := min(l.stabled, l.applied)
+	if hi < l.offset {
+		return
+	}
+	n := min(hi-l.offset+1, uint64(len(l.entries)))
+	var size uint64
+	for ; n > 0; n-- {
+		size += uint64(l.entries[n-1].Size())
+		if size > l.maxCacheSize {
+			break
+		}
+	}
+	if n > 0 {
+		l.dropBefore(l.offset + n)
+	}
+}
+
+// dropBefore discards the in-memory entries before index i. The entries are
+// only re-sliced, so compaction costs O(compacted entries) rather than a copy
+// of the whole log; the dropped slots are cleared so their payloads can be
+// collected, and the stale head of the backing array is released the next
+// time append has to grow it.
+func (l *RaftLog) dropBefore(i uint64) {
+	n := uint64(len(l.entries))
+	if off := i - l.offset; off < n {
+		n = off
 	}
+	for j := uint64(0); j < n; j++ {
+		l.entries[j] = pb.Entry{}
+	}
+	l.entries = l.entries[n:]
+	if len(l.entries) == 0 {
+		l.entries = nil
+	}
+	l.offset = i
+}
+
+// truncateAndAppend replaces the entries from ents[0].Index on with ents.
+// The conflicting suffix is overwritten in place without copying the prefix,
+// a conflict with the stable entries not in memory drops the cache. It fails
+// if ents would be appended before the first entry or after a gap.
+func (l *RaftLog) truncateAndAppend(ents []pb.Entry) error {
+	if len(ents) == 0 {
+		return nil
+	}
+	if i := ents[0].Index; i >= l.first && i < l.offset {
+		l.dropBefore(l.offset + uint64(len(l.entries)))
+		l.entries = append(l.entries, ents...)
+		l.offset = i
+		return nil
+	}
+	idx, err := l.toSliceIndex(ents[0].Index)
+	if err != nil {
+		return err
+	}
+	l.entries = append(l.entries[:idx], ents...)
+	return nil
+}
+
+// Slice returns the entries in [lo, hi), at most maxSize bytes of them but
+// at least one if there is any, 0 means no limit. The entries before offset
+// are read from storage. It returns ErrCompacted if lo is before the first
+// entry and ErrUnavailable if hi is past the entry following the last one.
+func (l *RaftLog) Slice(lo, hi, maxSize uint64) ([]pb.Entry, error) {
+	if lo > hi {
+		return nil, fmt.Errorf("raft: invalid slice [%d, %d)", lo, hi)
+	}
+	if lo < l.first {
+		return nil, ErrCompacted
+	}
+	if hi > l.LastIndex()+1 {
+		return nil, ErrUnavailable
+	}
+	if lo == hi {
+		return nil, nil
+	}
+	var ents []pb.Entry
+	if lo < l.offset {
+		stored, err := l.storage.Entries(lo, min(hi, l.offset))
+		if err != nil {
+			return nil, err
+		}
+		for i := range stored {
+			if err := CheckEntry(&stored[i]); err != nil {
+				return nil, err
+			}
+		}
+		if limited := limitSize(stored, maxSize); hi <= l.offset || len(limited) < len(stored) {
+			return limited, nil
+		}
+		// Don't append to the array of the storage.
+		ents = append(make([]pb.Entry, 0, hi-lo), stored...)
+		lo = l.offset
+	}
+	mem := l.entries[lo-l.offset : hi-l.offset]
+	if ents == nil {
+		return limitSize(mem, maxSize), nil
+	}
+	return limitSize(append(ents, mem...), maxSize), nil
+}
+
+// allEntries returns all the entries of the log.
+func (l *RaftLog) allEntries() ([]pb.Entry, error) {
+	return l.Slice(l.first, l.LastIndex()+1, 0)
 }
 
 // unstableEntries return all the unstable entries
 func (l *RaftLog) unstableEntries() []pb.Entry {
 	// Your Code Here (2A).
 	if len(l.entries) > 0 {
-		return l.entries[l.stabled-l.first+1:]
+		return l.entries[l.stabled-l.offset+1:]
 	}
 	return nil
 }
 
-// nextEnts returns all the committed but not applied entries
-func (l *RaftLog) nextEnts() (ents []pb.Entry) {
-	// Your Code Here (2A).
+// unpersistedEntries returns the unstable entries not handed out for
+// persistence yet.
+func (l *RaftLog) unpersistedEntries() []pb.Entry {
 	if len(l.entries) > 0 {
-		return l.entries[l.applied-l.first+1 : l.committed-l.first+1]
+		return l.entries[max(l.stabled, l.persisting)-l.offset+1:]
 	}
 	return nil
 }
 
+// nextEnts returns the committed entries not handed out to the application
+// yet, at most maxNextEntsSize bytes of them.
+func (l *RaftLog) nextEnts() ([]pb.Entry, error) {
+	// Your Code Here (2A).
+	lo, hi := l.nextEntsRange()
+	if lo >= hi {
+		return nil, nil
+	}
+	return l.Slice(lo, hi, l.maxNextEntsSize)
+}
+
+// hasNextEnts returns whether nextEnts has entries, without reading them.
+func (l *RaftLog) hasNextEnts() bool {
+	lo, hi := l.nextEntsRange()
+	return lo < hi
+}
+
+// nextEntsRange returns the range [lo, hi) of the entries nextEnts returns
+// without the size limit.
+func (l *RaftLog) nextEntsRange() (lo, hi uint64) {
+	lo, hi = max(l.applied, l.applying)+1, l.committed+1
+	if l.persistBeforeApply {
+		hi = min(hi, l.stabled+1)
+	}
+	return max(lo, l.first), hi
+}
+
+// appliedTo records that the application applied the entries up to i.
+func (l *RaftLog) appliedTo(i uint64) {
+	l.applied = i
+	if l.applying < i {
+		l.applying = i
+	}
+}
+
+// snapshot returns the incoming snapshot if it is not persisted yet, and the
+// snapshot of the storage otherwise.
+func (l *RaftLog) snapshot() (pb.Snapshot, error) {
+	if !IsEmptySnap(l.pendingSnapshot) {
+		return *l.pendingSnapshot, nil
+	}
+	return l.storage.Snapshot()
+}
+
 // LastIndex return the last index of the log entries
 func (l *RaftLog) LastIndex() uint64 {
 	// Your Code Here (2A).
@@ -113,18 +315,14 @@ func (l *RaftLog) LastIndex() uint64 {
 	if !IsEmptySnap(l.pendingSnapshot) {
 		index = l.pendingSnapshot.Metadata.Index
 	}
-	if len(l.entries) > 0 {
-		return max(l.entries[len(l.entries)-1].Index, index)
-	}
-	i, _ := l.storage.LastIndex()
-	return max(i, index)
+	return max(l.offset+uint64(len(l.entries))-1, index)
 }
 
 // Term return the term of the entry in the given index
 func (l *RaftLog) Term(i uint64) (uint64, error) {
 	// Your Code Here (2A).
-	if len(l.entries) > 0 && i >= l.first {
-		return l.entries[i-l.first].Term, nil
+	if i >= l.offset && i-l.offset < uint64(len(l.entries)) {
+		return l.entries[i-l.offset].Term, nil
 	}
 	term, err := l.storage.Term(i)
 	if err == ErrUnavailable && !IsEmptySnap(l.pendingSnapshot) {
@@ -142,16 +340,27 @@ func (l *RaftLog) FirstIndex() uint64 {
 	return l.first
 }
 
-func (l *RaftLog) toEntryIndex(si int) uint64 {
-	return uint64(si) + l.first
+// toSliceIndex returns the position of the entry ei in the entries, which is
+// len(entries) for the entry following the last one. It returns ErrCompacted
+// for an entry before offset and ErrUnavailable past the last one.
+func (l *RaftLog) toSliceIndex(ei uint64) (int, error) {
+	if ei < l.offset {
+		return 0, ErrCompacted
+	}
+	if ei-l.offset > uint64(len(l.entries)) {
+		return 0, ErrUnavailable
+	}
+	return int(ei - l.offset), nil
 }
 
-func (l *RaftLog) toSliceIndex(ei uint64) int {
-	idx := int(ei - l.first)
-	if idx < 0 {
-		panic("slice index cannot < 0")
-	}
-	return idx
+// searchTerm returns the smallest index in [lo, hi) whose term satisfies f,
+// hi if there is none. f must be false then true over the range, as terms
+// only grow along the log.
+func (l *RaftLog) searchTerm(lo, hi uint64, f func(term uint64) bool) uint64 {
+	return lo + uint64(sort.Search(int(hi-lo), func(i int) bool {
+		term, _ := l.Term(lo + uint64(i))
+		return f(term)
+	}))
 }
 
 func (l *RaftLog) isUpToDate(index, term uint64) bool {
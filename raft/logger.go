@@ -0,0 +1,48 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import "github.com/pingcap-incubator/tinykv/log"
+
+// Logger receives the events of a raft peer: state transitions, votes,
+// rejected appends and snapshots sent. Every message starts with the id of
+// the peer, so the events of several peers can share a logger.
+type Logger interface {
+	Debugf(format string, v ...interface{})
+	Infof(format string, v ...interface{})
+	Warningf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+}
+
+// DefaultLogger writes to the global logger of the log package, so it obeys
+// its level.
+var DefaultLogger Logger = defaultLogger{}
+
+// DiscardLogger drops every event.
+var DiscardLogger Logger = discardLogger{}
+
+type defaultLogger struct{}
+
+func (defaultLogger) Debugf(format string, v ...interface{})   { log.Debugf(format, v...) }
+func (defaultLogger) Infof(format string, v ...interface{})    { log.Infof(format, v...) }
+func (defaultLogger) Warningf(format string, v ...interface{}) { log.Warningf(format, v...) }
+func (defaultLogger) Errorf(format string, v ...interface{})   { log.Errorf(format, v...) }
+
+type discardLogger struct{}
+
+func (discardLogger) Debugf(format string, v ...interface{})   {}
+func (discardLogger) Infof(format string, v ...interface{})    {}
+func (discardLogger) Warningf(format string, v ...interface{}) {}
+func (discardLogger) Errorf(format string, v ...interface{})   {}
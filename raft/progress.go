@@ -0,0 +1,188 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import "fmt"
+
+// ProgressStateType is the state of a follower in the view of the leader.
+type ProgressStateType uint64
+
+const (
+	// ProgressStateProbe is the state of a follower whose log the leader is
+	// unsure of. The leader sends at most one append per heartbeat interval
+	// until it learns where the logs match.
+	ProgressStateProbe ProgressStateType = iota
+	// ProgressStateReplicate is the state of a healthy follower. The leader
+	// sends it every entry it lacks at once, limited by the inflight window.
+	ProgressStateReplicate
+	// ProgressStateSnapshot is the state of a follower being sent a snapshot.
+	// Nothing else is sent to it until it acknowledges the snapshot.
+	ProgressStateSnapshot
+)
+
+var prstmap = [...]string{
+	"ProgressStateProbe",
+	"ProgressStateReplicate",
+	"ProgressStateSnapshot",
+}
+
+func (st ProgressStateType) String() string { return prstmap[uint64(st)] }
+
+// Progress represents a follower’s progress in the view of the leader. Leader maintains
+// progresses of all followers, and sends entries to the follower based on its progress.
+type Progress struct {
+	Match, Next uint64
+	// State decides how entries are sent to the follower, see
+	// ProgressStateType.
+	State ProgressStateType
+	// Paused is used in ProgressStateProbe. While it is set, the leader waits
+	// for the follower to answer the last append before sending another one.
+	Paused bool
+	// PendingSnapshot is the index of the snapshot in flight to the peer, or 0
+	// if there is none. No entries are sent to the peer until it acknowledges
+	// the snapshot, as they could only be rejected.
+	PendingSnapshot uint64
+	// Rebuild is set when the peer is asked to drop its state, the next
+	// message it is sent is a snapshot it must install.
+	Rebuild bool
+	// NeedSnapshot is set when the last snapshot sent to the peer failed, the
+	// next message it is sent is a new snapshot.
+	NeedSnapshot bool
+	// RecentActive is set when the peer answered the leader since the last
+	// check of Config.CheckQuorum.
+	RecentActive bool
+
+	// pendingRebuild is whether the snapshot in flight rebuilds the peer.
+	pendingRebuild bool
+	// probeBackoff is the number of heartbeats the leader waited before it
+	// resent the unanswered probe last, 0 if the peer answered the last probe.
+	probeBackoff int
+	// probeWait is the number of heartbeats left to wait before resending the
+	// unanswered probe.
+	probeWait int
+	// sentCommit is the commit index carried by the last append sent to the
+	// peer in ProgressStateReplicate.
+	sentCommit uint64
+
+	// ins tracks the append messages in flight to the peer.
+	ins *inflights
+}
+
+func (pr *Progress) resetState(state ProgressStateType) {
+	pr.Paused = false
+	pr.PendingSnapshot = 0
+	pr.pendingRebuild = false
+	pr.sentCommit = 0
+	pr.State = state
+	pr.ins.reset()
+}
+
+func (pr *Progress) becomeProbe() {
+	// If the original state is ProgressStateSnapshot, progress knows that
+	// the pending snapshot has been sent to this peer successfully, then
+	// probes from pendingSnapshot + 1.
+	if pr.State == ProgressStateSnapshot {
+		pendingSnapshot := pr.PendingSnapshot
+		pr.resetState(ProgressStateProbe)
+		pr.Next = max(pr.Match+1, pendingSnapshot+1)
+	} else {
+		pr.resetState(ProgressStateProbe)
+		pr.Next = pr.Match + 1
+	}
+}
+
+func (pr *Progress) becomeReplicate() {
+	pr.resetState(ProgressStateReplicate)
+	pr.Next = pr.Match + 1
+}
+
+func (pr *Progress) becomeSnapshot(snapshoti uint64) {
+	pr.resetState(ProgressStateSnapshot)
+	pr.PendingSnapshot = snapshoti
+	pr.Next = snapshoti + 1
+}
+
+// maybeUpdate returns false if the given n index comes from an outdated message.
+// Otherwise it updates the progress and returns true.
+func (pr *Progress) maybeUpdate(n uint64) bool {
+	var updated bool
+	if pr.Match < n {
+		pr.Match = n
+		updated = true
+		pr.Paused = false
+		pr.probeBackoff, pr.probeWait = 0, 0
+	}
+	if pr.Next < n+1 {
+		pr.Next = n + 1
+	}
+	return updated
+}
+
+// maybeDecrTo moves Next back to hint after the follower rejected an append.
+// It returns false if the rejection is stale, i.e. it answers an append sent
+// before the follower acknowledged a later one.
+func (pr *Progress) maybeDecrTo(hint uint64) bool {
+	if hint <= pr.Match {
+		return false
+	}
+	pr.Next = hint
+	pr.Paused = false
+	pr.probeBackoff, pr.probeWait = 0, 0
+	return true
+}
+
+// backoffProbe is called when the peer answers a heartbeat while its last
+// probe is unanswered. It unpauses the peer so the probe is resent, unless
+// the wait since the last resend isn't over yet. The wait doubles on every
+// resend, up to limit heartbeats.
+func (pr *Progress) backoffProbe(limit int) {
+	if pr.probeWait > 0 {
+		pr.probeWait--
+		return
+	}
+	pr.Paused = false
+	if limit == 0 {
+		return
+	}
+	pr.probeBackoff *= 2
+	if pr.probeBackoff == 0 {
+		pr.probeBackoff = 1
+	}
+	if pr.probeBackoff > limit {
+		pr.probeBackoff = limit
+	}
+	pr.probeWait = pr.probeBackoff
+}
+
+// isPaused returns whether sending log entries to this node has been
+// paused. A node may be paused because it has rejected recent
+// MsgApps, is currently waiting for a snapshot, or has reached the
+// MaxInflightMsgs limit.
+func (pr *Progress) isPaused() bool {
+	switch pr.State {
+	case ProgressStateProbe:
+		return pr.Paused
+	case ProgressStateReplicate:
+		return pr.ins.full()
+	case ProgressStateSnapshot:
+		return true
+	default:
+		panic("unexpected state")
+	}
+}
+
+func (pr *Progress) String() string {
+	return fmt.Sprintf("next = %d, match = %d, state = %s, waiting = %v, pendingSnapshot = %d", pr.Next, pr.Match, pr.State, pr.isPaused(), pr.PendingSnapshot)
+}
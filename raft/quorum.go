@@ -0,0 +1,142 @@
+// Copyright 2019 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import "sort"
+
+// VoteResult is the outcome of a vote, see Quorum.
+type VoteResult uint8
+
+const (
+	// VotePending means the vote is not decided yet.
+	VotePending VoteResult = 1 + iota
+	// VoteLost means the vote can't be won anymore.
+	VoteLost
+	// VoteWon means a quorum granted the vote.
+	VoteWon
+)
+
+// VoteState is the vote of a voter, see Quorum.
+type VoteState uint8
+
+const (
+	// VoteMissing means the voter hasn't voted yet.
+	VoteMissing VoteState = iota
+	// VoteGranted means the voter granted the vote.
+	VoteGranted
+	// VoteRejected means the voter rejected the vote.
+	VoteRejected
+)
+
+// Quorum decides which sets of voters form a quorum. It is used for
+// elections, for the commit index and for read index requests. Any two
+// quorums must intersect, otherwise two leaders could be elected in the same
+// term or a committed entry could be lost.
+type Quorum interface {
+	// VoteResult returns the outcome of a vote among voters, given the votes
+	// received so far, votes[i] being the vote of voters[i].
+	VoteResult(voters []uint64, votes []VoteState) VoteResult
+	// CommittedIndex returns the largest index acknowledged by a quorum of
+	// voters, matches[i] being the index acknowledged by voters[i]. It may
+	// reorder matches.
+	CommittedIndex(voters []uint64, matches []uint64) uint64
+}
+
+// MajorityQuorum is the default Quorum: more than half of the voters.
+type MajorityQuorum struct{}
+
+func (MajorityQuorum) VoteResult(voters []uint64, votes []VoteState) VoteResult {
+	granted, rejected := 0, 0
+	for i := range voters {
+		switch votes[i] {
+		case VoteGranted:
+			granted++
+		case VoteRejected:
+			rejected++
+		}
+	}
+	quorum := len(voters) / 2
+	switch {
+	case granted > quorum:
+		return VoteWon
+	case rejected > quorum:
+		return VoteLost
+	default:
+		return VotePending
+	}
+}
+
+func (MajorityQuorum) CommittedIndex(voters []uint64, matches []uint64) uint64 {
+	if len(matches) == 0 {
+		return 0
+	}
+	sort.Sort(uint64Slice(matches))
+	return matches[(len(matches)-1)/2]
+}
+
+// WeightedQuorum is a quorum of voters holding more than half of the total
+// weight. A voter without a weight has a weight of 1.
+type WeightedQuorum struct {
+	Weights map[uint64]int
+}
+
+func (q WeightedQuorum) weight(id uint64) int {
+	if w, ok := q.Weights[id]; ok {
+		return w
+	}
+	return 1
+}
+
+func (q WeightedQuorum) VoteResult(voters []uint64, votes []VoteState) VoteResult {
+	total, granted, rejected := 0, 0, 0
+	for i, id := range voters {
+		w := q.weight(id)
+		total += w
+		switch votes[i] {
+		case VoteGranted:
+			granted += w
+		case VoteRejected:
+			rejected += w
+		}
+	}
+	switch {
+	case 2*granted > total:
+		return VoteWon
+	case 2*rejected >= total:
+		return VoteLost
+	default:
+		return VotePending
+	}
+}
+
+func (q WeightedQuorum) CommittedIndex(voters []uint64, matches []uint64) uint64 {
+	total := 0
+	order := make([]int, len(voters))
+	for i, id := range voters {
+		total += q.weight(id)
+		order[i] = i
+	}
+	// The largest index acknowledged by voters holding more than half of
+	// the weight.
+	sort.Slice(order, func(a, b int) bool { return matches[order[a]] > matches[order[b]] })
+	acked := 0
+	for _, i := range order {
+		acked += q.weight(voters[i])
+		if 2*acked > total {
+			return matches[i]
+		}
+	}
+	return 0
+}
@@ -16,6 +16,7 @@ package raft
 
 import (
 	"errors"
+	"fmt"
 	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 	"math/rand"
 	"sort"
@@ -31,22 +32,54 @@ const (
 	StateFollower StateType = iota
 	StateCandidate
 	StateLeader
+	StatePreCandidate
 )
 
 var stmap = [...]string{
 	"StateFollower",
 	"StateCandidate",
 	"StateLeader",
+	"StatePreCandidate",
 }
 
 func (st StateType) String() string {
 	return stmap[uint64(st)]
 }
 
+// CampaignType is the kind of an election, it decides how the election is run
+// and whether the voters may ignore it.
+type CampaignType string
+
+const (
+	// campaignPreElection runs a pre-vote round first, see Config.PreVote.
+	campaignPreElection CampaignType = "CampaignPreElection"
+	// campaignElection runs a normal election.
+	campaignElection CampaignType = "CampaignElection"
+	// campaignTransfer runs an election asked for by the leader transferring
+	// its leadership. It skips the pre-vote round and voters don't ignore it
+	// even if they heard from the leader recently.
+	campaignTransfer CampaignType = "CampaignTransfer"
+)
+
 // ErrProposalDropped is returned when the proposal is ignored by some cases,
 // so that the proposer can be notified and fail fast.
 var ErrProposalDropped = errors.New("raft proposal dropped")
 
+// ErrProposalTooLarge is returned when a proposal is dropped because one of
+// its entries is larger than Config.MaxEntrySize. It wraps ErrProposalDropped.
+var ErrProposalTooLarge = fmt.Errorf("%w: entry too large", ErrProposalDropped)
+
+// ErrProposalDuplicate is returned when every entry of a proposal is dropped
+// as the retry of an uncommitted entry, see Config.DedupProposals. Nothing is
+// appended for the proposal. It wraps ErrProposalDropped.
+var ErrProposalDuplicate = fmt.Errorf("%w: duplicate request", ErrProposalDropped)
+
+// ErrInvalidMessage is returned by Step for a message of a peer which doesn't
+// fit the log, e.g. an append whose entries don't follow its index, or a
+// response acknowledging entries the leader doesn't have. The message is
+// dropped, so a faulty peer can't corrupt the log or crash the node.
+var ErrInvalidMessage = errors.New("raft: invalid message")
+
 // Config contains the parameters to start a raft.
 type Config struct {
 	// ID is the identity of the local raft. ID cannot be 0.
@@ -80,6 +113,128 @@ type Config struct {
 	// Applied. If Applied is unset when restarting, raft might return previous
 	// applied entries. This is a very application dependent configuration.
 	Applied uint64
+
+	// OptimisticReplication lets the leader send MsgAppend to its followers
+	// before its own copy of the entries is persisted, overlapping the local
+	// fsync with the network round trip. The leader's own progress is then only
+	// advanced once the application reports the entries stable via Advance,
+	// so the leader never counts itself towards a quorum for entries it may
+	// still lose in a crash.
+	OptimisticReplication bool
+
+	// AsyncStorageWrites lets the application persist the Entries and the
+	// HardState of a Ready in the background while it keeps handling the next
+	// Readies, and report them durable with RawNode.AckPersisted. Advance then
+	// no longer implies that the Ready was persisted: messages which must not
+	// be sent before the writes are durable are held until acknowledged,
+	// committed entries are only handed out once persisted locally, and the
+	// leader replicates optimistically as with OptimisticReplication.
+	AsyncStorageWrites bool
+
+	// PreVote makes a node whose election timeout elapsed run a pre-vote
+	// round first, asking whether it could win an election without bumping
+	// any term. It only becomes a candidate once a quorum agrees, so a node
+	// partitioned away cannot disrupt the group with an inflated term when it
+	// rejoins.
+	PreVote bool
+
+	// MaxSizePerMsg limits the size in bytes of the entries of a single
+	// append message. A follower far behind is caught up with several bounded
+	// messages instead of one huge message. Each message carries at least one
+	// entry, 0 means no limit.
+	MaxSizePerMsg uint64
+	// MaxInflightMsgs limits the number of append messages a leader sends to
+	// a follower without an acknowledgement, so a slow follower can't make
+	// the messages pile up on the leader. 0 means no limit.
+	MaxInflightMsgs int
+	// MaxProbeBackoff limits the number of heartbeats a leader waits before
+	// resending an unanswered probe to a follower. The first probe is resent
+	// on the next heartbeat the follower answers, the wait then doubles on
+	// each resend until the follower answers the probe, so a follower which
+	// answers heartbeats but loses appends isn't sent the same append every
+	// heartbeat. 0 resends on every heartbeat.
+	MaxProbeBackoff int
+	// MaxCommittedSizePerReady limits the size in bytes of the committed
+	// entries of a single Ready, so a large backlog of committed entries is
+	// applied in several bounded batches. Each Ready carries at least one
+	// committed entry when there is any, 0 means no limit.
+	MaxCommittedSizePerReady uint64
+	// MaxCachedEntriesSize limits the size in bytes of the persisted and
+	// applied entries kept in memory, the latest ones are cached so the
+	// followers just behind are caught up without reading the storage. The
+	// older entries are read back from Storage when needed, 0 means none is
+	// cached.
+	MaxCachedEntriesSize uint64
+	// MaxEntrySize limits the size in bytes of a proposed entry. The leader
+	// drops a proposal with a larger entry with ErrProposalTooLarge, as a
+	// huge entry would stall the replication of the group. 0 means no limit.
+	MaxEntrySize uint64
+	// MaxUncommittedEntriesSize limits the size in bytes of the data of the
+	// entries the leader appended and didn't commit yet. The leader drops
+	// the proposals with ErrProposalDropped past the limit, so a leader
+	// which lost its quorum doesn't pile up entries in memory. A proposal is
+	// always accepted when nothing is uncommitted, 0 means no limit.
+	MaxUncommittedEntriesSize uint64
+
+	// EntryChecksums makes the leader compute a checksum of every entry it
+	// appends. The checksums are verified whenever present, when entries are
+	// read back from Storage or received in MsgAppend, so corruption of the
+	// disk or the network surfaces as ErrCorruptEntry instead of reaching
+	// the state machine.
+	EntryChecksums bool
+
+	// DedupProposals makes the leader drop a proposed entry whose RequestID
+	// is the one of an entry it appended in its term and didn't commit yet,
+	// so a client retrying a proposal while the leader stalls doesn't grow
+	// the log. A proposal whose entries are all dropped fails with
+	// ErrProposalDuplicate, as the entries it proposes are in the log
+	// already at other indexes.
+	DedupProposals bool
+
+	// BatchProposals makes the leader append the proposals it receives
+	// without sending them, the entries appended since the last Ready are
+	// sent to the followers together when the next Ready is built. Under a
+	// high write load this sends one MsgAppend per follower for many
+	// proposals instead of one per proposal.
+	BatchProposals bool
+
+	// Quorum decides which sets of voters elect a leader, commit an entry and
+	// confirm a read index. It must be the same on every node of the group,
+	// nil means MajorityQuorum.
+	Quorum Quorum
+
+	// LeaderStickiness makes a peer which heard from the leader within the
+	// election timeout ignore the vote requests of higher terms, so a peer
+	// which missed the heartbeats, e.g. partitioned away, can't depose a
+	// healthy leader. The elections of a leader transfer are not ignored.
+	LeaderStickiness bool
+	// CheckQuorum makes the leader step down when it didn't hear from a
+	// quorum within an election timeout, so a leader partitioned away stops
+	// serving. As the leader of a healthy group is then known to be alive,
+	// it implies LeaderStickiness.
+	CheckQuorum bool
+
+	// ForwardProposals makes a follower forward the proposals it receives to
+	// the leader, so clients don't need to find the leader first. Without a
+	// known leader, or without this option, proposals to a follower are
+	// dropped with ErrProposalDropped.
+	ForwardProposals bool
+
+	// Logger receives the events of the peer, DefaultLogger if it is nil.
+	Logger Logger
+	// Tracer sees the messages and state transitions of the peer, if set.
+	Tracer Tracer
+
+	// RandSource randomizes the election timeouts of the peer instead of the
+	// global source of math/rand, so a simulation seeding it can be replayed
+	// deterministically. It is only used by the peer, which doesn't lock it.
+	RandSource rand.Source
+
+	// ValidateSnapshot checks the snapshots received before they are
+	// restored, if set. A follower discards a snapshot failing it, e.g. one
+	// whose data the application can't read, and reports the failure to the
+	// leader, which sends a new snapshot.
+	ValidateSnapshot func(*pb.Snapshot) error
 }
 
 func (c *Config) validate() error {
@@ -99,13 +254,15 @@ func (c *Config) validate() error {
 		return errors.New("storage cannot be nil")
 	}
 
-	return nil
-}
+	if c.MaxInflightMsgs < 0 {
+		return errors.New("max inflight messages must not be negative")
+	}
+
+	if c.MaxProbeBackoff < 0 {
+		return errors.New("max probe backoff must not be negative")
+	}
 
-// Progress represents a follower’s progress in the view of the leader. Leader maintains
-// progresses of all followers, and sends entries to the follower based on its progress.
-type Progress struct {
-	Match, Next uint64
+	return nil
 }
 
 type Raft struct {
@@ -119,12 +276,26 @@ type Raft struct {
 
 	// log replication progress of each peers
 	Prs map[uint64]*Progress
+	// peerIDs holds the keys of Prs in ascending order, so that broadcasts
+	// and tallies iterate the peers deterministically.
+	peerIDs []uint64
+	// quorum is copied from Config.Quorum.
+	quorum Quorum
+	// selfQuorum is true when the local node is a quorum by itself, like the
+	// only node of a group. It is updated with peerIDs.
+	selfQuorum bool
+	// matchBuf is the scratch buffer leaderCommit sorts match indexes in.
+	matchBuf []uint64
+	// voteBuf is the scratch buffer the tallies other than the election, e.g.
+	// of the active peers, are collected in.
+	voteBuf []VoteState
 
 	// this peer's role
 	State StateType
 
-	// votes records
-	votes map[uint64]bool
+	// votes records the votes of the current election, votes[i] being the
+	// vote of peerIDs[i].
+	votes []VoteState
 
 	// msgs need to send
 	msgs []pb.Message
@@ -159,10 +330,81 @@ type Raft struct {
 	// (Used in 3A conf change)
 	PendingConfIndex uint64
 
+	// removed is true once a conf change removed the local node from the
+	// group, the application should destroy it then.
+	removed bool
+
 	// randomizedElectionTimeout is a random number between
 	// [electiontimeout, 2 * electiontimeout - 1]. It gets reset
 	// when raft changes its state to follower or candidate.
 	randomizedElectionTimeout int
+
+	// optimisticReplication is copied from Config.OptimisticReplication.
+	optimisticReplication bool
+
+	// preVote is copied from Config.PreVote.
+	preVote bool
+	// leaderStickiness is copied from Config.LeaderStickiness.
+	leaderStickiness bool
+	// checkQuorum is copied from Config.CheckQuorum.
+	checkQuorum bool
+	// forwardProposals is copied from Config.ForwardProposals.
+	forwardProposals bool
+
+	// maxMsgSize is copied from Config.MaxSizePerMsg.
+	maxMsgSize uint64
+	// maxEntrySize is copied from Config.MaxEntrySize.
+	maxEntrySize uint64
+	// maxUncommittedSize is copied from Config.MaxUncommittedEntriesSize.
+	maxUncommittedSize uint64
+	// uncommittedSize is the size of the data of the entries the leader
+	// appended in its term and didn't commit yet.
+	uncommittedSize uint64
+
+	// entryChecksums is copied from Config.EntryChecksums.
+	entryChecksums bool
+
+	// dedupProposals is copied from Config.DedupProposals.
+	dedupProposals bool
+	// pendingRequests maps the request IDs of the uncommitted entries the
+	// leader appended in its term to their index, see dedupProposals.
+	pendingRequests map[string]uint64
+
+	// batchProposals is copied from Config.BatchProposals.
+	batchProposals bool
+	// appendsPending is set when entries were appended with batchProposals
+	// and not sent yet, see flushAppends.
+	appendsPending bool
+	// maxInflight is copied from Config.MaxInflightMsgs.
+	maxInflight int
+	// maxProbeBackoff is copied from Config.MaxProbeBackoff.
+	maxProbeBackoff int
+
+	// readOnly holds the read index requests waiting for a heartbeat round to
+	// confirm the leadership.
+	readOnly *readOnly
+	// readStates are the read indexes ready to be handed to the application.
+	readStates []ReadState
+	// pendingReadIndexMessages are the read index requests received before
+	// the leader committed an entry of its term, when its commit index may
+	// still be behind the one of the previous leader.
+	pendingReadIndexMessages []pb.Message
+	// forwardedReads are the read index requests forwarded to the leader and
+	// not answered yet.
+	forwardedReads *forwardedReads
+
+	logger Logger
+	// tracer is copied from Config.Tracer.
+	tracer Tracer
+	// rand randomizes the election timeouts with Config.RandSource, nil
+	// means the global source.
+	rand *rand.Rand
+	// validateSnapshot is copied from Config.ValidateSnapshot.
+	validateSnapshot func(*pb.Snapshot) error
+	// storageErr is the first error met reading the log outside of the
+	// handling of a message, e.g. while sending an append, see fail. The
+	// next Step returns it.
+	storageErr error
 }
 
 // newRaft return a raft peer with the given config
@@ -175,10 +417,41 @@ func newRaft(c *Config) *Raft {
 		id:               c.ID,
 		RaftLog:          newLog(c.Storage),
 		Prs:              make(map[uint64]*Progress),
-		votes:            make(map[uint64]bool),
 		electionTimeout:  c.ElectionTick,
 		heartbeatTimeout: c.HeartbeatTick,
+
+		optimisticReplication: c.OptimisticReplication || c.AsyncStorageWrites,
+		preVote:               c.PreVote,
+		leaderStickiness:      c.LeaderStickiness || c.CheckQuorum,
+		checkQuorum:           c.CheckQuorum,
+		forwardProposals:      c.ForwardProposals,
+		maxMsgSize:            c.MaxSizePerMsg,
+		maxEntrySize:          c.MaxEntrySize,
+		maxUncommittedSize:    c.MaxUncommittedEntriesSize,
+		entryChecksums:        c.EntryChecksums,
+		dedupProposals:        c.DedupProposals,
+		batchProposals:        c.BatchProposals,
+		quorum:                c.Quorum,
+		maxInflight:           c.MaxInflightMsgs,
+		maxProbeBackoff:       c.MaxProbeBackoff,
+		readOnly:              newReadOnly(),
+		forwardedReads:        newForwardedReads(),
+		logger:                c.Logger,
+		tracer:                c.Tracer,
+		validateSnapshot:      c.ValidateSnapshot,
+	}
+	if r.logger == nil {
+		r.logger = DefaultLogger
 	}
+	if c.RandSource != nil {
+		r.rand = rand.New(c.RandSource)
+	}
+	if r.quorum == nil {
+		r.quorum = MajorityQuorum{}
+	}
+	r.RaftLog.maxNextEntsSize = c.MaxCommittedSizePerReady
+	r.RaftLog.maxCacheSize = c.MaxCachedEntriesSize
+	r.RaftLog.persistBeforeApply = c.AsyncStorageWrites
 
 	hardSt, confSt, _ := c.Storage.InitialState()
 
@@ -188,49 +461,158 @@ func newRaft(c *Config) *Raft {
 	for _, peer := range c.peers {
 		r.Prs[peer] = &Progress{}
 	}
+	r.updatePeerIDs()
 
 	r.becomeFollower(0, None)
 	r.resetRandomizedElectionTimeout()
 	r.Term, r.Vote, r.RaftLog.committed = hardSt.Term, hardSt.Vote, hardSt.Commit
 	if c.Applied > 0 {
-		r.RaftLog.applied = c.Applied
+		r.RaftLog.appliedTo(c.Applied)
 	}
 	return r
 }
 
+// send queues m for the next Ready.
+func (r *Raft) send(m pb.Message) {
+	if r.tracer != nil {
+		r.tracer.OnSend(m)
+	}
+	r.msgs = append(r.msgs, m)
+}
+
+// setState changes the state of the peer to state, its term must be set
+// first.
+func (r *Raft) setState(state StateType) {
+	from := r.State
+	r.State = state
+	if r.tracer != nil {
+		r.tracer.OnStateChange(from, state, r.Term)
+	}
+}
+
 // sendAppend sends an append RPC with new entries (if any) and the
 // current commit index to the given peer. Returns true if a message was sent.
 func (r *Raft) sendAppend(to uint64) bool {
 	// Your Code Here (2A).
-	prevLogIndex := r.Prs[to].Next - 1
+	return r.maybeSendAppend(to, false)
+}
+
+// maybeSendAppend is sendAppend, but an empty append to a peer in
+// ProgressStateReplicate which was sent the whole log and the current commit
+// index already is only sent if sendIfEmpty is true. It would carry nothing
+// new, the answers to the appends in flight move the replication on.
+func (r *Raft) maybeSendAppend(to uint64, sendIfEmpty bool) bool {
+	pr := r.Prs[to]
+	if pr.isPaused() {
+		return false
+	}
+	if pr.Rebuild || pr.NeedSnapshot {
+		return r.sendSnapshot(to)
+	}
+	prevLogIndex := pr.Next - 1
 	prevLogTerm, err := r.RaftLog.Term(prevLogIndex)
 	if err != nil {
 		if err == ErrCompacted {
-			// TODO: send snapshot
-			return false
+			return r.sendSnapshot(to)
 		}
-		panic(err)
+		r.fail(err)
+		return false
 	}
 
-	var ents []*pb.Entry
-	start := r.RaftLog.toSliceIndex(prevLogIndex + 1)
-	n := len(r.RaftLog.entries)
-	for i := start; i < n; i++ {
-		ents = append(ents, &r.RaftLog.entries[i])
+	lastIndex := r.RaftLog.LastIndex()
+	if pr.State == ProgressStateReplicate && pr.Next > lastIndex && pr.sentCommit == r.RaftLog.committed && !sendIfEmpty {
+		return false
 	}
+	for {
+		batch, err := r.RaftLog.Slice(prevLogIndex+1, lastIndex+1, r.maxMsgSize)
+		if err != nil {
+			if err == ErrCompacted {
+				return r.sendSnapshot(to)
+			}
+			r.fail(err)
+			return false
+		}
+		// The message may be read after dropBefore cleared the slots of
+		// the in-memory entries, it gets its own copy of them.
+		batch = append([]pb.Entry(nil), batch...)
+		ents := make([]*pb.Entry, 0, len(batch))
+		for i := range batch {
+			ents = append(ents, &batch[i])
+		}
+		msg := pb.Message{
+			MsgType: pb.MessageType_MsgAppend,
+			To:      to,
+			From:    r.id,
+			Term:    r.Term,
+			LogTerm: prevLogTerm,
+			Index:   prevLogIndex,
+			Entries: ents,
+			Commit:  r.RaftLog.committed,
+		}
+		r.send(msg)
+		pr.sentCommit = msg.Commit
+		if len(batch) == 0 {
+			return true
+		}
+		last := batch[len(batch)-1]
+		switch pr.State {
+		case ProgressStateReplicate:
+			// Stream the next entries after the ones in flight, a rejection
+			// moves Next back.
+			pr.Next = last.Index + 1
+			pr.ins.add(last.Index)
+		case ProgressStateProbe:
+			// Wait for the answer before sending anything else.
+			pr.Paused = true
+			return true
+		}
 
-	msg := pb.Message{
-		MsgType: pb.MessageType_MsgAppend,
-		To:      to,
-		From:    r.id,
-		Term:    r.Term,
-		LogTerm: prevLogTerm,
-		Index:   prevLogIndex,
-		Entries: ents,
-		Commit:  r.RaftLog.committed,
+		if last.Index == lastIndex || pr.ins.full() {
+			return true
+		}
+		prevLogIndex, prevLogTerm = last.Index, last.Term
+	}
+}
+
+// newInflights returns the inflight window of a follower, nil if the number
+// of inflight messages is not limited.
+func (r *Raft) newInflights() *inflights {
+	if r.maxInflight == 0 {
+		return nil
 	}
-	r.msgs = append(r.msgs, msg)
+	return newInflights(r.maxInflight)
+}
 
+// sendSnapshot sends the latest snapshot to a peer whose next entries
+// are compacted. It returns false if the storage is still generating the
+// snapshot, it is sent again on the next attempt to replicate to the peer.
+func (r *Raft) sendSnapshot(to uint64) bool {
+	snapshot, err := r.RaftLog.snapshot()
+	if err != nil {
+		if err == ErrSnapshotTemporarilyUnavailable {
+			return false
+		}
+		r.fail(err)
+		return false
+	}
+	if IsEmptySnap(&snapshot) {
+		panic("need non-empty snapshot")
+	}
+	pr := r.Prs[to]
+	r.send(pb.Message{
+		MsgType:  pb.MessageType_MsgSnapshot,
+		To:       to,
+		From:     r.id,
+		Term:     r.Term,
+		Snapshot: &snapshot,
+		Rebuild:  pr.Rebuild,
+	})
+	rebuild := pr.Rebuild
+	pr.Rebuild, pr.NeedSnapshot = false, false
+	pr.becomeSnapshot(snapshot.Metadata.Index)
+	pr.pendingRebuild = rebuild
+	r.logger.Infof("%x [firstindex: %d, commit: %d] sent snapshot[index: %d, term: %d] to %x [%s]",
+		r.id, r.RaftLog.FirstIndex(), r.RaftLog.committed, snapshot.Metadata.Index, snapshot.Metadata.Term, to, pr)
 	return true
 }
 
@@ -244,33 +626,42 @@ func (r *Raft) sendAppendResponse(to, logTerm, index uint64, reject bool) {
 		Index:   index,
 		Reject:  reject,
 	}
-	r.msgs = append(r.msgs, msg)
+	r.send(msg)
 }
 
 // sendHeartbeat sends a heartbeat RPC to the given peer.
-func (r *Raft) sendHeartbeat(to uint64) {
+func (r *Raft) sendHeartbeat(to uint64, ctx []byte) {
 	// Your Code Here (2A).
+	// The follower may not have the entries up to the commit index yet, it
+	// only commits the entries it is known to match.
+	var commit uint64
+	if pr := r.Prs[to]; pr != nil {
+		commit = min(pr.Match, r.RaftLog.committed)
+	}
 	msg := pb.Message{
 		MsgType: pb.MessageType_MsgHeartbeat,
 		To:      to,
 		From:    r.id,
 		Term:    r.Term,
+		Commit:  commit,
+		Context: ctx,
 	}
-	r.msgs = append(r.msgs, msg)
+	r.send(msg)
 }
 
-func (r *Raft) sendHeartbeatResponse(to uint64, reject bool) {
+func (r *Raft) sendHeartbeatResponse(to uint64, reject bool, ctx []byte) {
 	msg := pb.Message{
 		MsgType: pb.MessageType_MsgHeartbeatResponse,
 		To:      to,
 		From:    r.id,
 		Term:    r.Term,
 		Reject:  reject,
+		Context: ctx,
 	}
-	r.msgs = append(r.msgs, msg)
+	r.send(msg)
 }
 
-func (r *Raft) sendRequestVote(to, index, term uint64) {
+func (r *Raft) sendRequestVote(to, index, term uint64, t CampaignType) {
 	msg := pb.Message{
 		MsgType: pb.MessageType_MsgRequestVote,
 		To:      to,
@@ -279,7 +670,10 @@ func (r *Raft) sendRequestVote(to, index, term uint64) {
 		LogTerm: term,
 		Index:   index,
 	}
-	r.msgs = append(r.msgs, msg)
+	if t == campaignTransfer {
+		msg.Context = []byte(t)
+	}
+	r.send(msg)
 }
 
 func (r *Raft) sendRequestVoteResponse(to uint64, reject bool) {
@@ -290,35 +684,177 @@ func (r *Raft) sendRequestVoteResponse(to uint64, reject bool) {
 		Term:    r.Term,
 		Reject:  reject,
 	}
-	r.msgs = append(r.msgs, msg)
+	r.send(msg)
+}
+
+func (r *Raft) sendRequestPreVote(to, index, term uint64) {
+	r.send(pb.Message{
+		MsgType: pb.MessageType_MsgRequestPreVote,
+		To:      to,
+		From:    r.id,
+		Term:    r.Term + 1,
+		LogTerm: term,
+		Index:   index,
+	})
+}
+
+// sendRequestPreVoteResponse answers a pre-vote. A granted pre-vote carries
+// the term the candidate asked for, as the local term is left unchanged.
+func (r *Raft) sendRequestPreVoteResponse(to, term uint64, reject bool) {
+	r.send(pb.Message{
+		MsgType: pb.MessageType_MsgRequestPreVoteResponse,
+		To:      to,
+		From:    r.id,
+		Term:    term,
+		Reject:  reject,
+	})
 }
 
+// bcastHeartbeat sends heartbeats carrying the context of the last pending
+// read index request, so the requests still complete if an earlier round was
+// lost.
 func (r *Raft) bcastHeartbeat() {
-	for peer := range r.Prs {
+	r.bcastHeartbeatWithCtx(r.readOnly.lastPendingRequestCtx())
+}
+
+func (r *Raft) bcastHeartbeatWithCtx(ctx []byte) {
+	for _, peer := range r.peerIDs {
 		if r.id != peer {
-			r.sendHeartbeat(peer)
+			r.sendHeartbeat(peer, ctx)
 		}
 	}
 }
 
 func (r *Raft) bcastAppend() {
-	for peer := range r.Prs {
+	for _, peer := range r.peerIDs {
 		if r.id != peer {
 			r.sendAppend(peer)
 		}
 	}
 }
 
+// flushAppends sends the entries appended with batchProposals since the last
+// flush to the followers which don't have them yet.
+func (r *Raft) flushAppends() {
+	if !r.appendsPending {
+		return
+	}
+	r.appendsPending = false
+	if r.State != StateLeader {
+		return
+	}
+	lastIndex := r.RaftLog.LastIndex()
+	for _, peer := range r.peerIDs {
+		if peer != r.id && r.Prs[peer].Next <= lastIndex {
+			r.sendAppend(peer)
+		}
+	}
+}
+
+// updatePeerIDs rebuilds peerIDs after the membership in Prs changed, and
+// realigns votes with them.
+func (r *Raft) updatePeerIDs() {
+	oldIDs, oldVotes := append([]uint64(nil), r.peerIDs...), r.votes
+	r.peerIDs = r.peerIDs[:0]
+	for id := range r.Prs {
+		r.peerIDs = append(r.peerIDs, id)
+	}
+	sort.Sort(uint64Slice(r.peerIDs))
+	r.votes = make([]VoteState, len(r.peerIDs))
+	for i, id := range oldIDs {
+		if j := r.peerIndex(id); j >= 0 {
+			r.votes[j] = oldVotes[i]
+		}
+	}
+	self := r.resetVoteBuf()
+	if i := r.peerIndex(r.id); i >= 0 {
+		self[i] = VoteGranted
+	}
+	r.selfQuorum = r.quorum.VoteResult(r.peerIDs, self) == VoteWon
+}
+
+// peerIndex returns the index of id in peerIDs, or -1 if it is not a peer.
+func (r *Raft) peerIndex(id uint64) int {
+	i := sort.Search(len(r.peerIDs), func(i int) bool { return r.peerIDs[i] >= id })
+	if i < len(r.peerIDs) && r.peerIDs[i] == id {
+		return i
+	}
+	return -1
+}
+
+// resetVoteBuf returns voteBuf with a missing vote for each peer.
+func (r *Raft) resetVoteBuf() []VoteState {
+	if cap(r.voteBuf) < len(r.peerIDs) {
+		r.voteBuf = make([]VoteState, len(r.peerIDs))
+	}
+	r.voteBuf = r.voteBuf[:len(r.peerIDs)]
+	for i := range r.voteBuf {
+		r.voteBuf[i] = VoteMissing
+	}
+	return r.voteBuf
+}
+
+// resetVotes clears the votes of the previous election and votes for itself.
+func (r *Raft) resetVotes() {
+	for i := range r.votes {
+		r.votes[i] = VoteMissing
+	}
+	r.recordVote(r.id, true)
+}
+
+// recordVote records the vote of id in the current election. The votes of
+// the nodes which are not peers are ignored.
+func (r *Raft) recordVote(id uint64, granted bool) {
+	i := r.peerIndex(id)
+	if i < 0 {
+		return
+	}
+	if granted {
+		r.votes[i] = VoteGranted
+	} else {
+		r.votes[i] = VoteRejected
+	}
+}
+
 // tick advances the internal logical clock by a single tick.
 func (r *Raft) tick() {
 	// Your Code Here (2A).
+	if !r.isMember() {
+		// A removed node must not start elections and disrupt the group.
+		return
+	}
 	switch r.State {
 	case StateFollower:
 		r.tickElection()
-	case StateCandidate:
+	case StateCandidate, StatePreCandidate:
 		r.tickElection()
 	case StateLeader:
 		r.tickHeartbeat()
+		r.tickQuorum()
+	}
+}
+
+// tickStalled advances the logical clock by n ticks which were missed while
+// the node was stalled. A leader sends at most one round of heartbeats. A
+// follower or candidate moves its election timer forward, but never past one
+// tick short of its timeout, so a stall alone cannot start an election: the
+// node still waits one more tick to hear from the leader.
+func (r *Raft) tickStalled(n int) {
+	if n <= 0 || !r.isMember() {
+		return
+	}
+	switch r.State {
+	case StateFollower, StateCandidate, StatePreCandidate:
+		r.electionElapsed += n
+		if r.electionElapsed >= r.randomizedElectionTimeout {
+			r.electionElapsed = r.randomizedElectionTimeout - 1
+		}
+	case StateLeader:
+		r.heartbeatElapsed += n - 1
+		if r.heartbeatElapsed >= r.heartbeatTimeout {
+			r.heartbeatElapsed = r.heartbeatTimeout - 1
+		}
+		r.tickHeartbeat()
 	}
 }
 
@@ -326,7 +862,36 @@ func (r *Raft) tickElection() {
 	r.electionElapsed++
 	if r.electionElapsed >= r.randomizedElectionTimeout {
 		r.electionElapsed = 0
-		_ = r.Step(pb.Message{MsgType: pb.MessageType_MsgHup})
+		_ = r.step(pb.Message{MsgType: pb.MessageType_MsgHup})
+	}
+}
+
+// tickQuorum makes the leader step down with checkQuorum if no quorum was
+// active since the last election timeout.
+func (r *Raft) tickQuorum() {
+	r.electionElapsed++
+	if r.electionElapsed < r.electionTimeout {
+		return
+	}
+	r.electionElapsed = 0
+	if r.leadTransferee != None {
+		r.logger.Infof("%x [term %d] abort transferring leadership to %x", r.id, r.Term, r.leadTransferee)
+		r.leadTransferee = None
+	}
+	if !r.checkQuorum {
+		return
+	}
+	active := r.resetVoteBuf()
+	for i, id := range r.peerIDs {
+		pr := r.Prs[id]
+		if id == r.id || pr.RecentActive {
+			active[i] = VoteGranted
+		}
+		pr.RecentActive = false
+	}
+	if r.quorum.VoteResult(r.peerIDs, active) != VoteWon {
+		r.logger.Warningf("%x stepped down to follower since quorum is not active", r.id)
+		r.becomeFollower(r.Term, None)
 	}
 }
 
@@ -334,76 +899,218 @@ func (r *Raft) tickHeartbeat() {
 	r.heartbeatElapsed++
 	if r.heartbeatElapsed >= r.heartbeatTimeout {
 		r.heartbeatElapsed = 0
-		_ = r.Step(pb.Message{MsgType: pb.MessageType_MsgBeat})
+		_ = r.step(pb.Message{MsgType: pb.MessageType_MsgBeat})
 	}
 }
 
 // becomeFollower transform this peer's state to Follower
 func (r *Raft) becomeFollower(term uint64, lead uint64) {
 	// Your Code Here (2A).
-	r.State = StateFollower
 	r.Term = term
+	r.setState(StateFollower)
 	r.Lead = lead
 	r.Vote = None
+	r.leadTransferee = None
+	r.resetReadOnly()
 	r.resetRandomizedElectionTimeout()
+	r.logger.Infof("%x became follower at term %d", r.id, r.Term)
+	r.forwardReadIndex()
 }
 
 // becomeCandidate transform this peer's state to candidate
 func (r *Raft) becomeCandidate() {
 	// Your Code Here (2A).
-	r.State = StateCandidate
 	r.Term++
+	r.setState(StateCandidate)
 	r.Lead = None
 	r.Vote = r.id
-	r.votes = make(map[uint64]bool)
-	r.votes[r.id] = true
+	r.resetVotes()
+	r.resetReadOnly()
+	r.resetRandomizedElectionTimeout()
+	r.logger.Infof("%x became candidate at term %d", r.id, r.Term)
+}
+
+// becomePreCandidate starts a pre-vote round. The term and vote are left
+// alone, so losing the round leaves no trace in the group.
+func (r *Raft) becomePreCandidate() {
+	r.setState(StatePreCandidate)
+	r.Lead = None
+	r.resetVotes()
 	r.resetRandomizedElectionTimeout()
+	r.logger.Infof("%x became pre-candidate at term %d", r.id, r.Term)
 }
 
 // becomeLeader transform this peer's state to leader
 func (r *Raft) becomeLeader() {
 	// Your Code Here (2A).
 	// NOTE: Leader should propose a noop entry on its term
-	r.State = StateLeader
+	r.setState(StateLeader)
 	r.Lead = r.id
 	r.heartbeatElapsed = 0
+	r.electionElapsed = 0
+	r.uncommittedSize = 0
+	r.leadTransferee = None
+	r.pendingRequests = nil
+	r.resetReadOnly()
 
 	// Append a noop entry
 	lastIndex := r.RaftLog.LastIndex()
-	for peer := range r.Prs {
+	for _, peer := range r.peerIDs {
+		pr := r.Prs[peer]
 		if r.id == peer {
-			r.Prs[peer].Next = lastIndex + 2
-			r.Prs[peer].Match = lastIndex + 1
+			pr.Next = lastIndex + 2
+			pr.Match = r.selfMatch(lastIndex + 1)
+			pr.State = ProgressStateReplicate
 		} else {
-			r.Prs[peer].Next = lastIndex + 1
+			// Optimistically assume the follower is up to date, the first
+			// rejection moves it to ProgressStateProbe. A Match learned in an
+			// earlier term may cover entries a later leader overwrote since,
+			// so it is relearned from the responses of this term.
+			pr.ins = r.newInflights()
+			pr.resetState(ProgressStateReplicate)
+			pr.Next = lastIndex + 1
+			pr.Match = 0
+			pr.RecentActive = false
 		}
 	}
-	r.RaftLog.entries = append(r.RaftLog.entries, pb.Entry{Term: r.Term, Index: lastIndex + 1})
+	noop := pb.Entry{EntryType: pb.EntryType_EntryNoOp, Term: r.Term, Index: lastIndex + 1}
+	if r.entryChecksums {
+		noop.Checksum = EntryChecksum(&noop)
+	}
+	r.RaftLog.entries = append(r.RaftLog.entries, noop)
 	r.bcastAppend()
 
-	if len(r.Prs) == 1 {
-		r.RaftLog.committed = r.Prs[r.id].Match
+	if r.selfQuorum {
+		r.leaderCommit()
+	}
+	r.logger.Infof("%x became leader at term %d", r.id, r.Term)
+
+	// The requests forwarded to the previous leader are served here now.
+	forwarded := r.forwardedReads.requests()
+	r.forwardedReads = newForwardedReads()
+	for _, m := range forwarded {
+		r.handleReadIndex(m)
+	}
+}
+
+// selfMatch returns the match index the leader may record for itself after
+// appending entries up to lastIndex. With optimistic replication only the
+// persisted prefix of the log counts.
+func (r *Raft) selfMatch(lastIndex uint64) uint64 {
+	if r.optimisticReplication {
+		return min(r.RaftLog.stabled, lastIndex)
+	}
+	return lastIndex
+}
+
+// stableTo records that the log entries up to index i have been persisted.
+// A leader using optimistic replication advances its own progress here and
+// may commit entries which were waiting only for the local vote.
+func (r *Raft) stableTo(i uint64) {
+	r.RaftLog.stabled = i
+	r.RaftLog.persisting = max(r.RaftLog.persisting, i)
+	if !r.optimisticReplication || r.State != StateLeader {
+		return
+	}
+	pr, ok := r.Prs[r.id]
+	if !ok || i <= pr.Match {
+		return
+	}
+	pr.Match = i
+	pr.Next = max(pr.Next, i+1)
+	// The quorum decides, a leader which is a quorum by itself commits here.
+	r.leaderCommit()
+}
+
+// fail records an error met reading the log for the next Step to return. The
+// first one is kept, the later ones likely follow from it.
+func (r *Raft) fail(err error) {
+	r.logger.Errorf("%x failed to read the log: %v", r.id, err)
+	if r.storageErr == nil {
+		r.storageErr = err
 	}
 }
 
 // Step the entrance of handle message, see `MessageType`
-// on `eraftpb.proto` for what msgs should be handled
+// on `eraftpb.proto` for what msgs should be handled. It returns the error
+// met reading the log while handling m, or since the previous Step, first.
 func (r *Raft) Step(m pb.Message) error {
+	err := r.step(m)
+	if r.storageErr != nil {
+		err, r.storageErr = r.storageErr, nil
+	}
+	return err
+}
+
+func (r *Raft) step(m pb.Message) error {
 	// Your Code Here (2A).
-	if _, ok := r.Prs[r.id]; !ok {
+	if r.tracer != nil {
+		r.tracer.OnReceive(m)
+	}
+	if !r.isMember() && !fromLeaderToNonMember(m) {
 		return nil
 	}
 
+	if m.MsgType == pb.MessageType_MsgAppend {
+		for _, ent := range m.Entries {
+			if err := CheckEntry(ent); err != nil {
+				r.logger.Errorf("%x dropping %s from %x: %v", r.id, m.MsgType, m.From, err)
+				return err
+			}
+		}
+	}
+	if err := r.checkMessage(m); err != nil {
+		r.logger.Errorf("%x dropping %s from %x: %v", r.id, m.MsgType, m.From, err)
+		return err
+	}
+
 	if m.Term > r.Term {
-		r.becomeFollower(m.Term, None)
+		if r.inLease(m) {
+			lastIndex := r.RaftLog.LastIndex()
+			lastTerm, _ := r.RaftLog.Term(lastIndex)
+			r.logger.Infof("%x [logterm: %d, index: %d, vote: %x] ignored %s from %x [logterm: %d, index: %d] at term %d: lease is not expired (remaining ticks: %d)",
+				r.id, lastTerm, lastIndex, r.Vote, m.MsgType, m.From, m.LogTerm, m.Index, r.Term, r.electionTimeout-r.electionElapsed)
+			return nil
+		}
+		switch {
+		case m.MsgType == pb.MessageType_MsgRequestPreVote:
+			// The sender only asks what would happen in a higher term.
+		case m.MsgType == pb.MessageType_MsgRequestPreVoteResponse && !m.Reject:
+			// A granted pre-vote carries the term we asked for.
+		default:
+			r.becomeFollower(m.Term, None)
+		}
 	}
 	switch r.State {
 	case StateFollower:
-		_ = r.stepFollower(m)
-	case StateCandidate:
-		_ = r.stepCandidate(m)
+		return r.stepFollower(m)
+	case StateCandidate, StatePreCandidate:
+		return r.stepCandidate(m)
 	case StateLeader:
-		_ = r.stepLeader(m)
+		return r.stepLeader(m)
+	}
+	return nil
+}
+
+// checkMessage returns ErrInvalidMessage if the indexes of m are inconsistent,
+// either among themselves or with the log of the leader it answers.
+func (r *Raft) checkMessage(m pb.Message) error {
+	switch m.MsgType {
+	case pb.MessageType_MsgAppend:
+		prevTerm := m.LogTerm
+		for i, ent := range m.Entries {
+			if ent.Index != m.Index+uint64(i)+1 {
+				return fmt.Errorf("%w: entry %d at position %d of an append after index %d", ErrInvalidMessage, ent.Index, i, m.Index)
+			}
+			if ent.Term < prevTerm || ent.Term > m.Term {
+				return fmt.Errorf("%w: entry %d of term %d after term %d in an append of term %d", ErrInvalidMessage, ent.Index, ent.Term, prevTerm, m.Term)
+			}
+			prevTerm = ent.Term
+		}
+	case pb.MessageType_MsgAppendResponse:
+		if r.State == StateLeader && m.Term == r.Term && m.Index > r.RaftLog.LastIndex() {
+			return fmt.Errorf("%w: append response at index %d after the last index %d", ErrInvalidMessage, m.Index, r.RaftLog.LastIndex())
+		}
 	}
 	return nil
 }
@@ -411,16 +1118,47 @@ func (r *Raft) Step(m pb.Message) error {
 func (r *Raft) stepFollower(m pb.Message) error {
 	switch m.MsgType {
 	case pb.MessageType_MsgHup:
-		r.doElection()
+		r.hup(campaignPreElection)
+	case pb.MessageType_MsgPropose:
+		if r.Lead == None {
+			r.logger.Debugf("%x no leader at term %d, dropping proposal", r.id, r.Term)
+			return ErrProposalDropped
+		}
+		if !r.forwardProposals {
+			r.logger.Debugf("%x not forwarding to leader %x at term %d, dropping proposal", r.id, r.Lead, r.Term)
+			return ErrProposalDropped
+		}
+		m.To = r.Lead
+		r.send(m)
 	case pb.MessageType_MsgAppend:
-		r.handleAppendEntries(m)
+		return r.handleAppendEntries(m)
 	case pb.MessageType_MsgRequestVote:
 		r.handleRequestVote(m)
+	case pb.MessageType_MsgRequestPreVote:
+		r.handleRequestPreVote(m)
 	case pb.MessageType_MsgSnapshot:
+		r.handleSnapshot(m)
 	case pb.MessageType_MsgHeartbeat:
 		r.handleHeartbeat(m)
+	case pb.MessageType_MsgReadIndex:
+		if len(m.Entries) == 1 && r.forwardedReads.add(m) && r.Lead != None {
+			r.sendReadIndex(m)
+		}
+	case pb.MessageType_MsgReadIndexResp:
+		if len(m.Entries) != 1 {
+			return nil
+		}
+		// Answer the original requester, a response to a request not
+		// forwarded, or answered already, is stale.
+		if req, ok := r.forwardedReads.take(m.Entries[0].Data); ok {
+			r.responseToReadIndexReq(req, m.Index)
+		}
 	case pb.MessageType_MsgTransferLeader:
 	case pb.MessageType_MsgTimeoutNow:
+		// The leader hands its leadership over, campaign at once without
+		// waiting for the election timeout.
+		r.logger.Infof("%x [term %d] received MsgTimeoutNow from %x and starts an election to get leadership", r.id, r.Term, m.From)
+		r.hup(campaignTransfer)
 	}
 	return nil
 }
@@ -428,17 +1166,32 @@ func (r *Raft) stepFollower(m pb.Message) error {
 func (r *Raft) stepCandidate(m pb.Message) error {
 	switch m.MsgType {
 	case pb.MessageType_MsgHup:
-		r.doElection()
+		r.hup(campaignPreElection)
+	case pb.MessageType_MsgPropose:
+		r.logger.Debugf("%x no leader at term %d, dropping proposal", r.id, r.Term)
+		return ErrProposalDropped
 	case pb.MessageType_MsgAppend:
 		if m.Term == r.Term {
 			r.becomeFollower(m.Term, m.From)
 		}
-		r.handleAppendEntries(m)
+		return r.handleAppendEntries(m)
 	case pb.MessageType_MsgRequestVote:
 		r.handleRequestVote(m)
 	case pb.MessageType_MsgRequestVoteResponse:
-		r.handleRequestVoteResponse(m)
+		if r.State == StateCandidate {
+			r.handleRequestVoteResponse(m)
+		}
+	case pb.MessageType_MsgRequestPreVote:
+		r.handleRequestPreVote(m)
+	case pb.MessageType_MsgRequestPreVoteResponse:
+		if r.State == StatePreCandidate {
+			r.handleRequestPreVoteResponse(m)
+		}
 	case pb.MessageType_MsgSnapshot:
+		if m.Term == r.Term {
+			r.becomeFollower(m.Term, m.From)
+		}
+		r.handleSnapshot(m)
 	case pb.MessageType_MsgHeartbeat:
 		if m.Term == r.Term {
 			r.becomeFollower(m.Term, m.From)
@@ -451,43 +1204,153 @@ func (r *Raft) stepCandidate(m pb.Message) error {
 }
 
 func (r *Raft) stepLeader(m pb.Message) error {
+	if m.MsgType == pb.MessageType_MsgAppendResponse || m.MsgType == pb.MessageType_MsgHeartbeatResponse {
+		if pr := r.Prs[m.From]; pr != nil {
+			pr.RecentActive = true
+		}
+	}
 	switch m.MsgType {
 	case pb.MessageType_MsgBeat:
 		r.bcastHeartbeat()
 	case pb.MessageType_MsgPropose:
+		if r.leadTransferee != None {
+			r.logger.Debugf("%x [term %d] transfer leadership to %x is in progress, dropping proposal", r.id, r.Term, r.leadTransferee)
+			return ErrProposalDropped
+		}
+		for _, ent := range m.Entries {
+			if r.maxEntrySize > 0 && uint64(ent.Size()) > r.maxEntrySize {
+				r.logger.Warningf("%x dropping proposal with an entry of %d bytes, larger than %d", r.id, ent.Size(), r.maxEntrySize)
+				return ErrProposalTooLarge
+			}
+		}
+		if m.Entries = r.dedupEntries(m.Entries); len(m.Entries) == 0 {
+			return ErrProposalDuplicate
+		}
+		if !r.increaseUncommittedSize(m.Entries) {
+			r.logger.Debugf("%x dropping proposal as %d bytes are uncommitted, limit %d", r.id, r.uncommittedSize, r.maxUncommittedSize)
+			return ErrProposalDropped
+		}
 		r.appendEntries(m.Entries)
 	case pb.MessageType_MsgAppend:
-		r.handleAppendEntries(m)
+		return r.handleAppendEntries(m)
 	case pb.MessageType_MsgAppendResponse:
 		r.handleAppendEntriesResponse(m)
 	case pb.MessageType_MsgRequestVote:
 		r.handleRequestVote(m)
+	case pb.MessageType_MsgRequestPreVote:
+		// The group has a leader, there is no need for an election.
+		r.sendRequestPreVoteResponse(m.From, r.Term, true)
 	case pb.MessageType_MsgSnapshot:
 	case pb.MessageType_MsgHeartbeat:
 		r.handleHeartbeat(m)
 	case pb.MessageType_MsgHeartbeatResponse:
 		if !m.Reject {
-			r.sendAppend(m.From)
+			if pr := r.Prs[m.From]; pr != nil {
+				// The follower is alive, an append response may have been lost.
+				if pr.State == ProgressStateProbe && pr.Paused {
+					pr.backoffProbe(r.maxProbeBackoff)
+				}
+				if pr.State == ProgressStateReplicate && pr.ins.full() {
+					pr.ins.freeFirstOne()
+				}
+			}
+			// An empty append after the ones in flight is rejected if they
+			// were lost, which moves the peer back to probing.
+			r.maybeSendAppend(m.From, true)
+			if len(m.Context) > 0 {
+				r.handleReadIndexAck(m)
+			}
 		}
+	case pb.MessageType_MsgReadIndex:
+		r.handleReadIndex(m)
 	case pb.MessageType_MsgTransferLeader:
 	case pb.MessageType_MsgTimeoutNow:
+	case pb.MessageType_MsgUnreachable:
+		// The appends in flight are probably lost, probe the peer instead of
+		// streaming more appends to it.
+		if pr := r.Prs[m.From]; pr != nil && pr.State == ProgressStateReplicate {
+			pr.becomeProbe()
+			r.logger.Debugf("%x failed to send message to %x because it is unreachable [%s]", r.id, m.From, pr)
+		}
+	case pb.MessageType_MsgSnapStatus:
+		r.handleSnapStatus(m)
 	}
 	return nil
 }
 
-func (r *Raft) doElection() {
+// handleSnapStatus handles the report of the transport on the snapshot sent
+// to a peer, or the rejection of the peer which discarded it. Once delivered,
+// the peer is probed from the snapshot on. A failed snapshot is forgotten and
+// the peer marked as needing a new one, rebuilding it again if the failed one
+// did. Either way, nothing is sent to the peer until it answers a heartbeat,
+// since it is busy applying the snapshot or unreachable.
+func (r *Raft) handleSnapStatus(m pb.Message) {
+	pr := r.Prs[m.From]
+	if pr == nil || pr.State != ProgressStateSnapshot || (m.Term != None && m.Term < r.Term) {
+		return
+	}
+	if m.Reject {
+		rebuild := pr.pendingRebuild
+		pr.PendingSnapshot = 0
+		pr.becomeProbe()
+		pr.NeedSnapshot, pr.Rebuild = true, rebuild
+		r.logger.Infof("%x snapshot failed, will send a new one to %x [%s]", r.id, m.From, pr)
+	} else {
+		pr.becomeProbe()
+		r.logger.Infof("%x snapshot succeeded, resumed sending replication messages to %x [%s]", r.id, m.From, pr)
+	}
+	pr.Paused = true
+}
+
+// inLease returns whether m is a vote request of an ordinary election this
+// peer ignores, as it heard from the leader within the election timeout.
+func (r *Raft) inLease(m pb.Message) bool {
+	if m.MsgType != pb.MessageType_MsgRequestVote && m.MsgType != pb.MessageType_MsgRequestPreVote {
+		return false
+	}
+	if CampaignType(m.Context) == campaignTransfer {
+		return false
+	}
+	return r.leaderStickiness && r.Lead != None && r.electionElapsed < r.electionTimeout
+}
+
+// hup starts an election of the given type.
+func (r *Raft) hup(t CampaignType) {
+	if !r.isMember() {
+		r.logger.Warningf("%x is not a member of the group and can not campaign", r.id)
+		return
+	}
+	if t == campaignPreElection && r.preVote && !r.selfQuorum {
+		r.becomePreCandidate()
+		lastIndex := r.RaftLog.LastIndex()
+		lastLogTerm, _ := r.RaftLog.Term(lastIndex)
+		for _, peer := range r.peerIDs {
+			if peer != r.id {
+				r.sendRequestPreVote(peer, lastIndex, lastLogTerm)
+			}
+		}
+		return
+	}
+	if t == campaignPreElection {
+		t = campaignElection
+	}
+	r.campaign(t)
+}
+
+// campaign becomes a candidate in the next term and asks for votes.
+func (r *Raft) campaign(t CampaignType) {
 	r.becomeCandidate()
 	r.heartbeatElapsed = 0
-	if len(r.Prs) == 1 {
+	if r.selfQuorum {
 		r.becomeLeader()
 		return
 	}
 
 	lastIndex := r.RaftLog.LastIndex()
 	lastLogTerm, _ := r.RaftLog.Term(lastIndex)
-	for peer := range r.Prs {
+	for _, peer := range r.peerIDs {
 		if peer != r.id {
-			r.sendRequestVote(peer, lastIndex, lastLogTerm)
+			r.sendRequestVote(peer, lastIndex, lastLogTerm, t)
 		}
 	}
 }
@@ -496,64 +1359,105 @@ func (r *Raft) handleRequestVote(m pb.Message) {
 	// Q: Why `r.Term == m.Term` won't reject?
 	// A: See `Step()`
 	if r.Term > m.Term {
+		r.logVote(m, false)
 		r.sendRequestVoteResponse(m.From, true)
 		return
 	}
 
 	if r.Vote == None || r.Vote == m.From {
 		if r.RaftLog.isUpToDate(m.Index, m.LogTerm) {
+			r.logVote(m, true)
 			r.Vote = m.From
 			r.electionElapsed = 0
 			r.sendRequestVoteResponse(m.From, false)
 			return
 		}
 	}
+	r.logVote(m, false)
 	r.sendRequestVoteResponse(m.From, true)
 }
 
-func (r *Raft) handleRequestVoteResponse(m pb.Message) {
-	r.votes[m.From] = !m.Reject
-	granted := 0
-	quorum := len(r.Prs) / 2
-	for _, v := range r.votes {
-		if v {
-			granted++
-		}
+// logVote logs the vote or pre-vote given to the sender of m.
+func (r *Raft) logVote(m pb.Message, granted bool) {
+	action := "rejected"
+	if granted {
+		action = "cast"
 	}
-	if granted > quorum {
+	lastIndex := r.RaftLog.LastIndex()
+	lastTerm, _ := r.RaftLog.Term(lastIndex)
+	r.logger.Infof("%x [logterm: %d, index: %d, vote: %x] %s %s from %x [logterm: %d, index: %d] at term %d",
+		r.id, lastTerm, lastIndex, r.Vote, action, m.MsgType, m.From, m.LogTerm, m.Index, r.Term)
+}
+
+func (r *Raft) handleRequestVoteResponse(m pb.Message) {
+	r.recordVote(m.From, !m.Reject)
+	switch r.quorum.VoteResult(r.peerIDs, r.votes) {
+	case VoteWon:
 		r.becomeLeader()
-	} else if len(r.votes)-granted > quorum {
+	case VoteLost:
 		r.becomeFollower(r.Term, None)
 	}
 }
 
+// handleRequestPreVote grants a pre-vote if the sender could get our vote in
+// the term it asks for. Neither the term nor the vote are changed.
+func (r *Raft) handleRequestPreVote(m pb.Message) {
+	if m.Term <= r.Term || !r.RaftLog.isUpToDate(m.Index, m.LogTerm) {
+		r.logVote(m, false)
+		r.sendRequestPreVoteResponse(m.From, r.Term, true)
+		return
+	}
+	r.logVote(m, true)
+	r.sendRequestPreVoteResponse(m.From, m.Term, false)
+}
+
+func (r *Raft) handleRequestPreVoteResponse(m pb.Message) {
+	if m.Term < r.Term || (!m.Reject && m.Term != r.Term+1) {
+		// A response to an older pre-vote round.
+		return
+	}
+	r.recordVote(m.From, !m.Reject)
+	switch r.quorum.VoteResult(r.peerIDs, r.votes) {
+	case VoteWon:
+		r.campaign(campaignElection)
+	case VoteLost:
+		// Stay in the current term, keeping the vote cast in it.
+		r.setState(StateFollower)
+		r.resetRandomizedElectionTimeout()
+	}
+}
+
 // handleAppendEntries handle AppendEntries RPC request
-func (r *Raft) handleAppendEntries(m pb.Message) {
+func (r *Raft) handleAppendEntries(m pb.Message) error {
 	// Your Code Here (2A).
 	if r.Term > m.Term {
 		r.sendAppendResponse(m.From, None, None, true)
-		return
+		return nil
 	}
 
-	r.Lead = m.From
+	r.learnLeader(m.From)
 	r.electionElapsed = 0
 
 	lastIndex := r.RaftLog.LastIndex()
 	if m.Index > lastIndex {
+		r.logger.Debugf("%x rejected append [logterm: %d, index: %d] from %x, last index is %d",
+			r.id, m.LogTerm, m.Index, m.From, lastIndex)
 		r.sendAppendResponse(m.From, None, lastIndex+1, true)
-		return
+		return nil
 	}
 	if m.Index >= r.RaftLog.FirstIndex() {
 		logTerm, err := r.RaftLog.Term(m.Index)
 		if err != nil {
-			panic(err)
+			return err
 		}
 		// Find the minimum log index at logTerm (index -> nextIndex)
 		if logTerm != m.LogTerm {
-			nexti := r.RaftLog.toEntryIndex(sort.Search(r.RaftLog.toSliceIndex(m.Index+1),
-				func(i int) bool { return r.RaftLog.entries[i].Term == logTerm }))
+			nexti := r.RaftLog.searchTerm(r.RaftLog.FirstIndex(), m.Index+1,
+				func(term uint64) bool { return term >= logTerm })
+			r.logger.Debugf("%x [logterm: %d, index: %d] rejected append [logterm: %d, index: %d] from %x",
+				r.id, logTerm, m.Index, m.LogTerm, m.Index, m.From)
 			r.sendAppendResponse(m.From, logTerm, nexti, true)
-			return
+			return nil
 		}
 	}
 
@@ -564,14 +1468,15 @@ func (r *Raft) handleAppendEntries(m pb.Message) {
 		if ent.Index <= r.RaftLog.LastIndex() {
 			logTerm, err := r.RaftLog.Term(ent.Index)
 			if err != nil {
-				panic(err)
+				return err
 			}
 			if logTerm != ent.Term {
-				idx := r.RaftLog.toSliceIndex(ent.Index)
-				r.RaftLog.entries[idx] = *ent
-				r.RaftLog.entries = r.RaftLog.entries[:idx+1]
+				if err := r.RaftLog.truncateAndAppend([]pb.Entry{*ent}); err != nil {
+					return err
+				}
 				// Truncation maybe cause stabled index decrement
 				r.RaftLog.stabled = min(r.RaftLog.stabled, ent.Index-1)
+				r.RaftLog.persisting = min(r.RaftLog.persisting, ent.Index-1)
 			}
 		} else {
 			for j := i; j < len(m.Entries); j++ {
@@ -588,6 +1493,7 @@ func (r *Raft) handleAppendEntries(m pb.Message) {
 		r.RaftLog.committed = min(m.Commit, m.Index+uint64(len(m.Entries)))
 	}
 	r.sendAppendResponse(m.From, None, r.RaftLog.LastIndex(), false)
+	return nil
 }
 
 func (r *Raft) handleAppendEntriesResponse(m pb.Message) {
@@ -595,68 +1501,325 @@ func (r *Raft) handleAppendEntriesResponse(m pb.Message) {
 		return
 	}
 
+	pr := r.Prs[m.From]
+	if pr == nil {
+		// The sender was removed from the group since.
+		return
+	}
 	if m.Reject {
+		if pr.State == ProgressStateSnapshot {
+			// The rejection answers an append sent before the snapshot.
+			return
+		}
 		rejectHint := m.Index
 		if rejectHint == None {
 			return
 		}
+		r.logger.Debugf("%x received append rejection [logterm: %d, hint: %d] from %x [%s]",
+			r.id, m.LogTerm, m.Index, m.From, pr)
 		if m.LogTerm != None {
 			logTerm := m.LogTerm
-			sliceIndex := sort.Search(len(r.RaftLog.entries),
-				func(i int) bool { return r.RaftLog.entries[i].Term > logTerm })
-			if sliceIndex > 0 && r.RaftLog.entries[sliceIndex-1].Term == logTerm {
-				rejectHint = r.RaftLog.toEntryIndex(sliceIndex)
+			first := r.RaftLog.FirstIndex()
+			next := r.RaftLog.searchTerm(first, r.RaftLog.LastIndex()+1,
+				func(term uint64) bool { return term > logTerm })
+			if next > first {
+				if term, _ := r.RaftLog.Term(next - 1); term == logTerm {
+					rejectHint = next
+				}
 			}
 		}
-		r.Prs[m.From].Next = rejectHint
-		r.sendAppend(m.From)
+		if pr.maybeDecrTo(rejectHint) {
+			if pr.State == ProgressStateReplicate {
+				// Find where the logs match one append at a time.
+				pr.becomeProbe()
+				pr.Next = rejectHint
+			}
+			r.sendAppend(m.From)
+		}
 		return
 	}
 
-	if m.Index > r.Prs[m.From].Match {
-		r.Prs[m.From].Match = m.Index
-		r.Prs[m.From].Next = m.Index + 1
-		r.leaderCommit()
+	oldPaused := pr.isPaused()
+	if !pr.maybeUpdate(m.Index) {
+		return
+	}
+	switch pr.State {
+	case ProgressStateProbe:
+		pr.becomeReplicate()
+	case ProgressStateSnapshot:
+		if pr.Match >= pr.PendingSnapshot {
+			// Go through the probe state, which takes the snapshot into account.
+			pr.becomeProbe()
+			pr.becomeReplicate()
+		}
+	case ProgressStateReplicate:
+		pr.ins.freeTo(m.Index)
+	}
+	if !r.leaderCommit() && oldPaused && pr.Next <= r.RaftLog.LastIndex() {
+		// Catch the peer up with the entries held back while it was paused.
+		r.sendAppend(m.From)
 	}
+	if m.From == r.leadTransferee && pr.Match == r.RaftLog.LastIndex() {
+		r.sendTimeoutNow(m.From)
+	}
+}
+
+// gracefulStop hands the leadership over to the most up-to-date voter before
+// the node stops: it is caught up if needed, then told to campaign at once
+// with MsgTimeoutNow. Proposals are dropped meanwhile, and the handoff is
+// aborted if it doesn't complete within an election timeout. It returns true
+// if the node may stop already, as it doesn't lead other voters.
+func (r *Raft) gracefulStop() bool {
+	if r.State != StateLeader {
+		return true
+	}
+	if r.leadTransferee != None {
+		return false
+	}
+	var target uint64
+	for _, id := range r.peerIDs {
+		if id != r.id && (target == None || r.Prs[id].Match > r.Prs[target].Match) {
+			target = id
+		}
+	}
+	if target == None {
+		return true
+	}
+	r.logger.Infof("%x [term %d] stopping, transferring leadership to %x", r.id, r.Term, target)
+	r.leadTransferee = target
+	r.electionElapsed = 0
+	if r.Prs[target].Match == r.RaftLog.LastIndex() {
+		r.sendTimeoutNow(target)
+	} else {
+		// The appends in flight may be lost, an empty one finds out.
+		r.maybeSendAppend(target, true)
+	}
+	return false
+}
+
+func (r *Raft) sendTimeoutNow(to uint64) {
+	r.send(pb.Message{
+		MsgType: pb.MessageType_MsgTimeoutNow,
+		To:      to,
+		From:    r.id,
+		Term:    r.Term,
+	})
 }
 
-func (r *Raft) leaderCommit() {
-	match := make(uint64Slice, len(r.Prs))
-	i := 0
-	for _, pr := range r.Prs {
-		match[i] = pr.Match
-		i++
+// leaderCommit advances the commit index to the index replicated on a quorum,
+// and broadcasts it. It returns true if the commit index advanced.
+func (r *Raft) leaderCommit() bool {
+	match := r.matchBuf[:0]
+	for _, id := range r.peerIDs {
+		match = append(match, r.Prs[id].Match)
 	}
-	sort.Sort(match)
-	n := match[(len(match)-1)/2]
+	r.matchBuf = match
+	n := r.quorum.CommittedIndex(r.peerIDs, match)
 
 	if n > r.RaftLog.committed {
 		logTerm, err := r.RaftLog.Term(n)
 		if err != nil {
-			panic(err)
+			r.fail(err)
+			return false
 		}
 		if logTerm == r.Term {
+			r.reduceUncommittedSize(r.RaftLog.committed, n)
 			r.RaftLog.committed = n
+			for id, index := range r.pendingRequests {
+				if index <= n {
+					delete(r.pendingRequests, id)
+				}
+			}
 			r.bcastAppend()
+			r.releasePendingReadIndexMessages()
+			return true
 		}
 	}
+	return false
 }
 
 // handleHeartbeat handle Heartbeat RPC request
 func (r *Raft) handleHeartbeat(m pb.Message) {
 	// Your Code Here (2A).
 	if r.Term > m.Term {
-		r.sendHeartbeatResponse(m.From, true)
+		r.sendHeartbeatResponse(m.From, true, nil)
 		return
 	}
-	r.Lead = m.From
+	r.learnLeader(m.From)
 	r.electionElapsed = 0
-	r.sendHeartbeatResponse(m.From, false)
+	if commit := min(m.Commit, r.RaftLog.LastIndex()); commit > r.RaftLog.committed {
+		r.RaftLog.committed = commit
+	}
+	r.sendHeartbeatResponse(m.From, false, m.Context)
+}
+
+// handleReadIndex handles a read index request on the leader. The commit
+// index becomes the read index once a heartbeat round confirms nobody else
+// has been elected meanwhile.
+func (r *Raft) handleReadIndex(m pb.Message) {
+	if len(m.Entries) != 1 {
+		return
+	}
+	if r.selfQuorum {
+		r.responseToReadIndexReq(m, r.RaftLog.committed)
+		return
+	}
+	if !r.committedEntryInCurrentTerm() {
+		r.pendingReadIndexMessages = append(r.pendingReadIndexMessages, m)
+		return
+	}
+	r.readOnly.addRequest(r.RaftLog.committed, r.id, m)
+	r.bcastHeartbeatWithCtx(m.Entries[0].Data)
+}
+
+// handleReadIndexAck counts a heartbeat response towards the read index
+// requests, and releases every request up to the acknowledged one once a
+// quorum has responded.
+func (r *Raft) handleReadIndexAck(m pb.Message) {
+	acks := r.readOnly.recvAck(m.From, m.Context)
+	votes := r.resetVoteBuf()
+	for i, id := range r.peerIDs {
+		if acks[id] {
+			votes[i] = VoteGranted
+		}
+	}
+	if r.quorum.VoteResult(r.peerIDs, votes) != VoteWon {
+		return
+	}
+	for _, rs := range r.readOnly.advance(m.Context) {
+		r.responseToReadIndexReq(rs.req, rs.index)
+	}
+}
+
+func (r *Raft) responseToReadIndexReq(req pb.Message, readIndex uint64) {
+	if req.From == None || req.From == r.id {
+		r.readStates = append(r.readStates, ReadState{Index: readIndex, RequestCtx: req.Entries[0].Data})
+		return
+	}
+	r.send(pb.Message{
+		MsgType: pb.MessageType_MsgReadIndexResp,
+		To:      req.From,
+		From:    r.id,
+		Term:    r.Term,
+		Index:   readIndex,
+		Entries: req.Entries,
+	})
+}
+
+func (r *Raft) committedEntryInCurrentTerm() bool {
+	logTerm, err := r.RaftLog.Term(r.RaftLog.committed)
+	return err == nil && logTerm == r.Term
+}
+
+func (r *Raft) releasePendingReadIndexMessages() {
+	msgs := r.pendingReadIndexMessages
+	r.pendingReadIndexMessages = nil
+	for _, m := range msgs {
+		r.handleReadIndex(m)
+	}
+}
+
+// sendReadIndex forwards the read index request to the leader.
+func (r *Raft) sendReadIndex(req pb.Message) {
+	r.send(pb.Message{
+		MsgType: pb.MessageType_MsgReadIndex,
+		To:      r.Lead,
+		From:    r.id,
+		Term:    r.Term,
+		Entries: req.Entries,
+	})
+}
+
+// learnLeader records the leader a message came from. The read index
+// requests forwarded before the leader was known are forwarded to it.
+func (r *Raft) learnLeader(lead uint64) {
+	if r.Lead == lead {
+		return
+	}
+	r.Lead = lead
+	r.forwardReadIndex()
+}
+
+// forwardReadIndex forwards the read index requests which are not answered
+// yet to the current leader, the previous one may have lost them.
+func (r *Raft) forwardReadIndex() {
+	if r.Lead == None || r.Lead == r.id {
+		return
+	}
+	for _, m := range r.forwardedReads.requests() {
+		r.sendReadIndex(m)
+	}
+}
+
+// resetReadOnly drops the read index requests served as leader when the role
+// changes, the application retries them.
+func (r *Raft) resetReadOnly() {
+	r.readOnly = newReadOnly()
+	r.pendingReadIndexMessages = nil
 }
 
 // handleSnapshot handle Snapshot RPC request
 func (r *Raft) handleSnapshot(m pb.Message) {
 	// Your Code Here (2C).
+	if m.Term != None && m.Term < r.Term {
+		return
+	}
+	if m.From != None {
+		r.learnLeader(m.From)
+	}
+	r.electionElapsed = 0
+	if r.validateSnapshot != nil {
+		if err := r.validateSnapshot(m.Snapshot); err != nil {
+			meta := m.Snapshot.GetMetadata()
+			r.logger.Warningf("%x discarded snapshot [index: %d, term: %d] from %x: %v",
+				r.id, meta.GetIndex(), meta.GetTerm(), m.From, err)
+			// Unwedge the leader waiting for the snapshot to be acknowledged.
+			r.send(pb.Message{MsgType: pb.MessageType_MsgSnapStatus, To: m.From, From: r.id, Term: r.Term, Reject: true})
+			return
+		}
+	}
+	if r.restore(m.Snapshot, m.Rebuild) {
+		r.sendAppendResponse(m.From, None, r.RaftLog.LastIndex(), false)
+	} else {
+		r.sendAppendResponse(m.From, None, r.RaftLog.committed, false)
+	}
+}
+
+// restore replaces the log with snapshot and rebuilds the membership from its
+// ConfState. It returns false if the snapshot is ignored because the log
+// already covers it, the commit index is then fast-forwarded if the log
+// contains the last entry of the snapshot. A rebuild snapshot is never
+// ignored, it replaces whatever state the node has.
+func (r *Raft) restore(snapshot *pb.Snapshot, rebuild bool) bool {
+	meta := snapshot.Metadata
+	if !rebuild {
+		if meta.Index <= r.RaftLog.committed {
+			return false
+		}
+		if term, err := r.RaftLog.Term(meta.Index); err == nil && term == meta.Term {
+			r.RaftLog.committed = meta.Index
+			return false
+		}
+	}
+
+	r.RaftLog.entries = nil
+	r.RaftLog.offset = meta.Index + 1
+	r.RaftLog.first = meta.Index + 1
+	r.RaftLog.committed = meta.Index
+	r.RaftLog.appliedTo(meta.Index)
+	r.RaftLog.stabled = meta.Index
+	r.RaftLog.persisting = meta.Index
+	r.RaftLog.pendingSnapshot = snapshot
+
+	r.Prs = make(map[uint64]*Progress)
+	for _, id := range meta.ConfState.Nodes {
+		r.Prs[id] = &Progress{Next: meta.Index + 1}
+	}
+	if pr, ok := r.Prs[r.id]; ok {
+		pr.Match = meta.Index
+	}
+	r.updatePeerIDs()
+	return true
 }
 
 func (r *Raft) appendEntries(ents []*pb.Entry) {
@@ -664,31 +1827,169 @@ func (r *Raft) appendEntries(ents []*pb.Entry) {
 	for i, ent := range ents {
 		ent.Term = r.Term
 		ent.Index = lastIndex + uint64(i) + 1
+		if r.entryChecksums {
+			ent.Checksum = EntryChecksum(ent)
+		}
 		r.RaftLog.entries = append(r.RaftLog.entries, *ent)
+		if r.dedupProposals && len(ent.RequestId) > 0 {
+			if r.pendingRequests == nil {
+				r.pendingRequests = make(map[string]uint64)
+			}
+			r.pendingRequests[string(ent.RequestId)] = ent.Index
+		}
 	}
-	r.Prs[r.id].Match = r.RaftLog.LastIndex()
-	r.Prs[r.id].Next = r.Prs[r.id].Match + 1
-	r.bcastAppend()
+	r.Prs[r.id].Match = r.selfMatch(r.RaftLog.LastIndex())
+	r.Prs[r.id].Next = r.RaftLog.LastIndex() + 1
+	if r.batchProposals {
+		r.appendsPending = true
+	} else {
+		r.bcastAppend()
+	}
+
+	if r.selfQuorum {
+		r.leaderCommit()
+	}
+}
+
+// dedupEntries removes from ents the entries whose request ID is the one of
+// an uncommitted entry, or of an entry before them in ents.
+func (r *Raft) dedupEntries(ents []*pb.Entry) []*pb.Entry {
+	if !r.dedupProposals {
+		return ents
+	}
+	seen := make(map[string]bool)
+	kept := ents[:0:0]
+	for _, ent := range ents {
+		id := string(ent.RequestId)
+		if id == "" {
+			kept = append(kept, ent)
+			continue
+		}
+		if _, ok := r.pendingRequests[id]; ok || seen[id] {
+			r.logger.Debugf("%x dropping retried proposal %x at term %d", r.id, ent.RequestId, r.Term)
+			continue
+		}
+		seen[id] = true
+		kept = append(kept, ent)
+	}
+	return kept
+}
+
+// increaseUncommittedSize adds the size of ents to the uncommitted size, and
+// returns false without changing it if that exceeds maxUncommittedSize.
+func (r *Raft) increaseUncommittedSize(ents []*pb.Entry) bool {
+	var size uint64
+	for _, ent := range ents {
+		size += uint64(len(ent.Data))
+	}
+	if r.maxUncommittedSize > 0 && r.uncommittedSize > 0 && size > 0 && r.uncommittedSize+size > r.maxUncommittedSize {
+		return false
+	}
+	r.uncommittedSize += size
+	return true
+}
+
+// reduceUncommittedSize removes the size of the entries in (lo, hi] which
+// were just committed from the uncommitted size.
+func (r *Raft) reduceUncommittedSize(lo, hi uint64) {
+	if r.uncommittedSize == 0 {
+		return
+	}
+	ents, _ := r.RaftLog.Slice(max(lo+1, r.RaftLog.FirstIndex()), min(hi, r.RaftLog.LastIndex())+1, 0)
+	var size uint64
+	for _, ent := range ents {
+		size += uint64(len(ent.Data))
+	}
+	if size > r.uncommittedSize {
+		// The entries of the previous leaders are not counted.
+		size = r.uncommittedSize
+	}
+	r.uncommittedSize -= size
+}
 
-	if len(r.Prs) == 1 {
-		r.RaftLog.committed = r.Prs[r.id].Match
+// rebuildPeer makes the leader replace the state of a follower with a
+// snapshot. The follower no longer counts towards the commit index until it
+// acknowledges the snapshot, as the entries it had acknowledged are dropped.
+func (r *Raft) rebuildPeer(id uint64) {
+	pr, ok := r.Prs[id]
+	if !ok || id == r.id || r.State != StateLeader {
+		return
 	}
+	pr.Match = 0
+	pr.becomeProbe()
+	pr.Rebuild = true
+	r.sendAppend(id)
 }
 
 // addNode add a new node to raft group
 func (r *Raft) addNode(id uint64) {
 	// Your Code Here (3A).
+	if _, ok := r.Prs[id]; ok {
+		return
+	}
+	// A new peer is given a full election timeout to answer before
+	// counting as inactive.
+	r.Prs[id] = &Progress{Next: r.RaftLog.LastIndex() + 1, ins: r.newInflights(), RecentActive: true}
+	r.updatePeerIDs()
+	if id == r.id {
+		r.removed = false
+	}
+	if r.State == StateLeader {
+		r.sendAppend(id)
+	}
 }
 
 // removeNode remove a node from raft group
 func (r *Raft) removeNode(id uint64) {
 	// Your Code Here (3A).
+	if _, ok := r.Prs[id]; !ok {
+		return
+	}
+	delete(r.Prs, id)
+	r.updatePeerIDs()
+	if r.leadTransferee == id {
+		r.leadTransferee = None
+	}
+	if id == r.id {
+		// The node is out of the group, it forgets the others so it neither
+		// replicates to them nor campaigns.
+		r.logger.Infof("%x is removed from the group at term %d", r.id, r.Term)
+		if r.State != StateFollower {
+			r.becomeFollower(r.Term, None)
+		}
+		r.Prs = make(map[uint64]*Progress)
+		r.updatePeerIDs()
+		r.removed = true
+		return
+	}
+	// The quorum shrank, so entries waiting on the removed node may commit.
+	if r.State == StateLeader && len(r.Prs) > 0 {
+		r.leaderCommit()
+	}
+}
+
+// isMember returns true if the local node is in the raft group.
+func (r *Raft) isMember() bool {
+	_, ok := r.Prs[r.id]
+	return ok
+}
+
+// fromLeaderToNonMember returns true if m is a message a node outside the
+// group still steps. A peer created for a conf change starts with an empty
+// ConfState and only learns the group from the leader's snapshot.
+func fromLeaderToNonMember(m pb.Message) bool {
+	switch m.MsgType {
+	case pb.MessageType_MsgSnapshot, pb.MessageType_MsgAppend, pb.MessageType_MsgHeartbeat:
+		return true
+	}
+	return false
 }
 
 func (r *Raft) softState() *SoftState {
 	return &SoftState{
 		Lead:      r.Lead,
 		RaftState: r.State,
+		Removed:   r.removed,
 	}
 }
 
@@ -701,5 +2002,9 @@ func (r *Raft) hardState() pb.HardState {
 }
 
 func (r *Raft) resetRandomizedElectionTimeout() {
-	r.randomizedElectionTimeout = r.electionTimeout + rand.Intn(r.electionTimeout)
+	if r.rand != nil {
+		r.randomizedElectionTimeout = r.electionTimeout + r.rand.Intn(r.electionTimeout)
+	} else {
+		r.randomizedElectionTimeout = r.electionTimeout + rand.Intn(r.electionTimeout)
+	}
 }
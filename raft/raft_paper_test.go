@@ -147,7 +147,7 @@ func testNonleaderStartElection(t *testing.T, state StateType) {
 	if r.State != StateCandidate {
 		t.Errorf("state = %s, want %s", r.State, StateCandidate)
 	}
-	if !r.votes[r.id] {
+	if r.votes[r.peerIndex(r.id)] != VoteGranted {
 		t.Errorf("vote for self = false, want true")
 	}
 	msgs := r.readMessages()
@@ -417,7 +417,7 @@ func TestLeaderCommitEntry2AB(t *testing.T) {
 		t.Errorf("committed = %d, want %d", g, li+1)
 	}
 	wents := []pb.Entry{{Index: li + 1, Term: 1, Data: []byte("some data")}}
-	if g := r.RaftLog.nextEnts(); !reflect.DeepEqual(g, wents) {
+	if g := mustNextEnts(r.RaftLog); !reflect.DeepEqual(g, wents) {
 		t.Errorf("nextEnts = %+v, want %+v", g, wents)
 	}
 	msgs := r.readMessages()
@@ -501,8 +501,8 @@ func TestLeaderCommitPrecedingEntries2AB(t *testing.T) {
 		}
 
 		li := uint64(len(tt))
-		wents := append(tt, pb.Entry{Term: 3, Index: li + 1}, pb.Entry{Term: 3, Index: li + 2, Data: []byte("some data")})
-		if g := r.RaftLog.nextEnts(); !reflect.DeepEqual(g, wents) {
+		wents := append(tt, pb.Entry{EntryType: pb.EntryType_EntryNoOp, Term: 3, Index: li + 1}, pb.Entry{Term: 3, Index: li + 2, Data: []byte("some data")})
+		if g := mustNextEnts(r.RaftLog); !reflect.DeepEqual(g, wents) {
 			t.Errorf("#%d: ents = %+v, want %+v", i, g, wents)
 		}
 	}
@@ -557,7 +557,7 @@ func TestFollowerCommitEntry2AB(t *testing.T) {
 		for _, ent := range tt.ents[:int(tt.commit)] {
 			wents = append(wents, *ent)
 		}
-		if g := r.RaftLog.nextEnts(); !reflect.DeepEqual(g, wents) {
+		if g := mustNextEnts(r.RaftLog); !reflect.DeepEqual(g, wents) {
 			t.Errorf("#%d: nextEnts = %v, want %v", i, g, wents)
 		}
 	}
@@ -638,7 +638,7 @@ func TestFollowerAppendEntries2AB(t *testing.T) {
 			0, 0, 2,
 			[]*pb.Entry{{Term: 1, Index: 1}},
 			[]*pb.Entry{{Term: 1, Index: 1}, {Term: 2, Index: 2}},
-			[]*pb.Entry{},
+			nil,
 		},
 		{
 			0, 0, 3,
@@ -659,7 +659,7 @@ func TestFollowerAppendEntries2AB(t *testing.T) {
 		for _, ent := range tt.wents {
 			wents = append(wents, *ent)
 		}
-		if g := r.RaftLog.entries; !reflect.DeepEqual(g, wents) {
+		if g := mustAllEntries(r.RaftLog); !reflect.DeepEqual(g, wents) {
 			t.Errorf("#%d: ents = %+v, want %+v", i, g, wents)
 		}
 		var wunstable []pb.Entry
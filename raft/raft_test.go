@@ -16,9 +16,11 @@ package raft
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
 
 	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
@@ -38,11 +40,45 @@ func nextEnts(r *Raft, s *MemoryStorage) (ents []pb.Entry) {
 	s.Append(r.RaftLog.unstableEntries())
 	r.RaftLog.stabled = r.RaftLog.LastIndex()
 
-	ents = r.RaftLog.nextEnts()
+	ents = mustNextEnts(r.RaftLog)
 	r.RaftLog.applied = r.RaftLog.committed
 	return ents
 }
 
+// mustNextEnts returns the next entries of l, which the test storages never
+// fail to read.
+func mustNextEnts(l *RaftLog) []pb.Entry {
+	ents, err := l.nextEnts()
+	if err != nil {
+		panic(err)
+	}
+	return ents
+}
+
+// mustAllEntries returns all the entries of l, see mustNextEnts.
+func mustAllEntries(l *RaftLog) []pb.Entry {
+	ents, err := l.allEntries()
+	if err != nil {
+		panic(err)
+	}
+	return ents
+}
+
+// failingStorage fails to read the entries once fail is set.
+type failingStorage struct {
+	*MemoryStorage
+	fail bool
+}
+
+var errStorageFailed = errors.New("storage failed")
+
+func (s *failingStorage) Entries(lo, hi uint64) ([]pb.Entry, error) {
+	if s.fail {
+		return nil, errStorageFailed
+	}
+	return s.MemoryStorage.Entries(lo, hi)
+}
+
 type stateMachine interface {
 	Step(m pb.Message) error
 	readMessages() []pb.Message
@@ -175,7 +211,7 @@ func TestLeaderElectionOverwriteNewerLogs2AB(t *testing.T) {
 	// term 3 at index 2).
 	for i := range n.peers {
 		sm := n.peers[i].(*Raft)
-		entries := sm.RaftLog.entries
+		entries := mustAllEntries(sm.RaftLog)
 		if len(entries) != 2 {
 			t.Fatalf("node %d: len(entries) == %d, want 2", i, len(entries))
 		}
@@ -318,6 +354,509 @@ func TestSingleNodeCommit2AB(t *testing.T) {
 	}
 }
 
+// TestTickStalled2AA ensures that ticks missed during a stall never start an
+// election by themselves, while a leader still sends its heartbeats.
+func TestTickStalled2AA(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	r.tickStalled(100)
+	if r.State != StateFollower {
+		t.Fatalf("state = %s, want %s", r.State, StateFollower)
+	}
+	if len(r.readMessages()) != 0 {
+		t.Fatalf("unexpected messages after stalled ticks")
+	}
+	// One more regular tick reaches the election timeout.
+	r.tick()
+	if r.State != StateCandidate {
+		t.Fatalf("state = %s, want %s", r.State, StateCandidate)
+	}
+
+	r.becomeLeader()
+	r.readMessages()
+	r.tickStalled(100)
+	msgs := r.readMessages()
+	if len(msgs) != 2 {
+		t.Fatalf("len(msgs) = %d, want %d", len(msgs), 2)
+	}
+	for _, m := range msgs {
+		if m.MsgType != pb.MessageType_MsgHeartbeat {
+			t.Errorf("msg type = %v, want %v", m.MsgType, pb.MessageType_MsgHeartbeat)
+		}
+	}
+}
+
+// TestOptimisticReplicationWaitsForPersist2AB tests that a leader using
+// optimistic replication sends appends before persisting its own entries,
+// but only counts itself towards the quorum once they are stable.
+func TestOptimisticReplicationWaitsForPersist2AB(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg.OptimisticReplication = true
+	r := newRaft(cfg)
+	r.becomeCandidate()
+	r.becomeLeader()
+
+	msgs := r.readMessages()
+	if len(msgs) != 2 {
+		t.Fatalf("len(msgs) = %d, want %d", len(msgs), 2)
+	}
+	for _, m := range msgs {
+		if m.MsgType != pb.MessageType_MsgAppend {
+			t.Errorf("msg type = %v, want %v", m.MsgType, pb.MessageType_MsgAppend)
+		}
+	}
+
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 1})
+	if r.RaftLog.committed != 0 {
+		t.Errorf("committed = %d, want %d", r.RaftLog.committed, 0)
+	}
+
+	r.stableTo(1)
+	if r.RaftLog.committed != 1 {
+		t.Errorf("committed = %d, want %d", r.RaftLog.committed, 1)
+	}
+}
+
+// TestOptimisticReplicationSelfQuorum2AB tests that a leader which is a
+// quorum by itself commits once its own entries are persisted, and releases
+// the uncommitted size of the entries it commits.
+func TestOptimisticReplicationSelfQuorum2AB(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	cfg.OptimisticReplication = true
+	cfg.MaxUncommittedEntriesSize = 10
+	r := newRaft(cfg)
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.stableTo(1)
+
+	propose := func() error {
+		return r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: make([]byte, 10)}}})
+	}
+	for i := uint64(2); i <= 3; i++ {
+		if err := propose(); err != nil {
+			t.Fatalf("#%d: propose error = %v, want nil", i, err)
+		}
+		if r.RaftLog.committed != i-1 {
+			t.Errorf("#%d: committed = %d, want %d", i, r.RaftLog.committed, i-1)
+		}
+		r.stableTo(i)
+		if r.RaftLog.committed != i {
+			t.Errorf("#%d: committed = %d, want %d", i, r.RaftLog.committed, i)
+		}
+	}
+}
+
+// TestEntryChecksums2AB tests that a leader with EntryChecksums checksums the
+// entries it appends, and that a follower drops a MsgAppend carrying a
+// corrupted entry with ErrCorruptEntry.
+func TestEntryChecksums2AB(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	cfg.EntryChecksums = true
+	r := newRaft(cfg)
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("foo")}}})
+	ents := r.RaftLog.unstableEntries()
+	if len(ents) != 2 {
+		t.Fatalf("len(ents) = %d, want 2", len(ents))
+	}
+	for i := range ents {
+		if ents[i].Checksum == 0 || CheckEntry(&ents[i]) != nil {
+			t.Errorf("entry %d has checksum %d, want %d", ents[i].Index, ents[i].Checksum, EntryChecksum(&ents[i]))
+		}
+	}
+
+	f := newTestRaft(2, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	corrupted := ents[1]
+	corrupted.Data = []byte("bar")
+	err := f.Step(pb.Message{From: 1, To: 2, Term: r.Term, MsgType: pb.MessageType_MsgAppend,
+		Entries: []*pb.Entry{&ents[0], &corrupted}})
+	if !errors.Is(err, ErrCorruptEntry) {
+		t.Errorf("err = %v, want %v", err, ErrCorruptEntry)
+	}
+	if last := f.RaftLog.LastIndex(); last != 0 {
+		t.Errorf("last index = %d, want 0", last)
+	}
+}
+
+// TestRaftLogSlice2AB tests that Slice reads the entries across the stable
+// entries of the storage and the ones in memory, bounded by maxSize, and
+// rejects the ranges outside the log.
+func TestRaftLogSlice2AB(t *testing.T) {
+	storage := newMemoryStorageWithEnts([]pb.Entry{{Index: 2, Term: 1}, {Index: 3, Term: 1, Data: []byte("a")},
+		{Index: 4, Term: 2, Data: []byte("a")}, {Index: 5, Term: 2, Data: []byte("a")}})
+	l := newLog(storage)
+	if err := l.truncateAndAppend([]pb.Entry{{Index: 6, Term: 3, Data: []byte("a")},
+		{Index: 7, Term: 3, Data: []byte("a")}, {Index: 8, Term: 3, Data: []byte("a")}}); err != nil {
+		t.Fatal(err)
+	}
+	size := uint64((&pb.Entry{Index: 3, Term: 1, Data: []byte("a")}).Size())
+
+	tests := []struct {
+		lo, hi, maxSize uint64
+
+		wfirst, wlen uint64
+		werr         error
+	}{
+		{2, 4, 0, 0, 0, ErrCompacted},
+		{7, 10, 0, 0, 0, ErrUnavailable},
+		{3, 3, 0, 0, 0, nil},
+		{3, 9, 0, 3, 6, nil},
+		{6, 8, 0, 6, 2, nil},
+		{3, 5, 0, 3, 2, nil},
+		// The budget ends in the storage, in the memory, or before the
+		// first entry, which is returned anyway.
+		{3, 9, 2 * size, 3, 2, nil},
+		{4, 9, 3 * size, 4, 3, nil},
+		{5, 9, size - 1, 5, 1, nil},
+	}
+	for i, tt := range tests {
+		ents, err := l.Slice(tt.lo, tt.hi, tt.maxSize)
+		if err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+			continue
+		}
+		if uint64(len(ents)) != tt.wlen {
+			t.Errorf("#%d: len(ents) = %d, want %d", i, len(ents), tt.wlen)
+			continue
+		}
+		for j, ent := range ents {
+			if ent.Index != tt.wfirst+uint64(j) {
+				t.Errorf("#%d.%d: index = %d, want %d", i, j, ent.Index, tt.wfirst+uint64(j))
+			}
+		}
+	}
+	if _, err := l.Slice(5, 4, 0); err == nil {
+		t.Errorf("err = nil, want an error for an inverted range")
+	}
+}
+
+// TestInvalidMessage2AB tests that appends whose entries don't follow their
+// index, and append responses past the last index of the leader, are
+// dropped with ErrInvalidMessage instead of corrupting the log or panicking.
+func TestInvalidMessage2AB(t *testing.T) {
+	tests := []pb.Message{
+		// A gap after the index.
+		{From: 1, To: 2, Term: 1, MsgType: pb.MessageType_MsgAppend, Index: 0, Entries: []*pb.Entry{{Index: 2, Term: 1}}},
+		// Entries out of order.
+		{From: 1, To: 2, Term: 1, MsgType: pb.MessageType_MsgAppend, Index: 0, Entries: []*pb.Entry{{Index: 1, Term: 1}, {Index: 1, Term: 1}}},
+		// An entry of a term after the one of the append.
+		{From: 1, To: 2, Term: 1, MsgType: pb.MessageType_MsgAppend, Index: 0, Entries: []*pb.Entry{{Index: 1, Term: 2}}},
+		// An entry of a term before the previous one.
+		{From: 1, To: 2, Term: 3, MsgType: pb.MessageType_MsgAppend, Index: 0, Entries: []*pb.Entry{{Index: 1, Term: 2}, {Index: 2, Term: 1}}},
+	}
+	for i, m := range tests {
+		f := newTestRaft(2, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+		if err := f.Step(m); !errors.Is(err, ErrInvalidMessage) {
+			t.Errorf("#%d: err = %v, want %v", i, err, ErrInvalidMessage)
+		}
+		if last := f.RaftLog.LastIndex(); last != 0 {
+			t.Errorf("#%d: last index = %d, want 0", i, last)
+		}
+	}
+
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.readMessages()
+	next := r.Prs[2].Next
+	for _, reject := range []bool{false, true} {
+		err := r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 10, Reject: reject})
+		if !errors.Is(err, ErrInvalidMessage) {
+			t.Errorf("reject %v: err = %v, want %v", reject, err, ErrInvalidMessage)
+		}
+	}
+	if pr := r.Prs[2]; pr.Match != 0 || pr.Next != next {
+		t.Errorf("match, next = %d, %d, want 0, %d", pr.Match, pr.Next, next)
+	}
+}
+
+// TestBatchProposals2AB tests that a leader with BatchProposals sends the
+// proposals it received since the last flush in one MsgAppend per follower.
+func TestBatchProposals2AB(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg.BatchProposals = true
+	r := newRaft(cfg)
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.readMessages()
+	// The followers acknowledge the noop entry.
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 1})
+	r.Step(pb.Message{From: 3, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 1})
+	r.readMessages()
+
+	for i := 0; i < 3; i++ {
+		r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("foo")}}})
+	}
+	if msgs := r.readMessages(); len(msgs) != 0 {
+		t.Fatalf("msgs = %+v, want none before the flush", msgs)
+	}
+
+	r.flushAppends()
+	msgs := r.readMessages()
+	if len(msgs) != 2 {
+		t.Fatalf("len(msgs) = %d, want 2", len(msgs))
+	}
+	for _, m := range msgs {
+		if m.MsgType != pb.MessageType_MsgAppend || len(m.Entries) != 3 {
+			t.Errorf("msg = %+v, want a MsgAppend with 3 entries", m)
+		}
+	}
+	r.flushAppends()
+	if msgs := r.readMessages(); len(msgs) != 0 {
+		t.Errorf("msgs = %+v, want none after a second flush", msgs)
+	}
+}
+
+func TestMaxSizePerMsg2AB(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.readMessages()
+
+	data := make([]byte, 100)
+	for i := 0; i < 10; i++ {
+		r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: data}}})
+	}
+	r.readMessages()
+
+	entSize := uint64((&pb.Entry{Term: 1, Index: 1, Data: data}).Size())
+	r.maxMsgSize = 3 * entSize
+	// Resend the whole log, as after the follower rejected the first append.
+	r.Prs[2].becomeReplicate()
+	next := r.Prs[2].Next
+	r.sendAppend(2)
+	msgs := r.readMessages()
+	// The noop entry and ten proposals, three entries per message at most.
+	if len(msgs) != 4 {
+		t.Fatalf("len(msgs) = %d, want 4", len(msgs))
+	}
+	for i, m := range msgs {
+		if m.Index != next-1 {
+			t.Errorf("#%d: index = %d, want %d", i, m.Index, next-1)
+		}
+		if len(m.Entries) == 0 || len(m.Entries) > 3 {
+			t.Errorf("#%d: len(entries) = %d, want 1-3", i, len(m.Entries))
+		}
+		next += uint64(len(m.Entries))
+	}
+	if next != r.RaftLog.LastIndex()+1 {
+		t.Errorf("messages end at %d, want %d", next-1, r.RaftLog.LastIndex())
+	}
+}
+
+func TestMaxInflightMsgs2AB(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.maxInflight = 2
+	r.becomeCandidate()
+	r.becomeLeader()
+	// The append of the noop entry takes the first slot.
+	r.readMessages()
+
+	propose := func() {
+		r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+	}
+	propose()
+	if msgs := r.readMessages(); len(msgs) != 1 {
+		t.Fatalf("len(msgs) = %d, want 1", len(msgs))
+	}
+	// The window is full, nothing is sent until the follower responds.
+	propose()
+	propose()
+	if msgs := r.readMessages(); len(msgs) != 0 {
+		t.Fatalf("len(msgs) = %d, want 0", len(msgs))
+	}
+
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 2})
+	msgs := r.readMessages()
+	if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgAppend {
+		t.Fatalf("msgs = %+v, want one append", msgs)
+	}
+	if m := msgs[0]; m.Index != 2 || len(m.Entries) != 2 {
+		t.Errorf("append index, len(entries) = %d, %d, want 2, 2", m.Index, len(m.Entries))
+	}
+}
+
+// TestProgressReplicateStreams2AB tests that a follower in the replicate
+// state is sent each entry once: the appends of two proposals don't overlap,
+// although neither is acknowledged yet.
+func TestProgressReplicateStreams2AB(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.readMessages()
+	if st := r.Prs[2].State; st != ProgressStateReplicate {
+		t.Fatalf("state = %s, want %s", st, ProgressStateReplicate)
+	}
+
+	var msgs []pb.Message
+	for i := 0; i < 2; i++ {
+		r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+		msgs = append(msgs, r.readMessages()...)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("len(msgs) = %d, want 2", len(msgs))
+	}
+	for i, m := range msgs {
+		windex := uint64(i + 1)
+		if m.MsgType != pb.MessageType_MsgAppend || m.Index != windex || len(m.Entries) != 1 || m.Entries[0].Index != windex+1 {
+			t.Errorf("#%d: msg = %+v, want an append of entry %d after %d", i, m, windex+1, windex)
+		}
+	}
+	if next := r.Prs[2].Next; next != 4 {
+		t.Errorf("next = %d, want 4", next)
+	}
+}
+
+// TestProgressProbeAfterReject2AB tests that a rejected append moves the
+// follower to the probe state, where a single append is sent at a time, and
+// that the first accepted append moves it back to the replicate state.
+func TestProgressProbeAfterReject2AB(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.readMessages()
+	propose := func() {
+		r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+	}
+	propose()
+	r.readMessages()
+	if st := r.Prs[2].State; st != ProgressStateReplicate {
+		t.Fatalf("state = %s, want %s", st, ProgressStateReplicate)
+	}
+
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 1, Reject: true})
+	if st := r.Prs[2].State; st != ProgressStateProbe {
+		t.Fatalf("state = %s, want %s", st, ProgressStateProbe)
+	}
+	if msgs := r.readMessages(); len(msgs) != 1 || msgs[0].Index != 0 {
+		t.Fatalf("msgs = %+v, want one probe at index 0", msgs)
+	}
+	// Paused until the probe is answered.
+	propose()
+	if msgs := r.readMessages(); len(msgs) != 0 {
+		t.Fatalf("len(msgs) = %d, want 0", len(msgs))
+	}
+
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 2})
+	if st := r.Prs[2].State; st != ProgressStateReplicate {
+		t.Fatalf("state = %s, want %s", st, ProgressStateReplicate)
+	}
+	msgs := r.readMessages()
+	if len(msgs) != 1 || msgs[0].Index != 2 || len(msgs[0].Entries) != 1 {
+		t.Errorf("msgs = %+v, want one append of the held back entry", msgs)
+	}
+}
+
+// TestProgressProbeReelectedLeader2AB tests that a leader elected again
+// follows the rejections of a follower whose log another leader overwrote
+// since its earlier term, below the Match it had then.
+func TestProgressProbeReelectedLeader2AB(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{}}})
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 2})
+
+	// A leader of term 2 overwrote entry 2 of node 2.
+	r.becomeFollower(r.Term+1, 3)
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.readMessages()
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 2, LogTerm: 2, Reject: true})
+	if pr := r.Prs[2]; pr.State != ProgressStateProbe || pr.Next != 2 {
+		t.Fatalf("state, next = %s, %d, want %s, 2", pr.State, pr.Next, ProgressStateProbe)
+	}
+	msgs := r.readMessages()
+	if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgAppend || msgs[0].Index != 1 {
+		t.Errorf("msgs = %+v, want one probe at index 1", msgs)
+	}
+}
+
+// TestAppendResponseFromRemovedPeer2AB tests that the leader ignores the
+// append responses of a peer no longer in the group.
+func TestAppendResponseFromRemovedPeer2AB(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.readMessages()
+	for _, reject := range []bool{false, true} {
+		if err := r.Step(pb.Message{From: 3, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 1, Reject: reject}); err != nil {
+			t.Fatalf("reject %v: err = %v, want nil", reject, err)
+		}
+	}
+	if msgs := r.readMessages(); len(msgs) != 0 {
+		t.Errorf("msgs = %+v, want none", msgs)
+	}
+}
+
+// TestStepStorageError2AB tests that Step returns the error met reading the
+// log while handling a message, once.
+func TestStepStorageError2AB(t *testing.T) {
+	s := &failingStorage{MemoryStorage: newMemoryStorageWithEnts([]pb.Entry{{}, {Index: 1, Term: 1}, {Index: 2, Term: 1}})}
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, s)
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.readMessages()
+
+	s.fail = true
+	// Probing node 2 from index 0 reads the entries back from the storage.
+	m := pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 1, Reject: true}
+	if err := r.Step(m); err != errStorageFailed {
+		t.Fatalf("err = %v, want %v", err, errStorageFailed)
+	}
+	if msgs := r.readMessages(); len(msgs) != 0 {
+		t.Errorf("msgs = %+v, want none", msgs)
+	}
+	s.fail = false
+	if err := r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgHeartbeatResponse}); err != nil {
+		t.Errorf("err = %v, want nil after the error was returned", err)
+	}
+}
+
+// TestProbeBackoff2AB tests that a probe the follower doesn't answer is
+// resent after a number of answered heartbeats doubling up to
+// MaxProbeBackoff, and that answering it resets the backoff.
+func TestProbeBackoff2AB(t *testing.T) {
+	c := newTestConfig(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	c.MaxProbeBackoff = 4
+	r := newRaft(c)
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.readMessages()
+	r.Prs[2].becomeProbe()
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+	if msgs := r.readMessages(); len(msgs) != 1 {
+		t.Fatalf("len(msgs) = %d, want 1", len(msgs))
+	}
+
+	// The heartbeats answered before each resend.
+	var waits []int
+	answered := 0
+	for len(waits) < 5 {
+		r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgHeartbeatResponse})
+		answered++
+		if msgs := r.readMessages(); len(msgs) != 0 {
+			if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgAppend {
+				t.Fatalf("msgs = %+v, want one append", msgs)
+			}
+			waits = append(waits, answered)
+			answered = 0
+		}
+	}
+	if wwaits := []int{1, 2, 3, 5, 5}; !reflect.DeepEqual(waits, wwaits) {
+		t.Errorf("waits = %v, want %v", waits, wwaits)
+	}
+
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 2})
+	r.Prs[2].becomeProbe()
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+	r.readMessages()
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgHeartbeatResponse})
+	if msgs := r.readMessages(); len(msgs) != 1 {
+		t.Errorf("len(msgs) = %d, want the probe resent on the first heartbeat", len(msgs))
+	}
+}
+
 // TestCommitWithoutNewTermEntry tests the entries could be committed
 // when leader changes with noop entry and no new proposal comes in.
 func TestCommitWithoutNewTermEntry2AB(t *testing.T) {
@@ -408,7 +947,7 @@ func TestDuelingCandidates2AB(t *testing.T) {
 	// 3 will be follower again since both 1 and 2 rejects its vote request since 3 does not have a long enough log
 	nt.send(pb.Message{From: 3, To: 3, MsgType: pb.MessageType_MsgHup})
 
-	wlog := newLog(newMemoryStorageWithEnts([]pb.Entry{{}, {Data: nil, Term: 1, Index: 1}}))
+	wlog := newLog(newMemoryStorageWithEnts([]pb.Entry{{}, {EntryType: pb.EntryType_EntryNoOp, Term: 1, Index: 1}}))
 	wlog.committed = 1
 	tests := []struct {
 		sm      *Raft
@@ -465,7 +1004,7 @@ func TestCandidateConcede2AB(t *testing.T) {
 	if g := a.Term; g != 1 {
 		t.Errorf("term = %d, want %d", g, 1)
 	}
-	wlog := newLog(newMemoryStorageWithEnts([]pb.Entry{{}, {Data: nil, Term: 1, Index: 1}, {Term: 1, Index: 2, Data: data}}))
+	wlog := newLog(newMemoryStorageWithEnts([]pb.Entry{{}, {EntryType: pb.EntryType_EntryNoOp, Term: 1, Index: 1}, {Term: 1, Index: 2, Data: data}}))
 	wlog.committed = 2
 	wantLog := ltoa(wlog)
 	for i, p := range tt.peers {
@@ -490,6 +1029,45 @@ func TestSingleNodeCandidate2AA(t *testing.T) {
 	}
 }
 
+// TestWeightedQuorum2AA tests that a node holding more than half of the
+// weight of a WeightedQuorum is elected and commits by itself.
+func TestWeightedQuorum2AA(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	cfg.Quorum = WeightedQuorum{Weights: map[uint64]int{1: 3}}
+	cfg.PreVote = false
+	r := newRaft(cfg)
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	if r.State != StateLeader {
+		t.Fatalf("state = %s, want %s", r.State, StateLeader)
+	}
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("foo")}}})
+	if committed := r.RaftLog.committed; committed != 2 {
+		t.Errorf("committed = %d, want 2", committed)
+	}
+
+	q := WeightedQuorum{Weights: map[uint64]int{1: 2}}
+	voters := []uint64{1, 2, 3, 4}
+	yes, no, none := VoteGranted, VoteRejected, VoteMissing
+	tests := []struct {
+		votes []VoteState
+		want  VoteResult
+	}{
+		{[]VoteState{yes, none, none, none}, VotePending},
+		{[]VoteState{yes, yes, none, none}, VoteWon},
+		{[]VoteState{none, yes, yes, yes}, VoteWon},
+		{[]VoteState{no, yes, yes, none}, VotePending},
+		{[]VoteState{no, no, none, none}, VoteLost},
+	}
+	for i, tt := range tests {
+		if got := q.VoteResult(voters, tt.votes); got != tt.want {
+			t.Errorf("#%d: vote result = %d, want %d", i, got, tt.want)
+		}
+	}
+	if got := q.CommittedIndex(voters, []uint64{5, 1, 2, 9}); got != 5 {
+		t.Errorf("committed index = %d, want 5", got)
+	}
+}
+
 func TestOldMessages2AB(t *testing.T) {
 	tt := newNetwork(nil, nil, nil)
 	// make 0 leader @ term 3
@@ -503,8 +1081,8 @@ func TestOldMessages2AB(t *testing.T) {
 
 	ilog := newLog(
 		newMemoryStorageWithEnts([]pb.Entry{
-			{}, {Data: nil, Term: 1, Index: 1},
-			{Data: nil, Term: 2, Index: 2}, {Data: nil, Term: 3, Index: 3},
+			{}, {EntryType: pb.EntryType_EntryNoOp, Term: 1, Index: 1},
+			{EntryType: pb.EntryType_EntryNoOp, Term: 2, Index: 2}, {EntryType: pb.EntryType_EntryNoOp, Term: 3, Index: 3},
 			{Data: []byte("somedata"), Term: 3, Index: 4},
 		}))
 	ilog.committed = 4
@@ -542,7 +1120,7 @@ func TestProposal2AB(t *testing.T) {
 
 		wantLog := newLog(NewMemoryStorage())
 		if tt.success {
-			wantLog = newLog(newMemoryStorageWithEnts([]pb.Entry{{}, {Data: nil, Term: 1, Index: 1}, {Term: 1, Index: 2, Data: data}}))
+			wantLog = newLog(newMemoryStorageWithEnts([]pb.Entry{{}, {EntryType: pb.EntryType_EntryNoOp, Term: 1, Index: 1}, {Term: 1, Index: 2, Data: data}}))
 			wantLog.committed = 2
 		}
 		base := ltoa(wantLog)
@@ -561,48 +1139,223 @@ func TestProposal2AB(t *testing.T) {
 	}
 }
 
-// TestHandleMessageType_MsgAppend ensures:
-// 1. Reply false if log doesn’t contain an entry at prevLogIndex whose term matches prevLogTerm.
-// 2. If an existing entry conflicts with a new one (same index but different terms),
-//    delete the existing entry and all that follow it; append any new entries not already in the log.
-// 3. If leaderCommit > commitIndex, set commitIndex = min(leaderCommit, index of last new entry).
-func TestHandleMessageType_MsgAppend2AB(t *testing.T) {
-	tests := []struct {
-		m       pb.Message
-		wIndex  uint64
-		wCommit uint64
-		wReject bool
-	}{
-		// Ensure 1
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 3, LogTerm: 3, Index: 2, Commit: 3}, 2, 0, true}, // previous log mismatch
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 3, LogTerm: 3, Index: 3, Commit: 3}, 2, 0, true}, // previous log non-exist
+// TestProposalByProxy2AB tests that a follower forwards proposals to the
+// leader when ForwardProposals is set.
+func TestProposalByProxy2AB(t *testing.T) {
+	data := []byte("somedata")
+	var peers []stateMachine
+	for id := uint64(1); id <= 3; id++ {
+		c := newTestConfig(id, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		c.ForwardProposals = true
+		peers = append(peers, newRaft(c))
+	}
+	tt := newNetwork(peers...)
 
-		// Ensure 2
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 1, Index: 1, Commit: 1}, 2, 1, false},
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 0, Index: 0, Commit: 1, Entries: []*pb.Entry{{Index: 1, Term: 2}}}, 1, 1, false},
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 2, Index: 2, Commit: 3, Entries: []*pb.Entry{{Index: 3, Term: 2}, {Index: 4, Term: 2}}}, 4, 3, false},
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 2, Index: 2, Commit: 4, Entries: []*pb.Entry{{Index: 3, Term: 2}}}, 3, 3, false},
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 1, Index: 1, Commit: 4, Entries: []*pb.Entry{{Index: 2, Term: 2}}}, 2, 2, false},
+	// promote 1 to become leader
+	tt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	// propose via follower
+	tt.send(pb.Message{From: 2, To: 2, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: data}}})
 
-		// Ensure 3
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 1, Index: 1, Commit: 3}, 2, 1, false},                                            // match entry 1, commit up to last new entry 1
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 1, Index: 1, Commit: 3, Entries: []*pb.Entry{{Index: 2, Term: 2}}}, 2, 2, false}, // match entry 1, commit up to last new entry 2
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 2, Index: 2, Commit: 3}, 2, 2, false},                                            // match entry 2, commit up to last new entry 2
-		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 2, Index: 2, Commit: 4}, 2, 2, false},                                            // commit up to log.last()
+	wantLog := newLog(newMemoryStorageWithEnts([]pb.Entry{{}, {EntryType: pb.EntryType_EntryNoOp, Term: 1, Index: 1}, {Term: 1, Index: 2, Data: data}}))
+	wantLog.committed = 2
+	base := ltoa(wantLog)
+	for j, p := range tt.peers {
+		if sm, ok := p.(*Raft); ok {
+			l := ltoa(sm.RaftLog)
+			if g := diffu(base, l); g != "" {
+				t.Errorf("#%d: diff:\n%s", j, g)
+			}
+		}
 	}
+}
 
-	for i, tt := range tests {
-		storage := NewMemoryStorage()
-		storage.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 2}})
-		sm := newTestRaft(1, []uint64{1}, 10, 1, storage)
-		sm.becomeFollower(2, None)
+// TestProposalDropped2AB tests that a proposal a peer can neither append nor
+// forward is rejected with ErrProposalDropped.
+func TestProposalDropped2AB(t *testing.T) {
+	propose := func(r *Raft) error {
+		return r.Step(pb.Message{From: r.id, To: r.id, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+	}
+	c := newTestConfig(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	c.ForwardProposals = true
+	r := newRaft(c)
+	if err := propose(r); err != ErrProposalDropped {
+		t.Errorf("follower without leader: err = %v, want %v", err, ErrProposalDropped)
+	}
+	r.becomeCandidate()
+	if err := propose(r); err != ErrProposalDropped {
+		t.Errorf("candidate: err = %v, want %v", err, ErrProposalDropped)
+	}
 
-		sm.handleAppendEntries(tt.m)
-		if sm.RaftLog.LastIndex() != tt.wIndex {
-			t.Errorf("#%d: lastIndex = %d, want %d", i, sm.RaftLog.LastIndex(), tt.wIndex)
-		}
-		if sm.RaftLog.committed != tt.wCommit {
-			t.Errorf("#%d: committed = %d, want %d", i, sm.RaftLog.committed, tt.wCommit)
+	r = newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	r.becomeFollower(1, 2)
+	if err := propose(r); err != ErrProposalDropped {
+		t.Errorf("follower without forwarding: err = %v, want %v", err, ErrProposalDropped)
+	}
+	if msgs := r.readMessages(); len(msgs) != 0 {
+		t.Errorf("msgs = %+v, want none", msgs)
+	}
+}
+
+// TestProposalTooLarge2AB tests that the leader drops a proposal with an entry
+// larger than MaxEntrySize, and only such a proposal.
+func TestProposalTooLarge2AB(t *testing.T) {
+	c := newTestConfig(1, []uint64{1}, 10, 1, NewMemoryStorage())
+	c.MaxEntrySize = 100
+	r := newRaft(c)
+	r.becomeCandidate()
+	r.becomeLeader()
+	lastIndex := r.RaftLog.LastIndex()
+
+	err := r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("small")}, {Data: make([]byte, 200)}}})
+	if err != ErrProposalTooLarge {
+		t.Errorf("err = %v, want %v", err, ErrProposalTooLarge)
+	}
+	if !errors.Is(err, ErrProposalDropped) {
+		t.Errorf("err = %v, want it to be a %v", err, ErrProposalDropped)
+	}
+	if g := r.RaftLog.LastIndex(); g != lastIndex {
+		t.Errorf("lastIndex = %d, want %d", g, lastIndex)
+	}
+
+	err = r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: make([]byte, 50)}}})
+	if err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+	if g := r.RaftLog.LastIndex(); g != lastIndex+1 {
+		t.Errorf("lastIndex = %d, want %d", g, lastIndex+1)
+	}
+}
+
+// TestMaxUncommittedEntriesSize2AB tests that a leader which can't commit
+// drops the proposals past MaxUncommittedEntriesSize, and accepts them again
+// once the entries are committed.
+func TestMaxUncommittedEntriesSize2AB(t *testing.T) {
+	c := newTestConfig(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	c.MaxUncommittedEntriesSize = 100
+	r := newRaft(c)
+	r.becomeCandidate()
+	r.becomeLeader()
+	propose := func(size int) error {
+		return r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: make([]byte, size)}}})
+	}
+
+	// The first proposal is accepted whatever its size.
+	if err := propose(150); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+	if err := propose(10); err != ErrProposalDropped {
+		t.Fatalf("err = %v, want %v", err, ErrProposalDropped)
+	}
+	// An empty entry doesn't add to the uncommitted size.
+	if err := propose(0); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: r.RaftLog.LastIndex()})
+	if r.RaftLog.committed != r.RaftLog.LastIndex() {
+		t.Fatalf("committed = %d, want %d", r.RaftLog.committed, r.RaftLog.LastIndex())
+	}
+	if r.uncommittedSize != 0 {
+		t.Errorf("uncommittedSize = %d, want 0", r.uncommittedSize)
+	}
+	if err := propose(60); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+	if err := propose(40); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+	if err := propose(1); err != ErrProposalDropped {
+		t.Errorf("err = %v, want %v", err, ErrProposalDropped)
+	}
+}
+
+// TestDedupProposals2AB tests that the leader collapses the retries of a
+// proposal while its entry is uncommitted, and only then.
+func TestDedupProposals2AB(t *testing.T) {
+	c := newTestConfig(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	c.DedupProposals = true
+	r := newRaft(c)
+	r.becomeCandidate()
+	r.becomeLeader()
+	propose := func(ents ...*pb.Entry) {
+		if err := r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: ents}); err != nil {
+			t.Fatalf("err = %v, want nil", err)
+		}
+	}
+
+	propose(&pb.Entry{Data: []byte("a"), RequestId: []byte("1")})
+	propose(&pb.Entry{Data: []byte("a"), RequestId: []byte("1")}, &pb.Entry{Data: []byte("b"), RequestId: []byte("2")},
+		&pb.Entry{Data: []byte("b"), RequestId: []byte("2")}, &pb.Entry{Data: []byte("c")}, &pb.Entry{Data: []byte("c")})
+	var got []string
+	for _, ent := range mustAllEntries(r.RaftLog)[1:] {
+		got = append(got, string(ent.Data))
+	}
+	if want := []string{"a", "b", "c", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("entries = %v, want %v", got, want)
+	}
+
+	// Nothing is appended for a proposal of retries only, the proposer is
+	// told so.
+	lastIndex := r.RaftLog.LastIndex()
+	err := r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("b"), RequestId: []byte("2")}}})
+	if err != ErrProposalDuplicate {
+		t.Errorf("err = %v, want %v", err, ErrProposalDuplicate)
+	}
+	if g := r.RaftLog.LastIndex(); g != lastIndex {
+		t.Errorf("lastIndex = %d, want %d", g, lastIndex)
+	}
+
+	// Once committed, the request may be proposed again.
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: r.RaftLog.LastIndex()})
+	lastIndex = r.RaftLog.LastIndex()
+	propose(&pb.Entry{Data: []byte("a"), RequestId: []byte("1")})
+	if g := r.RaftLog.LastIndex(); g != lastIndex+1 {
+		t.Errorf("lastIndex = %d, want %d", g, lastIndex+1)
+	}
+}
+
+// TestHandleMessageType_MsgAppend ensures:
+// 1. Reply false if log doesn’t contain an entry at prevLogIndex whose term matches prevLogTerm.
+// 2. If an existing entry conflicts with a new one (same index but different terms),
+//    delete the existing entry and all that follow it; append any new entries not already in the log.
+// 3. If leaderCommit > commitIndex, set commitIndex = min(leaderCommit, index of last new entry).
+func TestHandleMessageType_MsgAppend2AB(t *testing.T) {
+	tests := []struct {
+		m       pb.Message
+		wIndex  uint64
+		wCommit uint64
+		wReject bool
+	}{
+		// Ensure 1
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 3, LogTerm: 3, Index: 2, Commit: 3}, 2, 0, true}, // previous log mismatch
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 3, LogTerm: 3, Index: 3, Commit: 3}, 2, 0, true}, // previous log non-exist
+
+		// Ensure 2
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 1, Index: 1, Commit: 1}, 2, 1, false},
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 0, Index: 0, Commit: 1, Entries: []*pb.Entry{{Index: 1, Term: 2}}}, 1, 1, false},
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 2, Index: 2, Commit: 3, Entries: []*pb.Entry{{Index: 3, Term: 2}, {Index: 4, Term: 2}}}, 4, 3, false},
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 2, Index: 2, Commit: 4, Entries: []*pb.Entry{{Index: 3, Term: 2}}}, 3, 3, false},
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 1, Index: 1, Commit: 4, Entries: []*pb.Entry{{Index: 2, Term: 2}}}, 2, 2, false},
+
+		// Ensure 3
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 1, Index: 1, Commit: 3}, 2, 1, false},                                            // match entry 1, commit up to last new entry 1
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 1, Index: 1, Commit: 3, Entries: []*pb.Entry{{Index: 2, Term: 2}}}, 2, 2, false}, // match entry 1, commit up to last new entry 2
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 2, Index: 2, Commit: 3}, 2, 2, false},                                            // match entry 2, commit up to last new entry 2
+		{pb.Message{MsgType: pb.MessageType_MsgAppend, Term: 2, LogTerm: 2, Index: 2, Commit: 4}, 2, 2, false},                                            // commit up to log.last()
+	}
+
+	for i, tt := range tests {
+		storage := NewMemoryStorage()
+		storage.Append([]pb.Entry{{Index: 1, Term: 1}, {Index: 2, Term: 2}})
+		sm := newTestRaft(1, []uint64{1}, 10, 1, storage)
+		sm.becomeFollower(2, None)
+
+		sm.handleAppendEntries(tt.m)
+		if sm.RaftLog.LastIndex() != tt.wIndex {
+			t.Errorf("#%d: lastIndex = %d, want %d", i, sm.RaftLog.LastIndex(), tt.wIndex)
+		}
+		if sm.RaftLog.committed != tt.wCommit {
+			t.Errorf("#%d: committed = %d, want %d", i, sm.RaftLog.committed, tt.wCommit)
 		}
 		m := sm.readMessages()
 		if len(m) != 1 {
@@ -733,8 +1486,8 @@ func TestAllServerStepdown2AB(t *testing.T) {
 			if sm.RaftLog.LastIndex() != tt.windex {
 				t.Errorf("#%d.%d index = %v , want %v", i, j, sm.RaftLog.LastIndex(), tt.windex)
 			}
-			if uint64(len(sm.RaftLog.entries)) != tt.windex {
-				t.Errorf("#%d.%d len(ents) = %v , want %v", i, j, len(sm.RaftLog.entries), tt.windex)
+			if ents := mustAllEntries(sm.RaftLog); uint64(len(ents)) != tt.windex {
+				t.Errorf("#%d.%d len(ents) = %v , want %v", i, j, len(ents), tt.windex)
 			}
 			wlead := uint64(2)
 			if msgType == pb.MessageType_MsgRequestVote {
@@ -809,6 +1562,140 @@ func testCandidateResetTerm(t *testing.T, mt pb.MessageType) {
 	}
 }
 
+// TestPreVoteIsolatedNode2AA tests that a partitioned node running pre-votes
+// does not bump its term, and does not disrupt the leader when it rejoins
+// with a log which fell behind.
+func TestPreVoteIsolatedNode2AA(t *testing.T) {
+	nt := newNetworkWithConfig(func(c *Config) { c.PreVote = true }, nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+
+	n1 := nt.peers[1].(*Raft)
+	n3 := nt.peers[3].(*Raft)
+	if n1.State != StateLeader {
+		t.Fatalf("node 1 state = %s, want %s", n1.State, StateLeader)
+	}
+	term := n1.Term
+
+	nt.isolate(3)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+	for i := 0; i < 5; i++ {
+		nt.send(pb.Message{From: 3, To: 3, MsgType: pb.MessageType_MsgHup})
+	}
+	if n3.Term != term {
+		t.Errorf("isolated node term = %d, want %d", n3.Term, term)
+	}
+	if n3.State != StatePreCandidate {
+		t.Errorf("isolated node state = %s, want %s", n3.State, StatePreCandidate)
+	}
+
+	nt.recover()
+	nt.send(pb.Message{From: 3, To: 3, MsgType: pb.MessageType_MsgHup})
+	if n1.State != StateLeader || n1.Term != term {
+		t.Errorf("node 1 state, term = %s, %d, want %s, %d", n1.State, n1.Term, StateLeader, term)
+	}
+	if n3.Term != term {
+		t.Errorf("rejoined node term = %d, want %d", n3.Term, term)
+	}
+}
+
+// TestPreVoteElection2AA tests that nodes running pre-votes still elect a
+// leader, in the term following the pre-vote.
+func TestPreVoteElection2AA(t *testing.T) {
+	nt := newNetworkWithConfig(func(c *Config) { c.PreVote = true }, nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+
+	n1 := nt.peers[1].(*Raft)
+	if n1.State != StateLeader || n1.Term != 1 {
+		t.Errorf("state, term = %s, %d, want %s, 1", n1.State, n1.Term, StateLeader)
+	}
+}
+
+func TestReadIndex2AB(t *testing.T) {
+	nt := newNetwork(nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+
+	n1 := nt.peers[1].(*Raft)
+	n2 := nt.peers[2].(*Raft)
+	tests := []struct {
+		sm  *Raft
+		ctx string
+	}{
+		// Served by the leader itself.
+		{n1, "ctx1"},
+		// Forwarded to the leader by a follower.
+		{n2, "ctx2"},
+	}
+	for i, tt := range tests {
+		nt.send(pb.Message{From: tt.sm.id, To: tt.sm.id, MsgType: pb.MessageType_MsgReadIndex, Entries: []*pb.Entry{{Data: []byte(tt.ctx)}}})
+		if len(tt.sm.readStates) != 1 {
+			t.Fatalf("#%d: len(readStates) = %d, want 1", i, len(tt.sm.readStates))
+		}
+		rs := tt.sm.readStates[0]
+		if rs.Index != n1.RaftLog.committed {
+			t.Errorf("#%d: readIndex = %d, want %d", i, rs.Index, n1.RaftLog.committed)
+		}
+		if string(rs.RequestCtx) != tt.ctx {
+			t.Errorf("#%d: requestCtx = %s, want %s", i, rs.RequestCtx, tt.ctx)
+		}
+		tt.sm.readStates = nil
+	}
+
+	// An isolated leader can't confirm its leadership, so the read is held.
+	nt.isolate(1)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgReadIndex, Entries: []*pb.Entry{{Data: []byte("ctx3")}}})
+	if len(n1.readStates) != 0 {
+		t.Errorf("isolated leader released read states %v", n1.readStates)
+	}
+}
+
+// TestForwardedReadIndex2AB tests that a follower keeps the read index
+// requests it forwarded until they are answered: it forwards them again to a
+// new leader, serves them itself if it is elected, and ignores stale answers.
+func TestForwardedReadIndex2AB(t *testing.T) {
+	nt := newNetwork(nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	n2 := nt.peers[2].(*Raft)
+	n3 := nt.peers[3].(*Raft)
+
+	// The leader loses the request, the next leader answers it.
+	nt.ignore(pb.MessageType_MsgReadIndex)
+	nt.send(pb.Message{From: 2, To: 2, MsgType: pb.MessageType_MsgReadIndex, Entries: []*pb.Entry{{Data: []byte("ctx1")}}})
+	if len(n2.readStates) != 0 {
+		t.Fatalf("readStates = %v, want none", n2.readStates)
+	}
+	nt.recover()
+	nt.send(pb.Message{From: 3, To: 3, MsgType: pb.MessageType_MsgHup})
+	if n3.State != StateLeader {
+		t.Fatalf("node 3 state = %s, want %s", n3.State, StateLeader)
+	}
+	if len(n2.readStates) != 1 || string(n2.readStates[0].RequestCtx) != "ctx1" {
+		t.Fatalf("readStates = %v, want ctx1", n2.readStates)
+	}
+	if n2.readStates[0].Index != n3.RaftLog.committed {
+		t.Errorf("readIndex = %d, want %d", n2.readStates[0].Index, n3.RaftLog.committed)
+	}
+	n2.readStates = nil
+
+	// The request was answered, another answer is stale.
+	n2.Step(pb.Message{From: 3, To: 2, Term: n2.Term, MsgType: pb.MessageType_MsgReadIndexResp, Index: 100, Entries: []*pb.Entry{{Data: []byte("ctx1")}}})
+	if len(n2.readStates) != 0 {
+		t.Errorf("readStates = %v, want none", n2.readStates)
+	}
+
+	// The follower which forwarded the request is elected and serves it.
+	nt.ignore(pb.MessageType_MsgReadIndex)
+	nt.send(pb.Message{From: 2, To: 2, MsgType: pb.MessageType_MsgReadIndex, Entries: []*pb.Entry{{Data: []byte("ctx2")}}})
+	nt.recover()
+	nt.send(pb.Message{From: 2, To: 2, MsgType: pb.MessageType_MsgHup})
+	if n2.State != StateLeader {
+		t.Fatalf("node 2 state = %s, want %s", n2.State, StateLeader)
+	}
+	if len(n2.readStates) != 1 || string(n2.readStates[0].RequestCtx) != "ctx2" {
+		t.Fatalf("readStates = %v, want ctx2", n2.readStates)
+	}
+}
+
 // TestDisruptiveFollower tests isolated follower,
 // with slow network incoming from leader, election times out
 // to become a candidate with an increased term. Then, the
@@ -946,6 +1833,71 @@ func TestHeartbeatUpdateCommit2AB(t *testing.T) {
 	}
 }
 
+// TestHeartbeatCarriesCommit2AB tests that a heartbeat carries the commit
+// index up to the entries the follower is known to match, and that the
+// follower commits up to it without waiting for the next append.
+func TestHeartbeatCarriesCommit2AB(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{}}})
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 2})
+	r.Step(pb.Message{From: 3, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 1})
+	if r.RaftLog.committed != 2 {
+		t.Fatalf("committed = %d, want 2", r.RaftLog.committed)
+	}
+	r.readMessages()
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgBeat})
+	for _, m := range r.readMessages() {
+		wcommit := map[uint64]uint64{2: 2, 3: 1}[m.To]
+		if m.MsgType != pb.MessageType_MsgHeartbeat || m.Commit != wcommit {
+			t.Errorf("to %d: %s with commit %d, want %s with commit %d", m.To, m.MsgType, m.Commit, pb.MessageType_MsgHeartbeat, wcommit)
+		}
+	}
+
+	storage := NewMemoryStorage()
+	storage.Append([]pb.Entry{{Term: 1, Index: 1}, {Term: 1, Index: 2}, {Term: 1, Index: 3}})
+	f := newTestRaft(2, []uint64{1, 2}, 10, 1, storage)
+	f.becomeFollower(1, 1)
+	f.Step(pb.Message{From: 1, To: 2, Term: 1, MsgType: pb.MessageType_MsgHeartbeat, Commit: 2})
+	if f.RaftLog.committed != 2 {
+		t.Errorf("committed = %d, want 2", f.RaftLog.committed)
+	}
+	// The follower doesn't commit past its log.
+	f.Step(pb.Message{From: 1, To: 2, Term: 1, MsgType: pb.MessageType_MsgHeartbeat, Commit: 5})
+	if f.RaftLog.committed != 3 {
+		t.Errorf("committed = %d, want 3", f.RaftLog.committed)
+	}
+}
+
+// TestHeartbeatReelectedLeader2AB tests that a leader elected again doesn't
+// send the commit index up to the Match of its earlier term: another leader
+// may have overwritten those entries of the follower meanwhile.
+func TestHeartbeatReelectedLeader2AB(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{}}})
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 2})
+	if r.RaftLog.committed != 2 {
+		t.Fatalf("committed = %d, want 2", r.RaftLog.committed)
+	}
+
+	r.becomeFollower(r.Term+1, 3)
+	r.becomeCandidate()
+	r.becomeLeader()
+	if pr := r.Prs[2]; pr.Match != 0 {
+		t.Errorf("match = %d, want 0", pr.Match)
+	}
+	r.readMessages()
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgBeat})
+	for _, m := range r.readMessages() {
+		if m.MsgType != pb.MessageType_MsgHeartbeat || m.Commit != 0 {
+			t.Errorf("to %d: %s with commit %d, want %s with commit 0", m.To, m.MsgType, m.Commit, pb.MessageType_MsgHeartbeat)
+		}
+	}
+}
+
 // tests the output of the state machine when receiving MessageType_MsgBeat
 func TestRecvMessageType_MsgBeat2AA(t *testing.T) {
 	tests := []struct {
@@ -1076,6 +2028,227 @@ func TestProvideSnap2C(t *testing.T) {
 	}
 }
 
+// TestRebuildPeer2C tests that a rebuilt follower is sent a snapshot it
+// installs even though its log already covers the snapshot.
+func TestRebuildPeer2C(t *testing.T) {
+	s := pb.Snapshot{
+		Metadata: &pb.SnapshotMetadata{
+			Index:     11,
+			Term:      11,
+			ConfState: &pb.ConfState{Nodes: []uint64{1, 2}},
+		},
+	}
+	storage := NewMemoryStorage()
+	storage.ApplySnapshot(s)
+	leader := newTestRaft(1, []uint64{1, 2}, 10, 1, storage)
+	leader.becomeCandidate()
+	leader.becomeLeader()
+	leader.Prs[2].Match = leader.RaftLog.LastIndex()
+	leader.Prs[2].Next = leader.RaftLog.LastIndex() + 1
+	leader.readMessages()
+
+	leader.rebuildPeer(2)
+	msgs := leader.readMessages()
+	if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgSnapshot || !msgs[0].Rebuild {
+		t.Fatalf("msgs = %+v, want a rebuild snapshot", msgs)
+	}
+	if pr := leader.Prs[2]; pr.Match != 0 || pr.PendingSnapshot != 11 || pr.Rebuild {
+		t.Errorf("progress = %+v, want match 0, pending snapshot 11", *pr)
+	}
+
+	follower := newTestRaft(2, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	follower.becomeFollower(leader.Term, 1)
+	for i := uint64(1); i <= 13; i++ {
+		follower.RaftLog.entries = append(follower.RaftLog.entries, pb.Entry{Index: i, Term: 11})
+	}
+	follower.RaftLog.committed = 13
+	follower.handleSnapshot(msgs[0])
+	if follower.RaftLog.pendingSnapshot == nil {
+		t.Fatalf("rebuild snapshot is ignored")
+	}
+	if follower.RaftLog.LastIndex() != 11 || follower.RaftLog.committed != 11 {
+		t.Errorf("last index, committed = %d, %d, want 11, 11", follower.RaftLog.LastIndex(), follower.RaftLog.committed)
+	}
+}
+
+// TestPendingSnapshotPausesAppend2C tests that no entries are sent to a peer
+// with a snapshot in flight, until the peer acknowledges the snapshot.
+func TestPendingSnapshotPausesAppend2C(t *testing.T) {
+	storage := NewMemoryStorage()
+	storage.ApplySnapshot(pb.Snapshot{
+		Metadata: &pb.SnapshotMetadata{
+			Index:     11,
+			Term:      11,
+			ConfState: &pb.ConfState{Nodes: []uint64{1, 2}},
+		},
+	})
+	sm := newTestRaft(1, []uint64{1, 2}, 10, 1, storage)
+	sm.becomeCandidate()
+	sm.becomeLeader()
+	sm.readMessages()
+
+	sm.Prs[2].Next = 10
+	sm.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+	msgs := sm.readMessages()
+	if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgSnapshot {
+		t.Fatalf("msgs = %+v, want a single snapshot", msgs)
+	}
+	if sm.Prs[2].PendingSnapshot != 11 {
+		t.Errorf("PendingSnapshot = %d, want 11", sm.Prs[2].PendingSnapshot)
+	}
+
+	sm.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+	if msgs := sm.readMessages(); len(msgs) != 0 {
+		t.Errorf("msgs = %+v, want none while the snapshot is pending", msgs)
+	}
+
+	sm.Step(pb.Message{From: 2, To: 1, Term: sm.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: 11})
+	if sm.Prs[2].PendingSnapshot != 0 {
+		t.Errorf("PendingSnapshot = %d, want 0", sm.Prs[2].PendingSnapshot)
+	}
+	msgs = sm.readMessages()
+	if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgAppend || msgs[0].Index != 11 {
+		t.Errorf("msgs = %+v, want an append after index 11", msgs)
+	}
+}
+
+// TestSnapshotStatus2C tests that a reported snapshot moves the peer to
+// probe, from the snapshot on if it was delivered, and that the leader waits
+// for a heartbeat response before probing.
+func TestSnapshotStatus2C(t *testing.T) {
+	tests := []struct {
+		status   SnapshotStatus
+		wantNext uint64
+	}{
+		{SnapshotFinish, 12},
+		{SnapshotFailure, 1},
+	}
+	for i, tt := range tests {
+		storage := NewMemoryStorage()
+		storage.ApplySnapshot(pb.Snapshot{
+			Metadata: &pb.SnapshotMetadata{
+				Index:     11,
+				Term:      11,
+				ConfState: &pb.ConfState{Nodes: []uint64{1, 2}},
+			},
+		})
+		rn, err := NewRawNode(newTestConfig(1, []uint64{1, 2}, 10, 1, storage))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sm := rn.Raft
+		sm.becomeCandidate()
+		sm.becomeLeader()
+		sm.readMessages()
+
+		sm.Prs[2].Next = 10
+		sm.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+		if msgs := sm.readMessages(); len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgSnapshot {
+			t.Fatalf("#%d: msgs = %+v, want a single snapshot", i, msgs)
+		}
+
+		rn.ReportSnapshot(2, tt.status)
+		pr := sm.Prs[2]
+		if pr.State != ProgressStateProbe || pr.Next != tt.wantNext || !pr.Paused {
+			t.Errorf("#%d: progress = %s, want paused probe from %d", i, pr, tt.wantNext)
+		}
+		if msgs := sm.readMessages(); len(msgs) != 0 {
+			t.Errorf("#%d: msgs = %+v, want none", i, msgs)
+		}
+
+		sm.Step(pb.Message{From: 2, To: 1, Term: sm.Term, MsgType: pb.MessageType_MsgHeartbeatResponse})
+		msgs := sm.readMessages()
+		if len(msgs) != 1 {
+			t.Fatalf("#%d: msgs = %+v, want one message", i, msgs)
+		}
+		wantType := pb.MessageType_MsgAppend
+		if tt.status == SnapshotFailure {
+			wantType = pb.MessageType_MsgSnapshot
+		}
+		if msgs[0].MsgType != wantType {
+			t.Errorf("#%d: msg type = %s, want %s", i, msgs[0].MsgType, wantType)
+		}
+	}
+}
+
+// TestSnapshotFailure2C tests that a follower discards a snapshot failing
+// validation and tells the leader, which sends a new snapshot, rebuilding the
+// follower again if the failed snapshot did.
+func TestSnapshotFailure2C(t *testing.T) {
+	s := pb.Snapshot{
+		Metadata: &pb.SnapshotMetadata{
+			Index:     11,
+			Term:      11,
+			ConfState: &pb.ConfState{Nodes: []uint64{1, 2}},
+		},
+	}
+	storage := NewMemoryStorage()
+	storage.ApplySnapshot(s)
+	leader := newTestRaft(1, []uint64{1, 2}, 10, 1, storage)
+	leader.becomeCandidate()
+	leader.becomeLeader()
+	leader.readMessages()
+	leader.rebuildPeer(2)
+	msgs := leader.readMessages()
+	if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgSnapshot {
+		t.Fatalf("msgs = %+v, want a snapshot", msgs)
+	}
+
+	cfg := newTestConfig(2, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	cfg.ValidateSnapshot = func(*pb.Snapshot) error { return errors.New("corrupted") }
+	follower := newRaft(cfg)
+	follower.becomeFollower(leader.Term, 1)
+	follower.Step(msgs[0])
+	if follower.RaftLog.pendingSnapshot != nil || follower.RaftLog.committed != 0 {
+		t.Fatalf("snapshot failing validation is restored")
+	}
+	msgs = follower.readMessages()
+	if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgSnapStatus || !msgs[0].Reject {
+		t.Fatalf("msgs = %+v, want a snapshot rejection", msgs)
+	}
+
+	leader.Step(msgs[0])
+	pr := leader.Prs[2]
+	if pr.State != ProgressStateProbe || !pr.NeedSnapshot || !pr.Rebuild {
+		t.Fatalf("progress = %s, want a probe needing a rebuild snapshot", pr)
+	}
+	leader.Step(pb.Message{From: 2, To: 1, Term: leader.Term, MsgType: pb.MessageType_MsgHeartbeatResponse})
+	msgs = leader.readMessages()
+	if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgSnapshot || !msgs[0].Rebuild {
+		t.Fatalf("msgs = %+v, want a new rebuild snapshot", msgs)
+	}
+	if pr.NeedSnapshot || pr.Rebuild || pr.PendingSnapshot != 11 {
+		t.Errorf("progress = %s, want pending snapshot 11", pr)
+	}
+}
+
+// TestReportUnreachable2AB tests that an unreachable peer is probed instead of
+// being sent every entry.
+func TestReportUnreachable2AB(t *testing.T) {
+	rn, err := NewRawNode(newTestConfig(1, []uint64{1, 2}, 10, 1, NewMemoryStorage()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := rn.Raft
+	sm.becomeCandidate()
+	sm.becomeLeader()
+	sm.readMessages()
+	if sm.Prs[2].State != ProgressStateReplicate {
+		t.Fatalf("state = %s, want %s", sm.Prs[2].State, ProgressStateReplicate)
+	}
+
+	rn.ReportUnreachable(2)
+	if sm.Prs[2].State != ProgressStateProbe {
+		t.Fatalf("state = %s, want %s", sm.Prs[2].State, ProgressStateProbe)
+	}
+	for i := 0; i < 3; i++ {
+		sm.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{Data: []byte("somedata")}}})
+	}
+	if msgs := sm.readMessages(); len(msgs) != 1 {
+		t.Errorf("len(msgs) = %d, want a single probe", len(msgs))
+	}
+}
+
 func TestRestoreFromSnapMsg2C(t *testing.T) {
 	s := pb.Snapshot{
 		Metadata: &pb.SnapshotMetadata{
@@ -1094,6 +2267,79 @@ func TestRestoreFromSnapMsg2C(t *testing.T) {
 	}
 }
 
+// TestRestoreFromSnapMsgNonMember2C tests that a peer which starts with an
+// empty ConfState, as one created for a conf change does, accepts the
+// leader's snapshot and joins the group it describes.
+func TestRestoreFromSnapMsgNonMember2C(t *testing.T) {
+	s := pb.Snapshot{
+		Metadata: &pb.SnapshotMetadata{
+			Index:     11, // magic number
+			Term:      11, // magic number
+			ConfState: &pb.ConfState{Nodes: []uint64{1, 2, 3}},
+		},
+	}
+	sm := newTestRaft(3, nil, 10, 1, NewMemoryStorage())
+	if len(sm.Prs) != 0 {
+		t.Fatalf("len(Prs) = %d, want 0", len(sm.Prs))
+	}
+
+	sm.Step(pb.Message{MsgType: pb.MessageType_MsgHeartbeat, From: 1, To: 3, Term: 2})
+	if sm.Lead != 1 {
+		t.Errorf("sm.Lead = %d, want 1", sm.Lead)
+	}
+	sm.readMessages()
+
+	sm.Step(pb.Message{MsgType: pb.MessageType_MsgSnapshot, From: 1, To: 3, Term: 2, Snapshot: &s})
+	if sm.RaftLog.LastIndex() != 11 || sm.RaftLog.committed != 11 {
+		t.Errorf("lastIndex, committed = %d, %d, want 11", sm.RaftLog.LastIndex(), sm.RaftLog.committed)
+	}
+	if sg := nodes(sm); !reflect.DeepEqual(sg, s.Metadata.ConfState.Nodes) {
+		t.Errorf("sm.Nodes = %+v, want %+v", sg, s.Metadata.ConfState.Nodes)
+	}
+	msgs := sm.readMessages()
+	if len(msgs) != 1 || msgs[0].MsgType != pb.MessageType_MsgAppendResponse || msgs[0].Reject || msgs[0].Index != 11 {
+		t.Errorf("msgs = %+v, want an accepted append response at 11", msgs)
+	}
+
+	// A non-member does not take part in elections.
+	sm2 := newTestRaft(3, nil, 10, 1, NewMemoryStorage())
+	sm2.Step(pb.Message{MsgType: pb.MessageType_MsgRequestVote, From: 1, To: 3, Term: 2})
+	if msgs := sm2.readMessages(); len(msgs) != 0 {
+		t.Errorf("msgs = %+v, want none", msgs)
+	}
+}
+
+func TestSnapshotResponse2C(t *testing.T) {
+	s := pb.Snapshot{
+		Metadata: &pb.SnapshotMetadata{
+			Index:     11,
+			Term:      11,
+			ConfState: &pb.ConfState{Nodes: []uint64{1, 2}},
+		},
+	}
+	sm := newTestRaft(2, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	sm.Step(pb.Message{MsgType: pb.MessageType_MsgSnapshot, From: 1, To: 2, Term: 2, Snapshot: &s})
+
+	msgs := sm.readMessages()
+	if len(msgs) != 1 {
+		t.Fatalf("len(msgs) = %d, want 1", len(msgs))
+	}
+	if m := msgs[0]; m.MsgType != pb.MessageType_MsgAppendResponse || m.Reject || m.Index != 11 {
+		t.Errorf("msg = %+v, want an accepted append response at 11", m)
+	}
+	if sm.RaftLog.committed != 11 || sm.RaftLog.applied != 11 || sm.RaftLog.stabled != 11 {
+		t.Errorf("committed, applied, stabled = %d, %d, %d, want 11",
+			sm.RaftLog.committed, sm.RaftLog.applied, sm.RaftLog.stabled)
+	}
+
+	// A snapshot covered by the log is ignored.
+	sm.Step(pb.Message{MsgType: pb.MessageType_MsgSnapshot, From: 1, To: 2, Term: 2, Snapshot: &s})
+	msgs = sm.readMessages()
+	if len(msgs) != 1 || msgs[0].Index != 11 {
+		t.Errorf("msgs = %+v, want an append response at the committed index", msgs)
+	}
+}
+
 func TestSlowNodeRestore2C(t *testing.T) {
 	nt := newNetwork(nil, nil, nil)
 	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
@@ -1154,6 +2400,65 @@ func TestRemoveNode3A(t *testing.T) {
 	}
 }
 
+// TestRemoveSelf3A tests that a leader removing itself steps down, forgets
+// the other nodes and no longer sends heartbeats or starts elections.
+func TestRemoveSelf3A(t *testing.T) {
+	r := newTestRaft(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	r.becomeCandidate()
+	r.becomeLeader()
+	r.removeNode(1)
+	if r.State != StateFollower {
+		t.Errorf("state = %s, want %s", r.State, StateFollower)
+	}
+	if len(r.Prs) != 0 {
+		t.Errorf("len(Prs) = %d, want 0", len(r.Prs))
+	}
+	r.readMessages()
+
+	r.Step(pb.Message{MsgType: pb.MessageType_MsgBeat})
+	r.Step(pb.Message{MsgType: pb.MessageType_MsgHup})
+	for i := 0; i < 2*r.electionTimeout; i++ {
+		r.tick()
+	}
+	if r.State != StateFollower {
+		t.Errorf("state = %s, want %s", r.State, StateFollower)
+	}
+	if msgs := r.readMessages(); len(msgs) != 0 {
+		t.Errorf("msgs = %v, want none", msgs)
+	}
+}
+
+// TestPeerOrder2AA tests that the peers are iterated in ascending order, and
+// that the votes stay aligned with them across membership changes.
+func TestPeerOrder2AA(t *testing.T) {
+	cfg := newTestConfig(1, []uint64{5, 3, 1, 4, 2}, 10, 1, NewMemoryStorage())
+	cfg.PreVote = false
+	r := newRaft(cfg)
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	var to []uint64
+	for _, m := range r.readMessages() {
+		to = append(to, m.To)
+	}
+	if wto := []uint64{2, 3, 4, 5}; !reflect.DeepEqual(to, wto) {
+		t.Errorf("vote requests to %v, want %v", to, wto)
+	}
+
+	r.Step(pb.Message{From: 4, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgRequestVoteResponse, Reject: true})
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgRequestVoteResponse})
+	yes, no, none := VoteGranted, VoteRejected, VoteMissing
+	if w := []VoteState{yes, yes, none, no, none}; !reflect.DeepEqual(r.votes, w) {
+		t.Errorf("votes = %v, want %v", r.votes, w)
+	}
+	r.addNode(6)
+	r.removeNode(3)
+	if w := []uint64{1, 2, 4, 5, 6}; !reflect.DeepEqual(r.peerIDs, w) {
+		t.Errorf("peers = %v, want %v", r.peerIDs, w)
+	}
+	if w := []VoteState{yes, yes, no, none, none}; !reflect.DeepEqual(r.votes, w) {
+		t.Errorf("votes = %v, want %v", r.votes, w)
+	}
+}
+
 func TestCampaignWhileLeader2AA(t *testing.T) {
 	cfg := newTestConfig(1, []uint64{1}, 5, 1, NewMemoryStorage())
 	r := newRaft(cfg)
@@ -1225,7 +2530,7 @@ func TestCommitAfterRemoveNode3A(t *testing.T) {
 	if len(ents) != 2 {
 		t.Fatalf("expected two committed entries, got %v", ents)
 	}
-	if ents[0].EntryType != pb.EntryType_EntryNormal || ents[0].Data != nil {
+	if ents[0].EntryType != pb.EntryType_EntryNoOp || ents[0].Data != nil {
 		t.Fatalf("expected ents[0] to be empty, but got %v", ents[0])
 	}
 	if ents[1].EntryType != pb.EntryType_EntryConfChange {
@@ -1242,6 +2547,92 @@ func TestCommitAfterRemoveNode3A(t *testing.T) {
 	}
 }
 
+// TestGracefulStop3A tests that a stopping leader hands its leadership over
+// to the most up-to-date follower, after catching it up if needed, and drops
+// proposals meanwhile.
+func TestGracefulStop3A(t *testing.T) {
+	nt := newNetwork(nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	lead := nt.peers[1].(*Raft)
+
+	nt.isolate(3)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{}}})
+	nt.recover()
+	if lead.gracefulStop() {
+		t.Fatalf("gracefulStop = true, want false while leading")
+	}
+	nt.send(nt.filter(lead.readMessages())...)
+	if sm := nt.peers[2].(*Raft); sm.State != StateLeader {
+		t.Errorf("peer 2: state = %s, want %s", sm.State, StateLeader)
+	}
+	if !lead.gracefulStop() {
+		t.Errorf("gracefulStop = false, want true once following")
+	}
+
+	// The most up-to-date follower lags, it is caught up first.
+	nt = newNetwork(nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	lead = nt.peers[1].(*Raft)
+	nt.ignore(pb.MessageType_MsgAppend)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{}}})
+	if lead.gracefulStop() {
+		t.Fatalf("gracefulStop = true, want false while leading")
+	}
+	if err := lead.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{}}}); err != ErrProposalDropped {
+		t.Errorf("err = %v, want %v", err, ErrProposalDropped)
+	}
+	nt.recover()
+	nt.send(nt.filter(lead.readMessages())...)
+	if sm := nt.peers[2].(*Raft); sm.State != StateLeader || sm.RaftLog.LastIndex() != 3 {
+		t.Errorf("peer 2: state = %s, last index = %d, want %s with last index 3", sm.State, sm.RaftLog.LastIndex(), StateLeader)
+	}
+	if lead.State != StateFollower {
+		t.Errorf("state = %s, want %s", lead.State, StateFollower)
+	}
+}
+
+// TestGracefulStopTimeout3A tests that a handoff to a follower which never
+// answers is aborted after an election timeout, counted from the start of the
+// handoff, and that proposals are accepted again then.
+func TestGracefulStopTimeout3A(t *testing.T) {
+	nt := newNetwork(nil, nil, nil)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	lead := nt.peers[1].(*Raft)
+
+	for i := 0; i < lead.electionTimeout-1; i++ {
+		lead.tick()
+	}
+	nt.isolate(2)
+	nt.isolate(3)
+	if lead.gracefulStop() {
+		t.Fatalf("gracefulStop = true, want false while leading")
+	}
+	target := lead.leadTransferee
+	if target == None {
+		t.Fatalf("no handoff in progress")
+	}
+	for i := 0; i < lead.electionTimeout-1; i++ {
+		lead.tick()
+	}
+	if lead.leadTransferee != target {
+		t.Fatalf("handoff aborted after %d ticks, want %d", lead.electionTimeout-1, lead.electionTimeout)
+	}
+	lead.tick()
+	if lead.leadTransferee != None {
+		t.Fatalf("leadTransferee = %x, want aborted", lead.leadTransferee)
+	}
+	if lead.State != StateLeader {
+		t.Fatalf("state = %s, want %s", lead.State, StateLeader)
+	}
+	lastIndex := lead.RaftLog.LastIndex()
+	if err := lead.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgPropose, Entries: []*pb.Entry{{}}}); err != nil {
+		t.Fatalf("err = %v, want proposals accepted after the abort", err)
+	}
+	if lead.RaftLog.LastIndex() != lastIndex+1 {
+		t.Errorf("last index = %d, want %d", lead.RaftLog.LastIndex(), lastIndex+1)
+	}
+}
+
 // TestLeaderTransferToUpToDateNode verifies transferring should succeed
 // if the transferee has the most up-to-date log entries when transfer starts.
 func TestLeaderTransferToUpToDateNode3A(t *testing.T) {
@@ -1444,6 +2835,178 @@ func TestTransferNonMember3A(t *testing.T) {
 
 // TestSplitVote verifies that after split vote, cluster can complete
 // election in next round.
+func newStickyNetwork() *network {
+	var peers []stateMachine
+	for id := uint64(1); id <= 3; id++ {
+		c := newTestConfig(id, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		c.LeaderStickiness = true
+		peers = append(peers, newRaft(c))
+	}
+	nt := newNetwork(peers...)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	return nt
+}
+
+// TestLeaderStickiness2AA tests that peers which heard from the leader
+// recently ignore an ordinary election, but not the election of a leader
+// transfer.
+func TestLeaderStickiness2AA(t *testing.T) {
+	nt := newStickyNetwork()
+	nt.send(pb.Message{From: 3, To: 3, MsgType: pb.MessageType_MsgHup})
+	if sm := nt.peers[1].(*Raft); sm.State != StateLeader || sm.Term != 1 {
+		t.Errorf("peer 1: state = %s, term = %d, want %s at term 1", sm.State, sm.Term, StateLeader)
+	}
+	if sm := nt.peers[3].(*Raft); sm.State != StateCandidate || sm.Term != 2 {
+		t.Errorf("peer 3: state = %s, term = %d, want %s at term 2", sm.State, sm.Term, StateCandidate)
+	}
+
+	nt = newStickyNetwork()
+	nt.send(pb.Message{From: 1, To: 3, MsgType: pb.MessageType_MsgTimeoutNow})
+	if sm := nt.peers[3].(*Raft); sm.State != StateLeader || sm.Term != 2 {
+		t.Errorf("peer 3: state = %s, term = %d, want %s at term 2", sm.State, sm.Term, StateLeader)
+	}
+	if sm := nt.peers[1].(*Raft); sm.State != StateFollower || sm.Lead != 3 {
+		t.Errorf("peer 1: state = %s, lead = %d, want %s of 3", sm.State, sm.Lead, StateFollower)
+	}
+}
+
+// TestCheckQuorum2AA tests that with CheckQuorum a leader partitioned away
+// steps down after an election timeout, and that the peers which heard from
+// it ignore an ordinary election.
+func TestCheckQuorum2AA(t *testing.T) {
+	var peers []stateMachine
+	for id := uint64(1); id <= 3; id++ {
+		c := newTestConfig(id, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		c.CheckQuorum = true
+		peers = append(peers, newRaft(c))
+	}
+	nt := newNetwork(peers...)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+
+	leader := nt.peers[1].(*Raft)
+	follower := nt.peers[2].(*Raft)
+	follower.Step(pb.Message{From: 3, To: 2, Term: 2, LogTerm: 1, Index: 1, MsgType: pb.MessageType_MsgRequestVote})
+	if follower.Term != 1 || follower.Vote != 1 {
+		t.Errorf("peer 2: term = %d, vote = %d, want term 1, vote 1", follower.Term, follower.Vote)
+	}
+
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgBeat})
+	for i := 0; i < leader.electionTimeout; i++ {
+		leader.tick()
+	}
+	if leader.State != StateLeader {
+		t.Errorf("state = %s, want %s while the quorum is active", leader.State, StateLeader)
+	}
+
+	nt.isolate(1)
+	for i := 0; i < leader.electionTimeout; i++ {
+		leader.tick()
+	}
+	if leader.State != StateFollower || leader.Term != 1 {
+		t.Errorf("state = %s, term = %d, want %s at term 1", leader.State, leader.Term, StateFollower)
+	}
+}
+
+type recordLogger struct {
+	discardLogger
+	lines []string
+}
+
+func (l *recordLogger) Infof(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func (l *recordLogger) contains(substr string) bool {
+	for _, line := range l.lines {
+		if strings.Contains(line, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestLoggerReceivesElectionEvents2AA tests that the state transitions and
+// the votes are sent to the logger of the config.
+func TestLoggerReceivesElectionEvents2AA(t *testing.T) {
+	var rafts []stateMachine
+	var loggers []*recordLogger
+	for id := uint64(1); id <= 3; id++ {
+		l := new(recordLogger)
+		c := newTestConfig(id, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		c.Logger = l
+		rafts = append(rafts, newRaft(c))
+		loggers = append(loggers, l)
+	}
+	nt := newNetwork(rafts...)
+	nt.send(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+
+	for _, line := range []string{"1 became candidate at term 1", "1 became leader at term 1"} {
+		if !loggers[0].contains(line) {
+			t.Errorf("%q is not logged in %v", line, loggers[0].lines)
+		}
+	}
+	if !loggers[1].contains("cast MsgRequestVote from 1") {
+		t.Errorf("vote is not logged in %v", loggers[1].lines)
+	}
+}
+
+type recordTracer struct {
+	received, sent []pb.MessageType
+	states         []StateType
+}
+
+func (tr *recordTracer) OnReceive(m pb.Message) { tr.received = append(tr.received, m.MsgType) }
+func (tr *recordTracer) OnSend(m pb.Message)    { tr.sent = append(tr.sent, m.MsgType) }
+func (tr *recordTracer) OnStateChange(from, to StateType, term uint64) {
+	tr.states = append(tr.states, to)
+}
+
+// TestTracer2AA tests that the tracer of the config sees the messages a peer
+// receives and sends, and its state transitions.
+func TestTracer2AA(t *testing.T) {
+	tr := new(recordTracer)
+	c := newTestConfig(1, []uint64{1, 2}, 10, 1, NewMemoryStorage())
+	c.Tracer = tr
+	r := newRaft(c)
+	r.Step(pb.Message{From: 1, To: 1, MsgType: pb.MessageType_MsgHup})
+	r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgRequestVoteResponse})
+
+	wreceived := []pb.MessageType{pb.MessageType_MsgHup, pb.MessageType_MsgRequestVoteResponse}
+	if !reflect.DeepEqual(tr.received, wreceived) {
+		t.Errorf("received = %v, want %v", tr.received, wreceived)
+	}
+	wsent := []pb.MessageType{pb.MessageType_MsgRequestVote, pb.MessageType_MsgAppend}
+	if !reflect.DeepEqual(tr.sent, wsent) {
+		t.Errorf("sent = %v, want %v", tr.sent, wsent)
+	}
+	if g := r.readMessages(); len(g) != len(wsent) {
+		t.Errorf("msgs = %v, want %d messages", g, len(wsent))
+	}
+	wstates := []StateType{StateFollower, StateCandidate, StateLeader}
+	if !reflect.DeepEqual(tr.states, wstates) {
+		t.Errorf("states = %v, want %v", tr.states, wstates)
+	}
+}
+
+// TestRandSource2AA tests that peers with equally seeded random sources
+// randomize their election timeouts the same way.
+func TestRandSource2AA(t *testing.T) {
+	timeouts := func() []int {
+		c := newTestConfig(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
+		c.RandSource = rand.NewSource(42)
+		r := newRaft(c)
+		var timeouts []int
+		for i := 0; i < 10; i++ {
+			r.resetRandomizedElectionTimeout()
+			timeouts = append(timeouts, r.randomizedElectionTimeout)
+		}
+		return timeouts
+	}
+	if a, b := timeouts(), timeouts(); !reflect.DeepEqual(a, b) {
+		t.Errorf("timeouts = %v and %v, want them equal", a, b)
+	}
+}
+
 func TestSplitVote2AA(t *testing.T) {
 	n1 := newTestRaft(1, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
 	n2 := newTestRaft(2, []uint64{1, 2, 3}, 10, 1, NewMemoryStorage())
@@ -27,14 +27,30 @@ var ErrStepLocalMsg = errors.New("raft: cannot step raft local message")
 // but there is no peer found in raft.Prs for that node.
 var ErrStepPeerNotFound = errors.New("raft: cannot step as peer not found")
 
+// ErrCompactNotApplied is returned by RawNode.CompactTo when the entries to
+// compact are not applied and persisted yet.
+var ErrCompactNotApplied = errors.New("raft: cannot compact entries not applied yet")
+
+// SnapshotStatus is the outcome of sending a snapshot, see
+// RawNode.ReportSnapshot.
+type SnapshotStatus int
+
+const (
+	SnapshotFinish  SnapshotStatus = 1
+	SnapshotFailure SnapshotStatus = 2
+)
+
 // SoftState provides state that is volatile and does not need to be persisted to the WAL.
 type SoftState struct {
 	Lead      uint64
 	RaftState StateType
+	// Removed is true once the local node is removed from the group, the
+	// application should stop and destroy it.
+	Removed bool
 }
 
 func (a *SoftState) equal(b *SoftState) bool {
-	return a.Lead == b.Lead && a.RaftState == b.RaftState
+	return a.Lead == b.Lead && a.RaftState == b.RaftState && a.Removed == b.Removed
 }
 
 // Ready encapsulates the entries and messages that are ready to read,
@@ -65,9 +81,23 @@ type Ready struct {
 
 	// Messages specifies outbound messages to be sent AFTER Entries are
 	// committed to stable storage.
+	// They must also be sent after HardState is persisted: vote grants and
+	// messages of a new term are only valid once that term or vote is durable,
+	// otherwise a crash could let this node vote twice in the same term.
 	// If it contains a MessageType_MsgSnapshot message, the application MUST report back to raft
 	// when the snapshot has been received or has failed by calling ReportSnapshot.
+	// When Config.OptimisticReplication is set, a leader's MsgAppend messages may be
+	// sent before Entries are persisted.
+	// With Config.AsyncStorageWrites, all Messages may be sent right away, the
+	// ones depending on Entries or HardState are held until AckPersisted.
+	// A Snapshot must still be applied before Messages are sent.
 	Messages []pb.Message
+
+	// ReadStates can be used for node to serve linearizable read requests locally
+	// when its applied index is greater than the index in ReadState.
+	// Note that the readState will be returned when raft receives msgReadIndex.
+	// The returned is only valid for the request that requested to read.
+	ReadStates []ReadState
 }
 
 // RawNode is a wrapper of Raft.
@@ -76,6 +106,25 @@ type RawNode struct {
 	// Your Data Here (2A).
 	prevSoftSt *SoftState
 	prevHardSt pb.HardState
+	// applyPaused holds committed entries back from Ready, see PauseApply.
+	applyPaused bool
+
+	// asyncWrites is copied from Config.AsyncStorageWrites.
+	asyncWrites bool
+	// persistedHardSt is the last HardState acknowledged by AckPersisted,
+	// while prevHardSt is the last one handed out by a Ready.
+	persistedHardSt pb.HardState
+	// msgsAfterAck holds the messages which wait for an AckPersisted.
+	msgsAfterAck []heldMsg
+}
+
+// heldMsg is a message held until the local log is persisted up to index
+// and, if needHardSt is set, a HardState with its term and vote is durable.
+type heldMsg struct {
+	m          pb.Message
+	index      uint64
+	hardSt     pb.HardState
+	needHardSt bool
 }
 
 // NewRawNode returns a new RawNode given configuration and a list of raft peers.
@@ -83,9 +132,11 @@ func NewRawNode(config *Config) (*RawNode, error) {
 	// Your Code Here (2A).
 	r := newRaft(config)
 	return &RawNode{
-		Raft:       r,
-		prevSoftSt: r.softState(),
-		prevHardSt: r.hardState(),
+		Raft:            r,
+		prevSoftSt:      r.softState(),
+		prevHardSt:      r.hardState(),
+		asyncWrites:     config.AsyncStorageWrites,
+		persistedHardSt: r.hardState(),
 	}, nil
 }
 
@@ -94,6 +145,20 @@ func (rn *RawNode) Tick() {
 	rn.Raft.tick()
 }
 
+// TickStalled advances the internal logical clock by n ticks which the
+// caller missed because it was stalled, e.g. by a GC pause. Unlike calling
+// Tick n times it never starts an election on its own.
+func (rn *RawNode) TickStalled(n int) {
+	rn.Raft.tickStalled(n)
+}
+
+// PauseApply stops or resumes handing committed entries to the application.
+// While paused, entries are still replicated, persisted and committed, and
+// they are all handed out in the first Ready after apply is resumed.
+func (rn *RawNode) PauseApply(paused bool) {
+	rn.applyPaused = paused
+}
+
 // Campaign causes this RawNode to transition to candidate state.
 func (rn *RawNode) Campaign() error {
 	return rn.Raft.Step(pb.Message{
@@ -110,6 +175,28 @@ func (rn *RawNode) Propose(data []byte) error {
 		Entries: []*pb.Entry{&ent}})
 }
 
+// ProposeWithID proposes data to be appended to the raft log like Propose,
+// with the ID of the request proposing it. The retries of the request are
+// collapsed while its entry is uncommitted, see Config.DedupProposals.
+func (rn *RawNode) ProposeWithID(id, data []byte) error {
+	ent := pb.Entry{Data: data, RequestId: id}
+	return rn.Raft.Step(pb.Message{
+		MsgType: pb.MessageType_MsgPropose,
+		From:    rn.Raft.id,
+		Entries: []*pb.Entry{&ent}})
+}
+
+// ReadIndex requests a read state. The read state will be set in ready.
+// Read State has a read index. Once the application advances further than the read
+// index, any linearizable read requests issued before the read request can be
+// processed safely. The read state will have the same rctx attached.
+func (rn *RawNode) ReadIndex(rctx []byte) {
+	_ = rn.Raft.Step(pb.Message{
+		MsgType: pb.MessageType_MsgReadIndex,
+		Entries: []*pb.Entry{{Data: rctx}},
+	})
+}
+
 // ProposeConfChange proposes a config change.
 func (rn *RawNode) ProposeConfChange(cc pb.ConfChange) error {
 	data, err := cc.Marshal()
@@ -123,18 +210,36 @@ func (rn *RawNode) ProposeConfChange(cc pb.ConfChange) error {
 	})
 }
 
+// ProposeConfChangeV2 proposes a config change carrying any number of
+// changes. Only changes which need no joint configuration, i.e. at most one
+// change with the automatic transition, are accepted for now.
+func (rn *RawNode) ProposeConfChangeV2(cc pb.ConfChangeV2) error {
+	if !isSimpleConfChange(cc) {
+		return ErrJointConfChange
+	}
+	data, err := cc.Marshal()
+	if err != nil {
+		return err
+	}
+	ent := pb.Entry{EntryType: pb.EntryType_EntryConfChangeV2, Data: data}
+	return rn.Raft.Step(pb.Message{
+		MsgType: pb.MessageType_MsgPropose,
+		Entries: []*pb.Entry{&ent},
+	})
+}
+
 // ApplyConfChange applies a config change to the local node.
 func (rn *RawNode) ApplyConfChange(cc pb.ConfChange) *pb.ConfState {
-	if cc.NodeId == None {
-		return &pb.ConfState{Nodes: nodes(rn.Raft)}
-	}
-	switch cc.ChangeType {
-	case pb.ConfChangeType_AddNode:
-		rn.Raft.addNode(cc.NodeId)
-	case pb.ConfChangeType_RemoveNode:
-		rn.Raft.removeNode(cc.NodeId)
-	default:
-		panic("unexpected conf type")
+	return rn.ApplyConfChangeV2(ConfChangeToV2(cc))
+}
+
+// ApplyConfChangeV2 applies the changes of a ConfChangeV2 to the local node
+// in order. Like ApplyConfChange, it must be called for every committed
+// EntryConfChangeV2, a change cancelled by the application is applied as an
+// empty ConfChangeV2.
+func (rn *RawNode) ApplyConfChangeV2(cc pb.ConfChangeV2) *pb.ConfState {
+	for _, change := range cc.Changes {
+		rn.Raft.applyConfChangeSingle(change)
 	}
 	return &pb.ConfState{Nodes: nodes(rn.Raft)}
 }
@@ -154,20 +259,47 @@ func (rn *RawNode) Step(m pb.Message) error {
 // Ready returns the current point-in-time state of this RawNode.
 func (rn *RawNode) Ready() Ready {
 	// Your Code Here (2A).
+	rn.Raft.flushAppends()
 	rd := Ready{
-		Entries:          rn.Raft.RaftLog.unstableEntries(),
-		CommittedEntries: rn.Raft.RaftLog.nextEnts(),
-		Messages:         rn.Raft.msgs,
+		Entries: rn.Raft.RaftLog.unstableEntries(),
+	}
+	if rn.asyncWrites {
+		rd.Entries = rn.Raft.RaftLog.unpersistedEntries()
+		if n := len(rd.Entries); n > 0 {
+			rn.Raft.RaftLog.persisting = rd.Entries[n-1].Index
+		}
+	}
+	if !rn.applyPaused {
+		ents, err := rn.Raft.RaftLog.nextEnts()
+		if err != nil {
+			// The entries are handed out by a later Ready, the next Step
+			// returns the error.
+			rn.Raft.fail(err)
+		}
+		rd.CommittedEntries = ents
+		if n := len(rd.CommittedEntries); n > 0 {
+			// The next Ready carries the following batch, even if this
+			// one is not applied yet.
+			rn.Raft.RaftLog.applying = rd.CommittedEntries[n-1].Index
+		}
 	}
 
 	softSt := rn.Raft.softState()
 	hardSt := rn.Raft.hardState()
+	rd.Messages = rn.Raft.msgs
+	if rn.asyncWrites {
+		rd.Messages = rn.holdUnackedMsgs(rn.Raft.msgs, hardSt)
+	}
 	if !rn.prevSoftSt.equal(softSt) {
 		rn.prevSoftSt = softSt
 		rd.SoftState = softSt
 	}
 	if !isHardStateEqual(rn.prevHardSt, hardSt) {
 		rd.HardState = hardSt
+		if rn.asyncWrites {
+			// Handed out now, durable only once acknowledged.
+			rn.prevHardSt = hardSt
+		}
 	}
 
 	if !IsEmptySnap(rn.Raft.RaftLog.pendingSnapshot) {
@@ -175,6 +307,11 @@ func (rn *RawNode) Ready() Ready {
 		rn.Raft.RaftLog.pendingSnapshot = nil
 	}
 
+	if len(rn.Raft.readStates) != 0 {
+		rd.ReadStates = rn.Raft.readStates
+		rn.Raft.readStates = nil
+	}
+
 	rn.Raft.msgs = make([]pb.Message, 0)
 	return rd
 }
@@ -183,31 +320,108 @@ func (rn *RawNode) Ready() Ready {
 func (rn *RawNode) HasReady() bool {
 	// Your Code Here (2A).
 	if !isHardStateEqual(rn.Raft.hardState(), rn.prevHardSt) ||
+		rn.Raft.removed && !rn.prevSoftSt.Removed ||
 		!IsEmptySnap(rn.Raft.RaftLog.pendingSnapshot) ||
-		len(rn.Raft.RaftLog.unstableEntries()) != 0 ||
-		len(rn.Raft.RaftLog.nextEnts()) != 0 ||
-		len(rn.Raft.msgs) != 0 {
+		!rn.asyncWrites && len(rn.Raft.RaftLog.unstableEntries()) != 0 ||
+		rn.asyncWrites && len(rn.Raft.RaftLog.unpersistedEntries()) != 0 ||
+		(!rn.applyPaused && rn.Raft.RaftLog.hasNextEnts()) ||
+		len(rn.Raft.msgs) != 0 || rn.Raft.appendsPending ||
+		len(rn.Raft.readStates) != 0 {
 		return true
 	}
 	return false
 }
 
 // Advance notifies the RawNode that the application has applied and saved progress in the
-// last Ready results.
+// last Ready results. With Config.AsyncStorageWrites it only reports the
+// CommittedEntries applied, persistence is reported by AckPersisted.
 func (rn *RawNode) Advance(rd Ready) {
 	// Your Code Here (2A).
+	if rn.asyncWrites {
+		if len(rd.CommittedEntries) > 0 {
+			rn.Raft.RaftLog.appliedTo(rd.CommittedEntries[len(rd.CommittedEntries)-1].Index)
+		}
+		rn.Raft.RaftLog.maybeCompact()
+		return
+	}
 	if !IsEmptyHardState(rd.HardState) {
 		rn.prevHardSt = rd.HardState
 	}
 	if len(rd.Entries) > 0 {
-		rn.Raft.RaftLog.stabled = rd.Entries[len(rd.Entries)-1].Index
+		rn.Raft.stableTo(rd.Entries[len(rd.Entries)-1].Index)
 	}
 	if len(rd.CommittedEntries) > 0 {
-		rn.Raft.RaftLog.applied = rd.CommittedEntries[len(rd.CommittedEntries)-1].Index
+		rn.Raft.RaftLog.appliedTo(rd.CommittedEntries[len(rd.CommittedEntries)-1].Index)
 	}
 	rn.Raft.RaftLog.maybeCompact()
 }
 
+// AckPersisted notifies the RawNode that the Entries and the HardState of rd
+// are durable, with Config.AsyncStorageWrites. Readies must be acknowledged in
+// the order they were returned, and on the goroutine driving the RawNode. The
+// messages waiting for these writes are returned by the next Ready.
+func (rn *RawNode) AckPersisted(rd Ready) {
+	if !IsEmptyHardState(rd.HardState) {
+		rn.persistedHardSt = rd.HardState
+	}
+	if n := len(rd.Entries); n > 0 {
+		last := rd.Entries[n-1]
+		// The entries may have been overwritten by a new leader meanwhile,
+		// the persisted copies then don't count.
+		if term, err := rn.Raft.RaftLog.Term(last.Index); err == nil && term == last.Term &&
+			last.Index > rn.Raft.RaftLog.stabled {
+			rn.Raft.stableTo(last.Index)
+		}
+	}
+	held := rn.msgsAfterAck[:0]
+	for _, h := range rn.msgsAfterAck {
+		if h.index <= rn.Raft.RaftLog.stabled && (!h.needHardSt || rn.hardStatePersisted(h.hardSt)) {
+			rn.Raft.msgs = append(rn.Raft.msgs, h.m)
+		} else {
+			held = append(held, h)
+		}
+	}
+	rn.msgsAfterAck = held
+}
+
+// holdUnackedMsgs holds the messages of a Ready with Config.AsyncStorageWrites,
+// which can't rely on the Ready being persisted before they are sent. It holds
+// the messages carrying a term or vote newer than the persisted HardState, so
+// a crash can't let this node vote twice in the same term, and the acceptances
+// of entries which are not persisted yet, so no one counts them as replicated
+// on this node before they are durable.
+func (rn *RawNode) holdUnackedMsgs(msgs []pb.Message, hardSt pb.HardState) []pb.Message {
+	sendable := make([]pb.Message, 0, len(msgs))
+	for _, m := range msgs {
+		h := heldMsg{m: m, hardSt: hardSt}
+		if !rn.hardStatePersisted(hardSt) {
+			switch {
+			case m.MsgType == pb.MessageType_MsgRequestVote,
+				m.MsgType == pb.MessageType_MsgRequestVoteResponse && !m.Reject,
+				m.Term > rn.persistedHardSt.Term:
+				h.needHardSt = true
+			}
+		}
+		if m.MsgType == pb.MessageType_MsgAppendResponse && !m.Reject &&
+			m.Index > rn.Raft.RaftLog.stabled {
+			h.index = m.Index
+		}
+		if h.needHardSt || h.index != 0 {
+			rn.msgsAfterAck = append(rn.msgsAfterAck, h)
+		} else {
+			sendable = append(sendable, m)
+		}
+	}
+	return sendable
+}
+
+// hardStatePersisted reports whether the term and vote of hardSt are durable.
+func (rn *RawNode) hardStatePersisted(hardSt pb.HardState) bool {
+	persisted := rn.persistedHardSt
+	return persisted.Term > hardSt.Term ||
+		persisted.Term == hardSt.Term && persisted.Vote == hardSt.Vote
+}
+
 // GetProgress return the Progress of this node and its peers, if this
 // node is leader.
 func (rn *RawNode) GetProgress() map[uint64]Progress {
@@ -220,6 +434,50 @@ func (rn *RawNode) GetProgress() map[uint64]Progress {
 	return prs
 }
 
+// ReportUnreachable reports that the given peer could not be reached with the
+// last message sent to it.
+func (rn *RawNode) ReportUnreachable(id uint64) {
+	_ = rn.Raft.Step(pb.Message{MsgType: pb.MessageType_MsgUnreachable, From: id})
+}
+
+// ReportSnapshot reports the status of the snapshot sent to the given peer.
+func (rn *RawNode) ReportSnapshot(id uint64, status SnapshotStatus) {
+	rej := status == SnapshotFailure
+	_ = rn.Raft.Step(pb.Message{MsgType: pb.MessageType_MsgSnapStatus, From: id, Reject: rej})
+}
+
+// Status returns the current status of the raft state machine.
+func (rn *RawNode) Status() Status {
+	return getStatus(rn.Raft)
+}
+
+// RebuildPeer makes the leader send a snapshot to the given follower, which
+// drops its state and installs the snapshot. It repairs a corrupted or lagging
+// replica without a configuration change. The rest of the group must hold a
+// quorum by itself, since the entries the follower acknowledged are lost.
+func (rn *RawNode) RebuildPeer(id uint64) {
+	rn.Raft.rebuildPeer(id)
+}
+
+// GracefulStop hands the leadership over to the most up-to-date follower
+// before the node stops, so the group doesn't wait for an election timeout to
+// elect another leader. It returns true once the node may stop, as it doesn't
+// lead other voters anymore. Call it again after handling each Ready until it
+// does, a handoff which didn't complete within an election timeout is started
+// over.
+func (rn *RawNode) GracefulStop() bool {
+	return rn.Raft.gracefulStop()
+}
+
+// CompactTo discards the log entries up to i, which the application included
+// in a snapshot, from memory and from the storage if it is a
+// CompactableStorage. Entry i must be applied and persisted, it returns
+// ErrCompactNotApplied otherwise and ErrCompacted if it is compacted already.
+// The followers needing the entries are sent the snapshot of the storage.
+func (rn *RawNode) CompactTo(i uint64) error {
+	return rn.Raft.RaftLog.compactTo(i)
+}
+
 // TransferLeader tries to transfer leadership to the given transferee.
 func (rn *RawNode) TransferLeader(transferee uint64) {
 	_ = rn.Raft.Step(pb.Message{MsgType: pb.MessageType_MsgTransferLeader, From: transferee})
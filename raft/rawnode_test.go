@@ -62,7 +62,7 @@ func TestRawNodeProposeAndConfChange3A(t *testing.T) {
 	}
 	rawNode.ProposeConfChange(cc)
 
-	entries := rawNode.Raft.RaftLog.entries
+	entries := mustAllEntries(rawNode.Raft.RaftLog)
 	if l := len(entries); l < 2 {
 		t.Fatalf("len(entries) = %d, want >= 2", l)
 	} else {
@@ -79,6 +79,62 @@ func TestRawNodeProposeAndConfChange3A(t *testing.T) {
 	}
 }
 
+// TestRawNodeProposeConfChangeV23A ensures that a ConfChangeV2 with a single
+// change is proposed and applied like a ConfChange, and that changes needing a
+// joint configuration are refused.
+func TestRawNodeProposeConfChangeV23A(t *testing.T) {
+	s := NewMemoryStorage()
+	rawNode, err := NewRawNode(newTestConfig(1, []uint64{1}, 10, 1, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawNode.Campaign()
+	for {
+		rd := rawNode.Ready()
+		s.Append(rd.Entries)
+		rawNode.Advance(rd)
+		if rawNode.Raft.State == StateLeader && !rawNode.HasReady() {
+			break
+		}
+	}
+
+	joint := pb.ConfChangeV2{Changes: []*pb.ConfChangeSingle{
+		{ChangeType: pb.ConfChangeType_AddNode, NodeId: 2},
+		{ChangeType: pb.ConfChangeType_AddNode, NodeId: 3},
+	}}
+	if err := rawNode.ProposeConfChangeV2(joint); err != ErrJointConfChange {
+		t.Fatalf("err = %v, want %v", err, ErrJointConfChange)
+	}
+
+	cc := pb.ConfChangeV2{Changes: []*pb.ConfChangeSingle{{ChangeType: pb.ConfChangeType_AddNode, NodeId: 2}}}
+	if err := rawNode.ProposeConfChangeV2(cc); err != nil {
+		t.Fatal(err)
+	}
+	rd := rawNode.Ready()
+	s.Append(rd.Entries)
+	var cs *pb.ConfState
+	for _, entry := range rd.CommittedEntries {
+		if entry.EntryType != pb.EntryType_EntryConfChangeV2 {
+			continue
+		}
+		got, err := ConfChangeFromEntry(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, cc) {
+			t.Fatalf("conf change = %v, want %v", got, cc)
+		}
+		cs = rawNode.ApplyConfChangeV2(got)
+	}
+	rawNode.Advance(rd)
+	if cs == nil {
+		t.Fatalf("no EntryConfChangeV2 committed")
+	}
+	if !reflect.DeepEqual(cs.Nodes, []uint64{1, 2}) {
+		t.Errorf("nodes = %v, want %v", cs.Nodes, []uint64{1, 2})
+	}
+}
+
 // TestRawNodeProposeAddDuplicateNode ensures that two proposes to add the same node should
 // not affect the later propose to add new node.
 func TestRawNodeProposeAddDuplicateNode3A(t *testing.T) {
@@ -192,7 +248,6 @@ func TestRawNodeRestart2AC(t *testing.T) {
 	st := pb.HardState{Term: 1, Commit: 1}
 
 	want := Ready{
-		Entries: []pb.Entry{},
 		// commit up to commit index in st
 		CommittedEntries: entries[:st.Commit],
 	}
@@ -214,6 +269,263 @@ func TestRawNodeRestart2AC(t *testing.T) {
 	}
 }
 
+// TestRawNodePauseApply2AC tests that committed entries are held back while
+// apply is paused and handed out once it is resumed.
+func TestRawNodePauseApply2AC(t *testing.T) {
+	entries := []pb.Entry{
+		{Term: 1, Index: 1},
+		{Term: 1, Index: 2, Data: []byte("foo")},
+	}
+	storage := NewMemoryStorage()
+	storage.SetHardState(pb.HardState{Term: 1, Commit: 2})
+	storage.Append(entries)
+	rawNode, err := NewRawNode(newTestConfig(1, nil, 10, 1, storage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawNode.PauseApply(true)
+	if rawNode.HasReady() {
+		t.Fatalf("unexpected Ready: %+v", rawNode.Ready())
+	}
+
+	rawNode.PauseApply(false)
+	if !rawNode.HasReady() {
+		t.Fatal("expected a Ready after resuming apply")
+	}
+	rd := rawNode.Ready()
+	if !reflect.DeepEqual(rd.CommittedEntries, entries) {
+		t.Errorf("CommittedEntries = %+v, want %+v", rd.CommittedEntries, entries)
+	}
+}
+
+// TestRawNodeMaxCommittedSizePerReady2AC tests that the committed entries are
+// handed out in batches bounded by MaxCommittedSizePerReady, and that a Ready
+// can carry the next batch before the previous one is applied.
+func TestRawNodeMaxCommittedSizePerReady2AC(t *testing.T) {
+	data := make([]byte, 100)
+	var entries []pb.Entry
+	for i := uint64(1); i <= 5; i++ {
+		entries = append(entries, pb.Entry{Term: 1, Index: i, Data: data})
+	}
+	storage := NewMemoryStorage()
+	storage.SetHardState(pb.HardState{Term: 1, Commit: 5})
+	storage.Append(entries)
+	c := newTestConfig(1, nil, 10, 1, storage)
+	c.MaxCommittedSizePerReady = uint64(2 * entries[0].Size())
+	rawNode, err := NewRawNode(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rd1 := rawNode.Ready()
+	if !reflect.DeepEqual(rd1.CommittedEntries, entries[:2]) {
+		t.Fatalf("CommittedEntries = %+v, want %+v", rd1.CommittedEntries, entries[:2])
+	}
+	rd2 := rawNode.Ready()
+	if !reflect.DeepEqual(rd2.CommittedEntries, entries[2:4]) {
+		t.Fatalf("CommittedEntries = %+v, want %+v", rd2.CommittedEntries, entries[2:4])
+	}
+	rawNode.Advance(rd1)
+	rawNode.Advance(rd2)
+	if applied := rawNode.Raft.RaftLog.applied; applied != 4 {
+		t.Errorf("applied = %d, want 4", applied)
+	}
+	rd3 := rawNode.Ready()
+	if !reflect.DeepEqual(rd3.CommittedEntries, entries[4:]) {
+		t.Fatalf("CommittedEntries = %+v, want %+v", rd3.CommittedEntries, entries[4:])
+	}
+	rawNode.Advance(rd3)
+	if rawNode.HasReady() {
+		t.Errorf("unexpected Ready: %+v", rawNode.Ready())
+	}
+}
+
+// TestRawNodeMaxCachedEntriesSize2AC tests that only the latest persisted
+// and applied entries which fit in MaxCachedEntriesSize stay in memory, the
+// others being read back from storage.
+func TestRawNodeMaxCachedEntriesSize2AC(t *testing.T) {
+	data := make([]byte, 100)
+	storage := NewMemoryStorage()
+	c := newTestConfig(1, []uint64{1}, 10, 1, storage)
+	c.MaxCachedEntriesSize = uint64(2 * (&pb.Entry{Term: 1, Index: 2, Data: data}).Size())
+	rawNode, err := NewRawNode(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawNode.Campaign()
+	for i := 0; i < 5; i++ {
+		rawNode.Propose(data)
+	}
+	for rawNode.HasReady() {
+		rd := rawNode.Ready()
+		storage.Append(rd.Entries)
+		rawNode.Advance(rd)
+	}
+
+	l := rawNode.Raft.RaftLog
+	if l.applied != 6 {
+		t.Fatalf("applied = %d, want 6", l.applied)
+	}
+	if len(l.entries) != 2 || l.offset != 5 {
+		t.Errorf("cached entries = %+v from %d, want 2 from 5", l.entries, l.offset)
+	}
+	ents := mustAllEntries(l)
+	if len(ents) != 6 {
+		t.Fatalf("len(entries) = %d, want 6", len(ents))
+	}
+	for i, ent := range ents {
+		if ent.Index != uint64(i+1) || ent.Term != 1 {
+			t.Errorf("#%d: entry = %+v, want index %d at term 1", i, ent, i+1)
+		}
+	}
+}
+
+// TestRawNodeCompactTo2C tests that CompactTo discards the applied entries
+// from the log and the storage, and refuses to compact the entries not
+// applied yet or compacted already.
+func TestRawNodeCompactTo2C(t *testing.T) {
+	var entries []pb.Entry
+	for i := uint64(1); i <= 5; i++ {
+		entries = append(entries, pb.Entry{Term: 1, Index: i, Data: []byte("foo")})
+	}
+	storage := NewMemoryStorage()
+	storage.SetHardState(pb.HardState{Term: 1, Commit: 5})
+	storage.Append(entries)
+	c := newTestConfig(1, nil, 10, 1, storage)
+	c.MaxCommittedSizePerReady = uint64(3 * entries[0].Size())
+	rawNode, err := NewRawNode(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawNode.Advance(rawNode.Ready())
+	if applied := rawNode.Raft.RaftLog.applied; applied != 3 {
+		t.Fatalf("applied = %d, want 3", applied)
+	}
+
+	if err := rawNode.CompactTo(4); err != ErrCompactNotApplied {
+		t.Errorf("err = %v, want %v", err, ErrCompactNotApplied)
+	}
+	if err := rawNode.CompactTo(2); err != nil {
+		t.Fatal(err)
+	}
+	if first := rawNode.Raft.RaftLog.FirstIndex(); first != 3 {
+		t.Errorf("first index = %d, want 3", first)
+	}
+	if first, _ := storage.FirstIndex(); first != 3 {
+		t.Errorf("storage first index = %d, want 3", first)
+	}
+	if err := rawNode.CompactTo(2); err != ErrCompacted {
+		t.Errorf("err = %v, want %v", err, ErrCompacted)
+	}
+	if term, err := rawNode.Raft.RaftLog.Term(2); err != nil || term != 1 {
+		t.Errorf("term = %d, %v, want 1, nil", term, err)
+	}
+
+	rd := rawNode.Ready()
+	if !reflect.DeepEqual(rd.CommittedEntries, entries[3:]) {
+		t.Errorf("CommittedEntries = %+v, want %+v", rd.CommittedEntries, entries[3:])
+	}
+}
+
+// TestRawNodeAsyncStorageWrites2AC tests that with AsyncStorageWrites the
+// entries are handed out for persistence once, and that the append response,
+// the vote and the committed entries depending on them wait for AckPersisted.
+func TestRawNodeAsyncStorageWrites2AC(t *testing.T) {
+	storage := NewMemoryStorage()
+	storage.SetHardState(pb.HardState{Term: 1, Commit: 1})
+	storage.Append([]pb.Entry{{Term: 1, Index: 1}})
+	c := newTestConfig(1, []uint64{1, 2}, 10, 1, storage)
+	c.AsyncStorageWrites = true
+	rawNode, err := NewRawNode(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawNode.Advance(rawNode.Ready())
+
+	ent := pb.Entry{Term: 1, Index: 2, Data: []byte("foo")}
+	rawNode.Step(pb.Message{MsgType: pb.MessageType_MsgAppend, From: 2, To: 1, Term: 1,
+		LogTerm: 1, Index: 1, Commit: 2, Entries: []*pb.Entry{&ent}})
+	rd := rawNode.Ready()
+	if !reflect.DeepEqual(rd.Entries, []pb.Entry{ent}) {
+		t.Fatalf("Entries = %+v, want %+v", rd.Entries, []pb.Entry{ent})
+	}
+	if len(rd.Messages) != 0 || len(rd.CommittedEntries) != 0 {
+		t.Fatalf("Messages = %+v, CommittedEntries = %+v, want none before persistence", rd.Messages, rd.CommittedEntries)
+	}
+	rawNode.Advance(rd)
+	if rawNode.HasReady() {
+		t.Fatalf("unexpected Ready: %+v", rawNode.Ready())
+	}
+
+	storage.Append(rd.Entries)
+	storage.SetHardState(rd.HardState)
+	rawNode.AckPersisted(rd)
+	if stabled := rawNode.Raft.RaftLog.stabled; stabled != 2 {
+		t.Errorf("stabled = %d, want 2", stabled)
+	}
+	rd = rawNode.Ready()
+	if len(rd.Messages) != 1 || rd.Messages[0].MsgType != pb.MessageType_MsgAppendResponse || rd.Messages[0].Index != 2 {
+		t.Errorf("Messages = %+v, want the append response for index 2", rd.Messages)
+	}
+	if !reflect.DeepEqual(rd.CommittedEntries, []pb.Entry{ent}) {
+		t.Errorf("CommittedEntries = %+v, want %+v", rd.CommittedEntries, []pb.Entry{ent})
+	}
+	rawNode.Advance(rd)
+
+	rawNode.Step(pb.Message{MsgType: pb.MessageType_MsgRequestVote, From: 2, To: 1, Term: 2, LogTerm: 1, Index: 2})
+	rd = rawNode.Ready()
+	if rd.HardState.Term != 2 || rd.HardState.Vote != 2 {
+		t.Fatalf("HardState = %+v, want term 2 vote 2", rd.HardState)
+	}
+	if len(rd.Messages) != 0 {
+		t.Fatalf("Messages = %+v, want the vote held", rd.Messages)
+	}
+	rawNode.Advance(rd)
+	rawNode.AckPersisted(rd)
+	rd = rawNode.Ready()
+	if len(rd.Messages) != 1 || rd.Messages[0].MsgType != pb.MessageType_MsgRequestVoteResponse || rd.Messages[0].Reject {
+		t.Errorf("Messages = %+v, want the vote", rd.Messages)
+	}
+}
+
+// TestRawNodeStatus2AC tests that Status reports the state of the node, and
+// the progress of the peers only on the leader.
+func TestRawNodeStatus2AC(t *testing.T) {
+	storage := NewMemoryStorage()
+	rawNode, err := NewRawNode(newTestConfig(1, []uint64{1}, 10, 1, storage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := rawNode.Status()
+	if status.ID != 1 || status.RaftState != StateFollower || status.Progress != nil {
+		t.Fatalf("unexpected status %+v", status)
+	}
+
+	if err := rawNode.Campaign(); err != nil {
+		t.Fatal(err)
+	}
+	status = rawNode.Status()
+	if status.Lead != 1 || status.RaftState != StateLeader || status.Term != 1 || status.Vote != 1 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	pr, ok := status.Progress[1]
+	if !ok {
+		t.Fatalf("no progress of the leader in %+v", status.Progress)
+	}
+	if pr.State != ProgressStateReplicate {
+		t.Errorf("progress state = %s, want %s", pr.State, ProgressStateReplicate)
+	}
+
+	rd := rawNode.Ready()
+	storage.Append(rd.Entries)
+	rawNode.Advance(rd)
+	status = rawNode.Status()
+	if status.Commit != 1 || status.Applied != 1 {
+		t.Errorf("commit = %d, applied = %d, want 1, 1", status.Commit, status.Applied)
+	}
+}
+
 func TestRawNodeRestartFromSnapshot2C(t *testing.T) {
 	snap := pb.Snapshot{
 		Metadata: &pb.SnapshotMetadata{
@@ -228,7 +540,6 @@ func TestRawNodeRestartFromSnapshot2C(t *testing.T) {
 	st := pb.HardState{Term: 1, Commit: 3}
 
 	want := Ready{
-		Entries: []pb.Entry{},
 		// commit up to commit index in st
 		CommittedEntries: entries,
 	}
@@ -250,3 +561,103 @@ func TestRawNodeRestartFromSnapshot2C(t *testing.T) {
 		t.Errorf("unexpected Ready: %+v", rawNode.HasReady())
 	}
 }
+
+// TestRawNodeVoteWithHardState2AA ensures that a vote granted by the RawNode
+// leaves through the Ready carrying the HardState recording it, which the
+// application persists before sending the messages, so the grant isn't held
+// back for another Ready.
+func TestRawNodeVoteWithHardState2AA(t *testing.T) {
+	s := NewMemoryStorage()
+	rawNode, err := NewRawNode(newTestConfig(1, []uint64{1, 2, 3}, 10, 1, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawNode.Step(pb.Message{From: 2, To: 1, Term: 1, MsgType: pb.MessageType_MsgRequestVote})
+
+	rd := rawNode.Ready()
+	if rd.HardState.Vote != 2 || rd.HardState.Term != 1 {
+		t.Fatalf("hardState = %+v, want vote 2 at term 1", rd.HardState)
+	}
+	if len(rd.Messages) != 1 || rd.Messages[0].MsgType != pb.MessageType_MsgRequestVoteResponse || rd.Messages[0].Reject {
+		t.Fatalf("messages = %+v, want a single granted vote response", rd.Messages)
+	}
+	s.SetHardState(rd.HardState)
+	rawNode.Advance(rd)
+
+	if rawNode.HasReady() {
+		t.Fatalf("unexpected Ready after the vote was sent")
+	}
+}
+
+// TestRawNodeReadyReportsRemoved3A tests that a Ready surfaces the removal of
+// the local node, so the application can destroy it.
+func TestRawNodeReadyReportsRemoved3A(t *testing.T) {
+	s := NewMemoryStorage()
+	rawNode, err := NewRawNode(newTestConfig(1, []uint64{1, 2}, 10, 1, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rd := rawNode.Ready()
+	s.Append(rd.Entries)
+	rawNode.Advance(rd)
+
+	rawNode.ApplyConfChange(pb.ConfChange{ChangeType: pb.ConfChangeType_RemoveNode, NodeId: 1})
+	if !rawNode.HasReady() {
+		t.Fatal("expected a Ready after the removal")
+	}
+	rd = rawNode.Ready()
+	if rd.SoftState == nil || !rd.SoftState.Removed {
+		t.Fatalf("SoftState = %+v, want removed", rd.SoftState)
+	}
+	rawNode.Advance(rd)
+	if rawNode.HasReady() {
+		t.Errorf("unexpected Ready after the removal is reported")
+	}
+}
+
+// TestRawNodeAppendOwnsEntries2AB tests that the entries of a MsgAppend stay
+// intact once the log dropped them from memory, as the application may send
+// the message after the next Ready.
+func TestRawNodeAppendOwnsEntries2AB(t *testing.T) {
+	s := NewMemoryStorage()
+	rawNode, err := NewRawNode(newTestConfig(1, []uint64{1, 2}, 10, 1, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rawNode.Raft
+	r.becomeCandidate()
+	r.becomeLeader()
+	handle := func() Ready {
+		rd := rawNode.Ready()
+		s.Append(rd.Entries)
+		rawNode.Advance(rd)
+		return rd
+	}
+	ack := func() {
+		r.Step(pb.Message{From: 2, To: 1, Term: r.Term, MsgType: pb.MessageType_MsgAppendResponse, Index: r.RaftLog.LastIndex()})
+	}
+	handle()
+	ack()
+	handle()
+
+	rawNode.Propose([]byte("foo"))
+	var app *pb.Message
+	for _, m := range handle().Messages {
+		if m.MsgType == pb.MessageType_MsgAppend && len(m.Entries) > 0 {
+			app = &m
+		}
+	}
+	if app == nil {
+		t.Fatal("expected a MsgAppend carrying the proposal")
+	}
+	ack()
+	handle()
+	if r.RaftLog.applied != r.RaftLog.LastIndex() {
+		t.Fatalf("applied = %d, want %d", r.RaftLog.applied, r.RaftLog.LastIndex())
+	}
+
+	ent := app.Entries[len(app.Entries)-1]
+	if ent.Index != r.RaftLog.LastIndex() || string(ent.Data) != "foo" {
+		t.Errorf("entry = %+v, want the proposal at index %d", ent, r.RaftLog.LastIndex())
+	}
+}
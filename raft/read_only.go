@@ -0,0 +1,151 @@
+// Copyright 2016 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+
+// ReadState provides state for read only query.
+// It's caller's responsibility to call ReadIndex first before getting
+// this state from ready, it's also caller's duty to differentiate if this
+// state is what it requests through RequestCtx, eg. given a unique id as
+// RequestCtx
+type ReadState struct {
+	Index      uint64
+	RequestCtx []byte
+}
+
+type readIndexStatus struct {
+	req   pb.Message
+	index uint64
+	acks  map[uint64]bool
+}
+
+// readOnly tracks the read-only requests waiting for the leader to confirm
+// it is still the leader.
+type readOnly struct {
+	pendingReadIndex map[string]*readIndexStatus
+	readIndexQueue   []string
+}
+
+func newReadOnly() *readOnly {
+	return &readOnly{
+		pendingReadIndex: make(map[string]*readIndexStatus),
+	}
+}
+
+// addRequest adds a read only request into readonly struct.
+// `index` is the commit index of the raft state machine when it received
+// the read only request.
+// `m` is the original read only request message from the local or remote node.
+func (ro *readOnly) addRequest(index, self uint64, m pb.Message) {
+	s := string(m.Entries[0].Data)
+	if _, ok := ro.pendingReadIndex[s]; ok {
+		return
+	}
+	ro.pendingReadIndex[s] = &readIndexStatus{index: index, req: m, acks: map[uint64]bool{self: true}}
+	ro.readIndexQueue = append(ro.readIndexQueue, s)
+}
+
+// recvAck notifies the readonly struct that the raft state machine received
+// an acknowledgment of the heartbeat that attached with the read only request
+// context.
+func (ro *readOnly) recvAck(id uint64, context []byte) map[uint64]bool {
+	rs, ok := ro.pendingReadIndex[string(context)]
+	if !ok {
+		return nil
+	}
+	rs.acks[id] = true
+	return rs.acks
+}
+
+// advance advances the read only request queue kept by the readonly struct.
+// It dequeues the requests until it finds the read only request that has
+// the same context as the given `context`.
+func (ro *readOnly) advance(context []byte) []*readIndexStatus {
+	ctx := string(context)
+	for i, okctx := range ro.readIndexQueue {
+		if okctx != ctx {
+			continue
+		}
+		rss := make([]*readIndexStatus, 0, i+1)
+		for _, c := range ro.readIndexQueue[:i+1] {
+			rss = append(rss, ro.pendingReadIndex[c])
+			delete(ro.pendingReadIndex, c)
+		}
+		ro.readIndexQueue = ro.readIndexQueue[i+1:]
+		return rss
+	}
+	return nil
+}
+
+// forwardedReads holds the read index requests a follower forwarded to the
+// leader, by request context, until the leader answers them. They outlive
+// leader changes: the requests are forwarded again to the next leader, or
+// served by the node itself if it is elected.
+type forwardedReads struct {
+	reqs  map[string]pb.Message
+	queue []string
+}
+
+func newForwardedReads() *forwardedReads {
+	return &forwardedReads{reqs: make(map[string]pb.Message)}
+}
+
+// add records a forwarded request, it returns false if a request with the
+// same context is forwarded already.
+func (f *forwardedReads) add(m pb.Message) bool {
+	s := string(m.Entries[0].Data)
+	if _, ok := f.reqs[s]; ok {
+		return false
+	}
+	f.reqs[s] = m
+	f.queue = append(f.queue, s)
+	return true
+}
+
+// take removes and returns the request forwarded with the context, if any.
+func (f *forwardedReads) take(context []byte) (pb.Message, bool) {
+	s := string(context)
+	m, ok := f.reqs[s]
+	if !ok {
+		return m, false
+	}
+	delete(f.reqs, s)
+	for i, c := range f.queue {
+		if c == s {
+			f.queue = append(f.queue[:i], f.queue[i+1:]...)
+			break
+		}
+	}
+	return m, true
+}
+
+// requests returns the forwarded requests in the order they were received.
+func (f *forwardedReads) requests() []pb.Message {
+	msgs := make([]pb.Message, 0, len(f.queue))
+	for _, c := range f.queue {
+		msgs = append(msgs, f.reqs[c])
+	}
+	return msgs
+}
+
+// lastPendingRequestCtx returns the context of the last pending read only
+// request in readonly struct, or nil if there is none.
+func (ro *readOnly) lastPendingRequestCtx() []byte {
+	if len(ro.readIndexQueue) == 0 {
+		return nil
+	}
+	return []byte(ro.readIndexQueue[len(ro.readIndexQueue)-1])
+}
@@ -0,0 +1,86 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"fmt"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+// Status contains information about this Raft peer and its view of the
+// system. It is a copy, it can be kept and read while the peer runs.
+type Status struct {
+	ID uint64
+
+	pb.HardState
+	SoftState
+
+	Applied uint64
+	// Progress holds the progress of every peer, this one included. It is
+	// only set on the leader.
+	Progress map[uint64]Progress
+	// LeadTransferee is the target of the leader transfer in progress, or
+	// None.
+	LeadTransferee uint64
+	// PendingConfIndex is the index of the latest conf change proposed, it
+	// is not applied yet if it is above Applied.
+	PendingConfIndex uint64
+}
+
+func getProgressCopy(r *Raft) map[uint64]Progress {
+	prs := make(map[uint64]Progress, len(r.Prs))
+	for id, p := range r.Prs {
+		pr := *p
+		pr.ins = p.ins.clone()
+		prs[id] = pr
+	}
+	return prs
+}
+
+// getStatus gets a copy of the current raft status.
+func getStatus(r *Raft) Status {
+	s := Status{
+		ID:               r.id,
+		HardState:        r.hardState(),
+		SoftState:        *r.softState(),
+		Applied:          r.RaftLog.applied,
+		LeadTransferee:   r.leadTransferee,
+		PendingConfIndex: r.PendingConfIndex,
+	}
+	if s.RaftState == StateLeader {
+		s.Progress = getProgressCopy(r)
+	}
+	return s
+}
+
+func (s Status) String() string {
+	str := fmt.Sprintf(`{"id":"%x","term":%d,"vote":"%x","commit":%d,"lead":"%x","raftState":%q,"applied":%d,"progress":{`,
+		s.ID, s.Term, s.Vote, s.Commit, s.Lead, s.RaftState, s.Applied)
+
+	if len(s.Progress) == 0 {
+		str += "},"
+	} else {
+		for k, v := range s.Progress {
+			subStr := fmt.Sprintf(`"%x":{"match":%d,"next":%d,"state":%q},`, k, v.Match, v.Next, v.State)
+			str += subStr
+		}
+		// remove the trailing ","
+		str = str[:len(str)-1] + "},"
+	}
+
+	str += fmt.Sprintf(`"leadtransferee":"%x","pendingConfIndex":%d}`, s.LeadTransferee, s.PendingConfIndex)
+	return str
+}
@@ -16,9 +16,9 @@ package raft
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 
-	"github.com/pingcap-incubator/tinykv/log"
 	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
 )
 
@@ -70,12 +70,39 @@ type Storage interface {
 	Snapshot() (pb.Snapshot, error)
 }
 
-// MemoryStorage implements the Storage interface backed by an
-// in-memory array.
+// CompactableStorage is a Storage which can discard the entries before an
+// index, see RawNode.CompactTo.
+type CompactableStorage interface {
+	Storage
+	// Compact discards the entries before compactIndex. The term of entry
+	// compactIndex stays available through Term for matching purposes.
+	Compact(compactIndex uint64) error
+}
+
+// SnapshotStorage is a CompactableStorage which also keeps the snapshots of
+// the group, so the application doesn't track them apart from the log.
+type SnapshotStorage interface {
+	CompactableStorage
+	// CreateSnapshot makes the snapshot of the state at entry i, which
+	// Snapshot returns from then on. cs is the ConfState at i, nil meaning
+	// it didn't change since the storage last saw it. It returns
+	// ErrSnapOutOfDate if i isn't after the index of the current snapshot
+	// and ErrUnavailable if it is after the last entry. The entries are kept
+	// until compacted.
+	CreateSnapshot(i uint64, cs *pb.ConfState, data []byte) (pb.Snapshot, error)
+	// ApplySnapshot replaces the log and the ConfState with the snapshot,
+	// e.g. one received from the leader. It returns ErrSnapOutOfDate if the
+	// snapshot isn't after the current one.
+	ApplySnapshot(snap pb.Snapshot) error
+}
+
+// MemoryStorage implements the SnapshotStorage interface backed by an
+// in-memory array. It is safe for concurrent use, e.g. by the goroutine
+// handling the Ready of a RawNode, which appends entries and applies
+// snapshots, and by the application, which creates snapshots and compacts
+// the log once they are applied.
 type MemoryStorage struct {
-	// Protects access to all fields. Most methods of MemoryStorage are
-	// run on the raft goroutine, but Append() is run on an application
-	// goroutine.
+	// Protects access to all fields.
 	sync.Mutex
 
 	hardState pb.HardState
@@ -84,6 +111,8 @@ type MemoryStorage struct {
 	ents []pb.Entry
 }
 
+var _ SnapshotStorage = new(MemoryStorage)
+
 // NewMemoryStorage creates an empty MemoryStorage.
 func NewMemoryStorage() *MemoryStorage {
 	return &MemoryStorage{
@@ -95,6 +124,8 @@ func NewMemoryStorage() *MemoryStorage {
 
 // InitialState implements the Storage interface.
 func (ms *MemoryStorage) InitialState() (pb.HardState, pb.ConfState, error) {
+	ms.Lock()
+	defer ms.Unlock()
 	return ms.hardState, *ms.snapshot.Metadata.ConfState, nil
 }
 
@@ -106,7 +137,8 @@ func (ms *MemoryStorage) SetHardState(st pb.HardState) error {
 	return nil
 }
 
-// Entries implements the Storage interface.
+// Entries implements the Storage interface. The entries returned are shared
+// with the storage and must not be modified, but they can be appended to.
 func (ms *MemoryStorage) Entries(lo, hi uint64) ([]pb.Entry, error) {
 	ms.Lock()
 	defer ms.Unlock()
@@ -114,11 +146,16 @@ func (ms *MemoryStorage) Entries(lo, hi uint64) ([]pb.Entry, error) {
 	if lo <= offset {
 		return nil, ErrCompacted
 	}
+	if lo > hi {
+		return nil, fmt.Errorf("raft: invalid entries range [%d, %d)", lo, hi)
+	}
 	if hi > ms.lastIndex()+1 {
-		log.Panicf("entries' hi(%d) is out of bound lastindex(%d)", hi, ms.lastIndex())
+		return nil, ErrUnavailable
 	}
 
-	ents := ms.ents[lo-offset : hi-offset]
+	// Cap the slice, so appending to it can't overwrite the entries after
+	// hi.
+	ents := ms.ents[lo-offset : hi-offset : hi-offset]
 	if len(ms.ents) == 1 && len(ents) != 0 {
 		// only contains dummy entries.
 		return nil, ErrUnavailable
@@ -172,6 +209,10 @@ func (ms *MemoryStorage) Snapshot() (pb.Snapshot, error) {
 // ApplySnapshot overwrites the contents of this Storage object with
 // those of the given snapshot.
 func (ms *MemoryStorage) ApplySnapshot(snap pb.Snapshot) error {
+	if snap.Metadata == nil || snap.Metadata.ConfState == nil {
+		return fmt.Errorf("raft: snapshot without metadata")
+	}
+
 	ms.Lock()
 	defer ms.Unlock()
 
@@ -197,18 +238,22 @@ func (ms *MemoryStorage) CreateSnapshot(i uint64, cs *pb.ConfState, data []byte)
 	if i <= ms.snapshot.Metadata.Index {
 		return pb.Snapshot{}, ErrSnapOutOfDate
 	}
-
-	offset := ms.ents[0].Index
 	if i > ms.lastIndex() {
-		log.Panicf("snapshot %d is out of bound lastindex(%d)", i, ms.lastIndex())
+		return pb.Snapshot{}, ErrUnavailable
 	}
 
-	ms.snapshot.Metadata.Index = i
-	ms.snapshot.Metadata.Term = ms.ents[i-offset].Term
+	// The metadata of the previous snapshot may still be used by the
+	// readers of Snapshot, so it is replaced instead of updated.
+	offset := ms.ents[0].Index
+	meta := &pb.SnapshotMetadata{
+		Index:     i,
+		Term:      ms.ents[i-offset].Term,
+		ConfState: ms.snapshot.Metadata.ConfState,
+	}
 	if cs != nil {
-		ms.snapshot.Metadata.ConfState = cs
+		meta.ConfState = cs
 	}
-	ms.snapshot.Data = data
+	ms.snapshot = pb.Snapshot{Metadata: meta, Data: data}
 	return ms.snapshot, nil
 }
 
@@ -223,7 +268,7 @@ func (ms *MemoryStorage) Compact(compactIndex uint64) error {
 		return ErrCompacted
 	}
 	if compactIndex > ms.lastIndex() {
-		log.Panicf("compact %d is out of bound lastindex(%d)", compactIndex, ms.lastIndex())
+		return ErrUnavailable
 	}
 
 	i := compactIndex - offset
@@ -235,13 +280,19 @@ func (ms *MemoryStorage) Compact(compactIndex uint64) error {
 	return nil
 }
 
-// Append the new entries to storage.
-// TODO (xiangli): ensure the entries are continuous and
-// entries[0].Index > ms.entries[0].Index
+// Append the new entries to storage. The entries must be continuous. Those
+// already compacted are skipped, and those conflicting with the entries of
+// the storage replace them along with the entries after them.
 func (ms *MemoryStorage) Append(entries []pb.Entry) error {
 	if len(entries) == 0 {
 		return nil
 	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Index != entries[i-1].Index+1 {
+			return fmt.Errorf("raft: entries are not continuous [%d after %d]",
+				entries[i].Index, entries[i-1].Index)
+		}
+	}
 
 	ms.Lock()
 	defer ms.Unlock()
@@ -266,7 +317,7 @@ func (ms *MemoryStorage) Append(entries []pb.Entry) error {
 	case uint64(len(ms.ents)) == offset:
 		ms.ents = append(ms.ents, entries...)
 	default:
-		log.Panicf("missing log entry [last: %d, append at: %d]",
+		return fmt.Errorf("raft: missing log entry [last: %d, append at: %d]",
 			ms.lastIndex(), entries[0].Index)
 	}
 	return nil
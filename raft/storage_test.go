@@ -0,0 +1,263 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+)
+
+func newTestMemoryStorage(ents []pb.Entry) *MemoryStorage {
+	s := NewMemoryStorage()
+	s.ents = ents
+	return s
+}
+
+func TestStorageEntries2AB(t *testing.T) {
+	ents := []pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}, {Index: 5, Term: 5}, {Index: 6, Term: 6}}
+	tests := []struct {
+		lo, hi uint64
+
+		werr     error
+		wentries []pb.Entry
+	}{
+		{2, 6, ErrCompacted, nil},
+		{3, 4, ErrCompacted, nil},
+		{4, 5, nil, []pb.Entry{{Index: 4, Term: 4}}},
+		{4, 7, nil, []pb.Entry{{Index: 4, Term: 4}, {Index: 5, Term: 5}, {Index: 6, Term: 6}}},
+		{4, 8, ErrUnavailable, nil},
+	}
+
+	for i, tt := range tests {
+		s := newTestMemoryStorage(ents)
+		entries, err := s.Entries(tt.lo, tt.hi)
+		if err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if !reflect.DeepEqual(entries, tt.wentries) {
+			t.Errorf("#%d: entries = %v, want %v", i, entries, tt.wentries)
+		}
+	}
+
+	// Appending to the entries returned leaves the storage as it is.
+	s := newTestMemoryStorage(ents)
+	entries, _ := s.Entries(4, 5)
+	_ = append(entries, pb.Entry{Index: 5, Term: 10})
+	if term, _ := s.Term(5); term != 5 {
+		t.Errorf("term = %d, want 5", term)
+	}
+}
+
+func TestStorageAppend2AB(t *testing.T) {
+	ents := []pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}, {Index: 5, Term: 5}}
+	tests := []struct {
+		entries []pb.Entry
+
+		werr     bool
+		wentries []pb.Entry
+	}{
+		// truncate the existing entries and append
+		{
+			[]pb.Entry{{Index: 4, Term: 6}, {Index: 5, Term: 6}},
+			false,
+			[]pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 6}, {Index: 5, Term: 6}},
+		},
+		// truncate the compacted entries
+		{
+			[]pb.Entry{{Index: 2, Term: 3}, {Index: 3, Term: 3}, {Index: 4, Term: 5}},
+			false,
+			[]pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 5}},
+		},
+		// direct append
+		{
+			[]pb.Entry{{Index: 6, Term: 5}},
+			false,
+			[]pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}, {Index: 5, Term: 5}, {Index: 6, Term: 5}},
+		},
+		// a gap before the entries
+		{
+			[]pb.Entry{{Index: 7, Term: 5}},
+			true,
+			[]pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}, {Index: 5, Term: 5}},
+		},
+		// a gap between the entries
+		{
+			[]pb.Entry{{Index: 6, Term: 5}, {Index: 8, Term: 5}},
+			true,
+			[]pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}, {Index: 5, Term: 5}},
+		},
+	}
+
+	for i, tt := range tests {
+		s := newTestMemoryStorage(append([]pb.Entry{}, ents...))
+		err := s.Append(tt.entries)
+		if (err != nil) != tt.werr {
+			t.Errorf("#%d: err = %v, want error %v", i, err, tt.werr)
+		}
+		if !reflect.DeepEqual(s.ents, tt.wentries) {
+			t.Errorf("#%d: entries = %v, want %v", i, s.ents, tt.wentries)
+		}
+	}
+}
+
+func TestStorageCompact2C(t *testing.T) {
+	ents := []pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}, {Index: 5, Term: 5}}
+	tests := []struct {
+		i uint64
+
+		werr   error
+		windex uint64
+		wterm  uint64
+		wlen   int
+	}{
+		{2, ErrCompacted, 3, 3, 3},
+		{3, ErrCompacted, 3, 3, 3},
+		{4, nil, 4, 4, 2},
+		{5, nil, 5, 5, 1},
+		{6, ErrUnavailable, 3, 3, 3},
+	}
+
+	for i, tt := range tests {
+		s := newTestMemoryStorage(ents)
+		err := s.Compact(tt.i)
+		if err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if s.ents[0].Index != tt.windex {
+			t.Errorf("#%d: index = %d, want %d", i, s.ents[0].Index, tt.windex)
+		}
+		if s.ents[0].Term != tt.wterm {
+			t.Errorf("#%d: term = %d, want %d", i, s.ents[0].Term, tt.wterm)
+		}
+		if len(s.ents) != tt.wlen {
+			t.Errorf("#%d: len = %d, want %d", i, len(s.ents), tt.wlen)
+		}
+	}
+}
+
+func TestStorageCreateSnapshot2C(t *testing.T) {
+	ents := []pb.Entry{{Index: 3, Term: 3}, {Index: 4, Term: 4}, {Index: 5, Term: 5}}
+	cs := &pb.ConfState{Nodes: []uint64{1, 2, 3}}
+	data := []byte("data")
+
+	tests := []struct {
+		i uint64
+
+		werr  error
+		wsnap pb.Snapshot
+	}{
+		{3, ErrSnapOutOfDate, pb.Snapshot{}},
+		{4, nil, pb.Snapshot{Data: data, Metadata: &pb.SnapshotMetadata{Index: 4, Term: 4, ConfState: cs}}},
+		{5, nil, pb.Snapshot{Data: data, Metadata: &pb.SnapshotMetadata{Index: 5, Term: 5, ConfState: cs}}},
+		{6, ErrUnavailable, pb.Snapshot{}},
+	}
+
+	for i, tt := range tests {
+		s := newTestMemoryStorage(ents)
+		s.snapshot.Metadata.Index = 3
+		snap, err := s.CreateSnapshot(tt.i, cs, data)
+		if err != tt.werr {
+			t.Errorf("#%d: err = %v, want %v", i, err, tt.werr)
+		}
+		if !reflect.DeepEqual(snap, tt.wsnap) {
+			t.Errorf("#%d: snap = %+v, want %+v", i, snap, tt.wsnap)
+		}
+	}
+
+	// A snapshot returned before is left as it is by the next one.
+	s := newTestMemoryStorage(ents)
+	first, _ := s.CreateSnapshot(4, cs, data)
+	if _, err := s.CreateSnapshot(5, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if first.Metadata.Index != 4 {
+		t.Errorf("index = %d, want 4", first.Metadata.Index)
+	}
+}
+
+func TestStorageApplySnapshot2C(t *testing.T) {
+	cs := &pb.ConfState{Nodes: []uint64{1, 2, 3}}
+	data := []byte("data")
+
+	tests := []pb.Snapshot{
+		{Data: data, Metadata: &pb.SnapshotMetadata{Index: 4, Term: 4, ConfState: cs}},
+		{Data: data, Metadata: &pb.SnapshotMetadata{Index: 3, Term: 3, ConfState: cs}},
+	}
+
+	s := NewMemoryStorage()
+	// Apply a snapshot successfully.
+	if err := s.ApplySnapshot(tests[0]); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+	if last, _ := s.LastIndex(); last != 4 {
+		t.Errorf("last index = %d, want 4", last)
+	}
+	if _, gcs, _ := s.InitialState(); !reflect.DeepEqual(gcs, *cs) {
+		t.Errorf("conf state = %v, want %v", gcs, *cs)
+	}
+
+	// Apply a snapshot that is older than the current one.
+	if err := s.ApplySnapshot(tests[1]); err != ErrSnapOutOfDate {
+		t.Errorf("err = %v, want %v", err, ErrSnapOutOfDate)
+	}
+}
+
+// TestStorageConcurrent2C ensures the storage can be appended to and
+// compacted by different goroutines, run it with -race.
+func TestStorageConcurrent2C(t *testing.T) {
+	s := NewMemoryStorage()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := uint64(1); i <= 1000; i++ {
+			if err := s.Append([]pb.Entry{{Index: i, Term: 1}}); err != nil {
+				t.Error(err)
+				return
+			}
+			s.SetHardState(pb.HardState{Term: 1, Commit: i})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := uint64(1); i <= 1000; {
+			hs, _, _ := s.InitialState()
+			if hs.Commit < i {
+				continue
+			}
+			if _, err := s.CreateSnapshot(i, nil, nil); err != nil {
+				t.Error(err)
+				return
+			}
+			if err := s.Compact(i); err != nil {
+				t.Error(err)
+				return
+			}
+			if _, err := s.Entries(i+1, hs.Commit+1); err != nil {
+				t.Error(err)
+				return
+			}
+			i++
+		}
+	}()
+	wg.Wait()
+
+	if first, _ := s.FirstIndex(); first != 1001 {
+		t.Errorf("first index = %d, want 1001", first)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2015 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raft
+
+import pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
+
+// Tracer sees every message a peer receives and sends, and its state
+// transitions, e.g. to record the traces of a group while debugging an
+// anomaly. Its methods are called synchronously by the peer, so they must be
+// fast and must not call back into it.
+type Tracer interface {
+	// OnReceive is called with every message stepped, local messages like
+	// MsgHup and MsgPropose included, before it is handled.
+	OnReceive(m pb.Message)
+	// OnSend is called with every message the peer sends, when it is queued
+	// for the next Ready.
+	OnSend(m pb.Message)
+	// OnStateChange is called when the peer becomes a follower, candidate,
+	// pre-candidate or leader, term being its term in the new state.
+	OnStateChange(from, to StateType, term uint64)
+}
@@ -15,12 +15,14 @@
 package raft
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
-	"sort"
 	"strings"
 
 	pb "github.com/pingcap-incubator/tinykv/proto/pkg/eraftpb"
@@ -60,13 +62,25 @@ func mustTerm(term uint64, err error) uint64 {
 	return term
 }
 
-func nodes(r *Raft) []uint64 {
-	nodes := make([]uint64, 0, len(r.Prs))
-	for id := range r.Prs {
-		nodes = append(nodes, id)
+// limitSize returns the longest prefix of ents whose total size doesn't
+// exceed maxSize, but at least one entry. A maxSize of 0 means no limit.
+func limitSize(ents []pb.Entry, maxSize uint64) []pb.Entry {
+	if maxSize == 0 || len(ents) == 0 {
+		return ents
+	}
+	size := uint64(ents[0].Size())
+	limit := 1
+	for ; limit < len(ents); limit++ {
+		size += uint64(ents[limit].Size())
+		if size > maxSize {
+			break
+		}
 	}
-	sort.Sort(uint64Slice(nodes))
-	return nodes
+	return ents[:limit]
+}
+
+func nodes(r *Raft) []uint64 {
+	return append(make([]uint64, 0, len(r.peerIDs)), r.peerIDs...)
 }
 
 func diffu(a, b string) string {
@@ -104,7 +118,11 @@ func mustTemp(pre, body string) string {
 func ltoa(l *RaftLog) string {
 	s := fmt.Sprintf("committed: %d\n", l.committed)
 	s += fmt.Sprintf("applied:  %d\n", l.applied)
-	for i, e := range l.entries {
+	ents, err := l.allEntries()
+	if err != nil {
+		return s + fmt.Sprintf("error: %v\n", err)
+	}
+	for i, e := range ents {
 		s += fmt.Sprintf("#%d: %+v\n", i, e)
 	}
 	return s
@@ -121,9 +139,34 @@ func IsLocalMsg(msgt pb.MessageType) bool {
 }
 
 func IsResponseMsg(msgt pb.MessageType) bool {
-	return msgt == pb.MessageType_MsgAppendResponse || msgt == pb.MessageType_MsgRequestVoteResponse || msgt == pb.MessageType_MsgHeartbeatResponse
+	return msgt == pb.MessageType_MsgAppendResponse || msgt == pb.MessageType_MsgRequestVoteResponse || msgt == pb.MessageType_MsgHeartbeatResponse || msgt == pb.MessageType_MsgRequestPreVoteResponse
 }
 
 func isHardStateEqual(a, b pb.HardState) bool {
 	return a.Term == b.Term && a.Vote == b.Vote && a.Commit == b.Commit
 }
+
+// ErrCorruptEntry is returned when the checksum of an entry read from Storage
+// or received in a MsgAppend doesn't match its content.
+var ErrCorruptEntry = errors.New("raft: corrupt entry")
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// EntryChecksum returns the checksum of the type, term, index and data of e.
+func EntryChecksum(e *pb.Entry) uint32 {
+	var buf [20]byte
+	binary.BigEndian.PutUint32(buf[0:], uint32(e.EntryType))
+	binary.BigEndian.PutUint64(buf[4:], e.Term)
+	binary.BigEndian.PutUint64(buf[12:], e.Index)
+	crc := crc32.Update(0, crcTable, buf[:])
+	return crc32.Update(crc, crcTable, e.Data)
+}
+
+// CheckEntry returns ErrCorruptEntry if e carries a checksum which doesn't
+// match its content. An entry without a checksum is always accepted.
+func CheckEntry(e *pb.Entry) error {
+	if e.Checksum != 0 && e.Checksum != EntryChecksum(e) {
+		return fmt.Errorf("%w: index %d, term %d", ErrCorruptEntry, e.Index, e.Term)
+	}
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap-incubator/tinykv/scheduler/server/core"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/id"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule"
+	"github.com/pingcap-incubator/tinykv/scheduler/server/schedule/operator"
 	"github.com/pingcap/errcode"
 	"github.com/pingcap/log"
 	"github.com/pkg/errors"
@@ -67,6 +68,10 @@ type RaftCluster struct {
 
 	prepareChecker *prepareChecker
 
+	// restarting maps the stores prepared for a restart, see PrepareRestart,
+	// to the start time they had then.
+	restarting map[uint64]uint32
+
 	coordinator *coordinator
 
 	wg   sync.WaitGroup
@@ -135,6 +140,7 @@ func (c *RaftCluster) initCluster(id id.Allocator, opt *config.ScheduleOption, s
 	c.storage = storage
 	c.id = id
 	c.prepareChecker = newPrepareChecker()
+	c.restarting = make(map[uint64]uint32)
 }
 
 func (c *RaftCluster) start() error {
@@ -273,6 +279,12 @@ func (c *RaftCluster) handleStoreHeartbeat(stats *schedulerpb.StoreStats) error
 	}
 	newStore := store.Clone(core.SetStoreStats(stats), core.SetLastHeartbeatTS(time.Now()))
 	c.core.PutStore(newStore)
+	if startTime, ok := c.restarting[storeID]; ok && stats.GetStartTime() != startTime {
+		// The store is back from its restart.
+		delete(c.restarting, storeID)
+		c.core.UnblockStore(storeID)
+		log.Info("store rejoined after restart", zap.Uint64("store-id", storeID))
+	}
 	return nil
 }
 
@@ -362,6 +374,16 @@ func (c *RaftCluster) ScanRegions(startKey, endKey []byte, limit int) []*core.Re
 	return c.core.ScanRange(startKey, endKey, limit)
 }
 
+// GetTopology returns all the regions with the version of the topology.
+func (c *RaftCluster) GetTopology() (uint64, []*core.RegionInfo) {
+	return c.core.GetTopology()
+}
+
+// GetTopologyDiff returns the regions changed and removed since version.
+func (c *RaftCluster) GetTopologyDiff(version uint64) (uint64, []*core.RegionInfo, []uint64, bool) {
+	return c.core.GetTopologyDiff(version)
+}
+
 // GetRegionByID gets region and leader peer by regionID from cluster.
 func (c *RaftCluster) GetRegionByID(regionID uint64) (*metapb.Region, *metapb.Peer) {
 	region := c.GetRegion(regionID)
@@ -581,6 +603,66 @@ func (c *RaftCluster) UnblockStore(storeID uint64) {
 	c.core.UnblockStore(storeID)
 }
 
+// PrepareRestart prepares the store to be restarted: it is blocked, so the
+// schedulers move neither leaders nor regions to it, and its leaders are
+// transferred to the followers. It returns the number of leaders left on the
+// store, it is safe to stop once none is. The store is unblocked when it
+// heartbeats with a new start time, or when cancel is set.
+func (c *RaftCluster) PrepareRestart(storeID uint64, cancel bool) (int, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	store := c.GetStore(storeID)
+	if store == nil {
+		return 0, core.NewStoreNotFoundErr(storeID)
+	}
+	if cancel {
+		if _, ok := c.restarting[storeID]; ok {
+			delete(c.restarting, storeID)
+			c.core.UnblockStore(storeID)
+		}
+		return c.core.GetStoreLeaderCount(storeID), nil
+	}
+	if !store.IsUp() {
+		return 0, errors.Errorf("store %d is not up", storeID)
+	}
+	if _, ok := c.restarting[storeID]; !ok {
+		if err := c.core.BlockStore(storeID); err != nil {
+			return 0, err
+		}
+		c.restarting[storeID] = store.GetStartTime()
+		log.Info("prepare store for restart", zap.Uint64("store-id", storeID))
+	}
+
+	var ops []*operator.Operator
+	for _, region := range c.core.GetStoreRegions(storeID) {
+		if region.GetLeader().GetStoreId() != storeID {
+			continue
+		}
+		// The leader goes to the follower with the fewest leaders.
+		var target *core.StoreInfo
+		for _, store := range c.core.GetFollowerStores(region) {
+			if !store.IsUp() || store.IsBlocked() || store.IsDisconnected() {
+				continue
+			}
+			if target == nil || store.GetLeaderCount() < target.GetLeaderCount() {
+				target = store
+			}
+		}
+		if target != nil {
+			ops = append(ops, operator.CreateTransferLeaderOperator("prepare-restart", region, storeID, target.GetID(), operator.OpAdmin))
+		}
+	}
+	if c.coordinator != nil {
+		// Added one by one, as a region which already has one is skipped
+		// without failing the others.
+		for _, op := range ops {
+			c.coordinator.opController.AddOperator(op)
+		}
+	}
+	return c.core.GetStoreLeaderCount(storeID), nil
+}
+
 // AttachAvailableFunc attaches an available function to a specific store.
 func (c *RaftCluster) AttachAvailableFunc(storeID uint64, f func() bool) {
 	c.core.AttachAvailableFunc(storeID, f)
@@ -514,6 +514,53 @@ func (s *testCoordinatorSuite) TestRemoveScheduler(c *C) {
 	co.wg.Wait()
 }
 
+func (s *testCoordinatorSuite) TestPrepareRestart(c *C) {
+	_, opt, err := newTestScheduleConfig()
+	c.Assert(err, IsNil)
+	tc := newTestCluster(opt)
+	hbStreams, cleanup := getHeartBeatStreams(s.ctx, c, tc)
+	defer cleanup()
+	defer hbStreams.Close()
+
+	co := newCoordinator(s.ctx, tc.RaftCluster, hbStreams)
+	tc.coordinator = co
+
+	c.Assert(tc.addRegionStore(1, 2), IsNil)
+	c.Assert(tc.addRegionStore(2, 2), IsNil)
+	c.Assert(tc.addRegionStore(3, 2), IsNil)
+	c.Assert(tc.addLeaderRegion(1, 1, 2, 3), IsNil)
+	c.Assert(tc.addLeaderRegion(2, 2, 1, 3), IsNil)
+
+	// The leader on store 1 is moved off it, to the follower with fewer leaders.
+	c.Assert(tc.updateLeaderCount(2, 1), IsNil)
+	leaders, err := tc.PrepareRestart(1, false)
+	c.Assert(err, IsNil)
+	c.Assert(leaders, Equals, 1)
+	c.Assert(tc.GetStore(1).IsBlocked(), IsTrue)
+	testutil.CheckTransferLeader(c, co.opController.GetOperator(1), operator.OpAdmin, 1, 3)
+	c.Assert(co.opController.GetOperator(2), IsNil)
+	// Asking again is fine.
+	_, err = tc.PrepareRestart(1, false)
+	c.Assert(err, IsNil)
+
+	// The store stays blocked until it is back with a new start time.
+	c.Assert(tc.handleStoreHeartbeat(&schedulerpb.StoreStats{StoreId: 1}), IsNil)
+	c.Assert(tc.GetStore(1).IsBlocked(), IsTrue)
+	c.Assert(tc.handleStoreHeartbeat(&schedulerpb.StoreStats{StoreId: 1, StartTime: 1}), IsNil)
+	c.Assert(tc.GetStore(1).IsBlocked(), IsFalse)
+
+	// A cancel unblocks it too.
+	_, err = tc.PrepareRestart(2, false)
+	c.Assert(err, IsNil)
+	c.Assert(tc.GetStore(2).IsBlocked(), IsTrue)
+	_, err = tc.PrepareRestart(2, true)
+	c.Assert(err, IsNil)
+	c.Assert(tc.GetStore(2).IsBlocked(), IsFalse)
+
+	_, err = tc.PrepareRestart(4, false)
+	c.Assert(err, NotNil)
+}
+
 func (s *testCoordinatorSuite) TestRestart(c *C) {
 	// Turn off balance, we test add replica only.
 	cfg, opt, err := newTestScheduleConfig()
@@ -22,15 +22,17 @@ import (
 // BasicCluster provides basic data member and interface for a tikv cluster.
 type BasicCluster struct {
 	sync.RWMutex
-	Stores  *StoresInfo
-	Regions *RegionsInfo
+	Stores   *StoresInfo
+	Regions  *RegionsInfo
+	topology *topologyLog
 }
 
 // NewBasicCluster creates a BasicCluster.
 func NewBasicCluster() *BasicCluster {
 	return &BasicCluster{
-		Stores:  NewStoresInfo(),
-		Regions: NewRegionsInfo(),
+		Stores:   NewStoresInfo(),
+		Regions:  NewRegionsInfo(),
+		topology: newTopologyLog(topologyLogCapacity),
 	}
 }
 
@@ -274,7 +276,15 @@ func (bc *BasicCluster) TakeStore(storeID uint64) *StoreInfo {
 func (bc *BasicCluster) PutRegion(region *RegionInfo) []*RegionInfo {
 	bc.Lock()
 	defer bc.Unlock()
-	return bc.Regions.SetRegion(region)
+	origin := bc.Regions.GetRegion(region.GetID())
+	overlaps := bc.Regions.SetRegion(region)
+	for _, item := range overlaps {
+		bc.topology.record(item.GetID())
+	}
+	if topologyChanged(origin, region) {
+		bc.topology.record(region.GetID())
+	}
+	return overlaps
 }
 
 // RemoveRegion removes RegionInfo from regionTree and regionMap.
@@ -282,6 +292,35 @@ func (bc *BasicCluster) RemoveRegion(region *RegionInfo) {
 	bc.Lock()
 	defer bc.Unlock()
 	bc.Regions.RemoveRegion(region)
+	bc.topology.record(region.GetID())
+}
+
+// GetTopology returns all the regions and the version of the topology they
+// form, to pass to GetTopologyDiff.
+func (bc *BasicCluster) GetTopology() (uint64, []*RegionInfo) {
+	bc.RLock()
+	defer bc.RUnlock()
+	return bc.topology.version, bc.Regions.GetRegions()
+}
+
+// GetTopologyDiff returns the regions changed since version and the ones
+// removed, with the current version. ok is false if the changes since version
+// are no longer known, GetTopology must be called again then.
+func (bc *BasicCluster) GetTopologyDiff(version uint64) (current uint64, changed []*RegionInfo, removed []uint64, ok bool) {
+	bc.RLock()
+	defer bc.RUnlock()
+	ids, ok := bc.topology.since(version)
+	if !ok {
+		return bc.topology.version, nil, nil, false
+	}
+	for _, id := range ids {
+		if region := bc.Regions.GetRegion(id); region != nil {
+			changed = append(changed, region)
+		} else {
+			removed = append(removed, id)
+		}
+	}
+	return bc.topology.version, changed, removed, true
 }
 
 // SearchRegion searches RegionInfo from regionTree.
@@ -14,11 +14,13 @@
 package core
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
@@ -209,6 +211,59 @@ func (s *Storage) LoadGCSafePoint() (uint64, error) {
 	return safePoint, nil
 }
 
+// ServiceSafePoint is the oldest ts a service still reads at, the GC safe
+// point must not pass it before it expires.
+type ServiceSafePoint struct {
+	ServiceID string `json:"service_id"`
+	ExpiredAt int64  `json:"expired_at"`
+	SafePoint uint64 `json:"safe_point"`
+}
+
+func serviceSafePointPath(serviceID string) string {
+	return path.Join(gcPath, "safe_point", "service", serviceID)
+}
+
+// SaveServiceGCSafePoint saves a service safe point to storage.
+func (s *Storage) SaveServiceGCSafePoint(ssp *ServiceSafePoint) error {
+	value, err := json.Marshal(ssp)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return s.Save(serviceSafePointPath(ssp.ServiceID), string(value))
+}
+
+// RemoveServiceGCSafePoint removes a service safe point from storage.
+func (s *Storage) RemoveServiceGCSafePoint(serviceID string) error {
+	return s.Remove(serviceSafePointPath(serviceID))
+}
+
+// LoadMinServiceGCSafePoint returns the oldest service safe point not expired
+// at now, or nil if there is none. The expired ones are removed.
+func (s *Storage) LoadMinServiceGCSafePoint(now time.Time) (*ServiceSafePoint, error) {
+	prefix := path.Join(gcPath, "safe_point", "service") + "/"
+	_, values, err := s.LoadRange(prefix, clientv3.GetPrefixRangeEnd(prefix), maxKVRangeLimit)
+	if err != nil {
+		return nil, err
+	}
+	var min *ServiceSafePoint
+	for _, value := range values {
+		ssp := &ServiceSafePoint{}
+		if err := json.Unmarshal([]byte(value), ssp); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if ssp.ExpiredAt < now.Unix() {
+			if err := s.RemoveServiceGCSafePoint(ssp.ServiceID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if min == nil || ssp.SafePoint < min.SafePoint {
+			min = ssp
+		}
+	}
+	return min, nil
+}
+
 // LoadAllScheduleConfig loads all schedulers' config.
 func (s *Storage) LoadAllScheduleConfig() ([]string, []string, error) {
 	keys, values, err := s.LoadRange(customScheduleConfigPath, clientv3.GetPrefixRangeEnd(customScheduleConfigPath), 1000)
@@ -15,6 +15,7 @@ package core
 
 import (
 	"math"
+	"time"
 
 	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
 	"github.com/pingcap-incubator/tinykv/scheduler/server/kv"
@@ -115,3 +116,44 @@ func (s *testKVSuite) TestLoadGCSafePoint(c *C) {
 		c.Assert(safePoint, Equals, safePoint1)
 	}
 }
+
+func (s *testKVSuite) TestLoadMinServiceGCSafePoint(c *C) {
+	storage := NewStorage(kv.NewMemoryKV())
+	now := time.Now()
+
+	min, err := storage.LoadMinServiceGCSafePoint(now)
+	c.Assert(err, IsNil)
+	c.Assert(min, IsNil)
+
+	c.Assert(storage.SaveGCSafePoint(100), IsNil)
+	ssps := []*ServiceSafePoint{
+		{ServiceID: "backup", ExpiredAt: now.Unix() + 10, SafePoint: 300},
+		{ServiceID: "cdc", ExpiredAt: now.Unix() + 20, SafePoint: 200},
+		{ServiceID: "scan", ExpiredAt: now.Unix() - 1, SafePoint: 150},
+	}
+	for _, ssp := range ssps {
+		c.Assert(storage.SaveServiceGCSafePoint(ssp), IsNil)
+	}
+
+	// The expired one is skipped and removed.
+	min, err = storage.LoadMinServiceGCSafePoint(now)
+	c.Assert(err, IsNil)
+	c.Assert(min, DeepEquals, ssps[1])
+	v, err := storage.Load(serviceSafePointPath("scan"))
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, "")
+
+	// The GC safe point isn't mistaken for a service safe point.
+	safePoint, err := storage.LoadGCSafePoint()
+	c.Assert(err, IsNil)
+	c.Assert(safePoint, Equals, uint64(100))
+
+	c.Assert(storage.RemoveServiceGCSafePoint("cdc"), IsNil)
+	min, err = storage.LoadMinServiceGCSafePoint(now)
+	c.Assert(err, IsNil)
+	c.Assert(min, DeepEquals, ssps[0])
+
+	min, err = storage.LoadMinServiceGCSafePoint(now.Add(15 * time.Second))
+	c.Assert(err, IsNil)
+	c.Assert(min, IsNil)
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"time"
+)
+
+// topologyLogCapacity is the number of region changes kept to answer diffs,
+// older diffs require to read the whole topology again.
+const topologyLogCapacity = 64 * 1024
+
+// topologyLog versions the region descriptors, so a client can ask for the
+// regions changed since the version it read last instead of all of them.
+// Versions are not persisted: they start from the time the log is created,
+// so the versions handed out before a restart or by another leader are older
+// than the log and are answered as stale.
+type topologyLog struct {
+	version uint64
+	// floor is the version before the oldest change logged.
+	floor uint64
+	// changes are the regions changed, in the order of their versions
+	// floor+1, floor+2, ...
+	changes  []uint64
+	capacity int
+}
+
+func newTopologyLog(capacity int) *topologyLog {
+	start := uint64(time.Now().UnixNano())
+	return &topologyLog{version: start, floor: start, capacity: capacity}
+}
+
+// record logs a change of the region regionID.
+func (l *topologyLog) record(regionID uint64) {
+	if len(l.changes) >= l.capacity {
+		drop := len(l.changes) / 2
+		l.changes = append(l.changes[:0], l.changes[drop:]...)
+		l.floor += uint64(drop)
+	}
+	l.changes = append(l.changes, regionID)
+	l.version++
+}
+
+// since returns the regions changed after version, or false if the changes
+// are not known.
+func (l *topologyLog) since(version uint64) ([]uint64, bool) {
+	if version < l.floor || version > l.version {
+		return nil, false
+	}
+	seen := make(map[uint64]struct{})
+	var ids []uint64
+	for _, id := range l.changes[version-l.floor:] {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	return ids, true
+}
+
+// topologyChanged returns whether the descriptor or the leader of a region
+// differs between origin and region.
+func topologyChanged(origin, region *RegionInfo) bool {
+	if origin == nil {
+		return true
+	}
+	if origin.GetLeader().GetId() != region.GetLeader().GetId() {
+		return true
+	}
+	o, r := origin.GetRegionEpoch(), region.GetRegionEpoch()
+	return o.GetVersion() != r.GetVersion() || o.GetConfVer() != r.GetConfVer()
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap-incubator/tinykv/proto/pkg/metapb"
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testTopologySuite{})
+
+type testTopologySuite struct{}
+
+func (s *testTopologySuite) region(id uint64, start, end string, version uint64, leader uint64) *RegionInfo {
+	peers := []*metapb.Peer{{Id: id*10 + 1, StoreId: 1}, {Id: id*10 + 2, StoreId: 2}}
+	meta := &metapb.Region{
+		Id:          id,
+		StartKey:    []byte(start),
+		EndKey:      []byte(end),
+		RegionEpoch: &metapb.RegionEpoch{Version: version, ConfVer: 1},
+		Peers:       peers,
+	}
+	return NewRegionInfo(meta, peers[leader-1])
+}
+
+func (s *testTopologySuite) TestTopologyDiff(c *C) {
+	bc := NewBasicCluster()
+	bc.PutRegion(s.region(1, "a", "b", 1, 1))
+	bc.PutRegion(s.region(2, "b", "c", 1, 1))
+	v0, regions := bc.GetTopology()
+	c.Assert(regions, HasLen, 2)
+
+	// A heartbeat without change is not a new version.
+	bc.PutRegion(s.region(1, "a", "b", 1, 1))
+	v, changed, removed, ok := bc.GetTopologyDiff(v0)
+	c.Assert(ok, IsTrue)
+	c.Assert(v, Equals, v0)
+	c.Assert(changed, HasLen, 0)
+	c.Assert(removed, HasLen, 0)
+
+	// The leader of region 2 moves, then region 1 merges region 2.
+	bc.PutRegion(s.region(2, "b", "c", 1, 2))
+	v1, changed, _, _ := bc.GetTopologyDiff(v0)
+	c.Assert(changed, HasLen, 1)
+	c.Assert(changed[0].GetLeader().GetStoreId(), Equals, uint64(2))
+	bc.PutRegion(s.region(1, "a", "c", 2, 1))
+
+	v, changed, removed, ok = bc.GetTopologyDiff(v0)
+	c.Assert(ok, IsTrue)
+	c.Assert(v > v1, IsTrue)
+	c.Assert(changed, HasLen, 1)
+	c.Assert(changed[0].GetID(), Equals, uint64(1))
+	c.Assert(removed, DeepEquals, []uint64{2})
+
+	_, changed, removed, ok = bc.GetTopologyDiff(v)
+	c.Assert(ok, IsTrue)
+	c.Assert(changed, HasLen, 0)
+	c.Assert(removed, HasLen, 0)
+
+	// Versions the cluster didn't hand out are stale.
+	_, _, _, ok = bc.GetTopologyDiff(v + 1)
+	c.Assert(ok, IsFalse)
+	_, _, _, ok = bc.GetTopologyDiff(0)
+	c.Assert(ok, IsFalse)
+}
+
+func (s *testTopologySuite) TestTopologyLogCapacity(c *C) {
+	l := newTopologyLog(4)
+	v0 := l.version
+	for id := uint64(1); id <= 5; id++ {
+		l.record(id)
+	}
+	_, ok := l.since(v0)
+	c.Assert(ok, IsFalse)
+	ids, ok := l.since(v0 + 2)
+	c.Assert(ok, IsTrue)
+	c.Assert(ids, DeepEquals, []uint64{3, 4, 5})
+}
@@ -463,6 +463,74 @@ func (s *Server) ScanRegions(ctx context.Context, request *schedulerpb.ScanRegio
 	return resp, nil
 }
 
+// GetRegionTopology implements gRPC PDServer.
+func (s *Server) GetRegionTopology(ctx context.Context, request *schedulerpb.GetRegionTopologyRequest) (*schedulerpb.GetRegionTopologyResponse, error) {
+	if err := s.validateRequest(request.GetHeader()); err != nil {
+		return nil, err
+	}
+
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &schedulerpb.GetRegionTopologyResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+	version, regions := cluster.GetTopology()
+	resp := &schedulerpb.GetRegionTopologyResponse{Header: s.header(), Version: version}
+	resp.Regions, resp.Leaders = regionsWithLeaders(regions)
+	return resp, nil
+}
+
+// GetRegionTopologyDiff implements gRPC PDServer.
+func (s *Server) GetRegionTopologyDiff(ctx context.Context, request *schedulerpb.GetRegionTopologyDiffRequest) (*schedulerpb.GetRegionTopologyDiffResponse, error) {
+	if err := s.validateRequest(request.GetHeader()); err != nil {
+		return nil, err
+	}
+
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &schedulerpb.GetRegionTopologyDiffResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+	version, changed, removed, ok := cluster.GetTopologyDiff(request.GetSinceVersion())
+	resp := &schedulerpb.GetRegionTopologyDiffResponse{Header: s.header(), Version: version, Stale: !ok}
+	resp.Regions, resp.Leaders = regionsWithLeaders(changed)
+	resp.RemovedRegionIds = removed
+	return resp, nil
+}
+
+// PrepareRestart implements gRPC PDServer.
+func (s *Server) PrepareRestart(ctx context.Context, request *schedulerpb.PrepareRestartRequest) (*schedulerpb.PrepareRestartResponse, error) {
+	if err := s.validateRequest(request.GetHeader()); err != nil {
+		return nil, err
+	}
+
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &schedulerpb.PrepareRestartResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+	leaderCount, err := cluster.PrepareRestart(request.GetStoreId(), request.GetCancel())
+	if err != nil {
+		return nil, status.Errorf(codes.Unknown, err.Error())
+	}
+	return &schedulerpb.PrepareRestartResponse{
+		Header:      s.header(),
+		LeaderCount: uint64(leaderCount),
+		Ready:       !request.GetCancel() && leaderCount == 0,
+	}, nil
+}
+
+func regionsWithLeaders(regions []*core.RegionInfo) ([]*metapb.Region, []*metapb.Peer) {
+	metas := make([]*metapb.Region, 0, len(regions))
+	leaders := make([]*metapb.Peer, 0, len(regions))
+	for _, r := range regions {
+		leader := r.GetLeader()
+		if leader == nil {
+			leader = &metapb.Peer{}
+		}
+		metas = append(metas, r.GetMeta())
+		leaders = append(leaders, leader)
+	}
+	return metas, leaders
+}
+
 // AskSplit implements gRPC PDServer.
 func (s *Server) AskSplit(ctx context.Context, request *schedulerpb.AskSplitRequest) (*schedulerpb.AskSplitResponse, error) {
 	if err := s.validateRequest(request.GetHeader()); err != nil {
@@ -619,6 +687,9 @@ func (s *Server) UpdateGCSafePoint(ctx context.Context, request *schedulerpb.Upd
 		return &schedulerpb.UpdateGCSafePointResponse{Header: s.notBootstrappedHeader()}, nil
 	}
 
+	s.gcSafePointLock.Lock()
+	defer s.gcSafePointLock.Unlock()
+
 	oldSafePoint, err := s.storage.LoadGCSafePoint()
 	if err != nil {
 		return nil, err
@@ -626,6 +697,19 @@ func (s *Server) UpdateGCSafePoint(ctx context.Context, request *schedulerpb.Upd
 
 	newSafePoint := request.SafePoint
 
+	// The GC safe point must not pass the data some service still reads.
+	min, err := s.storage.LoadMinServiceGCSafePoint(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if min != nil && newSafePoint > min.SafePoint {
+		log.Info("gc safe point is held back by service safe point",
+			zap.String("service-id", min.ServiceID),
+			zap.Uint64("service-safe-point", min.SafePoint),
+			zap.Uint64("request-safe-point", newSafePoint))
+		newSafePoint = min.SafePoint
+	}
+
 	// Only save the safe point if it's greater than the previous one
 	if newSafePoint > oldSafePoint {
 		if err := s.storage.SaveGCSafePoint(newSafePoint); err != nil {
@@ -646,6 +730,72 @@ func (s *Server) UpdateGCSafePoint(ctx context.Context, request *schedulerpb.Upd
 	}, nil
 }
 
+// UpdateServiceGCSafePoint implements gRPC PDServer.
+func (s *Server) UpdateServiceGCSafePoint(ctx context.Context, request *schedulerpb.UpdateServiceGCSafePointRequest) (*schedulerpb.UpdateServiceGCSafePointResponse, error) {
+	if err := s.validateRequest(request.GetHeader()); err != nil {
+		return nil, err
+	}
+
+	cluster := s.GetRaftCluster()
+	if cluster == nil {
+		return &schedulerpb.UpdateServiceGCSafePointResponse{Header: s.notBootstrappedHeader()}, nil
+	}
+
+	s.gcSafePointLock.Lock()
+	defer s.gcSafePointLock.Unlock()
+
+	serviceID := string(request.ServiceId)
+	if request.Ttl <= 0 {
+		if err := s.storage.RemoveServiceGCSafePoint(serviceID); err != nil {
+			return nil, err
+		}
+		log.Info("removed service safe point", zap.String("service-id", serviceID))
+	}
+
+	gcSafePoint, err := s.storage.LoadGCSafePoint()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	// The data below the GC safe point may be collected already, so a service
+	// safe point below it can't be honored.
+	if request.Ttl > 0 && request.SafePoint >= gcSafePoint {
+		ssp := &core.ServiceSafePoint{
+			ServiceID: serviceID,
+			ExpiredAt: now.Unix() + request.Ttl,
+			SafePoint: request.SafePoint,
+		}
+		if err := s.storage.SaveServiceGCSafePoint(ssp); err != nil {
+			return nil, err
+		}
+		log.Info("updated service safe point",
+			zap.String("service-id", serviceID),
+			zap.Int64("expire-at", ssp.ExpiredAt),
+			zap.Uint64("safe-point", ssp.SafePoint))
+	} else if request.Ttl > 0 {
+		log.Warn("trying to set service safe point below gc safe point",
+			zap.String("service-id", serviceID),
+			zap.Uint64("gc-safe-point", gcSafePoint),
+			zap.Uint64("service-safe-point", request.SafePoint))
+	}
+
+	minSafePoint := gcSafePoint
+	min, err := s.storage.LoadMinServiceGCSafePoint(now)
+	if err != nil {
+		return nil, err
+	}
+	if min != nil && min.SafePoint < minSafePoint {
+		minSafePoint = min.SafePoint
+	}
+
+	return &schedulerpb.UpdateServiceGCSafePointResponse{
+		Header:       s.header(),
+		ServiceId:    request.ServiceId,
+		Ttl:          request.Ttl,
+		MinSafePoint: minSafePoint,
+	}, nil
+}
+
 // GetOperator gets information about the operator belonging to the speicfy region.
 func (s *Server) GetOperator(ctx context.Context, request *schedulerpb.GetOperatorRequest) (*schedulerpb.GetOperatorResponse, error) {
 	if err := s.validateRequest(request.GetHeader()); err != nil {
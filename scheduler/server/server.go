@@ -117,6 +117,8 @@ type Server struct {
 	tso *tso.TimestampOracle
 	// for raft cluster
 	cluster *RaftCluster
+	// serializes the updates of the GC safe point and the service safe points.
+	gcSafePointLock sync.Mutex
 	// For async region heartbeat.
 	hbStreams *heartbeatStreams
 	// Zap logger